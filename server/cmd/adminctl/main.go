@@ -0,0 +1,573 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"golang.org/x/crypto/bcrypt"
+	_ "modernc.org/sqlite"
+)
+
+var configPath = flag.String("config", ".env", "Path to the config file")
+
+const usage = `Usage: adminctl [-config .env] <command> [flags]
+
+Works directly on the database in DATA_PATH, so it is safest to run while the server is stopped.
+
+Commands:
+  create-account  Create a new account and its player
+  reset-password  Set a new password for an account
+  ban             Ban an account, optionally for a limited time
+  unban           Lift all bans on an account
+  grant-items     Give a player items, mailing them whatever they can't carry
+  export          Write all accounts, players, inventories and bans as JSON
+  import          Read accounts previously written by export
+  validate-data   Check the game data for mistakes, as the server does when it starts
+  release-data    Write a manifest of the game data as it is now, as its next version
+`
+
+// The JSON format used by export and import
+type accountExport struct {
+	Username     string        `json:"username"`
+	PasswordHash string        `json:"password_hash"`
+	Player       *playerExport `json:"player,omitempty"`
+	Bans         []banExport   `json:"bans,omitempty"`
+}
+
+type playerExport struct {
+	Name      string           `json:"name"`
+	BestScore int64            `json:"best_score"`
+	Color     int64            `json:"color"`
+	Items     map[string]int64 `json:"items,omitempty"`
+}
+
+type banExport struct {
+	Reason    string `json:"reason"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+}
+
+type admin struct {
+	dataPath string
+	dbPool   *sql.DB
+	queries  *db.Queries
+	ctx      context.Context
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(*configPath); err != nil {
+		log.Printf("Error loading config file, using environment only: %v", err)
+	}
+
+	dataPath := os.Getenv("DATA_PATH")
+	if dataPath == "" {
+		dataPath = "."
+	}
+
+	dbPool, err := sql.Open("sqlite", path.Join(dataPath, "db.sqlite"))
+	if err != nil {
+		log.Fatalf("Error opening database: %v", err)
+	}
+	defer dbPool.Close()
+
+	a := &admin{dataPath: dataPath, dbPool: dbPool, queries: db.New(dbPool), ctx: context.Background()}
+	if _, err := dbPool.ExecContext(a.ctx, db.SchemaSql); err != nil {
+		log.Fatalf("Error initializing database: %v", err)
+	}
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	switch command {
+	case "create-account":
+		err = a.createAccount(args)
+	case "reset-password":
+		err = a.resetPassword(args)
+	case "ban":
+		err = a.ban(args)
+	case "unban":
+		err = a.unban(args)
+	case "grant-items":
+		err = a.grantItems(args)
+	case "export":
+		err = a.exportAccounts(args)
+	case "import":
+		err = a.importAccounts(args)
+	case "validate-data":
+		err = a.validateData(args)
+	case "release-data":
+		err = a.releaseData(args)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("%s: %v", command, err)
+	}
+}
+
+func (a *admin) createAccount(args []string) error {
+	fs := flag.NewFlagSet("create-account", flag.ExitOnError)
+	username := fs.String("username", "", "Username to log in with, also used as the player name")
+	password := fs.String("password", "", "Password to log in with")
+	color := fs.Int64("color", 0xffffff, "Player color as an RGB integer")
+	force := fs.Bool("force", false, "Allow names that are profane or reserved, e.g. for staff accounts")
+	fs.Parse(args)
+
+	if *username == "" || *password == "" {
+		return errors.New("-username and -password are required")
+	}
+
+	// The same rules as players registering are held to
+	if err := server.ValidateUsername(*username); err != nil {
+		return fmt.Errorf("invalid username: %w", err)
+	}
+	if !*force {
+		gameData, err := gamedata.Load(a.dataPath)
+		if err != nil {
+			return fmt.Errorf("failed to load game data: %w", err)
+		}
+		if gameData.Profane(*username) {
+			return fmt.Errorf("username %s isn't allowed, use -force to create it anyway", *username)
+		}
+
+		reserved, err := a.queries.GetReservedNameMatch(a.ctx, strings.ToLower(*username))
+		if err == nil {
+			return fmt.Errorf("username %s matches reserved %s %s, use -force to create it anyway", *username, reserved.Kind, reserved.Name)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to check reserved names: %w", err)
+		}
+	}
+
+	if _, err := a.queries.GetUserByUsername(a.ctx, strings.ToLower(*username)); err == nil {
+		return fmt.Errorf("user %s already exists", *username)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return a.inTx(func(queries *db.Queries) error {
+		user, err := queries.CreateUser(a.ctx, db.CreateUserParams{
+			Username:     strings.ToLower(*username),
+			PasswordHash: string(passwordHash),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		if _, err := queries.CreatePlayer(a.ctx, db.CreatePlayerParams{
+			UserID: user.ID,
+			Name:   *username,
+			Color:  *color,
+		}); err != nil {
+			return fmt.Errorf("failed to create player: %w", err)
+		}
+
+		log.Printf("Created account %s (user ID %d)", *username, user.ID)
+		return nil
+	})
+}
+
+func (a *admin) resetPassword(args []string) error {
+	fs := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	username := fs.String("username", "", "Username of the account")
+	password := fs.String("password", "", "New password")
+	fs.Parse(args)
+
+	if *username == "" || *password == "" {
+		return errors.New("-username and -password are required")
+	}
+
+	user, err := a.getUser(*username)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := a.queries.UpdateUserPasswordHash(a.ctx, db.UpdateUserPasswordHashParams{
+		PasswordHash: string(passwordHash),
+		ID:           user.ID,
+	}); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	log.Printf("Reset password for %s", user.Username)
+	return nil
+}
+
+func (a *admin) ban(args []string) error {
+	fs := flag.NewFlagSet("ban", flag.ExitOnError)
+	username := fs.String("username", "", "Username of the account")
+	reason := fs.String("reason", "", "Reason shown to the player when they try to log in")
+	duration := fs.Duration("duration", 0, "How long the ban lasts (e.g. 72h), or 0 for a permanent ban")
+	fs.Parse(args)
+
+	if *username == "" || *reason == "" {
+		return errors.New("-username and -reason are required")
+	}
+
+	user, err := a.getUser(*username)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := sql.NullInt64{}
+	if *duration > 0 {
+		expiresAt = sql.NullInt64{Int64: now.Add(*duration).Unix(), Valid: true}
+	}
+
+	if _, err := a.queries.CreateBan(a.ctx, db.CreateBanParams{
+		UserID:    user.ID,
+		Reason:    *reason,
+		CreatedAt: now.Unix(),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return fmt.Errorf("failed to create ban: %w", err)
+	}
+
+	log.Printf("Banned %s", user.Username)
+	return nil
+}
+
+func (a *admin) unban(args []string) error {
+	fs := flag.NewFlagSet("unban", flag.ExitOnError)
+	username := fs.String("username", "", "Username of the account")
+	fs.Parse(args)
+
+	if *username == "" {
+		return errors.New("-username is required")
+	}
+
+	user, err := a.getUser(*username)
+	if err != nil {
+		return err
+	}
+
+	if err := a.queries.DeleteBansByUserId(a.ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete bans: %w", err)
+	}
+
+	log.Printf("Unbanned %s", user.Username)
+	return nil
+}
+
+// Held to the same carry limits as everything else the server grants, from the same config
+func (a *admin) grantItems(args []string) error {
+	fs := flag.NewFlagSet("grant-items", flag.ExitOnError)
+	playerName := fs.String("player", "", "Name of the player")
+	itemId := fs.String("item", "", "ID of the item, as in items.json")
+	quantity := fs.Int64("quantity", 1, "How many of the item to give")
+	fs.Parse(args)
+
+	if *playerName == "" || *itemId == "" {
+		return errors.New("-player and -item are required")
+	}
+	if *quantity <= 0 {
+		return errors.New("-quantity must be positive")
+	}
+
+	gameData, err := gamedata.Load(a.dataPath)
+	if err != nil {
+		return fmt.Errorf("failed to load game data: %w", err)
+	}
+	if _, exists := gameData.Items[*itemId]; !exists && *itemId != gamedata.CurrencyItemId {
+		return fmt.Errorf("no item %s in the game data", *itemId)
+	}
+
+	limits, err := carryLimits()
+	if err != nil {
+		return err
+	}
+
+	player, err := a.queries.GetPlayerByName(a.ctx, *playerName)
+	if err != nil {
+		return fmt.Errorf("failed to get player %s: %w", *playerName, err)
+	}
+
+	return a.inTx(func(queries *db.Queries) error {
+		overflow, err := server.GrantItems(a.ctx, queries, gameData, limits, player.ID, map[string]int64{*itemId: *quantity})
+		if err != nil {
+			return fmt.Errorf("failed to grant items: %w", err)
+		}
+
+		log.Printf("Granted %d %s to %s", *quantity-overflow[*itemId], *itemId, player.Name)
+		if overflow[*itemId] > 0 {
+			log.Printf("Mailed %d %s to %s that they couldn't carry", overflow[*itemId], *itemId, player.Name)
+		}
+		return nil
+	})
+}
+
+// The carry limits the server's configured with, so what's granted here fits the same way
+func carryLimits() (server.CarryLimits, error) {
+	var limits server.CarryLimits
+	if slots := os.Getenv("INVENTORY_SLOTS"); slots != "" {
+		inventorySlots, err := strconv.ParseInt(slots, 10, 64)
+		if err != nil || inventorySlots < 0 {
+			return limits, errors.New("INVENTORY_SLOTS must be a whole number, 0 or more")
+		}
+		limits.Slots = inventorySlots
+	}
+	if weight := os.Getenv("INVENTORY_MAX_WEIGHT"); weight != "" {
+		maxWeight, err := strconv.ParseFloat(weight, 64)
+		if err != nil || maxWeight < 0 {
+			return limits, errors.New("INVENTORY_MAX_WEIGHT must be a number, 0 or more")
+		}
+		limits.MaxWeight = maxWeight
+	}
+	return limits, nil
+}
+
+func (a *admin) exportAccounts(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	outPath := fs.String("out", "", "File to write to (default stdout)")
+	fs.Parse(args)
+
+	users, err := a.queries.GetUsers(a.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+
+	accounts := make([]accountExport, 0, len(users))
+	for _, user := range users {
+		account := accountExport{Username: user.Username, PasswordHash: user.PasswordHash}
+
+		player, err := a.queries.GetPlayerByUserId(a.ctx, user.ID)
+		if err == nil {
+			account.Player = &playerExport{Name: player.Name, BestScore: player.BestScore, Color: player.Color}
+			items, err := a.queries.GetInventoryItems(a.ctx, player.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get inventory for %s: %w", user.Username, err)
+			}
+			for _, item := range items {
+				if account.Player.Items == nil {
+					account.Player.Items = make(map[string]int64, len(items))
+				}
+				account.Player.Items[item.ItemID] = item.Quantity
+			}
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get player for %s: %w", user.Username, err)
+		}
+
+		bans, err := a.queries.GetBansByUserId(a.ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get bans for %s: %w", user.Username, err)
+		}
+		for _, ban := range bans {
+			b := banExport{Reason: ban.Reason, CreatedAt: ban.CreatedAt}
+			if ban.ExpiresAt.Valid {
+				b.ExpiresAt = &ban.ExpiresAt.Int64
+			}
+			account.Bans = append(account.Bans, b)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(accounts); err != nil {
+		return err
+	}
+
+	log.Printf("Exported %d accounts", len(accounts))
+	return nil
+}
+
+func (a *admin) importAccounts(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	inPath := fs.String("in", "", "File to read from (default stdin)")
+	fs.Parse(args)
+
+	var in io.Reader = os.Stdin
+	if *inPath != "" {
+		file, err := os.Open(*inPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var accounts []accountExport
+	if err := json.NewDecoder(in).Decode(&accounts); err != nil {
+		return fmt.Errorf("failed to decode accounts: %w", err)
+	}
+
+	// Either everything is imported or nothing is, so a bad file can simply be fixed and imported again
+	imported := 0
+	err := a.inTx(func(queries *db.Queries) error {
+		for _, account := range accounts {
+			username := strings.ToLower(account.Username)
+			if _, err := queries.GetUserByUsername(a.ctx, username); err == nil {
+				log.Printf("User %s already exists, skipping", username)
+				continue
+			}
+
+			user, err := queries.CreateUser(a.ctx, db.CreateUserParams{
+				Username:     username,
+				PasswordHash: account.PasswordHash,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create user %s: %w", username, err)
+			}
+
+			if account.Player != nil {
+				player, err := queries.CreatePlayer(a.ctx, db.CreatePlayerParams{
+					UserID: user.ID,
+					Name:   account.Player.Name,
+					Color:  account.Player.Color,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create player for %s: %w", username, err)
+				}
+
+				if err := queries.UpdatePlayerBestScore(a.ctx, db.UpdatePlayerBestScoreParams{
+					ID:        player.ID,
+					BestScore: account.Player.BestScore,
+				}); err != nil {
+					return fmt.Errorf("failed to set best score for %s: %w", username, err)
+				}
+
+				// Restored as it was, whatever the carry limits are now
+				for itemId, quantity := range account.Player.Items {
+					if err := queries.AddInventoryItem(a.ctx, db.AddInventoryItemParams{
+						PlayerID: player.ID,
+						ItemID:   itemId,
+						Quantity: quantity,
+					}); err != nil {
+						return fmt.Errorf("failed to add %s to the inventory of %s: %w", itemId, username, err)
+					}
+				}
+			}
+
+			for _, ban := range account.Bans {
+				expiresAt := sql.NullInt64{}
+				if ban.ExpiresAt != nil {
+					expiresAt = sql.NullInt64{Int64: *ban.ExpiresAt, Valid: true}
+				}
+				if _, err := queries.CreateBan(a.ctx, db.CreateBanParams{
+					UserID:    user.ID,
+					Reason:    ban.Reason,
+					CreatedAt: ban.CreatedAt,
+					ExpiresAt: expiresAt,
+				}); err != nil {
+					return fmt.Errorf("failed to create ban for %s: %w", username, err)
+				}
+			}
+
+			imported++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Imported %d of %d accounts", imported, len(accounts))
+	return nil
+}
+
+func (a *admin) getUser(username string) (db.User, error) {
+	user, err := a.queries.GetUserByUsername(a.ctx, strings.ToLower(username))
+	if err != nil {
+		return user, fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+	return user, nil
+}
+
+func (a *admin) inTx(fn func(queries *db.Queries) error) error {
+	tx, err := a.dbPool.BeginTx(a.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(a.queries.WithTx(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (a *admin) validateData(args []string) error {
+	fs := flag.NewFlagSet("validate-data", flag.ExitOnError)
+	fs.Parse(args)
+
+	if err := gamedata.Validate(a.dataPath); err != nil {
+		return err
+	}
+	log.Println("Game data is valid")
+	return nil
+}
+
+// Clients with a different version of the game data than the server are told to update before they can log in, so
+// this should be run whenever the game data changes
+func (a *admin) releaseData(args []string) error {
+	current, err := gamedata.ManifestVersion(a.dataPath)
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("release-data", flag.ExitOnError)
+	version := fs.Int64("version", current+1, "Version to release the game data as, which must be higher than the last")
+	fs.Parse(args)
+
+	if *version <= current {
+		return fmt.Errorf("version must be higher than the current version %d", current)
+	}
+
+	// Otherwise clients would be told to update to data the server refuses to start with
+	if err := gamedata.Validate(a.dataPath); err != nil {
+		return fmt.Errorf("the game data has mistakes, so it can't be released:\n%w", err)
+	}
+
+	pack, err := gamedata.WriteManifest(a.dataPath, *version)
+	if err != nil {
+		return err
+	}
+	for _, file := range pack.Files {
+		log.Printf("%s: %d bytes, %x", file.Name, file.Size, file.Sha256)
+	}
+	log.Printf("Released game data version %d with checksum %x", pack.Version, pack.Checksum)
+	return nil
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"server/internal/server"
+	"server/pkg/client"
+	"server/pkg/mmoserver"
+	"server/pkg/packets"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	dataPath   = flag.String("data", ".", "Path to the data directory, which is copied so it's left as it is")
+	playerList = flag.String("players", "50,100,200", "Player counts to try, comma separated")
+	aoiList    = flag.String("aoi", strconv.Itoa(mmoserver.DefaultAoiRadius), "AOI radii to try with each player count, comma separated")
+	warmup     = flag.Duration("warmup", 5*time.Second, "How long players move around before measuring starts")
+	duration   = flag.Duration("duration", 30*time.Second, "How long to measure each scenario for")
+	outPath    = flag.String("out", "capacity-report.json", "Where to write the capacity report")
+	scenario   = flag.String("scenario", "", "Run one scenario, given as players:aoi, and write its result to stdout. Used by the report run itself.")
+)
+
+const usage = `Usage: loadtest [-data .] [-players 50,100,200] [-aoi 1500] [-warmup 5s] [-duration 30s] [-out capacity-report.json]
+
+Runs a server on a copy of the data directory with every combination of player count and AOI radius given, with bots
+logged in as seeded players wandering around, and writes a capacity report of the bandwidth each client and the server
+as a whole used, the CPU used per tick and how fast memory was allocated. Each scenario runs in a process of its own,
+so they don't skew each other. The bots run in the same process as the server, so CPU and allocations include what
+they cost too, which makes them an upper bound.
+
+Flags:
+`
+
+// One combination of player count and AOI radius, and what it cost
+type scenarioResult struct {
+	Players   int     `json:"players"`
+	AoiRadius float64 `json:"aoi_radius"`
+
+	// How many bots managed to log in and stay connected until the end
+	Connected int `json:"connected"`
+
+	// Bytes per second each client sent and was sent, and all of them together
+	ClientReceived distribution `json:"client_received_bytes_per_second"`
+	ClientSent     distribution `json:"client_sent_bytes_per_second"`
+	TotalReceived  float64      `json:"total_received_bytes_per_second"`
+	TotalSent      float64      `json:"total_sent_bytes_per_second"`
+
+	// CPU time used per server tick, and how many cores' worth that is
+	CpuPerTickMs float64 `json:"cpu_per_tick_ms"`
+	CpuCores     float64 `json:"cpu_cores"`
+
+	AllocBytesPerSecond   float64 `json:"alloc_bytes_per_second"`
+	AllocObjectsPerSecond float64 `json:"alloc_objects_per_second"`
+	AllocBytesPerTick     float64 `json:"alloc_bytes_per_tick"`
+	HeapBytes             uint64  `json:"heap_bytes"`
+	Goroutines            int     `json:"goroutines"`
+}
+
+type distribution struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P95  float64 `json:"p95"`
+	Max  float64 `json:"max"`
+}
+
+type report struct {
+	GeneratedAt    time.Time        `json:"generated_at"`
+	GoVersion      string           `json:"go_version"`
+	Cpus           int              `json:"cpus"`
+	TickIntervalMs float64          `json:"tick_interval_ms"`
+	Warmup         string           `json:"warmup"`
+	Duration       string           `json:"duration"`
+	Scenarios      []scenarioResult `json:"scenarios"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *scenario != "" {
+		players, aoiRadius, err := parseScenario(*scenario)
+		if err != nil {
+			log.Fatalf("Error parsing scenario: %v", err)
+		}
+		result, err := runScenario(players, aoiRadius)
+		if err != nil {
+			log.Fatalf("Error running scenario: %v", err)
+		}
+		if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+			log.Fatalf("Error writing result: %v", err)
+		}
+		return
+	}
+
+	playerCounts, err := parseList(*playerList, strconv.Atoi)
+	if err != nil {
+		log.Fatalf("Error parsing -players: %v", err)
+	}
+	aoiRadii, err := parseList(*aoiList, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	if err != nil {
+		log.Fatalf("Error parsing -aoi: %v", err)
+	}
+
+	r := &report{
+		GeneratedAt:    time.Now().UTC(),
+		GoVersion:      runtime.Version(),
+		Cpus:           runtime.NumCPU(),
+		TickIntervalMs: float64(server.TickInterval) / float64(time.Millisecond),
+		Warmup:         warmup.String(),
+		Duration:       duration.String(),
+	}
+	for _, players := range playerCounts {
+		for _, aoiRadius := range aoiRadii {
+			log.Printf("Running %d players with an AOI radius of %v...", players, aoiRadius)
+			result, err := runScenarioProcess(players, aoiRadius)
+			if err != nil {
+				log.Fatalf("Error running %d players with an AOI radius of %v: %v", players, aoiRadius, err)
+			}
+			r.Scenarios = append(r.Scenarios, *result)
+			logResult(result)
+		}
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding report: %v", err)
+	}
+	if err := os.WriteFile(*outPath, data, 0644); err != nil {
+		log.Fatalf("Error writing report: %v", err)
+	}
+	log.Printf("Wrote capacity report to %s", *outPath)
+}
+
+// Run the scenario in a fresh copy of this program, so nothing's left over from the last one
+func runScenarioProcess(players int, aoiRadius float64) (*scenarioResult, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(executable,
+		"-data", *dataPath,
+		"-warmup", warmup.String(),
+		"-duration", duration.String(),
+		"-scenario", fmt.Sprintf("%d:%v", players, aoiRadius),
+	)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+	result := &scenarioResult{}
+	if err := json.Unmarshal(output, result); err != nil {
+		return nil, fmt.Errorf("error reading result: %w", err)
+	}
+	return result, nil
+}
+
+func runScenario(players int, aoiRadius float64) (*scenarioResult, error) {
+	scenarioPath, err := os.MkdirTemp("", "loadtest")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(scenarioPath)
+	if err := copyData(*dataPath, scenarioPath); err != nil {
+		return nil, fmt.Errorf("error copying data directory: %w", err)
+	}
+
+	// Every bot logs in to an account seeded for it, which is much quicker than each registering its own
+	srv := mmoserver.New(mmoserver.Config{
+		DataPath:  scenarioPath,
+		AoiRadius: aoiRadius,
+		DevSeed:   fmt.Sprintf("accounts=%d", players),
+		LogLevels: "hub=error,clients=error,db=error,states=error,admin=error",
+	})
+	go srv.Hub.Run()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+	go http.Serve(listener, srv.Mux)
+	url := "ws://" + listener.Addr().String() + "/ws"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bots, err := connectBots(ctx, url, players)
+	if err != nil {
+		return nil, err
+	}
+	for _, bot := range bots {
+		go wander(ctx, bot)
+	}
+
+	time.Sleep(*warmup)
+	before := sample(bots)
+	time.Sleep(*duration)
+	after := sample(bots)
+
+	return summarize(players, aoiRadius, bots, before, after), nil
+}
+
+// Log a bot in as each seeded player, a few at a time
+func connectBots(ctx context.Context, url string, players int) ([]*client.Client, error) {
+	bots := make([]*client.Client, players)
+	errs := make(chan error, players)
+	limit := make(chan struct{}, 16)
+	var wg sync.WaitGroup
+	for i := range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			bot, err := dialWhenUp(ctx, url)
+			if err != nil {
+				errs <- err
+				return
+			}
+			bot.AcceptPrompts()
+			if err := bot.Login(ctx, fmt.Sprintf("seed%d", i+1), mmoserver.DefaultSeedPassword); err != nil {
+				bot.Close()
+				errs <- fmt.Errorf("seed%d: %w", i+1, err)
+				return
+			}
+			bots[i] = bot
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return bots, nil
+}
+
+// The hub only hands out IDs once it's running, which is after the database is seeded
+func dialWhenUp(ctx context.Context, url string) (*client.Client, error) {
+	deadline := time.Now().Add(time.Minute)
+	for {
+		dialCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		bot, err := client.Dial(dialCtx, url, nil)
+		cancel()
+		if err == nil || time.Now().After(deadline) {
+			return bot, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Head off in a new direction every so often, like a player looking around
+func wander(ctx context.Context, bot *client.Client) {
+	for {
+		bot.Send(&packets.Packet_PlayerDirection{PlayerDirection: &packets.PlayerDirectionMessage{Direction: rand.Float64() * 2 * math.Pi}})
+		select {
+		case <-time.After(500*time.Millisecond + rand.N(1500*time.Millisecond)):
+		case <-ctx.Done():
+			return
+		case <-bot.Done():
+			return
+		}
+	}
+}
+
+type snapshot struct {
+	at           time.Time
+	received     []int64
+	sent         []int64
+	cpuSeconds   float64
+	allocBytes   uint64
+	allocObjects uint64
+	heapBytes    uint64
+	goroutines   int
+}
+
+func sample(bots []*client.Client) *snapshot {
+	// The runtime only brings its CPU estimates up to date when it collects garbage
+	runtime.GC()
+	samples := []metrics.Sample{
+		{Name: "/cpu/classes/total:cpu-seconds"},
+		{Name: "/cpu/classes/idle:cpu-seconds"},
+		{Name: "/gc/heap/allocs:bytes"},
+		{Name: "/gc/heap/allocs:objects"},
+		{Name: "/memory/classes/heap/objects:bytes"},
+	}
+	metrics.Read(samples)
+
+	s := &snapshot{
+		at:           time.Now(),
+		cpuSeconds:   samples[0].Value.Float64() - samples[1].Value.Float64(),
+		allocBytes:   samples[2].Value.Uint64(),
+		allocObjects: samples[3].Value.Uint64(),
+		heapBytes:    samples[4].Value.Uint64(),
+		goroutines:   runtime.NumGoroutine(),
+	}
+	for _, bot := range bots {
+		s.received = append(s.received, bot.BytesReceived())
+		s.sent = append(s.sent, bot.BytesSent())
+	}
+	return s
+}
+
+func summarize(players int, aoiRadius float64, bots []*client.Client, before *snapshot, after *snapshot) *scenarioResult {
+	seconds := after.at.Sub(before.at).Seconds()
+	ticks := seconds / server.TickInterval.Seconds()
+
+	result := &scenarioResult{Players: players, AoiRadius: aoiRadius}
+	var received, sent []float64
+	for i, bot := range bots {
+		select {
+		case <-bot.Done():
+			continue
+		default:
+		}
+		result.Connected++
+		received = append(received, float64(after.received[i]-before.received[i])/seconds)
+		sent = append(sent, float64(after.sent[i]-before.sent[i])/seconds)
+	}
+	result.ClientReceived, result.TotalReceived = distributionOf(received)
+	result.ClientSent, result.TotalSent = distributionOf(sent)
+
+	cpuSeconds := after.cpuSeconds - before.cpuSeconds
+	result.CpuPerTickMs = cpuSeconds / ticks * 1000
+	result.CpuCores = cpuSeconds / seconds
+
+	allocBytes := float64(after.allocBytes - before.allocBytes)
+	result.AllocBytesPerSecond = allocBytes / seconds
+	result.AllocObjectsPerSecond = float64(after.allocObjects-before.allocObjects) / seconds
+	result.AllocBytesPerTick = allocBytes / ticks
+	result.HeapBytes = after.heapBytes
+	result.Goroutines = after.goroutines
+	return result
+}
+
+// The distribution of the values, and their total
+func distributionOf(values []float64) (distribution, float64) {
+	if len(values) == 0 {
+		return distribution{}, 0
+	}
+	slices.Sort(values)
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	percentile := func(p float64) float64 {
+		return values[min(int(p*float64(len(values))), len(values)-1)]
+	}
+	return distribution{
+		Mean: total / float64(len(values)),
+		P50:  percentile(0.5),
+		P95:  percentile(0.95),
+		Max:  values[len(values)-1],
+	}, total
+}
+
+func logResult(result *scenarioResult) {
+	log.Printf("%d players (%d connected), AOI %v: %.1f KiB/s down and %.1f KiB/s up per client (p95 %.1f down), "+
+		"%.1f MiB/s down in total, %.2f ms CPU per tick, %.1f MiB/s allocated",
+		result.Players, result.Connected, result.AoiRadius,
+		result.ClientReceived.Mean/1024, result.ClientSent.Mean/1024, result.ClientReceived.P95/1024,
+		result.TotalReceived/1024/1024, result.CpuPerTickMs, result.AllocBytesPerSecond/1024/1024)
+}
+
+func parseScenario(spec string) (int, float64, error) {
+	playersValue, aoiValue, found := strings.Cut(spec, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("expected players:aoi, got %q", spec)
+	}
+	players, err := strconv.Atoi(playersValue)
+	if err != nil || players <= 0 {
+		return 0, 0, fmt.Errorf("invalid player count %q", playersValue)
+	}
+	aoiRadius, err := strconv.ParseFloat(aoiValue, 64)
+	if err != nil || aoiRadius <= 0 {
+		return 0, 0, fmt.Errorf("invalid AOI radius %q", aoiValue)
+	}
+	return players, aoiRadius, nil
+}
+
+func parseList[T int | float64](list string, parse func(string) (T, error)) ([]T, error) {
+	var values []T
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		parsed, err := parse(value)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid value %q", value)
+		}
+		values = append(values, parsed)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("nothing given")
+	}
+	return values, nil
+}
+
+// Copy the game data files, but not the database, so every scenario starts from an empty one
+func copyData(from string, to string) error {
+	files, err := filepath.Glob(filepath.Join(from, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(to, filepath.Base(file)), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
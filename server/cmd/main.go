@@ -1,16 +1,16 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
-	"server/internal/server"
-	"server/internal/server/clients"
+	"server/pkg/mmoserver"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -27,11 +27,229 @@ type config struct {
 	CertPath   string
 	KeyPath    string
 	ClientPath string
+
+	// Serve over TLS with the cert and key directly, rather than leaving it to a reverse proxy, optionally redirecting
+	// plain HTTP on another port
+	UseTls           bool
+	HttpRedirectPort int
+
+	// Native clients can also connect over UDP on this port, unless 0
+	UdpPort int
+
+	// Browsers can also connect over WebTransport on this UDP port, unless 0, if there's a cert and key
+	WebTransportPort int
+
+	// Presence gossip between shards, disabled unless a secret and peers are configured
+	ShardId        string
+	PresencePeers  []string
+	PresenceSecret string
+
+	// Which node this server hands out IDs as, which must be different for every server running at once
+	IdNode uint64
+
+	// Restart the server this long after it starts, or never if 0
+	RestartInterval time.Duration
+
+	// Limits on what clients can send
+	MaxMessageSize int64
+	ReadTimeout    time.Duration
+
+	// How long a state may take to handle a message before it's logged as slow
+	SlowHandlerThreshold time.Duration
+
+	// How much of each tick periodic work can take before the rest is put off, or below 0 for no limit
+	TickBudget time.Duration
+
+	// How many workers deliver each broadcast, 0 for one for each CPU or 1 for none
+	BroadcastWorkers int
+
+	// STUN and TURN servers for voice calls, with the secret shared with the TURN servers
+	VoiceRelay mmoserver.VoiceRelayConfig
+
+	// How often players' live state is checked against what's saved, below 0 for only when an admin asks, and whether
+	// scheduled checks repair what they find
+	ConsistencyInterval time.Duration
+	ConsistencyRepair   bool
+
+	// The admin API and dashboard are disabled unless a token is configured
+	AdminToken string
+
+	// Also accepted by the admin API, and needed for anything sensitive enough to skip asking players first, like
+	// impersonating them without their consent. Left empty, nothing can.
+	AdminElevatedToken string
+
+	// The API for an external matchmaker to hold places for players is disabled unless a token is configured
+	MatchmakerToken string
+
+	// World simulators can't log in unless a secret is configured
+	SimulatorSecret string
+
+	// How long each player heatmap covers, and whether they're all kept in the database
+	HeatmapInterval time.Duration
+	HeatmapHistory  bool
+
+	// Where events from the outbox are published to
+	OutboxWebhooks []string
+	OutboxNatsUrl  string
+
+	// How long an account can go without logging in before its name is released for someone else to claim, or 0 to
+	// never release names
+	NameReleaseAfter time.Duration
+
+	// How often each tier of players' progress is written, and how much of it at once
+	SaveTiers mmoserver.SaveTiers
+
+	// How often writes that can wait a tick are committed together, and how many at once
+	WriteBatch mmoserver.WriteBatchConfig
+
+	// Where kicked clients are told to reconnect to instead of here, if anywhere
+	ReconnectEndpoint string
+
+	// How long players have to cancel deleting their accounts, and how long their data exports can be downloaded
+	AccountDeletionDelay time.Duration
+	DataExportTtl        time.Duration
+
+	// Bytes per account per day before it's throttled, or 0 for no limit, and bytes per second once it is
+	BandwidthDailyLimit   int64
+	BandwidthThrottleRate int64
+
+	// Only let browsers connect from pages served by the server itself or from these origins
+	CheckOrigin    bool
+	AllowedOrigins []string
+
+	// Whether to serve the protocol inspector, for development
+	DebugProtocol bool
+
+	// Whether to serve the protocol as JSON over websockets, for development
+	DebugJson bool
+
+	// What developers send to have an actor's debug overlay streamed to them, which is off without it
+	DebugOverlaySecret string
+
+	// Whether failures can be injected through the admin API, for development and staging
+	Chaos bool
+
+	// Whether to serve goroutine counts and profiles, for development, and how much lock contention to sample
+	DebugDiagnostics     bool
+	MutexProfileFraction int
+
+	// What each module logs, e.g. hub=info,states=debug
+	LogLevels string
+
+	// The share of clients whose states are shadowed, for states with a shadow registered
+	ShadowFraction float64
+
+	// What can be pushed to clients through the admin API
+	MaxFileSize      int
+	FileContentTypes []string
+
+	// How many background jobs can run at once
+	JobConcurrency int
+
+	// Where the database is backed up to, if anywhere, how often, and how many backups are kept
+	BackupPath     string
+	BackupInterval time.Duration
+	BackupKeep     int
+
+	// Caps on each subsystem, e.g. players=500,chat=50/s:drop
+	Budgets string
+
+	// Whether the world runs deterministically, from what seed, and where it's recorded to if anywhere
+	Deterministic bool
+	Seed          uint64
+	RecordPath    string
+
+	// Thresholds on metrics to alert on, e.g. mmo_log_errors_total>1/s, how often they're checked, and how long before
+	// a rule that's still broken alerts again
+	AlertRules    string
+	AlertInterval time.Duration
+	AlertCooldown time.Duration
+
+	// Where alerts are sent, by webhook and by email through an SMTP server
+	AlertWebhooks     []string
+	AlertSmtpAddr     string
+	AlertSmtpUser     string
+	AlertSmtpPassword string
+	AlertEmailFrom    string
+	AlertEmailTo      []string
+
+	// The oldest and newest client builds that can log in, until when older ones are only warned, and where to get
+	// the latest
+	ClientVersions mmoserver.ClientVersionPolicy
+
+	// Which CAPTCHA provider to challenge suspicious logins with, if any, and after how many failures from an IP
+	Captcha mmoserver.CaptchaConfig
+
+	// How far from each player others are updated more often the nearer they are
+	AoiRadius float64
+
+	// How big the squares of the grid player updates are broadcast by are, or 0 to broadcast them to everyone
+	InterestCellSize float64
+
+	// Fake accounts to fill the database with on starting, for development, e.g. accounts=200,characters=2,mail=5
+	DevSeed string
+
+	// How long a player must be idle for to be disconnected for someone with priority login on a full server, or 0 to
+	// never disconnect anyone
+	PriorityLoginEvictIdle time.Duration
+
+	// How long chat is kept for moderators to export
+	ChatRetention time.Duration
+
+	// The most coins that can change hands at once and per account per day, and how many get a transfer flagged
+	CurrencyLimits mmoserver.CurrencyLimits
+
+	// How many inventory slots players have and how much weight they can carry, 0 for no limit
+	CarryLimits mmoserver.CarryLimits
+
+	// When bosses' loot lockouts reset each week, in UTC
+	LockoutReset mmoserver.LockoutReset
 }
 
 var (
-	defaultConfig = &config{Port: 8080}
-	configPath    = flag.String("config", ".env", "Path to the config file")
+	defaultConfig = &config{
+		Port:           8080,
+		MaxMessageSize: mmoserver.DefaultWebSocketLimits.MaxMessageSize,
+		ReadTimeout:    mmoserver.DefaultWebSocketLimits.ReadTimeout,
+
+		SlowHandlerThreshold: mmoserver.DefaultSlowHandlerThreshold,
+		TickBudget:           mmoserver.DefaultTickBudget,
+
+		HeatmapInterval: time.Minute,
+
+		SaveTiers:  mmoserver.DefaultSaveTiers,
+		WriteBatch: mmoserver.DefaultWriteBatchConfig,
+
+		AccountDeletionDelay: mmoserver.DefaultAccountDeletionDelay,
+		DataExportTtl:        mmoserver.DefaultDataExportTtl,
+
+		BandwidthThrottleRate: mmoserver.DefaultBandwidthThrottleRate,
+
+		Captcha: mmoserver.CaptchaConfig{FailureThreshold: mmoserver.DefaultCaptchaFailureThreshold},
+
+		ShadowFraction: mmoserver.DefaultShadowFraction,
+
+		MutexProfileFraction: mmoserver.DefaultMutexProfileFraction,
+
+		MaxFileSize:      mmoserver.DefaultMaxFileSize,
+		FileContentTypes: mmoserver.DefaultFileContentTypes,
+
+		JobConcurrency: mmoserver.DefaultJobConcurrency,
+
+		BackupInterval: mmoserver.DefaultBackupInterval,
+		BackupKeep:     mmoserver.DefaultBackupKeep,
+
+		AlertInterval: mmoserver.DefaultAlertInterval,
+		AlertCooldown: mmoserver.DefaultAlertCooldown,
+
+		ChatRetention: mmoserver.DefaultChatRetention,
+
+		CurrencyLimits: mmoserver.DefaultCurrencyLimits,
+
+		LockoutReset: mmoserver.DefaultLockoutReset,
+	}
+	configPath  = flag.String("config", ".env", "Path to the config file")
+	profileName = flag.String("profile", "", "Which profile of defaults to use, overriding PROFILE: "+profileNames())
 )
 
 func loadConfig() *config {
@@ -40,6 +258,416 @@ func loadConfig() *config {
 	cfg.CertPath = os.Getenv("CERT_PATH")
 	cfg.KeyPath = os.Getenv("KEY_PATH")
 	cfg.ClientPath = os.Getenv("CLIENT_PATH")
+	cfg.UseTls = os.Getenv("TLS") == "true"
+	cfg.ShardId = os.Getenv("SHARD_ID")
+	cfg.PresenceSecret = os.Getenv("PRESENCE_SECRET")
+	cfg.AdminToken = os.Getenv("ADMIN_TOKEN")
+	cfg.AdminElevatedToken = os.Getenv("ADMIN_ELEVATED_TOKEN")
+	cfg.MatchmakerToken = os.Getenv("MATCHMAKER_TOKEN")
+	cfg.SimulatorSecret = os.Getenv("SIMULATOR_SECRET")
+	cfg.DebugOverlaySecret = os.Getenv("DEBUG_OVERLAY_SECRET")
+	cfg.HeatmapHistory = os.Getenv("HEATMAP_HISTORY") == "true"
+	loadBoolConfig("CHECK_ORIGIN", &cfg.CheckOrigin)
+	loadBoolConfig("DEBUG_PROTOCOL", &cfg.DebugProtocol)
+	loadBoolConfig("DEBUG_JSON", &cfg.DebugJson)
+	loadBoolConfig("CHAOS", &cfg.Chaos)
+	loadBoolConfig("DEBUG_DIAGNOSTICS", &cfg.DebugDiagnostics)
+	if levels := os.Getenv("LOG_LEVELS"); levels != "" {
+		cfg.LogLevels = levels
+	}
+	cfg.BackupPath = os.Getenv("BACKUP_PATH")
+	if budgets := os.Getenv("BUDGETS"); budgets != "" {
+		cfg.Budgets = budgets
+	}
+	if origins := os.Getenv("ALLOWED_ORIGINS"); origins != "" {
+		cfg.AllowedOrigins = strings.Split(origins, ",")
+	}
+	cfg.Deterministic = os.Getenv("DETERMINISTIC") == "true"
+	cfg.RecordPath = os.Getenv("RECORD_PATH")
+	cfg.AlertRules = os.Getenv("ALERT_RULES")
+	cfg.AlertSmtpAddr = os.Getenv("ALERT_SMTP_ADDR")
+	cfg.AlertSmtpUser = os.Getenv("ALERT_SMTP_USER")
+	cfg.AlertSmtpPassword = os.Getenv("ALERT_SMTP_PASSWORD")
+	cfg.AlertEmailFrom = os.Getenv("ALERT_EMAIL_FROM")
+	if webhooks := os.Getenv("ALERT_WEBHOOKS"); webhooks != "" {
+		cfg.AlertWebhooks = strings.Split(webhooks, ",")
+	}
+	if to := os.Getenv("ALERT_EMAIL_TO"); to != "" {
+		cfg.AlertEmailTo = strings.Split(to, ",")
+	}
+	cfg.OutboxNatsUrl = os.Getenv("OUTBOX_NATS_URL")
+	if peers := os.Getenv("PRESENCE_PEERS"); peers != "" {
+		cfg.PresencePeers = strings.Split(peers, ",")
+	}
+	if webhooks := os.Getenv("OUTBOX_WEBHOOKS"); webhooks != "" {
+		cfg.OutboxWebhooks = strings.Split(webhooks, ",")
+	}
+	if interval := os.Getenv("RESTART_INTERVAL"); interval != "" {
+		restartInterval, err := time.ParseDuration(interval)
+		if err != nil {
+			log.Printf("Error parsing RESTART_INTERVAL, not scheduling restarts: %v", err)
+		} else {
+			cfg.RestartInterval = restartInterval
+		}
+	}
+	if interval := os.Getenv("HEATMAP_INTERVAL"); interval != "" {
+		heatmapInterval, err := time.ParseDuration(interval)
+		if err != nil || heatmapInterval <= 0 {
+			log.Printf("Error parsing HEATMAP_INTERVAL, using %s", cfg.HeatmapInterval)
+		} else {
+			cfg.HeatmapInterval = heatmapInterval
+		}
+	}
+	if port := os.Getenv("HTTP_REDIRECT_PORT"); port != "" {
+		redirectPort, err := strconv.Atoi(port)
+		if err != nil {
+			log.Printf("Error parsing HTTP_REDIRECT_PORT, not redirecting HTTP: %v", err)
+		} else {
+			cfg.HttpRedirectPort = redirectPort
+		}
+	}
+	if port := os.Getenv("UDP_PORT"); port != "" {
+		udpPort, err := strconv.Atoi(port)
+		if err != nil {
+			log.Printf("Error parsing UDP_PORT, not listening for UDP: %v", err)
+		} else {
+			cfg.UdpPort = udpPort
+		}
+	}
+	if port := os.Getenv("WEBTRANSPORT_PORT"); port != "" {
+		webTransportPort, err := strconv.Atoi(port)
+		if err != nil {
+			log.Printf("Error parsing WEBTRANSPORT_PORT, not listening for WebTransport: %v", err)
+		} else {
+			cfg.WebTransportPort = webTransportPort
+		}
+	}
+	if size := os.Getenv("MAX_MESSAGE_SIZE"); size != "" {
+		maxMessageSize, err := strconv.ParseInt(size, 10, 64)
+		if err != nil || maxMessageSize <= 0 {
+			log.Printf("Error parsing MAX_MESSAGE_SIZE, using %d", cfg.MaxMessageSize)
+		} else {
+			cfg.MaxMessageSize = maxMessageSize
+		}
+	}
+	if timeout := os.Getenv("READ_TIMEOUT"); timeout != "" {
+		readTimeout, err := time.ParseDuration(timeout)
+		if err != nil || readTimeout <= 0 {
+			log.Printf("Error parsing READ_TIMEOUT, using %s", cfg.ReadTimeout)
+		} else {
+			cfg.ReadTimeout = readTimeout
+		}
+	}
+
+	if threshold := os.Getenv("SLOW_HANDLER_THRESHOLD"); threshold != "" {
+		slowHandlerThreshold, err := time.ParseDuration(threshold)
+		if err != nil || slowHandlerThreshold <= 0 {
+			log.Printf("Error parsing SLOW_HANDLER_THRESHOLD, using %s", cfg.SlowHandlerThreshold)
+		} else {
+			cfg.SlowHandlerThreshold = slowHandlerThreshold
+		}
+	}
+
+	if budget := os.Getenv("TICK_BUDGET"); budget == "off" {
+		cfg.TickBudget = -1
+	} else if budget != "" {
+		tickBudget, err := time.ParseDuration(budget)
+		if err != nil || tickBudget <= 0 {
+			log.Printf("Error parsing TICK_BUDGET, using %s", cfg.TickBudget)
+		} else {
+			cfg.TickBudget = tickBudget
+		}
+	}
+
+	if workers := os.Getenv("BROADCAST_WORKERS"); workers != "" {
+		broadcastWorkers, err := strconv.Atoi(workers)
+		if err != nil || broadcastWorkers < 0 {
+			log.Printf("Error parsing BROADCAST_WORKERS, using %d", cfg.BroadcastWorkers)
+		} else {
+			cfg.BroadcastWorkers = broadcastWorkers
+		}
+	}
+	if urls := os.Getenv("STUN_URLS"); urls != "" {
+		cfg.VoiceRelay.StunUrls = strings.Split(urls, ",")
+	}
+	if urls := os.Getenv("TURN_URLS"); urls != "" {
+		cfg.VoiceRelay.TurnUrls = strings.Split(urls, ",")
+	}
+	cfg.VoiceRelay.TurnSecret = os.Getenv("TURN_SECRET")
+	if ttl := os.Getenv("TURN_CREDENTIAL_TTL"); ttl != "" {
+		credentialTtl, err := time.ParseDuration(ttl)
+		if err != nil || credentialTtl <= 0 {
+			log.Printf("Error parsing TURN_CREDENTIAL_TTL, using the default")
+		} else {
+			cfg.VoiceRelay.CredentialTtl = credentialTtl
+		}
+	}
+	if interval := os.Getenv("CONSISTENCY_INTERVAL"); interval != "" {
+		consistencyInterval, err := time.ParseDuration(interval)
+		if err != nil {
+			log.Printf("Error parsing CONSISTENCY_INTERVAL, using the default")
+		} else {
+			cfg.ConsistencyInterval = consistencyInterval
+		}
+	}
+	cfg.ConsistencyRepair = os.Getenv("CONSISTENCY_REPAIR") == "true"
+
+	loadSaveTierConfig("HOT", &cfg.SaveTiers.Hot)
+	loadSaveTierConfig("WARM", &cfg.SaveTiers.Warm)
+	loadSaveTierConfig("COLD", &cfg.SaveTiers.Cold)
+
+	if interval := os.Getenv("WRITE_BATCH_INTERVAL"); interval != "" {
+		batchInterval, err := time.ParseDuration(interval)
+		if err != nil || batchInterval < 0 {
+			log.Printf("Error parsing WRITE_BATCH_INTERVAL, using %s", cfg.WriteBatch.Interval)
+		} else {
+			cfg.WriteBatch.Interval = batchInterval
+		}
+	}
+	if batch := os.Getenv("WRITE_BATCH_SIZE"); batch != "" {
+		maxBatch, err := strconv.Atoi(batch)
+		if err != nil || maxBatch <= 0 {
+			log.Printf("Error parsing WRITE_BATCH_SIZE, using %d", cfg.WriteBatch.MaxBatch)
+		} else {
+			cfg.WriteBatch.MaxBatch = maxBatch
+		}
+	}
+
+	cfg.ReconnectEndpoint = os.Getenv("RECONNECT_ENDPOINT")
+
+	if node := os.Getenv("ID_NODE"); node != "" {
+		idNode, err := strconv.ParseUint(node, 10, 64)
+		if err != nil || idNode >= mmoserver.MaxIdNodes {
+			log.Printf("Error parsing ID_NODE, using %d", cfg.IdNode)
+		} else {
+			cfg.IdNode = idNode
+		}
+	}
+
+	if releaseAfter := os.Getenv("NAME_RELEASE_AFTER"); releaseAfter != "" {
+		nameReleaseAfter, err := time.ParseDuration(releaseAfter)
+		if err != nil || nameReleaseAfter < 0 {
+			log.Printf("Error parsing NAME_RELEASE_AFTER, never releasing names")
+		} else {
+			cfg.NameReleaseAfter = nameReleaseAfter
+		}
+	}
+
+	if delay := os.Getenv("ACCOUNT_DELETION_DELAY"); delay != "" {
+		deletionDelay, err := time.ParseDuration(delay)
+		if err != nil || deletionDelay <= 0 {
+			log.Printf("Error parsing ACCOUNT_DELETION_DELAY, using %s", cfg.AccountDeletionDelay)
+		} else {
+			cfg.AccountDeletionDelay = deletionDelay
+		}
+	}
+
+	if ttl := os.Getenv("DATA_EXPORT_TTL"); ttl != "" {
+		exportTtl, err := time.ParseDuration(ttl)
+		if err != nil || exportTtl <= 0 {
+			log.Printf("Error parsing DATA_EXPORT_TTL, using %s", cfg.DataExportTtl)
+		} else {
+			cfg.DataExportTtl = exportTtl
+		}
+	}
+
+	if limit := os.Getenv("BANDWIDTH_DAILY_LIMIT"); limit != "" {
+		dailyLimit, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || dailyLimit < 0 {
+			log.Printf("Error parsing BANDWIDTH_DAILY_LIMIT, not limiting bandwidth")
+		} else {
+			cfg.BandwidthDailyLimit = dailyLimit
+		}
+	}
+
+	if rate := os.Getenv("BANDWIDTH_THROTTLE_RATE"); rate != "" {
+		throttleRate, err := strconv.ParseInt(rate, 10, 64)
+		if err != nil || throttleRate <= 0 {
+			log.Printf("Error parsing BANDWIDTH_THROTTLE_RATE, using %d", cfg.BandwidthThrottleRate)
+		} else {
+			cfg.BandwidthThrottleRate = throttleRate
+		}
+	}
+
+	if fraction := os.Getenv("SHADOW_FRACTION"); fraction != "" {
+		shadowFraction, err := strconv.ParseFloat(fraction, 64)
+		if err != nil || shadowFraction <= 0 || shadowFraction > 1 {
+			log.Printf("Error parsing SHADOW_FRACTION, using %g", cfg.ShadowFraction)
+		} else {
+			cfg.ShadowFraction = shadowFraction
+		}
+	}
+
+	if size := os.Getenv("MAX_FILE_SIZE"); size != "" {
+		maxFileSize, err := strconv.Atoi(size)
+		if err != nil || maxFileSize <= 0 {
+			log.Printf("Error parsing MAX_FILE_SIZE, using %d", cfg.MaxFileSize)
+		} else {
+			cfg.MaxFileSize = maxFileSize
+		}
+	}
+
+	if types := os.Getenv("FILE_CONTENT_TYPES"); types != "" {
+		cfg.FileContentTypes = strings.Split(types, ",")
+	}
+
+	if fraction := os.Getenv("MUTEX_PROFILE_FRACTION"); fraction != "" {
+		mutexProfileFraction, err := strconv.Atoi(fraction)
+		if err != nil || mutexProfileFraction <= 0 {
+			log.Printf("Error parsing MUTEX_PROFILE_FRACTION, using %d", cfg.MutexProfileFraction)
+		} else {
+			cfg.MutexProfileFraction = mutexProfileFraction
+		}
+	}
+
+	if concurrency := os.Getenv("JOB_CONCURRENCY"); concurrency != "" {
+		jobConcurrency, err := strconv.Atoi(concurrency)
+		if err != nil || jobConcurrency <= 0 {
+			log.Printf("Error parsing JOB_CONCURRENCY, using %d", cfg.JobConcurrency)
+		} else {
+			cfg.JobConcurrency = jobConcurrency
+		}
+	}
+
+	if interval := os.Getenv("BACKUP_INTERVAL"); interval != "" {
+		backupInterval, err := time.ParseDuration(interval)
+		if err != nil || backupInterval <= 0 {
+			log.Printf("Error parsing BACKUP_INTERVAL, using %s", cfg.BackupInterval)
+		} else {
+			cfg.BackupInterval = backupInterval
+		}
+	}
+
+	if keep := os.Getenv("BACKUP_KEEP"); keep != "" {
+		backupKeep, err := strconv.Atoi(keep)
+		if err != nil || backupKeep <= 0 {
+			log.Printf("Error parsing BACKUP_KEEP, using %d", cfg.BackupKeep)
+		} else {
+			cfg.BackupKeep = backupKeep
+		}
+	}
+
+	if seed := os.Getenv("SEED"); seed != "" {
+		parsedSeed, err := strconv.ParseUint(seed, 10, 64)
+		if err != nil {
+			log.Printf("Error parsing SEED, using %d", cfg.Seed)
+		} else {
+			cfg.Seed = parsedSeed
+		}
+	}
+
+	if interval := os.Getenv("ALERT_INTERVAL"); interval != "" {
+		alertInterval, err := time.ParseDuration(interval)
+		if err != nil || alertInterval <= 0 {
+			log.Printf("Error parsing ALERT_INTERVAL, using %s", cfg.AlertInterval)
+		} else {
+			cfg.AlertInterval = alertInterval
+		}
+	}
+
+	if cooldown := os.Getenv("ALERT_COOLDOWN"); cooldown != "" {
+		alertCooldown, err := time.ParseDuration(cooldown)
+		if err != nil || alertCooldown <= 0 {
+			log.Printf("Error parsing ALERT_COOLDOWN, using %s", cfg.AlertCooldown)
+		} else {
+			cfg.AlertCooldown = alertCooldown
+		}
+	}
+
+	cfg.DevSeed = os.Getenv("DEV_SEED")
+
+	if radius := os.Getenv("AOI_RADIUS"); radius != "" {
+		aoiRadius, err := strconv.ParseFloat(radius, 64)
+		if err != nil || aoiRadius <= 0 {
+			log.Printf("Error parsing AOI_RADIUS, using %v", mmoserver.DefaultAoiRadius)
+		} else {
+			cfg.AoiRadius = aoiRadius
+		}
+	}
+
+	if cellSize := os.Getenv("INTEREST_CELL_SIZE"); cellSize != "" {
+		interestCellSize, err := strconv.ParseFloat(cellSize, 64)
+		if err != nil || interestCellSize < 0 {
+			log.Printf("Error parsing INTEREST_CELL_SIZE, broadcasting player updates to everyone")
+		} else {
+			cfg.InterestCellSize = interestCellSize
+		}
+	}
+
+	if evictIdle := os.Getenv("PRIORITY_LOGIN_EVICT_IDLE"); evictIdle != "" {
+		priorityLoginEvictIdle, err := time.ParseDuration(evictIdle)
+		if err != nil || priorityLoginEvictIdle < 0 {
+			log.Printf("Error parsing PRIORITY_LOGIN_EVICT_IDLE, never disconnecting idle players")
+		} else {
+			cfg.PriorityLoginEvictIdle = priorityLoginEvictIdle
+		}
+	}
+
+	if retention := os.Getenv("CHAT_RETENTION"); retention != "" {
+		chatRetention, err := time.ParseDuration(retention)
+		if err != nil || chatRetention <= 0 {
+			log.Printf("Error parsing CHAT_RETENTION, using %s", cfg.ChatRetention)
+		} else {
+			cfg.ChatRetention = chatRetention
+		}
+	}
+
+	loadCurrencyConfig("CURRENCY_MAX_TRANSFER", &cfg.CurrencyLimits.MaxPerTransfer)
+	loadCurrencyConfig("CURRENCY_DAILY_LIMIT", &cfg.CurrencyLimits.DailyLimit)
+	loadCurrencyConfig("CURRENCY_FLAG_TRANSFER", &cfg.CurrencyLimits.FlagTransfer)
+	loadCurrencyConfig("CURRENCY_FLAG_DAILY", &cfg.CurrencyLimits.FlagDaily)
+
+	if slots := os.Getenv("INVENTORY_SLOTS"); slots != "" {
+		inventorySlots, err := strconv.ParseInt(slots, 10, 64)
+		if err != nil || inventorySlots < 0 {
+			log.Printf("Error parsing INVENTORY_SLOTS, using no limit")
+		} else {
+			cfg.CarryLimits.Slots = inventorySlots
+		}
+	}
+	if weight := os.Getenv("INVENTORY_MAX_WEIGHT"); weight != "" {
+		maxWeight, err := strconv.ParseFloat(weight, 64)
+		if err != nil || maxWeight < 0 {
+			log.Printf("Error parsing INVENTORY_MAX_WEIGHT, using no limit")
+		} else {
+			cfg.CarryLimits.MaxWeight = maxWeight
+		}
+	}
+
+	if reset := os.Getenv("LOCKOUT_RESET"); reset != "" {
+		lockoutReset, err := mmoserver.ParseLockoutReset(reset)
+		if err != nil {
+			log.Printf("Error parsing LOCKOUT_RESET, using %s: %v", cfg.LockoutReset, err)
+		} else {
+			cfg.LockoutReset = lockoutReset
+		}
+	}
+
+	cfg.ClientVersions.DownloadUrl = os.Getenv("CLIENT_DOWNLOAD_URL")
+	loadClientBuildConfig("CLIENT_MIN_BUILD", &cfg.ClientVersions.MinBuild)
+	loadClientBuildConfig("CLIENT_MAX_BUILD", &cfg.ClientVersions.MaxBuild)
+
+	if deadline := os.Getenv("CLIENT_UPDATE_DEADLINE"); deadline != "" {
+		updateDeadline, err := time.Parse(time.RFC3339, deadline)
+		if err != nil {
+			log.Printf("Error parsing CLIENT_UPDATE_DEADLINE, turning old clients away straight away")
+		} else {
+			cfg.ClientVersions.UpdateDeadline = &updateDeadline
+		}
+	}
+
+	cfg.Captcha.Provider = os.Getenv("CAPTCHA_PROVIDER")
+	cfg.Captcha.SiteKey = os.Getenv("CAPTCHA_SITE_KEY")
+	cfg.Captcha.Secret = os.Getenv("CAPTCHA_SECRET")
+	cfg.Captcha.VerifyUrl = os.Getenv("CAPTCHA_VERIFY_URL")
+	if threshold := os.Getenv("CAPTCHA_FAILURE_THRESHOLD"); threshold != "" {
+		failureThreshold, err := strconv.Atoi(threshold)
+		if err != nil || failureThreshold <= 0 {
+			log.Printf("Error parsing CAPTCHA_FAILURE_THRESHOLD, using %d", cfg.Captcha.FailureThreshold)
+		} else {
+			cfg.Captcha.FailureThreshold = failureThreshold
+		}
+	}
 
 	port, err := strconv.Atoi(os.Getenv("PORT"))
 	if err != nil {
@@ -52,6 +680,60 @@ func loadConfig() *config {
 	return cfg
 }
 
+// Read SAVE_<TIER>_INTERVAL and SAVE_<TIER>_BATCH into the tier's config, keeping what's there for anything missing
+// or invalid. An interval of 0 writes the tier's saves straight away.
+func loadSaveTierConfig(tier string, tierConfig *mmoserver.SaveTierConfig) {
+	intervalVar, batchVar := "SAVE_"+tier+"_INTERVAL", "SAVE_"+tier+"_BATCH"
+
+	if interval := os.Getenv(intervalVar); interval != "" {
+		saveInterval, err := time.ParseDuration(interval)
+		if err != nil || saveInterval < 0 {
+			log.Printf("Error parsing %s, using %s", intervalVar, tierConfig.Interval)
+		} else {
+			tierConfig.Interval = saveInterval
+		}
+	}
+
+	if batch := os.Getenv(batchVar); batch != "" {
+		maxBatch, err := strconv.Atoi(batch)
+		if err != nil || maxBatch <= 0 {
+			log.Printf("Error parsing %s, using %d", batchVar, tierConfig.MaxBatch)
+		} else {
+			tierConfig.MaxBatch = maxBatch
+		}
+	}
+}
+
+// Read the variable as true or false if it's set, keeping what's there, e.g. from the profile, if it isn't
+func loadBoolConfig(boolVar string, value *bool) {
+	if setting := os.Getenv(boolVar); setting != "" {
+		*value = setting == "true"
+	}
+}
+
+func loadClientBuildConfig(buildVar string, build *uint32) {
+	if value := os.Getenv(buildVar); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			log.Printf("Error parsing %s, accepting any build", buildVar)
+		} else {
+			*build = uint32(parsed)
+		}
+	}
+}
+
+// A number of coins, or 0 for no cap or threshold
+func loadCurrencyConfig(coinsVar string, coins *int64) {
+	if value := os.Getenv(coinsVar); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			log.Printf("Error parsing %s, using %d", coinsVar, *coins)
+		} else {
+			*coins = parsed
+		}
+	}
+}
+
 func coalescePaths(fallbacks ...string) string {
 	for i, path := range fallbacks {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -87,62 +769,132 @@ func resolveLiveCertsPath(certPath string) string {
 func main() {
 	flag.Parse()
 	err := godotenv.Load(*configPath)
+
+	if *profileName == "" {
+		*profileName = os.Getenv("PROFILE")
+	}
+	var selected *profile
+	if *profileName != "" {
+		var exists bool
+		if selected, exists = profiles[*profileName]; !exists {
+			log.Fatalf("Unknown profile %q, expected one of %s", *profileName, profileNames())
+		}
+		selected.apply(defaultConfig)
+		log.Printf("Using the %s profile", *profileName)
+	}
+
 	cfg := defaultConfig
 	if err != nil {
 		log.Printf("Error loading config file, defaulting to %+v", defaultConfig)
 	} else {
 		cfg = loadConfig()
 	}
+	if selected != nil {
+		selected.enforce(*profileName, cfg)
+	}
 
 	// Try to load the Docker-mounted data directory. If that fails, fall back
 	// to the current directory
 	cfg.DataPath = coalescePaths(cfg.DataPath, dockerMountedDataDir, ".")
 
-	// Define the game hub
-	hub := server.NewHub(cfg.DataPath)
-
-	// Define handler for serving the HTML5 export
 	exportPath := coalescePaths(cfg.ClientPath, filepath.Join(cfg.DataPath, "html5"))
-	if _, err := os.Stat(exportPath); err != nil {
-		if !os.IsNotExist(err) {
-			log.Fatalf("Error checking for HTML export: %v", err)
+
+	var certPath, keyPath string
+	if cfg.UseTls {
+		certPath = resolveLiveCertsPath(cfg.CertPath)
+		keyPath = resolveLiveCertsPath(cfg.KeyPath)
+		if certPath == "" || keyPath == "" {
+			log.Fatalf("TLS is enabled but the cert or key could not be found (cert: %q, key: %q)", cfg.CertPath, cfg.KeyPath)
 		}
-	} else {
-		log.Printf("Serving HTML5 export from %s", exportPath)
-		http.Handle("/", addHeaders(http.StripPrefix("/", http.FileServer(http.Dir(exportPath)))))
 	}
 
-	// Define handler for WebSocket connections
-	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		hub.Serve(clients.NewWebSocketClient, w, r)
+	srv := mmoserver.New(mmoserver.Config{
+		Port:             cfg.Port,
+		DataPath:         cfg.DataPath,
+		ClientPath:       exportPath,
+		CertPath:         certPath,
+		KeyPath:          keyPath,
+		HttpRedirectPort: cfg.HttpRedirectPort,
+		UdpPort:          cfg.UdpPort,
+		WebTransportPort: cfg.WebTransportPort,
+		ShardId:          cfg.ShardId,
+		PresencePeers:    cfg.PresencePeers,
+		PresenceSecret:   cfg.PresenceSecret,
+		IdNode:           cfg.IdNode,
+		RestartInterval:  cfg.RestartInterval,
+		WebSocketLimits: mmoserver.WebSocketLimits{
+			MaxMessageSize: cfg.MaxMessageSize,
+			ReadTimeout:    cfg.ReadTimeout,
+		},
+		CheckOrigin:            cfg.CheckOrigin,
+		AllowedOrigins:         cfg.AllowedOrigins,
+		SlowHandlerThreshold:   cfg.SlowHandlerThreshold,
+		TickBudget:             cfg.TickBudget,
+		BroadcastWorkers:       cfg.BroadcastWorkers,
+		VoiceRelay:             cfg.VoiceRelay,
+		ConsistencyInterval:    cfg.ConsistencyInterval,
+		ConsistencyRepair:      cfg.ConsistencyRepair,
+		AdminToken:             cfg.AdminToken,
+		AdminElevatedToken:     cfg.AdminElevatedToken,
+		MatchmakerToken:        cfg.MatchmakerToken,
+		SimulatorSecret:        cfg.SimulatorSecret,
+		HeatmapInterval:        cfg.HeatmapInterval,
+		HeatmapHistory:         cfg.HeatmapHistory,
+		OutboxWebhooks:         cfg.OutboxWebhooks,
+		OutboxNatsUrl:          cfg.OutboxNatsUrl,
+		NameReleaseAfter:       cfg.NameReleaseAfter,
+		SaveTiers:              cfg.SaveTiers,
+		WriteBatch:             cfg.WriteBatch,
+		ReconnectEndpoint:      cfg.ReconnectEndpoint,
+		AccountDeletionDelay:   cfg.AccountDeletionDelay,
+		DataExportTtl:          cfg.DataExportTtl,
+		BandwidthDailyLimit:    cfg.BandwidthDailyLimit,
+		BandwidthThrottleRate:  cfg.BandwidthThrottleRate,
+		DebugProtocol:          cfg.DebugProtocol,
+		DebugJson:              cfg.DebugJson,
+		DebugOverlaySecret:     cfg.DebugOverlaySecret,
+		Chaos:                  cfg.Chaos,
+		ShadowFraction:         cfg.ShadowFraction,
+		MaxFileSize:            cfg.MaxFileSize,
+		FileContentTypes:       cfg.FileContentTypes,
+		DebugDiagnostics:       cfg.DebugDiagnostics,
+		MutexProfileFraction:   cfg.MutexProfileFraction,
+		LogLevels:              cfg.LogLevels,
+		JobConcurrency:         cfg.JobConcurrency,
+		BackupPath:             cfg.BackupPath,
+		BackupInterval:         cfg.BackupInterval,
+		BackupKeep:             cfg.BackupKeep,
+		Budgets:                cfg.Budgets,
+		Deterministic:          cfg.Deterministic,
+		Seed:                   cfg.Seed,
+		RecordPath:             cfg.RecordPath,
+		AlertRules:             cfg.AlertRules,
+		AlertInterval:          cfg.AlertInterval,
+		AlertCooldown:          cfg.AlertCooldown,
+		AlertWebhooks:          cfg.AlertWebhooks,
+		AlertSmtpAddr:          cfg.AlertSmtpAddr,
+		AlertSmtpUser:          cfg.AlertSmtpUser,
+		AlertSmtpPassword:      cfg.AlertSmtpPassword,
+		AlertEmailFrom:         cfg.AlertEmailFrom,
+		AlertEmailTo:           cfg.AlertEmailTo,
+		ClientVersions:         cfg.ClientVersions,
+		Captcha:                cfg.Captcha,
+		AoiRadius:              cfg.AoiRadius,
+		InterestCellSize:       cfg.InterestCellSize,
+		DevSeed:                cfg.DevSeed,
+		PriorityLoginEvictIdle: cfg.PriorityLoginEvictIdle,
+		ChatRetention:          cfg.ChatRetention,
+		CurrencyLimits:         cfg.CurrencyLimits,
+		CarryLimits:            cfg.CarryLimits,
+		LockoutReset:           &cfg.LockoutReset,
 	})
 
-	go hub.Run()
-	addr := fmt.Sprintf(":%d", cfg.Port)
+	err = srv.ListenAndServe()
 
-	log.Printf("Starting server on %s", addr)
-
-	// cfg.CertPath = resolveLiveCertsPath(cfg.CertPath)
-	// cfg.KeyPath = resolveLiveCertsPath(cfg.KeyPath)
-
-	// log.Printf("Using cert at %s and key at %s", cfg.CertPath, cfg.KeyPath)
-
-	// err = http.ListenAndServeTLS(addr, cfg.CertPath, cfg.KeyPath, nil)
-
-	// if err != nil {
-		log.Println("Starting server without TLS (forced)")
-		err = http.ListenAndServe(addr, nil)
-		if err != nil {
-			log.Fatalf("Failed to start server without TLS: %v", err)
-		// }
+	// Leave it to the orchestrator to start the server again after a restart
+	if errors.Is(err, mmoserver.ErrRestart) {
+		log.Printf("Exiting for restart with code %d", mmoserver.RestartExitCode)
+		os.Exit(mmoserver.RestartExitCode)
 	}
-}
-
-// Add headers required for the HTML5 export to work with threads
-func addHeaders(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
-		w.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
-		next.ServeHTTP(w, r)
-	})
+	log.Fatalf("Failed to start server: %v", err)
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// How short a token or secret can be in a strict profile before the server refuses to start with it
+const minStrictSecretLength = 32
+
+// Defaults for where the server's deployed, picked with -profile or PROFILE, so settings only meant for development
+// don't end up in production by being left in a config file. Anything set in the environment still overrides them,
+// but strict profiles refuse to start with anything only meant for development turned on.
+type profile struct {
+	LogLevels string
+	Budgets   string
+
+	// Whether browsers can only connect from pages served by the server or from ALLOWED_ORIGINS
+	CheckOrigin bool
+
+	// The protocol inspector, the JSON debug transport, failure injection and profiles
+	DebugProtocol    bool
+	DebugJson        bool
+	Chaos            bool
+	DebugDiagnostics bool
+
+	// Whether DEV_SEED can fill the database with accounts anyone knows the password to, and tokens and secrets can be
+	// short enough to guess, like they usually are in development
+	allowDevSeed     bool
+	allowWeakSecrets bool
+
+	// Whether problems found by check stop the server starting, rather than only being warned about
+	strict bool
+}
+
+var profiles = map[string]*profile{
+	"dev": {
+		LogLevels:        "hub=debug,clients=debug,db=debug,states=debug,admin=debug",
+		DebugProtocol:    true,
+		DebugJson:        true,
+		Chaos:            true,
+		DebugDiagnostics: true,
+		allowDevSeed:     true,
+		allowWeakSecrets: true,
+	},
+	"staging": {
+		Budgets:     "chat=50/s:drop,spore_drops=100/s",
+		CheckOrigin: true,
+		Chaos:       true,
+
+		allowDevSeed: true,
+	},
+	"prod": {
+		LogLevels:   "clients=error,states=error",
+		Budgets:     "chat=50/s:drop,spore_drops=100/s",
+		CheckOrigin: true,
+		strict:      true,
+	},
+}
+
+func profileNames() string {
+	return strings.Join(slices.Sorted(maps.Keys(profiles)), ", ")
+}
+
+// Set the profile's defaults in the config, before anything in the environment overrides them
+func (p *profile) apply(cfg *config) {
+	cfg.LogLevels = p.LogLevels
+	cfg.Budgets = p.Budgets
+	cfg.CheckOrigin = p.CheckOrigin
+	cfg.DebugProtocol = p.DebugProtocol
+	cfg.DebugJson = p.DebugJson
+	cfg.Chaos = p.Chaos
+	cfg.DebugDiagnostics = p.DebugDiagnostics
+}
+
+// What's wrong with running the config under the profile: anything only meant for development, and tokens and
+// secrets short enough to guess
+func (p *profile) check(cfg *config) []string {
+	var problems []string
+	if cfg.DebugProtocol && !p.DebugProtocol {
+		problems = append(problems, "DEBUG_PROTOCOL is on, so anyone can see what players send")
+	}
+	if cfg.DebugJson && !p.DebugJson {
+		problems = append(problems, "DEBUG_JSON is on, so any site can connect over the JSON debug transport")
+	}
+	if cfg.Chaos && !p.Chaos {
+		problems = append(problems, "CHAOS is on, so failures can be injected")
+	}
+	if cfg.DebugDiagnostics && !p.DebugDiagnostics {
+		problems = append(problems, "DEBUG_DIAGNOSTICS is on, so anyone can profile the server")
+	}
+	if cfg.DebugOverlaySecret != "" && p.strict {
+		problems = append(problems, "DEBUG_OVERLAY_SECRET is set, so whoever has it can follow any player around")
+	}
+	if cfg.DevSeed != "" && !p.allowDevSeed {
+		problems = append(problems, "DEV_SEED is set, so there are accounts with a known password")
+	}
+	if !cfg.CheckOrigin && p.CheckOrigin {
+		problems = append(problems, "CHECK_ORIGIN is off, so any site can connect as its visitors")
+	}
+
+	if p.allowWeakSecrets {
+		return problems
+	}
+
+	secrets := []struct {
+		name  string
+		value string
+	}{
+		{"ADMIN_TOKEN", cfg.AdminToken},
+		{"ADMIN_ELEVATED_TOKEN", cfg.AdminElevatedToken},
+		{"MATCHMAKER_TOKEN", cfg.MatchmakerToken},
+		{"SIMULATOR_SECRET", cfg.SimulatorSecret},
+		{"DEBUG_OVERLAY_SECRET", cfg.DebugOverlaySecret},
+		{"PRESENCE_SECRET", cfg.PresenceSecret},
+		{"TURN_SECRET", cfg.VoiceRelay.TurnSecret},
+	}
+	for _, secret := range secrets {
+		if secret.value != "" && len(secret.value) < minStrictSecretLength {
+			problems = append(problems, fmt.Sprintf("%s is shorter than %d characters", secret.name, minStrictSecretLength))
+		}
+	}
+	if cfg.AdminElevatedToken != "" && cfg.AdminElevatedToken == cfg.AdminToken {
+		problems = append(problems, "ADMIN_ELEVATED_TOKEN is the same as ADMIN_TOKEN, so it isn't any harder to get")
+	}
+
+	return problems
+}
+
+// Refuse to start if a strict profile has problems with the config, or warn about them otherwise
+func (p *profile) enforce(name string, cfg *config) {
+	problems := p.check(cfg)
+	if len(problems) == 0 {
+		return
+	}
+	if p.strict {
+		log.Fatalf("Refusing to start with the %s profile: %s", name, strings.Join(problems, "; "))
+	}
+	for _, problem := range problems {
+		log.Printf("Warning for the %s profile: %s", name, problem)
+	}
+}
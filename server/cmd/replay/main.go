@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"server/internal/server"
+	"server/internal/server/clients"
+	"server/internal/server/objects"
+)
+
+var dataPath = flag.String("data", ".", "Path to a copy of the data directory as it was when recording started")
+
+const usage = `Usage: replay [-data .] <recording>
+
+Plays a recording made by a server run with DETERMINISTIC=true and RECORD_PATH set over again, checking the world
+evolves exactly as it did after every tick. Exits with 1 at the first tick it doesn't. The data directory is copied
+first, so it's left as it was for the next replay.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	recording, err := server.LoadRecording(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Error loading recording: %v", err)
+	}
+
+	replayPath, err := os.MkdirTemp("", "replay")
+	if err != nil {
+		log.Fatalf("Error making directory to replay in: %v", err)
+	}
+	defer os.RemoveAll(replayPath)
+	if err := copyData(*dataPath, replayPath); err != nil {
+		log.Fatalf("Error copying data directory: %v", err)
+	}
+
+	ids, err := objects.NewIdGenerator(recording.IdNode)
+	if err != nil {
+		log.Fatalf("Error setting up IDs: %v", err)
+	}
+	objects.Ids = ids
+
+	hub := server.NewHub(replayPath)
+	hub.Simulation.ConfigureReplay(recording, func() server.ClientInterfacer { return clients.NewHeadlessClient(hub) })
+	go hub.Run()
+
+	err = <-hub.Simulation.Replayed()
+	var divergence *server.ReplayDivergence
+	if errors.As(err, &divergence) {
+		log.Printf("Replay %v", divergence)
+		os.RemoveAll(replayPath)
+		os.Exit(1)
+	} else if err != nil {
+		log.Fatalf("Error replaying: %v", err)
+	}
+	log.Printf("Replayed %d ticks, all the same as recorded", len(recording.Ticks))
+}
+
+// Copy the files in the data directory, not anything in directories inside it
+func copyData(from string, to string) error {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err := copyFile(path.Join(from, entry.Name()), path.Join(to, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(from string, to string) error {
+	source, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	destination, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(destination, source); err != nil {
+		destination.Close()
+		return err
+	}
+	return destination.Close()
+}
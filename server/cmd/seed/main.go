@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+
+	"github.com/joho/godotenv"
+	_ "modernc.org/sqlite"
+)
+
+var (
+	configPath = flag.String("config", ".env", "Path to the config file")
+	accounts   = flag.Int("accounts", 100, "How many accounts there should be")
+	characters = flag.Int("characters", 1, "How many characters each account should have")
+	mail       = flag.Int("mail", 0, "How much mail each character should have")
+	password   = flag.String("password", server.DefaultSeedPassword, "Password to log in to every seeded account with")
+)
+
+const usage = `Usage: seed [-config .env] [-accounts 100] [-characters 1] [-mail 0] [-password password]
+
+Fills the database in DATA_PATH with fake accounts named seed1, seed2 and so on, their characters and mail, for load
+tests and developing the client against. Running it again tops every account up to the counts given rather than
+making more, so it's safe to run after changing the schema. Never run it against a database real players use.
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(*configPath); err != nil {
+		log.Printf("Error loading config file, using environment only: %v", err)
+	}
+
+	dataPath := os.Getenv("DATA_PATH")
+	if dataPath == "" {
+		dataPath = "."
+	}
+
+	gameData, err := gamedata.Load(dataPath)
+	if err != nil {
+		log.Fatalf("Error loading game data: %v", err)
+	}
+
+	dbPool, err := sql.Open("sqlite", path.Join(dataPath, "db.sqlite"))
+	if err != nil {
+		log.Fatalf("Error opening database: %v", err)
+	}
+	defer dbPool.Close()
+
+	ctx := context.Background()
+	if _, err := dbPool.ExecContext(ctx, db.SchemaSql); err != nil {
+		log.Fatalf("Error initializing database: %v", err)
+	}
+
+	queries := db.New(dbPool)
+	inTx := func(fn func(queries *db.Queries) error) error {
+		tx, err := dbPool.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if err := fn(queries.WithTx(tx)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+
+	result, err := server.Seed(ctx, inTx, gameData, server.SeedOptions{
+		Accounts:   *accounts,
+		Characters: *characters,
+		Mail:       *mail,
+		Password:   *password,
+	})
+	if err != nil {
+		log.Fatalf("Error seeding: %v", err)
+	}
+	log.Printf("Seeded %d accounts, %d characters and %d mail", result.Accounts, result.Characters, result.Mail)
+}
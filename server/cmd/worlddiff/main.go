@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"server/internal/server"
+	"slices"
+	"strings"
+)
+
+var (
+	tolerance = flag.Float64("tolerance", 0, "How far apart numbers can be and still be the same")
+	common    = flag.Bool("common", false, "Only compare objects in both dumps, e.g. when one is what a client can see")
+	token     = flag.String("token", os.Getenv("ADMIN_TOKEN"), "Admin token for fetching dumps from a live server")
+)
+
+const usage = `Usage: worlddiff [-tolerance 0] [-common] [-token $ADMIN_TOKEN] <first> <second>
+
+Diffs two dumps of the world, to pinpoint which objects and fields a desync is in. Each can be a file, - for stdin,
+or the URL of a live server's admin API, e.g. http://localhost:8080/admin/api/world, to dump it now. Either dump can
+be partial, like what a client thinks the world is, in which case fields it leaves out aren't compared but objects it
+leaves out are, unless -common. Exits with 1 if the dumps differ.
+
+Flags:
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	first, err := readDump(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", flag.Arg(0), err)
+	}
+	second, err := readDump(flag.Arg(1))
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", flag.Arg(1), err)
+	}
+
+	diffs, err := server.DiffWorldDumps(first, second, *tolerance)
+	if err != nil {
+		log.Fatalf("Error diffing: %v", err)
+	}
+	if *common {
+		diffs = slices.DeleteFunc(diffs, server.WorldDiff.Missing)
+	}
+	for _, diff := range diffs {
+		fmt.Println(diff)
+	}
+	if len(diffs) > 0 {
+		log.Printf("%d differences", len(diffs))
+		os.Exit(1)
+	}
+	log.Println("No differences")
+}
+
+func readDump(source string) ([]byte, error) {
+	if source == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+*token)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded %s", response.Status)
+	}
+	return io.ReadAll(response.Body)
+}
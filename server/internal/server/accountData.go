@@ -0,0 +1,882 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"time"
+)
+
+const (
+	// How often queued exports and due deletions are looked for, besides straight after an export is requested
+	accountDataInterval = 10 * time.Second
+
+	// The most accounts deleted in one go, so a backlog doesn't hold up exports
+	accountDeletionBatchSize = 20
+
+	// Where exports are downloaded from, followed by their token
+	DataExportPath = "/api/account/export/"
+
+	DataExportQueued  = "queued"
+	DataExportReady   = "ready"
+	DataExportExpired = "expired"
+
+	DefaultAccountDeletionDelay = 14 * 24 * time.Hour
+	DefaultDataExportTtl        = 7 * 24 * time.Hour
+)
+
+var (
+	ErrDataExportPending      = errors.New("an export is already being prepared")
+	ErrAccountDeletionPending = errors.New("account already due to be deleted")
+)
+
+// Where a player's requests for their data stand
+type AccountDataStatus struct {
+	// Of the player's latest export, if they've ever asked for one
+	ExportStatus      string
+	ExportUrl         string
+	ExportRequestedAt time.Time
+	ExportExpiresAt   time.Time
+
+	// Zero if the account isn't going to be deleted
+	DeletionAt time.Time
+}
+
+// Handles players' requests for a copy of everything kept about them, and for their accounts to be deleted. Exports
+// are put together in the background and kept for a while to be downloaded with an unguessable link, which the player
+// is mailed once it's ready. Deletions wait out a cooling-off period in which the player can change their mind, then
+// the account is anonymized: everything personal is removed, but the rows other players' history points at, like the
+// player on the other end of an auction, stay with the name replaced.
+type AccountData struct {
+	hub *Hub
+
+	deletionDelay time.Duration
+	exportTtl     time.Duration
+
+	wake chan struct{}
+}
+
+func NewAccountData(hub *Hub) *AccountData {
+	return &AccountData{
+		hub:           hub,
+		deletionDelay: DefaultAccountDeletionDelay,
+		exportTtl:     DefaultDataExportTtl,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// Must be called before the hub is run. Durations left at 0 keep their defaults.
+func (a *AccountData) Configure(deletionDelay time.Duration, exportTtl time.Duration) {
+	if deletionDelay > 0 {
+		a.deletionDelay = deletionDelay
+	}
+	if exportTtl > 0 {
+		a.exportTtl = exportTtl
+	}
+}
+
+func (a *AccountData) Status(playerDbId int64) (AccountDataStatus, error) {
+	var status AccountDataStatus
+	dbTx := a.hub.NewDbTx()
+
+	export, err := dbTx.Queries.GetLatestDataExport(dbTx.Ctx, playerDbId)
+	if err == nil {
+		status.ExportStatus = export.Status
+		status.ExportRequestedAt = time.UnixMilli(export.RequestedAt)
+		if export.Status == DataExportReady {
+			status.ExportUrl = DataExportPath + export.Token
+			status.ExportExpiresAt = time.UnixMilli(export.ExpiresAt.Int64)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return status, err
+	}
+
+	deletion, err := dbTx.Queries.GetAccountDeletion(dbTx.Ctx, playerDbId)
+	if err == nil && !deletion.DeletedAt.Valid {
+		status.DeletionAt = time.UnixMilli(deletion.DeleteAt)
+	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return status, err
+	}
+	return status, nil
+}
+
+// Queue an export of everything kept about the player
+func (a *AccountData) RequestExport(requestedBy string, playerDbId int64) (db.DataExport, error) {
+	dbTx := a.hub.NewDbTx()
+	latest, err := dbTx.Queries.GetLatestDataExport(dbTx.Ctx, playerDbId)
+	if err == nil && latest.Status == DataExportQueued {
+		return latest, ErrDataExportPending
+	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return db.DataExport{}, err
+	}
+
+	token := make([]byte, 32)
+	rand.Read(token)
+	export, err := dbTx.Queries.CreateDataExport(dbTx.Ctx, db.CreateDataExportParams{
+		PlayerID:    playerDbId,
+		RequestedBy: requestedBy,
+		Token:       hex.EncodeToString(token),
+		Status:      DataExportQueued,
+		RequestedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return db.DataExport{}, err
+	}
+
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+	return export, nil
+}
+
+// Schedule the player's account to be deleted once the cooling-off period is over. Returns when that will be.
+func (a *AccountData) RequestDeletion(requestedBy string, playerDbId int64) (time.Time, error) {
+	dbTx := a.hub.NewDbTx()
+	existing, err := dbTx.Queries.GetAccountDeletion(dbTx.Ctx, playerDbId)
+	if err == nil {
+		return time.UnixMilli(existing.DeleteAt), ErrAccountDeletionPending
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, err
+	}
+
+	now := time.Now()
+	deleteAt := now.Add(a.deletionDelay)
+	err = dbTx.Queries.CreateAccountDeletion(dbTx.Ctx, db.CreateAccountDeletionParams{
+		PlayerID:    playerDbId,
+		RequestedBy: requestedBy,
+		RequestedAt: now.UnixMilli(),
+		DeleteAt:    deleteAt.UnixMilli(),
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return deleteAt, nil
+}
+
+// Call off a deletion that hasn't happened yet. Returns false if there wasn't one.
+func (a *AccountData) CancelDeletion(playerDbId int64) (bool, error) {
+	dbTx := a.hub.NewDbTx()
+	cancelled, err := dbTx.Queries.CancelAccountDeletion(dbTx.Ctx, playerDbId)
+	return cancelled > 0, err
+}
+
+// Serve a ready export to whoever has its link
+func (a *AccountData) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	dbTx := a.hub.NewDbTx()
+	export, err := dbTx.Queries.GetDataExportByToken(request.Context(), request.PathValue("token"))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && export.Status == DataExportExpired) {
+		http.Error(writer, "export not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Hub.Errorf("Error getting data export: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if export.Status != DataExportReady || !time.Now().Before(time.UnixMilli(export.ExpiresAt.Int64)) {
+		http.Error(writer, "export not ready", http.StatusNotFound)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="account-export-%d.json"`, export.ID))
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.Write(export.Bundle)
+}
+
+func (a *AccountData) workLoop() {
+	ticker := time.NewTicker(accountDataInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-a.wake:
+		}
+		if !a.hub.dbHealth.Healthy() {
+			continue
+		}
+
+		dbTx := a.hub.NewDbTx()
+		now := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+		if expired, err := dbTx.Queries.ExpireDataExports(dbTx.Ctx, now); err != nil {
+			logging.Hub.Errorf("Error expiring data exports: %v", err)
+		} else if expired > 0 {
+			logging.Hub.Printf("Expired %d data exports", expired)
+		}
+
+		for {
+			exported, err := a.exportNext()
+			if err != nil {
+				logging.Hub.Errorf("Error exporting account data: %v", err)
+				break
+			}
+			if !exported {
+				break
+			}
+		}
+
+		if err := a.deleteDue(); err != nil {
+			logging.Hub.Errorf("Error deleting accounts: %v", err)
+		}
+	}
+}
+
+// Put together the oldest queued export. Returns whether there was one.
+func (a *AccountData) exportNext() (bool, error) {
+	dbTx := a.hub.NewDbTx()
+	export, err := dbTx.Queries.GetNextQueuedDataExport(dbTx.Ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// Anything still held back for the player belongs in the export too
+	a.hub.Saves.FlushPlayer(export.PlayerID)
+
+	bundle, err := a.bundle(dbTx, export.PlayerID)
+	if err != nil {
+		return false, fmt.Errorf("error putting together export %d: %w", export.ID, err)
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	err = dbTx.InTx(func(queries *db.Queries) error {
+		err := queries.CompleteDataExport(dbTx.Ctx, db.CompleteDataExportParams{
+			Bundle:    data,
+			ReadyAt:   sql.NullInt64{Int64: now.UnixMilli(), Valid: true},
+			ExpiresAt: sql.NullInt64{Int64: now.Add(a.exportTtl).UnixMilli(), Valid: true},
+			ID:        export.ID,
+		})
+		if err != nil {
+			return err
+		}
+		return SendMail(dbTx.Ctx, queries, export.PlayerID, "Support", "Your account data export is ready to download", "", 0)
+	})
+	if err != nil {
+		return false, fmt.Errorf("error completing export %d: %w", export.ID, err)
+	}
+
+	logging.Hub.Printf("Exported data of player %d (%d bytes)", export.PlayerID, len(data))
+	a.hub.NotifyMail(export.PlayerID)
+	a.notify(export.PlayerID)
+	return true, nil
+}
+
+// Delete the accounts whose cooling-off period is over
+func (a *AccountData) deleteDue() error {
+	dbTx := a.hub.NewDbTx()
+	due, err := dbTx.Queries.GetDueAccountDeletions(dbTx.Ctx, db.GetDueAccountDeletionsParams{
+		DeleteAt: time.Now().UnixMilli(),
+		Limit:    accountDeletionBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, deletion := range due {
+		// Players still in game are sent off first, and deleted next time round once they're gone, so nothing they
+		// were doing gets saved over the anonymized account
+		if client, online := a.hub.ClientByPlayerDbId(deletion.PlayerID); online {
+			client.SocketSend(packets.NewDenyResponse("Your account is being deleted"))
+			go client.Kick(KickAccountDeleted, "Account deleted")
+			continue
+		}
+		if err := a.delete(deletion); err != nil {
+			logging.Hub.Errorf("Error deleting account of player %d: %v", deletion.PlayerID, err)
+		}
+	}
+	return nil
+}
+
+// Anonymize the account, keeping its rows so nothing else pointing at it breaks
+func (a *AccountData) delete(deletion db.AccountDeletion) error {
+	a.hub.Saves.FlushPlayer(deletion.PlayerID)
+
+	dbTx := a.hub.NewDbTx()
+	player, err := dbTx.Queries.GetPlayer(dbTx.Ctx, deletion.PlayerID)
+	if err != nil {
+		return err
+	}
+
+	// Longer than anyone can register, so the placeholder can't already be taken
+	placeholder := fmt.Sprintf("deleted-account-%08d", player.ID)
+	playerId := player.ID
+	err = dbTx.InTx(func(queries *db.Queries) error {
+		ctx := dbTx.Ctx
+		steps := []func() error{
+			func() error {
+				return queries.AnonymizeUser(ctx, db.AnonymizeUserParams{Username: placeholder, ID: player.UserID})
+			},
+			func() error {
+				return queries.AnonymizePlayer(ctx, db.AnonymizePlayerParams{Name: placeholder, ID: playerId})
+			},
+			func() error { return queries.DeletePlayerInventory(ctx, playerId) },
+			func() error {
+				return queries.DeletePlayerBlocks(ctx, db.DeletePlayerBlocksParams{PlayerID: playerId, BlockedPlayerID: playerId})
+			},
+			func() error { return queries.DeletePlayerOnboardingSteps(ctx, playerId) },
+			func() error { return queries.DeletePlayerQuestStages(ctx, playerId) },
+			func() error { return queries.DeletePlayerEmotes(ctx, playerId) },
+			func() error { return queries.DeletePlayerMail(ctx, playerId) },
+			func() error { return queries.DeletePlayerLogin(ctx, playerId) },
+			func() error { return queries.DeletePlayerPosition(ctx, playerId) },
+			func() error { _, err := queries.DeleteVoiceMute(ctx, playerId); return err },
+			func() error { return queries.DeletePlayerDataExports(ctx, playerId) },
+			func() error { return queries.DeletePlayerChatMessages(ctx, playerId) },
+			func() error { return queries.DeletePlayerCurrencyTransfers(ctx, playerId) },
+			func() error { return queries.DeletePlayerBossLockouts(ctx, playerId) },
+			func() error { return queries.DeleteUserLoginIps(ctx, player.UserID) },
+			func() error { return queries.DeleteUserSessions(ctx, player.UserID) },
+			func() error { return queries.DeleteUserEntitlements(ctx, player.UserID) },
+			func() error { return queries.DeleteUserWelcomeAcceptances(ctx, player.UserID) },
+			func() error { return queries.DeleteUserReleasedNames(ctx, player.UserID) },
+
+			// Cases stay for the moderators, with the player's name and what they said taken out
+			func() error {
+				return queries.AnonymizeModerationCaseReporter(ctx, db.AnonymizeModerationCaseReporterParams{ReporterName: placeholder, ReporterID: playerId})
+			},
+			func() error {
+				return queries.AnonymizeModerationCaseReported(ctx, db.AnonymizeModerationCaseReportedParams{ReportedName: placeholder, ReportedID: playerId})
+			},
+			func() error { return anonymizeModerationSnapshots(ctx, queries, player, placeholder) },
+
+			// Receipts stay as a record of the purchases, but can't be checked any more once they're hashed
+			func() error {
+				return queries.RejectPendingStoreReceipts(ctx, db.RejectPendingStoreReceiptsParams{
+					Reason:     "Account deleted",
+					ResolvedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+					PlayerID:   playerId,
+				})
+			},
+			func() error { return hashStoreReceipts(ctx, queries, playerId) },
+
+			// Sold listings stay for the buyer's history; the rest only ever mattered to the seller
+			func() error { return queries.DeleteUnsoldAuctionListings(ctx, playerId) },
+
+			func() error {
+				deletedAt := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+				return queries.FinishAccountDeletion(ctx, db.FinishAccountDeletionParams{DeletedAt: deletedAt, PlayerID: playerId})
+			},
+			func() error {
+				return dbTx.RecordEvent(queries, "account.deleted", map[string]any{
+					"player_id": playerId,
+					"user_id":   player.UserID,
+				})
+			},
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The name isn't kept in the audit log either, only which account it was
+	a.hub.Audit(deletion.RequestedBy, "account.deleted", fmt.Sprintf("player %d", playerId), "")
+	return nil
+}
+
+// Take the player out of every case snapshot that mentions them, whether as the one reported or in the chat around
+// them
+func anonymizeModerationSnapshots(ctx context.Context, queries *db.Queries, player db.Player, placeholder string) error {
+	cases, err := queries.GetModerationCasesMentioning(ctx, player.Name)
+	if err != nil {
+		return err
+	}
+	for _, c := range cases {
+		var snapshot ModerationSnapshot
+		if err := json.Unmarshal([]byte(c.Snapshot), &snapshot); err != nil {
+			return fmt.Errorf("error reading snapshot of case %d: %w", c.ID, err)
+		}
+		if snapshot.DbId == player.ID {
+			snapshot.Name = placeholder
+		}
+		for i, line := range snapshot.Chat {
+			if line.From == player.Name {
+				snapshot.Chat[i].From = placeholder
+				snapshot.Chat[i].Message = ""
+			}
+		}
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		err = queries.SetModerationCaseSnapshot(ctx, db.SetModerationCaseSnapshotParams{Snapshot: string(data), ID: c.ID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hashStoreReceipts(ctx context.Context, queries *db.Queries, playerDbId int64) error {
+	receipts, err := queries.GetPlayerStoreReceipts(ctx, playerDbId)
+	if err != nil {
+		return err
+	}
+	for _, receipt := range receipts {
+		err := queries.SetStoreReceipt(ctx, db.SetStoreReceiptParams{Receipt: hashReceipt(receipt.Receipt), ID: receipt.ID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Have the player ask for their status again, if they're online
+func (a *AccountData) notify(playerDbId int64) {
+	if client, online := a.hub.ClientByPlayerDbId(playerDbId); online {
+		client.ProcessMessage(0, packets.NewAccountDataStatusRequest())
+	}
+}
+
+type accountExport struct {
+	ExportedAt time.Time `json:"exported_at"`
+
+	Username    string     `json:"username"`
+	PlayerName  string     `json:"player_name"`
+	BestScore   int64      `json:"best_score"`
+	Color       int64      `json:"color"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	Position    *struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	} `json:"last_position,omitempty"`
+
+	Inventory       map[string]int64       `json:"inventory"`
+	BlockedPlayers  []string               `json:"blocked_players"`
+	OnboardingSteps []string               `json:"onboarding_steps"`
+	QuestStages     map[string]int64       `json:"quest_stages"`
+	Emotes          []string               `json:"emotes"`
+	Mail            []mailExport           `json:"mail"`
+	AuctionListings []auctionListingExport `json:"auction_listings"`
+	Bans            []banExport            `json:"bans"`
+	VoiceMute       *voiceMuteExport       `json:"voice_mute,omitempty"`
+	ChatMessages    []chatMessageExport    `json:"chat_messages"`
+	CoinTransfers   []coinTransferExport   `json:"coin_transfers"`
+	BossLockouts    []bossLockoutExport    `json:"boss_lockouts"`
+	LoginIps        []loginIpExport        `json:"login_ips"`
+	Sessions        []sessionExport        `json:"sessions"`
+	ModerationCases []moderationCaseExport `json:"moderation_cases"`
+	StoreReceipts   []storeReceiptExport   `json:"store_receipts"`
+	Entitlements    []entitlementExport    `json:"entitlements"`
+	Welcome         []welcomeExport        `json:"welcome_acceptances"`
+	ReleasedNames   []string               `json:"released_names"`
+}
+
+type mailExport struct {
+	Sender   string    `json:"sender"`
+	Subject  string    `json:"subject"`
+	ItemId   string    `json:"item_id,omitempty"`
+	Quantity int64     `json:"quantity,omitempty"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+type auctionListingExport struct {
+	// Whether the player was the one "selling" or "buying"
+	Role      string     `json:"role"`
+	ItemId    string     `json:"item_id"`
+	Quantity  int64      `json:"quantity"`
+	Price     int64      `json:"price"`
+	ListedAt  time.Time  `json:"listed_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	SoldAt    *time.Time `json:"sold_at,omitempty"`
+}
+
+type banExport struct {
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type voiceMuteExport struct {
+	Reason    string     `json:"reason"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type chatMessageExport struct {
+	ZoneId  string    `json:"zone_id"`
+	Channel int64     `json:"channel"`
+	Message string    `json:"message"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+type coinTransferExport struct {
+	Kind    string `json:"kind"`
+	Amount  int64  `json:"amount"`
+	Refused bool   `json:"refused,omitempty"`
+	// Why the transfer was looked into, if it was
+	Flag      string    `json:"flag,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type bossLockoutExport struct {
+	BossId   string    `json:"boss_id"`
+	LootedAt time.Time `json:"looted_at"`
+}
+
+// Only a hash of each address is kept
+type loginIpExport struct {
+	IpHash      string    `json:"ip_hash"`
+	LastLoginAt time.Time `json:"last_login_at"`
+}
+
+// Only the network each IP's on is kept
+type sessionExport struct {
+	IpNetwork   string     `json:"ip_network"`
+	Device      string     `json:"device"`
+	ClientBuild int64      `json:"client_build"`
+	LoggedInAt  time.Time  `json:"logged_in_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+}
+
+// Who reported the player is left out
+type moderationCaseExport struct {
+	// Whether the player was the one "reporting" or "reported"
+	Role         string     `json:"role"`
+	ReportedName string     `json:"reported_name,omitempty"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+}
+
+type storeReceiptExport struct {
+	EntryId    string     `json:"entry_id"`
+	Platform   string     `json:"platform"`
+	Receipt    string     `json:"receipt"`
+	Status     string     `json:"status"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+type entitlementExport struct {
+	EntitlementId string    `json:"entitlement_id"`
+	Source        string    `json:"source"`
+	GrantedAt     time.Time `json:"granted_at"`
+}
+
+// Every version of each step accepted
+type welcomeExport struct {
+	StepId     string    `json:"step_id"`
+	Version    int64     `json:"version"`
+	AcceptedAt time.Time `json:"accepted_at"`
+}
+
+// Everything kept about the player
+func (a *AccountData) bundle(dbTx *DbTx, playerDbId int64) (*accountExport, error) {
+	ctx, queries := dbTx.Ctx, dbTx.Queries
+	player, err := queries.GetPlayer(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	user, err := queries.GetUser(ctx, player.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &accountExport{
+		ExportedAt:      time.Now(),
+		Username:        user.Username,
+		PlayerName:      player.Name,
+		BestScore:       player.BestScore,
+		Color:           player.Color,
+		Inventory:       make(map[string]int64),
+		BlockedPlayers:  []string{},
+		OnboardingSteps: []string{},
+		QuestStages:     make(map[string]int64),
+		Emotes:          []string{},
+		Mail:            []mailExport{},
+		AuctionListings: []auctionListingExport{},
+		Bans:            []banExport{},
+		ChatMessages:    []chatMessageExport{},
+		CoinTransfers:   []coinTransferExport{},
+		BossLockouts:    []bossLockoutExport{},
+		LoginIps:        []loginIpExport{},
+		Sessions:        []sessionExport{},
+		ModerationCases: []moderationCaseExport{},
+		StoreReceipts:   []storeReceiptExport{},
+		Entitlements:    []entitlementExport{},
+		Welcome:         []welcomeExport{},
+		ReleasedNames:   []string{},
+	}
+
+	if login, err := queries.GetPlayerLogin(ctx, playerDbId); err == nil {
+		lastLoginAt := time.UnixMilli(login.LastLoginAt)
+		export.LastLoginAt = &lastLoginAt
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if position, err := queries.GetPlayerPosition(ctx, playerDbId); err == nil {
+		export.Position = &struct {
+			X float64 `json:"x"`
+			Y float64 `json:"y"`
+		}{position.X, position.Y}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	items, err := queries.GetInventoryItems(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		export.Inventory[item.ItemID] = item.Quantity
+	}
+
+	blocked, err := queries.GetBlockedPlayers(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range blocked {
+		export.BlockedPlayers = append(export.BlockedPlayers, row.Name)
+	}
+
+	steps, err := queries.GetCompletedOnboardingSteps(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	export.OnboardingSteps = append(export.OnboardingSteps, steps...)
+
+	questStages, err := queries.GetQuestStages(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range questStages {
+		export.QuestStages[row.QuestID] = row.Stage
+	}
+
+	emotes, err := queries.GetUnlockedEmotes(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	export.Emotes = append(export.Emotes, emotes...)
+
+	mail, err := queries.GetMail(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mail {
+		export.Mail = append(export.Mail, mailExport{
+			Sender:   m.Sender,
+			Subject:  m.Subject,
+			ItemId:   m.ItemID,
+			Quantity: m.Quantity,
+			SentAt:   time.UnixMilli(m.SentAt),
+		})
+	}
+
+	listings, err := queries.GetPlayerAuctionListings(ctx, db.GetPlayerAuctionListingsParams{
+		SellerID: playerDbId,
+		BuyerID:  sql.NullInt64{Int64: playerDbId, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, listing := range listings {
+		l := auctionListingExport{
+			Role:      "selling",
+			ItemId:    listing.ItemID,
+			Quantity:  listing.Quantity,
+			Price:     listing.Price,
+			ListedAt:  time.UnixMilli(listing.ListedAt),
+			ExpiresAt: time.UnixMilli(listing.ExpiresAt),
+		}
+		if listing.SellerID != playerDbId {
+			l.Role = "buying"
+		}
+		if listing.SoldAt.Valid {
+			soldAt := time.UnixMilli(listing.SoldAt.Int64)
+			l.SoldAt = &soldAt
+		}
+		export.AuctionListings = append(export.AuctionListings, l)
+	}
+
+	// Bans are kept in seconds
+	bans, err := queries.GetBansByUserId(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ban := range bans {
+		b := banExport{Reason: ban.Reason, CreatedAt: time.Unix(ban.CreatedAt, 0)}
+		if ban.ExpiresAt.Valid {
+			expiresAt := time.Unix(ban.ExpiresAt.Int64, 0)
+			b.ExpiresAt = &expiresAt
+		}
+		export.Bans = append(export.Bans, b)
+	}
+
+	if mute, err := queries.GetVoiceMute(ctx, playerDbId); err == nil {
+		m := &voiceMuteExport{Reason: mute.Reason, CreatedAt: time.UnixMilli(mute.CreatedAt)}
+		if mute.ExpiresAt > 0 {
+			expiresAt := time.UnixMilli(mute.ExpiresAt)
+			m.ExpiresAt = &expiresAt
+		}
+		export.VoiceMute = m
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	messages, err := queries.GetPlayerChatMessages(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range messages {
+		export.ChatMessages = append(export.ChatMessages, chatMessageExport{
+			ZoneId:  m.ZoneID,
+			Channel: m.Channel,
+			Message: m.Message,
+			SentAt:  time.UnixMilli(m.CreatedAt),
+		})
+	}
+
+	transfers, err := queries.GetPlayerCurrencyTransfers(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transfers {
+		export.CoinTransfers = append(export.CoinTransfers, coinTransferExport{
+			Kind:      t.Kind,
+			Amount:    t.Amount,
+			Refused:   t.Refused,
+			Flag:      t.Flag,
+			CreatedAt: time.UnixMilli(t.CreatedAt),
+		})
+	}
+
+	lockouts, err := queries.GetBossLockouts(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lockouts {
+		export.BossLockouts = append(export.BossLockouts, bossLockoutExport{
+			BossId:   l.BossID,
+			LootedAt: time.UnixMilli(l.LootedAt),
+		})
+	}
+
+	ips, err := queries.GetUserLoginIps(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		export.LoginIps = append(export.LoginIps, loginIpExport{
+			IpHash:      ip.IpHash,
+			LastLoginAt: time.UnixMilli(ip.LastLoginAt),
+		})
+	}
+
+	sessions, err := queries.GetUserSessions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		s := sessionExport{
+			IpNetwork:   session.Ip,
+			Device:      session.Device,
+			ClientBuild: session.ClientBuild,
+			LoggedInAt:  time.UnixMilli(session.LoggedInAt),
+		}
+		if session.EndedAt.Valid {
+			endedAt := time.UnixMilli(session.EndedAt.Int64)
+			s.EndedAt = &endedAt
+		}
+		export.Sessions = append(export.Sessions, s)
+	}
+
+	cases, err := queries.GetPlayerModerationCases(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cases {
+		m := moderationCaseExport{
+			Role:         "reporting",
+			ReportedName: c.ReportedName,
+			Reason:       c.Reason,
+			Status:       c.Status,
+			CreatedAt:    time.UnixMilli(c.CreatedAt),
+		}
+		if c.ReporterID != playerDbId {
+			m.Role = "reported"
+			m.ReportedName = ""
+		}
+		if c.ClosedAt.Valid {
+			closedAt := time.UnixMilli(c.ClosedAt.Int64)
+			m.ClosedAt = &closedAt
+		}
+		export.ModerationCases = append(export.ModerationCases, m)
+	}
+
+	receipts, err := queries.GetPlayerStoreReceipts(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	for _, receipt := range receipts {
+		r := storeReceiptExport{
+			EntryId:   receipt.EntryID,
+			Platform:  receipt.Platform,
+			Receipt:   receipt.Receipt,
+			Status:    receipt.Status,
+			Reason:    receipt.Reason,
+			CreatedAt: time.UnixMilli(receipt.CreatedAt),
+		}
+		if receipt.ResolvedAt.Valid {
+			resolvedAt := time.UnixMilli(receipt.ResolvedAt.Int64)
+			r.ResolvedAt = &resolvedAt
+		}
+		export.StoreReceipts = append(export.StoreReceipts, r)
+	}
+
+	// Entitlements are kept in seconds
+	entitlements, err := queries.GetEntitlements(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entitlements {
+		export.Entitlements = append(export.Entitlements, entitlementExport{
+			EntitlementId: e.EntitlementID,
+			Source:        e.Source,
+			GrantedAt:     time.Unix(e.GrantedAt, 0),
+		})
+	}
+
+	acceptances, err := queries.GetUserWelcomeAcceptances(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range acceptances {
+		export.Welcome = append(export.Welcome, welcomeExport{
+			StepId:     a.StepID,
+			Version:    a.Version,
+			AcceptedAt: time.UnixMilli(a.AcceptedAt),
+		})
+	}
+
+	releasedNames, err := queries.GetUserReleasedNames(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	export.ReleasedNames = append(export.ReleasedNames, releasedNames...)
+
+	return export, nil
+}
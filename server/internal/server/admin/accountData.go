@@ -0,0 +1,144 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"time"
+)
+
+type accountDataRequest struct {
+	// Who is asking on the player's behalf, for the audit log
+	Admin string `json:"admin"`
+}
+
+type accountDataStatus struct {
+	ExportStatus      string     `json:"export_status,omitempty"`
+	ExportUrl         string     `json:"export_url,omitempty"`
+	ExportRequestedAt *time.Time `json:"export_requested_at,omitempty"`
+	ExportExpiresAt   *time.Time `json:"export_expires_at,omitempty"`
+	DeletionAt        *time.Time `json:"deletion_at,omitempty"`
+}
+
+func (a *Api) getAccountData(writer http.ResponseWriter, request *http.Request) {
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	status, err := a.hub.AccountData.Status(player.ID)
+	if err != nil {
+		logging.Admin.Errorf("Error getting account data status of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := accountDataStatus{ExportStatus: status.ExportStatus, ExportUrl: status.ExportUrl}
+	if !status.ExportRequestedAt.IsZero() {
+		result.ExportRequestedAt = &status.ExportRequestedAt
+	}
+	if !status.ExportExpiresAt.IsZero() {
+		result.ExportExpiresAt = &status.ExportExpiresAt
+	}
+	if !status.DeletionAt.IsZero() {
+		result.DeletionAt = &status.DeletionAt
+	}
+	writeJson(writer, result)
+}
+
+// Export a player's data for them, e.g. when they've asked through support. The link is mailed to the player as usual.
+func (a *Api) requestDataExport(writer http.ResponseWriter, request *http.Request) {
+	var body accountDataRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	export, err := a.hub.AccountData.RequestExport(body.Admin, player.ID)
+	if errors.Is(err, server.ErrDataExportPending) {
+		http.Error(writer, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error requesting data export of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "account.export_requested", player.Name, fmt.Sprintf("export %d", export.ID))
+	writer.WriteHeader(http.StatusAccepted)
+}
+
+// Schedule a player's account to be deleted once the cooling-off period is over
+func (a *Api) requestAccountDeletion(writer http.ResponseWriter, request *http.Request) {
+	var body accountDataRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	deleteAt, err := a.hub.AccountData.RequestDeletion(body.Admin, player.ID)
+	if errors.Is(err, server.ErrAccountDeletionPending) {
+		http.Error(writer, err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error requesting deletion of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "account.deletion_requested", player.Name, "at "+deleteAt.Format(time.RFC3339))
+	writer.WriteHeader(http.StatusAccepted)
+}
+
+// Query parameters: admin (who is cancelling it, for the audit log)
+func (a *Api) cancelAccountDeletion(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	cancelled, err := a.hub.AccountData.CancelDeletion(player.ID)
+	if err != nil {
+		logging.Admin.Errorf("Error cancelling deletion of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !cancelled {
+		http.Error(writer, fmt.Sprintf("%s is not due to be deleted", player.Name), http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "account.deletion_cancelled", player.Name, "")
+	writer.WriteHeader(http.StatusNoContent)
+}
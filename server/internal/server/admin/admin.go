@@ -0,0 +1,235 @@
+package admin
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed dashboard.html
+var dashboardHtml []byte
+
+// The HTTP API for operating the live server, along with a dashboard page that uses it. Every API request must carry
+// the admin token or the elevated token as a bearer token.
+type Api struct {
+	hub           *server.Hub
+	token         string
+	elevatedToken string
+	mux           *http.ServeMux
+}
+
+func NewApi(hub *server.Hub, token string, elevatedToken string) *Api {
+	a := &Api{
+		hub:           hub,
+		token:         token,
+		elevatedToken: elevatedToken,
+		mux:           http.NewServeMux(),
+	}
+
+	a.mux.HandleFunc("GET /admin/{$}", a.serveDashboard)
+	a.handle("GET /admin/api/heatmap/{zone}", a.getHeatmap)
+	a.handle("GET /admin/api/heatmap/{zone}/history", a.getHeatmapHistory)
+	a.handle("GET /admin/api/fanout", a.getFanout)
+	a.handle("GET /admin/api/world", a.getWorld)
+	a.handle("GET /admin/api/world/stats", a.getWorldStats)
+	a.handle("POST /admin/api/world/diff", a.diffWorld)
+	a.handle("POST /admin/api/players/{name}/emotes/{emote}", a.grantEmote)
+	a.handle("GET /admin/api/players/{name}/entitlements", a.listEntitlements)
+	a.handle("PUT /admin/api/players/{name}/entitlements/{id}", a.grantEntitlement)
+	a.handle("DELETE /admin/api/players/{name}/entitlements/{id}", a.revokeEntitlement)
+	a.handle("POST /admin/api/players/{name}/impersonate", a.requestImpersonation)
+	a.handle("GET /admin/api/impersonations", a.listImpersonations)
+	a.handle("GET /admin/api/impersonations/{id}", a.getImpersonation)
+	a.handle("DELETE /admin/api/impersonations/{id}", a.endImpersonation)
+	a.handle("GET /admin/api/impersonations/{id}/attach", a.attachImpersonation)
+	a.handle("GET /admin/api/audit", a.getAuditLog)
+	a.handle("GET /admin/api/names/reserved", a.listReservedNames)
+	a.handle("POST /admin/api/names/reserved", a.reserveName)
+	a.handle("DELETE /admin/api/names/reserved/{name}", a.releaseReservedName)
+	a.handle("POST /admin/api/players/{name}/rename", a.renamePlayer)
+	a.handle("POST /admin/api/mail/bulk", a.queueBulkMail)
+	a.handle("GET /admin/api/mail/bulk", a.listBulkMail)
+	a.handle("GET /admin/api/mail/bulk/{id}", a.getBulkMail)
+	a.handle("DELETE /admin/api/mail/bulk/{id}", a.cancelBulkMail)
+	a.handle("GET /admin/api/flags", a.listFlags)
+	a.handle("PUT /admin/api/flags/{id}", a.setFlag)
+	a.handle("DELETE /admin/api/flags/{id}", a.resetFlag)
+	a.handle("PUT /admin/api/players/{name}/voice-mute", a.muteVoice)
+	a.handle("DELETE /admin/api/players/{name}/voice-mute", a.unmuteVoice)
+	a.handle("GET /admin/api/players/{name}/account-data", a.getAccountData)
+	a.handle("POST /admin/api/players/{name}/data-export", a.requestDataExport)
+	a.handle("POST /admin/api/players/{name}/deletion", a.requestAccountDeletion)
+	a.handle("DELETE /admin/api/players/{name}/deletion", a.cancelAccountDeletion)
+	a.handle("GET /admin/api/players/{name}/bandwidth", a.getPlayerBandwidth)
+	a.handle("GET /admin/api/bandwidth", a.getTopBandwidth)
+	a.handle("POST /admin/api/files", a.pushFile)
+	a.handle("GET /admin/api/store/receipts", a.listStoreReceipts)
+	a.handle("POST /admin/api/store/receipts/{id}", a.resolveStoreReceipt)
+	a.handle("GET /admin/api/logging", a.listLogModules)
+	a.handle("PUT /admin/api/logging/{module}", a.setLogModule)
+	a.handle("GET /admin/api/jobs", a.listJobs)
+	a.handle("POST /admin/api/jobs", a.queueJob)
+	a.handle("GET /admin/api/jobs/{id}", a.getJob)
+	a.handle("POST /admin/api/jobs/{id}/retry", a.retryJob)
+	a.handle("GET /admin/api/client-versions", a.getClientVersions)
+	a.handle("PUT /admin/api/client-versions", a.setClientVersions)
+	a.handle("POST /admin/api/environment-cues", a.triggerEnvironmentCue)
+	a.handle("GET /admin/api/emitters", a.listEmitters)
+	a.handle("POST /admin/api/emitters", a.placeEmitter)
+	a.handle("DELETE /admin/api/emitters/{id}", a.removeEmitter)
+	a.handle("GET /admin/api/tags/{tag}", a.queryTag)
+	a.handle("DELETE /admin/api/tags/{tag}", a.despawnTag)
+	a.handle("GET /admin/api/api-keys", a.listApiKeys)
+	a.handle("POST /admin/api/api-keys", a.issueApiKey)
+	a.handle("DELETE /admin/api/api-keys/{id}", a.revokeApiKey)
+	a.handle("POST /admin/api/announcements", a.makeAnnouncement)
+	a.handle("GET /admin/api/moderation/cases", a.listModerationCases)
+	a.handle("GET /admin/api/moderation/cases/{id}", a.getModerationCase)
+	a.handle("POST /admin/api/moderation/cases/{id}/close", a.closeModerationCase)
+	a.handle("GET /admin/api/chat/{zone}/export", a.exportChat)
+	a.handle("GET /admin/api/currency/flags", a.listCurrencyFlags)
+	a.handle("POST /admin/api/consistency/checks", a.queueConsistencyCheck)
+	a.handle("GET /admin/api/consistency/reports", a.listConsistencyReports)
+	a.handle("GET /admin/api/consistency/reports/{id}", a.downloadConsistencyReport)
+	a.handle("GET /admin/api/chaos", a.getChaos)
+	a.handle("POST /admin/api/chaos/drop-clients", a.dropClients)
+	a.handle("POST /admin/api/chaos/stall-db", a.stallDb)
+	a.handle("POST /admin/api/chaos/delay-broadcasts", a.delayBroadcasts)
+	a.handle("DELETE /admin/api/chaos", a.stopChaos)
+
+	return a
+}
+
+func (a *Api) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	a.mux.ServeHTTP(writer, request)
+}
+
+// Register an API endpoint, which is only served to requests with the admin token or the elevated token
+func (a *Api) handle(pattern string, handler http.HandlerFunc) {
+	a.mux.HandleFunc(pattern, func(writer http.ResponseWriter, request *http.Request) {
+		if !tokenMatches(request, a.token) && !a.elevated(request) {
+			http.Error(writer, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler(writer, request)
+	})
+}
+
+// Whether the request carries the elevated token
+func (a *Api) elevated(request *http.Request) bool {
+	return tokenMatches(request, a.elevatedToken)
+}
+
+func tokenMatches(request *http.Request, expected string) bool {
+	token, _ := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+// The dashboard page itself isn't secret, it asks for the token and uses it to call the API
+func (a *Api) serveDashboard(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.Write(dashboardHtml)
+}
+
+func (a *Api) getHeatmap(writer http.ResponseWriter, request *http.Request) {
+	zoneId := request.PathValue("zone")
+	if _, exists := a.hub.GameData.Zone(zoneId); !exists {
+		http.Error(writer, "zone not found", http.StatusNotFound)
+		return
+	}
+
+	heatmap, exists := a.hub.Heatmaps.Latest(zoneId)
+	if !exists {
+		http.Error(writer, "no heatmap yet", http.StatusNotFound)
+		return
+	}
+
+	writeJson(writer, heatmap)
+}
+
+// Query parameters: since (unix milliseconds, default a day ago) and limit (default 100)
+func (a *Api) getHeatmapHistory(writer http.ResponseWriter, request *http.Request) {
+	zone, exists := a.hub.GameData.Zone(request.PathValue("zone"))
+	if !exists {
+		http.Error(writer, "zone not found", http.StatusNotFound)
+		return
+	}
+
+	since := time.Now().Add(-24 * time.Hour)
+	if param := request.URL.Query().Get("since"); param != "" {
+		sinceMillis, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			http.Error(writer, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = time.UnixMilli(sinceMillis)
+	}
+
+	limit := 100
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	heatmaps, err := a.hub.Heatmaps.History(request.Context(), zone, since, limit)
+	if err != nil {
+		logging.Admin.Errorf("Error getting heatmap history of zone %s: %v", zone.Id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(writer, heatmaps)
+}
+
+// Grant an emote to a player, e.g. after they've bought it. If they're in game they can use it straight away.
+func (a *Api) grantEmote(writer http.ResponseWriter, request *http.Request) {
+	emoteId := request.PathValue("emote")
+	if _, exists := a.hub.GameData.Emote(emoteId); !exists {
+		http.Error(writer, "emote not found", http.StatusNotFound)
+		return
+	}
+
+	dbTx := a.hub.NewDbTx()
+	name := request.PathValue("name")
+	player, err := dbTx.Queries.GetPlayerByName(request.Context(), name)
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	err = dbTx.Queries.UnlockEmote(request.Context(), db.UnlockEmoteParams{
+		PlayerID:   player.ID,
+		EmoteID:    emoteId,
+		UnlockedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error granting emote %s to player %s: %v", emoteId, name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if client, online := a.hub.ClientByPlayerName(player.Name); online {
+		client.ProcessMessage(0, packets.NewEmoteUnlocked(emoteId))
+	}
+
+	logging.Admin.Printf("Granted emote %s to player %s", emoteId, player.Name)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func writeJson(writer http.ResponseWriter, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		logging.Admin.Errorf("Error writing admin API response: %v", err)
+	}
+}
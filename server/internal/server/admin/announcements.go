@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type announcementRequest struct {
+	// Who is making it, for the audit log and the announcement history
+	Admin   string `json:"admin"`
+	Message string `json:"message"`
+}
+
+// Announce something to everyone in game, which players who aren't can look back on later
+func (a *Api) makeAnnouncement(writer http.ResponseWriter, request *http.Request) {
+	var body announcementRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Message == "" {
+		http.Error(writer, "admin and message are required", http.StatusBadRequest)
+		return
+	}
+
+	a.hub.Announcements.Announce(body.Admin, body.Message)
+	a.hub.Audit(body.Admin, "announcement.made", "everyone", body.Message)
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,119 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"strconv"
+	"strings"
+)
+
+type apiKey struct {
+	Id                int64    `json:"id"`
+	Name              string   `json:"name"`
+	Prefix            string   `json:"prefix"`
+	Scopes            []string `json:"scopes"`
+	RequestsPerMinute int64    `json:"requests_per_minute"`
+	CreatedBy         string   `json:"created_by"`
+	CreatedAt         int64    `json:"created_at"`
+	RevokedAt         *int64   `json:"revoked_at"`
+}
+
+type issueApiKeyRequest struct {
+	// Who is issuing it, for the audit log
+	Admin string `json:"admin"`
+
+	// Who or what the key's for, e.g. the community tool using it
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+
+	// server.DefaultApiKeyRequestsPerMinute if left out
+	RequestsPerMinute int `json:"requests_per_minute"`
+}
+
+func (a *Api) listApiKeys(writer http.ResponseWriter, request *http.Request) {
+	keys, err := a.hub.ApiKeys.List(request.Context())
+	if err != nil {
+		logging.Admin.Errorf("Error listing API keys: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]apiKey, len(keys))
+	for i, key := range keys {
+		result[i] = apiKey{
+			Id:                key.ID,
+			Name:              key.Name,
+			Prefix:            key.Prefix,
+			Scopes:            strings.Split(key.Scopes, ","),
+			RequestsPerMinute: key.RequestsPerMinute,
+			CreatedBy:         key.CreatedBy,
+			CreatedAt:         key.CreatedAt,
+		}
+		if key.RevokedAt.Valid {
+			result[i].RevokedAt = &key.RevokedAt.Int64
+		}
+	}
+	writeJson(writer, result)
+}
+
+// Issue a key for the public API. The key's only in the response, so it has to be passed on from there.
+func (a *Api) issueApiKey(writer http.ResponseWriter, request *http.Request) {
+	var body issueApiKeyRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Name == "" {
+		http.Error(writer, "admin and name are required", http.StatusBadRequest)
+		return
+	}
+	if body.RequestsPerMinute < 0 {
+		http.Error(writer, "requests_per_minute can't be negative", http.StatusBadRequest)
+		return
+	}
+
+	id, key, err := a.hub.ApiKeys.Issue(request.Context(), body.Name, body.Scopes, body.RequestsPerMinute, body.Admin)
+	if errors.Is(err, server.ErrUnknownApiScope) || errors.Is(err, server.ErrNoApiScopes) {
+		http.Error(writer, fmt.Sprintf("%v, expected some of %s", err, strings.Join(server.ApiScopes, ", ")), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error issuing API key for %s: %v", body.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "api_key.issued", fmt.Sprint(id), fmt.Sprintf("%s: %s", body.Name, strings.Join(body.Scopes, ", ")))
+	writeJson(writer, map[string]any{"id": id, "key": key})
+}
+
+// Query parameters: admin (who is revoking it, for the audit log)
+func (a *Api) revokeApiKey(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	revoked, err := a.hub.ApiKeys.Revoke(request.Context(), id)
+	if err != nil {
+		logging.Admin.Errorf("Error revoking API key %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !revoked {
+		http.Error(writer, "API key not found or already revoked", http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "api_key.revoked", fmt.Sprint(id), "")
+	writer.WriteHeader(http.StatusNoContent)
+}
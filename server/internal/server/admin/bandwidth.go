@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type bandwidthCounts struct {
+	BytesIn    int64 `json:"bytes_in"`
+	BytesOut   int64 `json:"bytes_out"`
+	PacketsIn  int64 `json:"packets_in"`
+	PacketsOut int64 `json:"packets_out"`
+}
+
+type bandwidthSession struct {
+	ConnectedAt time.Time `json:"connected_at"`
+	Throttled   bool      `json:"throttled"`
+	bandwidthCounts
+}
+
+type bandwidthDay struct {
+	Day      string `json:"day"`
+	Sessions int64  `json:"sessions"`
+	bandwidthCounts
+}
+
+type playerBandwidth struct {
+	// The connection they're on right now, if they're online
+	Session *bandwidthSession `json:"session,omitempty"`
+
+	// Newest first
+	Days []bandwidthDay `json:"days"`
+}
+
+type accountBandwidth struct {
+	Name     string `json:"name"`
+	Sessions int64  `json:"sessions"`
+	bandwidthCounts
+}
+
+// Query parameters: days (default 30)
+func (a *Api) getPlayerBandwidth(writer http.ResponseWriter, request *http.Request) {
+	days := 30
+	if param := request.URL.Query().Get("days"); param != "" {
+		var err error
+		if days, err = strconv.Atoi(param); err != nil || days <= 0 {
+			http.Error(writer, "invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	queries := a.hub.NewDbTx().Queries
+	player, err := queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	rows, err := queries.GetPlayerBandwidthUsage(request.Context(), db.GetPlayerBandwidthUsageParams{
+		PlayerID: player.ID,
+		Limit:    int64(days),
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error getting bandwidth usage of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := playerBandwidth{Days: make([]bandwidthDay, len(rows))}
+	for i, row := range rows {
+		result.Days[i] = bandwidthDay{
+			Day:             row.Day,
+			Sessions:        row.Sessions,
+			bandwidthCounts: bandwidthCounts{row.BytesIn, row.BytesOut, row.PacketsIn, row.PacketsOut},
+		}
+	}
+	if session, online := a.hub.Bandwidth.Session(player.ID); online {
+		usage, connectedAt := session.Usage()
+		result.Session = &bandwidthSession{
+			ConnectedAt:     connectedAt,
+			Throttled:       session.Throttled(),
+			bandwidthCounts: bandwidthCounts{usage.BytesIn, usage.BytesOut, usage.PacketsIn, usage.PacketsOut},
+		}
+	}
+	writeJson(writer, result)
+}
+
+// The accounts that were sent the most on a day. Query parameters: day (YYYY-MM-DD in UTC, default today) and limit
+// (default 100)
+func (a *Api) getTopBandwidth(writer http.ResponseWriter, request *http.Request) {
+	day := time.Now().UTC().Format(time.DateOnly)
+	if param := request.URL.Query().Get("day"); param != "" {
+		if _, err := time.Parse(time.DateOnly, param); err != nil {
+			http.Error(writer, "invalid day", http.StatusBadRequest)
+			return
+		}
+		day = param
+	}
+
+	limit := 100
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := a.hub.NewDbTx().Queries.GetTopBandwidthUsage(request.Context(), db.GetTopBandwidthUsageParams{
+		Day:   day,
+		Limit: int64(limit),
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error getting bandwidth usage on %s: %v", day, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]accountBandwidth, len(rows))
+	for i, row := range rows {
+		result[i] = accountBandwidth{
+			Name:            row.Name,
+			Sessions:        row.Sessions,
+			bandwidthCounts: bandwidthCounts{row.BytesIn, row.BytesOut, row.PacketsIn, row.PacketsOut},
+		}
+	}
+	writeJson(writer, result)
+}
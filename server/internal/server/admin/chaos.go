@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type chaosRequest struct {
+	// Who is injecting it, for the audit log
+	Admin string `json:"admin"`
+
+	// The share of clients to drop, from 0 to 100
+	Percent float64 `json:"percent"`
+
+	// How long to stall the database or delay broadcasts for
+	Seconds float64 `json:"seconds"`
+
+	// How long to delay each broadcast by
+	DelayMs int64 `json:"delay_ms"`
+}
+
+// Every chaos endpoint needs the elevated token as well as chaos testing being enabled, so the admin token alone can't
+// take the server down
+func (a *Api) chaosAllowed(writer http.ResponseWriter, request *http.Request) bool {
+	if !a.elevated(request) {
+		http.Error(writer, "elevated token required", http.StatusForbidden)
+		return false
+	}
+	if !a.hub.Chaos.Enabled() {
+		http.Error(writer, "chaos testing isn't enabled", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+func (a *Api) decodeChaosRequest(writer http.ResponseWriter, request *http.Request) (chaosRequest, bool) {
+	var body chaosRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return body, false
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return body, false
+	}
+	return body, true
+}
+
+func (a *Api) getChaos(writer http.ResponseWriter, request *http.Request) {
+	if !a.chaosAllowed(writer, request) {
+		return
+	}
+	writeJson(writer, a.hub.Chaos.Status())
+}
+
+// Drop a share of the clients at random, for checking they reconnect and pick up where they left off
+func (a *Api) dropClients(writer http.ResponseWriter, request *http.Request) {
+	if !a.chaosAllowed(writer, request) {
+		return
+	}
+	body, ok := a.decodeChaosRequest(writer, request)
+	if !ok {
+		return
+	}
+	if body.Percent <= 0 || body.Percent > 100 {
+		http.Error(writer, "percent must be more than 0 and at most 100", http.StatusBadRequest)
+		return
+	}
+
+	dropped, err := a.hub.Chaos.DropClients(body.Percent)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	a.hub.Audit(body.Admin, "chaos.clients_dropped", "", fmt.Sprintf("%d clients (%g%%)", dropped, body.Percent))
+	writeJson(writer, map[string]int{"dropped": dropped})
+}
+
+// Stall the database, for checking the circuit breaker opens and write-behind catches up once it's back
+func (a *Api) stallDb(writer http.ResponseWriter, request *http.Request) {
+	if !a.chaosAllowed(writer, request) {
+		return
+	}
+	body, ok := a.decodeChaosRequest(writer, request)
+	if !ok {
+		return
+	}
+	if body.Seconds <= 0 {
+		http.Error(writer, "seconds must be more than 0", http.StatusBadRequest)
+		return
+	}
+
+	duration := time.Duration(body.Seconds * float64(time.Second))
+	if err := a.hub.Chaos.StallDb(duration); err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	a.hub.Audit(body.Admin, "chaos.db_stalled", "", "for "+duration.String())
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Delay broadcasts, for checking clients cope with lag
+func (a *Api) delayBroadcasts(writer http.ResponseWriter, request *http.Request) {
+	if !a.chaosAllowed(writer, request) {
+		return
+	}
+	body, ok := a.decodeChaosRequest(writer, request)
+	if !ok {
+		return
+	}
+	if body.Seconds <= 0 || body.DelayMs <= 0 {
+		http.Error(writer, "seconds and delay_ms must be more than 0", http.StatusBadRequest)
+		return
+	}
+
+	delay, duration := time.Duration(body.DelayMs)*time.Millisecond, time.Duration(body.Seconds*float64(time.Second))
+	if err := a.hub.Chaos.DelayBroadcasts(delay, duration); err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+	a.hub.Audit(body.Admin, "chaos.broadcasts_delayed", "", fmt.Sprintf("by %s for %s", delay, duration))
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Query parameters: admin (who is stopping it, for the audit log)
+func (a *Api) stopChaos(writer http.ResponseWriter, request *http.Request) {
+	if !a.chaosAllowed(writer, request) {
+		return
+	}
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	a.hub.Chaos.Stop()
+	a.hub.Audit(by, "chaos.stopped", "", "")
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package admin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type chatExport struct {
+	ZoneId string `json:"zone_id"`
+
+	// 0 for every channel of the zone
+	Channel int `json:"channel"`
+
+	// From no earlier than the retention policy goes back
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	// Whether there was more than could be exported at once, in which case only the first are
+	Truncated bool `json:"truncated"`
+
+	Messages []chatExportMessage `json:"messages"`
+}
+
+type chatExportMessage struct {
+	Id         int64     `json:"id"`
+	Channel    int64     `json:"channel"`
+	AccountId  int64     `json:"account_id"`
+	PlayerId   int64     `json:"player_id"`
+	SenderName string    `json:"sender_name"`
+	Message    string    `json:"message"`
+	SentAt     time.Time `json:"sent_at"`
+}
+
+// Export what was said in a zone for handling a harassment report, as JSON or CSV. Chat shows who's been talking to
+// whom, so it needs the elevated token, and each export is audited.
+// Query parameters: admin, from (RFC 3339), to (RFC 3339, default now), channel (default every channel of the zone),
+// format (json or csv, default json)
+func (a *Api) exportChat(writer http.ResponseWriter, request *http.Request) {
+	if !a.elevated(request) {
+		http.Error(writer, "elevated token required", http.StatusForbidden)
+		return
+	}
+
+	zoneId := request.PathValue("zone")
+	if _, exists := a.hub.GameData.Zone(zoneId); !exists {
+		http.Error(writer, "zone not found", http.StatusNotFound)
+		return
+	}
+
+	query := request.URL.Query()
+	admin := query.Get("admin")
+	if admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	from, err := time.Parse(time.RFC3339, query.Get("from"))
+	if err != nil {
+		http.Error(writer, "invalid from, expected RFC 3339", http.StatusBadRequest)
+		return
+	}
+	to := time.Now()
+	if param := query.Get("to"); param != "" {
+		if to, err = time.Parse(time.RFC3339, param); err != nil {
+			http.Error(writer, "invalid to, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+	}
+	if !from.Before(to) {
+		http.Error(writer, "from must be before to", http.StatusBadRequest)
+		return
+	}
+	channel := 0
+	if param := query.Get("channel"); param != "" {
+		if channel, err = strconv.Atoi(param); err != nil || channel < 1 {
+			http.Error(writer, "invalid channel", http.StatusBadRequest)
+			return
+		}
+	}
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	} else if format != "json" && format != "csv" {
+		http.Error(writer, "invalid format, expected json or csv", http.StatusBadRequest)
+		return
+	}
+
+	history := a.hub.ChatHistory
+	messages, truncated, err := history.Export(request.Context(), a.hub.NewDbTx().Queries, zoneId, channel, from, to)
+	if err != nil {
+		logging.Admin.Errorf("Error exporting chat in %s: %v", zoneId, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	export := chatExport{
+		ZoneId:    zoneId,
+		Channel:   channel,
+		From:      history.Clamp(from),
+		To:        to,
+		Truncated: truncated,
+		Messages:  make([]chatExportMessage, len(messages)),
+	}
+	for i, message := range messages {
+		export.Messages[i] = chatExportMessage{
+			Id:         message.ID,
+			Channel:    message.Channel,
+			AccountId:  message.UserID,
+			PlayerId:   message.PlayerID,
+			SenderName: message.SenderName,
+			Message:    message.Message,
+			SentAt:     time.UnixMilli(message.CreatedAt),
+		}
+	}
+
+	target := zoneId
+	if channel != 0 {
+		target = fmt.Sprintf("%s channel %d", zoneId, channel)
+	}
+	a.hub.Audit(admin, "chat.exported", target, fmt.Sprintf("%d messages from %s to %s as %s", len(messages), export.From.Format(time.RFC3339), to.Format(time.RFC3339), format))
+
+	if format == "json" {
+		writeJson(writer, export)
+		return
+	}
+	writeChatCsv(writer, export)
+}
+
+func writeChatCsv(writer http.ResponseWriter, export chatExport) {
+	writer.Header().Set("Content-Type", "text/csv")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "chat-"+export.ZoneId+".csv"))
+	if export.Truncated {
+		writer.Header().Set("X-Truncated", "true")
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Write([]string{"id", "sent_at", "channel", "account_id", "player_id", "sender_name", "message"})
+	for _, message := range export.Messages {
+		csvWriter.Write([]string{
+			strconv.FormatInt(message.Id, 10),
+			message.SentAt.UTC().Format(time.RFC3339Nano),
+			strconv.FormatInt(message.Channel, 10),
+			strconv.FormatInt(message.AccountId, 10),
+			strconv.FormatInt(message.PlayerId, 10),
+			message.SenderName,
+			message.Message,
+		})
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		logging.Admin.Errorf("Error writing chat export: %v", err)
+	}
+}
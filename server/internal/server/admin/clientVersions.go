@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+)
+
+type setClientVersionsRequest struct {
+	// Who is changing it, for the audit log
+	Admin string `json:"admin"`
+
+	server.ClientVersionPolicy
+}
+
+func (a *Api) getClientVersions(writer http.ResponseWriter, _ *http.Request) {
+	writeJson(writer, a.hub.ClientVersions.Policy())
+}
+
+// Replace the client version policy, e.g. to raise the minimum build with a deadline for players to update by, and
+// later to take the deadline away once it's passed
+func (a *Api) setClientVersions(writer http.ResponseWriter, request *http.Request) {
+	var body setClientVersionsRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.hub.ClientVersions.Configure(body.ClientVersionPolicy); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	detail := fmt.Sprintf("builds %d to %d", body.MinBuild, body.MaxBuild)
+	if body.UpdateDeadline != nil {
+		detail += ", older ones warned until " + body.UpdateDeadline.UTC().Format("2006-01-02 15:04:05 MST")
+	}
+	a.hub.Audit(body.Admin, "client_versions.set", "", detail)
+	writeJson(writer, a.hub.ClientVersions.Policy())
+}
@@ -0,0 +1,101 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type consistencyCheckRequest struct {
+	// Who is running it, for the audit log
+	Admin string `json:"admin"`
+
+	// Repair what's found, rather than only reporting it
+	Repair bool `json:"repair"`
+}
+
+// Queue a consistency check, returning its job. Its report can be listed once the job's done.
+func (a *Api) queueConsistencyCheck(writer http.ResponseWriter, request *http.Request) {
+	var body consistencyCheckRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil || body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	j, err := a.hub.ConsistencyChecks.Queue(body.Admin, body.Repair)
+	if err != nil {
+		logging.Admin.Errorf("Error queueing consistency check: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "consistency.queued", fmt.Sprintf("job %d", j.ID), fmt.Sprintf("repair: %t", body.Repair))
+	writeJson(writer, newJob(j))
+}
+
+// The latest reports, without their drift. Query parameters: limit (default 50)
+func (a *Api) listConsistencyReports(writer http.ResponseWriter, request *http.Request) {
+	limit := 50
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := a.hub.NewDbTx().Queries.GetConsistencyReports(request.Context(), int64(limit))
+	if err != nil {
+		logging.Admin.Errorf("Error getting consistency reports: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	reports := make([]server.ConsistencyReport, len(rows))
+	for i, row := range rows {
+		reports[i] = server.ConsistencyReport{
+			Id:             row.ID,
+			TriggeredBy:    row.TriggeredBy,
+			Repair:         row.Repair,
+			PlayersChecked: row.PlayersChecked,
+			DriftCount:     row.DriftCount,
+			StartedAt:      time.UnixMilli(row.StartedAt),
+			FinishedAt:     time.UnixMilli(row.FinishedAt),
+		}
+	}
+	writeJson(writer, reports)
+}
+
+// Download a report with everything it found
+func (a *Api) downloadConsistencyReport(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	row, err := a.hub.NewDbTx().Queries.GetConsistencyReport(request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, "report not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error getting consistency report %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	report, err := server.ConsistencyReportFromDb(row)
+	if err != nil {
+		logging.Admin.Errorf("Error decoding consistency report %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="consistency-report-%d.json"`, report.Id))
+	writeJson(writer, report)
+}
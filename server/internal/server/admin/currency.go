@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"net/http"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type currencyFlag struct {
+	Id             int64     `json:"id"`
+	AccountId      int64     `json:"account_id"`
+	PlayerId       int64     `json:"player_id"`
+	Kind           string    `json:"kind"`
+	Amount         int64     `json:"amount"`
+	CounterpartyId int64     `json:"counterparty_id,omitempty"`
+	Refused        bool      `json:"refused"`
+	Flag           string    `json:"flag"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type currencyFlags struct {
+	MaxPerTransfer int64          `json:"max_per_transfer"`
+	DailyLimit     int64          `json:"daily_limit"`
+	FlagTransfer   int64          `json:"flag_transfer"`
+	FlagDaily      int64          `json:"flag_daily"`
+	Flags          []currencyFlag `json:"flags"`
+}
+
+// Coin transfers flagged as possible duping or real money trading, and ones refused for going over a cap, most recent
+// first, along with the caps and thresholds they were flagged by.
+// Query parameters: since (RFC 3339, default a day ago), limit (default 100)
+func (a *Api) listCurrencyFlags(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	since := time.Now().Add(-24 * time.Hour)
+	if param := query.Get("since"); param != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, param); err != nil {
+			http.Error(writer, "invalid since, expected RFC 3339", http.StatusBadRequest)
+			return
+		}
+	}
+	limit := 100
+	if param := query.Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	transfers, err := a.hub.CurrencyCaps.Flagged(request.Context(), since, limit)
+	if err != nil {
+		logging.Admin.Errorf("Error getting flagged coin transfers: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	limits := a.hub.CurrencyCaps.Limits()
+	result := currencyFlags{
+		MaxPerTransfer: limits.MaxPerTransfer,
+		DailyLimit:     limits.DailyLimit,
+		FlagTransfer:   limits.FlagTransfer,
+		FlagDaily:      limits.FlagDaily,
+		Flags:          make([]currencyFlag, len(transfers)),
+	}
+	for i, transfer := range transfers {
+		result.Flags[i] = currencyFlag{
+			Id:             transfer.ID,
+			AccountId:      transfer.UserID,
+			PlayerId:       transfer.PlayerID,
+			Kind:           transfer.Kind,
+			Amount:         transfer.Amount,
+			CounterpartyId: transfer.CounterpartyID,
+			Refused:        transfer.Refused,
+			Flag:           transfer.Flag,
+			CreatedAt:      time.UnixMilli(transfer.CreatedAt),
+		}
+	}
+	writeJson(writer, result)
+}
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/gamedata"
+	"strconv"
+)
+
+type emitter struct {
+	Id     uint64   `json:"id"`
+	Kind   string   `json:"kind"`
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Radius float64  `json:"radius"`
+	Sound  string   `json:"sound"`
+	Effect string   `json:"effect"`
+	Volume float64  `json:"volume"`
+	Tags   []string `json:"tags"`
+}
+
+type placeEmitterRequest struct {
+	// Who is placing it, for the audit log
+	Admin string `json:"admin"`
+
+	gamedata.Emitter
+}
+
+func (a *Api) listEmitters(writer http.ResponseWriter, request *http.Request) {
+	result := []emitter{}
+	for _, placed := range a.hub.Emitters.List() {
+		result = append(result, emitter{
+			Id:     placed.Id,
+			Kind:   placed.Kind,
+			X:      placed.X,
+			Y:      placed.Y,
+			Radius: placed.Radius,
+			Sound:  placed.Sound,
+			Effect: placed.Effect,
+			Volume: placed.Volume,
+			Tags:   a.hub.WorldTags.TagsOf(placed.Id),
+		})
+	}
+	writeJson(writer, result)
+}
+
+// Place an emitter until the server restarts, e.g. for a world event. Ones that should stay belong in the game data.
+func (a *Api) placeEmitter(writer http.ResponseWriter, request *http.Request) {
+	var body placeEmitterRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	if body.Volume == 0 {
+		body.Volume = 1
+	}
+	if err := gamedata.ValidateEmitter(&body.Emitter); err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id := a.hub.Emitters.Place(&body.Emitter)
+	a.hub.Audit(body.Admin, "emitter.placed", fmt.Sprint(id), fmt.Sprintf("%s at (%g, %g)", body.Kind, body.X, body.Y))
+	writeJson(writer, map[string]uint64{"id": id})
+}
+
+// Query parameters: admin (who is removing it, for the audit log)
+func (a *Api) removeEmitter(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.ParseUint(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := a.hub.Emitters.Remove(id); errors.Is(err, server.ErrNoSuchEmitter) {
+		http.Error(writer, "emitter not found", http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "emitter.removed", fmt.Sprint(id), "")
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"time"
+)
+
+type grantEntitlementRequest struct {
+	// Who is granting it, for the audit log
+	Admin string `json:"admin"`
+
+	// Where it came from, e.g. "steam" for DLC bought there, "admin" if left out
+	Source string `json:"source"`
+}
+
+type entitlement struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	Source    string    `json:"source"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+type accountEntitlements struct {
+	Entitlements   []entitlement `json:"entitlements"`
+	CharacterSlots int           `json:"character_slots"`
+}
+
+// What the player's account is entitled to, including anything no longer in the game data
+func (a *Api) listEntitlements(writer http.ResponseWriter, request *http.Request) {
+	dbTx := a.hub.NewDbTx()
+	player, err := dbTx.Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	rows, err := dbTx.Queries.GetEntitlements(request.Context(), player.UserID)
+	if err != nil {
+		logging.Admin.Errorf("Error getting entitlements of player %s: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := accountEntitlements{Entitlements: make([]entitlement, len(rows))}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		result.Entitlements[i] = entitlement{Id: row.EntitlementID, Source: row.Source, GrantedAt: time.Unix(row.GrantedAt, 0)}
+		if known, exists := a.hub.GameData.Entitlement(row.EntitlementID); exists {
+			result.Entitlements[i].Name = known.Name
+		}
+		ids[i] = row.EntitlementID
+	}
+	result.CharacterSlots = a.hub.Entitlements.CharacterSlots(ids)
+	writeJson(writer, result)
+}
+
+// Entitle the player's account, e.g. once a store integration has seen DLC bought outside the game. If they're in game
+// they get what it gates straight away.
+func (a *Api) grantEntitlement(writer http.ResponseWriter, request *http.Request) {
+	var body grantEntitlementRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	if body.Source == "" {
+		body.Source = "admin"
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	err = a.hub.Entitlements.Grant(body.Admin, player.UserID, request.PathValue("id"), body.Source)
+	if errors.Is(err, server.ErrNoSuchEntitlement) {
+		http.Error(writer, "entitlement not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, server.ErrAlreadyEntitled) {
+		http.Error(writer, "already entitled", http.StatusConflict)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error granting entitlement %s to player %s: %v", request.PathValue("id"), player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Query parameters: admin (who is revoking it, for the audit log)
+func (a *Api) revokeEntitlement(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	err = a.hub.Entitlements.Revoke(by, player.UserID, request.PathValue("id"))
+	if errors.Is(err, server.ErrNotEntitled) {
+		http.Error(writer, "not entitled", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error revoking entitlement %s from player %s: %v", request.PathValue("id"), player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
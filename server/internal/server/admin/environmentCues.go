@@ -0,0 +1,103 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"time"
+)
+
+type cueRegion struct {
+	MinX float64 `json:"min_x"`
+	MinY float64 `json:"min_y"`
+	MaxX float64 `json:"max_x"`
+	MaxY float64 `json:"max_y"`
+}
+
+type triggerCueRequest struct {
+	// Who is triggering it, for the audit log
+	Admin string `json:"admin"`
+
+	// Where to send it, exactly one of: every player in a zone (or only one of its channels), in a region, or within
+	// the radius of x, y
+	Zone    string     `json:"zone"`
+	Channel int        `json:"channel"`
+	Region  *cueRegion `json:"region"`
+	Radius  float64    `json:"radius"`
+
+	AmbientTrack   string  `json:"ambient_track"`
+	ParticleEvent  string  `json:"particle_event"`
+	X              float64 `json:"x"`
+	Y              float64 `json:"y"`
+	ShakeIntensity float64 `json:"shake_intensity"`
+	ShakeMs        int64   `json:"shake_ms"`
+}
+
+// Play an ambient or visual cue for the players in an area, e.g. from a script running a world event
+func (a *Api) triggerEnvironmentCue(writer http.ResponseWriter, request *http.Request) {
+	var body triggerCueRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	if body.AmbientTrack == "" && body.ParticleEvent == "" && body.ShakeIntensity <= 0 {
+		http.Error(writer, "one of ambient_track, particle_event or shake_intensity is required", http.StatusBadRequest)
+		return
+	}
+	if body.ShakeMs < 0 {
+		http.Error(writer, "shake_ms can't be negative", http.StatusBadRequest)
+		return
+	}
+
+	areas := 0
+	for _, given := range []bool{body.Zone != "", body.Region != nil, body.Radius > 0} {
+		if given {
+			areas++
+		}
+	}
+	if areas != 1 {
+		http.Error(writer, "exactly one of zone, region or radius is required", http.StatusBadRequest)
+		return
+	}
+
+	cue := server.EnvironmentCue{
+		AmbientTrack:   body.AmbientTrack,
+		ParticleEvent:  body.ParticleEvent,
+		X:              body.X,
+		Y:              body.Y,
+		ShakeIntensity: body.ShakeIntensity,
+		ShakeDuration:  time.Duration(body.ShakeMs) * time.Millisecond,
+	}
+
+	var sent int
+	var target string
+	switch {
+	case body.Zone != "":
+		var err error
+		sent, err = a.hub.EnvironmentCues.ToZone(0, body.Zone, body.Channel, cue)
+		if errors.Is(err, server.ErrNoSuchZone) {
+			http.Error(writer, "zone not found", http.StatusNotFound)
+			return
+		}
+		target = "zone " + body.Zone
+		if body.Channel != 0 {
+			target += fmt.Sprintf(" channel %d", body.Channel)
+		}
+	case body.Region != nil:
+		region := body.Region
+		sent = a.hub.EnvironmentCues.ToRegion(0, region.MinX, region.MinY, region.MaxX, region.MaxY, cue)
+		target = fmt.Sprintf("region (%g, %g) to (%g, %g)", region.MinX, region.MinY, region.MaxX, region.MaxY)
+	default:
+		sent = a.hub.EnvironmentCues.ToRadius(0, body.X, body.Y, body.Radius, cue)
+		target = fmt.Sprintf("radius %g of (%g, %g)", body.Radius, body.X, body.Y)
+	}
+
+	a.hub.Audit(body.Admin, "environment_cue.triggered", target, fmt.Sprintf("sent to %d players", sent))
+	writeJson(writer, map[string]int{"sent": sent})
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"net/http"
+	"server/internal/server"
+	"time"
+)
+
+// What the fan-out did in a tick, or on average or at most over the ticks something was broadcast in
+type fanoutTick struct {
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	Broadcasts   float64    `json:"broadcasts"`
+	Recipients   float64    `json:"recipients"`
+	Skipped      float64    `json:"skipped"`
+	Held         float64    `json:"held"`
+	Dropped      float64    `json:"dropped"`
+	EncodedBytes float64    `json:"encoded_bytes"`
+	SentBytes    float64    `json:"sent_bytes"`
+	EncodeMicros float64    `json:"encode_us"`
+}
+
+type fanoutSummary struct {
+	// How many ticks the rest covers, out of the last few seconds
+	ActiveTicks int `json:"active_ticks"`
+
+	Average fanoutTick `json:"average"`
+	Peak    fanoutTick `json:"peak"`
+
+	// Bytes queued for clients, by message kind
+	SentBytesByKind map[string]int `json:"sent_bytes_by_kind"`
+
+	// Oldest first
+	Ticks []fanoutTick `json:"ticks"`
+}
+
+func toFanoutTick(tick server.FanoutTick) fanoutTick {
+	return fanoutTick{
+		StartedAt:    &tick.StartedAt,
+		Broadcasts:   float64(tick.Broadcasts),
+		Recipients:   float64(tick.Recipients),
+		Skipped:      float64(tick.Skipped),
+		Held:         float64(tick.Held),
+		Dropped:      float64(tick.Dropped),
+		EncodedBytes: float64(tick.EncodedBytes),
+		SentBytes:    float64(tick.SentBytes),
+		EncodeMicros: float64(tick.EncodeDuration) / float64(time.Microsecond),
+	}
+}
+
+// The broadcast fan-out over the last few seconds, per tick
+func (a *Api) getFanout(writer http.ResponseWriter, request *http.Request) {
+	stats := a.hub.Fanout.Stats()
+	summary := fanoutSummary{
+		ActiveTicks:     len(stats.Ticks),
+		SentBytesByKind: stats.SentBytesByKind,
+		Ticks:           make([]fanoutTick, len(stats.Ticks)),
+	}
+
+	total := &summary.Average
+	peak := &summary.Peak
+	for i, tick := range stats.Ticks {
+		summary.Ticks[i] = toFanoutTick(tick)
+		t := &summary.Ticks[i]
+		for _, field := range []struct{ value, total, peak *float64 }{
+			{&t.Broadcasts, &total.Broadcasts, &peak.Broadcasts},
+			{&t.Recipients, &total.Recipients, &peak.Recipients},
+			{&t.Skipped, &total.Skipped, &peak.Skipped},
+			{&t.Held, &total.Held, &peak.Held},
+			{&t.Dropped, &total.Dropped, &peak.Dropped},
+			{&t.EncodedBytes, &total.EncodedBytes, &peak.EncodedBytes},
+			{&t.SentBytes, &total.SentBytes, &peak.SentBytes},
+			{&t.EncodeMicros, &total.EncodeMicros, &peak.EncodeMicros},
+		} {
+			*field.total += *field.value
+			*field.peak = max(*field.peak, *field.value)
+		}
+	}
+	if ticks := float64(len(stats.Ticks)); ticks > 0 {
+		for _, value := range []*float64{
+			&total.Broadcasts, &total.Recipients, &total.Skipped, &total.Held, &total.Dropped,
+			&total.EncodedBytes, &total.SentBytes, &total.EncodeMicros,
+		} {
+			*value /= ticks
+		}
+	}
+	writeJson(writer, summary)
+}
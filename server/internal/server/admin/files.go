@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"strings"
+)
+
+type pushFileRequest struct {
+	// Who is pushing it, for the audit log
+	Admin string `json:"admin"`
+
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+
+	// Base64 in the JSON
+	Data []byte `json:"data"`
+
+	// Who it's pushed to, everyone in game if left out
+	Players []string `json:"players"`
+}
+
+type pushFileResponse struct {
+	TransferIds []uint64 `json:"transfer_ids"`
+
+	// Any of the players asked for who aren't in game here
+	Offline []string `json:"offline,omitempty"`
+}
+
+// Push a small data file to players in game, e.g. a map update or a localization pack
+func (a *Api) pushFile(writer http.ResponseWriter, request *http.Request) {
+	var body pushFileRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Name == "" || body.ContentType == "" {
+		http.Error(writer, "admin, name and content_type are required", http.StatusBadRequest)
+		return
+	}
+
+	err := a.hub.FileTransfers.Validate(body.Name, body.ContentType, body.Data)
+	if errors.Is(err, server.ErrFileTooLarge) {
+		http.Error(writer, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	} else if errors.Is(err, server.ErrFileTypeNotAllowed) {
+		http.Error(writer, err.Error(), http.StatusUnsupportedMediaType)
+		return
+	} else if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var response pushFileResponse
+	var clients []server.ClientInterfacer
+	if len(body.Players) == 0 {
+		a.hub.SharedGameObjects.Players.ForEach(func(clientId uint64, _ *objects.Player) {
+			if client, exists := a.hub.Clients.Get(clientId); exists {
+				clients = append(clients, client)
+			}
+		})
+	} else {
+		for _, name := range body.Players {
+			if client, online := a.hub.ClientByPlayerName(name); online {
+				clients = append(clients, client)
+			} else {
+				response.Offline = append(response.Offline, name)
+			}
+		}
+	}
+
+	response.TransferIds, err = a.hub.FileTransfers.Push(clients, body.Name, body.ContentType, body.Data)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	target := "players"
+	if len(body.Players) > 0 {
+		target = strings.Join(body.Players, ",")
+	}
+	a.hub.Audit(body.Admin, "file.pushed", target, fmt.Sprintf("%s (%s, %d bytes) to %d clients", body.Name, body.ContentType, len(body.Data), len(clients)))
+
+	writeJson(writer, response)
+}
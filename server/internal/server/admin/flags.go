@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"time"
+)
+
+type setFlagRequest struct {
+	// Who is changing it, for the audit log
+	Admin string `json:"admin"`
+
+	Enabled bool `json:"enabled"`
+
+	// The share of accounts it's on for, from 0 to 100, everyone if left out
+	RolloutPercent *int64 `json:"rollout_percent"`
+
+	// Only on for clients that logged in saying they support this, if set
+	Capability string `json:"capability"`
+}
+
+type featureFlag struct {
+	Id             string     `json:"id"`
+	Enabled        bool       `json:"enabled"`
+	RolloutPercent int64      `json:"rollout_percent"`
+	Capability     string     `json:"capability,omitempty"`
+	Source         string     `json:"source"`
+	UpdatedBy      string     `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+func (a *Api) listFlags(writer http.ResponseWriter, request *http.Request) {
+	flags := a.hub.FeatureFlags.List()
+
+	result := make([]featureFlag, len(flags))
+	for i, flag := range flags {
+		result[i] = featureFlag{
+			Id:             flag.Id,
+			Enabled:        flag.Enabled,
+			RolloutPercent: flag.RolloutPercent,
+			Capability:     flag.Capability,
+			Source:         flag.Source,
+			UpdatedBy:      flag.UpdatedBy,
+		}
+		if flag.Source == server.FeatureFlagSourceAdmin {
+			result[i].UpdatedAt = &flag.UpdatedAt
+		}
+	}
+	writeJson(writer, result)
+}
+
+// Turn a flag on or off, or change who it's on for. Flags that aren't in the game data can be made up on the spot.
+func (a *Api) setFlag(writer http.ResponseWriter, request *http.Request) {
+	var body setFlagRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	rolloutPercent := int64(100)
+	if body.RolloutPercent != nil {
+		rolloutPercent = *body.RolloutPercent
+	}
+	if rolloutPercent < 0 || rolloutPercent > 100 {
+		http.Error(writer, "rollout_percent must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+
+	id := request.PathValue("id")
+	if err := a.hub.FeatureFlags.Set(body.Admin, id, body.Enabled, rolloutPercent, body.Capability); err != nil {
+		logging.Admin.Errorf("Error setting feature flag %s: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	detail := fmt.Sprintf("enabled %t for %d%%", body.Enabled, rolloutPercent)
+	if body.Capability != "" {
+		detail += " of clients with " + body.Capability
+	}
+	a.hub.Audit(body.Admin, "flag.set", id, detail)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Put a flag back to how the game data has it. Query parameters: admin (who is resetting it, for the audit log)
+func (a *Api) resetFlag(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	id := request.PathValue("id")
+	reset, err := a.hub.FeatureFlags.Reset(id)
+	if err != nil {
+		logging.Admin.Errorf("Error resetting feature flag %s: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !reset {
+		http.Error(writer, "flag not overridden", http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "flag.reset", id, "")
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+type impersonationRequest struct {
+	// Who is asking, for the audit log
+	Admin  string `json:"admin"`
+	Reason string `json:"reason"`
+
+	// Whether to act as the player, rather than only watch
+	Control bool `json:"control"`
+
+	// Skip asking the player first, which needs the elevated token
+	Elevated bool `json:"elevated"`
+}
+
+type auditLogEntry struct {
+	Id        int64     `json:"id"`
+	Admin     string    `json:"admin"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Detail    string    `json:"detail"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Ask to impersonate a player. The session is pending until the player consents, unless it's elevated.
+func (a *Api) requestImpersonation(writer http.ResponseWriter, request *http.Request) {
+	var body impersonationRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Reason == "" {
+		http.Error(writer, "admin and reason are required", http.StatusBadRequest)
+		return
+	}
+	if body.Elevated && !a.elevated(request) {
+		http.Error(writer, "elevated token required", http.StatusForbidden)
+		return
+	}
+
+	session, err := a.hub.Impersonations.Request(body.Admin, body.Reason, request.PathValue("name"), body.Control, body.Elevated)
+	if errors.Is(err, server.ErrPlayerOffline) {
+		http.Error(writer, "player not online", http.StatusNotFound)
+		return
+	} else if errors.Is(err, server.ErrAlreadyImpersonated) {
+		http.Error(writer, "player is already being impersonated", http.StatusConflict)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error requesting impersonation of player %s: %v", request.PathValue("name"), err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(writer, session)
+}
+
+func (a *Api) listImpersonations(writer http.ResponseWriter, _ *http.Request) {
+	writeJson(writer, a.hub.Impersonations.List())
+}
+
+func (a *Api) getImpersonation(writer http.ResponseWriter, request *http.Request) {
+	session, exists := a.hub.Impersonations.Get(request.PathValue("id"))
+	if !exists {
+		http.Error(writer, "impersonation not found", http.StatusNotFound)
+		return
+	}
+	writeJson(writer, session)
+}
+
+// Query parameters: admin (who is ending it, for the audit log, default the admin who asked for it)
+func (a *Api) endImpersonation(writer http.ResponseWriter, request *http.Request) {
+	id := request.PathValue("id")
+	session, exists := a.hub.Impersonations.Get(id)
+	if !exists {
+		http.Error(writer, "impersonation not found", http.StatusNotFound)
+		return
+	}
+
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		by = session.Admin
+	}
+	if err := a.hub.Impersonations.End(id, by); err != nil {
+		http.Error(writer, "impersonation not found", http.StatusNotFound)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Attach to an active session over a websocket. Every packet sent to the player from then on is sent on as a binary
+// message in the same format the game client gets. With control, binary messages sent by the admin are handled as if
+// the player sent them. Detaching ends the session.
+func (a *Api) attachImpersonation(writer http.ResponseWriter, request *http.Request) {
+	id := request.PathValue("id")
+	session, mirror, err := a.hub.Impersonations.Attach(id)
+	if errors.Is(err, server.ErrImpersonationNotFound) {
+		http.Error(writer, "impersonation not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(writer, err.Error(), http.StatusConflict)
+		return
+	}
+	defer a.hub.Impersonations.End(id, session.Admin)
+
+	upgrader := websocket.Upgrader{CheckOrigin: func(_ *http.Request) bool { return true }}
+	conn, err := upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		logging.Admin.Errorf("Error attaching to impersonation %s: %v", id, err)
+		return
+	}
+	defer conn.Close()
+
+	go a.relayImpersonationInput(conn, session)
+
+	for packet := range mirror {
+		data, err := proto.Marshal(packet)
+		if err != nil {
+			logging.Admin.Errorf("Error marshalling %T packet for impersonation %s: %v", packet.Msg, id, err)
+			continue
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, append(data, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+// Hand what the admin sends to the player's client until they detach, which ends the session
+func (a *Api) relayImpersonationInput(conn *websocket.Conn, session server.Impersonation) {
+	defer a.hub.Impersonations.End(session.Id, session.Admin)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		client, err := a.hub.Impersonations.ControlledClient(session.Id)
+		if errors.Is(err, server.ErrImpersonationNoControl) {
+			continue
+		} else if err != nil {
+			return
+		}
+
+		// Decoded here only to say what it was in the audit log, the client still handles the raw data itself
+		packet := &packets.Packet{}
+		if err := proto.Unmarshal(data, packet); err != nil {
+			continue
+		}
+		detail := fmt.Sprintf("session %s, sent %T", session.Id, packet.Msg)
+		a.hub.Audit(session.Admin, "impersonation.input", session.PlayerName, detail)
+		client.ProcessSocketData(data)
+	}
+}
+
+// Query parameters: limit (default 100)
+func (a *Api) getAuditLog(writer http.ResponseWriter, request *http.Request) {
+	limit := 100
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := a.hub.NewDbTx().Queries.GetAuditLog(request.Context(), int64(limit))
+	if err != nil {
+		logging.Admin.Errorf("Error getting the audit log: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	entries := make([]auditLogEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = auditLogEntry{
+			Id:        row.ID,
+			Admin:     row.Admin,
+			Action:    row.Action,
+			Target:    row.Target,
+			Detail:    row.Detail,
+			CreatedAt: time.UnixMilli(row.CreatedAt),
+		}
+	}
+	writeJson(writer, entries)
+}
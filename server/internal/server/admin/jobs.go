@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type job struct {
+	Id         int64           `json:"id"`
+	Kind       string          `json:"kind"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Status     string          `json:"status"`
+	Recurring  bool            `json:"recurring"`
+	Attempts   int64           `json:"attempts"`
+	Runs       int64           `json:"runs"`
+	LastError  string          `json:"last_error,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	RunAt      time.Time       `json:"run_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+func newJob(j db.Job) job {
+	result := job{
+		Id:        j.ID,
+		Kind:      j.Kind,
+		Status:    j.Status,
+		Recurring: j.EveryMs > 0,
+		Attempts:  j.Attempts,
+		Runs:      j.Runs,
+		LastError: j.LastError,
+		CreatedAt: time.UnixMilli(j.CreatedAt),
+		RunAt:     time.UnixMilli(j.RunAt),
+	}
+	if string(j.Payload) != "null" {
+		result.Payload = j.Payload
+	}
+	if j.StartedAt.Valid {
+		startedAt := time.UnixMilli(j.StartedAt.Int64)
+		result.StartedAt = &startedAt
+	}
+	if j.FinishedAt.Valid {
+		finishedAt := time.UnixMilli(j.FinishedAt.Int64)
+		result.FinishedAt = &finishedAt
+	}
+	return result
+}
+
+type jobKind struct {
+	Kind        string `json:"kind"`
+	Running     int    `json:"running"`
+	Concurrency int    `json:"concurrency"`
+
+	// How often it runs on its own, if it does
+	EverySeconds float64 `json:"every_seconds,omitempty"`
+}
+
+type jobsResponse struct {
+	Kinds []jobKind `json:"kinds"`
+	Jobs  []job     `json:"jobs"`
+}
+
+type queueJobRequest struct {
+	// Who is queueing it, for the audit log
+	Admin   string          `json:"admin"`
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type retryJobRequest struct {
+	// Who is retrying it, for the audit log
+	Admin string `json:"admin"`
+}
+
+// Every kind of job with how many are running, and the latest jobs. Query parameters: status (pending, running, done
+// or failed, default any), limit (default 50)
+func (a *Api) listJobs(writer http.ResponseWriter, request *http.Request) {
+	limit := 50
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	queries := a.hub.NewDbTx().Queries
+	var jobs []db.Job
+	var err error
+	if status := request.URL.Query().Get("status"); status != "" {
+		jobs, err = queries.GetJobsByStatus(request.Context(), db.GetJobsByStatusParams{Status: status, Limit: int64(limit)})
+	} else {
+		jobs, err = queries.GetJobs(request.Context(), int64(limit))
+	}
+	if err != nil {
+		logging.Admin.Errorf("Error getting jobs: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := jobsResponse{Kinds: []jobKind{}, Jobs: make([]job, len(jobs))}
+	for _, kind := range a.hub.Jobs.Kinds() {
+		result.Kinds = append(result.Kinds, jobKind{
+			Kind:         kind.Kind,
+			Running:      kind.Running,
+			Concurrency:  kind.Concurrency,
+			EverySeconds: kind.Every.Seconds(),
+		})
+	}
+	for i, j := range jobs {
+		result.Jobs[i] = newJob(j)
+	}
+	writeJson(writer, result)
+}
+
+func (a *Api) getJob(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	j, err := a.hub.NewDbTx().Queries.GetJob(request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, "job not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error getting job %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	writeJson(writer, newJob(j))
+}
+
+// Queue a job of any registered kind, e.g. to back up the database now rather than waiting for the next one
+func (a *Api) queueJob(writer http.ResponseWriter, request *http.Request) {
+	var body queueJobRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Kind == "" {
+		http.Error(writer, "admin and kind are required", http.StatusBadRequest)
+		return
+	}
+
+	j, err := a.hub.Jobs.Queue(body.Kind, body.Payload)
+	if errors.Is(err, server.ErrUnknownJobKind) {
+		http.Error(writer, "unknown kind", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error queueing %s job: %v", body.Kind, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "job.queued", body.Kind, fmt.Sprintf("job %d", j.ID))
+	writeJson(writer, newJob(j))
+}
+
+// Give a job that's failed for good another go
+func (a *Api) retryJob(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+	var body retryJobRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil || body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	j, err := a.hub.Jobs.Retry(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, "job not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, server.ErrJobNotFailed) {
+		http.Error(writer, "job is "+j.Status, http.StatusConflict)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error retrying job %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "job.retried", j.Kind, fmt.Sprintf("job %d", j.ID))
+	writeJson(writer, newJob(j))
+}
@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/internal/server/logging"
+)
+
+type setLogModuleRequest struct {
+	// Who is changing it, for the audit log
+	Admin string `json:"admin"`
+
+	// One of debug, info, error or off, left as it is if left out
+	Level string `json:"level"`
+
+	// Log one in this many sampled lines, e.g. for each movement packet, left as it is if left out
+	SampleEvery int64 `json:"sample_every"`
+}
+
+type logModule struct {
+	Name        string `json:"name"`
+	Level       string `json:"level"`
+	SampleEvery int64  `json:"sample_every"`
+}
+
+func (a *Api) listLogModules(writer http.ResponseWriter, _ *http.Request) {
+	modules := logging.Modules()
+	result := make([]logModule, len(modules))
+	for i, module := range modules {
+		result[i] = logModule{Name: module.Name(), Level: module.Level().String(), SampleEvery: module.SampleEvery()}
+	}
+	writeJson(writer, result)
+}
+
+// Change what a module logs, until the server restarts
+func (a *Api) setLogModule(writer http.ResponseWriter, request *http.Request) {
+	var body setLogModuleRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	if body.SampleEvery < 0 {
+		http.Error(writer, "sample_every must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	module, exists := logging.Lookup(request.PathValue("module"))
+	if !exists {
+		http.Error(writer, "log module not found", http.StatusNotFound)
+		return
+	}
+	level := module.Level()
+	if body.Level != "" {
+		var err error
+		if level, err = logging.ParseLevel(body.Level); err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	module.SetLevel(level)
+	if body.SampleEvery > 0 {
+		module.SetSampleEvery(body.SampleEvery)
+	}
+
+	a.hub.Audit(body.Admin, "logging.changed", module.Name(), fmt.Sprintf("level %s, sampling one in %d", level, module.SampleEvery()))
+	writeJson(writer, logModule{Name: module.Name(), Level: level.String(), SampleEvery: module.SampleEvery()})
+}
@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+// Who bulk mail is from unless the request says otherwise
+const defaultBulkMailSender = "The Team"
+
+type bulkMailRequest struct {
+	// Who is sending it, for the audit log
+	Admin   string `json:"admin"`
+	Sender  string `json:"sender"`
+	Subject string `json:"subject"`
+
+	// An optional attachment, which can be coins
+	ItemId   string `json:"item_id"`
+	Quantity int64  `json:"quantity"`
+
+	// Who it's sent to, everyone if left out. Last login times are unix milliseconds.
+	MinBestScore    int64 `json:"min_best_score"`
+	MaxBestScore    int64 `json:"max_best_score"`
+	LastLoginAfter  int64 `json:"last_login_after"`
+	LastLoginBefore int64 `json:"last_login_before"`
+}
+
+type bulkMailJob struct {
+	Id         int64      `json:"id"`
+	Admin      string     `json:"admin"`
+	Sender     string     `json:"sender"`
+	Subject    string     `json:"subject"`
+	ItemId     string     `json:"item_id,omitempty"`
+	Quantity   int64      `json:"quantity,omitempty"`
+	Status     string     `json:"status"`
+	Total      int64      `json:"total"`
+	Sent       int64      `json:"sent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func newBulkMailJob(job db.BulkMailJob) bulkMailJob {
+	result := bulkMailJob{
+		Id:        job.ID,
+		Admin:     job.Admin,
+		Sender:    job.Sender,
+		Subject:   job.Subject,
+		ItemId:    job.ItemID,
+		Quantity:  job.Quantity,
+		Status:    job.Status,
+		Total:     job.Total,
+		Sent:      job.Sent,
+		CreatedAt: time.UnixMilli(job.CreatedAt),
+	}
+	if job.FinishedAt.Valid {
+		finishedAt := time.UnixMilli(job.FinishedAt.Int64)
+		result.FinishedAt = &finishedAt
+	}
+	return result
+}
+
+// Queue mail to every player, or a cohort of them. It's sent in the background; the job returned has its progress.
+func (a *Api) queueBulkMail(writer http.ResponseWriter, request *http.Request) {
+	var body bulkMailRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Subject == "" {
+		http.Error(writer, "admin and subject are required", http.StatusBadRequest)
+		return
+	}
+	if body.Quantity < 0 || (body.ItemId == "") != (body.Quantity == 0) {
+		http.Error(writer, "an attachment needs both an item and a quantity", http.StatusBadRequest)
+		return
+	}
+	if body.Sender == "" {
+		body.Sender = defaultBulkMailSender
+	}
+
+	cohort := server.BulkMailCohort{
+		MinBestScore: body.MinBestScore,
+		MaxBestScore: body.MaxBestScore,
+	}
+	if body.LastLoginAfter != 0 {
+		cohort.LastLoginAfter = time.UnixMilli(body.LastLoginAfter)
+	}
+	if body.LastLoginBefore != 0 {
+		cohort.LastLoginBefore = time.UnixMilli(body.LastLoginBefore)
+	}
+
+	job, err := a.hub.BulkMailer.Queue(body.Admin, body.Sender, body.Subject, body.ItemId, body.Quantity, cohort)
+	if err != nil {
+		logging.Admin.Errorf("Error queueing bulk mail: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	detail := fmt.Sprintf("job %d to %d players: %q", job.ID, job.Total, job.Subject)
+	if job.ItemID != "" {
+		detail += fmt.Sprintf(" with %d %s", job.Quantity, job.ItemID)
+	}
+	a.hub.Audit(body.Admin, "mail.bulk_queued", "players", detail)
+
+	writeJson(writer, newBulkMailJob(job))
+}
+
+// Query parameters: limit (default 20)
+func (a *Api) listBulkMail(writer http.ResponseWriter, request *http.Request) {
+	limit := 20
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	jobs, err := a.hub.NewDbTx().Queries.GetBulkMailJobs(request.Context(), int64(limit))
+	if err != nil {
+		logging.Admin.Errorf("Error getting bulk mail jobs: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]bulkMailJob, len(jobs))
+	for i, job := range jobs {
+		result[i] = newBulkMailJob(job)
+	}
+	writeJson(writer, result)
+}
+
+func (a *Api) getBulkMail(writer http.ResponseWriter, request *http.Request) {
+	job, ok := a.bulkMailJob(writer, request)
+	if !ok {
+		return
+	}
+	writeJson(writer, newBulkMailJob(job))
+}
+
+// Query parameters: admin (who is cancelling it, for the audit log, default the admin who queued it)
+func (a *Api) cancelBulkMail(writer http.ResponseWriter, request *http.Request) {
+	job, ok := a.bulkMailJob(writer, request)
+	if !ok {
+		return
+	}
+	if job.Status != server.BulkMailQueued && job.Status != server.BulkMailRunning {
+		http.Error(writer, "job already "+job.Status, http.StatusConflict)
+		return
+	}
+
+	if err := a.hub.BulkMailer.Cancel(job.ID); err != nil {
+		logging.Admin.Errorf("Error cancelling bulk mail job %d: %v", job.ID, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		by = job.Admin
+	}
+	a.hub.Audit(by, "mail.bulk_cancelled", "players", fmt.Sprintf("job %d", job.ID))
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// The job in the request's path, or false if it's been answered with an error
+func (a *Api) bulkMailJob(writer http.ResponseWriter, request *http.Request) (db.BulkMailJob, bool) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return db.BulkMailJob{}, false
+	}
+
+	job, err := a.hub.NewDbTx().Queries.GetBulkMailJob(request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, "job not found", http.StatusNotFound)
+		return db.BulkMailJob{}, false
+	} else if err != nil {
+		logging.Admin.Errorf("Error getting bulk mail job %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return db.BulkMailJob{}, false
+	}
+	return job, true
+}
@@ -0,0 +1,149 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type moderationCase struct {
+	Id           int64      `json:"id"`
+	ReporterId   int64      `json:"reporter_id"`
+	ReporterName string     `json:"reporter_name"`
+	ReportedId   int64      `json:"reported_id"`
+	ReportedName string     `json:"reported_name"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	Resolution   string     `json:"resolution,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+
+	// Only when getting a single case, since they're big
+	Snapshot json.RawMessage `json:"snapshot,omitempty"`
+}
+
+func newModerationCase(dbCase db.ModerationCase, withSnapshot bool) moderationCase {
+	result := moderationCase{
+		Id:           dbCase.ID,
+		ReporterId:   dbCase.ReporterID,
+		ReporterName: dbCase.ReporterName,
+		ReportedId:   dbCase.ReportedID,
+		ReportedName: dbCase.ReportedName,
+		Reason:       dbCase.Reason,
+		Status:       dbCase.Status,
+		Resolution:   dbCase.Resolution,
+		CreatedAt:    time.UnixMilli(dbCase.CreatedAt),
+	}
+	if dbCase.ClosedAt.Valid {
+		closedAt := time.UnixMilli(dbCase.ClosedAt.Int64)
+		result.ClosedAt = &closedAt
+	}
+	if withSnapshot {
+		result.Snapshot = json.RawMessage(dbCase.Snapshot)
+	}
+	return result
+}
+
+// Cases opened by players reporting each other, oldest first, without their snapshots.
+// Query parameters: status (default open), limit (default 100)
+func (a *Api) listModerationCases(writer http.ResponseWriter, request *http.Request) {
+	status := request.URL.Query().Get("status")
+	if status == "" {
+		status = server.ModerationCaseOpen
+	}
+	limit := 100
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	cases, err := a.hub.NewDbTx().Queries.GetModerationCasesByStatus(request.Context(), db.GetModerationCasesByStatusParams{
+		Status: status,
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error getting moderation cases: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]moderationCase, len(cases))
+	for i, dbCase := range cases {
+		result[i] = newModerationCase(dbCase, false)
+	}
+	writeJson(writer, result)
+}
+
+// A case along with the snapshot of what the reported player was doing
+func (a *Api) getModerationCase(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	dbCase, err := a.hub.NewDbTx().Queries.GetModerationCase(request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(writer, "case not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error getting moderation case %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(writer, newModerationCase(dbCase, true))
+}
+
+type closeModerationCaseRequest struct {
+	// Who closed it, for the audit log
+	Admin string `json:"admin"`
+
+	// What was done about it, if anything
+	Resolution string `json:"resolution"`
+}
+
+func (a *Api) closeModerationCase(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body closeModerationCaseRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.Resolution == "" {
+		http.Error(writer, "admin and resolution are required", http.StatusBadRequest)
+		return
+	}
+
+	dbCase, err := a.hub.ModerationCases.Close(id, body.Resolution, body.Admin)
+	if errors.Is(err, server.ErrCaseNotFound) {
+		http.Error(writer, "case not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, server.ErrCaseClosed) {
+		http.Error(writer, "case already closed", http.StatusConflict)
+		return
+	} else if errors.Is(err, server.ErrDbUnavailable) {
+		http.Error(writer, "database unavailable", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error closing moderation case %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(writer, newModerationCase(dbCase, false))
+}
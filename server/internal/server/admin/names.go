@@ -0,0 +1,185 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strings"
+	"time"
+)
+
+type reserveNameRequest struct {
+	// Who is reserving it, for the audit log
+	Admin string `json:"admin"`
+
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+
+	// Either "name", the default, which reserves just that name, or "term", which keeps it out of any name
+	Kind string `json:"kind"`
+}
+
+type reservedName struct {
+	Name       string    `json:"name"`
+	Kind       string    `json:"kind"`
+	Reason     string    `json:"reason"`
+	ReservedBy string    `json:"reserved_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type renameRequest struct {
+	// Who is renaming them, for the audit log
+	Admin string `json:"admin"`
+
+	NewName string `json:"new_name"`
+}
+
+func (a *Api) listReservedNames(writer http.ResponseWriter, request *http.Request) {
+	rows, err := a.hub.NewDbTx().Queries.GetReservedNames(request.Context())
+	if err != nil {
+		logging.Admin.Errorf("Error getting reserved names: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	names := make([]reservedName, len(rows))
+	for i, row := range rows {
+		names[i] = reservedName{
+			Name:       row.Name,
+			Kind:       row.Kind,
+			Reason:     row.Reason,
+			ReservedBy: row.ReservedBy,
+			CreatedAt:  time.UnixMilli(row.CreatedAt),
+		}
+	}
+	writeJson(writer, names)
+}
+
+// Reserve a name so no one new can claim it. Whoever already has it keeps it.
+func (a *Api) reserveName(writer http.ResponseWriter, request *http.Request) {
+	var body reserveNameRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	name := strings.ToLower(strings.TrimSpace(body.Name))
+	if body.Admin == "" || name == "" {
+		http.Error(writer, "admin and name are required", http.StatusBadRequest)
+		return
+	}
+	if body.Kind == "" {
+		body.Kind = server.ReservedNameKindName
+	}
+	if body.Kind != server.ReservedNameKindName && body.Kind != server.ReservedNameKindTerm {
+		http.Error(writer, "kind must be name or term", http.StatusBadRequest)
+		return
+	}
+
+	dbTx := a.hub.NewDbTx()
+	err := dbTx.Queries.CreateReservedName(request.Context(), db.CreateReservedNameParams{
+		Name:       name,
+		Kind:       body.Kind,
+		Reason:     body.Reason,
+		ReservedBy: body.Admin,
+		CreatedAt:  time.Now().UnixMilli(),
+	})
+	if err != nil {
+		// The name is the primary key, so failing here almost always means it's reserved already
+		if _, lookupErr := dbTx.Queries.GetReservedNameMatch(request.Context(), name); lookupErr == nil {
+			http.Error(writer, "already reserved", http.StatusConflict)
+			return
+		}
+		logging.Admin.Errorf("Error reserving name %s: %v", name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "name.reserved", name, fmt.Sprintf("%s: %s", body.Kind, body.Reason))
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Query parameters: admin (who is releasing it, for the audit log)
+func (a *Api) releaseReservedName(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	name := strings.ToLower(request.PathValue("name"))
+	deleted, err := a.hub.NewDbTx().Queries.DeleteReservedName(request.Context(), name)
+	if err != nil {
+		logging.Admin.Errorf("Error releasing reserved name %s: %v", name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if deleted == 0 {
+		http.Error(writer, "not reserved", http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "name.unreserved", name, "")
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Rename a player, who must be offline. The new name is held to the same rules as registering with it, so reserved
+// names can only be given out by releasing them first.
+func (a *Api) renamePlayer(writer http.ResponseWriter, request *http.Request) {
+	var body renameRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" || body.NewName == "" {
+		http.Error(writer, "admin and new_name are required", http.StatusBadRequest)
+		return
+	}
+	if len(body.NewName) > 20 || body.NewName != strings.TrimSpace(body.NewName) {
+		http.Error(writer, "invalid new_name", http.StatusBadRequest)
+		return
+	}
+
+	dbTx := a.hub.NewDbTx()
+	player, err := dbTx.Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+	if _, online := a.hub.ClientByPlayerDbId(player.ID); online {
+		http.Error(writer, "player is online", http.StatusConflict)
+		return
+	}
+
+	// Only changing the case of their name doesn't need the name to be free
+	if !strings.EqualFold(player.Name, body.NewName) {
+		err = a.hub.Names.Claim(body.NewName)
+		if errors.Is(err, server.ErrNameTaken) || errors.Is(err, server.ErrNameReserved) {
+			http.Error(writer, err.Error(), http.StatusConflict)
+			return
+		} else if err != nil {
+			logging.Admin.Errorf("Error claiming name %s: %v", body.NewName, err)
+			http.Error(writer, "internal error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	err = dbTx.InTx(func(queries *db.Queries) error {
+		err := queries.UpdateUsername(dbTx.Ctx, db.UpdateUsernameParams{Username: strings.ToLower(body.NewName), ID: player.UserID})
+		if err != nil {
+			return err
+		}
+		return queries.UpdatePlayerName(dbTx.Ctx, db.UpdatePlayerNameParams{Name: body.NewName, ID: player.ID})
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error renaming player %s to %s: %v", player.Name, body.NewName, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	a.hub.Audit(body.Admin, "player.renamed", player.Name, "renamed to "+body.NewName)
+	writer.WriteHeader(http.StatusNoContent)
+}
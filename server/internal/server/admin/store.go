@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+type storeReceipt struct {
+	Id         int64      `json:"id"`
+	PlayerId   int64      `json:"player_id"`
+	EntryId    string     `json:"entry_id"`
+	Platform   string     `json:"platform"`
+	ProductId  string     `json:"product_id"`
+	Receipt    string     `json:"receipt"`
+	Status     string     `json:"status"`
+	Reason     string     `json:"reason,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+func (a *Api) newStoreReceipt(receipt db.StoreReceipt) storeReceipt {
+	result := storeReceipt{
+		Id:        receipt.ID,
+		PlayerId:  receipt.PlayerID,
+		EntryId:   receipt.EntryID,
+		Platform:  receipt.Platform,
+		Receipt:   receipt.Receipt,
+		Status:    receipt.Status,
+		Reason:    receipt.Reason,
+		CreatedAt: time.UnixMilli(receipt.CreatedAt),
+	}
+	if entry, exists := a.hub.GameData.StoreEntry(receipt.EntryID); exists {
+		result.ProductId = entry.Products[receipt.Platform]
+	}
+	if receipt.ResolvedAt.Valid {
+		resolvedAt := time.UnixMilli(receipt.ResolvedAt.Int64)
+		result.ResolvedAt = &resolvedAt
+	}
+	return result
+}
+
+// Receipts from app store purchases, for an external service to check the ones with no validator on this server.
+// Query parameters: status (default pending), limit (default 100)
+func (a *Api) listStoreReceipts(writer http.ResponseWriter, request *http.Request) {
+	status := request.URL.Query().Get("status")
+	if status == "" {
+		status = server.StoreReceiptPending
+	}
+	limit := 100
+	if param := request.URL.Query().Get("limit"); param != "" {
+		var err error
+		if limit, err = strconv.Atoi(param); err != nil || limit <= 0 {
+			http.Error(writer, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	receipts, err := a.hub.NewDbTx().Queries.GetStoreReceiptsByStatus(request.Context(), db.GetStoreReceiptsByStatusParams{
+		Status: status,
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		logging.Admin.Errorf("Error getting store receipts: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]storeReceipt, len(receipts))
+	for i, receipt := range receipts {
+		result[i] = a.newStoreReceipt(receipt)
+	}
+	writeJson(writer, result)
+}
+
+type resolveStoreReceiptRequest struct {
+	// Who or what checked it, for the audit log
+	Admin string `json:"admin"`
+
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// Approve a pending receipt, granting the player what they bought, or reject it
+func (a *Api) resolveStoreReceipt(writer http.ResponseWriter, request *http.Request) {
+	id, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(writer, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body resolveStoreReceiptRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	receipt, err := a.hub.Store.Resolve(id, body.Approved, body.Reason, body.Admin)
+	if errors.Is(err, server.ErrReceiptNotFound) {
+		http.Error(writer, "receipt not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, server.ErrReceiptResolved) {
+		http.Error(writer, "receipt already resolved", http.StatusConflict)
+		return
+	} else if errors.Is(err, server.ErrStoreEntryAbsent) {
+		http.Error(writer, "the store entry it's for no longer exists", http.StatusConflict)
+		return
+	} else if errors.Is(err, server.ErrDbUnavailable) {
+		http.Error(writer, "database unavailable", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		logging.Admin.Errorf("Error resolving store receipt %d: %v", id, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writeJson(writer, a.newStoreReceipt(receipt))
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server"
+	"strconv"
+)
+
+type taggedObject struct {
+	Id     uint64   `json:"id"`
+	Kind   string   `json:"kind"`
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Radius float64  `json:"radius"`
+	Tags   []string `json:"tags"`
+}
+
+// Query parameters: kind (actor, spore, resource_node or emitter), zone, and x, y and radius, which narrow down what's
+// found to what's within the radius of x, y
+func (a *Api) queryTag(writer http.ResponseWriter, request *http.Request) {
+	query, err := a.tagQuery(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := []taggedObject{}
+	for _, found := range a.hub.WorldTags.Query(query) {
+		result = append(result, taggedObject{
+			Id:     found.Id,
+			Kind:   string(found.Kind),
+			X:      found.X,
+			Y:      found.Y,
+			Radius: found.Radius,
+			Tags:   found.Tags,
+		})
+	}
+	writeJson(writer, result)
+}
+
+// Despawn everything with the tag, e.g. from a script once the world event it was placed for is over. Players are
+// never despawned, only what the world put there.
+// Query parameters: admin (who is despawning them, for the audit log), and any of those queryTag takes
+func (a *Api) despawnTag(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	query, err := a.tagQuery(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	despawned := a.hub.WorldTags.Despawn(query)
+	a.hub.Audit(by, "tag.despawned", query.Tag, fmt.Sprintf("despawned %d objects", despawned))
+	writeJson(writer, map[string]int{"despawned": despawned})
+}
+
+func (a *Api) tagQuery(request *http.Request) (server.TagQuery, error) {
+	params := request.URL.Query()
+	query := server.TagQuery{Tag: request.PathValue("tag"), Kind: server.ObjectKind(params.Get("kind")), ZoneId: params.Get("zone")}
+	switch query.Kind {
+	case "", server.ObjectActor, server.ObjectSpore, server.ObjectResourceNode, server.ObjectEmitter:
+	default:
+		return query, errors.New("unknown kind")
+	}
+	if query.ZoneId != "" {
+		if _, exists := a.hub.GameData.Zone(query.ZoneId); !exists {
+			return query, errors.New("unknown zone")
+		}
+	}
+
+	if param := params.Get("radius"); param != "" {
+		var err error
+		if query.Radius, err = strconv.ParseFloat(param, 64); err != nil || query.Radius <= 0 {
+			return query, errors.New("invalid radius")
+		}
+		if query.X, err = strconv.ParseFloat(params.Get("x"), 64); err != nil {
+			return query, errors.New("invalid x")
+		}
+		if query.Y, err = strconv.ParseFloat(params.Get("y"), 64); err != nil {
+			return query, errors.New("invalid y")
+		}
+	}
+	return query, nil
+}
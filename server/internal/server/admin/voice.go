@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/internal/server/logging"
+	"time"
+)
+
+type voiceMuteRequest struct {
+	// Who is muting them, for the audit log
+	Admin string `json:"admin"`
+
+	Reason string `json:"reason"`
+
+	// How long for, for good if left out
+	Minutes int64 `json:"minutes"`
+}
+
+// Mute a player from voice chat. Any calls they're in are hung up, and they can't start new ones until the mute runs
+// out or is lifted.
+func (a *Api) muteVoice(writer http.ResponseWriter, request *http.Request) {
+	var body voiceMuteRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.Admin == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+	if body.Minutes < 0 {
+		http.Error(writer, "minutes must not be negative", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	duration := time.Duration(body.Minutes) * time.Minute
+	if err := a.hub.Voice.SetMute(body.Admin, player.ID, body.Reason, duration); err != nil {
+		logging.Admin.Errorf("Error muting player %s from voice chat: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	detail := "for good"
+	if duration > 0 {
+		detail = "for " + duration.String()
+	}
+	if body.Reason != "" {
+		detail += ": " + body.Reason
+	}
+	a.hub.Audit(body.Admin, "voice.muted", player.Name, detail)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Query parameters: admin (who is unmuting them, for the audit log)
+func (a *Api) unmuteVoice(writer http.ResponseWriter, request *http.Request) {
+	by := request.URL.Query().Get("admin")
+	if by == "" {
+		http.Error(writer, "admin is required", http.StatusBadRequest)
+		return
+	}
+
+	player, err := a.hub.NewDbTx().Queries.GetPlayerByName(request.Context(), request.PathValue("name"))
+	if err != nil {
+		http.Error(writer, "player not found", http.StatusNotFound)
+		return
+	}
+
+	unmuted, err := a.hub.Voice.Unmute(player.ID)
+	if err != nil {
+		logging.Admin.Errorf("Error unmuting player %s from voice chat: %v", player.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !unmuted {
+		http.Error(writer, fmt.Sprintf("%s is not muted", player.Name), http.StatusNotFound)
+		return
+	}
+
+	a.hub.Audit(by, "voice.unmuted", player.Name, "")
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"slices"
+	"strconv"
+)
+
+// How big a world dump to diff against can be
+const maxWorldDumpBytes = 32 << 20
+
+// Everything in the world as the server has it right now
+func (a *Api) getWorld(writer http.ResponseWriter, _ *http.Request) {
+	writeJson(writer, a.hub.DumpWorld())
+}
+
+// Diff the world right now against the dump in the body, e.g. what a client thinks the world is, with the server's
+// dump first. Query parameters: tolerance (how far apart numbers can be and still be the same, default 0) and common
+// (true to only compare objects in both, e.g. when the body is only what a client can see)
+func (a *Api) diffWorld(writer http.ResponseWriter, request *http.Request) {
+	tolerance := 0.0
+	if param := request.URL.Query().Get("tolerance"); param != "" {
+		var err error
+		if tolerance, err = strconv.ParseFloat(param, 64); err != nil || tolerance < 0 {
+			http.Error(writer, "invalid tolerance", http.StatusBadRequest)
+			return
+		}
+	}
+
+	other, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxWorldDumpBytes))
+	if err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	dump, err := json.Marshal(a.hub.DumpWorld())
+	if err != nil {
+		logging.Admin.Errorf("Error dumping the world: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	diffs, err := server.DiffWorldDumps(dump, other, tolerance)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.URL.Query().Get("common") == "true" {
+		diffs = slices.DeleteFunc(diffs, server.WorldDiff.Missing)
+	}
+	if diffs == nil {
+		diffs = []server.WorldDiff{}
+	}
+	writeJson(writer, diffs)
+}
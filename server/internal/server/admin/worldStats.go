@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"net/http"
+	"server/internal/server"
+	"strconv"
+)
+
+type worldStats struct {
+	// Players online right now, since the day's peak is only as of the last rollup
+	Online int `json:"online"`
+
+	// Oldest first
+	Days []server.WorldStatsDay `json:"days"`
+}
+
+// The world's daily statistics, for the dashboard's graphs.
+// Query parameters: days (default 90, at most 365)
+func (a *Api) getWorldStats(writer http.ResponseWriter, request *http.Request) {
+	days := 90
+	if param := request.URL.Query().Get("days"); param != "" {
+		var err error
+		if days, err = strconv.Atoi(param); err != nil || days <= 0 || days > server.MaxWorldStatsDays {
+			http.Error(writer, "invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+	writeJson(writer, worldStats{Online: a.hub.Sessions.Count(), Days: a.hub.WorldStats.History(days)})
+}
@@ -0,0 +1,308 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// How often alert rules are checked unless configured otherwise, which is also the window rates are over
+	DefaultAlertInterval = 30 * time.Second
+
+	// How long a rule that's still broken waits to alert again unless configured otherwise
+	DefaultAlertCooldown = 15 * time.Minute
+)
+
+// How long each notifier has to send an alert
+const alertNotifyTimeout = 10 * time.Second
+
+var (
+	alertsTotal              = metrics.NewCounterVec("mmo_alerts_total", "Alerts sent, by rule.", "rule")
+	alertNotifyFailuresTotal = metrics.NewCounterVec("mmo_alert_notify_failures_total", "Alerts that couldn't be sent, by notifier.", "notifier")
+	alertsFiringGauge        = metrics.NewGauge("mmo_alerts_firing", "Alert rules broken when they were last checked.")
+)
+
+// A threshold on one of the server's metrics, e.g. mmo_log_errors_total>1/s
+type AlertRule struct {
+	// As written, which is also what it's called in alerts
+	Name   string
+	Metric string
+
+	// Whether it's the rate a counter goes up, or a histogram is observed, per second that's compared
+	PerSecond bool
+
+	// Whether it's broken by going below the threshold rather than above it
+	Below     bool
+	Threshold float64
+}
+
+// A rule that was broken, or is working again
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Metric    string    `json:"metric"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Resolved  bool      `json:"resolved"`
+	At        time.Time `json:"at"`
+}
+
+func (a Alert) String() string {
+	if a.Resolved {
+		return fmt.Sprintf("Resolved: %s, now %g", a.Rule, a.Value)
+	}
+	return fmt.Sprintf("%s, at %g", a.Rule, a.Value)
+}
+
+// Somewhere alerts are sent, e.g. to whoever's on call
+type AlertNotifier interface {
+	// For logs and metrics
+	Name() string
+	Notify(ctx context.Context, alert Alert) error
+}
+
+type alertState struct {
+	rule AlertRule
+
+	// The reading at the last check, which rates and averages are worked out from
+	last metrics.Reading
+	read bool
+
+	firing     bool
+	notifiedAt time.Time
+}
+
+// Keeps an eye on the server's metrics, telling operators when any of them cross the thresholds they've set, so they
+// learn of the server getting worse before players have to tell them
+type Alerts struct {
+	interval  time.Duration
+	cooldown  time.Duration
+	rules     []*alertState
+	notifiers []AlertNotifier
+	mux       sync.Mutex
+}
+
+func NewAlerts() *Alerts {
+	return &Alerts{interval: DefaultAlertInterval, cooldown: DefaultAlertCooldown}
+}
+
+// Must be called before the hub is run. Rules are separated by commas, each a metric, > or <, and a threshold, which
+// ends in /s for the rate a counter goes up or a histogram is observed at. Histograms are otherwise compared by the
+// average observed since the last check, and metrics with labels by their sum or, for gauges, the highest.
+func (a *Alerts) Configure(rules string, interval time.Duration, cooldown time.Duration) error {
+	if interval > 0 {
+		a.interval = interval
+	}
+	if cooldown > 0 {
+		a.cooldown = cooldown
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		if rule = strings.TrimSpace(rule); rule == "" {
+			continue
+		}
+		parsed, err := parseAlertRule(rule)
+		if err != nil {
+			return err
+		}
+		a.rules = append(a.rules, &alertState{rule: parsed})
+		logging.Hub.Printf("Alerting when %s", parsed.Name)
+	}
+	return nil
+}
+
+// Send alerts to the notifier too
+func (a *Alerts) AddNotifier(notifier AlertNotifier) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.notifiers = append(a.notifiers, notifier)
+}
+
+func parseAlertRule(rule string) (AlertRule, error) {
+	index := strings.IndexAny(rule, "<>")
+	if index < 0 {
+		return AlertRule{}, fmt.Errorf("expected metric>threshold or metric<threshold, got %q", rule)
+	}
+	parsed := AlertRule{Name: rule, Metric: strings.TrimSpace(rule[:index]), Below: rule[index] == '<'}
+
+	value, perSecond := strings.CutSuffix(strings.TrimSpace(rule[index+1:]), "/s")
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return AlertRule{}, fmt.Errorf("%s: invalid threshold %q", parsed.Metric, value)
+	}
+	parsed.Threshold, parsed.PerSecond = threshold, perSecond
+
+	reading, exists := metrics.Read(parsed.Metric)
+	if !exists {
+		return AlertRule{}, fmt.Errorf("unknown metric %q", parsed.Metric)
+	}
+	if perSecond && reading.Kind == "gauge" {
+		return AlertRule{}, fmt.Errorf("%s: gauges don't have a rate, so the threshold can't end in /s", parsed.Metric)
+	}
+	return parsed, nil
+}
+
+func (a *Alerts) watchLoop() {
+	if len(a.rules) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		a.check()
+	}
+}
+
+func (a *Alerts) check() {
+	firing := 0
+	for _, state := range a.rules {
+		reading, _ := metrics.Read(state.rule.Metric)
+		value, known := state.value(reading, a.interval)
+		state.last, state.read = reading, true
+		if !known {
+			continue
+		}
+
+		broken := value > state.rule.Threshold
+		if state.rule.Below {
+			broken = value < state.rule.Threshold
+		}
+		if broken {
+			firing++
+		}
+
+		now := time.Now()
+		switch {
+		case broken && now.Sub(state.notifiedAt) >= a.cooldown:
+			state.firing, state.notifiedAt = true, now
+			alertsTotal.With(state.rule.Name).Inc()
+			a.notify(Alert{Rule: state.rule.Name, Metric: state.rule.Metric, Value: value, Threshold: state.rule.Threshold, At: now})
+		case !broken && state.firing:
+			state.firing = false
+			a.notify(Alert{Rule: state.rule.Name, Metric: state.rule.Metric, Value: value, Threshold: state.rule.Threshold, Resolved: true, At: now})
+		}
+	}
+	alertsFiringGauge.Set(float64(firing))
+}
+
+// What the rule compares with its threshold, unless there's nothing to compare yet, e.g. a rate before the second
+// check
+func (s *alertState) value(reading metrics.Reading, interval time.Duration) (float64, bool) {
+	switch {
+	case reading.Kind == "histogram" && s.rule.PerSecond:
+		return float64(reading.Count-s.last.Count) / interval.Seconds(), s.read
+	case reading.Kind == "histogram":
+		observed := reading.Count - s.last.Count
+		if !s.read || observed == 0 {
+			return 0, false
+		}
+		return (reading.Sum - s.last.Sum) / float64(observed), true
+	case s.rule.PerSecond:
+		return (reading.Value - s.last.Value) / interval.Seconds(), s.read
+	default:
+		return reading.Value, true
+	}
+}
+
+func (a *Alerts) notify(alert Alert) {
+	logging.Hub.Printf("ALERT: %s", alert)
+
+	a.mux.Lock()
+	notifiers := a.notifiers
+	a.mux.Unlock()
+
+	for _, notifier := range notifiers {
+		ctx, cancel := context.WithTimeout(context.Background(), alertNotifyTimeout)
+		if err := notifier.Notify(ctx, alert); err != nil {
+			logging.Hub.Errorf("Error sending alert to %s: %v", notifier.Name(), err)
+			alertNotifyFailuresTotal.With(notifier.Name()).Inc()
+		}
+		cancel()
+	}
+}
+
+// POSTs each alert as JSON, e.g. to a chat channel's incoming webhook. Any status other than 2xx counts as a failure.
+type WebhookAlertNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewWebhookAlertNotifier(url string) *WebhookAlertNotifier {
+	return &WebhookAlertNotifier{url: url, httpClient: &http.Client{}}
+}
+
+func (w *WebhookAlertNotifier) Name() string {
+	return "webhook " + w.url
+}
+
+func (w *WebhookAlertNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", response.Status)
+	}
+	return nil
+}
+
+// Emails each alert through an SMTP server, logging in if there's a username
+type EmailAlertNotifier struct {
+	addr     string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+// The address is the SMTP server's host and port, e.g. smtp.example.com:587
+func NewEmailAlertNotifier(addr string, username string, password string, from string, to []string) *EmailAlertNotifier {
+	return &EmailAlertNotifier{addr: addr, username: username, password: password, from: from, to: to}
+}
+
+func (e *EmailAlertNotifier) Name() string {
+	return "email " + e.addr
+}
+
+// SMTP has no way to be cancelled, so the context is only checked before sending
+func (e *EmailAlertNotifier) Notify(ctx context.Context, alert Alert) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if e.username != "" {
+		host, _, _ := strings.Cut(e.addr, ":")
+		auth = smtp.PlainAuth("", e.username, e.password, host)
+	}
+
+	subject := "[mmo alert] " + alert.String()
+	body := fmt.Sprintf("Rule: %s\r\nMetric: %s\r\nValue: %g\r\nThreshold: %g\r\nAt: %s\r\n",
+		alert.Rule, alert.Metric, alert.Value, alert.Threshold, alert.At.Format(time.RFC3339))
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", e.from, strings.Join(e.to, ", "), subject, body)
+	return smtp.SendMail(e.addr, auth, e.from, e.to, []byte(message))
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	AnnouncementsPath = "/api/announcements"
+
+	announcementPageSize = 20
+
+	// How many times each IP address or player can look through the history a minute
+	announcementRequestsPerMinute = 30
+)
+
+// A page of the announcement history, newest first, as served over HTTP
+type announcementPage struct {
+	Announcements []announcementEntry `json:"announcements"`
+	Page          int                 `json:"page"`
+	Pages         int                 `json:"pages"`
+}
+
+type announcementEntry struct {
+	Id        int64  `json:"id"`
+	Author    string `json:"author"`
+	Message   string `json:"message"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Requests made in the current minute
+type announcementRequests struct {
+	since time.Time
+	count int
+}
+
+// Announcements made to the whole server, by admins or by the server itself like restart countdowns. Each is kept in
+// the database, so players who were offline when it was made can look back through them, in game or at
+// AnnouncementsPath. Looking through them is rate limited for each IP address and player, since anyone can.
+type Announcements struct {
+	hub *Hub
+
+	requests map[string]*announcementRequests
+	prunedAt time.Time
+	mux      sync.Mutex
+}
+
+func NewAnnouncements(hub *Hub) *Announcements {
+	return &Announcements{
+		hub:      hub,
+		requests: make(map[string]*announcementRequests),
+		prunedAt: time.Now(),
+	}
+}
+
+// Tell everyone in game, and keep it for anyone who isn't
+func (a *Announcements) Announce(author string, message string) {
+	a.record(author, message)
+	a.hub.Broadcast(&packets.Packet{
+		SenderId: 0,
+		Msg:      packets.NewChat(message),
+	})
+}
+
+// Keep the announcement without telling anyone, for those made to each player differently, e.g. in their own time.
+// The write waits for the database if it's unavailable, so nothing is lost to an outage.
+func (a *Announcements) record(author string, message string) {
+	logging.Hub.Printf("Announcement by %s: %s", author, message)
+
+	announcement := db.CreateAnnouncementParams{
+		Author:    author,
+		Message:   message,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	err := a.hub.NewDbTx().WriteBehind("announcement", func(ctx context.Context, queries *db.Queries) error {
+		return queries.CreateAnnouncement(ctx, announcement)
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error recording announcement by %s: %v", author, err)
+	}
+}
+
+// A page of the announcements made since the given time, in Unix milliseconds, newest first, along with how many
+// pages there are. Pages past the last are the last.
+func (a *Announcements) History(ctx context.Context, queries *db.Queries, page int, since int64) ([]db.Announcement, int, int, error) {
+	total, err := queries.CountAnnouncements(ctx, since)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	pages := max(int((total+announcementPageSize-1)/announcementPageSize), 1)
+	page = min(max(page, 0), pages-1)
+
+	announcements, err := queries.GetAnnouncements(ctx, db.GetAnnouncementsParams{
+		CreatedAt: since,
+		Limit:     announcementPageSize,
+		Offset:    int64(page * announcementPageSize),
+	})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return announcements, page, pages, nil
+}
+
+// Whether whoever it is can look through the history again yet, by "ip " and their IP address or "player " and their
+// name
+func (a *Announcements) Allow(key string) bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	now := time.Now()
+	if now.Sub(a.prunedAt) >= time.Minute {
+		for key, requests := range a.requests {
+			if now.Sub(requests.since) >= time.Minute {
+				delete(a.requests, key)
+			}
+		}
+		a.prunedAt = now
+	}
+
+	requests, exists := a.requests[key]
+	if !exists || now.Sub(requests.since) >= time.Minute {
+		requests = &announcementRequests{since: now}
+		a.requests[key] = requests
+	}
+	if requests.count >= announcementRequestsPerMinute {
+		return false
+	}
+	requests.count++
+	return true
+}
+
+// Query parameters: page (default 0) and since (unix milliseconds, default forever)
+func (a *Announcements) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	ip, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		ip = request.RemoteAddr
+	}
+	if !a.Allow("ip " + ip) {
+		writer.Header().Set("Retry-After", "60")
+		http.Error(writer, "too many requests", http.StatusTooManyRequests)
+		return
+	}
+
+	page := 0
+	if param := request.URL.Query().Get("page"); param != "" {
+		if page, err = strconv.Atoi(param); err != nil || page < 0 {
+			http.Error(writer, "invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var since int64
+	if param := request.URL.Query().Get("since"); param != "" {
+		if since, err = strconv.ParseInt(param, 10, 64); err != nil {
+			http.Error(writer, "invalid since", http.StatusBadRequest)
+			return
+		}
+	}
+
+	dbTx := a.hub.NewDbTx()
+	announcements, page, pages, err := a.History(request.Context(), dbTx.Queries, page, since)
+	if err != nil {
+		logging.Hub.Errorf("Error getting announcements: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	response := announcementPage{
+		Announcements: make([]announcementEntry, len(announcements)),
+		Page:          page,
+		Pages:         pages,
+	}
+	for i, announcement := range announcements {
+		response.Announcements[i] = announcementEntry{
+			Id:        announcement.ID,
+			Author:    announcement.Author,
+			Message:   announcement.Message,
+			CreatedAt: announcement.CreatedAt,
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(response); err != nil {
+		logging.Hub.Errorf("Error writing announcements: %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	PublicApiPath = "/api/v1/"
+
+	// What every key starts with, so one pasted somewhere it shouldn't be is easy to spot
+	apiKeyPrefix = "mmo_"
+
+	// How much of each key is kept as it is, so admins can tell keys apart without the whole key
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+
+	DefaultApiKeyRequestsPerMinute = 60
+)
+
+// What each key can be allowed to read through the public API
+const (
+	// Every player's rank, name and best score
+	ApiScopeHiscores = "hiscores"
+
+	// What's sold on the auction house, for how much and when
+	ApiScopeMarket = "market"
+)
+
+var ApiScopes = []string{ApiScopeHiscores, ApiScopeMarket}
+
+var (
+	ErrUnknownApiScope = errors.New("unknown scope")
+	ErrNoApiScopes     = errors.New("at least one scope is required")
+)
+
+var publicApiRequestsTotal = metrics.NewCounterVec("mmo_public_api_requests_total", "Requests to the public API, by whether they were served or why not.", "result")
+
+// Requests made with a key in the current minute
+type apiKeyRequests struct {
+	since time.Time
+	count int
+}
+
+// Keys for community developers to read what anonymous requests can't from the public API at PublicApiPath, e.g. the
+// whole hiscores or the auction house's sales. Each key's only allowed what its scopes are for, and is rate limited on
+// its own, so one busy tool can't starve the rest. Keys are issued and revoked through the admin API, and only a hash
+// of each is kept, so the key itself is only ever seen when it's issued.
+type ApiKeys struct {
+	hub *Hub
+	mux *http.ServeMux
+
+	// By key ID
+	requests map[int64]*apiKeyRequests
+	prunedAt time.Time
+	limitMux sync.Mutex
+}
+
+func NewApiKeys(hub *Hub) *ApiKeys {
+	k := &ApiKeys{
+		hub:      hub,
+		mux:      http.NewServeMux(),
+		requests: make(map[int64]*apiKeyRequests),
+		prunedAt: time.Now(),
+	}
+	k.handle("GET "+PublicApiPath+"hiscores", ApiScopeHiscores, k.serveHiscores)
+	k.handle("GET "+PublicApiPath+"market/history", ApiScopeMarket, k.serveMarketHistory)
+	return k
+}
+
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// Issue a key allowed the scopes and that many requests a minute, returning its ID and the key itself, which can't be
+// looked up again
+func (k *ApiKeys) Issue(ctx context.Context, name string, scopes []string, requestsPerMinute int, by string) (int64, string, error) {
+	if len(scopes) == 0 {
+		return 0, "", ErrNoApiScopes
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(ApiScopes, scope) {
+			return 0, "", fmt.Errorf("%w %q", ErrUnknownApiScope, scope)
+		}
+	}
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = DefaultApiKeyRequestsPerMinute
+	}
+
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return 0, "", err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	id, err := k.hub.NewDbTx().Queries.CreateApiKey(ctx, db.CreateApiKeyParams{
+		Name:              name,
+		Prefix:            key[:apiKeyShownLength],
+		KeyHash:           hashApiKey(key),
+		Scopes:            strings.Join(scopes, ","),
+		RequestsPerMinute: int64(requestsPerMinute),
+		CreatedBy:         by,
+		CreatedAt:         time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return 0, "", err
+	}
+	logging.Hub.Printf("Issued API key %d (%s) for %s", id, name, strings.Join(scopes, ", "))
+	return id, key, nil
+}
+
+// Every key ever issued, revoked ones included
+func (k *ApiKeys) List(ctx context.Context) ([]db.ApiKey, error) {
+	return k.hub.NewDbTx().Queries.GetApiKeys(ctx)
+}
+
+// Stop the key working straight away. Returns false if there's no such key, or it was already revoked.
+func (k *ApiKeys) Revoke(ctx context.Context, id int64) (bool, error) {
+	revoked, err := k.hub.NewDbTx().Queries.RevokeApiKey(ctx, db.RevokeApiKeyParams{
+		RevokedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:        id,
+	})
+	if err != nil || revoked == 0 {
+		return false, err
+	}
+	logging.Hub.Printf("Revoked API key %d", id)
+	return true, nil
+}
+
+func (k *ApiKeys) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	k.mux.ServeHTTP(writer, request)
+}
+
+// Register an endpoint, which is only served to requests with a key allowed the scope that isn't over its rate limit.
+// Keys are given as a bearer token or in the X-Api-Key header.
+func (k *ApiKeys) handle(pattern string, scope string, handler func(http.ResponseWriter, *http.Request, db.ApiKey)) {
+	k.mux.HandleFunc(pattern, func(writer http.ResponseWriter, request *http.Request) {
+		key := request.Header.Get("X-Api-Key")
+		if bearer, found := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer "); found {
+			key = bearer
+		}
+		if !strings.HasPrefix(key, apiKeyPrefix) {
+			publicApiRequestsTotal.With("unauthorized").Inc()
+			http.Error(writer, "an API key is required", http.StatusUnauthorized)
+			return
+		}
+
+		apiKey, err := k.hub.NewDbTx().Queries.GetApiKeyByHash(request.Context(), hashApiKey(key))
+		if errors.Is(err, sql.ErrNoRows) {
+			publicApiRequestsTotal.With("unauthorized").Inc()
+			http.Error(writer, "invalid or revoked API key", http.StatusUnauthorized)
+			return
+		} else if err != nil {
+			logging.Hub.Errorf("Error looking up API key: %v", err)
+			http.Error(writer, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		if !slices.Contains(strings.Split(apiKey.Scopes, ","), scope) {
+			publicApiRequestsTotal.With("forbidden").Inc()
+			http.Error(writer, "this API key isn't allowed "+scope, http.StatusForbidden)
+			return
+		}
+		if !k.allow(apiKey) {
+			publicApiRequestsTotal.With("rate_limited").Inc()
+			writer.Header().Set("Retry-After", "60")
+			http.Error(writer, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+
+		publicApiRequestsTotal.With("served").Inc()
+		handler(writer, request, apiKey)
+	})
+}
+
+// Whether the key can make another request this minute
+func (k *ApiKeys) allow(apiKey db.ApiKey) bool {
+	k.limitMux.Lock()
+	defer k.limitMux.Unlock()
+
+	now := time.Now()
+	if now.Sub(k.prunedAt) >= time.Minute {
+		for id, requests := range k.requests {
+			if now.Sub(requests.since) >= time.Minute {
+				delete(k.requests, id)
+			}
+		}
+		k.prunedAt = now
+	}
+
+	requests, exists := k.requests[apiKey.ID]
+	if !exists || now.Sub(requests.since) >= time.Minute {
+		requests = &apiKeyRequests{since: now}
+		k.requests[apiKey.ID] = requests
+	}
+	if int64(requests.count) >= apiKey.RequestsPerMinute {
+		return false
+	}
+	requests.count++
+	return true
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"strings"
+	"time"
+)
+
+const (
+	// How often sold and expired listings are looked for, besides straight after each buyout
+	auctionSettleInterval = 5 * time.Second
+
+	auctionSettleBatchSize = 100
+
+	auctionSettleJob = "auctions.settle"
+
+	// Who mail from the auction house is from
+	AuctionHouseSender = "Auction House"
+)
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// Escape LIKE wildcards in what's matched against item IDs, for the auction queries that use ESCAPE '!'
+func EscapeItemPattern(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// Settles listings in the background. Items are taken from the seller when they're listed, and coins from the buyer
+// when they buy it out, and held by the listing until it's settled here: sold listings send the items to the buyer
+// and the coins to the seller, and expired ones send the items back to the seller, all by mail.
+type AuctionHouse struct {
+	hub *Hub
+}
+
+func NewAuctionHouse(hub *Hub) *AuctionHouse {
+	a := &AuctionHouse{hub: hub}
+	hub.Jobs.Register(auctionSettleJob, a.settle, JobOptions{Every: auctionSettleInterval})
+	return a
+}
+
+// Settle listings now rather than on the next interval, e.g. because one was just bought out
+func (a *AuctionHouse) Wake() {
+	if err := a.hub.Jobs.RunNow(auctionSettleJob); err != nil {
+		logging.Hub.Errorf("Error settling auction listings now: %v", err)
+	}
+}
+
+func (a *AuctionHouse) settle(ctx context.Context, _ json.RawMessage) error {
+	dbTx := a.hub.NewDbTx()
+	dbTx.Ctx = ctx
+	for {
+		listings, err := dbTx.Queries.GetUnsettledAuctionListings(dbTx.Ctx, db.GetUnsettledAuctionListingsParams{
+			ExpiresAt: time.Now().UnixMilli(),
+			Limit:     auctionSettleBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, listing := range listings {
+			if err := a.settleListing(dbTx, listing.ID); err != nil {
+				return fmt.Errorf("error settling listing %d: %w", listing.ID, err)
+			}
+		}
+
+		if len(listings) < auctionSettleBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *AuctionHouse) settleListing(dbTx *DbTx, listingId int64) error {
+	var notify []int64
+	expired := false
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		// Marking the listing settled first means no one can buy it out from under us while it's read again
+		settled, err := queries.SettleAuctionListing(dbTx.Ctx, db.SettleAuctionListingParams{
+			SettledAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:        listingId,
+		})
+		if err != nil || settled == 0 {
+			return err
+		}
+
+		listing, err := queries.GetAuctionListing(dbTx.Ctx, listingId)
+		if err != nil {
+			return err
+		}
+
+		if !listing.BuyerID.Valid {
+			expired = true
+			notify = []int64{listing.SellerID}
+			subject := fmt.Sprintf("Your listing of %d %s expired unsold", listing.Quantity, listing.ItemID)
+			return SendMail(dbTx.Ctx, queries, listing.SellerID, AuctionHouseSender, subject, listing.ItemID, listing.Quantity)
+		}
+
+		notify = []int64{listing.BuyerID.Int64, listing.SellerID}
+		subject := fmt.Sprintf("You bought %d %s", listing.Quantity, listing.ItemID)
+		if err := SendMail(dbTx.Ctx, queries, listing.BuyerID.Int64, AuctionHouseSender, subject, listing.ItemID, listing.Quantity); err != nil {
+			return err
+		}
+		subject = fmt.Sprintf("You sold %d %s", listing.Quantity, listing.ItemID)
+		if err := SendMail(dbTx.Ctx, queries, listing.SellerID, AuctionHouseSender, subject, gamedata.CurrencyItemId, listing.Price); err != nil {
+			return err
+		}
+
+		return dbTx.RecordEvent(queries, "auction.sold", map[string]any{
+			"listing_id": listing.ID,
+			"seller_id":  listing.SellerID,
+			"buyer_id":   listing.BuyerID.Int64,
+			"item_id":    listing.ItemID,
+			"quantity":   listing.Quantity,
+			"price":      listing.Price,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, playerDbId := range notify {
+		a.hub.NotifyMail(playerDbId)
+	}
+	// Bought listings left the feed when they were bought, not when they're settled
+	if expired {
+		a.hub.Subscriptions.Publish(FeedAuctions, packets.NewAuctionListingClosed(uint64(listingId), "expired"))
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"time"
+)
+
+// Record something an admin did, both in the log and in the database so it can be looked back on later. The write
+// waits for the database if it's unavailable, so nothing is lost to an outage.
+func (h *Hub) Audit(admin string, action string, target string, detail string) {
+	logging.Hub.Printf("AUDIT: %s %s %s: %s", admin, action, target, detail)
+
+	entry := db.CreateAuditLogEntryParams{
+		Admin:     admin,
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	description := fmt.Sprintf("audit log entry %s %s", action, target)
+	err := h.NewDbTx().Batch(description, func(ctx context.Context, queries *db.Queries) error {
+		return queries.CreateAuditLogEntry(ctx, entry)
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error writing audit log entry %s %s: %v", action, target, err)
+	}
+}
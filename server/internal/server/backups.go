@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"server/internal/server/logging"
+	"sort"
+	"time"
+)
+
+const (
+	DefaultBackupInterval = 24 * time.Hour
+	DefaultBackupKeep     = 7
+
+	backupJob = "db.backup"
+
+	// Copying a big database can take a while
+	backupTimeout = time.Hour
+)
+
+// Copies the database to a directory every so often, keeping the latest few copies. Each copy is consistent, as if the
+// server had stopped at the moment it was taken, without the server having to stop.
+type Backups struct {
+	hub  *Hub
+	path string
+	keep int
+}
+
+func NewBackups(hub *Hub) *Backups {
+	return &Backups{hub: hub, keep: DefaultBackupKeep}
+}
+
+// Must be called before the hub is run. Nothing's backed up unless there's a directory to back up to. Left at 0, the
+// interval and how many backups are kept keep their defaults.
+func (b *Backups) Configure(path string, interval time.Duration, keep int) {
+	if path == "" {
+		return
+	}
+	b.path = path
+	if interval <= 0 {
+		interval = DefaultBackupInterval
+	}
+	if keep > 0 {
+		b.keep = keep
+	}
+	b.hub.Jobs.Register(backupJob, b.backup, JobOptions{Every: interval, Timeout: backupTimeout})
+}
+
+func (b *Backups) backup(ctx context.Context, _ json.RawMessage) error {
+	if err := os.MkdirAll(b.path, 0755); err != nil {
+		return err
+	}
+
+	// Names sort by when they were taken
+	name := filepath.Join(b.path, "db-"+time.Now().UTC().Format("20060102-150405")+".sqlite")
+	if _, err := b.hub.dbPool.ExecContext(ctx, "VACUUM INTO ?", name); err != nil {
+		os.Remove(name)
+		return err
+	}
+	logging.Hub.Printf("Backed up the database to %s", name)
+
+	backups, err := filepath.Glob(filepath.Join(b.path, "db-*.sqlite"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(backups)
+	for _, old := range backups[:max(len(backups)-b.keep, 0)] {
+		if err := os.Remove(old); err != nil {
+			return err
+		}
+	}
+	return nil
+}
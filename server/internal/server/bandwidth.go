@@ -0,0 +1,358 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// How often each account's traffic is added to its daily totals, and checked against the daily limit
+	bandwidthFlushInterval = time.Minute
+
+	// Daily totals are kept by UTC day
+	bandwidthDayLayout = time.DateOnly
+
+	DefaultBandwidthThrottleRate = 16 * 1024
+)
+
+var bandwidthBytesTotal = metrics.NewCounterVec("mmo_bandwidth_bytes_total", "Bytes received from and sent to clients over their sockets.", "direction")
+
+// Bytes and packets over a client's socket, in each direction
+type BandwidthCounts struct {
+	BytesIn    int64
+	BytesOut   int64
+	PacketsIn  int64
+	PacketsOut int64
+}
+
+func (c BandwidthCounts) add(other BandwidthCounts) BandwidthCounts {
+	return BandwidthCounts{
+		BytesIn:    c.BytesIn + other.BytesIn,
+		BytesOut:   c.BytesOut + other.BytesOut,
+		PacketsIn:  c.PacketsIn + other.PacketsIn,
+		PacketsOut: c.PacketsOut + other.PacketsOut,
+	}
+}
+
+// Traffic counted for an account but not written yet
+type bandwidthDelta struct {
+	playerDbId int64
+	sessions   int64
+	counts     BandwidthCounts
+}
+
+// Counts how much each account sends and receives, for servers paying for their traffic to see who it goes to. Each
+// connection is counted as it goes, and added to its account's totals for the day every so often. Accounts that go
+// over the daily limit, if there is one, have what they're sent slowed down for the rest of the day: anything the
+// client can't do without still goes out, but updates beyond the throttle rate are dropped.
+type Bandwidth struct {
+	hub *Hub
+
+	// In bytes both ways per account per day, or 0 for no limit
+	dailyLimit int64
+
+	// In bytes per second sent to a throttled client
+	throttleRate int64
+
+	sessions map[*BandwidthSession]struct{}
+
+	// Traffic of sessions that have since moved on to another account, or that failed to be written
+	carried []bandwidthDelta
+
+	mux sync.Mutex
+}
+
+func NewBandwidth(hub *Hub) *Bandwidth {
+	return &Bandwidth{
+		hub:          hub,
+		throttleRate: DefaultBandwidthThrottleRate,
+		sessions:     make(map[*BandwidthSession]struct{}),
+	}
+}
+
+// Must be called before the hub is run. A throttle rate of 0 keeps the default.
+func (b *Bandwidth) Configure(dailyLimit int64, throttleRate int64) {
+	b.dailyLimit = dailyLimit
+	if throttleRate > 0 {
+		b.throttleRate = throttleRate
+	}
+}
+
+// Start counting a new connection
+func (b *Bandwidth) NewSession() *BandwidthSession {
+	session := &BandwidthSession{bandwidth: b, connectedAt: time.Now()}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.sessions[session] = struct{}{}
+	return session
+}
+
+// The connection the player is logged in on, if they are
+func (b *Bandwidth) Session(playerDbId int64) (*BandwidthSession, bool) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	for session := range b.sessions {
+		if !session.closed.Load() && session.PlayerDbId() == playerDbId {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+func (b *Bandwidth) flushLoop() {
+	ticker := time.NewTicker(bandwidthFlushInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !b.hub.dbHealth.Healthy() {
+			continue
+		}
+		b.flush()
+		b.enforce()
+	}
+}
+
+// Add everything counted since last time to each account's totals for today
+func (b *Bandwidth) flush() {
+	b.mux.Lock()
+	deltas := b.carried
+	b.carried = nil
+	for session := range b.sessions {
+		if delta, ok := session.takePending(); ok {
+			deltas = append(deltas, delta)
+		}
+		if session.closed.Load() {
+			delete(b.sessions, session)
+		}
+	}
+	b.mux.Unlock()
+
+	if len(deltas) == 0 {
+		return
+	}
+
+	// One row per account, however many connections it had
+	byPlayer := make(map[int64]bandwidthDelta)
+	for _, delta := range deltas {
+		total := byPlayer[delta.playerDbId]
+		total.playerDbId = delta.playerDbId
+		total.sessions += delta.sessions
+		total.counts = total.counts.add(delta.counts)
+		byPlayer[delta.playerDbId] = total
+	}
+
+	day := time.Now().UTC().Format(bandwidthDayLayout)
+	dbTx := b.hub.NewDbTx()
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		for _, delta := range byPlayer {
+			err := queries.AddBandwidthUsage(dbTx.Ctx, db.AddBandwidthUsageParams{
+				PlayerID:   delta.playerDbId,
+				Day:        day,
+				Sessions:   delta.sessions,
+				BytesIn:    delta.counts.BytesIn,
+				BytesOut:   delta.counts.BytesOut,
+				PacketsIn:  delta.counts.PacketsIn,
+				PacketsOut: delta.counts.PacketsOut,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error writing bandwidth usage of %d accounts, trying again next time: %v", len(byPlayer), err)
+		b.mux.Lock()
+		for _, delta := range byPlayer {
+			b.carried = append(b.carried, delta)
+		}
+		b.mux.Unlock()
+	}
+}
+
+// Throttle the connections of accounts over today's limit, and stop throttling any that aren't anymore
+func (b *Bandwidth) enforce() {
+	if b.dailyLimit <= 0 {
+		return
+	}
+
+	b.mux.Lock()
+	sessions := make([]*BandwidthSession, 0, len(b.sessions))
+	for session := range b.sessions {
+		if session.PlayerDbId() != 0 {
+			sessions = append(sessions, session)
+		}
+	}
+	b.mux.Unlock()
+
+	day := time.Now().UTC().Format(bandwidthDayLayout)
+	dbTx := b.hub.NewDbTx()
+	for _, session := range sessions {
+		playerDbId := session.PlayerDbId()
+		usage, err := dbTx.Queries.GetBandwidthUsage(dbTx.Ctx, db.GetBandwidthUsageParams{PlayerID: playerDbId, Day: day})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logging.Hub.Errorf("Error getting bandwidth usage of player %d: %v", playerDbId, err)
+			continue
+		}
+
+		over := usage.BytesIn+usage.BytesOut > b.dailyLimit
+		if session.throttled.Swap(over) != over {
+			if over {
+				logging.Hub.Printf("Player %d used %d bytes today, over the limit of %d, throttling them", playerDbId, usage.BytesIn+usage.BytesOut, b.dailyLimit)
+			} else {
+				logging.Hub.Printf("Player %d is no longer over today's bandwidth limit, no longer throttling them", playerDbId)
+			}
+		}
+	}
+}
+
+// The traffic over one client's connection
+type BandwidthSession struct {
+	bandwidth   *Bandwidth
+	connectedAt time.Time
+
+	// The account logged in on the connection, or 0 before logging in
+	playerDbId int64
+
+	// Since connecting, and since the account's totals were last written
+	total   BandwidthCounts
+	pending BandwidthCounts
+
+	// Whether the session is yet to be counted as one of its account's sessions for the day
+	counted bool
+
+	mux sync.Mutex
+
+	closed    atomic.Bool
+	throttled atomic.Bool
+
+	// What can still be sent right now while throttled. Only used by the goroutine writing to the client.
+	allowance   float64
+	allowanceAt time.Time
+}
+
+func (s *BandwidthSession) Received(bytes int) {
+	bandwidthBytesTotal.With("in").Add(uint64(bytes))
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.total.BytesIn += int64(bytes)
+	s.total.PacketsIn++
+	s.pending.BytesIn += int64(bytes)
+	s.pending.PacketsIn++
+}
+
+func (s *BandwidthSession) Sent(bytes int) {
+	bandwidthBytesTotal.With("out").Add(uint64(bytes))
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.total.BytesOut += int64(bytes)
+	s.total.PacketsOut++
+	s.pending.BytesOut += int64(bytes)
+	s.pending.PacketsOut++
+}
+
+// Count the connection's traffic towards the player's account from now on. What was sent before logging in is
+// counted towards the first account logged in as.
+func (s *BandwidthSession) Attach(playerDbId int64) {
+	s.mux.Lock()
+	if playerDbId == s.playerDbId {
+		s.mux.Unlock()
+		return
+	}
+
+	var delta bandwidthDelta
+	var carried bool
+	if s.playerDbId != 0 {
+		delta, carried = s.takePendingLocked()
+		s.throttled.Store(false)
+	}
+	s.playerDbId = playerDbId
+	s.counted = false
+	s.mux.Unlock()
+
+	// Taken after letting go of the session's lock, since flushing takes them the other way round
+	if carried {
+		s.bandwidth.mux.Lock()
+		s.bandwidth.carried = append(s.bandwidth.carried, delta)
+		s.bandwidth.mux.Unlock()
+	}
+}
+
+func (s *BandwidthSession) PlayerDbId() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.playerDbId
+}
+
+// Everything counted since connecting
+func (s *BandwidthSession) Usage() (BandwidthCounts, time.Time) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.total, s.connectedAt
+}
+
+func (s *BandwidthSession) Throttled() bool {
+	return s.throttled.Load()
+}
+
+// Whether a packet of the given size can be sent now. Always true unless the account is being throttled.
+func (s *BandwidthSession) AllowSend(bytes int) bool {
+	if !s.throttled.Load() {
+		return true
+	}
+
+	rate := float64(s.bandwidth.throttleRate)
+	now := time.Now()
+	if s.allowanceAt.IsZero() {
+		s.allowance = rate
+	} else {
+		s.allowance = min(s.allowance+now.Sub(s.allowanceAt).Seconds()*rate, rate)
+	}
+	s.allowanceAt = now
+
+	if s.allowance < float64(bytes) {
+		return false
+	}
+	s.allowance -= float64(bytes)
+	return true
+}
+
+// Stop counting the connection. What's left is written with everything else next time.
+func (s *BandwidthSession) Close() {
+	s.closed.Store(true)
+}
+
+func (s *BandwidthSession) takePending() (bandwidthDelta, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.takePendingLocked()
+}
+
+// Must be called with the lock held
+func (s *BandwidthSession) takePendingLocked() (bandwidthDelta, bool) {
+	// Connections that never log in aren't anyone's to pay for
+	if s.playerDbId == 0 {
+		if s.closed.Load() {
+			s.pending = BandwidthCounts{}
+		}
+		return bandwidthDelta{}, false
+	}
+	if s.pending == (BandwidthCounts{}) && s.counted {
+		return bandwidthDelta{}, false
+	}
+
+	delta := bandwidthDelta{playerDbId: s.playerDbId, counts: s.pending}
+	if !s.counted {
+		delta.sessions = 1
+		s.counted = true
+	}
+	s.pending = BandwidthCounts{}
+	return delta, true
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// Longer than any lockout lasts, so loot this old can't be locking anyone out
+	bossLockoutRetention = 8 * 24 * time.Hour
+
+	bossLockoutCleanupJob      = "lockouts.cleanup"
+	bossLockoutCleanupInterval = time.Hour
+)
+
+// When lockouts reset, in UTC: daily ones every day at the time of day, and weekly ones on the weekday at that time
+type LockoutReset struct {
+	Weekday time.Weekday
+
+	// How long after midnight
+	TimeOfDay time.Duration
+}
+
+var DefaultLockoutReset = LockoutReset{Weekday: time.Tuesday, TimeOfDay: 15 * time.Hour}
+
+func (r LockoutReset) String() string {
+	return fmt.Sprintf("%s %02d:%02d UTC", r.Weekday, int(r.TimeOfDay.Hours()), int(r.TimeOfDay.Minutes())%60)
+}
+
+// Parse a reset like "tuesday 15:00", in UTC
+func ParseLockoutReset(value string) (LockoutReset, error) {
+	day, clock, found := strings.Cut(strings.TrimSpace(value), " ")
+	if !found {
+		return LockoutReset{}, fmt.Errorf("expected a weekday and a time, e.g. tuesday 15:00")
+	}
+
+	reset := LockoutReset{Weekday: -1}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) {
+			reset.Weekday = weekday
+		}
+	}
+	if reset.Weekday < 0 {
+		return LockoutReset{}, fmt.Errorf("unknown weekday %q", day)
+	}
+
+	at, err := time.Parse("15:04", strings.TrimSpace(clock))
+	if err != nil {
+		return LockoutReset{}, fmt.Errorf("invalid time %q, expected hours and minutes, e.g. 15:00", clock)
+	}
+	reset.TimeOfDay = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+	return reset, nil
+}
+
+// Keeps each character from looting a boss more than once a lockout. Bosses are the resource node kinds the game data
+// gives a lockout, and each character's locked out of one from looting it until the next daily or weekly reset.
+type BossLockouts struct {
+	hub   *Hub
+	reset LockoutReset
+}
+
+func NewBossLockouts(hub *Hub) *BossLockouts {
+	l := &BossLockouts{hub: hub, reset: DefaultLockoutReset}
+	hub.Jobs.Register(bossLockoutCleanupJob, l.cleanUp, JobOptions{Every: bossLockoutCleanupInterval})
+	return l
+}
+
+// Must be called before the hub is run
+func (l *BossLockouts) Configure(reset LockoutReset) {
+	l.reset = reset
+	logging.Hub.Printf("Resetting boss lockouts weekly on %s", reset)
+}
+
+// When lockouts of the cadence last reset, at or before the time
+func (l *BossLockouts) LastReset(cadence string, at time.Time) time.Time {
+	at = at.UTC()
+	reset := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC).Add(l.reset.TimeOfDay)
+	if cadence == gamedata.LockoutWeekly {
+		reset = reset.AddDate(0, 0, -((int(at.Weekday()) - int(l.reset.Weekday) + 7) % 7))
+		if reset.After(at) {
+			reset = reset.AddDate(0, 0, -7)
+		}
+	} else if reset.After(at) {
+		reset = reset.AddDate(0, 0, -1)
+	}
+	return reset
+}
+
+// When lockouts of the cadence next reset, after the time
+func (l *BossLockouts) NextReset(cadence string, at time.Time) time.Time {
+	if cadence == gamedata.LockoutWeekly {
+		return l.LastReset(cadence, at).AddDate(0, 0, 7)
+	}
+	return l.LastReset(cadence, at).AddDate(0, 0, 1)
+}
+
+// Whether the character's looted the boss since its lockout last reset. Kinds that aren't bosses are never locked.
+func (l *BossLockouts) Locked(ctx context.Context, queries *db.Queries, playerDbId int64, kind *gamedata.ResourceNodeKind) (bool, error) {
+	if kind.Lockout == "" {
+		return false, nil
+	}
+	lockouts, err := queries.GetBossLockouts(ctx, playerDbId)
+	if err != nil {
+		return false, err
+	}
+	lastReset := l.LastReset(kind.Lockout, time.Now())
+	for _, lockout := range lockouts {
+		if lockout.BossID == kind.Id {
+			return !time.UnixMilli(lockout.LootedAt).Before(lastReset), nil
+		}
+	}
+	return false, nil
+}
+
+// Lock the character out of the boss until its next reset. Pass queries that are part of the transaction giving the
+// character the loot, so they're either both done or neither is.
+func (l *BossLockouts) Record(ctx context.Context, queries *db.Queries, playerDbId int64, kind *gamedata.ResourceNodeKind) error {
+	if kind.Lockout == "" {
+		return nil
+	}
+	return queries.SetBossLockout(ctx, db.SetBossLockoutParams{
+		PlayerID: playerDbId,
+		BossID:   kind.Id,
+		LootedAt: time.Now().UnixMilli(),
+	})
+}
+
+// Every boss in the world, whether the character's locked out of it and when it resets, sorted by ID
+func (l *BossLockouts) List(ctx context.Context, queries *db.Queries, playerDbId int64) ([]*packets.BossLockoutMessage, error) {
+	lockouts, err := queries.GetBossLockouts(ctx, playerDbId)
+	if err != nil {
+		return nil, err
+	}
+	lootedAt := make(map[string]time.Time, len(lockouts))
+	for _, lockout := range lockouts {
+		lootedAt[lockout.BossID] = time.UnixMilli(lockout.LootedAt)
+	}
+
+	now := time.Now()
+	kinds := l.hub.GameData.ResourceNodeKinds
+	var result []*packets.BossLockoutMessage
+	for _, kindId := range slices.Sorted(maps.Keys(kinds)) {
+		kind := kinds[kindId]
+		if kind.Lockout == "" {
+			continue
+		}
+		looted, exists := lootedAt[kind.Id]
+		result = append(result, &packets.BossLockoutMessage{
+			BossId:   kind.Id,
+			Locked:   exists && !looted.Before(l.LastReset(kind.Lockout, now)),
+			ResetsAt: l.NextReset(kind.Lockout, now).UnixMilli(),
+		})
+	}
+	return result, nil
+}
+
+// Forget loot too old to be locking anyone out of anything
+func (l *BossLockouts) cleanUp(ctx context.Context, _ json.RawMessage) error {
+	deleted, err := l.hub.NewDbTx().Queries.DeleteBossLockoutsBefore(ctx, time.Now().Add(-bossLockoutRetention).UnixMilli())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logging.Hub.Printf("Deleted %d expired boss lockouts", deleted)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"fmt"
+	"runtime"
+	"server/internal/server/diagnostics"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"sync"
+)
+
+// Broadcasts to fewer clients than this are delivered on the hub's goroutine, since handing them out to the workers
+// would cost more than it saves
+const minParallelRecipients = 64
+
+// A worker's share of one broadcast: the clients in its shard, which it passes the packet to in order
+type broadcastShard struct {
+	packet  *packets.Packet
+	clients []ClientInterfacer
+
+	// What it did, read once every shard's done
+	recipients int
+	skipped    int
+}
+
+// Passes each broadcast to its recipients on a pool of workers instead of one by one on the hub's goroutine, so the
+// handling and queueing every recipient's client does for it is spread over every core. Clients are sharded between
+// the workers by ID, so each client is always passed broadcasts by the same worker, and each broadcast is delivered to
+// everyone before the next one is started, so every client still gets them in the order they were broadcast.
+type BroadcastWorkers struct {
+	hub *Hub
+
+	// One for each worker, none if broadcasts are delivered on the hub's goroutine
+	shards []*broadcastShard
+	work   []chan *broadcastShard
+	done   sync.WaitGroup
+}
+
+func NewBroadcastWorkers(hub *Hub) *BroadcastWorkers {
+	return &BroadcastWorkers{hub: hub}
+}
+
+// Must be called before the hub is run. 0 workers means one for each CPU, and 1 means broadcasts are delivered on the
+// hub's goroutine.
+func (w *BroadcastWorkers) Configure(workers int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers == 1 {
+		w.shards, w.work = nil, nil
+		return
+	}
+	w.shards = make([]*broadcastShard, workers)
+	w.work = make([]chan *broadcastShard, workers)
+	for i := range workers {
+		w.shards[i] = &broadcastShard{}
+		w.work[i] = make(chan *broadcastShard, 1)
+	}
+	logging.Hub.Printf("Delivering broadcasts on %d workers", workers)
+}
+
+func (w *BroadcastWorkers) start() {
+	for i, work := range w.work {
+		diagnostics.Go(fmt.Sprintf("broadcast worker %d", i), func() { w.workLoop(work) })
+	}
+}
+
+// Stop the workers, once nothing more will be broadcast
+func (w *BroadcastWorkers) stop() {
+	for _, work := range w.work {
+		close(work)
+	}
+}
+
+func (w *BroadcastWorkers) workLoop(work chan *broadcastShard) {
+	for shard := range work {
+		for _, client := range shard.clients {
+			if w.hub.deliverTo(client, shard.packet) {
+				shard.recipients++
+			} else {
+				shard.skipped++
+			}
+		}
+		w.done.Done()
+	}
+}
+
+// Pass the packet to every client but its sender that can see it, returning how many it was passed to and how many
+// were skipped for not being able to. Only called on the hub's goroutine.
+func (w *BroadcastWorkers) deliver(packet *packets.Packet) (int, int) {
+	if len(w.work) == 0 || w.hub.Clients.Len() < minParallelRecipients {
+		return w.deliverSerially(packet)
+	}
+	return w.deliverSharded(packet, w.hub.Clients.ForEach)
+}
+
+// Like deliver, only to the given clients rather than everyone, e.g. those interested in the packet's sender
+func (w *BroadcastWorkers) deliverAmong(packet *packets.Packet, clients []ClientInterfacer) (int, int) {
+	forEach := func(f func(uint64, ClientInterfacer)) {
+		for _, client := range clients {
+			f(client.Id(), client)
+		}
+	}
+	if len(w.work) == 0 || len(clients) < minParallelRecipients {
+		return w.deliverEach(packet, forEach)
+	}
+	return w.deliverSharded(packet, forEach)
+}
+
+// Share the clients out between the workers' shards, and wait for every worker to pass the packet to its own
+func (w *BroadcastWorkers) deliverSharded(packet *packets.Packet, forEach func(func(uint64, ClientInterfacer))) (int, int) {
+	for _, shard := range w.shards {
+		shard.packet, shard.clients, shard.recipients, shard.skipped = packet, shard.clients[:0], 0, 0
+	}
+	forEach(func(clientId uint64, client ClientInterfacer) {
+		if clientId != packet.SenderId {
+			shard := w.shards[shardOf(clientId, len(w.shards))]
+			shard.clients = append(shard.clients, client)
+		}
+	})
+
+	w.done.Add(len(w.shards))
+	for i, shard := range w.shards {
+		w.work[i] <- shard
+	}
+	w.done.Wait()
+
+	recipients, skipped := 0, 0
+	for _, shard := range w.shards {
+		recipients += shard.recipients
+		skipped += shard.skipped
+
+		// Not kept hold of until the next broadcast
+		shard.packet = nil
+		clear(shard.clients)
+	}
+	return recipients, skipped
+}
+
+func (w *BroadcastWorkers) deliverSerially(packet *packets.Packet) (int, int) {
+	return w.deliverEach(packet, w.hub.Clients.ForEach)
+}
+
+func (w *BroadcastWorkers) deliverEach(packet *packets.Packet, forEach func(func(uint64, ClientInterfacer))) (int, int) {
+	recipients, skipped := 0, 0
+	forEach(func(clientId uint64, client ClientInterfacer) {
+		if clientId == packet.SenderId {
+			return
+		}
+		if w.hub.deliverTo(client, packet) {
+			recipients++
+		} else {
+			skipped++
+		}
+	})
+	return recipients, skipped
+}
+
+// Which of the shards the client's in. IDs are mixed first, since those handed out in different milliseconds all have
+// the same low bits.
+func shardOf(clientId uint64, shards int) int {
+	return int((clientId * 0x9e3779b97f4a7c15 >> 32) % uint64(shards))
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// What each subsystem has a budget for
+const (
+	// Players logged in at once, not counting actors driven by simulators
+	BudgetPlayers = "players"
+
+	// Spores in the world at once, whether placed by the server or dropped by players
+	BudgetSpores = "spores"
+
+	// Spores dropped by players each second
+	BudgetSporeDrops = "spore_drops"
+
+	// Chat messages sent each second, across every player
+	BudgetChat = "chat"
+)
+
+// What happens to whatever goes over a budget
+const (
+	// Refuse it, telling whoever asked for it where there's someone to tell
+	ShedReject = "reject"
+
+	// Refuse it without telling anyone, e.g. a chat message that's never delivered. Logins over the players budget are
+	// still refused, since the client's waiting on an answer, but it isn't disconnected.
+	ShedDrop = "drop"
+)
+
+// How often a budget being hit is logged, at most
+const budgetLogInterval = time.Minute
+
+var (
+	budgetHitsTotal = metrics.NewCounterVec("mmo_budget_hits_total", "Things shed for going over budget, by budget.", "budget")
+	budgetUsage     = metrics.NewGaugeVec("mmo_budget_usage_ratio", "How much of each budget was used when it was last checked, from 0 to 1.", "budget")
+)
+
+// A cap on how much of something a subsystem can use, either how many there can be at once or how many there can be
+// each second. Over a budget, whatever's asked for is shed, so one runaway subsystem can't starve the rest.
+type Budget struct {
+	name string
+
+	// No limit if 0
+	limit float64
+
+	// Whether the limit is for each second rather than at once
+	perSecond bool
+
+	shed string
+
+	// Of this second's budget, for budgets per second, topped up continuously
+	tokens     float64
+	refilledAt time.Time
+
+	loggedAt time.Time
+	mux      sync.Mutex
+}
+
+// Whether there's room for one more, given how many are in use now. Budgets per second ignore how many are in use and
+// count each admission instead.
+func (b *Budget) Admit(inUse int) bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.limit <= 0 {
+		return true
+	}
+
+	admitted := false
+	if b.perSecond {
+		now := time.Now()
+		b.tokens = min(b.tokens+now.Sub(b.refilledAt).Seconds()*b.limit, b.limit)
+		b.refilledAt = now
+		if b.tokens >= 1 {
+			b.tokens--
+			admitted = true
+		}
+		budgetUsage.With(b.name).Set(1 - b.tokens/b.limit)
+	} else {
+		admitted = float64(inUse) < b.limit
+		budgetUsage.With(b.name).Set(min(float64(inUse)/b.limit, 1))
+	}
+
+	if !admitted {
+		budgetHitsTotal.With(b.name).Inc()
+		if time.Since(b.loggedAt) >= budgetLogInterval {
+			b.loggedAt = time.Now()
+			logging.Hub.Printf("Over the %s budget of %s, shedding with %s", b.name, b.describe(), b.shed)
+		}
+	}
+	return admitted
+}
+
+// Whether whoever asked for something shed should be told it was refused
+func (b *Budget) Rejects() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.shed == ShedReject
+}
+
+// Must be called with the lock held
+func (b *Budget) describe() string {
+	if b.perSecond {
+		return fmt.Sprintf("%g/s", b.limit)
+	}
+	return fmt.Sprintf("%g", b.limit)
+}
+
+// How much of the server's capacity each subsystem can use, for running it with hard limits sized to its hardware.
+// Every budget is unlimited unless configured.
+type Budgets struct {
+	Players    *Budget
+	Spores     *Budget
+	SporeDrops *Budget
+	Chat       *Budget
+
+	byName map[string]*Budget
+}
+
+func NewBudgets() *Budgets {
+	b := &Budgets{
+		Players:    &Budget{name: BudgetPlayers, shed: ShedReject},
+		Spores:     &Budget{name: BudgetSpores, shed: ShedReject},
+		SporeDrops: &Budget{name: BudgetSporeDrops, perSecond: true, shed: ShedReject},
+		Chat:       &Budget{name: BudgetChat, perSecond: true, shed: ShedReject},
+	}
+	b.byName = map[string]*Budget{
+		BudgetPlayers:    b.Players,
+		BudgetSpores:     b.Spores,
+		BudgetSporeDrops: b.SporeDrops,
+		BudgetChat:       b.Chat,
+	}
+	return b
+}
+
+// Must be called before the hub is run. Sets each budget named, e.g. from "players=500,chat=50/s:drop", where limits
+// per second end in /s and the shedding after a colon defaults to reject. Budgets left out stay unlimited.
+func (b *Budgets) Configure(budgets string) error {
+	for _, setting := range strings.Split(budgets, ",") {
+		if setting = strings.TrimSpace(setting); setting == "" {
+			continue
+		}
+		name, value, found := strings.Cut(setting, "=")
+		if !found {
+			return fmt.Errorf("expected budget=limit, got %q", setting)
+		}
+		budget, exists := b.byName[strings.TrimSpace(name)]
+		if !exists {
+			return fmt.Errorf("unknown budget %q, expected one of %s", name, strings.Join(b.names(), ", "))
+		}
+
+		value, shed, _ := strings.Cut(strings.TrimSpace(value), ":")
+		if shed == "" {
+			shed = ShedReject
+		} else if shed != ShedReject && shed != ShedDrop {
+			return fmt.Errorf("%s: unknown shedding %q, expected %s or %s", budget.name, shed, ShedReject, ShedDrop)
+		}
+
+		value, perSecond := strings.CutSuffix(value, "/s")
+		if perSecond != budget.perSecond {
+			if budget.perSecond {
+				return fmt.Errorf("%s: the budget is per second, so needs to end in /s", budget.name)
+			}
+			return fmt.Errorf("%s: the budget is for how many at once, so can't be per second", budget.name)
+		}
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("%s: invalid limit %q", budget.name, value)
+		}
+
+		budget.limit = limit
+		budget.shed = shed
+		budget.tokens = limit
+		budget.refilledAt = time.Now()
+		logging.Hub.Printf("Budgeting %s to %s, shedding with %s", budget.name, budget.describe(), shed)
+	}
+	return nil
+}
+
+func (b *Budgets) names() []string {
+	names := make([]string, 0, len(b.byName))
+	for name := range b.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
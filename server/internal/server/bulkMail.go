@@ -0,0 +1,213 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"time"
+)
+
+const (
+	// How often queued jobs are looked for, besides straight after one is queued
+	bulkMailInterval = 5 * time.Second
+
+	// How many players are mailed in each transaction, and how long to wait before the next, so the rest of the
+	// server still gets a look in at the database while a big job is being worked through
+	bulkMailBatchSize  = 200
+	bulkMailBatchPause = 100 * time.Millisecond
+
+	BulkMailQueued    = "queued"
+	BulkMailRunning   = "running"
+	BulkMailDone      = "done"
+	BulkMailCancelled = "cancelled"
+)
+
+var errBulkMailCancelled = errors.New("bulk mail job cancelled")
+
+// Who a bulk mail job is sent to. Zero values don't filter anything out.
+type BulkMailCohort struct {
+	MinBestScore int64
+	MaxBestScore int64
+
+	// Only players who last logged in within this window. Players who haven't logged in since logins started being
+	// recorded count as never having logged in, so they're left out of any cohort with a window.
+	LastLoginAfter  time.Time
+	LastLoginBefore time.Time
+}
+
+// Works through jobs sending the same mail to many players in the background, e.g. a newsletter or a gift to everyone
+// who played over a holiday. Jobs are worked through one at a time, in the order they were queued, and their progress
+// is kept in the database, so a job picks up where it left off after a restart.
+type BulkMailer struct {
+	hub  *Hub
+	wake chan struct{}
+}
+
+func NewBulkMailer(hub *Hub) *BulkMailer {
+	return &BulkMailer{
+		hub:  hub,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Queue mail with an optional attachment to every player in the cohort. The number of players it will go to is
+// worked out now, though players who join the cohort before the job is finished may still get it.
+func (b *BulkMailer) Queue(admin string, sender string, subject string, itemId string, quantity int64, cohort BulkMailCohort) (db.BulkMailJob, error) {
+	params := cohortParams(cohort)
+	dbTx := b.hub.NewDbTx()
+
+	total, err := dbTx.Queries.CountBulkMailRecipients(dbTx.Ctx, params)
+	if err != nil {
+		return db.BulkMailJob{}, err
+	}
+
+	job, err := dbTx.Queries.CreateBulkMailJob(dbTx.Ctx, db.CreateBulkMailJobParams{
+		Admin:           admin,
+		Sender:          sender,
+		Subject:         subject,
+		ItemID:          itemId,
+		Quantity:        quantity,
+		MinBestScore:    params.MinBestScore,
+		MaxBestScore:    params.MaxBestScore,
+		LastLoginAfter:  params.LastLoginAfter,
+		LastLoginBefore: params.LastLoginBefore,
+		Total:           total,
+		CreatedAt:       time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return db.BulkMailJob{}, err
+	}
+
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Stop sending a job's mail. Whoever it was already sent to keeps it.
+func (b *BulkMailer) Cancel(jobId int64) error {
+	dbTx := b.hub.NewDbTx()
+	return dbTx.Queries.FinishBulkMailJob(dbTx.Ctx, db.FinishBulkMailJobParams{
+		Status:     BulkMailCancelled,
+		FinishedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:         jobId,
+	})
+}
+
+func cohortParams(cohort BulkMailCohort) db.CountBulkMailRecipientsParams {
+	params := db.CountBulkMailRecipientsParams{
+		MinBestScore:    cohort.MinBestScore,
+		MaxBestScore:    math.MaxInt64,
+		LastLoginBefore: math.MaxInt64,
+	}
+	if cohort.MaxBestScore != 0 {
+		params.MaxBestScore = cohort.MaxBestScore
+	}
+
+	// Never logging in counts as last logging in at 0, so it only matches when there's no window at all
+	if !cohort.LastLoginAfter.IsZero() || !cohort.LastLoginBefore.IsZero() {
+		params.LastLoginAfter = max(cohort.LastLoginAfter.UnixMilli(), 1)
+	}
+	if !cohort.LastLoginBefore.IsZero() {
+		params.LastLoginBefore = cohort.LastLoginBefore.UnixMilli()
+	}
+	return params
+}
+
+func (b *BulkMailer) sendLoop() {
+	ticker := time.NewTicker(bulkMailInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-b.wake:
+		}
+
+		for b.hub.dbHealth.Healthy() {
+			sentAny, err := b.sendBatch()
+			if err != nil {
+				logging.Hub.Errorf("Error sending bulk mail: %v", err)
+				break
+			}
+			if !sentAny {
+				break
+			}
+			time.Sleep(bulkMailBatchPause)
+		}
+	}
+}
+
+// Send the next batch of the oldest unfinished job. Returns whether there was anything to do.
+func (b *BulkMailer) sendBatch() (bool, error) {
+	dbTx := b.hub.NewDbTx()
+	job, err := dbTx.Queries.GetNextBulkMailJob(dbTx.Ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	var recipients []int64
+	err = dbTx.InTx(func(queries *db.Queries) error {
+		recipients, err = queries.GetBulkMailRecipients(dbTx.Ctx, db.GetBulkMailRecipientsParams{
+			AfterID:         job.LastPlayerID,
+			MinBestScore:    job.MinBestScore,
+			MaxBestScore:    job.MaxBestScore,
+			LastLoginAfter:  job.LastLoginAfter,
+			LastLoginBefore: job.LastLoginBefore,
+			Limit:           bulkMailBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(recipients) > 0 {
+			for _, playerDbId := range recipients {
+				if err := SendMail(dbTx.Ctx, queries, playerDbId, job.Sender, job.Subject, job.ItemID, job.Quantity); err != nil {
+					return fmt.Errorf("error mailing player %d: %w", playerDbId, err)
+				}
+			}
+
+			// Checked after sending, in the same transaction, so a job cancelled part way through a batch doesn't
+			// send any of it
+			updated, err := queries.UpdateBulkMailJobProgress(dbTx.Ctx, db.UpdateBulkMailJobProgressParams{
+				Sent:         int64(len(recipients)),
+				LastPlayerID: recipients[len(recipients)-1],
+				ID:           job.ID,
+			})
+			if err != nil {
+				return err
+			}
+			if updated == 0 {
+				return errBulkMailCancelled
+			}
+		}
+
+		if len(recipients) < bulkMailBatchSize {
+			return queries.FinishBulkMailJob(dbTx.Ctx, db.FinishBulkMailJobParams{
+				Status:     BulkMailDone,
+				FinishedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+				ID:         job.ID,
+			})
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkMailCancelled) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error sending job %d: %w", job.ID, err)
+	}
+
+	for _, playerDbId := range recipients {
+		b.hub.NotifyMail(playerDbId)
+	}
+	if len(recipients) < bulkMailBatchSize {
+		logging.Hub.Printf("Finished bulk mail job %d, sent to %d players", job.ID, job.Sent+int64(len(recipients)))
+	}
+	return true, nil
+}
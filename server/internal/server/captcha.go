@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Where each provider's tokens are verified, unless configured otherwise
+var captchaVerifyUrls = map[string]string{
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"turnstile": "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+const (
+	// How long failed logins from an IP count towards challenging everything from it
+	captchaFailureWindow = 15 * time.Minute
+
+	// How long after an IP registers an account any more it registers are challenged
+	captchaRegistrationWindow = 24 * time.Hour
+
+	// How long the provider gets to say whether a token's valid
+	captchaVerifyTimeout = 5 * time.Second
+
+	DefaultCaptchaFailureThreshold = 3
+)
+
+var ErrUnknownCaptchaProvider = errors.New("unknown CAPTCHA provider")
+
+var (
+	captchaChallengesTotal    = metrics.NewCounterVec("mmo_captcha_challenges_total", "Logins and registrations challenged with a CAPTCHA, by why: failures, new_ip or registration.", "reason")
+	captchaVerificationsTotal = metrics.NewCounterVec("mmo_captcha_verifications_total", "CAPTCHA responses checked with the provider, by result: passed, failed or error.", "result")
+)
+
+type CaptchaConfig struct {
+	// "hcaptcha" or "turnstile", or empty to never challenge anyone
+	Provider string
+
+	// The site key clients show the provider's widget with, and the secret tokens are verified with
+	SiteKey string
+	Secret  string
+
+	// Where tokens are verified, or the provider's own endpoint if left out
+	VerifyUrl string
+
+	// How many failed logins from an IP within the failure window before every login and registration from it is
+	// challenged. DefaultCaptchaFailureThreshold if left out.
+	FailureThreshold int
+}
+
+// What's been seen from one IP lately
+type captchaIpActivity struct {
+	failures     int
+	failedSince  time.Time
+	registeredAt time.Time
+}
+
+// Challenges logins and registrations that look suspicious with a CAPTCHA from hCaptcha or Turnstile: any from an IP
+// with a lot of failed logins lately, logins to accounts from anywhere they haven't logged in from before, and more
+// than one registration from the same IP in a day. Clients are sent a challenge instead of an answer, and their
+// request goes no further until they send back a token the provider says is valid.
+type Captcha struct {
+	hub        *Hub
+	config     CaptchaConfig
+	httpClient *http.Client
+
+	// By IP
+	activity map[string]*captchaIpActivity
+	prunedAt time.Time
+	mux      sync.Mutex
+}
+
+func NewCaptcha(hub *Hub) *Captcha {
+	return &Captcha{
+		hub:        hub,
+		config:     CaptchaConfig{FailureThreshold: DefaultCaptchaFailureThreshold},
+		httpClient: &http.Client{Timeout: captchaVerifyTimeout},
+		activity:   make(map[string]*captchaIpActivity),
+		prunedAt:   time.Now(),
+	}
+}
+
+// Must be called before the hub is run
+func (c *Captcha) Configure(config CaptchaConfig) error {
+	if config.Provider == "" {
+		return nil
+	}
+	if config.VerifyUrl == "" {
+		verifyUrl, known := captchaVerifyUrls[config.Provider]
+		if !known {
+			return fmt.Errorf("%w %q", ErrUnknownCaptchaProvider, config.Provider)
+		}
+		config.VerifyUrl = verifyUrl
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultCaptchaFailureThreshold
+	}
+	c.config = config
+	logging.Hub.Printf("Challenging suspicious logins with %s after %d failures", config.Provider, config.FailureThreshold)
+	return nil
+}
+
+func (c *Captcha) Enabled() bool {
+	return c.config.Provider != ""
+}
+
+// Send the client a challenge, counting why it was sent
+func (c *Captcha) Challenge(client ClientInterfacer, reason string) {
+	captchaChallengesTotal.With(reason).Inc()
+	client.SocketSend(packets.NewCaptchaChallenge(c.config.Provider, c.config.SiteKey))
+}
+
+// Whether everything from the IP should be challenged, for the logins that have failed from it lately
+func (c *Captcha) Suspicious(ip string) bool {
+	if !c.Enabled() {
+		return false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	activity := c.activityOf(ip)
+	return activity.failures >= c.config.FailureThreshold
+}
+
+// Whether a registration from the IP should be challenged
+func (c *Captcha) SuspiciousRegistration(ip string) bool {
+	if !c.Enabled() {
+		return false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	activity := c.activityOf(ip)
+	return activity.failures >= c.config.FailureThreshold || time.Since(activity.registeredAt) < captchaRegistrationWindow
+}
+
+// Count a failed login, or failed challenge, from the IP
+func (c *Captcha) Failed(ip string) {
+	if !c.Enabled() {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	activity := c.activityOf(ip)
+	if time.Since(activity.failedSince) >= captchaFailureWindow {
+		activity.failures = 0
+		activity.failedSince = time.Now()
+	}
+	activity.failures++
+}
+
+// Note that the IP's registered an account
+func (c *Captcha) Registered(ip string) {
+	if !c.Enabled() {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.activityOf(ip).registeredAt = time.Now()
+}
+
+// What's been seen from the IP, which must be called with the lock held. Pruned of IPs with nothing that counts any
+// more now and then.
+func (c *Captcha) activityOf(ip string) *captchaIpActivity {
+	now := time.Now()
+	if now.Sub(c.prunedAt) >= captchaFailureWindow {
+		for other, activity := range c.activity {
+			if now.Sub(activity.failedSince) >= captchaFailureWindow && now.Sub(activity.registeredAt) >= captchaRegistrationWindow {
+				delete(c.activity, other)
+			}
+		}
+		c.prunedAt = now
+	}
+
+	activity, exists := c.activity[ip]
+	if !exists {
+		activity = &captchaIpActivity{}
+		c.activity[ip] = activity
+	}
+	return activity
+}
+
+// Only hashes of IPs are kept with accounts here, since all they're needed for is telling whether one's been seen
+// before. Sessions keep only the network each is on, see ipNetwork.
+func hashIp(ip string) string {
+	hash := sha256.Sum256([]byte(ip))
+	return hex.EncodeToString(hash[:])
+}
+
+// Whether the account's logged in from the IP before. Accounts with no logins on record, e.g. from before they were
+// kept, have nothing to compare against, so any IP counts, as does every IP while CAPTCHAs are off.
+func (c *Captcha) KnownIp(ctx context.Context, queries *db.Queries, userId int64, ip string) (bool, error) {
+	if !c.Enabled() {
+		return true, nil
+	}
+	hashes, err := queries.GetLoginIpHashes(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	return len(hashes) == 0 || slices.Contains(hashes, hashIp(ip)), nil
+}
+
+// Keep that the account logged in from the IP, so it isn't challenged there again. Nothing's kept while CAPTCHAs are
+// off.
+func (c *Captcha) RecordLogin(ctx context.Context, queries *db.Queries, userId int64, ip string) error {
+	if !c.Enabled() || ip == "" {
+		return nil
+	}
+	return queries.RecordLoginIp(ctx, db.RecordLoginIpParams{
+		UserID:      userId,
+		IpHash:      hashIp(ip),
+		LastLoginAt: time.Now().UnixMilli(),
+	})
+}
+
+// Ask the provider whether the token the client sent back is valid for the IP it's connected from
+func (c *Captcha) Verify(ctx context.Context, token string, ip string) (bool, error) {
+	form := url.Values{"secret": {c.config.Secret}, "response": {token}}
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.VerifyUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		captchaVerificationsTotal.With("error").Inc()
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		captchaVerificationsTotal.With("error").Inc()
+		return false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		captchaVerificationsTotal.With("error").Inc()
+		return false, fmt.Errorf("%s responded with %s", c.config.Provider, response.Status)
+	}
+
+	// Both providers answer the same way
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		captchaVerificationsTotal.With("error").Inc()
+		return false, err
+	}
+	if !result.Success {
+		captchaVerificationsTotal.With("failed").Inc()
+		logging.Hub.Printf("CAPTCHA response from %s failed: %s", ip, strings.Join(result.ErrorCodes, ", "))
+		return false, nil
+	}
+	captchaVerificationsTotal.With("passed").Inc()
+	return true, nil
+}
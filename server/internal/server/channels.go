@@ -0,0 +1,334 @@
+package server
+
+import (
+	"errors"
+	"maps"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+)
+
+// How far past a channel's cap players can go to join someone already in it, so groups can stay together
+const channelJoinAllowance = 5
+
+var (
+	ErrNoSuchChannel = errors.New("no such channel")
+	ErrChannelFull   = errors.New("channel full")
+)
+
+type ChannelInfo struct {
+	Number        int
+	Population    int
+	MaxPopulation int
+}
+
+// Splits crowded zones into copies, or channels, each holding up to the zone's max population. Players entering a zone
+// go into the first channel with room, and a new one is opened when they're all full. Players in different channels
+// of the same zone don't see each other, or anything about each other, since that's decided by the hub's visibility.
+// Players in different zones always can, so nothing changes for players at a border.
+//
+// Channels are numbered from 1. Overflow channels stay open, and keep their numbers, until every channel after them is
+// empty too.
+type Channels struct {
+	hub *Hub
+
+	zones   map[string]*zoneChannels
+	members map[uint64]channelMembership
+	mux     sync.RWMutex
+}
+
+type zoneChannels struct {
+	maxPopulation int
+
+	// The clients in each channel, channel 1 first
+	channels []map[uint64]struct{}
+
+	// How many places in each channel are held for players who haven't come in yet
+	reserved []int
+}
+
+type channelMembership struct {
+	zoneId string
+	number int
+}
+
+func NewChannels(hub *Hub) *Channels {
+	return &Channels{
+		hub:     hub,
+		zones:   make(map[string]*zoneChannels),
+		members: make(map[uint64]channelMembership),
+	}
+}
+
+// Put the client in a channel of the zone, the preferred one if it has room. Returns the channel's number. Does
+// nothing if they're in the zone already.
+func (c *Channels) Enter(clientId uint64, zone *gamedata.Zone, preferred int) int {
+	return c.enter(clientId, zone, preferred, false)
+}
+
+// Put the client in the channel a place was reserved in for them, taking up the place. If it's gone, e.g. because the
+// reservation ran out, they go in whichever channel has room as usual.
+func (c *Channels) EnterReserved(clientId uint64, zone *gamedata.Zone, number int) int {
+	return c.enter(clientId, zone, number, true)
+}
+
+func (c *Channels) enter(clientId uint64, zone *gamedata.Zone, preferred int, reserved bool) int {
+	c.mux.Lock()
+	if membership, exists := c.members[clientId]; exists && membership.zoneId == zone.Id {
+		c.mux.Unlock()
+		return membership.number
+	}
+
+	apartBefore := c.apartFrom(clientId)
+	c.leave(clientId)
+
+	channels := c.zone(zone)
+	number := 0
+	if reserved && preferred > 0 && preferred <= len(channels.channels) && channels.reserved[preferred-1] > 0 {
+		channels.reserved[preferred-1]--
+		number = preferred
+	} else if preferred > 0 && preferred <= len(channels.channels) && channels.hasRoom(preferred, 0) {
+		number = preferred
+	}
+	for i := 1; number == 0 && i <= len(channels.channels); i++ {
+		if channels.hasRoom(i, 0) {
+			number = i
+		}
+	}
+	if number == 0 {
+		number = channels.open(zone.Id)
+	}
+	c.join(clientId, zone.Id, number)
+
+	apartAfter := c.apartFrom(clientId)
+	c.mux.Unlock()
+
+	c.separate(clientId, apartBefore, apartAfter)
+	return number
+}
+
+// Hold places in a channel of the zone for players on their way in, the given channel or else the first with room for
+// all of them, opening a new one if none has. Returns the channel's number.
+func (c *Channels) Reserve(zone *gamedata.Zone, number int, places int) (int, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	channels := c.zone(zone)
+	if number != 0 {
+		if number < 1 || number > len(channels.channels) {
+			return 0, ErrNoSuchChannel
+		}
+		if !channels.hasRoomFor(number, places) {
+			return 0, ErrChannelFull
+		}
+	}
+	for i := 1; number == 0 && i <= len(channels.channels); i++ {
+		if channels.hasRoomFor(i, places) {
+			number = i
+		}
+	}
+	if number == 0 {
+		number = channels.open(zone.Id)
+	}
+
+	channels.reserved[number-1] += places
+	return number, nil
+}
+
+// Give up places held in a channel, e.g. once the players they were for are no longer coming
+func (c *Channels) Unreserve(zoneId string, number int, places int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	channels, exists := c.zones[zoneId]
+	if !exists || number < 1 || number > len(channels.channels) {
+		return
+	}
+	channels.reserved[number-1] = max(channels.reserved[number-1]-places, 0)
+	channels.closeEmpty(zoneId)
+}
+
+// Move the client to another channel of the zone they're in. With priority, the channel can be over its cap by a
+// little, for joining someone in it.
+func (c *Channels) Switch(clientId uint64, number int, priority bool) error {
+	c.mux.Lock()
+	membership, exists := c.members[clientId]
+	if !exists {
+		c.mux.Unlock()
+		return ErrNoSuchChannel
+	}
+	if membership.number == number {
+		c.mux.Unlock()
+		return nil
+	}
+
+	channels := c.zones[membership.zoneId]
+	if number < 1 || number > len(channels.channels) {
+		c.mux.Unlock()
+		return ErrNoSuchChannel
+	}
+	allowance := 0
+	if priority {
+		allowance = channelJoinAllowance
+	}
+	if !channels.hasRoom(number, allowance) {
+		c.mux.Unlock()
+		return ErrChannelFull
+	}
+
+	apartBefore := c.apartFrom(clientId)
+	c.leave(clientId)
+	c.join(clientId, membership.zoneId, number)
+	apartAfter := c.apartFrom(clientId)
+	c.mux.Unlock()
+
+	c.separate(clientId, apartBefore, apartAfter)
+	return nil
+}
+
+// Take the client out of whatever channel they're in, e.g. as they leave the game
+func (c *Channels) Leave(clientId uint64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.leave(clientId)
+}
+
+// The clients in any channel
+func (c *Channels) clientIds() []uint64 {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return slices.Collect(maps.Keys(c.members))
+}
+
+// The zone and number of the channel the client is in, or false if they aren't in one
+func (c *Channels) Current(clientId uint64) (string, int, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	membership, exists := c.members[clientId]
+	return membership.zoneId, membership.number, exists
+}
+
+// Every channel of the zone
+func (c *Channels) List(zoneId string) []ChannelInfo {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	channels, exists := c.zones[zoneId]
+	if !exists {
+		return nil
+	}
+
+	infos := make([]ChannelInfo, len(channels.channels))
+	for i, members := range channels.channels {
+		infos[i] = ChannelInfo{Number: i + 1, Population: len(members), MaxPopulation: channels.maxPopulation}
+	}
+	return infos
+}
+
+// Whether the clients can see each other. Clients not in game are never kept apart.
+func (c *Channels) Together(clientId uint64, otherId uint64) bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+	return c.together(clientId, otherId)
+}
+
+// Must be called with the lock held
+func (c *Channels) together(clientId uint64, otherId uint64) bool {
+	membership, exists := c.members[clientId]
+	otherMembership, otherExists := c.members[otherId]
+	return !exists || !otherExists || membership.zoneId != otherMembership.zoneId || membership.number == otherMembership.number
+}
+
+// Everyone the client can't see. Must be called with the lock held.
+func (c *Channels) apartFrom(clientId uint64) map[uint64]struct{} {
+	apart := make(map[uint64]struct{})
+	for otherId := range c.members {
+		if !c.together(clientId, otherId) {
+			apart[otherId] = struct{}{}
+		}
+	}
+	return apart
+}
+
+// Tell the client and everyone they can no longer see that the others are gone, since nothing more is sent about them
+func (c *Channels) separate(clientId uint64, apartBefore map[uint64]struct{}, apartAfter map[uint64]struct{}) {
+	client, exists := c.hub.Clients.Get(clientId)
+	if !exists {
+		return
+	}
+
+	for otherId := range apartAfter {
+		if _, wasApart := apartBefore[otherId]; wasApart {
+			continue
+		}
+		if other, exists := c.hub.Clients.Get(otherId); exists {
+			other.ProcessMessage(clientId, packets.NewDisconnect("Changed channel"))
+			client.ProcessMessage(otherId, packets.NewDisconnect("Changed channel"))
+		}
+	}
+}
+
+// Must be called with the lock held
+func (c *Channels) zone(zone *gamedata.Zone) *zoneChannels {
+	channels, exists := c.zones[zone.Id]
+	if !exists {
+		channels = &zoneChannels{
+			maxPopulation: zone.MaxPopulation,
+			channels:      []map[uint64]struct{}{make(map[uint64]struct{})},
+			reserved:      []int{0},
+		}
+		c.zones[zone.Id] = channels
+	}
+	return channels
+}
+
+// Must be called with the lock held
+func (c *Channels) join(clientId uint64, zoneId string, number int) {
+	c.zones[zoneId].channels[number-1][clientId] = struct{}{}
+	c.members[clientId] = channelMembership{zoneId: zoneId, number: number}
+}
+
+// Must be called with the lock held
+func (c *Channels) leave(clientId uint64) {
+	membership, exists := c.members[clientId]
+	if !exists {
+		return
+	}
+	delete(c.members, clientId)
+
+	channels := c.zones[membership.zoneId]
+	delete(channels.channels[membership.number-1], clientId)
+	channels.closeEmpty(membership.zoneId)
+}
+
+// Open another channel at the end. Returns its number.
+func (z *zoneChannels) open(zoneId string) int {
+	z.channels = append(z.channels, make(map[uint64]struct{}))
+	z.reserved = append(z.reserved, 0)
+	number := len(z.channels)
+	logging.Hub.Printf("Zone %s is full, opened channel %d", zoneId, number)
+	return number
+}
+
+// Close empty overflow channels at the end, leaving channel 1 open. Channels with places held in them aren't empty.
+func (z *zoneChannels) closeEmpty(zoneId string) {
+	for last := len(z.channels) - 1; last > 0 && len(z.channels[last]) == 0 && z.reserved[last] == 0; last-- {
+		z.channels = z.channels[:last]
+		z.reserved = z.reserved[:last]
+		logging.Hub.Printf("Closed empty channel %d of zone %s", last+1, zoneId)
+	}
+}
+
+// Whether the channel can take one more, going over the cap by up to the allowance. Zones without a cap only ever
+// have the one channel, which always has room.
+func (z *zoneChannels) hasRoom(number int, allowance int) bool {
+	return z.hasRoomFor(number, 1-allowance)
+}
+
+// Whether the channel has room for that many more, counting the places already held in it
+func (z *zoneChannels) hasRoomFor(number int, places int) bool {
+	return z.maxPopulation <= 0 || len(z.channels[number-1])+z.reserved[number-1]+places <= z.maxPopulation
+}
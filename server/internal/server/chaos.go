@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync/atomic"
+	"time"
+)
+
+// The longest any fault can be injected for, so one left on by mistake wears off on its own
+const maxChaosDuration = 10 * time.Minute
+
+var ErrChaosDisabled = errors.New("chaos testing isn't enabled")
+
+var chaosInjectedTotal = metrics.NewCounterVec("mmo_chaos_injected_total", "Faults injected for chaos testing, by kind.", "kind")
+
+// Injects failures on command, for checking the server copes with them: clients reconnecting after being dropped, the
+// circuit breaker opening and write-behind catching up once the database stalls, and clients putting up with lag. It
+// does nothing unless enabled, which should only ever be done on servers players aren't on.
+type Chaos struct {
+	hub     *Hub
+	enabled atomic.Bool
+
+	// Until when the database is stalled, and broadcasts delayed, as Unix nanoseconds, or 0 if they aren't
+	dbStalledUntil         atomic.Int64
+	broadcastsDelayedUntil atomic.Int64
+	broadcastDelayNanos    atomic.Int64
+}
+
+func NewChaos(hub *Hub) *Chaos {
+	return &Chaos{hub: hub}
+}
+
+// Must be called before the hub is run for any faults to be injected
+func (c *Chaos) Enable() {
+	c.enabled.Store(true)
+	logging.Hub.Println("Chaos testing is enabled, which shouldn't be done in production")
+}
+
+func (c *Chaos) Enabled() bool {
+	return c.enabled.Load()
+}
+
+// What's being injected right now
+type ChaosStatus struct {
+	DbStalledUntil         *time.Time    `json:"db_stalled_until,omitempty"`
+	BroadcastsDelayedUntil *time.Time    `json:"broadcasts_delayed_until,omitempty"`
+	BroadcastDelay         time.Duration `json:"broadcast_delay,omitempty"`
+}
+
+func (c *Chaos) Status() ChaosStatus {
+	var status ChaosStatus
+	if until, stalled := c.until(&c.dbStalledUntil); stalled {
+		status.DbStalledUntil = &until
+	}
+	if until, delayed := c.until(&c.broadcastsDelayedUntil); delayed {
+		status.BroadcastsDelayedUntil = &until
+		status.BroadcastDelay = time.Duration(c.broadcastDelayNanos.Load())
+	}
+	return status
+}
+
+// Close a share of the clients, from 0 to 100 percent, picked at random, as though their connections had dropped.
+// Returns how many were closed.
+func (c *Chaos) DropClients(percent float64) (int, error) {
+	if !c.Enabled() {
+		return 0, ErrChaosDisabled
+	}
+
+	var dropped []ClientInterfacer
+	// Not the world's random source, so dropping clients doesn't change how a deterministic simulation plays out
+	c.hub.Clients.ForEach(func(_ uint64, client ClientInterfacer) {
+		if rand.Float64()*100 < percent {
+			dropped = append(dropped, client)
+		}
+	})
+	for _, client := range dropped {
+		client.Close("Connection dropped for chaos testing")
+	}
+
+	chaosInjectedTotal.With("drop_clients").Add(uint64(len(dropped)))
+	logging.Hub.Printf("Chaos: dropped %d clients", len(dropped))
+	return len(dropped), nil
+}
+
+// Have every query wait as though the database weren't answering, for the duration or until the query's context runs
+// out, whichever's first. Health checks time out the same way, so the circuit opens if it goes on long enough.
+func (c *Chaos) StallDb(duration time.Duration) error {
+	if !c.Enabled() {
+		return ErrChaosDisabled
+	}
+	duration = min(duration, maxChaosDuration)
+	c.dbStalledUntil.Store(time.Now().Add(duration).UnixNano())
+
+	chaosInjectedTotal.With("stall_db").Inc()
+	logging.Hub.Printf("Chaos: stalling the database for %s", duration)
+	return nil
+}
+
+// Hold every broadcast back by the delay before it's sent on to other clients, for the duration
+func (c *Chaos) DelayBroadcasts(delay time.Duration, duration time.Duration) error {
+	if !c.Enabled() {
+		return ErrChaosDisabled
+	}
+	delay, duration = min(delay, maxChaosDuration), min(duration, maxChaosDuration)
+	c.broadcastDelayNanos.Store(int64(delay))
+	c.broadcastsDelayedUntil.Store(time.Now().Add(duration).UnixNano())
+
+	chaosInjectedTotal.With("delay_broadcasts").Inc()
+	logging.Hub.Printf("Chaos: delaying broadcasts by %s for %s", delay, duration)
+	return nil
+}
+
+// Stop injecting everything that's still being injected
+func (c *Chaos) Stop() {
+	c.dbStalledUntil.Store(0)
+	c.broadcastsDelayedUntil.Store(0)
+	logging.Hub.Println("Chaos: stopped")
+}
+
+// Wait out the database stall, if there is one, returning the context's error if it runs out first
+func (c *Chaos) waitForDb(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+	until, stalled := c.until(&c.dbStalledUntil)
+	if !stalled {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Until(until))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// How long to hold the next broadcast back, if at all
+func (c *Chaos) broadcastDelay() time.Duration {
+	if _, delayed := c.until(&c.broadcastsDelayedUntil); !delayed {
+		return 0
+	}
+	return time.Duration(c.broadcastDelayNanos.Load())
+}
+
+func (c *Chaos) until(at *atomic.Int64) (time.Time, bool) {
+	until := at.Load()
+	if until == 0 || time.Now().UnixNano() >= until {
+		return time.Time{}, false
+	}
+	return time.Unix(0, until), true
+}
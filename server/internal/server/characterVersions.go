@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync/atomic"
+	"time"
+)
+
+// Returned for saves and transactions of a character that's been loaded somewhere else since the session making them
+// loaded it
+var ErrStaleCharacter = errors.New("character has been loaded by another session since")
+
+var saveConflictsTotal = metrics.NewCounter("mmo_save_conflicts_total", "Sessions whose saves were rejected because their character was loaded by another session.")
+
+// A session's hold on a character. Each time a character is loaded its version goes up, and the session's saves are
+// only written while the version is still the one it loaded, so if the same character ends up in play twice, e.g. on
+// two shards through a bug, the session that loaded it first can't overwrite what the other is doing. Once one of its
+// saves is rejected the session is stale, none of its saves are written from then on, and it's sent off. The same goes
+// for its transactions, e.g. granting or taking items, which are rolled back instead.
+type characterClaim struct {
+	clientId   uint64
+	playerDbId int64
+	version    int64
+	stale      atomic.Bool
+}
+
+// Load the character for the client's session, so that any session that loaded it before can no longer save it
+func (t *DbTx) ClaimCharacter(clientId uint64, playerDbId int64) error {
+	version, err := t.Queries.ClaimPlayerVersion(t.Ctx, db.ClaimPlayerVersionParams{
+		PlayerID:  playerDbId,
+		ClaimedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	t.claim.Store(&characterClaim{clientId: clientId, playerDbId: playerDbId, version: version})
+	return nil
+}
+
+// Let go of the character once the session's done playing it, so what the session does next isn't checked against it
+func (t *DbTx) ReleaseCharacter() {
+	t.claim.Store(nil)
+}
+
+// Only write the save if the session's claim on the character is still good
+func (t *DbTx) versioned(playerDbId int64, key string, write func(ctx context.Context, queries *db.Queries) error) (func(ctx context.Context, queries *db.Queries) error, error) {
+	claim := t.claim.Load()
+	if claim == nil || claim.playerDbId != playerDbId {
+		return write, nil
+	}
+	if claim.stale.Load() {
+		return nil, ErrStaleCharacter
+	}
+
+	return func(ctx context.Context, queries *db.Queries) error {
+		if err := t.checkClaim(ctx, queries, claim, key); err != nil {
+			return err
+		}
+		return write(ctx, queries)
+	}, nil
+}
+
+// Return ErrStaleCharacter if the character's been claimed by another session since this claim was made, sending this
+// one off the first time it's found to be
+func (t *DbTx) checkClaim(ctx context.Context, queries *db.Queries, claim *characterClaim, key string) error {
+	if claim.stale.Load() {
+		return ErrStaleCharacter
+	}
+	matched, err := queries.CheckPlayerVersion(ctx, db.CheckPlayerVersionParams{PlayerID: claim.playerDbId, Version: claim.version})
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		if claim.stale.CompareAndSwap(false, true) {
+			// The check may be part of a transaction that's about to be rolled back, so this waits for it
+			go t.rejectStale(claim, key)
+		}
+		return ErrStaleCharacter
+	}
+	return nil
+}
+
+// Log and record the conflict for reconciliation, then send the stale session off if it's here and still playing the
+// character
+func (t *DbTx) rejectStale(claim *characterClaim, key string) {
+	saveConflictsTotal.Inc()
+
+	current, err := t.Queries.GetPlayerVersion(t.Ctx, claim.playerDbId)
+	if err != nil {
+		logging.Db.Errorf("Error getting version of player %d: %v", claim.playerDbId, err)
+	}
+	logging.Db.Printf("Rejected write (%s) of player %d from a stale session: it loaded version %d, but version %d has been loaded since",
+		key, claim.playerDbId, claim.version, current.Version)
+
+	err = t.RecordEvent(t.Queries, "player.save_conflict", map[string]any{
+		"player_id":       claim.playerDbId,
+		"key":             key,
+		"stale_version":   claim.version,
+		"current_version": current.Version,
+		"claimed_at":      current.ClaimedAt,
+	})
+	if err != nil {
+		logging.Db.Errorf("Error recording save conflict of player %d: %v", claim.playerDbId, err)
+	}
+
+	if client, online := t.clients.Get(claim.clientId); online && client.DbTx().claim.Load() == claim {
+		client.SocketSend(packets.NewDenyResponse("Your character was loaded somewhere else"))
+		go client.Kick(KickSessionReplaced, "Character loaded by another session")
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"time"
+)
+
+const (
+	// How long what's said in chat is kept for unless configured otherwise
+	DefaultChatRetention = 30 * 24 * time.Hour
+
+	chatCleanupJob      = "chat.cleanup"
+	chatCleanupInterval = time.Hour
+
+	// The most messages exported at once, so a huge range can't tie up the database
+	MaxChatExportMessages = 100000
+)
+
+// Keeps what's said in each channel of each zone, along with the accounts it was said by, so moderators can export a
+// stretch of it when handling harassment reports. Messages are deleted once they're older than the retention policy,
+// and can't be exported from before it even if they haven't been deleted yet.
+type ChatHistory struct {
+	hub       *Hub
+	retention time.Duration
+}
+
+func NewChatHistory(hub *Hub) *ChatHistory {
+	h := &ChatHistory{hub: hub, retention: DefaultChatRetention}
+	hub.Jobs.Register(chatCleanupJob, h.cleanUp, JobOptions{Every: chatCleanupInterval})
+	return h
+}
+
+// Must be called before the hub is run. DefaultChatRetention if 0.
+func (h *ChatHistory) Configure(retention time.Duration) {
+	if retention > 0 {
+		h.retention = retention
+	}
+	logging.Hub.Printf("Keeping chat for %s", h.retention)
+}
+
+func (h *ChatHistory) Retention() time.Duration {
+	return h.retention
+}
+
+// Keep the message said by the player in the channel. The write waits for the database if it's unavailable.
+func (h *ChatHistory) Record(zoneId string, channel int, userId int64, playerDbId int64, sender string, message string) {
+	chatMessage := db.CreateChatMessageParams{
+		ZoneID:     zoneId,
+		Channel:    int64(channel),
+		UserID:     userId,
+		PlayerID:   playerDbId,
+		SenderName: sender,
+		Message:    message,
+		CreatedAt:  time.Now().UnixMilli(),
+	}
+	err := h.hub.NewDbTx().Batch("chat message", func(ctx context.Context, queries *db.Queries) error {
+		return queries.CreateChatMessage(ctx, chatMessage)
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error keeping chat message from %s: %v", sender, err)
+	}
+}
+
+// What was said in the channel of the zone from the start of the range up to its end, oldest first, or in every
+// channel of the zone if the channel's 0. The range starts no earlier than the retention policy goes back. Returns
+// whether there was more than MaxChatExportMessages, in which case only the first are returned.
+func (h *ChatHistory) Export(ctx context.Context, queries *db.Queries, zoneId string, channel int, from time.Time, to time.Time) ([]db.ChatMessage, bool, error) {
+	from = h.Clamp(from)
+
+	var messages []db.ChatMessage
+	var err error
+	if channel == 0 {
+		messages, err = queries.GetZoneChatMessages(ctx, db.GetZoneChatMessagesParams{
+			ZoneID:      zoneId,
+			CreatedAt:   from.UnixMilli(),
+			CreatedAt_2: to.UnixMilli(),
+			Limit:       MaxChatExportMessages + 1,
+		})
+	} else {
+		messages, err = queries.GetChatMessages(ctx, db.GetChatMessagesParams{
+			ZoneID:      zoneId,
+			Channel:     int64(channel),
+			CreatedAt:   from.UnixMilli(),
+			CreatedAt_2: to.UnixMilli(),
+			Limit:       MaxChatExportMessages + 1,
+		})
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(messages) > MaxChatExportMessages {
+		return messages[:MaxChatExportMessages], true, nil
+	}
+	return messages, false, nil
+}
+
+// The time, or the furthest back the retention policy goes if it's before that
+func (h *ChatHistory) Clamp(from time.Time) time.Time {
+	if oldest := time.Now().Add(-h.retention); from.Before(oldest) {
+		return oldest
+	}
+	return from
+}
+
+// Delete what was said longer ago than the retention policy keeps it for
+func (h *ChatHistory) cleanUp(ctx context.Context, _ json.RawMessage) error {
+	deleted, err := h.hub.NewDbTx().Queries.DeleteChatMessagesBefore(ctx, time.Now().Add(-h.retention).UnixMilli())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logging.Hub.Printf("Deleted %d chat messages older than %s", deleted, h.retention)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"errors"
+	"maps"
+	"server/internal/server/metrics"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// How long after being sent chat messages can be reacted to, and how many of the latest are kept for it at most
+	chatReactionWindow    = 10 * time.Minute
+	maxReactableChatCount = 1000
+
+	// Each player can react this many times in a row, then once each cooldown
+	chatReactionBurst    = 5
+	chatReactionCooldown = 2 * time.Second
+)
+
+var (
+	ErrChatMessageGone  = errors.New("message is too old or doesn't exist")
+	ErrAlreadyReacted   = errors.New("already reacted with that emote")
+	ErrNotReacted       = errors.New("not reacted with that emote")
+	ErrReactionCooldown = errors.New("reacting too fast")
+)
+
+var chatReactionsTotal = metrics.NewCounterVec("mmo_chat_reactions_total", "Reactions to chat messages, by whether they were added, removed or refused.", "result")
+
+// Who's reacted to one chat message with each emote, by player
+type chatMessageReactions struct {
+	sentAt   time.Time
+	reactors map[string]map[int64]struct{}
+}
+
+// How many reactions a player has left before having to wait, topped up continuously
+type chatReactionAllowance struct {
+	tokens     float64
+	refilledAt time.Time
+}
+
+// Counts the emotes players react to recent chat messages with. Only the latest messages can be reacted to, and each
+// player can only react so often, so reactions can't be used to spam everyone. Counts are only kept in memory, since
+// they only matter while the messages are still on players' screens.
+type ChatReactions struct {
+	messages map[uint64]*chatMessageReactions
+
+	// Oldest first
+	order []uint64
+
+	// By client ID
+	allowances map[uint64]*chatReactionAllowance
+
+	mux sync.Mutex
+}
+
+func NewChatReactions() *ChatReactions {
+	return &ChatReactions{
+		messages:   make(map[uint64]*chatMessageReactions),
+		allowances: make(map[uint64]*chatReactionAllowance),
+	}
+}
+
+// A chat message was sent with the ID, so it can be reacted to from now on
+func (r *ChatReactions) Sent(messageId uint64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	now := time.Now()
+	r.messages[messageId] = &chatMessageReactions{sentAt: now, reactors: make(map[string]map[int64]struct{})}
+	r.order = append(r.order, messageId)
+
+	expired := 0
+	for _, id := range r.order {
+		if len(r.order)-expired <= maxReactableChatCount && now.Sub(r.messages[id].sentAt) < chatReactionWindow {
+			break
+		}
+		delete(r.messages, id)
+		expired++
+	}
+	r.order = r.order[expired:]
+}
+
+// React to the message as the player, or take the reaction back, returning how many reactions the message now has with
+// that emote. With ErrReactionCooldown, also returns how long until the client can react again.
+func (r *ChatReactions) React(clientId uint64, playerDbId int64, messageId uint64, emoteId string, remove bool) (int, time.Duration, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	message, exists := r.messages[messageId]
+	if !exists || time.Since(message.sentAt) >= chatReactionWindow {
+		chatReactionsTotal.With("gone").Inc()
+		return 0, 0, ErrChatMessageGone
+	}
+
+	if wait := r.spend(clientId); wait > 0 {
+		chatReactionsTotal.With("cooldown").Inc()
+		return 0, wait, ErrReactionCooldown
+	}
+
+	reactors := message.reactors[emoteId]
+	_, reacted := reactors[playerDbId]
+	switch {
+	case remove && !reacted:
+		return len(reactors), 0, ErrNotReacted
+	case remove:
+		delete(reactors, playerDbId)
+		if len(reactors) == 0 {
+			delete(message.reactors, emoteId)
+		}
+		chatReactionsTotal.With("removed").Inc()
+		return len(reactors), 0, nil
+	case reacted:
+		return len(reactors), 0, ErrAlreadyReacted
+	}
+
+	if reactors == nil {
+		reactors = make(map[int64]struct{})
+		message.reactors[emoteId] = reactors
+	}
+	reactors[playerDbId] = struct{}{}
+	chatReactionsTotal.With("added").Inc()
+	return len(reactors), 0, nil
+}
+
+// Use up one of the client's reactions, returning how long it has to wait first if it has none left. Must be called
+// with the lock held.
+func (r *ChatReactions) spend(clientId uint64) time.Duration {
+	now := time.Now()
+	allowance, exists := r.allowances[clientId]
+	if !exists {
+		allowance = &chatReactionAllowance{tokens: chatReactionBurst, refilledAt: now}
+		r.allowances[clientId] = allowance
+	}
+
+	refill := float64(now.Sub(allowance.refilledAt)) / float64(chatReactionCooldown)
+	allowance.tokens = min(allowance.tokens+refill, chatReactionBurst)
+	allowance.refilledAt = now
+	if allowance.tokens < 1 {
+		return time.Duration((1 - allowance.tokens) * float64(chatReactionCooldown))
+	}
+	allowance.tokens--
+	return 0
+}
+
+// The clients with a cooldown kept
+func (r *ChatReactions) clientIds() []uint64 {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	return slices.Collect(maps.Keys(r.allowances))
+}
+
+// Forget the client's cooldown once it's disconnected
+func (r *ChatReactions) Forget(clientId uint64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.allowances, clientId)
+}
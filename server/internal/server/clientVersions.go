@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+)
+
+type ClientVersionStatus int
+
+const (
+	// The client's build is accepted
+	ClientVersionOk ClientVersionStatus = iota
+
+	// The client's build is older than the minimum, but can still log in until the deadline, when it must have updated
+	ClientVersionUpdateSoon
+
+	// The client's build is older than the minimum and the deadline has passed, so it has to update before logging in
+	ClientVersionOutdated
+
+	// The client's build is newer than the maximum, e.g. because it was released before the server it works with
+	ClientVersionTooNew
+)
+
+var clientVersionStatusNames = map[ClientVersionStatus]string{
+	ClientVersionOk:         "ok",
+	ClientVersionUpdateSoon: "update_soon",
+	ClientVersionOutdated:   "outdated",
+	ClientVersionTooNew:     "too_new",
+}
+
+func (s ClientVersionStatus) String() string {
+	return clientVersionStatusNames[s]
+}
+
+var clientVersionChecksTotal = metrics.NewCounterVec("mmo_client_version_checks_total", "Client builds checked on registering and logging in, by the result: ok, update_soon, outdated or too_new.", "result")
+
+// Which client builds may log in, so breaking protocol changes can be rolled out in stages: raise the minimum with a
+// deadline, and clients older than it are warned to update until the deadline passes, after which they're turned away
+// with where to download the new build
+type ClientVersionPolicy struct {
+	// The oldest build accepted, or 0 for any
+	MinBuild uint32 `json:"min_build"`
+
+	// The newest build accepted, or 0 for any
+	MaxBuild uint32 `json:"max_build"`
+
+	// Until when builds older than the minimum are still let in with a warning. If left out they're turned away
+	// straight away.
+	UpdateDeadline *time.Time `json:"update_deadline,omitempty"`
+
+	// Where players can get the latest build
+	DownloadUrl string `json:"download_url"`
+}
+
+// Enforces the client version policy, which can be changed while the server is running, e.g. through the admin API
+// to move a rollout along
+type ClientVersions struct {
+	policy ClientVersionPolicy
+	mux    sync.RWMutex
+}
+
+func NewClientVersions() *ClientVersions {
+	return &ClientVersions{}
+}
+
+func (v *ClientVersions) Configure(policy ClientVersionPolicy) error {
+	if policy.MaxBuild != 0 && policy.MinBuild > policy.MaxBuild {
+		return errors.New("the minimum client build can't be newer than the maximum")
+	}
+
+	v.mux.Lock()
+	v.policy = policy
+	v.mux.Unlock()
+
+	if policy.MinBuild != 0 || policy.MaxBuild != 0 {
+		logging.Hub.Printf("Accepting client builds %d to %d", policy.MinBuild, policy.MaxBuild)
+	}
+	return nil
+}
+
+func (v *ClientVersions) Policy() ClientVersionPolicy {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	return v.policy
+}
+
+// How the build compares to the policy at the given time, along with the policy it was checked against. Clients
+// that don't say which build they are count as build 0, older than any minimum.
+func (v *ClientVersions) Check(build uint32, now time.Time) (ClientVersionStatus, ClientVersionPolicy) {
+	policy := v.Policy()
+
+	status := ClientVersionOk
+	switch {
+	case policy.MaxBuild != 0 && build > policy.MaxBuild:
+		status = ClientVersionTooNew
+	case build < policy.MinBuild && policy.UpdateDeadline != nil && now.Before(*policy.UpdateDeadline):
+		status = ClientVersionUpdateSoon
+	case build < policy.MinBuild:
+		status = ClientVersionOutdated
+	}
+
+	clientVersionChecksTotal.With(status.String()).Inc()
+	return status, policy
+}
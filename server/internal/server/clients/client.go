@@ -0,0 +1,617 @@
+package clients
+
+import (
+	"fmt"
+	"net"
+	"server/internal/server"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/states"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// How long a kicked client's packets, the kick included, get to be written before it's closed anyway
+const kickWriteTimeout = 2 * time.Second
+
+// How many packets a client can send that can't be parsed before it's kicked for it, so a client that's fallen out of
+// step with the protocol is told so rather than ignored for good
+const maxMalformedPackets = 10
+
+var protocolViolationsTotal = metrics.NewCounterVec("mmo_websocket_protocol_violations_total", "Clients disconnected for breaking the limits on what they can send.", "reason")
+
+// A packet waiting in the send queue. The message's encoding may be shared with other clients it's being sent to.
+type outgoingPacket struct {
+	senderId uint64
+	message  *packets.SharedMsg
+
+	// Which of the client's states it was sent in, counting from 1, see baseClient.states
+	state uint64
+
+	// Closed once the packet's been written, if anything's waiting on it
+	written chan struct{}
+}
+
+// What makes a client one, whatever it's connected over. Each transport's client embeds it, and only has to pump
+// packets over its connection and close it.
+type baseClient struct {
+	id          uint64
+	hub         *server.Hub
+	sendChan    chan outgoingPacket
+	state       server.ClientStateHandler
+	logger      *logging.Logger
+	dbTx        *server.DbTx
+	clockSync   *server.ClockSync
+	locale      *server.Locale
+	prompts     *server.Prompts
+	deadLetters *deadLetters
+	limits      WebSocketLimits
+	bandwidth   *server.BandwidthSession
+
+	// The client embedding this one, which is what the hub and states are given
+	transport transport
+
+	// The user agent the client connected with
+	userAgent string
+
+	// The shadow of the current state, if it's being shadowed
+	shadow atomic.Pointer[server.Shadow]
+
+	// How many states the client's been in, so the write pump knows which packets were sent before the client's view
+	// of the world was started over
+	states atomic.Uint64
+
+	// How many packets the client's sent that couldn't be parsed, only counted by whatever processes what it sends
+	malformed int
+
+	// Reused for every packet written to the connection, so writing doesn't allocate once it's grown big enough
+	writeBuf []byte
+
+	// Closed once the client is, which stops the write pump. Both pumps close the client when they stop, so only
+	// the first does anything.
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// What each transport's client does differently
+type transport interface {
+	server.ClientInterfacer
+
+	// Let go of the connection once the client's closed, telling the other end why if there's a way to
+	closeTransport(reason string)
+}
+
+// Which players a client's been sent reliably, for transports that can send packets unreliably. Players are sent
+// again every tick as they move, so once a client knows of one, one update lost is soon made up for and one late is no
+// use, but the first it's sent of them mustn't be lost, or a player standing still would never be seen. So only Player
+// packets about players the client knows of are sent unreliably, and the next after a player leaves, or the client's
+// state changes, which starts its view of the world over, is sent reliably. Clients ignore unreliable Player packets
+// about players they don't know of, so one sent before a player left and arriving after doesn't leave a ghost. Only
+// used by the write pump.
+type unreliablePlayers struct {
+	known map[uint64]struct{}
+	state uint64
+}
+
+// Whether the packet should be sent reliably
+func (u *unreliablePlayers) reliable(packet outgoingPacket) bool {
+	if u.known == nil || packet.state != u.state {
+		u.known = make(map[uint64]struct{})
+		u.state = packet.state
+	}
+	switch message := packet.message.Msg.(type) {
+	case *packets.Packet_Player:
+		if _, known := u.known[message.Player.Id]; known {
+			return false
+		}
+		u.known[message.Player.Id] = struct{}{}
+	case *packets.Packet_Disconnect:
+		delete(u.known, packet.senderId)
+	case *packets.Packet_PlayerConsumed:
+		delete(u.known, message.PlayerConsumed.PlayerId)
+	case *packets.Packet_Interest:
+		for _, playerId := range message.Interest.Left {
+			delete(u.known, playerId)
+		}
+	}
+	return true
+}
+
+// Optionally implemented by transports that can tell the other end how it's being closed, once a kick's been written
+type kickCloser interface {
+	closeForKick(kick *packets.KickMessage)
+}
+
+// Must be called before the client's used, with the client embedding this one
+func (c *baseClient) init(hub *server.Hub, limits WebSocketLimits, transport transport) {
+	c.hub = hub
+	c.transport = transport
+	c.sendChan = make(chan outgoingPacket, 256)
+	c.logger = logging.Clients.NewLogger("Client unknown: ")
+	c.dbTx = hub.NewDbTx()
+	c.clockSync = &server.ClockSync{}
+	c.locale = &server.Locale{}
+	c.deadLetters = &deadLetters{logger: c.logger}
+	c.limits = limits
+	c.bandwidth = hub.Bandwidth.NewSession()
+	c.closed = make(chan struct{})
+	c.prompts = server.NewPrompts(c.SocketSend)
+}
+
+func (c *baseClient) Id() uint64 {
+	return c.id
+}
+
+func (c *baseClient) SetState(state server.ClientStateHandler) {
+	prevStateName := "None"
+	if c.state != nil {
+		prevStateName = c.state.Name()
+		c.state.OnExit()
+	}
+
+	newStateName := "None"
+	if state != nil {
+		newStateName = state.Name()
+	}
+
+	c.logger.Printf("Switching from state %s to %s", prevStateName, newStateName)
+
+	if shadow := c.shadow.Swap(nil); shadow != nil {
+		shadow.Record("state", 0, nil, newStateName)
+	}
+	c.state = state
+	c.states.Add(1)
+
+	if c.state != nil {
+		c.state.SetClient(c.transport)
+		c.state.OnEnter()
+	}
+	c.shadow.Store(c.hub.Shadows.Start(c.transport, c.state))
+}
+
+func (c *baseClient) ProcessMessage(senderId uint64, message packets.Msg) {
+	// A client that's closing has left its last state, but can still be passed broadcasts until it's unregistered,
+	// e.g. about the actors it drove being despawned as it left
+	if c.state == nil {
+		return
+	}
+
+	shadow := c.shadow.Load()
+	if shadow == nil {
+		done := c.hub.Watchdog.TrackHandler(c.state.Name(), message)
+		c.state.HandleMessage(senderId, message)
+		done()
+		return
+	}
+
+	// Only the current state's handling is timed, not the shadow's
+	shadow.Mirror(senderId, message, func() {
+		done := c.hub.Watchdog.TrackHandler(c.state.Name(), message)
+		c.state.HandleMessage(senderId, message)
+		done()
+	})
+}
+
+func (c *baseClient) Initialize(id uint64) {
+	c.id = id
+	c.logger.SetPrefix(fmt.Sprintf("Client %d: ", c.id))
+	if c.hub.NewInitialState != nil {
+		c.SetState(c.hub.NewInitialState())
+	} else {
+		c.SetState(&states.Connected{})
+	}
+}
+
+func (c *baseClient) SocketSend(message packets.Msg) {
+	c.SocketSendAs(message, c.id)
+}
+
+func (c *baseClient) SocketSendAs(message packets.Msg, senderId uint64) {
+	if shadow := c.shadow.Load(); shadow != nil {
+		shadow.Record("send", senderId, message, "")
+	}
+	packet := outgoingPacket{senderId: senderId, message: c.hub.SharedMsg(message), state: c.states.Load()}
+	c.hub.Impersonations.Mirror(c.id, senderId, message)
+	select {
+	case c.sendChan <- packet:
+	default:
+		held := c.deadLetters.record("queue_full", packet)
+		if held {
+			c.logger.Printf("Send channel full, holding message for retry: %T", message)
+		} else {
+			c.logger.Printf("Send channel full, dropping message: %T", message)
+		}
+		if c.hub.IsBroadcasting(packet.message) {
+			c.hub.Fanout.Backpressured(held)
+		}
+	}
+}
+
+func (c *baseClient) PassToPeer(message packets.Msg, peerId uint64) {
+	if shadow := c.shadow.Load(); shadow != nil {
+		shadow.Record("pass", peerId, message, "")
+	}
+	if peer, exists := c.hub.Clients.Get(peerId); exists {
+		peer.ProcessMessage(c.id, message)
+	}
+}
+
+func (c *baseClient) Broadcast(message packets.Msg) {
+	if shadow := c.shadow.Load(); shadow != nil {
+		shadow.Record("broadcast", 0, message, "")
+	}
+	c.hub.Broadcast(&packets.Packet{SenderId: c.id, Msg: message})
+}
+
+// Handle a packet as it's read, or in a deterministic simulation on its next tick
+func (c *baseClient) handleSocketData(data []byte) {
+	if c.hub.Simulation.Deterministic() {
+		c.hub.Simulation.Receive(c.transport, data)
+	} else {
+		c.ProcessSocketData(data)
+	}
+}
+
+func (c *baseClient) ProcessSocketData(data []byte) {
+	// Only peek at what kind of message this is first, so messages the current state would ignore anyway aren't
+	// fully decoded
+	envelope, err := packets.ParseEnvelope(data)
+	if err != nil {
+		c.logger.Errorf("error parsing packet envelope: %v", err)
+		c.malformedPacket()
+		return
+	}
+	c.hub.ModerationCases.Received(c.id, envelope.Kind)
+
+	if filter, ok := c.state.(server.MessageKindFilter); ok && !filter.AcceptsKind(envelope.Kind) {
+		c.hub.Protocol.Received(envelope.Kind, len(data), envelope.SenderId, nil)
+		return
+	}
+
+	packet, err := envelope.Decode()
+	if err != nil {
+		c.logger.Errorf("error unmarshalling data: %v", err)
+		c.malformedPacket()
+		return
+	}
+	c.hub.Protocol.Received(envelope.Kind, len(data), packet.SenderId, packet.Msg)
+	c.logger.Sampledf("Received %T (%d bytes)", packet.Msg, len(data))
+
+	// To allow the client to lazily not send the sender ID, we'll assume they want to send it as themselves
+	if packet.SenderId == 0 {
+		packet.SenderId = c.id
+	}
+
+	c.ProcessMessage(packet.SenderId, packet.Msg)
+}
+
+// Count a packet that couldn't be parsed, kicking the client once it's sent too many
+func (c *baseClient) malformedPacket() {
+	c.malformed++
+	if c.malformed == maxMalformedPackets {
+		c.logger.Printf("Sent %d packets that couldn't be parsed, disconnecting", c.malformed)
+		protocolViolationsTotal.With("malformed_packets").Inc()
+		go c.Kick(server.KickProtocolError, "Too many packets the server couldn't understand")
+	}
+}
+
+// Whether the packet's held back by the account's bandwidth throttle, in which case it's recorded as a dead letter.
+// Accounts over their daily bandwidth only get what they can't do without once they've used up the throttle rate.
+func (c *baseClient) throttle(packet outgoingPacket, size int) bool {
+	if c.bandwidth.Throttled() && !criticalKinds.Has(packets.KindOf(packet.message.Msg)) && !c.bandwidth.AllowSend(size) {
+		c.deadLetters.record("throttled", packet)
+		return true
+	}
+	return false
+}
+
+// Count the packet as written, and queue critical packets dropped earlier now there's room
+func (c *baseClient) wrote(packet outgoingPacket, size int) {
+	c.bandwidth.Sent(size)
+	c.hub.Protocol.Sent(packets.KindOf(packet.message.Msg), size, packet.senderId, packet.message.Msg)
+	if packet.written != nil {
+		close(packet.written)
+	}
+	c.retryHeldPackets()
+}
+
+func (c *baseClient) encode(packet outgoingPacket) ([]byte, error) {
+	return packet.message.AppendPacket(c.writeBuf[:0], packet.senderId)
+}
+
+// Queue critical packets that were dropped earlier, now that there's room for at least one
+func (c *baseClient) retryHeldPackets() {
+	for _, packet := range c.deadLetters.takeHeld(cap(c.sendChan) - len(c.sendChan)) {
+		select {
+		case c.sendChan <- packet:
+			deadLetterRetriesTotal.Inc()
+		default:
+			c.deadLetters.holdAgain(packet)
+		}
+	}
+}
+
+func (c *baseClient) DbTx() *server.DbTx {
+	return c.dbTx
+}
+
+func (c *baseClient) SharedGameObjects() *server.SharedGameObjects {
+	return c.hub.SharedGameObjects
+}
+
+func (c *baseClient) ServerTime() time.Time {
+	return c.hub.GetServerTime()
+}
+
+func (c *baseClient) ClockSync() *server.ClockSync {
+	return c.clockSync
+}
+
+func (c *baseClient) Locale() *server.Locale {
+	return c.locale
+}
+
+func (c *baseClient) Budgets() *server.Budgets {
+	return c.hub.Budgets
+}
+
+func (c *baseClient) Simulation() *server.Simulation {
+	return c.hub.Simulation
+}
+
+func (c *baseClient) Prompts() *server.Prompts {
+	return c.prompts
+}
+
+func (c *baseClient) Bandwidth() *server.BandwidthSession {
+	return c.bandwidth
+}
+
+func (c *baseClient) GameData() *gamedata.GameData {
+	return c.hub.GameData
+}
+
+func (c *baseClient) Presence() *server.Presence {
+	return c.hub.Presence
+}
+
+func (c *baseClient) Subscriptions() *server.Subscriptions {
+	return c.hub.Subscriptions
+}
+
+func (c *baseClient) PriorityLogin() *server.PriorityLogin {
+	return c.hub.PriorityLogin
+}
+
+func (c *baseClient) Announcements() *server.Announcements {
+	return c.hub.Announcements
+}
+
+func (c *baseClient) ChatReactions() *server.ChatReactions {
+	return c.hub.ChatReactions
+}
+
+func (c *baseClient) ModerationCases() *server.ModerationCases {
+	return c.hub.ModerationCases
+}
+
+func (c *baseClient) ChatHistory() *server.ChatHistory {
+	return c.hub.ChatHistory
+}
+
+func (c *baseClient) CurrencyCaps() *server.CurrencyCaps {
+	return c.hub.CurrencyCaps
+}
+
+func (c *baseClient) Interest() *server.Interest {
+	return c.hub.Interest
+}
+
+func (c *baseClient) Inventories() *server.Inventories {
+	return c.hub.Inventories
+}
+
+func (c *baseClient) DebugOverlays() *server.DebugOverlays {
+	return c.hub.DebugOverlays
+}
+
+func (c *baseClient) BossLockouts() *server.BossLockouts {
+	return c.hub.BossLockouts
+}
+
+func (c *baseClient) Minimaps() *server.Minimaps {
+	return c.hub.Minimaps
+}
+
+func (c *baseClient) Maintenance() *server.Maintenance {
+	return c.hub.Maintenance
+}
+
+func (c *baseClient) AuctionHouse() *server.AuctionHouse {
+	return c.hub.AuctionHouse
+}
+
+func (c *baseClient) Watchdog() *server.Watchdog {
+	return c.hub.Watchdog
+}
+
+func (c *baseClient) TickBudget() *server.TickBudget {
+	return c.hub.TickBudget
+}
+
+func (c *baseClient) CustomHandler(customType string) (server.CustomHandler, bool) {
+	return c.hub.CustomHandler(customType)
+}
+
+func (c *baseClient) DialogHook(name string) (server.DialogHook, bool) {
+	return c.hub.DialogHook(name)
+}
+
+func (c *baseClient) Impersonations() *server.Impersonations {
+	return c.hub.Impersonations
+}
+
+func (c *baseClient) Simulators() *server.Simulators {
+	return c.hub.Simulators
+}
+
+func (c *baseClient) Names() *server.Names {
+	return c.hub.Names
+}
+
+func (c *baseClient) Channels() *server.Channels {
+	return c.hub.Channels
+}
+
+func (c *baseClient) Visibility() *server.Visibility {
+	return c.hub.Visibility
+}
+
+func (c *baseClient) Saves() *server.Saves {
+	return c.hub.Saves
+}
+
+func (c *baseClient) FeatureFlags() *server.FeatureFlags {
+	return c.hub.FeatureFlags
+}
+
+func (c *baseClient) Voice() *server.Voice {
+	return c.hub.Voice
+}
+
+func (c *baseClient) AccountData() *server.AccountData {
+	return c.hub.AccountData
+}
+
+func (c *baseClient) Matchmaking() *server.Matchmaking {
+	return c.hub.Matchmaking
+}
+
+func (c *baseClient) FileTransfers() *server.FileTransfers {
+	return c.hub.FileTransfers
+}
+
+func (c *baseClient) Store() *server.Store {
+	return c.hub.Store
+}
+
+func (c *baseClient) Entitlements() *server.Entitlements {
+	return c.hub.Entitlements
+}
+
+func (c *baseClient) DamageAttribution() *server.DamageAttribution {
+	return c.hub.DamageAttribution
+}
+
+func (c *baseClient) Sessions() *server.Sessions {
+	return c.hub.Sessions
+}
+
+func (c *baseClient) Sandbox() *server.Sandbox {
+	return c.hub.Sandbox
+}
+
+func (c *baseClient) WorldStats() *server.WorldStats {
+	return c.hub.WorldStats
+}
+
+func (c *baseClient) Captcha() *server.Captcha {
+	return c.hub.Captcha
+}
+
+func (c *baseClient) LoginTokens() *server.LoginTokens {
+	return c.hub.LoginTokens
+}
+
+// Websockets may be behind TLS, which the server can't always tell, e.g. if it's done by a reverse proxy
+func (c *baseClient) Plaintext() bool {
+	return false
+}
+
+// The IP the address is of, without its port
+func remoteIp(addr net.Addr) string {
+	if ip, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return ip
+	}
+	return addr.String()
+}
+
+func (c *baseClient) Device() string {
+	return c.userAgent
+}
+
+func (c *baseClient) ClientVersions() *server.ClientVersions {
+	return c.hub.ClientVersions
+}
+
+func (c *baseClient) Close(reason string) {
+	c.closeOnce.Do(func() { c.close(reason) })
+}
+
+func (c *baseClient) Kick(code string, reason string) {
+	c.closeOnce.Do(func() {
+		c.sendKick(c.hub.Kicks.Hint(code, reason))
+		c.close(reason)
+	})
+}
+
+// Send the kick after everything already queued, e.g. a deny response saying why, and tell the client how it's being
+// closed if its transport can. Gives up waiting after a while, since the client's going either way.
+func (c *baseClient) sendKick(kick *packets.KickMessage) {
+	written := make(chan struct{})
+	select {
+	case c.sendChan <- outgoingPacket{message: c.hub.SharedMsg(packets.NewKick(kick)), written: written}:
+	default:
+		c.logger.Printf("Send channel full, kicking without saying why")
+	}
+	select {
+	case <-written:
+	case <-c.closed:
+		return
+	case <-time.After(kickWriteTimeout):
+	}
+
+	if closer, ok := c.transport.(kickCloser); ok {
+		closer.closeForKick(kick)
+	}
+}
+
+func (c *baseClient) Closed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close the client once one of its pumps stops, or in a deterministic simulation on the next tick
+func (c *baseClient) closeFromPump(reason string) {
+	if c.hub.Simulation.Deterministic() {
+		c.hub.Simulation.Disconnect(c.transport, reason)
+		return
+	}
+	c.Close(reason)
+}
+
+func (c *baseClient) close(reason string) {
+	c.logger.Printf("Closing client connection because: %s", reason)
+
+	// Closing is all the shadow needs to see, not everything closing does
+	if shadow := c.shadow.Swap(nil); shadow != nil {
+		shadow.Record("close", 0, nil, reason)
+	}
+	c.Broadcast(packets.NewDisconnect(reason))
+
+	c.SetState(nil)
+	c.prompts.CloseAll()
+	c.locale.Forget()
+	c.bandwidth.Close()
+
+	c.hub.Unregister(c.transport)
+	c.transport.closeTransport(reason)
+	close(c.closed)
+}
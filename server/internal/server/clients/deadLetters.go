@@ -0,0 +1,106 @@
+package clients
+
+import (
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	// How many critical packets are held for retry per client before the oldest are given up on
+	maxHeldCriticalPackets = 64
+
+	// A client failing to receive this many packets within the window is flagged as chronically failing
+	chronicDeadLetterThreshold = 256
+	chronicDeadLetterWindow    = time.Minute
+)
+
+var (
+	deadLettersTotal         = metrics.NewCounterVec("mmo_dead_letters_total", "Packets that could not be delivered to a client.", "reason")
+	deadLetterRetriesTotal   = metrics.NewCounter("mmo_dead_letter_retries_total", "Critical packets queued again after initially being dropped.")
+	chronicDeadLetterClients = metrics.NewCounter("mmo_chronic_dead_letter_clients_total", "Times a client was flagged for repeatedly failing to receive packets.")
+)
+
+// Packets the client can't do without, because they carry state the server won't send again by itself. If one of
+// these can't be queued it's held on to and retried as soon as the send queue has room again.
+var criticalKinds = packets.NewKindSet(
+	&packets.Packet_Id{},
+	&packets.Packet_OkResponse{},
+	&packets.Packet_DenyResponse{},
+	&packets.Packet_Inventory{},
+	&packets.Packet_BlockList{},
+	&packets.Packet_Presence{},
+	&packets.Packet_ResourceNode{},
+	&packets.Packet_Kick{},
+)
+
+// Keeps track of the packets a client failed to receive
+type deadLetters struct {
+	logger *logging.Logger
+
+	total       uint64
+	windowStart time.Time
+	windowCount int
+	held        []outgoingPacket
+	mux         sync.Mutex
+}
+
+// Record a packet that couldn't be delivered. Returns whether it was held for retry.
+func (d *deadLetters) record(reason string, packet outgoingPacket) bool {
+	deadLettersTotal.With(reason).Inc()
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.total++
+
+	now := time.Now()
+	if now.Sub(d.windowStart) > chronicDeadLetterWindow {
+		d.windowStart = now
+		d.windowCount = 0
+	}
+	d.windowCount++
+	if d.windowCount == chronicDeadLetterThreshold {
+		chronicDeadLetterClients.Inc()
+		d.logger.Printf("Failed to deliver %d packets within %v (%d total), client can't keep up", d.windowCount, chronicDeadLetterWindow, d.total)
+	}
+
+	if !criticalKinds.Has(packets.KindOf(packet.message.Msg)) {
+		return false
+	}
+
+	d.hold(packet)
+	return true
+}
+
+// Put a held packet back after failing to retry it, without counting it as another dead letter
+func (d *deadLetters) holdAgain(packet outgoingPacket) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.hold(packet)
+}
+
+// Must be called with the lock held
+func (d *deadLetters) hold(packet outgoingPacket) {
+	if len(d.held) >= maxHeldCriticalPackets {
+		d.held = d.held[1:]
+	}
+	d.held = append(d.held, packet)
+}
+
+// Take up to the given number of held packets, oldest first
+func (d *deadLetters) takeHeld(limit int) []outgoingPacket {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	n := min(limit, len(d.held))
+	if n <= 0 {
+		return nil
+	}
+
+	taken := d.held[:n:n]
+	d.held = d.held[n:]
+	return taken
+}
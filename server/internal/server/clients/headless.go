@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"server/internal/server"
+	"server/internal/server/diagnostics"
+)
+
+// A client with no connection, which everything sent to is thrown away, e.g. to stand in for a client in a recording
+// being replayed. Messages are handed to it with ProcessSocketData, as they would be read from a socket.
+type headlessClient struct {
+	baseClient
+}
+
+func NewHeadlessClient(hub *server.Hub) server.ClientInterfacer {
+	c := &headlessClient{}
+	c.init(hub, DefaultWebSocketLimits, c)
+	diagnostics.GoFor(c, "write pump", c.WritePump)
+	return c
+}
+
+// Nothing's read, since there's nothing to read from
+func (c *headlessClient) ReadPump() {}
+
+// Throw away whatever's sent until the client's closed
+func (c *headlessClient) WritePump() {
+	for {
+		select {
+		case <-c.sendChan:
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *headlessClient) RemoteIp() string {
+	return ""
+}
+
+func (c *headlessClient) closeTransport(string) {}
@@ -0,0 +1,513 @@
+package clients
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"net"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+)
+
+// What each datagram is, by its first byte. Packets go in reliable and unreliable datagrams, the same protobuf as over
+// a websocket, after a sequence number. Reliable ones are acknowledged, sent again until they are, and handled in the
+// order they were sent. Unreliable ones are never sent again, and any older than one already handled are dropped.
+// Only updates about players the client knows of are sent unreliably, see unreliablePlayers.
+//
+// A client says hello with at least udpHelloSize bytes and is answered with a cookie, which it says hello with again
+// to be welcomed and connected. Either side can disconnect at any time, with the reason after the first byte, and
+// either side that's sent nothing else for a while pings, since a client that hears nothing for its read timeout is
+// disconnected.
+const (
+	udpHello byte = iota + 1
+	udpWelcome
+	udpReliable
+	udpUnreliable
+	udpAck
+	udpPing
+	udpDisconnect
+)
+
+const (
+	// A hello without a cookie must be at least this big, padded out with anything, so it's never answered with more
+	// than was sent, and spoofing hellos can't be used to flood anyone with cookies
+	udpHelloSize = 64
+
+	udpCookieSize = 16
+
+	// Cookies are good for the window they're handed out in and the one after
+	udpCookieWindow = 30 * time.Second
+
+	// The type of datagram and its sequence number
+	udpHeaderSize = 5
+
+	// The biggest datagram UDP over IPv4 can carry. Anything much bigger than the path's MTU is fragmented by IP, and
+	// lost if any fragment is, so reliable packets that big may take a few tries.
+	maxUdpDatagram = 65507
+
+	// How many reliable packets can be waiting to be acknowledged before no more are sent, and how many received out
+	// of order are held for those before them
+	udpWindow = 256
+
+	// How long a reliable packet goes unacknowledged before it's sent again
+	udpResendInterval = 200 * time.Millisecond
+
+	// How many datagrams can be waiting for a client's read pump before more are dropped. Reliable ones are sent again.
+	udpInboundQueue = 128
+)
+
+var (
+	udpDatagramsDroppedTotal = metrics.NewCounter("mmo_udp_datagrams_dropped_total", "Datagrams from UDP clients dropped for arriving faster than they could be handled.")
+	udpResendsTotal          = metrics.NewCounter("mmo_udp_resends_total", "Reliable packets sent to UDP clients again for going unacknowledged.")
+)
+
+// Where native clients connect over UDP, for lower latency than a websocket's. Game exports that can't use UDP, e.g.
+// in browsers, keep connecting over websockets, and clients over either are handled the same once they're connected.
+type UdpListener struct {
+	hub    *server.Hub
+	conn   net.PacketConn
+	limits WebSocketLimits
+
+	// Cookies are signed with this
+	secret []byte
+
+	// By the address they're from
+	sessions map[string]*udpSession
+	mux      sync.Mutex
+}
+
+// Listen for clients on the UDP address, e.g. ":8081". Call Serve to start connecting them.
+func ListenUdp(hub *server.Hub, addr string, limits WebSocketLimits) (*UdpListener, error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &UdpListener{
+		hub:      hub,
+		conn:     conn,
+		limits:   limits,
+		secret:   secret,
+		sessions: make(map[string]*udpSession),
+	}, nil
+}
+
+func (l *UdpListener) Addr() net.Addr {
+	return l.conn.LocalAddr()
+}
+
+// Read datagrams until the listener's closed, handing each to the client it's from, or connecting whoever it's from if
+// they're saying hello
+func (l *UdpListener) Serve() error {
+	buf := make([]byte, maxUdpDatagram)
+	for {
+		n, addr, err := l.conn.ReadFrom(buf)
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+
+		l.mux.Lock()
+		session, exists := l.sessions[addr.String()]
+		l.mux.Unlock()
+		if !exists {
+			if buf[0] == udpHello {
+				l.hello(addr, buf[1:n])
+			}
+			continue
+		}
+
+		// Copied, since the buffer's read into again straight away
+		select {
+		case session.inbound <- append([]byte(nil), buf[:n]...):
+		default:
+			udpDatagramsDroppedTotal.Inc()
+		}
+	}
+}
+
+// Stop listening, and close every client connected over UDP
+func (l *UdpListener) Close() error {
+	err := l.conn.Close()
+	l.mux.Lock()
+	sessions := make([]*udpSession, 0, len(l.sessions))
+	for _, session := range l.sessions {
+		sessions = append(sessions, session)
+	}
+	l.mux.Unlock()
+	for _, session := range sessions {
+		session.client.Close("server stopped listening for UDP")
+	}
+	return err
+}
+
+// Answer a hello with a cookie, or connect whoever it's from if it has a valid one
+func (l *UdpListener) hello(addr net.Addr, data []byte) {
+	now := time.Now()
+	if len(data) == udpCookieSize && (hmac.Equal(data, l.cookie(addr, now)) || hmac.Equal(data, l.cookie(addr, now.Add(-udpCookieWindow)))) {
+		l.connect(addr)
+		return
+	}
+	if len(data)+1 < udpHelloSize {
+		return
+	}
+	l.conn.WriteTo(append([]byte{udpHello}, l.cookie(addr, now)...), addr)
+}
+
+// What proves a hello's really from the address, since anyone can send from any address but only those there get the
+// cookie
+func (l *UdpListener) cookie(addr net.Addr, at time.Time) []byte {
+	mac := hmac.New(sha256.New, l.secret)
+	mac.Write([]byte(addr.String()))
+	mac.Write(binary.BigEndian.AppendUint64(nil, uint64(at.Unix()/int64(udpCookieWindow.Seconds()))))
+	return mac.Sum(nil)[:udpCookieSize]
+}
+
+func (l *UdpListener) connect(addr net.Addr) {
+	session := &udpSession{
+		listener: l,
+		addr:     addr,
+		inbound:  make(chan []byte, udpInboundQueue),
+		room:     make(chan struct{}, 1),
+		held:     make(map[uint32][]byte),
+	}
+	l.mux.Lock()
+	l.sessions[addr.String()] = session
+	l.mux.Unlock()
+
+	client := &UdpClient{session: session}
+	client.init(l.hub, l.limits, client)
+	session.client = client
+	logging.Clients.Printf("New UDP client connected from %s", addr)
+	session.write(udpWelcome, nil)
+	l.hub.Connect(client)
+}
+
+// A reliable packet waiting to be acknowledged
+type udpPending struct {
+	seq      uint32
+	datagram []byte
+	sentAt   time.Time
+
+	// When it was first sent, to give up on the client if it goes unacknowledged for too long
+	firstSentAt time.Time
+}
+
+// One client's side of the protocol
+type udpSession struct {
+	listener *UdpListener
+	addr     net.Addr
+	client   *UdpClient
+
+	// Datagrams from the client, waiting for its read pump
+	inbound chan []byte
+
+	// The next sequence numbers to send with, only used by the write pump
+	nextReliable   uint32
+	nextUnreliable uint32
+
+	// Reliable packets sent and waiting to be acknowledged, oldest first, with room signalled each time some are
+	pending    []udpPending
+	pendingMux sync.Mutex
+	room       chan struct{}
+
+	// The next reliable sequence number expected, those received ahead of it, and the last unreliable one handled,
+	// only used by the read pump
+	expected       uint32
+	held           map[uint32][]byte
+	lastUnreliable uint32
+	anyUnreliable  bool
+
+	closeOnce sync.Once
+}
+
+// Whether sequence number a comes after b, allowing for them wrapping around
+func seqAfter(a, b uint32) bool {
+	return int32(a-b) > 0
+}
+
+func (s *udpSession) write(kind byte, payload []byte) error {
+	_, err := s.listener.conn.WriteTo(append([]byte{kind}, payload...), s.addr)
+	return err
+}
+
+// Send the encoded packet, returning how big the datagram was
+func (s *udpSession) send(data []byte, reliable bool) (int, error) {
+	datagram := make([]byte, udpHeaderSize, udpHeaderSize+len(data))
+	if !reliable {
+		datagram[0] = udpUnreliable
+		binary.BigEndian.PutUint32(datagram[1:], s.nextUnreliable)
+		s.nextUnreliable++
+		datagram = append(datagram, data...)
+		_, err := s.listener.conn.WriteTo(datagram, s.addr)
+		return len(datagram), err
+	}
+
+	datagram[0] = udpReliable
+	binary.BigEndian.PutUint32(datagram[1:], s.nextReliable)
+	datagram = append(datagram, data...)
+	now := time.Now()
+	s.pendingMux.Lock()
+	s.pending = append(s.pending, udpPending{seq: s.nextReliable, datagram: datagram, sentAt: now, firstSentAt: now})
+	s.pendingMux.Unlock()
+	s.nextReliable++
+	_, err := s.listener.conn.WriteTo(datagram, s.addr)
+	return len(datagram), err
+}
+
+// Whether another reliable packet can be sent before more are acknowledged
+func (s *udpSession) hasRoom() bool {
+	s.pendingMux.Lock()
+	defer s.pendingMux.Unlock()
+	return len(s.pending) < udpWindow
+}
+
+// Send again whatever's gone unacknowledged for long enough. Returns an error if anything's gone unacknowledged for
+// longer than the timeout, since the client can't be hearing anything.
+func (s *udpSession) resend(timeout time.Duration) error {
+	now := time.Now()
+	s.pendingMux.Lock()
+	defer s.pendingMux.Unlock()
+	for i := range s.pending {
+		pending := &s.pending[i]
+		if now.Sub(pending.firstSentAt) > timeout {
+			return errors.New("reliable packets went unacknowledged")
+		}
+		if now.Sub(pending.sentAt) < udpResendInterval {
+			continue
+		}
+		if _, err := s.listener.conn.WriteTo(pending.datagram, s.addr); err != nil {
+			return err
+		}
+		pending.sentAt = now
+		udpResendsTotal.Inc()
+	}
+	return nil
+}
+
+// Forget the reliable packets the client's acknowledged, which is everything before the one it's expecting
+func (s *udpSession) acknowledge(expected uint32) {
+	s.pendingMux.Lock()
+	acked := 0
+	for acked < len(s.pending) && seqAfter(expected, s.pending[acked].seq) {
+		acked++
+	}
+	s.pending = s.pending[acked:]
+	s.pendingMux.Unlock()
+	if acked > 0 {
+		select {
+		case s.room <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Take in a datagram from the client, acknowledging it if it's reliable. Returns the packets it lets be handled, in
+// order, and whether the client's disconnected.
+func (s *udpSession) receive(datagram []byte) ([][]byte, bool, error) {
+	kind := datagram[0]
+	switch kind {
+	case udpPing:
+		return nil, false, nil
+	case udpDisconnect:
+		return nil, true, nil
+	case udpHello:
+		// The welcome was lost, so the client's still saying hello
+		return nil, false, s.write(udpWelcome, nil)
+	case udpAck:
+		if len(datagram) != udpHeaderSize {
+			return nil, false, errors.New("malformed ack")
+		}
+		s.acknowledge(binary.BigEndian.Uint32(datagram[1:]))
+		return nil, false, nil
+	case udpReliable, udpUnreliable:
+	default:
+		return nil, false, errors.New("unknown datagram")
+	}
+
+	if len(datagram) <= udpHeaderSize {
+		return nil, false, errors.New("datagram too short")
+	}
+	seq := binary.BigEndian.Uint32(datagram[1:])
+	data := datagram[udpHeaderSize:]
+
+	if kind == udpUnreliable {
+		if s.anyUnreliable && !seqAfter(seq, s.lastUnreliable) {
+			return nil, false, nil
+		}
+		s.lastUnreliable, s.anyUnreliable = seq, true
+		return [][]byte{data}, false, nil
+	}
+
+	// Acknowledged whether or not it's new, since a duplicate means the last acknowledgement was lost
+	var ready [][]byte
+	if seq == s.expected {
+		ready = append(ready, data)
+		s.expected++
+		for {
+			next, exists := s.held[s.expected]
+			if !exists {
+				break
+			}
+			delete(s.held, s.expected)
+			ready = append(ready, next)
+			s.expected++
+		}
+	} else if seqAfter(seq, s.expected) && seq-s.expected < udpWindow {
+		s.held[seq] = data
+	}
+	return ready, false, s.write(udpAck, binary.BigEndian.AppendUint32(nil, s.expected))
+}
+
+// Tell the client why it's being disconnected, and forget it
+func (s *udpSession) close(reason string) {
+	s.closeOnce.Do(func() {
+		s.write(udpDisconnect, []byte(reason))
+		s.listener.mux.Lock()
+		if s.listener.sessions[s.addr.String()] == s {
+			delete(s.listener.sessions, s.addr.String())
+		}
+		s.listener.mux.Unlock()
+	})
+}
+
+// A client connected over UDP
+type UdpClient struct {
+	baseClient
+	session *udpSession
+
+	// Only used by the write pump
+	players unreliablePlayers
+}
+
+func (c *UdpClient) ReadPump() {
+	defer func() {
+		c.logger.Println("Closing read pump")
+		c.closeFromPump("read pump closed")
+	}()
+
+	// Every datagram buys the client more time, pings included
+	timeout := time.NewTimer(c.limits.ReadTimeout)
+	defer timeout.Stop()
+
+	for {
+		var datagram []byte
+		select {
+		case datagram = <-c.session.inbound:
+		case <-timeout.C:
+			c.logger.Printf("Nothing received for %s, disconnecting", c.limits.ReadTimeout)
+			protocolViolationsTotal.With("read_timeout").Inc()
+			c.sendKick(c.hub.Kicks.Hint(server.KickTimeout, "Nothing was received from your game for too long"))
+			return
+		case <-c.closed:
+			return
+		}
+		timeout.Reset(c.limits.ReadTimeout)
+		c.bandwidth.Received(len(datagram))
+
+		if int64(len(datagram)) > c.limits.MaxMessageSize {
+			c.logger.Errorf("Datagram of %d bytes exceeded the limit of %d", len(datagram), c.limits.MaxMessageSize)
+			c.malformedPacket()
+			continue
+		}
+		ready, disconnected, err := c.session.receive(datagram)
+		if disconnected {
+			c.logger.Println("Disconnected over UDP")
+			return
+		}
+		if err != nil {
+			c.logger.Errorf("error receiving datagram: %v", err)
+			c.malformedPacket()
+		}
+		for _, data := range ready {
+			c.handleSocketData(data)
+		}
+	}
+}
+
+func (c *UdpClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing write pump")
+		c.closeFromPump("write pump closed")
+	}()
+
+	// Often enough that the client always hears from the server before its own read timeout
+	pingTicker := time.NewTicker(c.limits.ReadTimeout / 3)
+	defer pingTicker.Stop()
+	resendTicker := time.NewTicker(udpResendInterval)
+	defer resendTicker.Stop()
+
+	for {
+		// Nothing more's taken from the queue while the client's behind on acknowledging what it's been sent
+		sendChan := c.sendChan
+		if !c.session.hasRoom() {
+			sendChan = nil
+		}
+
+		var packet outgoingPacket
+		select {
+		case <-pingTicker.C:
+			if err := c.session.write(udpPing, nil); err != nil {
+				c.logger.Errorf("error sending ping, closing client: %v", err)
+				return
+			}
+			continue
+		case <-resendTicker.C:
+			if err := c.session.resend(c.limits.ReadTimeout); err != nil {
+				c.logger.Errorf("error resending packets, closing client: %v", err)
+				return
+			}
+			continue
+		case <-c.session.room:
+			continue
+		case <-c.closed:
+			return
+		case packet = <-sendChan:
+		}
+
+		data, err := c.encode(packet)
+		if err != nil {
+			c.logger.Errorf("error marshalling %T packet: %v", packet.message.Msg, err)
+			c.deadLetters.record("marshal_error", packet)
+			continue
+		}
+		c.writeBuf = data
+		if c.throttle(packet, len(data)+udpHeaderSize) {
+			continue
+		}
+
+		size, err := c.session.send(data, c.players.reliable(packet))
+		if err != nil {
+			c.logger.Errorf("error writing %T packet, closing client: %v", packet.message.Msg, err)
+			c.deadLetters.record("write_error", packet)
+			return
+		}
+		c.wrote(packet, size)
+	}
+}
+
+// There's no encryption over UDP, so players log in with a token from a secure connection instead of their password
+func (c *UdpClient) Plaintext() bool {
+	return true
+}
+
+func (c *UdpClient) RemoteIp() string {
+	return remoteIp(c.session.addr)
+}
+
+func (c *UdpClient) closeTransport(reason string) {
+	c.session.close(reason)
+}
@@ -1,192 +1,261 @@
 package clients
 
 import (
-	"fmt"
-	"log"
+	"errors"
+	"net"
 	"net/http"
+	"net/url"
 	"server/internal/server"
-	"server/internal/server/states"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
 	"server/pkg/packets"
+	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
-	"google.golang.org/protobuf/proto"
 )
 
-type WebSocketClient struct {
-	id       uint64
-	conn     *websocket.Conn
-	hub      *server.Hub
-	sendChan chan *packets.Packet
-	state    server.ClientStateHandler
-	logger   *log.Logger
-	dbTx     *server.DbTx
+// Limits on what a client can send, to protect the server from hostile or broken clients
+type WebSocketLimits struct {
+	// The largest message a client can send, in bytes. Clients sending anything bigger are disconnected.
+	MaxMessageSize int64
+
+	// How long a client can go without sending a complete message or answering a ping before it's disconnected. This
+	// catches clients trickling messages in slowly to tie up the server, as well as dead connections.
+	ReadTimeout time.Duration
 }
 
-func NewWebSocketClient(hub *server.Hub, writer http.ResponseWriter, request *http.Request) (server.ClientInterfacer, error) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin:     func(_ *http.Request) bool { return true },
-	}
+var DefaultWebSocketLimits = WebSocketLimits{
+	MaxMessageSize: 64 * 1024,
+	ReadTimeout:    60 * time.Second,
+}
 
-	conn, err := upgrader.Upgrade(writer, request, nil)
+// Where the debug transport is served, when it is
+const DebugWebSocketPath = "/ws-debug"
 
-	if err != nil {
-		return nil, err
-	}
+var originsRefusedTotal = metrics.NewCounter("mmo_websocket_origins_refused_total", "Websocket connections refused for coming from a page on another site.")
 
-	c := &WebSocketClient{
-		hub:      hub,
-		conn:     conn,
-		sendChan: make(chan *packets.Packet, 256),
-		logger:   log.New(log.Writer(), "Client unknown: ", log.LstdFlags),
-		dbTx:     hub.NewDbTx(),
-	}
+type WebSocketClient struct {
+	baseClient
+	conn *websocket.Conn
 
-	return c, nil
+	// Whether packets are read and written as protobuf's JSON mapping in text messages, for the debug transport
+	json bool
 }
 
-func (c *WebSocketClient) Id() uint64 {
-	return c.id
+func NewWebSocketClient(hub *server.Hub, writer http.ResponseWriter, request *http.Request) (server.ClientInterfacer, error) {
+	return newWebSocketClient(hub, writer, request, DefaultWebSocketLimits, anyOrigin, false)
 }
 
-func (c *WebSocketClient) SetState(state server.ClientStateHandler) {
-	prevStateName := "None"
-	if c.state != nil {
-		prevStateName = c.state.Name()
-		c.state.OnExit()
+// Make a function for creating clients with the given limits, to pass to the hub's Serve function
+func WebSocketClientWithLimits(limits WebSocketLimits) func(*server.Hub, http.ResponseWriter, *http.Request) (server.ClientInterfacer, error) {
+	return func(hub *server.Hub, writer http.ResponseWriter, request *http.Request) (server.ClientInterfacer, error) {
+		return newWebSocketClient(hub, writer, request, limits, anyOrigin, false)
 	}
+}
 
-	newStateName := "None"
-	if state != nil {
-		newStateName = state.Name()
+// Like WebSocketClientWithLimits, but browsers can only connect from pages served by the server itself or from one of
+// the allowed origins, e.g. "https://play.example.com", so other sites can't connect as players visiting them. Native
+// clients don't send an origin, so can always connect.
+func WebSocketClientWithOrigins(limits WebSocketLimits, allowedOrigins []string) func(*server.Hub, http.ResponseWriter, *http.Request) (server.ClientInterfacer, error) {
+	checkOrigin := checkOrigins(allowedOrigins)
+	return func(hub *server.Hub, writer http.ResponseWriter, request *http.Request) (server.ClientInterfacer, error) {
+		return newWebSocketClient(hub, writer, request, limits, checkOrigin, false)
 	}
+}
 
-	c.logger.Printf("Switching from state %s to %s", prevStateName, newStateName)
-
-	c.state = state
-
-	if c.state != nil {
-		c.state.SetClient(c)
-		c.state.OnEnter()
+// Make a function for creating clients that speak the same protocol but as JSON, in text messages, with fields named
+// as they are in packets.proto, so web tools, websocat and quick prototypes can play without protobuf. Every packet
+// takes a lot more to send and decode this way, so it's only for development.
+func DebugWebSocketClient(limits WebSocketLimits) func(*server.Hub, http.ResponseWriter, *http.Request) (server.ClientInterfacer, error) {
+	return func(hub *server.Hub, writer http.ResponseWriter, request *http.Request) (server.ClientInterfacer, error) {
+		return newWebSocketClient(hub, writer, request, limits, anyOrigin, true)
 	}
 }
 
-func (c *WebSocketClient) ProcessMessage(senderId uint64, message packets.Msg) {
-	c.state.HandleMessage(senderId, message)
+func anyOrigin(_ *http.Request) bool {
+	return true
 }
 
-func (c *WebSocketClient) Initialize(id uint64) {
-	c.id = id
-	c.logger.SetPrefix(fmt.Sprintf("Client %d: ", c.id))
-	c.SetState(&states.Connected{})
-}
+func checkOrigins(allowedOrigins []string) func(*http.Request) bool {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
 
-func (c *WebSocketClient) SocketSend(message packets.Msg) {
-	c.SocketSendAs(message, c.id)
+	return func(request *http.Request) bool {
+		origin := request.Header.Get("Origin")
+		if origin == "" || allowed[strings.ToLower(origin)] {
+			return true
+		}
+		originUrl, err := url.Parse(origin)
+		if err == nil && strings.EqualFold(originUrl.Host, request.Host) {
+			return true
+		}
+		logging.Clients.Printf("Refused websocket from origin %s", origin)
+		originsRefusedTotal.Inc()
+		return false
+	}
 }
 
-func (c *WebSocketClient) SocketSendAs(message packets.Msg, senderId uint64) {
-	select {
-	case c.sendChan <- &packets.Packet{SenderId: senderId, Msg: message}:
-	default:
-		c.logger.Printf("Send channel full, dropping message: %T", message)
+func newWebSocketClient(hub *server.Hub, writer http.ResponseWriter, request *http.Request, limits WebSocketLimits, checkOrigin func(*http.Request) bool, json bool) (server.ClientInterfacer, error) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin,
 	}
-}
 
-func (c *WebSocketClient) PassToPeer(message packets.Msg, peerId uint64) {
-	if peer, exists := c.hub.Clients.Get(peerId); exists {
-		peer.ProcessMessage(c.id, message)
+	conn, err := upgrader.Upgrade(writer, request, nil)
+
+	if err != nil {
+		return nil, err
 	}
-}
 
-func (c *WebSocketClient) Broadcast(message packets.Msg) {
-	c.hub.BroadcastChan <- &packets.Packet{SenderId: c.id, Msg: message}
+	conn.SetReadLimit(limits.MaxMessageSize)
+
+	client := &WebSocketClient{conn: conn, json: json}
+	client.init(hub, limits, client)
+	client.userAgent = request.UserAgent()
+	return client, nil
 }
 
 func (c *WebSocketClient) ReadPump() {
 	defer func() {
 		c.logger.Println("Closing read pump")
-		c.Close("read pump closed")
+		c.closeFromPump("read pump closed")
 	}()
 
+	// Every complete message and answered ping buys the client more time
+	c.conn.SetReadDeadline(time.Now().Add(c.limits.ReadTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.limits.ReadTimeout))
+	})
+
 	for {
 		_, data, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				c.logger.Printf("Error: %v", err)
-			}
+			c.handleReadError(err)
 			break
 		}
-
-		packet := &packets.Packet{}
-		err = proto.Unmarshal(data, packet)
-		if err != nil {
-			c.logger.Printf("error unmarshalling data: %v", err)
-			continue
+		c.conn.SetReadDeadline(time.Now().Add(c.limits.ReadTimeout))
+		c.bandwidth.Received(len(data))
+
+		// Handled from then on as if it had come in as protobuf, so the debug transport can't behave any differently
+		if c.json {
+			if data, err = packets.JsonToWire(data); err != nil {
+				c.logger.Errorf("error parsing JSON packet: %v", err)
+				c.SocketSend(packets.NewDenyResponse("Invalid JSON packet: " + err.Error()))
+				c.malformedPacket()
+				continue
+			}
 		}
 
-		// To allow the client to lazily not send the sender ID, we'll assume they want to send it as themselves
-		if packet.SenderId == 0 {
-			packet.SenderId = c.id
-		}
+		c.handleSocketData(data)
+	}
+}
 
-		c.ProcessMessage(packet.SenderId, packet.Msg)
+// Log why reading from the client failed, and if it was the client's fault tell it why it's being disconnected
+func (c *WebSocketClient) handleReadError(err error) {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, websocket.ErrReadLimit):
+		// The websocket library already sent the client a close message saying the message was too big, and nothing
+		// can be sent after it, so there's no kick
+		c.logger.Printf("Message exceeded the limit of %d bytes, disconnecting", c.limits.MaxMessageSize)
+		protocolViolationsTotal.With("message_too_big").Inc()
+	case errors.As(err, &netErr) && netErr.Timeout():
+		c.logger.Printf("Nothing received for %s, disconnecting", c.limits.ReadTimeout)
+		protocolViolationsTotal.With("read_timeout").Inc()
+		c.sendKick(c.hub.Kicks.Hint(server.KickTimeout, "Nothing was received from your game for too long"))
+	case websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure):
+		c.logger.Errorf("Error: %v", err)
 	}
 }
 
 func (c *WebSocketClient) WritePump() {
 	defer func() {
 		c.logger.Println("Closing write pump")
-		c.Close("write pump closed")
+		c.closeFromPump("write pump closed")
 	}()
 
-	for packet := range c.sendChan {
-		writer, err := c.conn.NextWriter(websocket.BinaryMessage)
-		if err != nil {
-			c.logger.Printf("error getting writer for %T packet, closing client: %v", packet.Msg, err)
+	// Ping often enough that well behaved clients always answer before their read deadline
+	pingTicker := time.NewTicker(c.limits.ReadTimeout * 9 / 10)
+	defer pingTicker.Stop()
+
+	for {
+		var packet outgoingPacket
+		select {
+		case <-pingTicker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				c.logger.Errorf("error sending ping, closing client: %v", err)
+				return
+			}
+			continue
+		case <-c.closed:
 			return
+		case packet = <-c.sendChan:
 		}
 
-		data, err := proto.Marshal(packet)
+		data, err := c.encode(packet)
 		if err != nil {
-			c.logger.Printf("error marshalling %T packet, closing client: %v", packet.Msg, err)
+			c.logger.Errorf("error marshalling %T packet: %v", packet.message.Msg, err)
+			c.deadLetters.record("marshal_error", packet)
 			continue
 		}
-
-		_, err = writer.Write(data)
-		if err != nil {
-			c.logger.Printf("error writing %T packet: %v", packet.Msg, err)
+		c.writeBuf = append(data, '\n')
+		if c.throttle(packet, len(c.writeBuf)) {
 			continue
 		}
 
-		writer.Write([]byte{'\n'})
-
-		if err = writer.Close(); err != nil {
-			c.logger.Printf("error closing writer for %T packet: %v", packet.Msg, err)
-			continue
+		// Once a write fails the connection is no good for any more, so the client is closed
+		if err := c.conn.WriteMessage(c.messageType(), c.writeBuf); err != nil {
+			c.logger.Errorf("error writing %T packet, closing client: %v", packet.message.Msg, err)
+			c.deadLetters.record("write_error", packet)
+			return
 		}
+		c.wrote(packet, len(c.writeBuf))
 	}
 }
 
-func (c *WebSocketClient) DbTx() *server.DbTx {
-	return c.dbTx
+func (c *WebSocketClient) encode(packet outgoingPacket) ([]byte, error) {
+	if c.json {
+		data, err := packets.MarshalJson(packet.senderId, packet.message.Msg)
+		return append(c.writeBuf[:0], data...), err
+	}
+	return c.baseClient.encode(packet)
 }
 
-func (c *WebSocketClient) SharedGameObjects() *server.SharedGameObjects {
-	return c.hub.SharedGameObjects
+func (c *WebSocketClient) messageType() int {
+	if c.json {
+		return websocket.TextMessage
+	}
+	return websocket.BinaryMessage
 }
 
-func (c *WebSocketClient) Close(reason string) {
-	c.logger.Printf("Closing client connection because: %s", reason)
+func (c *WebSocketClient) RemoteIp() string {
+	return remoteIp(c.conn.RemoteAddr())
+}
 
-	c.Broadcast(packets.NewDisconnect(reason))
+// The websocket close code for the kick, so clients that don't read the kick packet still know whether to come back
+func kickCloseCode(kick *packets.KickMessage) int {
+	switch {
+	case kick.ReasonCode == server.KickMaintenance:
+		return websocket.CloseServiceRestart
+	case kick.ReasonCode == server.KickTimeout || kick.ReasonCode == server.KickProtocolError:
+		return websocket.ClosePolicyViolation
+	case kick.Reconnect:
+		return websocket.CloseTryAgainLater
+	default:
+		return websocket.CloseNormalClosure
+	}
+}
 
-	c.SetState(nil)
+// Tell the client how it's being closed with a close code browsers understand too
+func (c *WebSocketClient) closeForKick(kick *packets.KickMessage) {
+	closeMessage := websocket.FormatCloseMessage(kickCloseCode(kick), kick.ReasonCode)
+	c.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(time.Second))
+}
 
-	c.hub.UnregisterChan <- c
+func (c *WebSocketClient) closeTransport(string) {
 	c.conn.Close()
-	if _, closed := <-c.sendChan; !closed {
-		close(c.sendChan)
-	}
 }
@@ -0,0 +1,372 @@
+package clients
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+)
+
+// Where browsers open WebTransport sessions, on the WebTransport port
+const WebTransportPath = "/wt"
+
+const (
+	// Before each packet on the stream, its length, and before each datagram, its sequence number, both big-endian
+	wtLengthSize = 4
+	wtSeqSize    = 4
+
+	// How long a client has to open its stream once its session's been accepted
+	wtStreamTimeout = 10 * time.Second
+
+	// How many packets can be waiting for a client's read pump. Reading the stream waits for room, and datagrams are
+	// dropped.
+	wtInboundQueue = 128
+
+	// How long a closed client has to read what it's been sent before its session's closed on it
+	wtCloseGrace = 2 * time.Second
+)
+
+var errWtMessageTooBig = errors.New("message too big")
+
+var wtDatagramsDroppedTotal = metrics.NewCounter("mmo_webtransport_datagrams_dropped_total", "Datagrams from WebTransport clients dropped for arriving faster than they could be handled.")
+
+// Where browsers connect over WebTransport, i.e. HTTP/3 over QUIC, with no head-of-line blocking between what's sent
+// unreliably and everything else. A client opens a session at WebTransportPath and then one bidirectional stream,
+// which packets go both ways on after their length, the same protobuf as over a websocket. Packets that would be sent
+// unreliably over UDP, see unreliablePlayers, are sent as datagrams instead, after a sequence number, and a client can
+// send them the same way; any older than one already handled are dropped. Clients that can't, e.g. browsers without WebTransport, keep
+// connecting over websockets, and clients over either are handled the same once they're connected.
+type WebTransportListener struct {
+	hub    *server.Hub
+	server *webtransport.Server
+	limits WebSocketLimits
+
+	certPath string
+	keyPath  string
+
+	sessions map[*wtSession]struct{}
+	mux      sync.Mutex
+}
+
+// Make a listener for the UDP address, e.g. ":8443", with the same certificate as the HTTPS server, since browsers only
+// use WebTransport over TLS. Browsers connecting from other pages are only let in as they are over websockets, if
+// checkOrigin is set. Call Serve to start listening.
+func ListenWebTransport(hub *server.Hub, addr string, certPath string, keyPath string, limits WebSocketLimits, checkOrigin bool, allowedOrigins []string) *WebTransportListener {
+	l := &WebTransportListener{
+		hub:      hub,
+		limits:   limits,
+		certPath: certPath,
+		keyPath:  keyPath,
+		sessions: make(map[*wtSession]struct{}),
+	}
+
+	origins := anyOrigin
+	if checkOrigin {
+		origins = checkOrigins(allowedOrigins)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(WebTransportPath, l)
+	l.server = &webtransport.Server{
+		H3: http3.Server{
+			Addr:    addr,
+			Handler: mux,
+
+			// QUIC keeps the connection alive and times it out itself, like websocket pings
+			QUICConfig: &quic.Config{
+				MaxIdleTimeout:  limits.ReadTimeout,
+				KeepAlivePeriod: limits.ReadTimeout / 3,
+			},
+		},
+		CheckOrigin: origins,
+	}
+	return l
+}
+
+// Listen until the listener's closed
+func (l *WebTransportListener) Serve() error {
+	err := l.server.ListenAndServeTLS(l.certPath, l.keyPath)
+	if errors.Is(err, http.ErrServerClosed) || errors.Is(err, quic.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Stop listening, and close every client connected over WebTransport
+func (l *WebTransportListener) Close() error {
+	l.mux.Lock()
+	sessions := make([]*wtSession, 0, len(l.sessions))
+	for session := range l.sessions {
+		sessions = append(sessions, session)
+	}
+	l.mux.Unlock()
+	for _, session := range sessions {
+		session.client.Close("server stopped listening for WebTransport")
+	}
+	return l.server.Close()
+}
+
+// Accept a session, and connect its client once it's opened its stream
+func (l *WebTransportListener) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	session, err := l.server.Upgrade(writer, request)
+	if err != nil {
+		logging.Clients.Printf("Refused WebTransport session from %s: %v", request.RemoteAddr, err)
+		http.Error(writer, "invalid WebTransport session", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(session.Context(), wtStreamTimeout)
+	defer cancel()
+	stream, err := session.AcceptStream(ctx)
+	if err != nil {
+		logging.Clients.Printf("WebTransport client at %s never opened a stream: %v", request.RemoteAddr, err)
+		session.CloseWithError(0, "no stream opened")
+		return
+	}
+
+	wt := &wtSession{
+		listener: l,
+		session:  session,
+		stream:   stream,
+		inbound:  make(chan []byte, wtInboundQueue),
+		readErr:  make(chan error, 1),
+		ended:    make(chan struct{}),
+	}
+	client := &WebTransportClient{session: wt}
+	client.init(l.hub, l.limits, client)
+	client.userAgent = request.UserAgent()
+	wt.client = client
+	l.mux.Lock()
+	l.sessions[wt] = struct{}{}
+	l.mux.Unlock()
+
+	logging.Clients.Printf("New WebTransport client connected from %s", session.RemoteAddr())
+	go wt.readStream(l.limits.MaxMessageSize)
+	go wt.readDatagrams()
+	l.hub.Connect(client)
+}
+
+// One client's session
+type wtSession struct {
+	listener *WebTransportListener
+	session  *webtransport.Session
+	stream   *webtransport.Stream
+	client   *WebTransportClient
+
+	// Packets from the stream and datagrams, waiting for the client's read pump, and why reading the stream stopped
+	inbound chan []byte
+	readErr chan error
+
+	// The next sequence number to send datagrams with, only used by the write pump, and the length and packet being
+	// written to the stream, reused for each one
+	nextDatagram uint32
+	frame        []byte
+
+	// Closed once the write pump's ended the stream
+	ended     chan struct{}
+	closeOnce sync.Once
+}
+
+// Read packets off the stream until it ends, or one's too big
+func (s *wtSession) readStream(maxSize int64) {
+	header := make([]byte, wtLengthSize)
+	for {
+		if _, err := io.ReadFull(s.stream, header); err != nil {
+			s.readErr <- err
+			return
+		}
+		size := binary.BigEndian.Uint32(header)
+		if int64(size) > maxSize {
+			s.readErr <- fmt.Errorf("%w: %d bytes", errWtMessageTooBig, size)
+			return
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(s.stream, data); err != nil {
+			s.readErr <- err
+			return
+		}
+
+		select {
+		case s.inbound <- data:
+		case <-s.session.Context().Done():
+			s.readErr <- context.Cause(s.session.Context())
+			return
+		}
+	}
+}
+
+// Read datagrams until the session ends, dropping any older than one already passed on
+func (s *wtSession) readDatagrams() {
+	var last uint32
+	var received bool
+	for {
+		datagram, err := s.session.ReceiveDatagram(s.session.Context())
+		if err != nil {
+			return
+		}
+		if len(datagram) <= wtSeqSize {
+			continue
+		}
+		seq := binary.BigEndian.Uint32(datagram)
+		if received && !seqAfter(seq, last) {
+			continue
+		}
+		last, received = seq, true
+
+		select {
+		case s.inbound <- datagram[wtSeqSize:]:
+		default:
+			wtDatagramsDroppedTotal.Inc()
+		}
+	}
+}
+
+// Write the packet to the stream after its length
+func (s *wtSession) writeStream(data []byte) (int, error) {
+	s.frame = binary.BigEndian.AppendUint32(s.frame[:0], uint32(len(data)))
+	s.frame = append(s.frame, data...)
+	return s.stream.Write(s.frame)
+}
+
+// Send the packet as a datagram, or on the stream if it's too big to be one
+func (s *wtSession) sendDatagram(data []byte) (int, error) {
+	s.frame = binary.BigEndian.AppendUint32(s.frame[:0], s.nextDatagram)
+	s.frame = append(s.frame, data...)
+	err := s.session.SendDatagram(s.frame)
+	var tooLarge *quic.DatagramTooLargeError
+	if errors.As(err, &tooLarge) {
+		return s.writeStream(data)
+	}
+	s.nextDatagram++
+	return len(s.frame), err
+}
+
+// Tell the client why it's being disconnected, and forget it. The session's only closed once the write pump's ended the
+// stream and the client's had a chance to read to the end of it, since closing it throws away anything still in
+// flight, e.g. a kick. Clients close the session themselves once they have, or it's closed anyway after a while.
+func (s *wtSession) close(reason string) {
+	s.closeOnce.Do(func() {
+		s.listener.mux.Lock()
+		delete(s.listener.sessions, s)
+		s.listener.mux.Unlock()
+
+		go func() {
+			ctx, cancel := context.WithTimeout(s.session.Context(), wtCloseGrace)
+			defer cancel()
+			select {
+			case <-s.ended:
+			case <-ctx.Done():
+			}
+			<-ctx.Done()
+			s.session.CloseWithError(0, reason)
+		}()
+	})
+}
+
+// A client connected over WebTransport
+type WebTransportClient struct {
+	baseClient
+	session *wtSession
+
+	// Only used by the write pump
+	players unreliablePlayers
+}
+
+func (c *WebTransportClient) ReadPump() {
+	defer func() {
+		c.logger.Println("Closing read pump")
+		c.closeFromPump("read pump closed")
+	}()
+
+	for {
+		select {
+		case data := <-c.session.inbound:
+			c.bandwidth.Received(len(data) + wtLengthSize)
+			c.handleSocketData(data)
+		case err := <-c.session.readErr:
+			c.handleReadError(err)
+			return
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// Log why reading the client's stream stopped, if it wasn't just the client going away
+func (c *WebTransportClient) handleReadError(err error) {
+	var idle *quic.IdleTimeoutError
+	var sessionErr *webtransport.SessionError
+	switch {
+	case errors.Is(err, errWtMessageTooBig):
+		c.logger.Printf("Message exceeded the limit of %d bytes, disconnecting", c.limits.MaxMessageSize)
+		protocolViolationsTotal.With("message_too_big").Inc()
+		c.sendKick(c.hub.Kicks.Hint(server.KickProtocolError, "Your game sent a message too big for the server"))
+	case errors.As(err, &idle):
+		// Nothing can be sent over a connection that's timed out, so there's no kick
+		c.logger.Printf("Nothing received for %s, disconnecting", c.limits.ReadTimeout)
+		protocolViolationsTotal.With("read_timeout").Inc()
+	case errors.Is(err, io.EOF), errors.As(err, &sessionErr) && sessionErr.Remote:
+	default:
+		c.logger.Errorf("Error: %v", err)
+	}
+}
+
+func (c *WebTransportClient) WritePump() {
+	defer func() {
+		c.logger.Println("Closing write pump")
+		c.session.stream.Close()
+		close(c.session.ended)
+		c.closeFromPump("write pump closed")
+	}()
+
+	for {
+		var packet outgoingPacket
+		select {
+		case <-c.closed:
+			return
+		case packet = <-c.sendChan:
+		}
+
+		data, err := c.encode(packet)
+		if err != nil {
+			c.logger.Errorf("error marshalling %T packet: %v", packet.message.Msg, err)
+			c.deadLetters.record("marshal_error", packet)
+			continue
+		}
+		c.writeBuf = data
+		if c.throttle(packet, len(data)+wtLengthSize) {
+			continue
+		}
+
+		var size int
+		if !c.players.reliable(packet) {
+			size, err = c.session.sendDatagram(data)
+		} else {
+			size, err = c.session.writeStream(data)
+		}
+		if err != nil {
+			c.logger.Errorf("error writing %T packet, closing client: %v", packet.message.Msg, err)
+			c.deadLetters.record("write_error", packet)
+			return
+		}
+		c.wrote(packet, size)
+	}
+}
+
+func (c *WebTransportClient) RemoteIp() string {
+	return remoteIp(c.session.session.RemoteAddr())
+}
+
+func (c *WebTransportClient) closeTransport(reason string) {
+	c.session.close(reason)
+}
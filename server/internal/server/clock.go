@@ -0,0 +1,48 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// The number of round trip samples kept per client when estimating its latency
+const clockSyncSamples = 8
+
+// Tracks the round trip times a client reports during time sync, so the server knows roughly how old the client's
+// view of the world is, and how far its player could have moved since
+type ClockSync struct {
+	samples [clockSyncSamples]time.Duration
+	count   int
+	next    int
+	mux     sync.Mutex
+}
+
+func (c *ClockSync) AddRttSample(rtt time.Duration) {
+	if rtt <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.samples[c.next] = rtt
+	c.next = (c.next + 1) % clockSyncSamples
+	c.count = min(c.count+1, clockSyncSamples)
+}
+
+// Estimated one-way latency between the client and the server, or 0 if the client hasn't synced yet.
+// The smallest recent round trip is used, since larger ones are mostly noise from queueing along the way.
+func (c *ClockSync) Latency() time.Duration {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.count == 0 {
+		return 0
+	}
+
+	best := c.samples[0]
+	for _, rtt := range c.samples[1:c.count] {
+		best = min(best, rtt)
+	}
+	return best / 2
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultConsistencyCheckInterval = 6 * time.Hour
+
+	consistencyCheckJob = "consistency.check"
+
+	// Who's named as having triggered the checks that run on their own
+	consistencyScheduled = "schedule"
+
+	// The most drift kept in one report, and the most negative rows looked at in one check. Anything past this is
+	// still counted, and found again next time.
+	maxConsistencyDrift = 1000
+
+	consistencyReportRetention = 30 * 24 * time.Hour
+)
+
+// How what's live and what's saved disagree
+type DriftKind string
+
+const (
+	// More of the item in memory than saved, e.g. from a write that was rolled back after the mirror was updated
+	DriftDuplicated DriftKind = "duplicated"
+
+	// Less of the item in memory than saved, e.g. from a grant made while the player was online
+	DriftMissing DriftKind = "missing"
+
+	// A saved stack below zero, which the player can't see or use but still counts against the supply, e.g. coins
+	// taken twice
+	DriftNegativeQuantity DriftKind = "negative_quantity"
+)
+
+var consistencyDriftTotal = metrics.NewCounterVec("mmo_consistency_drift_total", "Drift found by consistency checks, by kind.", "kind")
+
+// One thing a check found wrong with a player
+type Drift struct {
+	Kind     DriftKind `json:"kind"`
+	PlayerId int64     `json:"player_id"`
+
+	// Only known for players who were online
+	PlayerName string `json:"player_name,omitempty"`
+
+	ItemId string `json:"item_id"`
+
+	// How many of the item the player had in memory, 0 if they were offline, and how many were saved
+	Live      int64 `json:"live"`
+	Persisted int64 `json:"persisted"`
+
+	Repaired bool `json:"repaired"`
+}
+
+// What a check found, as downloaded from the admin API
+type ConsistencyReport struct {
+	Id             int64     `json:"id"`
+	TriggeredBy    string    `json:"triggered_by"`
+	Repair         bool      `json:"repair"`
+	PlayersChecked int64     `json:"players_checked"`
+	DriftCount     int64     `json:"drift_count"`
+	Drift          []Drift   `json:"drift,omitempty"`
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+}
+
+// A check for an admin to queue, see ConsistencyChecks.Queue
+type consistencyCheckPayload struct {
+	Admin  string `json:"admin"`
+	Repair bool   `json:"repair"`
+}
+
+// Reconciles what online players have in memory against what's saved for them, and looks for saved state no player
+// should be able to get into, like negative coins, reporting whatever's drifted and optionally repairing it. What's
+// saved is always taken to be right: a player's live inventory is reloaded from the database, and negative stacks are
+// cleared out. Checks run every so often as a job, and whenever an admin queues one, and each leaves a report behind
+// to download from the admin API.
+type ConsistencyChecks struct {
+	hub *Hub
+
+	// Whether the checks that run on their own repair what they find
+	repair bool
+}
+
+func NewConsistencyChecks(hub *Hub) *ConsistencyChecks {
+	return &ConsistencyChecks{hub: hub}
+}
+
+// Must be called before the hub is run. Left at 0, the interval keeps its default, and below 0 checks only run when
+// an admin queues one.
+func (c *ConsistencyChecks) Configure(interval time.Duration, repair bool) {
+	if interval == 0 {
+		interval = DefaultConsistencyCheckInterval
+	} else if interval < 0 {
+		interval = 0
+	}
+	c.repair = repair
+	c.hub.Jobs.Register(consistencyCheckJob, c.check, JobOptions{Every: interval})
+}
+
+// Queue a check to run as soon as there's room, by the admin, repairing what it finds if asked to
+func (c *ConsistencyChecks) Queue(admin string, repair bool) (db.Job, error) {
+	return c.hub.Jobs.Queue(consistencyCheckJob, consistencyCheckPayload{Admin: admin, Repair: repair})
+}
+
+func (c *ConsistencyChecks) check(ctx context.Context, payload json.RawMessage) error {
+	request := consistencyCheckPayload{Admin: consistencyScheduled, Repair: c.repair}
+	if len(payload) > 0 && string(payload) != "null" {
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return fmt.Errorf("error decoding consistency check: %w", err)
+		}
+	}
+
+	report := ConsistencyReport{TriggeredBy: request.Admin, Repair: request.Repair, StartedAt: time.Now()}
+	queries := c.hub.NewDbTx().Queries
+	if err := c.checkOnline(ctx, queries, &report); err != nil {
+		return err
+	}
+	if err := c.checkNegative(ctx, queries, &report); err != nil {
+		return err
+	}
+	report.FinishedAt = time.Now()
+
+	drift, err := json.Marshal(report.Drift)
+	if err != nil {
+		return err
+	}
+	row, err := queries.CreateConsistencyReport(ctx, db.CreateConsistencyReportParams{
+		TriggeredBy:    report.TriggeredBy,
+		Repair:         report.Repair,
+		PlayersChecked: report.PlayersChecked,
+		DriftCount:     report.DriftCount,
+		Report:         drift,
+		StartedAt:      report.StartedAt.UnixMilli(),
+		FinishedAt:     report.FinishedAt.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if report.DriftCount > 0 {
+		logging.Hub.Printf("Consistency check %d found %d drift across %d players online", row.ID, report.DriftCount, report.PlayersChecked)
+	}
+	if report.Repair {
+		c.hub.Audit(report.TriggeredBy, "consistency.repaired", fmt.Sprintf("report %d", row.ID), fmt.Sprintf("%d drift found", report.DriftCount))
+	}
+
+	return queries.DeleteConsistencyReportsBefore(ctx, time.Now().Add(-consistencyReportRetention).UnixMilli())
+}
+
+// Compare each online player's inventory in memory to what's saved. Players whose inventory changes while they're
+// being checked are left for next time, since the two were never meant to agree mid-write.
+func (c *ConsistencyChecks) checkOnline(ctx context.Context, queries *db.Queries, report *ConsistencyReport) error {
+	online := make(map[uint64]*objects.Player)
+	c.hub.SharedGameObjects.Players.ForEach(func(clientId uint64, player *objects.Player) {
+		if player.DbId != 0 {
+			online[clientId] = player
+		}
+	})
+
+	for clientId, player := range online {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		live, loaded := objects.GetComponent[*objects.Inventory](&player.Components)
+		if !loaded {
+			continue
+		}
+		rows, err := queries.GetInventoryItems(ctx, player.DbId)
+		if err != nil {
+			return err
+		}
+		if current, _ := objects.GetComponent[*objects.Inventory](&player.Components); current != live {
+			continue
+		}
+		report.PlayersChecked++
+		client, exists := c.hub.Clients.Get(clientId)
+		repair := report.Repair && exists
+
+		persisted := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			persisted[row.ItemID] = row.Quantity
+		}
+		drifted := false
+		for _, itemId := range mergedKeys(live.Items, persisted) {
+			if live.Items[itemId] == persisted[itemId] {
+				continue
+			}
+			kind := DriftMissing
+			if live.Items[itemId] > persisted[itemId] {
+				kind = DriftDuplicated
+			}
+			c.found(report, Drift{
+				Kind:       kind,
+				PlayerId:   player.DbId,
+				PlayerName: player.Name,
+				ItemId:     itemId,
+				Live:       live.Items[itemId],
+				Persisted:  persisted[itemId],
+				Repaired:   repair,
+			})
+			drifted = true
+		}
+
+		// The player's own state reloads their inventory, so the mirror's only ever set from one place
+		if drifted && repair {
+			client.ProcessMessage(0, packets.NewInventory(nil))
+		}
+	}
+	return nil
+}
+
+// Look for saved stacks below zero, online or not, clearing them out if repairing
+func (c *ConsistencyChecks) checkNegative(ctx context.Context, queries *db.Queries, report *ConsistencyReport) error {
+	rows, err := queries.GetNegativeInventoryItems(ctx, maxConsistencyDrift)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		drift := Drift{Kind: DriftNegativeQuantity, PlayerId: row.PlayerID, ItemId: row.ItemID, Persisted: row.Quantity}
+		client, online := c.hub.ClientByPlayerDbId(row.PlayerID)
+		if report.Repair {
+			deleted, err := queries.DeleteNegativeInventoryItem(ctx, db.DeleteNegativeInventoryItemParams{PlayerID: row.PlayerID, ItemID: row.ItemID})
+			if err != nil {
+				return err
+			}
+			drift.Repaired = deleted > 0
+			if drift.Repaired && online {
+				client.ProcessMessage(0, packets.NewInventory(nil))
+			}
+		}
+		c.found(report, drift)
+	}
+	return nil
+}
+
+func (c *ConsistencyChecks) found(report *ConsistencyReport, drift Drift) {
+	consistencyDriftTotal.With(string(drift.Kind)).Inc()
+	report.DriftCount++
+	if len(report.Drift) < maxConsistencyDrift {
+		report.Drift = append(report.Drift, drift)
+	}
+}
+
+// Every key in either map, sorted
+func mergedKeys(a, b map[string]int64) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, exists := a[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// A report as its check saved it
+func ConsistencyReportFromDb(row db.ConsistencyReport) (ConsistencyReport, error) {
+	report := ConsistencyReport{
+		Id:             row.ID,
+		TriggeredBy:    row.TriggeredBy,
+		Repair:         row.Repair,
+		PlayersChecked: row.PlayersChecked,
+		DriftCount:     row.DriftCount,
+		StartedAt:      time.UnixMilli(row.StartedAt),
+		FinishedAt:     time.UnixMilli(row.FinishedAt),
+	}
+	err := json.Unmarshal(row.Report, &report.Drift)
+	return report, err
+}
@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"server/internal/server/db"
+	"server/internal/server/diagnostics"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"time"
+)
+
+const (
+	// The window an account's transfers are capped over
+	currencyDay = 24 * time.Hour
+
+	// Transfers are kept a little longer than the window, and flagged or refused ones long enough to investigate
+	currencyTransferRetention = 2 * currencyDay
+	currencyFlagRetention     = 90 * 24 * time.Hour
+
+	currencyCleanupJob      = "currency.cleanup"
+	currencyCleanupInterval = time.Hour
+)
+
+// Ways coins move between players
+const (
+	// Paid by the buyer, counterparty the seller
+	CurrencyAuctionBuyout = "auction_buyout"
+
+	// What a listing sold for, claimed from the auction house's mail by the seller
+	CurrencyAuctionProceeds = "auction_proceeds"
+)
+
+// What made a transfer worth looking into
+const (
+	currencyFlagLargeTransfer  = "large_transfer"
+	currencyFlagDailyVolume    = "daily_volume"
+	currencyFlagOverTransfer   = "over_transfer_cap"
+	currencyFlagOverDailyLimit = "over_daily_limit"
+)
+
+var (
+	ErrCurrencyTransferCap = errors.New("more coins than can be moved at once")
+	ErrCurrencyDailyLimit  = errors.New("more coins than the account can move today")
+)
+
+var currencyFlagsTotal = metrics.NewCounterVec("mmo_currency_flags_total", "Coin transfers flagged as possible duping or real money trading, by why.", "flag")
+
+// Hard caps on coins moving between players, which are refused beyond them, and soft thresholds, which let the
+// transfer through but flag it. Any cap or threshold that's 0 is off.
+type CurrencyLimits struct {
+	// The most coins in any one transfer, e.g. what a listing can sell for
+	MaxPerTransfer int64
+
+	// The most coins an account can move over a day, in and out together
+	DailyLimit int64
+
+	// Transfers of at least this many coins are flagged
+	FlagTransfer int64
+
+	// Transfers taking an account's coins moved over the day to at least this many are flagged
+	FlagDaily int64
+}
+
+var DefaultCurrencyLimits = CurrencyLimits{
+	FlagTransfer: 100_000,
+	FlagDaily:    500_000,
+}
+
+// One account's side of coins moving between players
+type CurrencyTransfer struct {
+	UserId     int64
+	PlayerDbId int64
+	Kind       string
+	Amount     int64
+
+	// The player on the other side, or 0 if there isn't one, e.g. for the auction house's mail
+	CounterpartyDbId int64
+}
+
+// Caps how many coins move between players, once at a time and per account per day, since the quickest coins from a
+// dupe or bought for real money are moved on before anyone notices. Transfers past a soft threshold, and any refused
+// for going over a cap, are flagged: published to the outbox as currency.flagged for anti-cheat to investigate, and
+// kept for moderators to list through the admin API.
+type CurrencyCaps struct {
+	hub    *Hub
+	limits CurrencyLimits
+}
+
+func NewCurrencyCaps(hub *Hub) *CurrencyCaps {
+	c := &CurrencyCaps{hub: hub, limits: DefaultCurrencyLimits}
+	hub.Jobs.Register(currencyCleanupJob, c.cleanUp, JobOptions{Every: currencyCleanupInterval})
+	return c
+}
+
+// Must be called before the hub is run
+func (c *CurrencyCaps) Configure(limits CurrencyLimits) {
+	c.limits = limits
+	logging.Hub.Printf("Capping coin transfers at %d at once and %d a day, flagging from %d at once and %d a day (0 is off)",
+		limits.MaxPerTransfer, limits.DailyLimit, limits.FlagTransfer, limits.FlagDaily)
+}
+
+func (c *CurrencyCaps) Limits() CurrencyLimits {
+	return c.limits
+}
+
+// Whether that many coins could be moved at once, to check before taking anything, e.g. the price of a new listing
+func (c *CurrencyCaps) Allows(amount int64) bool {
+	return c.limits.MaxPerTransfer <= 0 || amount <= c.limits.MaxPerTransfer
+}
+
+// Count the transfer against the account's caps, flagging it if it's over a threshold. Pass queries that are part of
+// the transaction moving the coins, so a transfer that's rolled back isn't counted. Fails with ErrCurrencyTransferCap
+// or ErrCurrencyDailyLimit if it's over a cap, in which case the coins mustn't move, but the refusal's still flagged.
+func (c *CurrencyCaps) Transfer(dbTx *DbTx, queries *db.Queries, transfer CurrencyTransfer) error {
+	if !c.Allows(transfer.Amount) {
+		c.refuse(transfer, currencyFlagOverTransfer)
+		return ErrCurrencyTransferCap
+	}
+
+	now := time.Now()
+	volume, err := queries.GetCurrencyTransferVolume(dbTx.Ctx, db.GetCurrencyTransferVolumeParams{
+		UserID:    transfer.UserId,
+		CreatedAt: now.Add(-currencyDay).UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if c.limits.DailyLimit > 0 && volume+transfer.Amount > c.limits.DailyLimit {
+		c.refuse(transfer, currencyFlagOverDailyLimit)
+		return ErrCurrencyDailyLimit
+	}
+
+	// Only the transfer taking the account over the threshold is flagged, not every one after it that day
+	flag := ""
+	if c.limits.FlagTransfer > 0 && transfer.Amount >= c.limits.FlagTransfer {
+		flag = currencyFlagLargeTransfer
+	} else if c.limits.FlagDaily > 0 && volume < c.limits.FlagDaily && volume+transfer.Amount >= c.limits.FlagDaily {
+		flag = currencyFlagDailyVolume
+	}
+	return c.record(dbTx, queries, transfer, false, flag, now)
+}
+
+// Flag the refused transfer on its own, since the transaction it was part of won't be committed. That transaction
+// holds the database until it's rolled back, so the flag's written once it has been.
+func (c *CurrencyCaps) refuse(transfer CurrencyTransfer, flag string) {
+	at := time.Now()
+	diagnostics.Go("currency caps", func() {
+		dbTx := c.hub.NewDbTx()
+		err := dbTx.WriteBehind("refused currency transfer", func(ctx context.Context, queries *db.Queries) error {
+			return c.record(dbTx, queries, transfer, true, flag, at)
+		})
+		if err != nil {
+			logging.Hub.Errorf("Error flagging refused transfer of %d coins by player %d: %v", transfer.Amount, transfer.PlayerDbId, err)
+		}
+	})
+}
+
+func (c *CurrencyCaps) record(dbTx *DbTx, queries *db.Queries, transfer CurrencyTransfer, refused bool, flag string, at time.Time) error {
+	err := queries.CreateCurrencyTransfer(dbTx.Ctx, db.CreateCurrencyTransferParams{
+		UserID:         transfer.UserId,
+		PlayerID:       transfer.PlayerDbId,
+		Kind:           transfer.Kind,
+		Amount:         transfer.Amount,
+		CounterpartyID: transfer.CounterpartyDbId,
+		Refused:        refused,
+		Flag:           flag,
+		CreatedAt:      at.UnixMilli(),
+	})
+	if err != nil || flag == "" {
+		return err
+	}
+
+	logging.Hub.Printf("Flagged %s of %d coins by player %d: %s", transfer.Kind, transfer.Amount, transfer.PlayerDbId, flag)
+	currencyFlagsTotal.With(flag).Inc()
+	return dbTx.RecordEvent(queries, "currency.flagged", map[string]any{
+		"user_id":         transfer.UserId,
+		"player_id":       transfer.PlayerDbId,
+		"kind":            transfer.Kind,
+		"amount":          transfer.Amount,
+		"counterparty_id": transfer.CounterpartyDbId,
+		"refused":         refused,
+		"flag":            flag,
+	})
+}
+
+// Flagged and refused transfers since the time, most recent first
+func (c *CurrencyCaps) Flagged(ctx context.Context, since time.Time, limit int) ([]db.CurrencyTransfer, error) {
+	return c.hub.NewDbTx().Queries.GetFlaggedCurrencyTransfers(ctx, db.GetFlaggedCurrencyTransfersParams{
+		CreatedAt: since.UnixMilli(),
+		Limit:     int64(limit),
+	})
+}
+
+// Forget transfers that no longer count towards any cap, other than flagged ones still worth investigating
+func (c *CurrencyCaps) cleanUp(ctx context.Context, _ json.RawMessage) error {
+	now := time.Now()
+	deleted, err := c.hub.NewDbTx().Queries.DeleteCurrencyTransfersBefore(ctx, db.DeleteCurrencyTransfersBeforeParams{
+		CreatedAt:   now.Add(-currencyTransferRetention).UnixMilli(),
+		CreatedAt_2: now.Add(-currencyFlagRetention).UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logging.Hub.Printf("Deleted %d old coin transfers", deleted)
+	}
+	return nil
+}
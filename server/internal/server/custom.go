@@ -0,0 +1,33 @@
+package server
+
+import "sync"
+
+// Handles custom messages of one type, for programs embedding the server to add their own packets without changing
+// the protocol. The payload is whatever the client put in it, usually another protobuf message.
+type CustomHandler func(client ClientInterfacer, senderId uint64, payload []byte)
+
+type customHandlers struct {
+	handlers map[string]CustomHandler
+	mux      sync.RWMutex
+}
+
+// Handle custom messages of the given type, in whichever state the client is in. Replaces any handler already
+// registered for the type.
+func (h *Hub) HandleCustom(customType string, handler CustomHandler) {
+	h.customHandlers.mux.Lock()
+	defer h.customHandlers.mux.Unlock()
+
+	if h.customHandlers.handlers == nil {
+		h.customHandlers.handlers = make(map[string]CustomHandler)
+	}
+	h.customHandlers.handlers[customType] = handler
+}
+
+// The handler for custom messages of the given type, if one has been registered
+func (h *Hub) CustomHandler(customType string) (CustomHandler, bool) {
+	h.customHandlers.mux.RLock()
+	defer h.customHandlers.mux.RUnlock()
+
+	handler, exists := h.customHandlers.handlers[customType]
+	return handler, exists
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"cmp"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// How long damage counts towards a kill. What was done before then has worn off, so someone who hit a target once and
+// left doesn't share in it being killed much later.
+const damageAttributionWindow = 30 * time.Second
+
+var killsCreditedTotal = metrics.NewCounter("mmo_kills_credited_total", "Kills whose rewards were shared between the players who damaged the target.")
+
+type damageHit struct {
+	attackerId uint64
+	amount     float64
+	at         time.Time
+}
+
+// How much of the damage done to a target lately one attacker did
+type Contribution struct {
+	AttackerId uint64
+	Damage     float64
+
+	// Of all the damage done to the target lately, between 0 and 1
+	Share float64
+}
+
+// Keeps track of who's damaged each target lately and how much, so what killing it is worth can be shared by how much
+// each attacker contributed rather than going to whoever dealt the last hit. Anything that wants to know who did what
+// to a target, e.g. leaderboards and achievements, can ask for its contributions.
+type DamageAttribution struct {
+	hub *Hub
+
+	// By target ID, oldest first
+	hits map[uint64][]damageHit
+	mux  sync.Mutex
+}
+
+func NewDamageAttribution(hub *Hub) *DamageAttribution {
+	return &DamageAttribution{hub: hub, hits: make(map[uint64][]damageHit)}
+}
+
+// Count damage the attacker did to the target
+func (a *DamageAttribution) Record(targetId uint64, attackerId uint64, amount float64) {
+	if amount <= 0 {
+		return
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	now := time.Now()
+	a.hits[targetId] = append(a.recent(targetId, now), damageHit{attackerId: attackerId, amount: amount, at: now})
+}
+
+// The target's hits still within the window, dropping the rest. Must be called with the lock held.
+func (a *DamageAttribution) recent(targetId uint64, now time.Time) []damageHit {
+	hits := a.hits[targetId]
+	expired, _ := slices.BinarySearchFunc(hits, now.Add(-damageAttributionWindow), func(hit damageHit, since time.Time) int {
+		return hit.at.Compare(since)
+	})
+	return hits[expired:]
+}
+
+// Who's damaged the target lately and how much, most damage first
+func (a *DamageAttribution) Contributions(targetId uint64) []Contribution {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.contributions(targetId)
+}
+
+func (a *DamageAttribution) contributions(targetId uint64) []Contribution {
+	hits := a.recent(targetId, time.Now())
+	a.hits[targetId] = hits
+	if len(hits) == 0 {
+		delete(a.hits, targetId)
+		return nil
+	}
+
+	var total float64
+	byAttacker := make(map[uint64]float64)
+	for _, hit := range hits {
+		byAttacker[hit.attackerId] += hit.amount
+		total += hit.amount
+	}
+	contributions := make([]Contribution, 0, len(byAttacker))
+	for attackerId, damage := range byAttacker {
+		contributions = append(contributions, Contribution{AttackerId: attackerId, Damage: damage, Share: damage / total})
+	}
+	slices.SortFunc(contributions, func(a, b Contribution) int {
+		if c := cmp.Compare(b.Damage, a.Damage); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.AttackerId, b.AttackerId)
+	})
+	return contributions
+}
+
+// The target's contributions, forgetting them, since it's been killed
+func (a *DamageAttribution) Settle(targetId uint64) []Contribution {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	contributions := a.contributions(targetId)
+	delete(a.hits, targetId)
+	return contributions
+}
+
+// Share the reward for killing the target between those who damaged it. Each attacker still in the world is credited
+// with their share of the mass, and the items and quest stages if their share's big enough, which their own state
+// grants them.
+func (a *DamageAttribution) Reward(targetId uint64, reward *gamedata.KillReward) []Contribution {
+	contributions := a.Settle(targetId)
+	if len(contributions) == 0 {
+		return nil
+	}
+	killsCreditedTotal.Inc()
+
+	for _, contribution := range contributions {
+		client, exists := a.hub.Clients.Get(contribution.AttackerId)
+		if !exists {
+			continue
+		}
+		var items, questStages map[string]int64
+		if contribution.Share >= reward.MinShare {
+			items, questStages = reward.Items, reward.QuestStages
+		}
+		client.ProcessMessage(0, packets.NewKillCredit(targetId, contribution.Share, reward.Mass*contribution.Share, items, questStages))
+	}
+	logging.Hub.Printf("Shared kill reward %s for %d between %d attackers", reward.Id, targetId, len(contributions))
+	return contributions
+}
+
+// Forget the damage done to the target, e.g. once it's gone without being killed
+func (a *DamageAttribution) Forget(targetId uint64) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	delete(a.hits, targetId)
+}
+
+// The targets damaged lately, regardless of whether they're still around
+func (a *DamageAttribution) targetIds() []uint64 {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	ids := make([]uint64, 0, len(a.hits))
+	for id := range a.hits {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+)
+
+// Where clients download game data files from, followed by the file's name
+const DataFilesPath = "/api/data/"
+
+// Serves the game data files the server loaded to clients whose copies are out of date. Only files in the pack are
+// served, and only while they're still what was loaded, so a client never gets a file the server isn't running.
+type DataFiles struct {
+	dataDirPath string
+	pack        *gamedata.DataPack
+}
+
+func NewDataFiles(dataDirPath string, pack *gamedata.DataPack) *DataFiles {
+	return &DataFiles{dataDirPath: dataDirPath, pack: pack}
+}
+
+func (d *DataFiles) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	file, exists := d.pack.File(request.PathValue("file"))
+	if !exists {
+		http.NotFound(writer, request)
+		return
+	}
+
+	data, err := os.ReadFile(path.Join(d.dataDirPath, file.Name))
+	if err != nil {
+		logging.Hub.Errorf("Error reading game data file %s: %v", file.Name, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if checksum := sha256.Sum256(data); !bytes.Equal(checksum[:], file.Sha256) {
+		logging.Hub.Errorf("Game data file %s has changed since it was loaded, not serving it until the server restarts", file.Name)
+		http.Error(writer, "game data is being updated", http.StatusServiceUnavailable)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("ETag", `"`+hex.EncodeToString(file.Sha256)+`"`)
+	writer.Write(data)
+}
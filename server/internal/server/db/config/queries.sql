@@ -44,4 +44,1049 @@ SELECT COUNT(*) + 1 as "rank" FROM players
 WHERE best_score >= (
     SELECT best_score FROM players p2
     WHERE p2.id = ?
-);
\ No newline at end of file
+);
+
+-- name: UpdateUserPasswordHash :exec
+UPDATE users
+SET password_hash = ?
+WHERE id = ?;
+
+-- name: GetUsers :many
+SELECT * FROM users
+ORDER BY id;
+
+-- name: CreateBan :one
+INSERT INTO bans (
+    user_id, reason, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetActiveBan :one
+SELECT * FROM bans
+WHERE user_id = ? AND (expires_at IS NULL OR expires_at > ?)
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetBansByUserId :many
+SELECT * FROM bans
+WHERE user_id = ?
+ORDER BY created_at;
+
+-- name: DeleteBansByUserId :exec
+DELETE FROM bans
+WHERE user_id = ?;
+
+-- name: GetInventoryItems :many
+SELECT * FROM inventory_items
+WHERE player_id = ? AND quantity > 0;
+
+-- name: AddInventoryItem :exec
+INSERT INTO inventory_items (
+    player_id, item_id, quantity
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (player_id, item_id) DO UPDATE SET quantity = quantity + excluded.quantity;
+
+-- name: RemoveInventoryItem :execrows
+UPDATE inventory_items
+SET quantity = quantity - sqlc.arg(quantity)
+WHERE player_id = sqlc.arg(player_id) AND item_id = sqlc.arg(item_id) AND quantity >= sqlc.arg(quantity);
+
+-- name: CreateBlock :exec
+INSERT OR IGNORE INTO blocks (
+    player_id, blocked_player_id
+) VALUES (
+    ?, ?
+);
+
+-- name: DeleteBlock :exec
+DELETE FROM blocks
+WHERE player_id = ? AND blocked_player_id = ?;
+
+-- name: GetBlockedPlayers :many
+SELECT p.id, p.name FROM blocks b
+JOIN players p ON p.id = b.blocked_player_id
+WHERE b.player_id = ?
+ORDER BY p.name;
+
+-- name: GetPlayersBlocking :many
+SELECT p.name FROM blocks b
+JOIN players p ON p.id = b.player_id
+WHERE b.blocked_player_id = ?;
+
+-- name: CreateHeatmap :exec
+INSERT INTO heatmaps (
+    zone_id, taken_at, width, height, cells
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: GetHeatmaps :many
+SELECT id, zone_id, taken_at, width, height, cells FROM heatmaps
+WHERE zone_id = ? AND taken_at >= ?
+ORDER BY taken_at
+LIMIT ?;
+
+-- name: CompleteOnboardingStep :exec
+INSERT OR IGNORE INTO onboarding_steps (
+    player_id, step_id, completed_at
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: GetCompletedOnboardingSteps :many
+SELECT step_id FROM onboarding_steps
+WHERE player_id = ?;
+
+-- name: UnlockEmote :exec
+INSERT OR IGNORE INTO unlocked_emotes (
+    player_id, emote_id, unlocked_at
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: GetUnlockedEmotes :many
+SELECT emote_id FROM unlocked_emotes
+WHERE player_id = ?;
+
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (
+    topic, payload, created_at
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: GetOutboxEvents :many
+SELECT * FROM outbox_events
+ORDER BY id
+LIMIT ?;
+
+-- name: DeleteOutboxEvent :exec
+DELETE FROM outbox_events
+WHERE id = ?;
+
+-- name: CreateAuctionListing :one
+INSERT INTO auction_listings (
+    seller_id, item_id, quantity, price, listed_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING id;
+
+-- name: GetAuctionListing :one
+SELECT * FROM auction_listings
+WHERE id = ? LIMIT 1;
+
+-- name: SearchAuctionListings :many
+SELECT auction_listings.id, auction_listings.item_id, auction_listings.quantity, auction_listings.price, auction_listings.expires_at, players.name AS seller_name
+FROM auction_listings
+JOIN players ON players.id = auction_listings.seller_id
+WHERE auction_listings.buyer_id IS NULL AND auction_listings.expires_at > ? AND auction_listings.item_id LIKE ? ESCAPE '!'
+ORDER BY auction_listings.price, auction_listings.id
+LIMIT ? OFFSET ?;
+
+-- name: CountAuctionListings :one
+SELECT count(*) FROM auction_listings
+WHERE buyer_id IS NULL AND expires_at > ? AND item_id LIKE ? ESCAPE '!';
+
+-- name: BuyAuctionListing :execrows
+UPDATE auction_listings
+SET buyer_id = ?, sold_at = ?
+WHERE id = ? AND buyer_id IS NULL AND settled_at IS NULL AND expires_at > ? AND seller_id != ?;
+
+-- name: GetUnsettledAuctionListings :many
+SELECT * FROM auction_listings
+WHERE settled_at IS NULL AND (buyer_id IS NOT NULL OR expires_at <= ?)
+ORDER BY id
+LIMIT ?;
+
+-- name: SettleAuctionListing :execrows
+UPDATE auction_listings
+SET settled_at = ?
+WHERE id = ? AND settled_at IS NULL;
+
+-- name: CreateMail :exec
+INSERT INTO mail (
+    player_id, sender, subject, item_id, quantity, sent_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetMail :many
+SELECT * FROM mail
+WHERE player_id = ?
+ORDER BY id;
+
+-- name: ClaimMail :one
+DELETE FROM mail
+WHERE id = ? AND player_id = ?
+RETURNING *;
+
+-- name: GetMailById :one
+SELECT * FROM mail
+WHERE id = ? AND player_id = ?;
+
+-- name: TakeMailItems :execrows
+UPDATE mail
+SET quantity = quantity - sqlc.arg(quantity)
+WHERE id = sqlc.arg(id) AND player_id = sqlc.arg(player_id) AND quantity > sqlc.arg(quantity);
+
+-- name: CreateAuditLogEntry :exec
+INSERT INTO admin_audit_log (
+    admin, action, target, detail, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: GetAuditLog :many
+SELECT * FROM admin_audit_log
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: RecordPlayerLogin :exec
+INSERT INTO player_logins (
+    player_id, last_login_at
+) VALUES (
+    ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET last_login_at = excluded.last_login_at;
+
+-- name: CountBulkMailRecipients :one
+SELECT COUNT(*) FROM players
+LEFT JOIN player_logins ON player_logins.player_id = players.id
+WHERE players.best_score BETWEEN sqlc.arg(min_best_score) AND sqlc.arg(max_best_score)
+    AND COALESCE(player_logins.last_login_at, 0) BETWEEN sqlc.arg(last_login_after) AND sqlc.arg(last_login_before);
+
+-- name: GetBulkMailRecipients :many
+SELECT players.id FROM players
+LEFT JOIN player_logins ON player_logins.player_id = players.id
+WHERE players.id > sqlc.arg(after_id)
+    AND players.best_score BETWEEN sqlc.arg(min_best_score) AND sqlc.arg(max_best_score)
+    AND COALESCE(player_logins.last_login_at, 0) BETWEEN sqlc.arg(last_login_after) AND sqlc.arg(last_login_before)
+ORDER BY players.id
+LIMIT sqlc.arg(limit);
+
+-- name: CreateBulkMailJob :one
+INSERT INTO bulk_mail_jobs (
+    admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?, ?
+)
+RETURNING *;
+
+-- name: GetBulkMailJob :one
+SELECT * FROM bulk_mail_jobs
+WHERE id = ? LIMIT 1;
+
+-- name: GetBulkMailJobs :many
+SELECT * FROM bulk_mail_jobs
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetNextBulkMailJob :one
+SELECT * FROM bulk_mail_jobs
+WHERE status IN ('queued', 'running')
+ORDER BY id
+LIMIT 1;
+
+-- name: UpdateBulkMailJobProgress :execrows
+UPDATE bulk_mail_jobs
+SET status = 'running', sent = sent + ?, last_player_id = ?
+WHERE id = ? AND status IN ('queued', 'running');
+
+-- name: FinishBulkMailJob :exec
+UPDATE bulk_mail_jobs
+SET status = ?, finished_at = ?
+WHERE id = ? AND status IN ('queued', 'running');
+
+-- name: CreateReservedName :exec
+INSERT INTO reserved_names (
+    name, kind, reason, reserved_by, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: GetReservedNames :many
+SELECT * FROM reserved_names
+ORDER BY name;
+
+-- name: GetReservedNameMatch :one
+SELECT * FROM reserved_names
+WHERE (kind = 'name' AND name = sqlc.arg(name)) OR (kind = 'term' AND instr(sqlc.arg(name), name) > 0)
+LIMIT 1;
+
+-- name: DeleteReservedName :execrows
+DELETE FROM reserved_names
+WHERE name = ?;
+
+-- name: CreateReleasedName :exec
+INSERT INTO released_names (
+    name, user_id, released_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (name, user_id) DO UPDATE SET released_at = excluded.released_at;
+
+-- name: GetReleasedNameUsers :many
+SELECT users.id, users.username, users.password_hash FROM released_names
+JOIN users ON users.id = released_names.user_id
+WHERE released_names.name = ?
+ORDER BY released_names.released_at DESC;
+
+-- name: GetUserReleasedNames :many
+SELECT name FROM released_names
+WHERE user_id = ?
+ORDER BY released_at;
+
+-- name: DeleteUserReleasedNames :exec
+DELETE FROM released_names
+WHERE user_id = ?;
+
+-- name: GetPlayerLogin :one
+SELECT * FROM player_logins
+WHERE player_id = ? LIMIT 1;
+
+-- name: UpdateUsername :exec
+UPDATE users
+SET username = ?
+WHERE id = ?;
+
+-- name: UpdatePlayerName :exec
+UPDATE players
+SET name = ?
+WHERE id = ?;
+
+-- name: GetFeatureFlags :many
+SELECT * FROM feature_flags
+ORDER BY id;
+
+-- name: UpsertFeatureFlag :exec
+INSERT INTO feature_flags (
+    id, enabled, rollout_percent, capability, updated_by, updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (id) DO UPDATE SET
+    enabled = excluded.enabled,
+    rollout_percent = excluded.rollout_percent,
+    capability = excluded.capability,
+    updated_by = excluded.updated_by,
+    updated_at = excluded.updated_at;
+
+-- name: DeleteFeatureFlag :execrows
+DELETE FROM feature_flags
+WHERE id = ?;
+
+-- name: SavePlayerPosition :exec
+INSERT INTO player_positions (
+    player_id, x, y, saved_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    x = excluded.x,
+    y = excluded.y,
+    saved_at = excluded.saved_at;
+
+-- name: GetPlayerPosition :one
+SELECT * FROM player_positions
+WHERE player_id = ? LIMIT 1;
+
+-- name: ClaimPlayerVersion :one
+INSERT INTO player_versions (
+    player_id, version, claimed_at
+) VALUES (
+    ?, 1, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    version = version + 1,
+    claimed_at = excluded.claimed_at
+RETURNING version;
+
+-- name: CheckPlayerVersion :execrows
+UPDATE player_versions SET version = version
+WHERE player_id = ? AND version = ?;
+
+-- name: GetPlayerVersion :one
+SELECT * FROM player_versions
+WHERE player_id = ? LIMIT 1;
+
+-- name: UpsertVoiceMute :exec
+INSERT INTO voice_mutes (
+    player_id, muted_by, reason, expires_at, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    muted_by = excluded.muted_by,
+    reason = excluded.reason,
+    expires_at = excluded.expires_at,
+    created_at = excluded.created_at;
+
+-- name: GetVoiceMute :one
+SELECT * FROM voice_mutes
+WHERE player_id = ? LIMIT 1;
+
+-- name: DeleteVoiceMute :execrows
+DELETE FROM voice_mutes
+WHERE player_id = ?;
+
+-- name: GetPlayer :one
+SELECT * FROM players
+WHERE id = ? LIMIT 1;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = ? LIMIT 1;
+
+-- name: GetPlayerAuctionListings :many
+SELECT * FROM auction_listings
+WHERE seller_id = ? OR buyer_id = ?
+ORDER BY id;
+
+-- name: CreateDataExport :one
+INSERT INTO data_exports (
+    player_id, requested_by, token, status, requested_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetLatestDataExport :one
+SELECT * FROM data_exports
+WHERE player_id = ?
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetDataExportByToken :one
+SELECT * FROM data_exports
+WHERE token = ? LIMIT 1;
+
+-- name: GetNextQueuedDataExport :one
+SELECT * FROM data_exports
+WHERE status = 'queued'
+ORDER BY id
+LIMIT 1;
+
+-- name: CompleteDataExport :exec
+UPDATE data_exports
+SET status = 'ready', bundle = ?, ready_at = ?, expires_at = ?
+WHERE id = ?;
+
+-- name: ExpireDataExports :execrows
+UPDATE data_exports
+SET status = 'expired', bundle = NULL
+WHERE status = 'ready' AND expires_at <= ?;
+
+-- name: DeletePlayerDataExports :exec
+DELETE FROM data_exports
+WHERE player_id = ?;
+
+-- name: CreateAccountDeletion :exec
+INSERT INTO account_deletions (
+    player_id, requested_by, requested_at, delete_at
+) VALUES (
+    ?, ?, ?, ?
+);
+
+-- name: GetAccountDeletion :one
+SELECT * FROM account_deletions
+WHERE player_id = ? LIMIT 1;
+
+-- name: CancelAccountDeletion :execrows
+DELETE FROM account_deletions
+WHERE player_id = ? AND deleted_at IS NULL;
+
+-- name: GetDueAccountDeletions :many
+SELECT * FROM account_deletions
+WHERE deleted_at IS NULL AND delete_at <= ?
+ORDER BY delete_at
+LIMIT ?;
+
+-- name: FinishAccountDeletion :exec
+UPDATE account_deletions
+SET deleted_at = ?
+WHERE player_id = ?;
+
+-- name: AnonymizeUser :exec
+UPDATE users
+SET username = ?, password_hash = ''
+WHERE id = ?;
+
+-- name: AnonymizePlayer :exec
+UPDATE players
+SET name = ?, best_score = 0, color = 0
+WHERE id = ?;
+
+-- name: DeletePlayerInventory :exec
+DELETE FROM inventory_items
+WHERE player_id = ?;
+
+-- name: DeletePlayerBlocks :exec
+DELETE FROM blocks
+WHERE player_id = ? OR blocked_player_id = ?;
+
+-- name: DeletePlayerOnboardingSteps :exec
+DELETE FROM onboarding_steps
+WHERE player_id = ?;
+
+-- name: DeletePlayerEmotes :exec
+DELETE FROM unlocked_emotes
+WHERE player_id = ?;
+
+-- name: DeletePlayerMail :exec
+DELETE FROM mail
+WHERE player_id = ?;
+
+-- name: DeletePlayerLogin :exec
+DELETE FROM player_logins
+WHERE player_id = ?;
+
+-- name: DeletePlayerPosition :exec
+DELETE FROM player_positions
+WHERE player_id = ?;
+
+-- name: DeleteUnsoldAuctionListings :exec
+DELETE FROM auction_listings
+WHERE seller_id = ? AND buyer_id IS NULL;
+
+-- name: AddBandwidthUsage :exec
+INSERT INTO bandwidth_usage (
+    player_id, day, sessions, bytes_in, bytes_out, packets_in, packets_out
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (player_id, day) DO UPDATE SET
+    sessions = sessions + excluded.sessions,
+    bytes_in = bytes_in + excluded.bytes_in,
+    bytes_out = bytes_out + excluded.bytes_out,
+    packets_in = packets_in + excluded.packets_in,
+    packets_out = packets_out + excluded.packets_out;
+
+-- name: GetBandwidthUsage :one
+SELECT * FROM bandwidth_usage
+WHERE player_id = ? AND day = ? LIMIT 1;
+
+-- name: GetPlayerBandwidthUsage :many
+SELECT * FROM bandwidth_usage
+WHERE player_id = ?
+ORDER BY day DESC
+LIMIT ?;
+
+-- name: GetTopBandwidthUsage :many
+SELECT p.name, b.sessions, b.bytes_in, b.bytes_out, b.packets_in, b.packets_out FROM bandwidth_usage b
+JOIN players p ON p.id = b.player_id
+WHERE b.day = ?
+ORDER BY b.bytes_out DESC
+LIMIT ?;
+
+-- name: CreateStoreReceipt :one
+INSERT INTO store_receipts (
+    player_id, entry_id, platform, receipt, status, reason, created_at
+) VALUES (
+    ?, ?, ?, ?, 'pending', '', ?
+)
+RETURNING *;
+
+-- name: GetStoreReceipt :one
+SELECT * FROM store_receipts
+WHERE id = ? LIMIT 1;
+
+-- name: GetStoreReceiptsByStatus :many
+SELECT * FROM store_receipts
+WHERE status = ?
+ORDER BY id
+LIMIT ?;
+
+-- name: ResolveStoreReceipt :execrows
+UPDATE store_receipts
+SET status = ?, reason = ?, resolved_at = ?
+WHERE id = ? AND status = 'pending';
+
+-- name: CountStoreReceipts :one
+SELECT COUNT(*) FROM store_receipts
+WHERE platform = ? AND receipt = ?;
+
+-- name: GetPlayerStoreReceipts :many
+SELECT * FROM store_receipts
+WHERE player_id = ?
+ORDER BY id;
+
+-- name: RejectPendingStoreReceipts :exec
+UPDATE store_receipts
+SET status = 'rejected', reason = ?, resolved_at = ?
+WHERE player_id = ? AND status = 'pending';
+
+-- name: SetStoreReceipt :exec
+UPDATE store_receipts
+SET receipt = ?
+WHERE id = ?;
+-- name: CreateJob :one
+INSERT INTO jobs (
+    kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at
+) VALUES (
+    ?, ?, 'pending', 0, 0, 0, '', ?, ?
+)
+RETURNING *;
+
+-- name: ScheduleJob :exec
+INSERT INTO jobs (
+    kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at
+) VALUES (
+    ?, 'null', 'pending', ?, 0, 0, '', ?, ?
+)
+ON CONFLICT (kind) WHERE every_ms > 0 DO UPDATE SET every_ms = excluded.every_ms;
+
+-- name: GetJob :one
+SELECT * FROM jobs
+WHERE id = ? LIMIT 1;
+
+-- name: GetJobs :many
+SELECT * FROM jobs
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetJobsByStatus :many
+SELECT * FROM jobs
+WHERE status = ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetDueJobs :many
+SELECT * FROM jobs
+WHERE status = 'pending' AND run_at <= ?
+ORDER BY run_at
+LIMIT ?;
+
+-- name: StartJob :execrows
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, started_at = ?
+WHERE id = ? AND status = 'pending';
+
+-- name: FinishJob :exec
+UPDATE jobs
+SET status = ?, attempts = ?, last_error = ?, run_at = ?, finished_at = ?, runs = runs + 1
+WHERE id = ?;
+
+-- name: ResetRunningJobs :execrows
+UPDATE jobs
+SET status = 'pending'
+WHERE status = 'running';
+
+-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', attempts = 0, run_at = ?
+WHERE id = ? AND status = 'failed';
+
+-- name: RunJobNow :execrows
+UPDATE jobs
+SET run_at = ?
+WHERE kind = ? AND every_ms > 0 AND status = 'pending';
+
+-- name: DeleteFinishedJobs :execrows
+DELETE FROM jobs
+WHERE every_ms = 0 AND status IN ('done', 'failed') AND finished_at < ?;
+
+-- name: DeleteMailSentBefore :execrows
+DELETE FROM mail
+WHERE sent_at < ?;
+
+-- name: DeleteHiscoreRanks :exec
+DELETE FROM hiscore_ranks;
+
+-- name: RankHiscores :exec
+INSERT INTO hiscore_ranks (
+    player_id, rank, best_score, ranked_at
+)
+SELECT id, ROW_NUMBER() OVER (ORDER BY best_score DESC, id), best_score, ?
+FROM players;
+
+-- name: GetHiscoreRank :one
+SELECT rank FROM hiscore_ranks
+WHERE player_id = ? LIMIT 1;
+
+-- name: GrantEntitlement :execrows
+INSERT INTO entitlements (
+    user_id, entitlement_id, source, granted_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (user_id, entitlement_id) DO NOTHING;
+
+-- name: RevokeEntitlement :execrows
+DELETE FROM entitlements
+WHERE user_id = ? AND entitlement_id = ?;
+
+-- name: GetEntitlements :many
+SELECT user_id, entitlement_id, source, granted_at FROM entitlements
+WHERE user_id = ?
+ORDER BY granted_at, entitlement_id;
+
+-- name: DeleteUserEntitlements :exec
+DELETE FROM entitlements
+WHERE user_id = ?;
+
+-- name: CountPlayersByUserId :one
+SELECT COUNT(*) FROM players
+WHERE user_id = ?;
+
+-- name: GetQuestStages :many
+SELECT * FROM quest_stages
+WHERE player_id = ?;
+
+-- name: SetQuestStage :exec
+INSERT INTO quest_stages (
+    player_id, quest_id, stage, updated_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (player_id, quest_id) DO UPDATE SET stage = excluded.stage, updated_at = excluded.updated_at;
+
+-- name: DeletePlayerQuestStages :exec
+DELETE FROM quest_stages
+WHERE player_id = ?;
+
+-- name: GetPlayersByUserId :many
+SELECT * FROM players
+WHERE user_id = ?
+ORDER BY id;
+
+-- name: GetRankForScore :one
+SELECT COUNT(*) + 1 as "rank" FROM players
+WHERE best_score > ?;
+
+-- name: CreateAnnouncement :exec
+INSERT INTO announcements (
+    author, message, created_at
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: CountAnnouncements :one
+SELECT count(*) FROM announcements
+WHERE created_at >= ?;
+
+-- name: GetAnnouncements :many
+SELECT * FROM announcements
+WHERE created_at >= ?
+ORDER BY id DESC
+LIMIT ? OFFSET ?;
+
+-- name: CreateModerationCase :exec
+INSERT INTO moderation_cases (
+    reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, 'open', '', ?
+);
+
+-- name: GetModerationCase :one
+SELECT * FROM moderation_cases
+WHERE id = ? LIMIT 1;
+
+-- name: GetModerationCasesByStatus :many
+SELECT * FROM moderation_cases
+WHERE status = ?
+ORDER BY id
+LIMIT ?;
+
+-- name: CloseModerationCase :execrows
+UPDATE moderation_cases
+SET status = 'closed', resolution = ?, closed_at = ?
+WHERE id = ? AND status = 'open';
+
+-- name: GetPlayerModerationCases :many
+SELECT * FROM moderation_cases
+WHERE reporter_id = sqlc.arg(player_id) OR reported_id = sqlc.arg(player_id)
+ORDER BY id;
+
+-- name: GetModerationCasesMentioning :many
+SELECT * FROM moderation_cases
+WHERE instr(snapshot, CAST(sqlc.arg(name) AS TEXT)) > 0
+ORDER BY id;
+
+-- name: SetModerationCaseSnapshot :exec
+UPDATE moderation_cases
+SET snapshot = ?
+WHERE id = ?;
+
+-- name: AnonymizeModerationCaseReporter :exec
+UPDATE moderation_cases
+SET reporter_name = ?
+WHERE reporter_id = ?;
+
+-- name: AnonymizeModerationCaseReported :exec
+UPDATE moderation_cases
+SET reported_name = ?
+WHERE reported_id = ?;
+
+-- name: CreateChatMessage :exec
+INSERT INTO chat_messages (
+    zone_id, channel, user_id, player_id, sender_name, message, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetChatMessages :many
+SELECT * FROM chat_messages
+WHERE zone_id = ? AND channel = ? AND created_at >= ? AND created_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: GetZoneChatMessages :many
+SELECT * FROM chat_messages
+WHERE zone_id = ? AND created_at >= ? AND created_at < ?
+ORDER BY id
+LIMIT ?;
+
+-- name: DeleteChatMessagesBefore :execrows
+DELETE FROM chat_messages
+WHERE created_at < ?;
+
+-- name: DeletePlayerChatMessages :exec
+DELETE FROM chat_messages
+WHERE player_id = ?;
+
+-- name: GetPlayerChatMessages :many
+SELECT * FROM chat_messages
+WHERE player_id = ?
+ORDER BY id;
+
+-- name: CreateCurrencyTransfer :exec
+INSERT INTO currency_transfers (
+    user_id, player_id, kind, amount, counterparty_id, refused, flag, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: GetCurrencyTransferVolume :one
+SELECT CAST(COALESCE(SUM(amount), 0) AS INTEGER) AS volume FROM currency_transfers
+WHERE user_id = ? AND NOT refused AND created_at >= ?;
+
+-- name: GetFlaggedCurrencyTransfers :many
+SELECT * FROM currency_transfers
+WHERE flag != '' AND created_at >= ?
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: DeleteCurrencyTransfersBefore :execrows
+DELETE FROM currency_transfers
+WHERE created_at < ? AND (flag = '' OR created_at < ?);
+
+-- name: DeletePlayerCurrencyTransfers :exec
+DELETE FROM currency_transfers
+WHERE player_id = ?;
+
+-- name: GetPlayerCurrencyTransfers :many
+SELECT * FROM currency_transfers
+WHERE player_id = ?
+ORDER BY id;
+
+-- name: GetBossLockouts :many
+SELECT * FROM boss_lockouts
+WHERE player_id = ?;
+
+-- name: SetBossLockout :exec
+INSERT INTO boss_lockouts (
+    player_id, boss_id, looted_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (player_id, boss_id) DO UPDATE SET looted_at = excluded.looted_at;
+
+-- name: DeleteBossLockoutsBefore :execrows
+DELETE FROM boss_lockouts
+WHERE looted_at < ?;
+
+-- name: DeletePlayerBossLockouts :exec
+DELETE FROM boss_lockouts
+WHERE player_id = ?;
+
+-- name: GetWelcomeAcceptances :many
+SELECT step_id, CAST(MAX(version) AS INTEGER) AS version FROM welcome_acceptances
+WHERE user_id = ?
+GROUP BY step_id;
+
+-- name: AcceptWelcomeStep :exec
+INSERT INTO welcome_acceptances (
+    user_id, step_id, version, accepted_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (user_id, step_id, version) DO NOTHING;
+
+-- name: GetUserWelcomeAcceptances :many
+SELECT * FROM welcome_acceptances
+WHERE user_id = ?
+ORDER BY accepted_at, step_id, version;
+
+-- name: DeleteUserWelcomeAcceptances :exec
+DELETE FROM welcome_acceptances
+WHERE user_id = ?;
+
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    name, prefix, key_hash, scopes, requests_per_minute, created_by, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = ? AND revoked_at IS NULL LIMIT 1;
+
+-- name: GetApiKeys :many
+SELECT * FROM api_keys
+ORDER BY id;
+
+-- name: RevokeApiKey :execrows
+UPDATE api_keys
+SET revoked_at = ?
+WHERE id = ? AND revoked_at IS NULL;
+
+-- name: GetAuctionSales :many
+SELECT item_id, quantity, price, sold_at FROM auction_listings
+WHERE buyer_id IS NOT NULL AND sold_at >= ? AND item_id LIKE ? ESCAPE '!'
+ORDER BY sold_at DESC, id DESC
+LIMIT ?;
+
+-- name: GetHiscores :many
+SELECT hiscore_ranks.rank, players.name, hiscore_ranks.best_score, hiscore_ranks.ranked_at
+FROM hiscore_ranks
+JOIN players ON players.id = hiscore_ranks.player_id
+ORDER BY hiscore_ranks.rank
+LIMIT ? OFFSET ?;
+
+-- name: RecordLoginIp :exec
+INSERT INTO login_ips (
+    user_id, ip_hash, last_login_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (user_id, ip_hash) DO UPDATE SET last_login_at = excluded.last_login_at;
+
+-- name: GetLoginIpHashes :many
+SELECT ip_hash FROM login_ips
+WHERE user_id = ?;
+
+-- name: DeleteUserLoginIps :exec
+DELETE FROM login_ips
+WHERE user_id = ?;
+
+-- name: GetUserLoginIps :many
+SELECT * FROM login_ips
+WHERE user_id = ?
+ORDER BY last_login_at;
+
+-- name: CreateSession :one
+INSERT INTO sessions (
+    user_id, ip, device, client_build, logged_in_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: EndSession :exec
+UPDATE sessions SET ended_at = ?
+WHERE id = ? AND ended_at IS NULL;
+
+-- name: EndOpenSessions :exec
+UPDATE sessions SET ended_at = ?
+WHERE ended_at IS NULL;
+
+-- name: GetRecentSessions :many
+SELECT * FROM sessions
+WHERE user_id = ? AND (ended_at IS NULL OR ended_at >= ?)
+ORDER BY logged_in_at DESC, id DESC
+LIMIT ?;
+
+-- name: CountSessionsFrom :one
+SELECT
+    COUNT(*) AS sessions,
+    COUNT(CASE WHEN ip = ? THEN 1 END) AS from_ip,
+    COUNT(CASE WHEN device = ? THEN 1 END) AS from_device
+FROM sessions
+WHERE user_id = ?;
+
+-- name: DeleteSessionsEndedBefore :exec
+DELETE FROM sessions
+WHERE ended_at < ?;
+
+-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = ?;
+
+-- name: GetSessionIps :many
+SELECT DISTINCT ip FROM sessions;
+
+-- name: SetSessionIp :exec
+UPDATE sessions SET ip = sqlc.arg(network)
+WHERE ip = sqlc.arg(ip);
+
+-- name: GetUserSessions :many
+SELECT * FROM sessions
+WHERE user_id = ?
+ORDER BY id;
+
+-- name: AddWorldStats :exec
+INSERT INTO world_stats (
+    day, players_total, peak_players, items_created, items_destroyed, currency_supply, updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (day) DO UPDATE SET
+    players_total = MAX(players_total, excluded.players_total),
+    peak_players = MAX(peak_players, excluded.peak_players),
+    items_created = items_created + excluded.items_created,
+    items_destroyed = items_destroyed + excluded.items_destroyed,
+    currency_supply = excluded.currency_supply,
+    updated_at = excluded.updated_at;
+
+-- name: GetWorldStats :many
+SELECT * FROM world_stats
+WHERE day >= ?
+ORDER BY day
+LIMIT ?;
+
+-- name: GetPlayersEver :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS players FROM players;
+
+-- name: GetCurrencySupply :one
+SELECT CAST(
+    (SELECT COALESCE(SUM(quantity), 0) FROM inventory_items WHERE inventory_items.item_id = sqlc.arg(item_id))
+    + (SELECT COALESCE(SUM(quantity), 0) FROM mail WHERE mail.item_id = sqlc.arg(item_id))
+AS INTEGER) AS supply;
+
+-- name: GetNegativeInventoryItems :many
+SELECT * FROM inventory_items
+WHERE quantity < 0
+ORDER BY player_id, item_id
+LIMIT ?;
+
+-- name: DeleteNegativeInventoryItem :execrows
+DELETE FROM inventory_items
+WHERE player_id = ? AND item_id = ? AND quantity < 0;
+
+-- name: CreateConsistencyReport :one
+INSERT INTO consistency_reports (
+    triggered_by, repair, players_checked, drift_count, report, started_at, finished_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetConsistencyReports :many
+SELECT id, triggered_by, repair, players_checked, drift_count, started_at, finished_at FROM consistency_reports
+ORDER BY id DESC
+LIMIT ?;
+
+-- name: GetConsistencyReport :one
+SELECT * FROM consistency_reports
+WHERE id = ? LIMIT 1;
+
+-- name: DeleteConsistencyReportsBefore :exec
+DELETE FROM consistency_reports
+WHERE finished_at < ?;
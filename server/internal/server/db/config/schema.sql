@@ -11,4 +11,414 @@ CREATE TABLE IF NOT EXISTS players (
     best_score INTEGER NOT NULL DEFAULT 0,
     color INTEGER NOT NULL,
     FOREIGN KEY (user_id) REFERENCES users(id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS bans (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    expires_at INTEGER,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS inventory_items (
+    player_id INTEGER NOT NULL,
+    item_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    PRIMARY KEY (player_id, item_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS blocks (
+    player_id INTEGER NOT NULL,
+    blocked_player_id INTEGER NOT NULL,
+    PRIMARY KEY (player_id, blocked_player_id),
+    FOREIGN KEY (player_id) REFERENCES players(id),
+    FOREIGN KEY (blocked_player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS heatmaps (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    zone_id TEXT NOT NULL,
+    taken_at INTEGER NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    cells BLOB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS heatmaps_zone_id_taken_at ON heatmaps (zone_id, taken_at);
+
+CREATE TABLE IF NOT EXISTS onboarding_steps (
+    player_id INTEGER NOT NULL,
+    step_id TEXT NOT NULL,
+    completed_at INTEGER NOT NULL,
+    PRIMARY KEY (player_id, step_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS unlocked_emotes (
+    player_id INTEGER NOT NULL,
+    emote_id TEXT NOT NULL,
+    unlocked_at INTEGER NOT NULL,
+    PRIMARY KEY (player_id, emote_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS auction_listings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    seller_id INTEGER NOT NULL,
+    item_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    price INTEGER NOT NULL,
+    listed_at INTEGER NOT NULL,
+    expires_at INTEGER NOT NULL,
+    buyer_id INTEGER,
+    sold_at INTEGER,
+    settled_at INTEGER,
+    FOREIGN KEY (seller_id) REFERENCES players(id),
+    FOREIGN KEY (buyer_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS auction_listings_item_id_price ON auction_listings (item_id, price);
+
+CREATE TABLE IF NOT EXISTS mail (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL,
+    sender TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    sent_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS admin_audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    admin TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target TEXT NOT NULL,
+    detail TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS player_logins (
+    player_id INTEGER PRIMARY KEY,
+    last_login_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS bulk_mail_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    admin TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    item_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    min_best_score INTEGER NOT NULL,
+    max_best_score INTEGER NOT NULL,
+    last_login_after INTEGER NOT NULL,
+    last_login_before INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    total INTEGER NOT NULL,
+    sent INTEGER NOT NULL DEFAULT 0,
+    last_player_id INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    finished_at INTEGER
+);
+
+CREATE TABLE IF NOT EXISTS reserved_names (
+    name TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    reserved_by TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+-- Names released from inactive accounts, so whoever had one can be told what their account's called now when they next
+-- try to log in with it
+CREATE TABLE IF NOT EXISTS released_names (
+    name TEXT NOT NULL,
+    user_id INTEGER NOT NULL,
+    released_at INTEGER NOT NULL,
+    PRIMARY KEY (name, user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id TEXT PRIMARY KEY,
+    enabled BOOLEAN NOT NULL,
+    rollout_percent INTEGER NOT NULL,
+    capability TEXT NOT NULL,
+    updated_by TEXT NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL,
+    requested_by TEXT NOT NULL,
+    token TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL,
+    bundle BLOB,
+    requested_at INTEGER NOT NULL,
+    ready_at INTEGER,
+    expires_at INTEGER,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_player_id ON data_exports (player_id);
+
+CREATE TABLE IF NOT EXISTS account_deletions (
+    player_id INTEGER PRIMARY KEY,
+    requested_by TEXT NOT NULL,
+    requested_at INTEGER NOT NULL,
+    delete_at INTEGER NOT NULL,
+    deleted_at INTEGER,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS voice_mutes (
+    player_id INTEGER PRIMARY KEY,
+    muted_by TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS player_positions (
+    player_id INTEGER PRIMARY KEY,
+    x REAL NOT NULL,
+    y REAL NOT NULL,
+    saved_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS bandwidth_usage (
+    player_id INTEGER NOT NULL,
+    day TEXT NOT NULL,
+    sessions INTEGER NOT NULL,
+    bytes_in INTEGER NOT NULL,
+    bytes_out INTEGER NOT NULL,
+    packets_in INTEGER NOT NULL,
+    packets_out INTEGER NOT NULL,
+    PRIMARY KEY (player_id, day),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS player_versions (
+    player_id INTEGER PRIMARY KEY,
+    version INTEGER NOT NULL,
+    claimed_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS store_receipts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id INTEGER NOT NULL,
+    entry_id TEXT NOT NULL,
+    platform TEXT NOT NULL,
+    receipt TEXT NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    resolved_at INTEGER,
+    UNIQUE (platform, receipt),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+CREATE TABLE IF NOT EXISTS jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    status TEXT NOT NULL,
+    every_ms INTEGER NOT NULL,
+    attempts INTEGER NOT NULL,
+    runs INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    run_at INTEGER NOT NULL,
+    started_at INTEGER,
+    finished_at INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS jobs_status_run_at ON jobs (status, run_at);
+
+-- Each recurring kind of job has the one row, which is rescheduled after each run
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_recurring_kind ON jobs (kind) WHERE every_ms > 0;
+
+CREATE TABLE IF NOT EXISTS hiscore_ranks (
+    player_id INTEGER PRIMARY KEY,
+    rank INTEGER NOT NULL,
+    best_score INTEGER NOT NULL,
+    ranked_at INTEGER NOT NULL,
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+CREATE TABLE IF NOT EXISTS entitlements (
+    user_id INTEGER NOT NULL,
+    entitlement_id TEXT NOT NULL,
+    source TEXT NOT NULL,
+    granted_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, entitlement_id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- How far each player is through each quest, which dialogs check and move along. Quests not started have no row.
+CREATE TABLE IF NOT EXISTS quest_stages (
+    player_id INTEGER NOT NULL,
+    quest_id TEXT NOT NULL,
+    stage INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (player_id, quest_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+
+-- Every announcement made to the whole server, so players who were offline can catch up on what they missed
+CREATE TABLE IF NOT EXISTS announcements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    author TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+
+-- Players reported by other players, with what the reported player was doing around then, for moderators to look into
+CREATE TABLE IF NOT EXISTS moderation_cases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    reporter_id INTEGER NOT NULL,
+    reporter_name TEXT NOT NULL,
+    reported_id INTEGER NOT NULL,
+    reported_name TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    snapshot TEXT NOT NULL,
+    status TEXT NOT NULL,
+    resolution TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    closed_at INTEGER,
+    FOREIGN KEY (reporter_id) REFERENCES players(id),
+    FOREIGN KEY (reported_id) REFERENCES players(id)
+);
+
+-- What's been said in each channel of each zone, kept for as long as the retention policy says so moderators can look
+-- back on it
+CREATE TABLE IF NOT EXISTS chat_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    zone_id TEXT NOT NULL,
+    channel INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    sender_name TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS chat_messages_zone_id_created_at ON chat_messages (zone_id, created_at);
+CREATE INDEX IF NOT EXISTS chat_messages_created_at ON chat_messages (created_at);
+
+-- Coins moved between players, so each account's transfers over the last day can be capped, with what made any look
+-- like duping or real money trading. Transfers refused for going over a cap are kept too, as they're suspicious
+-- themselves, but don't count towards it.
+CREATE TABLE IF NOT EXISTS currency_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    player_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    counterparty_id INTEGER NOT NULL,
+    refused BOOLEAN NOT NULL,
+    flag TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS currency_transfers_user_id_created_at ON currency_transfers (user_id, created_at);
+CREATE INDEX IF NOT EXISTS currency_transfers_created_at ON currency_transfers (created_at);
+
+-- When each character last looted each boss, so they can't loot it again until its lockout resets. Bosses never
+-- looted have no row.
+CREATE TABLE IF NOT EXISTS boss_lockouts (
+    player_id INTEGER NOT NULL,
+    boss_id TEXT NOT NULL,
+    looted_at INTEGER NOT NULL,
+    PRIMARY KEY (player_id, boss_id),
+    FOREIGN KEY (player_id) REFERENCES players(id)
+);
+CREATE INDEX IF NOT EXISTS boss_lockouts_looted_at ON boss_lockouts (looted_at);
+
+-- Which version of each welcome step each account accepted, and when, kept for every version as a record of what was
+-- agreed to until the account's deleted
+CREATE TABLE IF NOT EXISTS welcome_acceptances (
+    user_id INTEGER NOT NULL,
+    step_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    accepted_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, step_id, version),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- Keys for the public API, each only allowed what its scopes (comma separated) are for and rate limited on its own.
+-- Only a hash of each key is kept, so a lost key can't be looked up again, only revoked and issued anew.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    requests_per_minute INTEGER NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    revoked_at INTEGER
+);
+CREATE INDEX IF NOT EXISTS auction_listings_sold_at ON auction_listings (sold_at);
+
+-- Where each account has logged in from, as hashes of the IP addresses, so logins from anywhere new can be challenged
+CREATE TABLE IF NOT EXISTS login_ips (
+    user_id INTEGER NOT NULL,
+    ip_hash TEXT NOT NULL,
+    last_login_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, ip_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- Each time an account's been logged in, from where and on what, so players can see where they're logged in and log
+-- out anywhere they don't recognise. Only the network each IP's on is kept, and ended sessions only for a while.
+CREATE TABLE IF NOT EXISTS sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    ip TEXT NOT NULL,
+    device TEXT NOT NULL,
+    client_build INTEGER NOT NULL,
+    logged_in_at INTEGER NOT NULL,
+    ended_at INTEGER,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX IF NOT EXISTS sessions_user_id ON sessions (user_id);
+
+-- Long-term statistics of the whole world, rolled up by UTC day, for graphing how it's grown. What's counted as it
+-- happens is added to the day's row every so often, and the rest is as it was when the row was last updated.
+CREATE TABLE IF NOT EXISTS world_stats (
+    day TEXT PRIMARY KEY,
+    players_total INTEGER NOT NULL,
+    peak_players INTEGER NOT NULL,
+    items_created INTEGER NOT NULL,
+    items_destroyed INTEGER NOT NULL,
+    currency_supply INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- What each consistency check found drifting between players' live state and what's saved, and whether it was
+-- repaired. The drift itself is kept as the JSON report admins download.
+CREATE TABLE IF NOT EXISTS consistency_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    triggered_by TEXT NOT NULL,
+    repair BOOLEAN NOT NULL,
+    players_checked INTEGER NOT NULL,
+    drift_count INTEGER NOT NULL,
+    report BLOB NOT NULL,
+    started_at INTEGER NOT NULL,
+    finished_at INTEGER NOT NULL
+);
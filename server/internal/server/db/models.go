@@ -4,6 +4,249 @@
 
 package db
 
+import (
+	"database/sql"
+)
+
+type AccountDeletion struct {
+	PlayerID    int64
+	RequestedBy string
+	RequestedAt int64
+	DeleteAt    int64
+	DeletedAt   sql.NullInt64
+}
+
+type AdminAuditLog struct {
+	ID        int64
+	Admin     string
+	Action    string
+	Target    string
+	Detail    string
+	CreatedAt int64
+}
+
+type Announcement struct {
+	ID        int64
+	Author    string
+	Message   string
+	CreatedAt int64
+}
+
+type ApiKey struct {
+	ID                int64
+	Name              string
+	Prefix            string
+	KeyHash           string
+	Scopes            string
+	RequestsPerMinute int64
+	CreatedBy         string
+	CreatedAt         int64
+	RevokedAt         sql.NullInt64
+}
+
+type AuctionListing struct {
+	ID        int64
+	SellerID  int64
+	ItemID    string
+	Quantity  int64
+	Price     int64
+	ListedAt  int64
+	ExpiresAt int64
+	BuyerID   sql.NullInt64
+	SoldAt    sql.NullInt64
+	SettledAt sql.NullInt64
+}
+
+type Ban struct {
+	ID        int64
+	UserID    int64
+	Reason    string
+	CreatedAt int64
+	ExpiresAt sql.NullInt64
+}
+
+type BandwidthUsage struct {
+	PlayerID   int64
+	Day        string
+	Sessions   int64
+	BytesIn    int64
+	BytesOut   int64
+	PacketsIn  int64
+	PacketsOut int64
+}
+
+type Block struct {
+	PlayerID        int64
+	BlockedPlayerID int64
+}
+
+type BossLockout struct {
+	PlayerID int64
+	BossID   string
+	LootedAt int64
+}
+
+type BulkMailJob struct {
+	ID              int64
+	Admin           string
+	Sender          string
+	Subject         string
+	ItemID          string
+	Quantity        int64
+	MinBestScore    int64
+	MaxBestScore    int64
+	LastLoginAfter  int64
+	LastLoginBefore int64
+	Status          string
+	Total           int64
+	Sent            int64
+	LastPlayerID    int64
+	CreatedAt       int64
+	FinishedAt      sql.NullInt64
+}
+
+type ChatMessage struct {
+	ID         int64
+	ZoneID     string
+	Channel    int64
+	UserID     int64
+	PlayerID   int64
+	SenderName string
+	Message    string
+	CreatedAt  int64
+}
+
+type ConsistencyReport struct {
+	ID             int64
+	TriggeredBy    string
+	Repair         bool
+	PlayersChecked int64
+	DriftCount     int64
+	Report         []byte
+	StartedAt      int64
+	FinishedAt     int64
+}
+
+type CurrencyTransfer struct {
+	ID             int64
+	UserID         int64
+	PlayerID       int64
+	Kind           string
+	Amount         int64
+	CounterpartyID int64
+	Refused        bool
+	Flag           string
+	CreatedAt      int64
+}
+
+type DataExport struct {
+	ID          int64
+	PlayerID    int64
+	RequestedBy string
+	Token       string
+	Status      string
+	Bundle      []byte
+	RequestedAt int64
+	ReadyAt     sql.NullInt64
+	ExpiresAt   sql.NullInt64
+}
+
+type Entitlement struct {
+	UserID        int64
+	EntitlementID string
+	Source        string
+	GrantedAt     int64
+}
+
+type FeatureFlag struct {
+	ID             string
+	Enabled        bool
+	RolloutPercent int64
+	Capability     string
+	UpdatedBy      string
+	UpdatedAt      int64
+}
+
+type Heatmap struct {
+	ID      int64
+	ZoneID  string
+	TakenAt int64
+	Width   int64
+	Height  int64
+	Cells   []byte
+}
+
+type HiscoreRank struct {
+	PlayerID  int64
+	Rank      int64
+	BestScore int64
+	RankedAt  int64
+}
+
+type InventoryItem struct {
+	PlayerID int64
+	ItemID   string
+	Quantity int64
+}
+
+type Job struct {
+	ID         int64
+	Kind       string
+	Payload    []byte
+	Status     string
+	EveryMs    int64
+	Attempts   int64
+	Runs       int64
+	LastError  string
+	CreatedAt  int64
+	RunAt      int64
+	StartedAt  sql.NullInt64
+	FinishedAt sql.NullInt64
+}
+
+type LoginIp struct {
+	UserID      int64
+	IpHash      string
+	LastLoginAt int64
+}
+
+type Mail struct {
+	ID       int64
+	PlayerID int64
+	Sender   string
+	Subject  string
+	ItemID   string
+	Quantity int64
+	SentAt   int64
+}
+
+type ModerationCase struct {
+	ID           int64
+	ReporterID   int64
+	ReporterName string
+	ReportedID   int64
+	ReportedName string
+	Reason       string
+	Snapshot     string
+	Status       string
+	Resolution   string
+	CreatedAt    int64
+	ClosedAt     sql.NullInt64
+}
+
+type OnboardingStep struct {
+	PlayerID    int64
+	StepID      string
+	CompletedAt int64
+}
+
+type OutboxEvent struct {
+	ID        int64
+	Topic     string
+	Payload   []byte
+	CreatedAt int64
+}
+
 type Player struct {
 	ID        int64
 	UserID    int64
@@ -12,8 +255,100 @@ type Player struct {
 	Color     int64
 }
 
+type PlayerLogin struct {
+	PlayerID    int64
+	LastLoginAt int64
+}
+
+type PlayerPosition struct {
+	PlayerID int64
+	X        float64
+	Y        float64
+	SavedAt  int64
+}
+
+type PlayerVersion struct {
+	PlayerID  int64
+	Version   int64
+	ClaimedAt int64
+}
+
+type QuestStage struct {
+	PlayerID  int64
+	QuestID   string
+	Stage     int64
+	UpdatedAt int64
+}
+
+type ReleasedName struct {
+	Name       string
+	UserID     int64
+	ReleasedAt int64
+}
+
+type ReservedName struct {
+	Name       string
+	Kind       string
+	Reason     string
+	ReservedBy string
+	CreatedAt  int64
+}
+
+type Session struct {
+	ID          int64
+	UserID      int64
+	Ip          string
+	Device      string
+	ClientBuild int64
+	LoggedInAt  int64
+	EndedAt     sql.NullInt64
+}
+
+type StoreReceipt struct {
+	ID         int64
+	PlayerID   int64
+	EntryID    string
+	Platform   string
+	Receipt    string
+	Status     string
+	Reason     string
+	CreatedAt  int64
+	ResolvedAt sql.NullInt64
+}
+
+type UnlockedEmote struct {
+	PlayerID   int64
+	EmoteID    string
+	UnlockedAt int64
+}
+
 type User struct {
 	ID           int64
 	Username     string
 	PasswordHash string
 }
+
+type VoiceMute struct {
+	PlayerID  int64
+	MutedBy   string
+	Reason    string
+	ExpiresAt int64
+	CreatedAt int64
+}
+
+type WelcomeAcceptance struct {
+	UserID     int64
+	StepID     string
+	Version    int64
+	AcceptedAt int64
+}
+
+type WorldStat struct {
+	Day            string
+	PlayersTotal   int64
+	PeakPlayers    int64
+	ItemsCreated   int64
+	ItemsDestroyed int64
+	CurrencySupply int64
+	UpdatedAt      int64
+}
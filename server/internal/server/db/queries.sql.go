@@ -7,137 +7,4755 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
+const acceptWelcomeStep = `-- name: AcceptWelcomeStep :exec
+INSERT INTO welcome_acceptances (
+    user_id, step_id, version, accepted_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (user_id, step_id, version) DO NOTHING
+`
+
+type AcceptWelcomeStepParams struct {
+	UserID     int64
+	StepID     string
+	Version    int64
+	AcceptedAt int64
+}
+
+func (q *Queries) AcceptWelcomeStep(ctx context.Context, arg AcceptWelcomeStepParams) error {
+	_, err := q.db.ExecContext(ctx, acceptWelcomeStep,
+		arg.UserID,
+		arg.StepID,
+		arg.Version,
+		arg.AcceptedAt,
+	)
+	return err
+}
+
+const addBandwidthUsage = `-- name: AddBandwidthUsage :exec
+INSERT INTO bandwidth_usage (
+    player_id, day, sessions, bytes_in, bytes_out, packets_in, packets_out
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (player_id, day) DO UPDATE SET
+    sessions = sessions + excluded.sessions,
+    bytes_in = bytes_in + excluded.bytes_in,
+    bytes_out = bytes_out + excluded.bytes_out,
+    packets_in = packets_in + excluded.packets_in,
+    packets_out = packets_out + excluded.packets_out
+`
+
+type AddBandwidthUsageParams struct {
+	PlayerID   int64
+	Day        string
+	Sessions   int64
+	BytesIn    int64
+	BytesOut   int64
+	PacketsIn  int64
+	PacketsOut int64
+}
+
+func (q *Queries) AddBandwidthUsage(ctx context.Context, arg AddBandwidthUsageParams) error {
+	_, err := q.db.ExecContext(ctx, addBandwidthUsage,
+		arg.PlayerID,
+		arg.Day,
+		arg.Sessions,
+		arg.BytesIn,
+		arg.BytesOut,
+		arg.PacketsIn,
+		arg.PacketsOut,
+	)
+	return err
+}
+
+const addInventoryItem = `-- name: AddInventoryItem :exec
+INSERT INTO inventory_items (
+    player_id, item_id, quantity
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (player_id, item_id) DO UPDATE SET quantity = quantity + excluded.quantity
+`
+
+type AddInventoryItemParams struct {
+	PlayerID int64
+	ItemID   string
+	Quantity int64
+}
+
+func (q *Queries) AddInventoryItem(ctx context.Context, arg AddInventoryItemParams) error {
+	_, err := q.db.ExecContext(ctx, addInventoryItem, arg.PlayerID, arg.ItemID, arg.Quantity)
+	return err
+}
+
+const addWorldStats = `-- name: AddWorldStats :exec
+INSERT INTO world_stats (
+    day, players_total, peak_players, items_created, items_destroyed, currency_supply, updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (day) DO UPDATE SET
+    players_total = MAX(players_total, excluded.players_total),
+    peak_players = MAX(peak_players, excluded.peak_players),
+    items_created = items_created + excluded.items_created,
+    items_destroyed = items_destroyed + excluded.items_destroyed,
+    currency_supply = excluded.currency_supply,
+    updated_at = excluded.updated_at
+`
+
+type AddWorldStatsParams struct {
+	Day            string
+	PlayersTotal   int64
+	PeakPlayers    int64
+	ItemsCreated   int64
+	ItemsDestroyed int64
+	CurrencySupply int64
+	UpdatedAt      int64
+}
+
+func (q *Queries) AddWorldStats(ctx context.Context, arg AddWorldStatsParams) error {
+	_, err := q.db.ExecContext(ctx, addWorldStats,
+		arg.Day,
+		arg.PlayersTotal,
+		arg.PeakPlayers,
+		arg.ItemsCreated,
+		arg.ItemsDestroyed,
+		arg.CurrencySupply,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const anonymizeModerationCaseReported = `-- name: AnonymizeModerationCaseReported :exec
+UPDATE moderation_cases
+SET reported_name = ?
+WHERE reported_id = ?
+`
+
+type AnonymizeModerationCaseReportedParams struct {
+	ReportedName string
+	ReportedID   int64
+}
+
+func (q *Queries) AnonymizeModerationCaseReported(ctx context.Context, arg AnonymizeModerationCaseReportedParams) error {
+	_, err := q.db.ExecContext(ctx, anonymizeModerationCaseReported, arg.ReportedName, arg.ReportedID)
+	return err
+}
+
+const anonymizeModerationCaseReporter = `-- name: AnonymizeModerationCaseReporter :exec
+UPDATE moderation_cases
+SET reporter_name = ?
+WHERE reporter_id = ?
+`
+
+type AnonymizeModerationCaseReporterParams struct {
+	ReporterName string
+	ReporterID   int64
+}
+
+func (q *Queries) AnonymizeModerationCaseReporter(ctx context.Context, arg AnonymizeModerationCaseReporterParams) error {
+	_, err := q.db.ExecContext(ctx, anonymizeModerationCaseReporter, arg.ReporterName, arg.ReporterID)
+	return err
+}
+
+const anonymizePlayer = `-- name: AnonymizePlayer :exec
+UPDATE players
+SET name = ?, best_score = 0, color = 0
+WHERE id = ?
+`
+
+type AnonymizePlayerParams struct {
+	Name string
+	ID   int64
+}
+
+func (q *Queries) AnonymizePlayer(ctx context.Context, arg AnonymizePlayerParams) error {
+	_, err := q.db.ExecContext(ctx, anonymizePlayer, arg.Name, arg.ID)
+	return err
+}
+
+const anonymizeUser = `-- name: AnonymizeUser :exec
+UPDATE users
+SET username = ?, password_hash = ''
+WHERE id = ?
+`
+
+type AnonymizeUserParams struct {
+	Username string
+	ID       int64
+}
+
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) error {
+	_, err := q.db.ExecContext(ctx, anonymizeUser, arg.Username, arg.ID)
+	return err
+}
+
+const buyAuctionListing = `-- name: BuyAuctionListing :execrows
+UPDATE auction_listings
+SET buyer_id = ?, sold_at = ?
+WHERE id = ? AND buyer_id IS NULL AND settled_at IS NULL AND expires_at > ? AND seller_id != ?
+`
+
+type BuyAuctionListingParams struct {
+	BuyerID   sql.NullInt64
+	SoldAt    sql.NullInt64
+	ID        int64
+	ExpiresAt int64
+	SellerID  int64
+}
+
+func (q *Queries) BuyAuctionListing(ctx context.Context, arg BuyAuctionListingParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, buyAuctionListing,
+		arg.BuyerID,
+		arg.SoldAt,
+		arg.ID,
+		arg.ExpiresAt,
+		arg.SellerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cancelAccountDeletion = `-- name: CancelAccountDeletion :execrows
+DELETE FROM account_deletions
+WHERE player_id = ? AND deleted_at IS NULL
+`
+
+func (q *Queries) CancelAccountDeletion(ctx context.Context, playerID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelAccountDeletion, playerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const checkPlayerVersion = `-- name: CheckPlayerVersion :execrows
+UPDATE player_versions SET version = version
+WHERE player_id = ? AND version = ?
+`
+
+type CheckPlayerVersionParams struct {
+	PlayerID int64
+	Version  int64
+}
+
+func (q *Queries) CheckPlayerVersion(ctx context.Context, arg CheckPlayerVersionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, checkPlayerVersion, arg.PlayerID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimMail = `-- name: ClaimMail :one
+DELETE FROM mail
+WHERE id = ? AND player_id = ?
+RETURNING id, player_id, sender, subject, item_id, quantity, sent_at
+`
+
+type ClaimMailParams struct {
+	ID       int64
+	PlayerID int64
+}
+
+func (q *Queries) ClaimMail(ctx context.Context, arg ClaimMailParams) (Mail, error) {
+	row := q.db.QueryRowContext(ctx, claimMail, arg.ID, arg.PlayerID)
+	var i Mail
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.Sender,
+		&i.Subject,
+		&i.ItemID,
+		&i.Quantity,
+		&i.SentAt,
+	)
+	return i, err
+}
+
+const claimPlayerVersion = `-- name: ClaimPlayerVersion :one
+INSERT INTO player_versions (
+    player_id, version, claimed_at
+) VALUES (
+    ?, 1, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    version = version + 1,
+    claimed_at = excluded.claimed_at
+RETURNING version
+`
+
+type ClaimPlayerVersionParams struct {
+	PlayerID  int64
+	ClaimedAt int64
+}
+
+func (q *Queries) ClaimPlayerVersion(ctx context.Context, arg ClaimPlayerVersionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, claimPlayerVersion, arg.PlayerID, arg.ClaimedAt)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const closeModerationCase = `-- name: CloseModerationCase :execrows
+UPDATE moderation_cases
+SET status = 'closed', resolution = ?, closed_at = ?
+WHERE id = ? AND status = 'open'
+`
+
+type CloseModerationCaseParams struct {
+	Resolution string
+	ClosedAt   sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) CloseModerationCase(ctx context.Context, arg CloseModerationCaseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, closeModerationCase,
+		arg.Resolution,
+		arg.ClosedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const completeDataExport = `-- name: CompleteDataExport :exec
+UPDATE data_exports
+SET status = 'ready', bundle = ?, ready_at = ?, expires_at = ?
+WHERE id = ?
+`
+
+type CompleteDataExportParams struct {
+	Bundle    []byte
+	ReadyAt   sql.NullInt64
+	ExpiresAt sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) CompleteDataExport(ctx context.Context, arg CompleteDataExportParams) error {
+	_, err := q.db.ExecContext(ctx, completeDataExport,
+		arg.Bundle,
+		arg.ReadyAt,
+		arg.ExpiresAt,
+		arg.ID,
+	)
+	return err
+}
+
+const completeOnboardingStep = `-- name: CompleteOnboardingStep :exec
+INSERT OR IGNORE INTO onboarding_steps (
+    player_id, step_id, completed_at
+) VALUES (
+    ?, ?, ?
+)
+`
+
+type CompleteOnboardingStepParams struct {
+	PlayerID    int64
+	StepID      string
+	CompletedAt int64
+}
+
+func (q *Queries) CompleteOnboardingStep(ctx context.Context, arg CompleteOnboardingStepParams) error {
+	_, err := q.db.ExecContext(ctx, completeOnboardingStep, arg.PlayerID, arg.StepID, arg.CompletedAt)
+	return err
+}
+
+const countAnnouncements = `-- name: CountAnnouncements :one
+SELECT count(*) FROM announcements
+WHERE created_at >= ?
+`
+
+func (q *Queries) CountAnnouncements(ctx context.Context, createdAt int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAnnouncements, createdAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countAuctionListings = `-- name: CountAuctionListings :one
+SELECT count(*) FROM auction_listings
+WHERE buyer_id IS NULL AND expires_at > ? AND item_id LIKE ? ESCAPE '!'
+`
+
+type CountAuctionListingsParams struct {
+	ExpiresAt int64
+	ItemID    string
+}
+
+func (q *Queries) CountAuctionListings(ctx context.Context, arg CountAuctionListingsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuctionListings, arg.ExpiresAt, arg.ItemID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countBulkMailRecipients = `-- name: CountBulkMailRecipients :one
+SELECT COUNT(*) FROM players
+LEFT JOIN player_logins ON player_logins.player_id = players.id
+WHERE players.best_score BETWEEN ?1 AND ?2
+    AND COALESCE(player_logins.last_login_at, 0) BETWEEN ?3 AND ?4
+`
+
+type CountBulkMailRecipientsParams struct {
+	MinBestScore    int64
+	MaxBestScore    int64
+	LastLoginAfter  int64
+	LastLoginBefore int64
+}
+
+func (q *Queries) CountBulkMailRecipients(ctx context.Context, arg CountBulkMailRecipientsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBulkMailRecipients,
+		arg.MinBestScore,
+		arg.MaxBestScore,
+		arg.LastLoginAfter,
+		arg.LastLoginBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPlayersByUserId = `-- name: CountPlayersByUserId :one
+SELECT COUNT(*) FROM players
+WHERE user_id = ?
+`
+
+func (q *Queries) CountPlayersByUserId(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPlayersByUserId, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSessionsFrom = `-- name: CountSessionsFrom :one
+SELECT
+    COUNT(*) AS sessions,
+    COUNT(CASE WHEN ip = ? THEN 1 END) AS from_ip,
+    COUNT(CASE WHEN device = ? THEN 1 END) AS from_device
+FROM sessions
+WHERE user_id = ?
+`
+
+type CountSessionsFromParams struct {
+	Ip     string
+	Device string
+	UserID int64
+}
+
+type CountSessionsFromRow struct {
+	Sessions   int64
+	FromIp     int64
+	FromDevice int64
+}
+
+func (q *Queries) CountSessionsFrom(ctx context.Context, arg CountSessionsFromParams) (CountSessionsFromRow, error) {
+	row := q.db.QueryRowContext(ctx, countSessionsFrom,
+		arg.Ip,
+		arg.Device,
+		arg.UserID,
+	)
+	var i CountSessionsFromRow
+	err := row.Scan(
+		&i.Sessions,
+		&i.FromIp,
+		&i.FromDevice,
+	)
+	return i, err
+}
+
+const countStoreReceipts = `-- name: CountStoreReceipts :one
+SELECT COUNT(*) FROM store_receipts
+WHERE platform = ? AND receipt = ?
+`
+
+type CountStoreReceiptsParams struct {
+	Platform string
+	Receipt  string
+}
+
+func (q *Queries) CountStoreReceipts(ctx context.Context, arg CountStoreReceiptsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countStoreReceipts, arg.Platform, arg.Receipt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAccountDeletion = `-- name: CreateAccountDeletion :exec
+INSERT INTO account_deletions (
+    player_id, requested_by, requested_at, delete_at
+) VALUES (
+    ?, ?, ?, ?
+)
+`
+
+type CreateAccountDeletionParams struct {
+	PlayerID    int64
+	RequestedBy string
+	RequestedAt int64
+	DeleteAt    int64
+}
+
+func (q *Queries) CreateAccountDeletion(ctx context.Context, arg CreateAccountDeletionParams) error {
+	_, err := q.db.ExecContext(ctx, createAccountDeletion,
+		arg.PlayerID,
+		arg.RequestedBy,
+		arg.RequestedAt,
+		arg.DeleteAt,
+	)
+	return err
+}
+
+const createAnnouncement = `-- name: CreateAnnouncement :exec
+INSERT INTO announcements (
+    author, message, created_at
+) VALUES (
+    ?, ?, ?
+)
+`
+
+type CreateAnnouncementParams struct {
+	Author    string
+	Message   string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateAnnouncement(ctx context.Context, arg CreateAnnouncementParams) error {
+	_, err := q.db.ExecContext(ctx, createAnnouncement,
+		arg.Author,
+		arg.Message,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO api_keys (
+    name, prefix, key_hash, scopes, requests_per_minute, created_by, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id
+`
+
+type CreateApiKeyParams struct {
+	Name              string
+	Prefix            string
+	KeyHash           string
+	Scopes            string
+	RequestsPerMinute int64
+	CreatedBy         string
+	CreatedAt         int64
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createApiKey,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		arg.Scopes,
+		arg.RequestsPerMinute,
+		arg.CreatedBy,
+		arg.CreatedAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createAuctionListing = `-- name: CreateAuctionListing :one
+INSERT INTO auction_listings (
+    seller_id, item_id, quantity, price, listed_at, expires_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+RETURNING id
+`
+
+type CreateAuctionListingParams struct {
+	SellerID  int64
+	ItemID    string
+	Quantity  int64
+	Price     int64
+	ListedAt  int64
+	ExpiresAt int64
+}
+
+func (q *Queries) CreateAuctionListing(ctx context.Context, arg CreateAuctionListingParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createAuctionListing,
+		arg.SellerID,
+		arg.ItemID,
+		arg.Quantity,
+		arg.Price,
+		arg.ListedAt,
+		arg.ExpiresAt,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createAuditLogEntry = `-- name: CreateAuditLogEntry :exec
+INSERT INTO admin_audit_log (
+    admin, action, target, detail, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateAuditLogEntryParams struct {
+	Admin     string
+	Action    string
+	Target    string
+	Detail    string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateAuditLogEntry(ctx context.Context, arg CreateAuditLogEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditLogEntry,
+		arg.Admin,
+		arg.Action,
+		arg.Target,
+		arg.Detail,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createBan = `-- name: CreateBan :one
+INSERT INTO bans (
+    user_id, reason, created_at, expires_at
+) VALUES (
+    ?, ?, ?, ?
+)
+RETURNING id, user_id, reason, created_at, expires_at
+`
+
+type CreateBanParams struct {
+	UserID    int64
+	Reason    string
+	CreatedAt int64
+	ExpiresAt sql.NullInt64
+}
+
+func (q *Queries) CreateBan(ctx context.Context, arg CreateBanParams) (Ban, error) {
+	row := q.db.QueryRowContext(ctx, createBan,
+		arg.UserID,
+		arg.Reason,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var i Ban
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const createBlock = `-- name: CreateBlock :exec
+INSERT OR IGNORE INTO blocks (
+    player_id, blocked_player_id
+) VALUES (
+    ?, ?
+)
+`
+
+type CreateBlockParams struct {
+	PlayerID        int64
+	BlockedPlayerID int64
+}
+
+func (q *Queries) CreateBlock(ctx context.Context, arg CreateBlockParams) error {
+	_, err := q.db.ExecContext(ctx, createBlock, arg.PlayerID, arg.BlockedPlayerID)
+	return err
+}
+
+const createBulkMailJob = `-- name: CreateBulkMailJob :one
+INSERT INTO bulk_mail_jobs (
+    admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, 'queued', ?, ?
+)
+RETURNING id, admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, sent, last_player_id, created_at, finished_at
+`
+
+type CreateBulkMailJobParams struct {
+	Admin           string
+	Sender          string
+	Subject         string
+	ItemID          string
+	Quantity        int64
+	MinBestScore    int64
+	MaxBestScore    int64
+	LastLoginAfter  int64
+	LastLoginBefore int64
+	Total           int64
+	CreatedAt       int64
+}
+
+func (q *Queries) CreateBulkMailJob(ctx context.Context, arg CreateBulkMailJobParams) (BulkMailJob, error) {
+	row := q.db.QueryRowContext(ctx, createBulkMailJob,
+		arg.Admin,
+		arg.Sender,
+		arg.Subject,
+		arg.ItemID,
+		arg.Quantity,
+		arg.MinBestScore,
+		arg.MaxBestScore,
+		arg.LastLoginAfter,
+		arg.LastLoginBefore,
+		arg.Total,
+		arg.CreatedAt,
+	)
+	var i BulkMailJob
+	err := row.Scan(
+		&i.ID,
+		&i.Admin,
+		&i.Sender,
+		&i.Subject,
+		&i.ItemID,
+		&i.Quantity,
+		&i.MinBestScore,
+		&i.MaxBestScore,
+		&i.LastLoginAfter,
+		&i.LastLoginBefore,
+		&i.Status,
+		&i.Total,
+		&i.Sent,
+		&i.LastPlayerID,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const createChatMessage = `-- name: CreateChatMessage :exec
+INSERT INTO chat_messages (
+    zone_id, channel, user_id, player_id, sender_name, message, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateChatMessageParams struct {
+	ZoneID     string
+	Channel    int64
+	UserID     int64
+	PlayerID   int64
+	SenderName string
+	Message    string
+	CreatedAt  int64
+}
+
+func (q *Queries) CreateChatMessage(ctx context.Context, arg CreateChatMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createChatMessage,
+		arg.ZoneID,
+		arg.Channel,
+		arg.UserID,
+		arg.PlayerID,
+		arg.SenderName,
+		arg.Message,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createConsistencyReport = `-- name: CreateConsistencyReport :one
+INSERT INTO consistency_reports (
+    triggered_by, repair, players_checked, drift_count, report, started_at, finished_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING id, triggered_by, repair, players_checked, drift_count, report, started_at, finished_at
+`
+
+type CreateConsistencyReportParams struct {
+	TriggeredBy    string
+	Repair         bool
+	PlayersChecked int64
+	DriftCount     int64
+	Report         []byte
+	StartedAt      int64
+	FinishedAt     int64
+}
+
+func (q *Queries) CreateConsistencyReport(ctx context.Context, arg CreateConsistencyReportParams) (ConsistencyReport, error) {
+	row := q.db.QueryRowContext(ctx, createConsistencyReport,
+		arg.TriggeredBy,
+		arg.Repair,
+		arg.PlayersChecked,
+		arg.DriftCount,
+		arg.Report,
+		arg.StartedAt,
+		arg.FinishedAt,
+	)
+	var i ConsistencyReport
+	err := row.Scan(
+		&i.ID,
+		&i.TriggeredBy,
+		&i.Repair,
+		&i.PlayersChecked,
+		&i.DriftCount,
+		&i.Report,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const createCurrencyTransfer = `-- name: CreateCurrencyTransfer :exec
+INSERT INTO currency_transfers (
+    user_id, player_id, kind, amount, counterparty_id, refused, flag, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateCurrencyTransferParams struct {
+	UserID         int64
+	PlayerID       int64
+	Kind           string
+	Amount         int64
+	CounterpartyID int64
+	Refused        bool
+	Flag           string
+	CreatedAt      int64
+}
+
+func (q *Queries) CreateCurrencyTransfer(ctx context.Context, arg CreateCurrencyTransferParams) error {
+	_, err := q.db.ExecContext(ctx, createCurrencyTransfer,
+		arg.UserID,
+		arg.PlayerID,
+		arg.Kind,
+		arg.Amount,
+		arg.CounterpartyID,
+		arg.Refused,
+		arg.Flag,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createDataExport = `-- name: CreateDataExport :one
+INSERT INTO data_exports (
+    player_id, requested_by, token, status, requested_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING id, player_id, requested_by, token, status, bundle, requested_at, ready_at, expires_at
+`
+
+type CreateDataExportParams struct {
+	PlayerID    int64
+	RequestedBy string
+	Token       string
+	Status      string
+	RequestedAt int64
+}
+
+func (q *Queries) CreateDataExport(ctx context.Context, arg CreateDataExportParams) (DataExport, error) {
+	row := q.db.QueryRowContext(ctx, createDataExport,
+		arg.PlayerID,
+		arg.RequestedBy,
+		arg.Token,
+		arg.Status,
+		arg.RequestedAt,
+	)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.RequestedBy,
+		&i.Token,
+		&i.Status,
+		&i.Bundle,
+		&i.RequestedAt,
+		&i.ReadyAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const createHeatmap = `-- name: CreateHeatmap :exec
+INSERT INTO heatmaps (
+    zone_id, taken_at, width, height, cells
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateHeatmapParams struct {
+	ZoneID  string
+	TakenAt int64
+	Width   int64
+	Height  int64
+	Cells   []byte
+}
+
+func (q *Queries) CreateHeatmap(ctx context.Context, arg CreateHeatmapParams) error {
+	_, err := q.db.ExecContext(ctx, createHeatmap,
+		arg.ZoneID,
+		arg.TakenAt,
+		arg.Width,
+		arg.Height,
+		arg.Cells,
+	)
+	return err
+}
+
+const createJob = `-- name: CreateJob :one
+INSERT INTO jobs (
+    kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at
+) VALUES (
+    ?, ?, 'pending', 0, 0, 0, '', ?, ?
+)
+RETURNING id, kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at, started_at, finished_at
+`
+
+type CreateJobParams struct {
+	Kind      string
+	Payload   []byte
+	CreatedAt int64
+	RunAt     int64
+}
+
+func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) (Job, error) {
+	row := q.db.QueryRowContext(ctx, createJob,
+		arg.Kind,
+		arg.Payload,
+		arg.CreatedAt,
+		arg.RunAt,
+	)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.EveryMs,
+		&i.Attempts,
+		&i.Runs,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.RunAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const createMail = `-- name: CreateMail :exec
+INSERT INTO mail (
+    player_id, sender, subject, item_id, quantity, sent_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+`
+
+type CreateMailParams struct {
+	PlayerID int64
+	Sender   string
+	Subject  string
+	ItemID   string
+	Quantity int64
+	SentAt   int64
+}
+
+func (q *Queries) CreateMail(ctx context.Context, arg CreateMailParams) error {
+	_, err := q.db.ExecContext(ctx, createMail,
+		arg.PlayerID,
+		arg.Sender,
+		arg.Subject,
+		arg.ItemID,
+		arg.Quantity,
+		arg.SentAt,
+	)
+	return err
+}
+
+const createModerationCase = `-- name: CreateModerationCase :exec
+INSERT INTO moderation_cases (
+    reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?, 'open', '', ?
+)
+`
+
+type CreateModerationCaseParams struct {
+	ReporterID   int64
+	ReporterName string
+	ReportedID   int64
+	ReportedName string
+	Reason       string
+	Snapshot     string
+	CreatedAt    int64
+}
+
+func (q *Queries) CreateModerationCase(ctx context.Context, arg CreateModerationCaseParams) error {
+	_, err := q.db.ExecContext(ctx, createModerationCase,
+		arg.ReporterID,
+		arg.ReporterName,
+		arg.ReportedID,
+		arg.ReportedName,
+		arg.Reason,
+		arg.Snapshot,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :exec
+INSERT INTO outbox_events (
+    topic, payload, created_at
+) VALUES (
+    ?, ?, ?
+)
+`
+
+type CreateOutboxEventParams struct {
+	Topic     string
+	Payload   []byte
+	CreatedAt int64
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, createOutboxEvent, arg.Topic, arg.Payload, arg.CreatedAt)
+	return err
+}
+
 const createPlayer = `-- name: CreatePlayer :one
 INSERT INTO players (
     user_id, name, color
 ) VALUES (
     ?, ?, ?
 )
-RETURNING id, user_id, name, best_score, color
+RETURNING id, user_id, name, best_score, color
+`
+
+type CreatePlayerParams struct {
+	UserID int64
+	Name   string
+	Color  int64
+}
+
+func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Player, error) {
+	row := q.db.QueryRowContext(ctx, createPlayer, arg.UserID, arg.Name, arg.Color)
+	var i Player
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.BestScore,
+		&i.Color,
+	)
+	return i, err
+}
+
+const createReleasedName = `-- name: CreateReleasedName :exec
+INSERT INTO released_names (
+    name, user_id, released_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (name, user_id) DO UPDATE SET released_at = excluded.released_at
+`
+
+type CreateReleasedNameParams struct {
+	Name       string
+	UserID     int64
+	ReleasedAt int64
+}
+
+func (q *Queries) CreateReleasedName(ctx context.Context, arg CreateReleasedNameParams) error {
+	_, err := q.db.ExecContext(ctx, createReleasedName,
+		arg.Name,
+		arg.UserID,
+		arg.ReleasedAt,
+	)
+	return err
+}
+
+const createReservedName = `-- name: CreateReservedName :exec
+INSERT INTO reserved_names (
+    name, kind, reason, reserved_by, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+`
+
+type CreateReservedNameParams struct {
+	Name       string
+	Kind       string
+	Reason     string
+	ReservedBy string
+	CreatedAt  int64
+}
+
+func (q *Queries) CreateReservedName(ctx context.Context, arg CreateReservedNameParams) error {
+	_, err := q.db.ExecContext(ctx, createReservedName,
+		arg.Name,
+		arg.Kind,
+		arg.Reason,
+		arg.ReservedBy,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createSession = `-- name: CreateSession :one
+INSERT INTO sessions (
+    user_id, ip, device, client_build, logged_in_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+RETURNING id, user_id, ip, device, client_build, logged_in_at, ended_at
+`
+
+type CreateSessionParams struct {
+	UserID      int64
+	Ip          string
+	Device      string
+	ClientBuild int64
+	LoggedInAt  int64
+}
+
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, createSession,
+		arg.UserID,
+		arg.Ip,
+		arg.Device,
+		arg.ClientBuild,
+		arg.LoggedInAt,
+	)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Ip,
+		&i.Device,
+		&i.ClientBuild,
+		&i.LoggedInAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+const createStoreReceipt = `-- name: CreateStoreReceipt :one
+INSERT INTO store_receipts (
+    player_id, entry_id, platform, receipt, status, reason, created_at
+) VALUES (
+    ?, ?, ?, ?, 'pending', '', ?
+)
+RETURNING id, player_id, entry_id, platform, receipt, status, reason, created_at, resolved_at
+`
+
+type CreateStoreReceiptParams struct {
+	PlayerID  int64
+	EntryID   string
+	Platform  string
+	Receipt   string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateStoreReceipt(ctx context.Context, arg CreateStoreReceiptParams) (StoreReceipt, error) {
+	row := q.db.QueryRowContext(ctx, createStoreReceipt,
+		arg.PlayerID,
+		arg.EntryID,
+		arg.Platform,
+		arg.Receipt,
+		arg.CreatedAt,
+	)
+	var i StoreReceipt
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.EntryID,
+		&i.Platform,
+		&i.Receipt,
+		&i.Status,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (
+    username, password_hash
+) VALUES (
+    ?, ?
+)
+RETURNING id, username, password_hash
+`
+
+type CreateUserParams struct {
+	Username     string
+	PasswordHash string
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser, arg.Username, arg.PasswordHash)
+	var i User
+	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
+	return i, err
+}
+
+const deleteBansByUserId = `-- name: DeleteBansByUserId :exec
+DELETE FROM bans
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteBansByUserId(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteBansByUserId, userID)
+	return err
+}
+
+const deleteBlock = `-- name: DeleteBlock :exec
+DELETE FROM blocks
+WHERE player_id = ? AND blocked_player_id = ?
+`
+
+type DeleteBlockParams struct {
+	PlayerID        int64
+	BlockedPlayerID int64
+}
+
+func (q *Queries) DeleteBlock(ctx context.Context, arg DeleteBlockParams) error {
+	_, err := q.db.ExecContext(ctx, deleteBlock, arg.PlayerID, arg.BlockedPlayerID)
+	return err
+}
+
+const deleteBossLockoutsBefore = `-- name: DeleteBossLockoutsBefore :execrows
+DELETE FROM boss_lockouts
+WHERE looted_at < ?
+`
+
+func (q *Queries) DeleteBossLockoutsBefore(ctx context.Context, lootedAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBossLockoutsBefore, lootedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteChatMessagesBefore = `-- name: DeleteChatMessagesBefore :execrows
+DELETE FROM chat_messages
+WHERE created_at < ?
+`
+
+func (q *Queries) DeleteChatMessagesBefore(ctx context.Context, createdAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteChatMessagesBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteConsistencyReportsBefore = `-- name: DeleteConsistencyReportsBefore :exec
+DELETE FROM consistency_reports
+WHERE finished_at < ?
+`
+
+func (q *Queries) DeleteConsistencyReportsBefore(ctx context.Context, finishedAt int64) error {
+	_, err := q.db.ExecContext(ctx, deleteConsistencyReportsBefore, finishedAt)
+	return err
+}
+
+const deleteCurrencyTransfersBefore = `-- name: DeleteCurrencyTransfersBefore :execrows
+DELETE FROM currency_transfers
+WHERE created_at < ? AND (flag = '' OR created_at < ?)
+`
+
+type DeleteCurrencyTransfersBeforeParams struct {
+	CreatedAt   int64
+	CreatedAt_2 int64
+}
+
+func (q *Queries) DeleteCurrencyTransfersBefore(ctx context.Context, arg DeleteCurrencyTransfersBeforeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCurrencyTransfersBefore, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFeatureFlag = `-- name: DeleteFeatureFlag :execrows
+DELETE FROM feature_flags
+WHERE id = ?
+`
+
+func (q *Queries) DeleteFeatureFlag(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFeatureFlag, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFinishedJobs = `-- name: DeleteFinishedJobs :execrows
+DELETE FROM jobs
+WHERE every_ms = 0 AND status IN ('done', 'failed') AND finished_at < ?
+`
+
+func (q *Queries) DeleteFinishedJobs(ctx context.Context, finishedAt sql.NullInt64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFinishedJobs, finishedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteHiscoreRanks = `-- name: DeleteHiscoreRanks :exec
+DELETE FROM hiscore_ranks
+`
+
+func (q *Queries) DeleteHiscoreRanks(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deleteHiscoreRanks)
+	return err
+}
+
+const deleteMailSentBefore = `-- name: DeleteMailSentBefore :execrows
+DELETE FROM mail
+WHERE sent_at < ?
+`
+
+func (q *Queries) DeleteMailSentBefore(ctx context.Context, sentAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMailSentBefore, sentAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteNegativeInventoryItem = `-- name: DeleteNegativeInventoryItem :execrows
+DELETE FROM inventory_items
+WHERE player_id = ? AND item_id = ? AND quantity < 0
+`
+
+type DeleteNegativeInventoryItemParams struct {
+	PlayerID int64
+	ItemID   string
+}
+
+func (q *Queries) DeleteNegativeInventoryItem(ctx context.Context, arg DeleteNegativeInventoryItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteNegativeInventoryItem, arg.PlayerID, arg.ItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteOutboxEvent = `-- name: DeleteOutboxEvent :exec
+DELETE FROM outbox_events
+WHERE id = ?
+`
+
+func (q *Queries) DeleteOutboxEvent(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, deleteOutboxEvent, id)
+	return err
+}
+
+const deletePlayerBlocks = `-- name: DeletePlayerBlocks :exec
+DELETE FROM blocks
+WHERE player_id = ? OR blocked_player_id = ?
+`
+
+type DeletePlayerBlocksParams struct {
+	PlayerID        int64
+	BlockedPlayerID int64
+}
+
+func (q *Queries) DeletePlayerBlocks(ctx context.Context, arg DeletePlayerBlocksParams) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerBlocks, arg.PlayerID, arg.BlockedPlayerID)
+	return err
+}
+
+const deletePlayerBossLockouts = `-- name: DeletePlayerBossLockouts :exec
+DELETE FROM boss_lockouts
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerBossLockouts(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerBossLockouts, playerID)
+	return err
+}
+
+const deletePlayerChatMessages = `-- name: DeletePlayerChatMessages :exec
+DELETE FROM chat_messages
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerChatMessages(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerChatMessages, playerID)
+	return err
+}
+
+const deletePlayerCurrencyTransfers = `-- name: DeletePlayerCurrencyTransfers :exec
+DELETE FROM currency_transfers
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerCurrencyTransfers(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerCurrencyTransfers, playerID)
+	return err
+}
+
+const deletePlayerDataExports = `-- name: DeletePlayerDataExports :exec
+DELETE FROM data_exports
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerDataExports(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerDataExports, playerID)
+	return err
+}
+
+const deletePlayerEmotes = `-- name: DeletePlayerEmotes :exec
+DELETE FROM unlocked_emotes
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerEmotes(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerEmotes, playerID)
+	return err
+}
+
+const deletePlayerInventory = `-- name: DeletePlayerInventory :exec
+DELETE FROM inventory_items
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerInventory(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerInventory, playerID)
+	return err
+}
+
+const deletePlayerLogin = `-- name: DeletePlayerLogin :exec
+DELETE FROM player_logins
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerLogin(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerLogin, playerID)
+	return err
+}
+
+const deletePlayerMail = `-- name: DeletePlayerMail :exec
+DELETE FROM mail
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerMail(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerMail, playerID)
+	return err
+}
+
+const deletePlayerOnboardingSteps = `-- name: DeletePlayerOnboardingSteps :exec
+DELETE FROM onboarding_steps
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerOnboardingSteps(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerOnboardingSteps, playerID)
+	return err
+}
+
+const deletePlayerPosition = `-- name: DeletePlayerPosition :exec
+DELETE FROM player_positions
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerPosition(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerPosition, playerID)
+	return err
+}
+
+const deletePlayerQuestStages = `-- name: DeletePlayerQuestStages :exec
+DELETE FROM quest_stages
+WHERE player_id = ?
+`
+
+func (q *Queries) DeletePlayerQuestStages(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerQuestStages, playerID)
+	return err
+}
+
+const deleteReservedName = `-- name: DeleteReservedName :execrows
+DELETE FROM reserved_names
+WHERE name = ?
+`
+
+func (q *Queries) DeleteReservedName(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteReservedName, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSessionsEndedBefore = `-- name: DeleteSessionsEndedBefore :exec
+DELETE FROM sessions
+WHERE ended_at < ?
+`
+
+func (q *Queries) DeleteSessionsEndedBefore(ctx context.Context, endedAt sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionsEndedBefore, endedAt)
+	return err
+}
+
+const deleteUnsoldAuctionListings = `-- name: DeleteUnsoldAuctionListings :exec
+DELETE FROM auction_listings
+WHERE seller_id = ? AND buyer_id IS NULL
+`
+
+func (q *Queries) DeleteUnsoldAuctionListings(ctx context.Context, sellerID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUnsoldAuctionListings, sellerID)
+	return err
+}
+
+const deleteUserEntitlements = `-- name: DeleteUserEntitlements :exec
+DELETE FROM entitlements
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserEntitlements(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserEntitlements, userID)
+	return err
+}
+
+const deleteUserLoginIps = `-- name: DeleteUserLoginIps :exec
+DELETE FROM login_ips
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserLoginIps(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserLoginIps, userID)
+	return err
+}
+
+const deleteUserReleasedNames = `-- name: DeleteUserReleasedNames :exec
+DELETE FROM released_names
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserReleasedNames(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserReleasedNames, userID)
+	return err
+}
+
+const deleteUserSessions = `-- name: DeleteUserSessions :exec
+DELETE FROM sessions
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserSessions(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserSessions, userID)
+	return err
+}
+
+const deleteUserWelcomeAcceptances = `-- name: DeleteUserWelcomeAcceptances :exec
+DELETE FROM welcome_acceptances
+WHERE user_id = ?
+`
+
+func (q *Queries) DeleteUserWelcomeAcceptances(ctx context.Context, userID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteUserWelcomeAcceptances, userID)
+	return err
+}
+
+const deleteVoiceMute = `-- name: DeleteVoiceMute :execrows
+DELETE FROM voice_mutes
+WHERE player_id = ?
+`
+
+func (q *Queries) DeleteVoiceMute(ctx context.Context, playerID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteVoiceMute, playerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const endOpenSessions = `-- name: EndOpenSessions :exec
+UPDATE sessions SET ended_at = ?
+WHERE ended_at IS NULL
+`
+
+func (q *Queries) EndOpenSessions(ctx context.Context, endedAt sql.NullInt64) error {
+	_, err := q.db.ExecContext(ctx, endOpenSessions, endedAt)
+	return err
+}
+
+const endSession = `-- name: EndSession :exec
+UPDATE sessions SET ended_at = ?
+WHERE id = ? AND ended_at IS NULL
+`
+
+type EndSessionParams struct {
+	EndedAt sql.NullInt64
+	ID      int64
+}
+
+func (q *Queries) EndSession(ctx context.Context, arg EndSessionParams) error {
+	_, err := q.db.ExecContext(ctx, endSession, arg.EndedAt, arg.ID)
+	return err
+}
+
+const expireDataExports = `-- name: ExpireDataExports :execrows
+UPDATE data_exports
+SET status = 'expired', bundle = NULL
+WHERE status = 'ready' AND expires_at <= ?
+`
+
+func (q *Queries) ExpireDataExports(ctx context.Context, expiresAt sql.NullInt64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, expireDataExports, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const finishAccountDeletion = `-- name: FinishAccountDeletion :exec
+UPDATE account_deletions
+SET deleted_at = ?
+WHERE player_id = ?
+`
+
+type FinishAccountDeletionParams struct {
+	DeletedAt sql.NullInt64
+	PlayerID  int64
+}
+
+func (q *Queries) FinishAccountDeletion(ctx context.Context, arg FinishAccountDeletionParams) error {
+	_, err := q.db.ExecContext(ctx, finishAccountDeletion, arg.DeletedAt, arg.PlayerID)
+	return err
+}
+
+const finishBulkMailJob = `-- name: FinishBulkMailJob :exec
+UPDATE bulk_mail_jobs
+SET status = ?, finished_at = ?
+WHERE id = ? AND status IN ('queued', 'running')
+`
+
+type FinishBulkMailJobParams struct {
+	Status     string
+	FinishedAt sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) FinishBulkMailJob(ctx context.Context, arg FinishBulkMailJobParams) error {
+	_, err := q.db.ExecContext(ctx, finishBulkMailJob, arg.Status, arg.FinishedAt, arg.ID)
+	return err
+}
+
+const finishJob = `-- name: FinishJob :exec
+UPDATE jobs
+SET status = ?, attempts = ?, last_error = ?, run_at = ?, finished_at = ?, runs = runs + 1
+WHERE id = ?
+`
+
+type FinishJobParams struct {
+	Status     string
+	Attempts   int64
+	LastError  string
+	RunAt      int64
+	FinishedAt sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) FinishJob(ctx context.Context, arg FinishJobParams) error {
+	_, err := q.db.ExecContext(ctx, finishJob,
+		arg.Status,
+		arg.Attempts,
+		arg.LastError,
+		arg.RunAt,
+		arg.FinishedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const getAccountDeletion = `-- name: GetAccountDeletion :one
+SELECT player_id, requested_by, requested_at, delete_at, deleted_at FROM account_deletions
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetAccountDeletion(ctx context.Context, playerID int64) (AccountDeletion, error) {
+	row := q.db.QueryRowContext(ctx, getAccountDeletion, playerID)
+	var i AccountDeletion
+	err := row.Scan(
+		&i.PlayerID,
+		&i.RequestedBy,
+		&i.RequestedAt,
+		&i.DeleteAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getActiveBan = `-- name: GetActiveBan :one
+SELECT id, user_id, reason, created_at, expires_at FROM bans
+WHERE user_id = ? AND (expires_at IS NULL OR expires_at > ?)
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type GetActiveBanParams struct {
+	UserID    int64
+	ExpiresAt sql.NullInt64
+}
+
+func (q *Queries) GetActiveBan(ctx context.Context, arg GetActiveBanParams) (Ban, error) {
+	row := q.db.QueryRowContext(ctx, getActiveBan, arg.UserID, arg.ExpiresAt)
+	var i Ban
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getAnnouncements = `-- name: GetAnnouncements :many
+SELECT id, author, message, created_at FROM announcements
+WHERE created_at >= ?
+ORDER BY id DESC
+LIMIT ? OFFSET ?
+`
+
+type GetAnnouncementsParams struct {
+	CreatedAt int64
+	Limit     int64
+	Offset    int64
+}
+
+func (q *Queries) GetAnnouncements(ctx context.Context, arg GetAnnouncementsParams) ([]Announcement, error) {
+	rows, err := q.db.QueryContext(ctx, getAnnouncements,
+		arg.CreatedAt,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Announcement
+	for rows.Next() {
+		var i Announcement
+		if err := rows.Scan(
+			&i.ID,
+			&i.Author,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApiKeyByHash = `-- name: GetApiKeyByHash :one
+SELECT id, name, prefix, key_hash, scopes, requests_per_minute, created_by, created_at, revoked_at FROM api_keys
+WHERE key_hash = ? AND revoked_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.RequestsPerMinute,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getApiKeys = `-- name: GetApiKeys :many
+SELECT id, name, prefix, key_hash, scopes, requests_per_minute, created_by, created_at, revoked_at FROM api_keys
+ORDER BY id
+`
+
+func (q *Queries) GetApiKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, getApiKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			&i.Scopes,
+			&i.RequestsPerMinute,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuctionListing = `-- name: GetAuctionListing :one
+SELECT id, seller_id, item_id, quantity, price, listed_at, expires_at, buyer_id, sold_at, settled_at FROM auction_listings
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetAuctionListing(ctx context.Context, id int64) (AuctionListing, error) {
+	row := q.db.QueryRowContext(ctx, getAuctionListing, id)
+	var i AuctionListing
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.ItemID,
+		&i.Quantity,
+		&i.Price,
+		&i.ListedAt,
+		&i.ExpiresAt,
+		&i.BuyerID,
+		&i.SoldAt,
+		&i.SettledAt,
+	)
+	return i, err
+}
+
+const getAuctionSales = `-- name: GetAuctionSales :many
+SELECT item_id, quantity, price, sold_at FROM auction_listings
+WHERE buyer_id IS NOT NULL AND sold_at >= ? AND item_id LIKE ? ESCAPE '!'
+ORDER BY sold_at DESC, id DESC
+LIMIT ?
+`
+
+type GetAuctionSalesParams struct {
+	SoldAt sql.NullInt64
+	ItemID string
+	Limit  int64
+}
+
+type GetAuctionSalesRow struct {
+	ItemID   string
+	Quantity int64
+	Price    int64
+	SoldAt   sql.NullInt64
+}
+
+func (q *Queries) GetAuctionSales(ctx context.Context, arg GetAuctionSalesParams) ([]GetAuctionSalesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuctionSales,
+		arg.SoldAt,
+		arg.ItemID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuctionSalesRow
+	for rows.Next() {
+		var i GetAuctionSalesRow
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.Quantity,
+			&i.Price,
+			&i.SoldAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditLog = `-- name: GetAuditLog :many
+SELECT id, admin, action, target, detail, created_at FROM admin_audit_log
+ORDER BY id DESC
+LIMIT ?
+`
+
+func (q *Queries) GetAuditLog(ctx context.Context, limit int64) ([]AdminAuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditLog, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AdminAuditLog
+	for rows.Next() {
+		var i AdminAuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Admin,
+			&i.Action,
+			&i.Target,
+			&i.Detail,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBandwidthUsage = `-- name: GetBandwidthUsage :one
+SELECT player_id, day, sessions, bytes_in, bytes_out, packets_in, packets_out FROM bandwidth_usage
+WHERE player_id = ? AND day = ? LIMIT 1
+`
+
+type GetBandwidthUsageParams struct {
+	PlayerID int64
+	Day      string
+}
+
+func (q *Queries) GetBandwidthUsage(ctx context.Context, arg GetBandwidthUsageParams) (BandwidthUsage, error) {
+	row := q.db.QueryRowContext(ctx, getBandwidthUsage, arg.PlayerID, arg.Day)
+	var i BandwidthUsage
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Day,
+		&i.Sessions,
+		&i.BytesIn,
+		&i.BytesOut,
+		&i.PacketsIn,
+		&i.PacketsOut,
+	)
+	return i, err
+}
+
+const getBansByUserId = `-- name: GetBansByUserId :many
+SELECT id, user_id, reason, created_at, expires_at FROM bans
+WHERE user_id = ?
+ORDER BY created_at
+`
+
+func (q *Queries) GetBansByUserId(ctx context.Context, userID int64) ([]Ban, error) {
+	rows, err := q.db.QueryContext(ctx, getBansByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ban
+	for rows.Next() {
+		var i Ban
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockedPlayers = `-- name: GetBlockedPlayers :many
+SELECT p.id, p.name FROM blocks b
+JOIN players p ON p.id = b.blocked_player_id
+WHERE b.player_id = ?
+ORDER BY p.name
+`
+
+type GetBlockedPlayersRow struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) GetBlockedPlayers(ctx context.Context, playerID int64) ([]GetBlockedPlayersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlockedPlayers, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlockedPlayersRow
+	for rows.Next() {
+		var i GetBlockedPlayersRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBossLockouts = `-- name: GetBossLockouts :many
+SELECT player_id, boss_id, looted_at FROM boss_lockouts
+WHERE player_id = ?
+`
+
+func (q *Queries) GetBossLockouts(ctx context.Context, playerID int64) ([]BossLockout, error) {
+	rows, err := q.db.QueryContext(ctx, getBossLockouts, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BossLockout
+	for rows.Next() {
+		var i BossLockout
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.BossID,
+			&i.LootedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBulkMailJob = `-- name: GetBulkMailJob :one
+SELECT id, admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, sent, last_player_id, created_at, finished_at FROM bulk_mail_jobs
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetBulkMailJob(ctx context.Context, id int64) (BulkMailJob, error) {
+	row := q.db.QueryRowContext(ctx, getBulkMailJob, id)
+	var i BulkMailJob
+	err := row.Scan(
+		&i.ID,
+		&i.Admin,
+		&i.Sender,
+		&i.Subject,
+		&i.ItemID,
+		&i.Quantity,
+		&i.MinBestScore,
+		&i.MaxBestScore,
+		&i.LastLoginAfter,
+		&i.LastLoginBefore,
+		&i.Status,
+		&i.Total,
+		&i.Sent,
+		&i.LastPlayerID,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getBulkMailJobs = `-- name: GetBulkMailJobs :many
+SELECT id, admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, sent, last_player_id, created_at, finished_at FROM bulk_mail_jobs
+ORDER BY id DESC
+LIMIT ?
+`
+
+func (q *Queries) GetBulkMailJobs(ctx context.Context, limit int64) ([]BulkMailJob, error) {
+	rows, err := q.db.QueryContext(ctx, getBulkMailJobs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BulkMailJob
+	for rows.Next() {
+		var i BulkMailJob
+		if err := rows.Scan(
+			&i.ID,
+			&i.Admin,
+			&i.Sender,
+			&i.Subject,
+			&i.ItemID,
+			&i.Quantity,
+			&i.MinBestScore,
+			&i.MaxBestScore,
+			&i.LastLoginAfter,
+			&i.LastLoginBefore,
+			&i.Status,
+			&i.Total,
+			&i.Sent,
+			&i.LastPlayerID,
+			&i.CreatedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBulkMailRecipients = `-- name: GetBulkMailRecipients :many
+SELECT players.id FROM players
+LEFT JOIN player_logins ON player_logins.player_id = players.id
+WHERE players.id > ?1
+    AND players.best_score BETWEEN ?2 AND ?3
+    AND COALESCE(player_logins.last_login_at, 0) BETWEEN ?4 AND ?5
+ORDER BY players.id
+LIMIT ?6
+`
+
+type GetBulkMailRecipientsParams struct {
+	AfterID         int64
+	MinBestScore    int64
+	MaxBestScore    int64
+	LastLoginAfter  int64
+	LastLoginBefore int64
+	Limit           int64
+}
+
+func (q *Queries) GetBulkMailRecipients(ctx context.Context, arg GetBulkMailRecipientsParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getBulkMailRecipients,
+		arg.AfterID,
+		arg.MinBestScore,
+		arg.MaxBestScore,
+		arg.LastLoginAfter,
+		arg.LastLoginBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getChatMessages = `-- name: GetChatMessages :many
+SELECT id, zone_id, channel, user_id, player_id, sender_name, message, created_at FROM chat_messages
+WHERE zone_id = ? AND channel = ? AND created_at >= ? AND created_at < ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetChatMessagesParams struct {
+	ZoneID      string
+	Channel     int64
+	CreatedAt   int64
+	CreatedAt_2 int64
+	Limit       int64
+}
+
+func (q *Queries) GetChatMessages(ctx context.Context, arg GetChatMessagesParams) ([]ChatMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getChatMessages,
+		arg.ZoneID,
+		arg.Channel,
+		arg.CreatedAt,
+		arg.CreatedAt_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ZoneID,
+			&i.Channel,
+			&i.UserID,
+			&i.PlayerID,
+			&i.SenderName,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCompletedOnboardingSteps = `-- name: GetCompletedOnboardingSteps :many
+SELECT step_id FROM onboarding_steps
+WHERE player_id = ?
+`
+
+func (q *Queries) GetCompletedOnboardingSteps(ctx context.Context, playerID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getCompletedOnboardingSteps, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var step_id string
+		if err := rows.Scan(&step_id); err != nil {
+			return nil, err
+		}
+		items = append(items, step_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getConsistencyReport = `-- name: GetConsistencyReport :one
+SELECT id, triggered_by, repair, players_checked, drift_count, report, started_at, finished_at FROM consistency_reports
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetConsistencyReport(ctx context.Context, id int64) (ConsistencyReport, error) {
+	row := q.db.QueryRowContext(ctx, getConsistencyReport, id)
+	var i ConsistencyReport
+	err := row.Scan(
+		&i.ID,
+		&i.TriggeredBy,
+		&i.Repair,
+		&i.PlayersChecked,
+		&i.DriftCount,
+		&i.Report,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getConsistencyReports = `-- name: GetConsistencyReports :many
+SELECT id, triggered_by, repair, players_checked, drift_count, started_at, finished_at FROM consistency_reports
+ORDER BY id DESC
+LIMIT ?
+`
+
+type GetConsistencyReportsRow struct {
+	ID             int64
+	TriggeredBy    string
+	Repair         bool
+	PlayersChecked int64
+	DriftCount     int64
+	StartedAt      int64
+	FinishedAt     int64
+}
+
+func (q *Queries) GetConsistencyReports(ctx context.Context, limit int64) ([]GetConsistencyReportsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getConsistencyReports, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetConsistencyReportsRow
+	for rows.Next() {
+		var i GetConsistencyReportsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TriggeredBy,
+			&i.Repair,
+			&i.PlayersChecked,
+			&i.DriftCount,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCurrencySupply = `-- name: GetCurrencySupply :one
+SELECT CAST(
+    (SELECT COALESCE(SUM(quantity), 0) FROM inventory_items WHERE inventory_items.item_id = ?1)
+    + (SELECT COALESCE(SUM(quantity), 0) FROM mail WHERE mail.item_id = ?1)
+AS INTEGER) AS supply
+`
+
+func (q *Queries) GetCurrencySupply(ctx context.Context, itemID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCurrencySupply, itemID)
+	var supply int64
+	err := row.Scan(&supply)
+	return supply, err
+}
+
+const getCurrencyTransferVolume = `-- name: GetCurrencyTransferVolume :one
+SELECT CAST(COALESCE(SUM(amount), 0) AS INTEGER) AS volume FROM currency_transfers
+WHERE user_id = ? AND NOT refused AND created_at >= ?
+`
+
+type GetCurrencyTransferVolumeParams struct {
+	UserID    int64
+	CreatedAt int64
+}
+
+func (q *Queries) GetCurrencyTransferVolume(ctx context.Context, arg GetCurrencyTransferVolumeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCurrencyTransferVolume, arg.UserID, arg.CreatedAt)
+	var volume int64
+	err := row.Scan(&volume)
+	return volume, err
+}
+
+const getDataExportByToken = `-- name: GetDataExportByToken :one
+SELECT id, player_id, requested_by, token, status, bundle, requested_at, ready_at, expires_at FROM data_exports
+WHERE token = ? LIMIT 1
+`
+
+func (q *Queries) GetDataExportByToken(ctx context.Context, token string) (DataExport, error) {
+	row := q.db.QueryRowContext(ctx, getDataExportByToken, token)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.RequestedBy,
+		&i.Token,
+		&i.Status,
+		&i.Bundle,
+		&i.RequestedAt,
+		&i.ReadyAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getDueAccountDeletions = `-- name: GetDueAccountDeletions :many
+SELECT player_id, requested_by, requested_at, delete_at, deleted_at FROM account_deletions
+WHERE deleted_at IS NULL AND delete_at <= ?
+ORDER BY delete_at
+LIMIT ?
+`
+
+type GetDueAccountDeletionsParams struct {
+	DeleteAt int64
+	Limit    int64
+}
+
+func (q *Queries) GetDueAccountDeletions(ctx context.Context, arg GetDueAccountDeletionsParams) ([]AccountDeletion, error) {
+	rows, err := q.db.QueryContext(ctx, getDueAccountDeletions, arg.DeleteAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccountDeletion
+	for rows.Next() {
+		var i AccountDeletion
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.RequestedBy,
+			&i.RequestedAt,
+			&i.DeleteAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDueJobs = `-- name: GetDueJobs :many
+SELECT id, kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at, started_at, finished_at FROM jobs
+WHERE status = 'pending' AND run_at <= ?
+ORDER BY run_at
+LIMIT ?
+`
+
+type GetDueJobsParams struct {
+	RunAt int64
+	Limit int64
+}
+
+func (q *Queries) GetDueJobs(ctx context.Context, arg GetDueJobsParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, getDueJobs, arg.RunAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.EveryMs,
+			&i.Attempts,
+			&i.Runs,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.RunAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEntitlements = `-- name: GetEntitlements :many
+SELECT user_id, entitlement_id, source, granted_at FROM entitlements
+WHERE user_id = ?
+ORDER BY granted_at, entitlement_id
+`
+
+func (q *Queries) GetEntitlements(ctx context.Context, userID int64) ([]Entitlement, error) {
+	rows, err := q.db.QueryContext(ctx, getEntitlements, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Entitlement
+	for rows.Next() {
+		var i Entitlement
+		if err := rows.Scan(
+			&i.UserID,
+			&i.EntitlementID,
+			&i.Source,
+			&i.GrantedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFeatureFlags = `-- name: GetFeatureFlags :many
+SELECT id, enabled, rollout_percent, capability, updated_by, updated_at FROM feature_flags
+ORDER BY id
+`
+
+func (q *Queries) GetFeatureFlags(ctx context.Context) ([]FeatureFlag, error) {
+	rows, err := q.db.QueryContext(ctx, getFeatureFlags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeatureFlag
+	for rows.Next() {
+		var i FeatureFlag
+		if err := rows.Scan(
+			&i.ID,
+			&i.Enabled,
+			&i.RolloutPercent,
+			&i.Capability,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFlaggedCurrencyTransfers = `-- name: GetFlaggedCurrencyTransfers :many
+SELECT id, user_id, player_id, kind, amount, counterparty_id, refused, flag, created_at FROM currency_transfers
+WHERE flag != '' AND created_at >= ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type GetFlaggedCurrencyTransfersParams struct {
+	CreatedAt int64
+	Limit     int64
+}
+
+func (q *Queries) GetFlaggedCurrencyTransfers(ctx context.Context, arg GetFlaggedCurrencyTransfersParams) ([]CurrencyTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getFlaggedCurrencyTransfers, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CurrencyTransfer
+	for rows.Next() {
+		var i CurrencyTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PlayerID,
+			&i.Kind,
+			&i.Amount,
+			&i.CounterpartyID,
+			&i.Refused,
+			&i.Flag,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHeatmaps = `-- name: GetHeatmaps :many
+SELECT id, zone_id, taken_at, width, height, cells FROM heatmaps
+WHERE zone_id = ? AND taken_at >= ?
+ORDER BY taken_at
+LIMIT ?
+`
+
+type GetHeatmapsParams struct {
+	ZoneID  string
+	TakenAt int64
+	Limit   int64
+}
+
+func (q *Queries) GetHeatmaps(ctx context.Context, arg GetHeatmapsParams) ([]Heatmap, error) {
+	rows, err := q.db.QueryContext(ctx, getHeatmaps, arg.ZoneID, arg.TakenAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Heatmap
+	for rows.Next() {
+		var i Heatmap
+		if err := rows.Scan(
+			&i.ID,
+			&i.ZoneID,
+			&i.TakenAt,
+			&i.Width,
+			&i.Height,
+			&i.Cells,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHiscoreRank = `-- name: GetHiscoreRank :one
+SELECT rank FROM hiscore_ranks
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetHiscoreRank(ctx context.Context, playerID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getHiscoreRank, playerID)
+	var rank int64
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getHiscores = `-- name: GetHiscores :many
+SELECT hiscore_ranks.rank, players.name, hiscore_ranks.best_score, hiscore_ranks.ranked_at
+FROM hiscore_ranks
+JOIN players ON players.id = hiscore_ranks.player_id
+ORDER BY hiscore_ranks.rank
+LIMIT ? OFFSET ?
+`
+
+type GetHiscoresParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type GetHiscoresRow struct {
+	Rank      int64
+	Name      string
+	BestScore int64
+	RankedAt  int64
+}
+
+func (q *Queries) GetHiscores(ctx context.Context, arg GetHiscoresParams) ([]GetHiscoresRow, error) {
+	rows, err := q.db.QueryContext(ctx, getHiscores, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetHiscoresRow
+	for rows.Next() {
+		var i GetHiscoresRow
+		if err := rows.Scan(
+			&i.Rank,
+			&i.Name,
+			&i.BestScore,
+			&i.RankedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getInventoryItems = `-- name: GetInventoryItems :many
+SELECT player_id, item_id, quantity FROM inventory_items
+WHERE player_id = ? AND quantity > 0
+`
+
+func (q *Queries) GetInventoryItems(ctx context.Context, playerID int64) ([]InventoryItem, error) {
+	rows, err := q.db.QueryContext(ctx, getInventoryItems, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InventoryItem
+	for rows.Next() {
+		var i InventoryItem
+		if err := rows.Scan(&i.PlayerID, &i.ItemID, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJob = `-- name: GetJob :one
+SELECT id, kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at, started_at, finished_at FROM jobs
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetJob(ctx context.Context, id int64) (Job, error) {
+	row := q.db.QueryRowContext(ctx, getJob, id)
+	var i Job
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Payload,
+		&i.Status,
+		&i.EveryMs,
+		&i.Attempts,
+		&i.Runs,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.RunAt,
+		&i.StartedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getJobs = `-- name: GetJobs :many
+SELECT id, kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at, started_at, finished_at FROM jobs
+ORDER BY id DESC
+LIMIT ?
+`
+
+func (q *Queries) GetJobs(ctx context.Context, limit int64) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, getJobs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.EveryMs,
+			&i.Attempts,
+			&i.Runs,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.RunAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getJobsByStatus = `-- name: GetJobsByStatus :many
+SELECT id, kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at, started_at, finished_at FROM jobs
+WHERE status = ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type GetJobsByStatusParams struct {
+	Status string
+	Limit  int64
+}
+
+func (q *Queries) GetJobsByStatus(ctx context.Context, arg GetJobsByStatusParams) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, getJobsByStatus, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.Payload,
+			&i.Status,
+			&i.EveryMs,
+			&i.Attempts,
+			&i.Runs,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.RunAt,
+			&i.StartedAt,
+			&i.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestDataExport = `-- name: GetLatestDataExport :one
+SELECT id, player_id, requested_by, token, status, bundle, requested_at, ready_at, expires_at FROM data_exports
+WHERE player_id = ?
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestDataExport(ctx context.Context, playerID int64) (DataExport, error) {
+	row := q.db.QueryRowContext(ctx, getLatestDataExport, playerID)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.RequestedBy,
+		&i.Token,
+		&i.Status,
+		&i.Bundle,
+		&i.RequestedAt,
+		&i.ReadyAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getLoginIpHashes = `-- name: GetLoginIpHashes :many
+SELECT ip_hash FROM login_ips
+WHERE user_id = ?
+`
+
+func (q *Queries) GetLoginIpHashes(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getLoginIpHashes, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var ip_hash string
+		if err := rows.Scan(&ip_hash); err != nil {
+			return nil, err
+		}
+		items = append(items, ip_hash)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMail = `-- name: GetMail :many
+SELECT id, player_id, sender, subject, item_id, quantity, sent_at FROM mail
+WHERE player_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetMail(ctx context.Context, playerID int64) ([]Mail, error) {
+	rows, err := q.db.QueryContext(ctx, getMail, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mail
+	for rows.Next() {
+		var i Mail
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlayerID,
+			&i.Sender,
+			&i.Subject,
+			&i.ItemID,
+			&i.Quantity,
+			&i.SentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMailById = `-- name: GetMailById :one
+SELECT id, player_id, sender, subject, item_id, quantity, sent_at FROM mail
+WHERE id = ? AND player_id = ?
+`
+
+type GetMailByIdParams struct {
+	ID       int64
+	PlayerID int64
+}
+
+func (q *Queries) GetMailById(ctx context.Context, arg GetMailByIdParams) (Mail, error) {
+	row := q.db.QueryRowContext(ctx, getMailById, arg.ID, arg.PlayerID)
+	var i Mail
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.Sender,
+		&i.Subject,
+		&i.ItemID,
+		&i.Quantity,
+		&i.SentAt,
+	)
+	return i, err
+}
+
+const getModerationCase = `-- name: GetModerationCase :one
+SELECT id, reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at, closed_at FROM moderation_cases
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetModerationCase(ctx context.Context, id int64) (ModerationCase, error) {
+	row := q.db.QueryRowContext(ctx, getModerationCase, id)
+	var i ModerationCase
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.ReporterName,
+		&i.ReportedID,
+		&i.ReportedName,
+		&i.Reason,
+		&i.Snapshot,
+		&i.Status,
+		&i.Resolution,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getModerationCasesByStatus = `-- name: GetModerationCasesByStatus :many
+SELECT id, reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at, closed_at FROM moderation_cases
+WHERE status = ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetModerationCasesByStatusParams struct {
+	Status string
+	Limit  int64
+}
+
+func (q *Queries) GetModerationCasesByStatus(ctx context.Context, arg GetModerationCasesByStatusParams) ([]ModerationCase, error) {
+	rows, err := q.db.QueryContext(ctx, getModerationCasesByStatus, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ModerationCase
+	for rows.Next() {
+		var i ModerationCase
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReporterID,
+			&i.ReporterName,
+			&i.ReportedID,
+			&i.ReportedName,
+			&i.Reason,
+			&i.Snapshot,
+			&i.Status,
+			&i.Resolution,
+			&i.CreatedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getModerationCasesMentioning = `-- name: GetModerationCasesMentioning :many
+SELECT id, reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at, closed_at FROM moderation_cases
+WHERE instr(snapshot, CAST(?1 AS TEXT)) > 0
+ORDER BY id
+`
+
+func (q *Queries) GetModerationCasesMentioning(ctx context.Context, name string) ([]ModerationCase, error) {
+	rows, err := q.db.QueryContext(ctx, getModerationCasesMentioning, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ModerationCase
+	for rows.Next() {
+		var i ModerationCase
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReporterID,
+			&i.ReporterName,
+			&i.ReportedID,
+			&i.ReportedName,
+			&i.Reason,
+			&i.Snapshot,
+			&i.Status,
+			&i.Resolution,
+			&i.CreatedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNegativeInventoryItems = `-- name: GetNegativeInventoryItems :many
+SELECT player_id, item_id, quantity FROM inventory_items
+WHERE quantity < 0
+ORDER BY player_id, item_id
+LIMIT ?
+`
+
+func (q *Queries) GetNegativeInventoryItems(ctx context.Context, limit int64) ([]InventoryItem, error) {
+	rows, err := q.db.QueryContext(ctx, getNegativeInventoryItems, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InventoryItem
+	for rows.Next() {
+		var i InventoryItem
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.ItemID,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNextBulkMailJob = `-- name: GetNextBulkMailJob :one
+SELECT id, admin, sender, subject, item_id, quantity, min_best_score, max_best_score, last_login_after, last_login_before, status, total, sent, last_player_id, created_at, finished_at FROM bulk_mail_jobs
+WHERE status IN ('queued', 'running')
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetNextBulkMailJob(ctx context.Context) (BulkMailJob, error) {
+	row := q.db.QueryRowContext(ctx, getNextBulkMailJob)
+	var i BulkMailJob
+	err := row.Scan(
+		&i.ID,
+		&i.Admin,
+		&i.Sender,
+		&i.Subject,
+		&i.ItemID,
+		&i.Quantity,
+		&i.MinBestScore,
+		&i.MaxBestScore,
+		&i.LastLoginAfter,
+		&i.LastLoginBefore,
+		&i.Status,
+		&i.Total,
+		&i.Sent,
+		&i.LastPlayerID,
+		&i.CreatedAt,
+		&i.FinishedAt,
+	)
+	return i, err
+}
+
+const getNextQueuedDataExport = `-- name: GetNextQueuedDataExport :one
+SELECT id, player_id, requested_by, token, status, bundle, requested_at, ready_at, expires_at FROM data_exports
+WHERE status = 'queued'
+ORDER BY id
+LIMIT 1
+`
+
+func (q *Queries) GetNextQueuedDataExport(ctx context.Context) (DataExport, error) {
+	row := q.db.QueryRowContext(ctx, getNextQueuedDataExport)
+	var i DataExport
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.RequestedBy,
+		&i.Token,
+		&i.Status,
+		&i.Bundle,
+		&i.RequestedAt,
+		&i.ReadyAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getOutboxEvents = `-- name: GetOutboxEvents :many
+SELECT id, topic, payload, created_at FROM outbox_events
+ORDER BY id
+LIMIT ?
+`
+
+func (q *Queries) GetOutboxEvents(ctx context.Context, limit int64) ([]OutboxEvent, error) {
+	rows, err := q.db.QueryContext(ctx, getOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Topic,
+			&i.Payload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayer = `-- name: GetPlayer :one
+SELECT id, user_id, name, best_score, color FROM players
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayer(ctx context.Context, id int64) (Player, error) {
+	row := q.db.QueryRowContext(ctx, getPlayer, id)
+	var i Player
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.BestScore,
+		&i.Color,
+	)
+	return i, err
+}
+
+const getPlayerAuctionListings = `-- name: GetPlayerAuctionListings :many
+SELECT id, seller_id, item_id, quantity, price, listed_at, expires_at, buyer_id, sold_at, settled_at FROM auction_listings
+WHERE seller_id = ? OR buyer_id = ?
+ORDER BY id
+`
+
+type GetPlayerAuctionListingsParams struct {
+	SellerID int64
+	BuyerID  sql.NullInt64
+}
+
+func (q *Queries) GetPlayerAuctionListings(ctx context.Context, arg GetPlayerAuctionListingsParams) ([]AuctionListing, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerAuctionListings, arg.SellerID, arg.BuyerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuctionListing
+	for rows.Next() {
+		var i AuctionListing
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.ItemID,
+			&i.Quantity,
+			&i.Price,
+			&i.ListedAt,
+			&i.ExpiresAt,
+			&i.BuyerID,
+			&i.SoldAt,
+			&i.SettledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerBandwidthUsage = `-- name: GetPlayerBandwidthUsage :many
+SELECT player_id, day, sessions, bytes_in, bytes_out, packets_in, packets_out FROM bandwidth_usage
+WHERE player_id = ?
+ORDER BY day DESC
+LIMIT ?
+`
+
+type GetPlayerBandwidthUsageParams struct {
+	PlayerID int64
+	Limit    int64
+}
+
+func (q *Queries) GetPlayerBandwidthUsage(ctx context.Context, arg GetPlayerBandwidthUsageParams) ([]BandwidthUsage, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerBandwidthUsage, arg.PlayerID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BandwidthUsage
+	for rows.Next() {
+		var i BandwidthUsage
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.Day,
+			&i.Sessions,
+			&i.BytesIn,
+			&i.BytesOut,
+			&i.PacketsIn,
+			&i.PacketsOut,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerByName = `-- name: GetPlayerByName :one
+SELECT id, user_id, name, best_score, color FROM players
+WHERE name LIKE ?
+LIMIT 1
+`
+
+func (q *Queries) GetPlayerByName(ctx context.Context, name string) (Player, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerByName, name)
+	var i Player
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.BestScore,
+		&i.Color,
+	)
+	return i, err
+}
+
+const getPlayerByUserId = `-- name: GetPlayerByUserId :one
+SELECT id, user_id, name, best_score, color FROM players
+WHERE user_id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayerByUserId(ctx context.Context, userID int64) (Player, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerByUserId, userID)
+	var i Player
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.BestScore,
+		&i.Color,
+	)
+	return i, err
+}
+
+const getPlayerChatMessages = `-- name: GetPlayerChatMessages :many
+SELECT id, zone_id, channel, user_id, player_id, sender_name, message, created_at FROM chat_messages
+WHERE player_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetPlayerChatMessages(ctx context.Context, playerID int64) ([]ChatMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerChatMessages, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ZoneID,
+			&i.Channel,
+			&i.UserID,
+			&i.PlayerID,
+			&i.SenderName,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerCurrencyTransfers = `-- name: GetPlayerCurrencyTransfers :many
+SELECT id, user_id, player_id, kind, amount, counterparty_id, refused, flag, created_at FROM currency_transfers
+WHERE player_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetPlayerCurrencyTransfers(ctx context.Context, playerID int64) ([]CurrencyTransfer, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerCurrencyTransfers, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CurrencyTransfer
+	for rows.Next() {
+		var i CurrencyTransfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PlayerID,
+			&i.Kind,
+			&i.Amount,
+			&i.CounterpartyID,
+			&i.Refused,
+			&i.Flag,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerLogin = `-- name: GetPlayerLogin :one
+SELECT player_id, last_login_at FROM player_logins
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayerLogin(ctx context.Context, playerID int64) (PlayerLogin, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerLogin, playerID)
+	var i PlayerLogin
+	err := row.Scan(&i.PlayerID, &i.LastLoginAt)
+	return i, err
+}
+
+const getPlayerModerationCases = `-- name: GetPlayerModerationCases :many
+SELECT id, reporter_id, reporter_name, reported_id, reported_name, reason, snapshot, status, resolution, created_at, closed_at FROM moderation_cases
+WHERE reporter_id = ?1 OR reported_id = ?1
+ORDER BY id
+`
+
+func (q *Queries) GetPlayerModerationCases(ctx context.Context, playerID int64) ([]ModerationCase, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerModerationCases, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ModerationCase
+	for rows.Next() {
+		var i ModerationCase
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReporterID,
+			&i.ReporterName,
+			&i.ReportedID,
+			&i.ReportedName,
+			&i.Reason,
+			&i.Snapshot,
+			&i.Status,
+			&i.Resolution,
+			&i.CreatedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerPosition = `-- name: GetPlayerPosition :one
+SELECT player_id, x, y, saved_at FROM player_positions
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayerPosition(ctx context.Context, playerID int64) (PlayerPosition, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerPosition, playerID)
+	var i PlayerPosition
+	err := row.Scan(
+		&i.PlayerID,
+		&i.X,
+		&i.Y,
+		&i.SavedAt,
+	)
+	return i, err
+}
+
+const getPlayerRank = `-- name: GetPlayerRank :one
+SELECT COUNT(*) + 1 as "rank" FROM players
+WHERE best_score >= (
+    SELECT best_score FROM players p2
+    WHERE p2.id = ?
+)
+`
+
+func (q *Queries) GetPlayerRank(ctx context.Context, id int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerRank, id)
+	var rank int64
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getPlayersBlocking = `-- name: GetPlayersBlocking :many
+SELECT p.name FROM blocks b
+JOIN players p ON p.id = b.player_id
+WHERE b.blocked_player_id = ?
+`
+
+func (q *Queries) GetPlayersBlocking(ctx context.Context, blockedPlayerID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayersBlocking, blockedPlayerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayersByUserId = `-- name: GetPlayersByUserId :many
+SELECT id, user_id, name, best_score, color FROM players
+WHERE user_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetPlayersByUserId(ctx context.Context, userID int64) ([]Player, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayersByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Player
+	for rows.Next() {
+		var i Player
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.BestScore,
+			&i.Color,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayersEver = `-- name: GetPlayersEver :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS players FROM players
+`
+
+func (q *Queries) GetPlayersEver(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPlayersEver)
+	var players int64
+	err := row.Scan(&players)
+	return players, err
+}
+
+const getPlayerStoreReceipts = `-- name: GetPlayerStoreReceipts :many
+SELECT id, player_id, entry_id, platform, receipt, status, reason, created_at, resolved_at FROM store_receipts
+WHERE player_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetPlayerStoreReceipts(ctx context.Context, playerID int64) ([]StoreReceipt, error) {
+	rows, err := q.db.QueryContext(ctx, getPlayerStoreReceipts, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StoreReceipt
+	for rows.Next() {
+		var i StoreReceipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlayerID,
+			&i.EntryID,
+			&i.Platform,
+			&i.Receipt,
+			&i.Status,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPlayerVersion = `-- name: GetPlayerVersion :one
+SELECT player_id, version, claimed_at FROM player_versions
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetPlayerVersion(ctx context.Context, playerID int64) (PlayerVersion, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerVersion, playerID)
+	var i PlayerVersion
+	err := row.Scan(
+		&i.PlayerID,
+		&i.Version,
+		&i.ClaimedAt,
+	)
+	return i, err
+}
+
+const getQuestStages = `-- name: GetQuestStages :many
+SELECT player_id, quest_id, stage, updated_at FROM quest_stages
+WHERE player_id = ?
+`
+
+func (q *Queries) GetQuestStages(ctx context.Context, playerID int64) ([]QuestStage, error) {
+	rows, err := q.db.QueryContext(ctx, getQuestStages, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuestStage
+	for rows.Next() {
+		var i QuestStage
+		if err := rows.Scan(
+			&i.PlayerID,
+			&i.QuestID,
+			&i.Stage,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRankForScore = `-- name: GetRankForScore :one
+SELECT COUNT(*) + 1 as "rank" FROM players
+WHERE best_score > ?
+`
+
+func (q *Queries) GetRankForScore(ctx context.Context, bestScore int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getRankForScore, bestScore)
+	var rank int64
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getRecentSessions = `-- name: GetRecentSessions :many
+SELECT id, user_id, ip, device, client_build, logged_in_at, ended_at FROM sessions
+WHERE user_id = ? AND (ended_at IS NULL OR ended_at >= ?)
+ORDER BY logged_in_at DESC, id DESC
+LIMIT ?
+`
+
+type GetRecentSessionsParams struct {
+	UserID  int64
+	EndedAt sql.NullInt64
+	Limit   int64
+}
+
+func (q *Queries) GetRecentSessions(ctx context.Context, arg GetRecentSessionsParams) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, getRecentSessions,
+		arg.UserID,
+		arg.EndedAt,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Ip,
+			&i.Device,
+			&i.ClientBuild,
+			&i.LoggedInAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReleasedNameUsers = `-- name: GetReleasedNameUsers :many
+SELECT users.id, users.username, users.password_hash FROM released_names
+JOIN users ON users.id = released_names.user_id
+WHERE released_names.name = ?
+ORDER BY released_names.released_at DESC
+`
+
+func (q *Queries) GetReleasedNameUsers(ctx context.Context, name string) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getReleasedNameUsers, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.PasswordHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReservedNameMatch = `-- name: GetReservedNameMatch :one
+SELECT name, kind, reason, reserved_by, created_at FROM reserved_names
+WHERE (kind = 'name' AND name = ?1) OR (kind = 'term' AND instr(?1, name) > 0)
+LIMIT 1
+`
+
+func (q *Queries) GetReservedNameMatch(ctx context.Context, name string) (ReservedName, error) {
+	row := q.db.QueryRowContext(ctx, getReservedNameMatch, name)
+	var i ReservedName
+	err := row.Scan(
+		&i.Name,
+		&i.Kind,
+		&i.Reason,
+		&i.ReservedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getReservedNames = `-- name: GetReservedNames :many
+SELECT name, kind, reason, reserved_by, created_at FROM reserved_names
+ORDER BY name
+`
+
+func (q *Queries) GetReservedNames(ctx context.Context) ([]ReservedName, error) {
+	rows, err := q.db.QueryContext(ctx, getReservedNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReservedName
+	for rows.Next() {
+		var i ReservedName
+		if err := rows.Scan(
+			&i.Name,
+			&i.Kind,
+			&i.Reason,
+			&i.ReservedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionIps = `-- name: GetSessionIps :many
+SELECT DISTINCT ip FROM sessions
+`
+
+func (q *Queries) GetSessionIps(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getSessionIps)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var ip string
+		if err := rows.Scan(&ip); err != nil {
+			return nil, err
+		}
+		items = append(items, ip)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStoreReceipt = `-- name: GetStoreReceipt :one
+SELECT id, player_id, entry_id, platform, receipt, status, reason, created_at, resolved_at FROM store_receipts
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetStoreReceipt(ctx context.Context, id int64) (StoreReceipt, error) {
+	row := q.db.QueryRowContext(ctx, getStoreReceipt, id)
+	var i StoreReceipt
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.EntryID,
+		&i.Platform,
+		&i.Receipt,
+		&i.Status,
+		&i.Reason,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const getStoreReceiptsByStatus = `-- name: GetStoreReceiptsByStatus :many
+SELECT id, player_id, entry_id, platform, receipt, status, reason, created_at, resolved_at FROM store_receipts
+WHERE status = ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetStoreReceiptsByStatusParams struct {
+	Status string
+	Limit  int64
+}
+
+func (q *Queries) GetStoreReceiptsByStatus(ctx context.Context, arg GetStoreReceiptsByStatusParams) ([]StoreReceipt, error) {
+	rows, err := q.db.QueryContext(ctx, getStoreReceiptsByStatus, arg.Status, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StoreReceipt
+	for rows.Next() {
+		var i StoreReceipt
+		if err := rows.Scan(
+			&i.ID,
+			&i.PlayerID,
+			&i.EntryID,
+			&i.Platform,
+			&i.Receipt,
+			&i.Status,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopBandwidthUsage = `-- name: GetTopBandwidthUsage :many
+SELECT p.name, b.sessions, b.bytes_in, b.bytes_out, b.packets_in, b.packets_out FROM bandwidth_usage b
+JOIN players p ON p.id = b.player_id
+WHERE b.day = ?
+ORDER BY b.bytes_out DESC
+LIMIT ?
+`
+
+type GetTopBandwidthUsageParams struct {
+	Day   string
+	Limit int64
+}
+
+type GetTopBandwidthUsageRow struct {
+	Name       string
+	Sessions   int64
+	BytesIn    int64
+	BytesOut   int64
+	PacketsIn  int64
+	PacketsOut int64
+}
+
+func (q *Queries) GetTopBandwidthUsage(ctx context.Context, arg GetTopBandwidthUsageParams) ([]GetTopBandwidthUsageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTopBandwidthUsage, arg.Day, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopBandwidthUsageRow
+	for rows.Next() {
+		var i GetTopBandwidthUsageRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Sessions,
+			&i.BytesIn,
+			&i.BytesOut,
+			&i.PacketsIn,
+			&i.PacketsOut,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopScores = `-- name: GetTopScores :many
+SELECT name, best_score
+FROM players
+ORDER BY best_score DESC
+LIMIT ?
+OFFSET ?
+`
+
+type GetTopScoresParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type GetTopScoresRow struct {
+	Name      string
+	BestScore int64
+}
+
+func (q *Queries) GetTopScores(ctx context.Context, arg GetTopScoresParams) ([]GetTopScoresRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTopScores, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopScoresRow
+	for rows.Next() {
+		var i GetTopScoresRow
+		if err := rows.Scan(&i.Name, &i.BestScore); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnlockedEmotes = `-- name: GetUnlockedEmotes :many
+SELECT emote_id FROM unlocked_emotes
+WHERE player_id = ?
+`
+
+func (q *Queries) GetUnlockedEmotes(ctx context.Context, playerID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getUnlockedEmotes, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var emote_id string
+		if err := rows.Scan(&emote_id); err != nil {
+			return nil, err
+		}
+		items = append(items, emote_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnsettledAuctionListings = `-- name: GetUnsettledAuctionListings :many
+SELECT id, seller_id, item_id, quantity, price, listed_at, expires_at, buyer_id, sold_at, settled_at FROM auction_listings
+WHERE settled_at IS NULL AND (buyer_id IS NOT NULL OR expires_at <= ?)
+ORDER BY id
+LIMIT ?
+`
+
+type GetUnsettledAuctionListingsParams struct {
+	ExpiresAt int64
+	Limit     int64
+}
+
+func (q *Queries) GetUnsettledAuctionListings(ctx context.Context, arg GetUnsettledAuctionListingsParams) ([]AuctionListing, error) {
+	rows, err := q.db.QueryContext(ctx, getUnsettledAuctionListings, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuctionListing
+	for rows.Next() {
+		var i AuctionListing
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.ItemID,
+			&i.Quantity,
+			&i.Price,
+			&i.ListedAt,
+			&i.ExpiresAt,
+			&i.BuyerID,
+			&i.SoldAt,
+			&i.SettledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, username, password_hash FROM users
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.PasswordHash,
+	)
+	return i, err
+}
+
+const getUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, username, password_hash FROM users
+WHERE username = ? LIMIT 1
+`
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
+	var i User
+	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
+	return i, err
+}
+
+const getUserLoginIps = `-- name: GetUserLoginIps :many
+SELECT user_id, ip_hash, last_login_at FROM login_ips
+WHERE user_id = ?
+ORDER BY last_login_at
+`
+
+func (q *Queries) GetUserLoginIps(ctx context.Context, userID int64) ([]LoginIp, error) {
+	rows, err := q.db.QueryContext(ctx, getUserLoginIps, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LoginIp
+	for rows.Next() {
+		var i LoginIp
+		if err := rows.Scan(
+			&i.UserID,
+			&i.IpHash,
+			&i.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserReleasedNames = `-- name: GetUserReleasedNames :many
+SELECT name FROM released_names
+WHERE user_id = ?
+ORDER BY released_at
+`
+
+func (q *Queries) GetUserReleasedNames(ctx context.Context, userID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getUserReleasedNames, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsers = `-- name: GetUsers :many
+SELECT id, username, password_hash FROM users
+ORDER BY id
+`
+
+func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Username, &i.PasswordHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserSessions = `-- name: GetUserSessions :many
+SELECT id, user_id, ip, device, client_build, logged_in_at, ended_at FROM sessions
+WHERE user_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetUserSessions(ctx context.Context, userID int64) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, getUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Session
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Ip,
+			&i.Device,
+			&i.ClientBuild,
+			&i.LoggedInAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserWelcomeAcceptances = `-- name: GetUserWelcomeAcceptances :many
+SELECT user_id, step_id, version, accepted_at FROM welcome_acceptances
+WHERE user_id = ?
+ORDER BY accepted_at, step_id, version
+`
+
+func (q *Queries) GetUserWelcomeAcceptances(ctx context.Context, userID int64) ([]WelcomeAcceptance, error) {
+	rows, err := q.db.QueryContext(ctx, getUserWelcomeAcceptances, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WelcomeAcceptance
+	for rows.Next() {
+		var i WelcomeAcceptance
+		if err := rows.Scan(
+			&i.UserID,
+			&i.StepID,
+			&i.Version,
+			&i.AcceptedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVoiceMute = `-- name: GetVoiceMute :one
+SELECT player_id, muted_by, reason, expires_at, created_at FROM voice_mutes
+WHERE player_id = ? LIMIT 1
+`
+
+func (q *Queries) GetVoiceMute(ctx context.Context, playerID int64) (VoiceMute, error) {
+	row := q.db.QueryRowContext(ctx, getVoiceMute, playerID)
+	var i VoiceMute
+	err := row.Scan(
+		&i.PlayerID,
+		&i.MutedBy,
+		&i.Reason,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWelcomeAcceptances = `-- name: GetWelcomeAcceptances :many
+SELECT step_id, CAST(MAX(version) AS INTEGER) AS version FROM welcome_acceptances
+WHERE user_id = ?
+GROUP BY step_id
+`
+
+type GetWelcomeAcceptancesRow struct {
+	StepID  string
+	Version int64
+}
+
+func (q *Queries) GetWelcomeAcceptances(ctx context.Context, userID int64) ([]GetWelcomeAcceptancesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWelcomeAcceptances, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWelcomeAcceptancesRow
+	for rows.Next() {
+		var i GetWelcomeAcceptancesRow
+		if err := rows.Scan(
+			&i.StepID,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorldStats = `-- name: GetWorldStats :many
+SELECT day, players_total, peak_players, items_created, items_destroyed, currency_supply, updated_at FROM world_stats
+WHERE day >= ?
+ORDER BY day
+LIMIT ?
+`
+
+type GetWorldStatsParams struct {
+	Day   string
+	Limit int64
+}
+
+func (q *Queries) GetWorldStats(ctx context.Context, arg GetWorldStatsParams) ([]WorldStat, error) {
+	rows, err := q.db.QueryContext(ctx, getWorldStats, arg.Day, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorldStat
+	for rows.Next() {
+		var i WorldStat
+		if err := rows.Scan(
+			&i.Day,
+			&i.PlayersTotal,
+			&i.PeakPlayers,
+			&i.ItemsCreated,
+			&i.ItemsDestroyed,
+			&i.CurrencySupply,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getZoneChatMessages = `-- name: GetZoneChatMessages :many
+SELECT id, zone_id, channel, user_id, player_id, sender_name, message, created_at FROM chat_messages
+WHERE zone_id = ? AND created_at >= ? AND created_at < ?
+ORDER BY id
+LIMIT ?
+`
+
+type GetZoneChatMessagesParams struct {
+	ZoneID      string
+	CreatedAt   int64
+	CreatedAt_2 int64
+	Limit       int64
+}
+
+func (q *Queries) GetZoneChatMessages(ctx context.Context, arg GetZoneChatMessagesParams) ([]ChatMessage, error) {
+	rows, err := q.db.QueryContext(ctx, getZoneChatMessages,
+		arg.ZoneID,
+		arg.CreatedAt,
+		arg.CreatedAt_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChatMessage
+	for rows.Next() {
+		var i ChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ZoneID,
+			&i.Channel,
+			&i.UserID,
+			&i.PlayerID,
+			&i.SenderName,
+			&i.Message,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const grantEntitlement = `-- name: GrantEntitlement :execrows
+INSERT INTO entitlements (
+    user_id, entitlement_id, source, granted_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (user_id, entitlement_id) DO NOTHING
+`
+
+type GrantEntitlementParams struct {
+	UserID        int64
+	EntitlementID string
+	Source        string
+	GrantedAt     int64
+}
+
+func (q *Queries) GrantEntitlement(ctx context.Context, arg GrantEntitlementParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, grantEntitlement,
+		arg.UserID,
+		arg.EntitlementID,
+		arg.Source,
+		arg.GrantedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const rankHiscores = `-- name: RankHiscores :exec
+INSERT INTO hiscore_ranks (
+    player_id, rank, best_score, ranked_at
+)
+SELECT id, ROW_NUMBER() OVER (ORDER BY best_score DESC, id), best_score, ?
+FROM players
+`
+
+func (q *Queries) RankHiscores(ctx context.Context, rankedAt int64) error {
+	_, err := q.db.ExecContext(ctx, rankHiscores, rankedAt)
+	return err
+}
+
+const recordLoginIp = `-- name: RecordLoginIp :exec
+INSERT INTO login_ips (
+    user_id, ip_hash, last_login_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (user_id, ip_hash) DO UPDATE SET last_login_at = excluded.last_login_at
+`
+
+type RecordLoginIpParams struct {
+	UserID      int64
+	IpHash      string
+	LastLoginAt int64
+}
+
+func (q *Queries) RecordLoginIp(ctx context.Context, arg RecordLoginIpParams) error {
+	_, err := q.db.ExecContext(ctx, recordLoginIp,
+		arg.UserID,
+		arg.IpHash,
+		arg.LastLoginAt,
+	)
+	return err
+}
+
+const recordPlayerLogin = `-- name: RecordPlayerLogin :exec
+INSERT INTO player_logins (
+    player_id, last_login_at
+) VALUES (
+    ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET last_login_at = excluded.last_login_at
+`
+
+type RecordPlayerLoginParams struct {
+	PlayerID    int64
+	LastLoginAt int64
+}
+
+func (q *Queries) RecordPlayerLogin(ctx context.Context, arg RecordPlayerLoginParams) error {
+	_, err := q.db.ExecContext(ctx, recordPlayerLogin, arg.PlayerID, arg.LastLoginAt)
+	return err
+}
+
+const rejectPendingStoreReceipts = `-- name: RejectPendingStoreReceipts :exec
+UPDATE store_receipts
+SET status = 'rejected', reason = ?, resolved_at = ?
+WHERE player_id = ? AND status = 'pending'
+`
+
+type RejectPendingStoreReceiptsParams struct {
+	Reason     string
+	ResolvedAt sql.NullInt64
+	PlayerID   int64
+}
+
+func (q *Queries) RejectPendingStoreReceipts(ctx context.Context, arg RejectPendingStoreReceiptsParams) error {
+	_, err := q.db.ExecContext(ctx, rejectPendingStoreReceipts,
+		arg.Reason,
+		arg.ResolvedAt,
+		arg.PlayerID,
+	)
+	return err
+}
+
+const removeInventoryItem = `-- name: RemoveInventoryItem :execrows
+UPDATE inventory_items
+SET quantity = quantity - ?1
+WHERE player_id = ?2 AND item_id = ?3 AND quantity >= ?1
+`
+
+type RemoveInventoryItemParams struct {
+	Quantity int64
+	PlayerID int64
+	ItemID   string
+}
+
+func (q *Queries) RemoveInventoryItem(ctx context.Context, arg RemoveInventoryItemParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeInventoryItem, arg.Quantity, arg.PlayerID, arg.ItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resetRunningJobs = `-- name: ResetRunningJobs :execrows
+UPDATE jobs
+SET status = 'pending'
+WHERE status = 'running'
+`
+
+func (q *Queries) ResetRunningJobs(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resetRunningJobs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resolveStoreReceipt = `-- name: ResolveStoreReceipt :execrows
+UPDATE store_receipts
+SET status = ?, reason = ?, resolved_at = ?
+WHERE id = ? AND status = 'pending'
+`
+
+type ResolveStoreReceiptParams struct {
+	Status     string
+	Reason     string
+	ResolvedAt sql.NullInt64
+	ID         int64
+}
+
+func (q *Queries) ResolveStoreReceipt(ctx context.Context, arg ResolveStoreReceiptParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, resolveStoreReceipt,
+		arg.Status,
+		arg.Reason,
+		arg.ResolvedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const retryJob = `-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'pending', attempts = 0, run_at = ?
+WHERE id = ? AND status = 'failed'
 `
 
-type CreatePlayerParams struct {
-	UserID int64
-	Name   string
-	Color  int64
+type RetryJobParams struct {
+	RunAt int64
+	ID    int64
 }
 
-func (q *Queries) CreatePlayer(ctx context.Context, arg CreatePlayerParams) (Player, error) {
-	row := q.db.QueryRowContext(ctx, createPlayer, arg.UserID, arg.Name, arg.Color)
-	var i Player
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Name,
-		&i.BestScore,
-		&i.Color,
-	)
-	return i, err
+func (q *Queries) RetryJob(ctx context.Context, arg RetryJobParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, retryJob, arg.RunAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const createUser = `-- name: CreateUser :one
-INSERT INTO users (
-    username, password_hash
-) VALUES (
-    ?, ?
-)
-RETURNING id, username, password_hash
+const revokeApiKey = `-- name: RevokeApiKey :execrows
+UPDATE api_keys
+SET revoked_at = ?
+WHERE id = ? AND revoked_at IS NULL
 `
 
-type CreateUserParams struct {
-	Username     string
-	PasswordHash string
+type RevokeApiKeyParams struct {
+	RevokedAt sql.NullInt64
+	ID        int64
 }
 
-func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, createUser, arg.Username, arg.PasswordHash)
-	var i User
-	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
-	return i, err
+func (q *Queries) RevokeApiKey(ctx context.Context, arg RevokeApiKeyParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeApiKey, arg.RevokedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getPlayerByName = `-- name: GetPlayerByName :one
-SELECT id, user_id, name, best_score, color FROM players
-WHERE name LIKE ?
-LIMIT 1
+const revokeEntitlement = `-- name: RevokeEntitlement :execrows
+DELETE FROM entitlements
+WHERE user_id = ? AND entitlement_id = ?
 `
 
-func (q *Queries) GetPlayerByName(ctx context.Context, name string) (Player, error) {
-	row := q.db.QueryRowContext(ctx, getPlayerByName, name)
-	var i Player
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Name,
-		&i.BestScore,
-		&i.Color,
-	)
-	return i, err
+type RevokeEntitlementParams struct {
+	UserID        int64
+	EntitlementID string
 }
 
-const getPlayerByUserId = `-- name: GetPlayerByUserId :one
-SELECT id, user_id, name, best_score, color FROM players
-WHERE user_id = ? LIMIT 1
+func (q *Queries) RevokeEntitlement(ctx context.Context, arg RevokeEntitlementParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeEntitlement, arg.UserID, arg.EntitlementID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const runJobNow = `-- name: RunJobNow :execrows
+UPDATE jobs
+SET run_at = ?
+WHERE kind = ? AND every_ms > 0 AND status = 'pending'
 `
 
-func (q *Queries) GetPlayerByUserId(ctx context.Context, userID int64) (Player, error) {
-	row := q.db.QueryRowContext(ctx, getPlayerByUserId, userID)
-	var i Player
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Name,
-		&i.BestScore,
-		&i.Color,
+type RunJobNowParams struct {
+	RunAt int64
+	Kind  string
+}
+
+func (q *Queries) RunJobNow(ctx context.Context, arg RunJobNowParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, runJobNow, arg.RunAt, arg.Kind)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const savePlayerPosition = `-- name: SavePlayerPosition :exec
+INSERT INTO player_positions (
+    player_id, x, y, saved_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    x = excluded.x,
+    y = excluded.y,
+    saved_at = excluded.saved_at
+`
+
+type SavePlayerPositionParams struct {
+	PlayerID int64
+	X        float64
+	Y        float64
+	SavedAt  int64
+}
+
+func (q *Queries) SavePlayerPosition(ctx context.Context, arg SavePlayerPositionParams) error {
+	_, err := q.db.ExecContext(ctx, savePlayerPosition,
+		arg.PlayerID,
+		arg.X,
+		arg.Y,
+		arg.SavedAt,
 	)
-	return i, err
+	return err
 }
 
-const getPlayerRank = `-- name: GetPlayerRank :one
-SELECT COUNT(*) + 1 as "rank" FROM players
-WHERE best_score >= (
-    SELECT best_score FROM players p2
-    WHERE p2.id = ?
+const scheduleJob = `-- name: ScheduleJob :exec
+INSERT INTO jobs (
+    kind, payload, status, every_ms, attempts, runs, last_error, created_at, run_at
+) VALUES (
+    ?, 'null', 'pending', ?, 0, 0, '', ?, ?
 )
+ON CONFLICT (kind) WHERE every_ms > 0 DO UPDATE SET every_ms = excluded.every_ms
 `
 
-func (q *Queries) GetPlayerRank(ctx context.Context, id int64) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getPlayerRank, id)
-	var rank int64
-	err := row.Scan(&rank)
-	return rank, err
+type ScheduleJobParams struct {
+	Kind      string
+	EveryMs   int64
+	CreatedAt int64
+	RunAt     int64
 }
 
-const getTopScores = `-- name: GetTopScores :many
-SELECT name, best_score
-FROM players
-ORDER BY best_score DESC
-LIMIT ?
-OFFSET ?
+func (q *Queries) ScheduleJob(ctx context.Context, arg ScheduleJobParams) error {
+	_, err := q.db.ExecContext(ctx, scheduleJob,
+		arg.Kind,
+		arg.EveryMs,
+		arg.CreatedAt,
+		arg.RunAt,
+	)
+	return err
+}
+
+const searchAuctionListings = `-- name: SearchAuctionListings :many
+SELECT auction_listings.id, auction_listings.item_id, auction_listings.quantity, auction_listings.price, auction_listings.expires_at, players.name AS seller_name
+FROM auction_listings
+JOIN players ON players.id = auction_listings.seller_id
+WHERE auction_listings.buyer_id IS NULL AND auction_listings.expires_at > ? AND auction_listings.item_id LIKE ? ESCAPE '!'
+ORDER BY auction_listings.price, auction_listings.id
+LIMIT ? OFFSET ?
 `
 
-type GetTopScoresParams struct {
-	Limit  int64
-	Offset int64
+type SearchAuctionListingsParams struct {
+	ExpiresAt int64
+	ItemID    string
+	Limit     int64
+	Offset    int64
 }
 
-type GetTopScoresRow struct {
-	Name      string
-	BestScore int64
+type SearchAuctionListingsRow struct {
+	ID         int64
+	ItemID     string
+	Quantity   int64
+	Price      int64
+	ExpiresAt  int64
+	SellerName string
 }
 
-func (q *Queries) GetTopScores(ctx context.Context, arg GetTopScoresParams) ([]GetTopScoresRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTopScores, arg.Limit, arg.Offset)
+func (q *Queries) SearchAuctionListings(ctx context.Context, arg SearchAuctionListingsParams) ([]SearchAuctionListingsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchAuctionListings,
+		arg.ExpiresAt,
+		arg.ItemID,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTopScoresRow
+	var items []SearchAuctionListingsRow
 	for rows.Next() {
-		var i GetTopScoresRow
-		if err := rows.Scan(&i.Name, &i.BestScore); err != nil {
+		var i SearchAuctionListingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.Quantity,
+			&i.Price,
+			&i.ExpiresAt,
+			&i.SellerName,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -151,16 +4769,198 @@ func (q *Queries) GetTopScores(ctx context.Context, arg GetTopScoresParams) ([]G
 	return items, nil
 }
 
-const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, password_hash FROM users
-WHERE username = ? LIMIT 1
+const setBossLockout = `-- name: SetBossLockout :exec
+INSERT INTO boss_lockouts (
+    player_id, boss_id, looted_at
+) VALUES (
+    ?, ?, ?
+)
+ON CONFLICT (player_id, boss_id) DO UPDATE SET looted_at = excluded.looted_at
 `
 
-func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
-	var i User
-	err := row.Scan(&i.ID, &i.Username, &i.PasswordHash)
-	return i, err
+type SetBossLockoutParams struct {
+	PlayerID int64
+	BossID   string
+	LootedAt int64
+}
+
+func (q *Queries) SetBossLockout(ctx context.Context, arg SetBossLockoutParams) error {
+	_, err := q.db.ExecContext(ctx, setBossLockout,
+		arg.PlayerID,
+		arg.BossID,
+		arg.LootedAt,
+	)
+	return err
+}
+
+const setModerationCaseSnapshot = `-- name: SetModerationCaseSnapshot :exec
+UPDATE moderation_cases
+SET snapshot = ?
+WHERE id = ?
+`
+
+type SetModerationCaseSnapshotParams struct {
+	Snapshot string
+	ID       int64
+}
+
+func (q *Queries) SetModerationCaseSnapshot(ctx context.Context, arg SetModerationCaseSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, setModerationCaseSnapshot, arg.Snapshot, arg.ID)
+	return err
+}
+
+const setQuestStage = `-- name: SetQuestStage :exec
+INSERT INTO quest_stages (
+    player_id, quest_id, stage, updated_at
+) VALUES (
+    ?, ?, ?, ?
+)
+ON CONFLICT (player_id, quest_id) DO UPDATE SET stage = excluded.stage, updated_at = excluded.updated_at
+`
+
+type SetQuestStageParams struct {
+	PlayerID  int64
+	QuestID   string
+	Stage     int64
+	UpdatedAt int64
+}
+
+func (q *Queries) SetQuestStage(ctx context.Context, arg SetQuestStageParams) error {
+	_, err := q.db.ExecContext(ctx, setQuestStage,
+		arg.PlayerID,
+		arg.QuestID,
+		arg.Stage,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const setSessionIp = `-- name: SetSessionIp :exec
+UPDATE sessions SET ip = ?1
+WHERE ip = ?2
+`
+
+type SetSessionIpParams struct {
+	Network string
+	Ip      string
+}
+
+func (q *Queries) SetSessionIp(ctx context.Context, arg SetSessionIpParams) error {
+	_, err := q.db.ExecContext(ctx, setSessionIp, arg.Network, arg.Ip)
+	return err
+}
+
+const setStoreReceipt = `-- name: SetStoreReceipt :exec
+UPDATE store_receipts
+SET receipt = ?
+WHERE id = ?
+`
+
+type SetStoreReceiptParams struct {
+	Receipt string
+	ID      int64
+}
+
+func (q *Queries) SetStoreReceipt(ctx context.Context, arg SetStoreReceiptParams) error {
+	_, err := q.db.ExecContext(ctx, setStoreReceipt, arg.Receipt, arg.ID)
+	return err
+}
+
+const settleAuctionListing = `-- name: SettleAuctionListing :execrows
+UPDATE auction_listings
+SET settled_at = ?
+WHERE id = ? AND settled_at IS NULL
+`
+
+type SettleAuctionListingParams struct {
+	SettledAt sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) SettleAuctionListing(ctx context.Context, arg SettleAuctionListingParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, settleAuctionListing, arg.SettledAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const startJob = `-- name: StartJob :execrows
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, started_at = ?
+WHERE id = ? AND status = 'pending'
+`
+
+type StartJobParams struct {
+	StartedAt sql.NullInt64
+	ID        int64
+}
+
+func (q *Queries) StartJob(ctx context.Context, arg StartJobParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, startJob, arg.StartedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const takeMailItems = `-- name: TakeMailItems :execrows
+UPDATE mail
+SET quantity = quantity - ?1
+WHERE id = ?2 AND player_id = ?3 AND quantity > ?1
+`
+
+type TakeMailItemsParams struct {
+	Quantity int64
+	ID       int64
+	PlayerID int64
+}
+
+func (q *Queries) TakeMailItems(ctx context.Context, arg TakeMailItemsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, takeMailItems, arg.Quantity, arg.ID, arg.PlayerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unlockEmote = `-- name: UnlockEmote :exec
+INSERT OR IGNORE INTO unlocked_emotes (
+    player_id, emote_id, unlocked_at
+) VALUES (
+    ?, ?, ?
+)
+`
+
+type UnlockEmoteParams struct {
+	PlayerID   int64
+	EmoteID    string
+	UnlockedAt int64
+}
+
+func (q *Queries) UnlockEmote(ctx context.Context, arg UnlockEmoteParams) error {
+	_, err := q.db.ExecContext(ctx, unlockEmote, arg.PlayerID, arg.EmoteID, arg.UnlockedAt)
+	return err
+}
+
+const updateBulkMailJobProgress = `-- name: UpdateBulkMailJobProgress :execrows
+UPDATE bulk_mail_jobs
+SET status = 'running', sent = sent + ?, last_player_id = ?
+WHERE id = ? AND status IN ('queued', 'running')
+`
+
+type UpdateBulkMailJobProgressParams struct {
+	Sent         int64
+	LastPlayerID int64
+	ID           int64
+}
+
+func (q *Queries) UpdateBulkMailJobProgress(ctx context.Context, arg UpdateBulkMailJobProgressParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateBulkMailJobProgress, arg.Sent, arg.LastPlayerID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const updatePlayerBestScore = `-- name: UpdatePlayerBestScore :exec
@@ -178,3 +4978,118 @@ func (q *Queries) UpdatePlayerBestScore(ctx context.Context, arg UpdatePlayerBes
 	_, err := q.db.ExecContext(ctx, updatePlayerBestScore, arg.BestScore, arg.ID)
 	return err
 }
+
+const updatePlayerName = `-- name: UpdatePlayerName :exec
+UPDATE players
+SET name = ?
+WHERE id = ?
+`
+
+type UpdatePlayerNameParams struct {
+	Name string
+	ID   int64
+}
+
+func (q *Queries) UpdatePlayerName(ctx context.Context, arg UpdatePlayerNameParams) error {
+	_, err := q.db.ExecContext(ctx, updatePlayerName, arg.Name, arg.ID)
+	return err
+}
+
+const updateUsername = `-- name: UpdateUsername :exec
+UPDATE users
+SET username = ?
+WHERE id = ?
+`
+
+type UpdateUsernameParams struct {
+	Username string
+	ID       int64
+}
+
+func (q *Queries) UpdateUsername(ctx context.Context, arg UpdateUsernameParams) error {
+	_, err := q.db.ExecContext(ctx, updateUsername, arg.Username, arg.ID)
+	return err
+}
+
+const updateUserPasswordHash = `-- name: UpdateUserPasswordHash :exec
+UPDATE users
+SET password_hash = ?
+WHERE id = ?
+`
+
+type UpdateUserPasswordHashParams struct {
+	PasswordHash string
+	ID           int64
+}
+
+func (q *Queries) UpdateUserPasswordHash(ctx context.Context, arg UpdateUserPasswordHashParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPasswordHash, arg.PasswordHash, arg.ID)
+	return err
+}
+
+const upsertFeatureFlag = `-- name: UpsertFeatureFlag :exec
+INSERT INTO feature_flags (
+    id, enabled, rollout_percent, capability, updated_by, updated_at
+) VALUES (
+    ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (id) DO UPDATE SET
+    enabled = excluded.enabled,
+    rollout_percent = excluded.rollout_percent,
+    capability = excluded.capability,
+    updated_by = excluded.updated_by,
+    updated_at = excluded.updated_at
+`
+
+type UpsertFeatureFlagParams struct {
+	ID             string
+	Enabled        bool
+	RolloutPercent int64
+	Capability     string
+	UpdatedBy      string
+	UpdatedAt      int64
+}
+
+func (q *Queries) UpsertFeatureFlag(ctx context.Context, arg UpsertFeatureFlagParams) error {
+	_, err := q.db.ExecContext(ctx, upsertFeatureFlag,
+		arg.ID,
+		arg.Enabled,
+		arg.RolloutPercent,
+		arg.Capability,
+		arg.UpdatedBy,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const upsertVoiceMute = `-- name: UpsertVoiceMute :exec
+INSERT INTO voice_mutes (
+    player_id, muted_by, reason, expires_at, created_at
+) VALUES (
+    ?, ?, ?, ?, ?
+)
+ON CONFLICT (player_id) DO UPDATE SET
+    muted_by = excluded.muted_by,
+    reason = excluded.reason,
+    expires_at = excluded.expires_at,
+    created_at = excluded.created_at
+`
+
+type UpsertVoiceMuteParams struct {
+	PlayerID  int64
+	MutedBy   string
+	Reason    string
+	ExpiresAt int64
+	CreatedAt int64
+}
+
+func (q *Queries) UpsertVoiceMute(ctx context.Context, arg UpsertVoiceMuteParams) error {
+	_, err := q.db.ExecContext(ctx, upsertVoiceMute,
+		arg.PlayerID,
+		arg.MutedBy,
+		arg.Reason,
+		arg.ExpiresAt,
+		arg.CreatedAt,
+	)
+	return err
+}
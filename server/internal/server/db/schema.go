@@ -0,0 +1,8 @@
+package db
+
+import _ "embed"
+
+// The schema is applied on every boot, so every statement in it must be idempotent
+//
+//go:embed config/schema.sql
+var SchemaSql string
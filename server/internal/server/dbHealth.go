@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// How often the database is checked on
+	dbHealthCheckInterval = 5 * time.Second
+	dbHealthCheckTimeout  = 2 * time.Second
+
+	// The circuit opens after this many health checks fail in a row, and closes again after one succeeds
+	dbFailureThreshold = 3
+
+	// How many writes are held while the database is unavailable before any more are dropped
+	maxWriteBehindQueue = 4096
+
+	// Queued writes still failing after this many attempts once the database is back are given up on
+	maxWriteBehindAttempts = 3
+)
+
+// Returned straight away by all queries while the circuit is open, instead of waiting on a database that's down
+var ErrDbUnavailable = errors.New("database unavailable")
+
+var ErrWriteBehindFull = errors.New("write-behind queue full")
+
+var (
+	dbHealthyGauge         = metrics.NewGauge("mmo_db_healthy", "Whether the database is reachable (1) or the circuit to it is open (0).")
+	dbCheckSeconds         = metrics.NewGauge("mmo_db_check_seconds", "How long the last database health check took, as a measure of its latency.")
+	dbCircuitOpensTotal    = metrics.NewCounter("mmo_db_circuit_opens_total", "Times the database was found to be unavailable.")
+	writeBehindQueuedGauge = metrics.NewGauge("mmo_db_write_behind_queued", "Writes waiting for the database to be available again.")
+	writeBehindDropped     = metrics.NewCounterVec("mmo_db_write_behind_dropped_total", "Writes given up on while the database was unavailable.", "reason")
+)
+
+// Keeps an eye on the database and acts as a circuit breaker in front of it. While the circuit is open, queries fail
+// immediately and writes that can wait are queued up until the database is back.
+type dbHealth struct {
+	pool    *sql.DB
+	healthy atomic.Bool
+
+	// Stalls the database on command, for chaos testing
+	chaos *Chaos
+
+	writeBehind []*pendingWrite
+	mux         sync.Mutex
+}
+
+type pendingWrite struct {
+	description string
+	write       func(ctx context.Context, queries *db.Queries) error
+	attempts    int
+}
+
+func newDbHealth(pool *sql.DB) *dbHealth {
+	h := &dbHealth{pool: pool}
+	h.healthy.Store(true)
+	dbHealthyGauge.Set(1)
+	return h
+}
+
+func (h *dbHealth) Healthy() bool {
+	return h.healthy.Load()
+}
+
+func (h *dbHealth) check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbHealthCheckTimeout)
+	defer cancel()
+	if err := h.chaos.waitForDb(ctx); err != nil {
+		return err
+	}
+
+	// Reading the schema makes sure the database file itself can be read, not just that the driver is there
+	var tables int
+	return h.pool.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master").Scan(&tables)
+}
+
+func (h *dbHealth) monitorLoop() {
+	ticker := time.NewTicker(dbHealthCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for range ticker.C {
+		checkedAt := time.Now()
+		err := h.check()
+		dbCheckSeconds.Set(time.Since(checkedAt).Seconds())
+		if err != nil {
+			failures++
+			if failures == dbFailureThreshold {
+				logging.Db.Printf("ALERT: database unavailable after %d failed health checks, opening circuit: %v", failures, err)
+				h.healthy.Store(false)
+				dbHealthyGauge.Set(0)
+				dbCircuitOpensTotal.Inc()
+			}
+			continue
+		}
+
+		if !h.Healthy() {
+			logging.Db.Println("Database available again, closing circuit")
+			h.healthy.Store(true)
+			dbHealthyGauge.Set(1)
+		}
+		failures = 0
+
+		h.flushWriteBehind()
+	}
+}
+
+// Queue a write to be made once the database is available again
+func (h *dbHealth) queueWrite(write *pendingWrite) error {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if len(h.writeBehind) >= maxWriteBehindQueue {
+		writeBehindDropped.With("queue_full").Inc()
+		return ErrWriteBehindFull
+	}
+
+	h.writeBehind = append(h.writeBehind, write)
+	writeBehindQueuedGauge.Set(float64(len(h.writeBehind)))
+	return nil
+}
+
+// Make the queued writes in the order they were queued, stopping if the circuit opens again
+func (h *dbHealth) flushWriteBehind() {
+	h.mux.Lock()
+	queue := h.writeBehind
+	h.writeBehind = nil
+	h.mux.Unlock()
+
+	if len(queue) == 0 {
+		return
+	}
+
+	logging.Db.Printf("Flushing %d writes queued while the database was unavailable", len(queue))
+	queries := db.New(h.pool)
+	var retry []*pendingWrite
+	for i, write := range queue {
+		if !h.Healthy() {
+			retry = append(retry, queue[i:]...)
+			break
+		}
+
+		write.attempts++
+		if err := write.write(context.Background(), queries); err != nil {
+			if errors.Is(err, ErrStaleCharacter) {
+				writeBehindDropped.With("stale").Inc()
+			} else if write.attempts >= maxWriteBehindAttempts {
+				logging.Db.Printf("Giving up on queued write (%s) after %d attempts: %v", write.description, write.attempts, err)
+				writeBehindDropped.With("failed").Inc()
+			} else {
+				retry = append(retry, write)
+			}
+		}
+	}
+
+	h.mux.Lock()
+	h.writeBehind = append(retry, h.writeBehind...)
+	writeBehindQueuedGauge.Set(float64(len(h.writeBehind)))
+	h.mux.Unlock()
+}
+
+// Stands in for the connection pool, failing fast instead of reaching the database while the circuit is open
+type breakerDb struct {
+	pool   *sql.DB
+	health *dbHealth
+}
+
+func (b *breakerDb) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if !b.health.Healthy() {
+		return nil, ErrDbUnavailable
+	}
+	if err := b.health.chaos.waitForDb(ctx); err != nil {
+		return nil, err
+	}
+	return b.pool.ExecContext(ctx, query, args...)
+}
+
+func (b *breakerDb) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if !b.health.Healthy() {
+		return nil, ErrDbUnavailable
+	}
+	if err := b.health.chaos.waitForDb(ctx); err != nil {
+		return nil, err
+	}
+	return b.pool.PrepareContext(ctx, query)
+}
+
+func (b *breakerDb) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if !b.health.Healthy() {
+		return nil, ErrDbUnavailable
+	}
+	if err := b.health.chaos.waitForDb(ctx); err != nil {
+		return nil, err
+	}
+	return b.pool.QueryContext(ctx, query, args...)
+}
+
+func (b *breakerDb) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if !b.health.Healthy() {
+		// A row can't be made with an error of our own, but one queried with a cancelled context fails without
+		// touching the database
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		return b.pool.QueryRowContext(cancelled, query, args...)
+	}
+	b.health.chaos.waitForDb(ctx)
+	return b.pool.QueryRowContext(ctx, query, args...)
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"crypto/subtle"
+	"math"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	// How often watchers are sent what the server believes about the actors they're watching
+	debugOverlayInterval = 100 * time.Millisecond
+
+	// How far (edge to edge) from the actor what it collides with is sent
+	debugOverlayColliderRange = 500
+
+	// How many of what each actor's client sent that was refused are kept
+	debugOverlayRejections = 20
+)
+
+var visibilityModeNames = map[VisibilityMode]string{
+	Visible:   "visible",
+	Stealthed: "stealthed",
+	Invisible: "invisible",
+}
+
+// Streams what the server believes about an actor to developers watching it, so a client can draw it over the game:
+// what the actor collides with, its area of interest and visibility, the path it's being walked along and what its
+// client sent lately that was refused. Only clients with the debug overlay secret can watch, and nothing's kept for
+// the overlay at all without one.
+type DebugOverlays struct {
+	hub    *Hub
+	secret string
+
+	// The actor each watching client's watching, by the watcher's client ID
+	watching map[uint64]uint64
+
+	// The waypoints left on each actor's path, as reported by whatever's moving it
+	paths map[uint64]func() []objects.Position
+
+	// Oldest first
+	rejections map[uint64][]*packets.DebugRejectionMessage
+
+	mux sync.Mutex
+}
+
+func NewDebugOverlays(hub *Hub) *DebugOverlays {
+	return &DebugOverlays{
+		hub:        hub,
+		watching:   make(map[uint64]uint64),
+		paths:      make(map[uint64]func() []objects.Position),
+		rejections: make(map[uint64][]*packets.DebugRejectionMessage),
+	}
+}
+
+// Must be called before the hub is run. The overlay's off without a secret.
+func (d *DebugOverlays) Configure(secret string) {
+	d.secret = secret
+	if secret != "" {
+		logging.Hub.Println("Streaming the debug overlay to clients with the secret, which shouldn't be done in production")
+	}
+}
+
+func (d *DebugOverlays) Enabled() bool {
+	return d.secret != ""
+}
+
+// Whether the secret is the one watching actors needs
+func (d *DebugOverlays) Authenticate(secret string) bool {
+	return d.Enabled() && subtle.ConstantTimeCompare([]byte(secret), []byte(d.secret)) == 1
+}
+
+// Start streaming the actor to the watcher, instead of any actor it was watching before
+func (d *DebugOverlays) Watch(watcherId uint64, actorId uint64) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.watching[watcherId] = actorId
+}
+
+func (d *DebugOverlays) Unwatch(watcherId uint64) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	delete(d.watching, watcherId)
+}
+
+// Have the actor's path shown as the function returns it, until the actor's forgotten
+func (d *DebugOverlays) TrackPath(actorId uint64, waypoints func() []objects.Position) {
+	if !d.Enabled() {
+		return
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.paths[actorId] = waypoints
+}
+
+// The actor's client sent a packet of the kind that was refused, for the reason
+func (d *DebugOverlays) Rejected(actorId uint64, kind packets.MsgKind, reason string) {
+	if !d.Enabled() {
+		return
+	}
+	rejection := &packets.DebugRejectionMessage{Kind: kindName(kind), Reason: reason, At: time.Now().UnixMilli()}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.rejections[actorId] = appendLatest(d.rejections[actorId], rejection, debugOverlayRejections)
+}
+
+// Forget the client, both as a watcher and as an actor, once it's gone
+func (d *DebugOverlays) Forget(clientId uint64) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	delete(d.watching, clientId)
+	delete(d.paths, clientId)
+	delete(d.rejections, clientId)
+}
+
+// The clients with anything kept for the overlay
+func (d *DebugOverlays) clientIds() []uint64 {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	ids := make(map[uint64]struct{})
+	for watcherId := range d.watching {
+		ids[watcherId] = struct{}{}
+	}
+	for actorId := range d.paths {
+		ids[actorId] = struct{}{}
+	}
+	for actorId := range d.rejections {
+		ids[actorId] = struct{}{}
+	}
+	result := make([]uint64, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	return result
+}
+
+func (d *DebugOverlays) sendLoop() {
+	ticker := time.NewTicker(debugOverlayInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		d.mux.Lock()
+		watching := make(map[uint64]uint64, len(d.watching))
+		for watcherId, actorId := range d.watching {
+			watching[watcherId] = actorId
+		}
+		d.mux.Unlock()
+
+		for watcherId, actorId := range watching {
+			watcher, exists := d.hub.Clients.Get(watcherId)
+			if !exists {
+				continue
+			}
+			if overlay, exists := d.overlay(actorId); exists {
+				watcher.SocketSendAs(overlay, actorId)
+			}
+		}
+	}
+}
+
+// What the server believes about the actor right now, if it's still in the world
+func (d *DebugOverlays) overlay(actorId uint64) (*packets.Packet_DebugOverlay, bool) {
+	actor, exists := d.hub.SharedGameObjects.Players.Get(actorId)
+	if !exists {
+		return nil, false
+	}
+
+	mode, stealthRadius := d.hub.Visibility.Mode(actorId)
+	overlay := &packets.DebugOverlayMessage{
+		ActorId:        actorId,
+		X:              actor.X,
+		Y:              actor.Y,
+		Radius:         actor.Radius,
+		AoiRadius:      d.hub.Visibility.AoiRadius(),
+		Visibility:     visibilityModeNames[mode],
+		StealthRadius:  stealthRadius,
+		InterestRadius: d.hub.Visibility.InterestRadius(actorId),
+	}
+
+	near := func(position objects.Position, radius float64) bool {
+		return math.Hypot(position.X-actor.X, position.Y-actor.Y)-radius-actor.Radius <= debugOverlayColliderRange
+	}
+	d.hub.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+		if id != actorId && near(player.Position, player.Radius) {
+			overlay.Colliders = append(overlay.Colliders, &packets.DebugColliderMessage{Id: id, Kind: "player", X: player.X, Y: player.Y, Radius: player.Radius})
+		}
+	})
+	d.hub.SharedGameObjects.ResourceNodes.ForEach(func(id uint64, node *objects.ResourceNode) {
+		if near(node.Position, node.Radius) {
+			overlay.Colliders = append(overlay.Colliders, &packets.DebugColliderMessage{Id: id, Kind: "resource_node", X: node.X, Y: node.Y, Radius: node.Radius})
+		}
+	})
+
+	d.mux.Lock()
+	waypoints := d.paths[actorId]
+	overlay.Rejections = append(overlay.Rejections, d.rejections[actorId]...)
+	d.mux.Unlock()
+
+	if waypoints != nil {
+		for _, waypoint := range waypoints() {
+			overlay.Waypoints = append(overlay.Waypoints, &packets.DebugWaypointMessage{X: waypoint.X, Y: waypoint.Y})
+		}
+	}
+	return &packets.Packet_DebugOverlay{DebugOverlay: overlay}, true
+}
@@ -0,0 +1,251 @@
+// Keeps count of the server's goroutines by the subsystem that started them, so a subsystem whose goroutines pile up,
+// or a client whose goroutines outlive its connection, stands out. Also serves Go's own profiles, including how long
+// goroutines spend waiting on each other's locks, for development.
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimePprof "runtime/pprof"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	GoroutinesPath = "/debug/goroutines"
+	ProfilesPath   = "/debug/pprof/"
+
+	// How long an owner's goroutines have to finish once it's released before they're reported as leaked
+	LeakGracePeriod = 10 * time.Second
+
+	// One in this many lock contention events is sampled for the mutex profile, unless configured otherwise
+	DefaultMutexProfileFraction = 100
+)
+
+var (
+	goroutinesRunning     = metrics.NewGaugeVec("mmo_goroutines", "Goroutines running, by the subsystem that started them.", "subsystem")
+	leakedGoroutinesTotal = metrics.NewCounterVec("mmo_leaked_goroutines_total", "Goroutines still running well after the client that started them disconnected, by subsystem.", "subsystem")
+	_                     = metrics.NewGaugeFunc("mmo_goroutines_all", "Every goroutine running, including those not started by a subsystem.", func() float64 { return float64(runtime.NumGoroutine()) })
+)
+
+var tracked = &tracker{
+	subsystems: make(map[string]int),
+	owned:      make(map[any]map[string]int),
+}
+
+type tracker struct {
+	subsystems map[string]int
+
+	// Of goroutines with an owner, e.g. a client, by owner then subsystem. Owners are dropped once none are left.
+	owned map[any]map[string]int
+
+	mux sync.Mutex
+}
+
+func (t *tracker) start(owner any, subsystem string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.subsystems[subsystem]++
+	if owner != nil {
+		if t.owned[owner] == nil {
+			t.owned[owner] = make(map[string]int)
+		}
+		t.owned[owner][subsystem]++
+	}
+	goroutinesRunning.With(subsystem).Add(1)
+}
+
+func (t *tracker) stop(owner any, subsystem string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.subsystems[subsystem]--
+	if owner != nil {
+		if t.owned[owner][subsystem]--; t.owned[owner][subsystem] == 0 {
+			delete(t.owned[owner], subsystem)
+		}
+		if len(t.owned[owner]) == 0 {
+			delete(t.owned, owner)
+		}
+	}
+	goroutinesRunning.With(subsystem).Add(-1)
+}
+
+// Run the function in a new goroutine counted under the subsystem
+func Go(subsystem string, fn func()) {
+	GoFor(nil, subsystem, fn)
+}
+
+// Run the function in a new goroutine counted under the subsystem and belonging to the owner, e.g. a client, so it's
+// reported if it's still running well after the owner is released. The goroutine is labelled with its subsystem in
+// the goroutine profile, for finding where it's stuck.
+func GoFor(owner any, subsystem string, fn func()) {
+	tracked.start(owner, subsystem)
+	go func() {
+		defer tracked.stop(owner, subsystem)
+		runtimePprof.Do(context.Background(), runtimePprof.Labels("subsystem", subsystem), func(context.Context) {
+			fn()
+		})
+	}()
+}
+
+// The owner is done with, e.g. a client that's disconnected, so any of its goroutines still running after the grace
+// period are logged and counted as leaked. The name is what it's called in the log.
+func Release(owner any, name string) {
+	time.AfterFunc(LeakGracePeriod, func() {
+		for subsystem, count := range OwnedBy(owner) {
+			leakedGoroutinesTotal.With(subsystem).Add(uint64(count))
+			logging.Hub.Errorf("%s still has %d %s goroutines running %s after it was done with", name, count, subsystem, LeakGracePeriod)
+		}
+	})
+}
+
+// How many goroutines started through Go and GoFor are running, by subsystem
+func Counts() map[string]int {
+	tracked.mux.Lock()
+	defer tracked.mux.Unlock()
+	counts := make(map[string]int, len(tracked.subsystems))
+	for subsystem, count := range tracked.subsystems {
+		if count > 0 {
+			counts[subsystem] = count
+		}
+	}
+	return counts
+}
+
+// How many of the owner's goroutines are running, by subsystem
+func OwnedBy(owner any) map[string]int {
+	tracked.mux.Lock()
+	defer tracked.mux.Unlock()
+	counts := make(map[string]int, len(tracked.owned[owner]))
+	for subsystem, count := range tracked.owned[owner] {
+		counts[subsystem] = count
+	}
+	return counts
+}
+
+// How many goroutines with an owner are running, across every owner
+func Owned() int {
+	tracked.mux.Lock()
+	defer tracked.mux.Unlock()
+	total := 0
+	for _, subsystems := range tracked.owned {
+		for _, count := range subsystems {
+			total += count
+		}
+	}
+	return total
+}
+
+// Start sampling lock contention for the mutex profile, one in every fraction events, or stop if 0
+func EnableMutexProfile(fraction int) {
+	runtime.SetMutexProfileFraction(fraction)
+}
+
+// The goroutine profile's stacks, grouped, for only the goroutines started under the given subsystems
+func Stacks(subsystems ...string) string {
+	var profile bytes.Buffer
+	runtimePprof.Lookup("goroutine").WriteTo(&profile, 1)
+
+	var stacks strings.Builder
+	for _, group := range strings.Split(profile.String(), "\n\n") {
+		for _, subsystem := range subsystems {
+			if strings.Contains(group, `"subsystem":"`+subsystem+`"`) {
+				stacks.WriteString(group)
+				stacks.WriteString("\n\n")
+				break
+			}
+		}
+	}
+	return stacks.String()
+}
+
+type goroutinesReport struct {
+	Total      int            `json:"total"`
+	Owned      int            `json:"owned"`
+	Subsystems map[string]int `json:"subsystems"`
+}
+
+// Serves the goroutine counts at GoroutinesPath and Go's profiles under ProfilesPath. Anyone can see these, so they're
+// only for development.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+GoroutinesPath, func(writer http.ResponseWriter, request *http.Request) {
+		if subsystem := request.URL.Query().Get("stacks"); subsystem != "" {
+			writer.Header().Set("Content-Type", "text/plain")
+			writer.Write([]byte(Stacks(subsystem)))
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(goroutinesReport{
+			Total:      runtime.NumGoroutine(),
+			Owned:      Owned(),
+			Subsystems: Counts(),
+		})
+	})
+	mux.HandleFunc(ProfilesPath, pprof.Index)
+	mux.HandleFunc(ProfilesPath+"cmdline", pprof.Cmdline)
+	mux.HandleFunc(ProfilesPath+"profile", pprof.Profile)
+	mux.HandleFunc(ProfilesPath+"symbol", pprof.Symbol)
+	mux.HandleFunc(ProfilesPath+"trace", pprof.Trace)
+	return mux
+}
+
+// What VerifyNoLeaks needs from a test, which *testing.T and *testing.B both are
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+}
+
+// Fail the test if, once it's done, more goroutines are running under any subsystem, or with an owner, than when this was
+// called. They get until the grace period to finish first. Call it once whatever's meant to keep running throughout,
+// like the hub, has started, and before anything closed by a cleanup, since those run after the check otherwise.
+func VerifyNoLeaks(tb TB) {
+	tb.Helper()
+	baseline := Counts()
+	owned := Owned()
+
+	tb.Cleanup(func() {
+		tb.Helper()
+		var leaked []string
+		deadline := time.Now().Add(LeakGracePeriod)
+		for {
+			leaked = leakedSince(baseline)
+			if (len(leaked) == 0 && Owned() <= owned) || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if len(leaked) == 0 && Owned() <= owned {
+			return
+		}
+
+		counts := Counts()
+		var report strings.Builder
+		for _, subsystem := range leaked {
+			fmt.Fprintf(&report, "\n\t%s: %d, up from %d", subsystem, counts[subsystem], baseline[subsystem])
+		}
+		tb.Errorf("goroutines leaked, %d with owners (%d before), by subsystem:%s\n\n%s", Owned(), owned, report.String(), Stacks(leaked...))
+	})
+}
+
+// The subsystems with more goroutines running than in the baseline
+func leakedSince(baseline map[string]int) []string {
+	var leaked []string
+	for subsystem, count := range Counts() {
+		if count > baseline[subsystem] {
+			leaked = append(leaked, subsystem)
+		}
+	}
+	sort.Strings(leaked)
+	return leaked
+}
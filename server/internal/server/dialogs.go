@@ -0,0 +1,44 @@
+package server
+
+import "sync"
+
+// Where a dialog hook was run from. ChoiceId is empty for a hook run on reaching a node.
+type DialogEvent struct {
+	DialogId string
+	NodeId   string
+	ChoiceId string
+
+	// What the player is talking to
+	TargetId uint64
+}
+
+// Runs the script behind a dialog hook named in the game data, for programs embedding the server to do what the data
+// alone can't, e.g. start an event when a player accepts a quest. A choice's hook runs after its effects are checked
+// but before they're applied, and returning an error refuses the choice with nothing changed. A node's hook runs once
+// it's been reached, and its error is only logged.
+type DialogHook func(client ClientInterfacer, event DialogEvent) error
+
+type dialogHooks struct {
+	hooks map[string]DialogHook
+	mux   sync.RWMutex
+}
+
+// Run the hook whenever a dialog names it. Replaces any hook already registered with the name.
+func (h *Hub) HandleDialogHook(name string, hook DialogHook) {
+	h.dialogHooks.mux.Lock()
+	defer h.dialogHooks.mux.Unlock()
+
+	if h.dialogHooks.hooks == nil {
+		h.dialogHooks.hooks = make(map[string]DialogHook)
+	}
+	h.dialogHooks.hooks[name] = hook
+}
+
+// The hook registered with the name, if there is one
+func (h *Hub) DialogHook(name string) (DialogHook, bool) {
+	h.dialogHooks.mux.RLock()
+	defer h.dialogHooks.mux.RUnlock()
+
+	hook, exists := h.dialogHooks.hooks[name]
+	return hook, exists
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// How often each player's told about emitters coming into and leaving their area of interest
+const emitterSyncInterval = 500 * time.Millisecond
+
+var ErrNoSuchEmitter = errors.New("no such emitter")
+
+// What one player's client has been told about, so it's only sent what's changed
+type emitterView struct {
+	// Which player it was, since a client leaving the world and coming back gets a new one, which knows of nothing
+	player *objects.Player
+
+	known map[uint64]struct{}
+}
+
+// Ambient emitters in the world, e.g. campfires and waterfalls, placed from the game data when the hub's run or through
+// the admin API. Each player's sent emitters as they come within their area of interest, edge to edge, and told when
+// they leave it or are removed, so clients play their sounds and effects where they are instead of hardcoding them in
+// the scene. Emitters placed through the admin API are gone when the server restarts.
+type Emitters struct {
+	hub *Hub
+
+	// By client ID
+	views map[uint64]*emitterView
+
+	mux sync.Mutex
+}
+
+func NewEmitters(hub *Hub) *Emitters {
+	return &Emitters{hub: hub, views: make(map[uint64]*emitterView)}
+}
+
+func newEmitter(emitter *gamedata.Emitter) *objects.Emitter {
+	return &objects.Emitter{
+		Position: objects.Position{X: emitter.X, Y: emitter.Y},
+		Body:     objects.Body{Radius: emitter.Radius},
+		Kind:     emitter.Kind,
+		Sound:    emitter.Sound,
+		Effect:   emitter.Effect,
+		Volume:   emitter.Volume,
+	}
+}
+
+func newEmitterMessage(id uint64, emitter *objects.Emitter) *packets.EmitterMessage {
+	return &packets.EmitterMessage{
+		Id:     id,
+		Kind:   emitter.Kind,
+		X:      emitter.X,
+		Y:      emitter.Y,
+		Radius: emitter.Radius,
+		Sound:  emitter.Sound,
+		Effect: emitter.Effect,
+		Volume: emitter.Volume,
+	}
+}
+
+// Place every emitter in the game data
+func (e *Emitters) placeAll() {
+	for _, emitter := range e.hub.GameData.Emitters {
+		id := e.hub.SharedGameObjects.Emitters.Add(newEmitter(emitter))
+		e.hub.WorldTags.Tag(ObjectEmitter, id, emitter.Tags...)
+	}
+}
+
+// Place the emitter, which must be valid, returning its ID. Players near it are sent it with the next sync.
+func (e *Emitters) Place(emitter *gamedata.Emitter) uint64 {
+	id := e.hub.SharedGameObjects.Emitters.Add(newEmitter(emitter))
+	e.hub.WorldTags.Tag(ObjectEmitter, id, emitter.Tags...)
+	logging.Hub.Printf("Placed %s emitter %d at %.0f, %.0f", emitter.Kind, id, emitter.X, emitter.Y)
+	return id
+}
+
+// Remove the emitter, which players who were sent it are told with the next sync
+func (e *Emitters) Remove(id uint64) error {
+	emitter, exists := e.hub.SharedGameObjects.Emitters.Get(id)
+	if !exists {
+		return ErrNoSuchEmitter
+	}
+	e.hub.SharedGameObjects.Emitters.Remove(id)
+	e.hub.WorldTags.Forget(id)
+	logging.Hub.Printf("Removed %s emitter %d", emitter.Kind, id)
+	return nil
+}
+
+// Every emitter in the world, sorted by ID
+func (e *Emitters) List() []*packets.EmitterMessage {
+	var result []*packets.EmitterMessage
+	e.hub.SharedGameObjects.Emitters.ForEach(func(id uint64, emitter *objects.Emitter) {
+		result = append(result, newEmitterMessage(id, emitter))
+	})
+	slices.SortFunc(result, func(a, b *packets.EmitterMessage) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return result
+}
+
+// Forget what the client's been told once it's gone
+func (e *Emitters) Forget(clientId uint64) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	delete(e.views, clientId)
+}
+
+// The clients with anything kept about what they've been told
+func (e *Emitters) clientIds() []uint64 {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	ids := make([]uint64, 0, len(e.views))
+	for id := range e.views {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Send every player in the world the emitters that have come into their area of interest, and despawn those that
+// have left it or been removed
+func (e *Emitters) sync() {
+	shared := e.hub.SharedGameObjects
+	aoiRadius := e.hub.Visibility.AoiRadius()
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	inWorld := make(map[uint64]struct{})
+	shared.Players.ForEach(func(playerId uint64, player *objects.Player) {
+		client, exists := e.hub.Clients.Get(playerId)
+		if !exists {
+			return
+		}
+		inWorld[playerId] = struct{}{}
+
+		view, exists := e.views[playerId]
+		if !exists || view.player != player {
+			view = &emitterView{player: player, known: make(map[uint64]struct{})}
+			e.views[playerId] = view
+		}
+
+		near := make(map[uint64]struct{})
+		var spawned []*packets.EmitterMessage
+		shared.Emitters.ForEach(func(emitterId uint64, emitter *objects.Emitter) {
+			if math.Hypot(emitter.X-player.X, emitter.Y-player.Y)-emitter.Radius-player.Radius > aoiRadius {
+				return
+			}
+			near[emitterId] = struct{}{}
+			if _, known := view.known[emitterId]; !known {
+				spawned = append(spawned, newEmitterMessage(emitterId, emitter))
+			}
+		})
+
+		var despawned []uint64
+		for emitterId := range view.known {
+			if _, stillNear := near[emitterId]; !stillNear {
+				despawned = append(despawned, emitterId)
+			}
+		}
+		view.known = near
+
+		// Emitters are part of the world rather than any actor, so they come from the server
+		if len(despawned) > 0 {
+			client.SocketSend(packets.NewEmitterDespawn(despawned))
+		}
+		if len(spawned) > 0 {
+			client.SocketSend(packets.NewEmitterSpawn(spawned))
+		}
+	})
+
+	// Players who've left the world will be sent everything again if they come back
+	for clientId := range e.views {
+		if _, exists := inWorld[clientId]; !exists {
+			delete(e.views, clientId)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"time"
+)
+
+// How many characters an account can have without any entitlements giving it more
+const BaseCharacterSlots = 1
+
+var (
+	ErrNoSuchEntitlement = errors.New("no such entitlement")
+	ErrAlreadyEntitled   = errors.New("already entitled")
+	ErrNotEntitled       = errors.New("not entitled")
+	ErrNoCharacterSlot   = errors.New("no character slots left")
+)
+
+// What each account has been entitled to, e.g. DLC, by the admin API or by buying it from the store. Zones, items,
+// emotes and store entries can require an entitlement, which players on accounts without it don't get, and
+// entitlements can give accounts more character slots. Every grant and revoke is recorded in the audit log.
+type Entitlements struct {
+	hub *Hub
+}
+
+func NewEntitlements(hub *Hub) *Entitlements {
+	return &Entitlements{hub: hub}
+}
+
+// The IDs of the entitlements the account has, leaving out any no longer in the game data
+func (e *Entitlements) List(ctx context.Context, queries *db.Queries, userId int64) ([]string, error) {
+	rows, err := queries.GetEntitlements(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if _, exists := e.hub.GameData.Entitlement(row.EntitlementID); exists {
+			ids = append(ids, row.EntitlementID)
+		}
+	}
+	return ids, nil
+}
+
+// How many characters an account with the entitlements can have
+func (e *Entitlements) CharacterSlots(entitlementIds []string) int {
+	slots := BaseCharacterSlots
+	for _, id := range entitlementIds {
+		if entitlement, exists := e.hub.GameData.Entitlement(id); exists {
+			slots += entitlement.CharacterSlots
+		}
+	}
+	return slots
+}
+
+// Returns ErrNoCharacterSlot if the account already has as many characters as it can, which must be checked as part
+// of the transaction making any character
+func (e *Entitlements) CheckCharacterSlot(ctx context.Context, queries *db.Queries, userId int64) error {
+	ids, err := e.List(ctx, queries, userId)
+	if err != nil {
+		return err
+	}
+	count, err := queries.CountPlayersByUserId(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if count >= int64(e.CharacterSlots(ids)) {
+		return ErrNoCharacterSlot
+	}
+	return nil
+}
+
+// Entitle the account, e.g. from the admin API once a purchase outside the game has gone through. Its player finds
+// out straight away if they're in game.
+func (e *Entitlements) Grant(grantedBy string, userId int64, entitlementId string, source string) error {
+	dbTx := e.hub.NewDbTx()
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		return e.grant(dbTx, queries, userId, entitlementId, source)
+	})
+	if err != nil {
+		return err
+	}
+
+	e.hub.Audit(grantedBy, "entitlement.granted", fmt.Sprintf("user %d", userId), fmt.Sprintf("%s from %s", entitlementId, source))
+	e.notify(userId)
+	return nil
+}
+
+// Grant the entitlement as part of the transaction, e.g. one paying for it
+func (e *Entitlements) grant(dbTx *DbTx, queries *db.Queries, userId int64, entitlementId string, source string) error {
+	if _, exists := e.hub.GameData.Entitlement(entitlementId); !exists {
+		return ErrNoSuchEntitlement
+	}
+
+	granted, err := queries.GrantEntitlement(dbTx.Ctx, db.GrantEntitlementParams{
+		UserID:        userId,
+		EntitlementID: entitlementId,
+		Source:        source,
+		GrantedAt:     time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	if granted == 0 {
+		return ErrAlreadyEntitled
+	}
+	return dbTx.RecordEvent(queries, "entitlement.granted", map[string]any{
+		"user_id":        userId,
+		"entitlement_id": entitlementId,
+		"source":         source,
+	})
+}
+
+// Take the entitlement away from the account, e.g. after a refund. Content requiring it is gone for its player
+// straight away if they're in game, though anything they made with it is theirs to keep.
+func (e *Entitlements) Revoke(revokedBy string, userId int64, entitlementId string) error {
+	dbTx := e.hub.NewDbTx()
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		revoked, err := queries.RevokeEntitlement(dbTx.Ctx, db.RevokeEntitlementParams{
+			UserID:        userId,
+			EntitlementID: entitlementId,
+		})
+		if err != nil {
+			return err
+		}
+		if revoked == 0 {
+			return ErrNotEntitled
+		}
+		return dbTx.RecordEvent(queries, "entitlement.revoked", map[string]any{
+			"user_id":        userId,
+			"entitlement_id": entitlementId,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	e.hub.Audit(revokedBy, "entitlement.revoked", fmt.Sprintf("user %d", userId), entitlementId)
+	e.notify(userId)
+	return nil
+}
+
+// Have the account's player reload their entitlements, if they're in game
+func (e *Entitlements) notify(userId int64) {
+	dbTx := e.hub.NewDbTx()
+	player, err := dbTx.Queries.GetPlayerByUserId(dbTx.Ctx, userId)
+	if err != nil {
+		return
+	}
+	if client, online := e.hub.ClientByPlayerDbId(player.ID); online {
+		client.ProcessMessage(0, packets.NewEntitlementListRequest())
+	}
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"errors"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"time"
+)
+
+var ErrNoSuchZone = errors.New("no such zone")
+
+var environmentCuesSentTotal = metrics.NewCounterVec("mmo_environment_cues_sent_total", "Environment cues sent to clients, by the kind of area they were triggered for.", "area")
+
+// An ambient or visual cue for clients to play, e.g. an ambient track starting as a storm rolls in, a particle event
+// where something exploded, or the screen shaking with it. Anything left empty or 0 isn't part of the cue.
+type EnvironmentCue struct {
+	AmbientTrack  string
+	ParticleEvent string
+
+	// Where the particle event plays
+	X float64
+	Y float64
+
+	ShakeIntensity float64
+	ShakeDuration  time.Duration
+}
+
+func (c EnvironmentCue) message() packets.Msg {
+	return packets.NewEnvironmentCue(c.AmbientTrack, c.ParticleEvent, c.X, c.Y, c.ShakeIntensity, c.ShakeDuration)
+}
+
+// Sends environment cues to the players they're relevant to: those in a zone, a region or within some distance of a
+// point. A cue caused by an actor, e.g. a player's explosion, only reaches players who can see it, so channels and
+// stealth apply to cues the same as to anything else about the actor.
+type EnvironmentCues struct {
+	hub *Hub
+}
+
+func NewEnvironmentCues(hub *Hub) *EnvironmentCues {
+	return &EnvironmentCues{hub: hub}
+}
+
+// Send the cue to every player in the zone, or only those in one of its channels unless channel is 0. The source is
+// the actor causing the cue, or 0 if it's the world itself. Returns how many players it was sent to.
+func (e *EnvironmentCues) ToZone(sourceId uint64, zoneId string, channel int, cue EnvironmentCue) (int, error) {
+	if _, exists := e.hub.GameData.Zone(zoneId); !exists {
+		return 0, ErrNoSuchZone
+	}
+	return e.send("zone", sourceId, cue, func(playerId uint64, _ *objects.Player) bool {
+		inZone, inChannel, exists := e.hub.Channels.Current(playerId)
+		return exists && inZone == zoneId && (channel == 0 || inChannel == channel)
+	}), nil
+}
+
+// Send the cue to every player inside the rectangle, whichever zones it spans. Returns how many players it was sent
+// to.
+func (e *EnvironmentCues) ToRegion(sourceId uint64, minX, minY, maxX, maxY float64, cue EnvironmentCue) int {
+	return e.send("region", sourceId, cue, func(_ uint64, player *objects.Player) bool {
+		return player.X >= minX && player.X < maxX && player.Y >= minY && player.Y < maxY
+	})
+}
+
+// Send the cue to every player within the radius of the point. Returns how many players it was sent to.
+func (e *EnvironmentCues) ToRadius(sourceId uint64, x, y, radius float64, cue EnvironmentCue) int {
+	return e.send("radius", sourceId, cue, func(_ uint64, player *objects.Player) bool {
+		dx, dy := player.X-x, player.Y-y
+		return dx*dx+dy*dy <= radius*radius
+	})
+}
+
+func (e *EnvironmentCues) send(area string, sourceId uint64, cue EnvironmentCue, in func(uint64, *objects.Player) bool) int {
+	var recipients []uint64
+	e.hub.SharedGameObjects.Players.ForEach(func(playerId uint64, player *objects.Player) {
+		if in(playerId, player) && e.hub.Visibility.CanSee(playerId, sourceId) {
+			recipients = append(recipients, playerId)
+		}
+	})
+
+	// Cues come from the server whoever caused them, so clients can tell them apart from anything a player sent
+	message := cue.message()
+	sent := 0
+	for _, playerId := range recipients {
+		if client, exists := e.hub.Clients.Get(playerId); exists {
+			client.ProcessMessage(0, message)
+			sent++
+		}
+	}
+
+	environmentCuesSentTotal.With(area).Add(uint64(sent))
+	return sent
+}
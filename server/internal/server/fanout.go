@@ -0,0 +1,173 @@
+package server
+
+import (
+	"fmt"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How many ticks of fan-out stats are kept for the admin API, 10 seconds' worth
+const fanoutHistoryTicks = 200
+
+var encodeBuckets = []float64{0.000001, 0.000005, 0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005}
+
+var (
+	fanoutBroadcastsTotal    = metrics.NewCounterVec("mmo_fanout_broadcasts_total", "Broadcasts fanned out, by message kind.", "kind")
+	fanoutRecipientsTotal    = metrics.NewCounterVec("mmo_fanout_recipients_total", "Clients broadcasts were passed to, by message kind.", "kind")
+	fanoutSkippedTotal       = metrics.NewCounterVec("mmo_fanout_skipped_total", "Clients broadcasts weren't passed to because they can't see the sender, by message kind.", "kind")
+	fanoutBackpressuredTotal = metrics.NewCounterVec("mmo_fanout_backpressured_total", "Broadcasts that found a client's send queue full, by whether they were held to retry or dropped.", "outcome")
+	fanoutEncodedBytesTotal  = metrics.NewCounterVec("mmo_fanout_encoded_bytes_total", "Bytes broadcasts were encoded to, once for each broadcast, by message kind.", "kind")
+	fanoutSentBytesTotal     = metrics.NewCounterVec("mmo_fanout_sent_bytes_total", "Bytes of broadcasts queued for clients, once for each recipient, by message kind.", "kind")
+	fanoutEncodeSeconds      = metrics.NewHistogramVec("mmo_fanout_encode_seconds", "How long broadcasts took to encode, by message kind.", "kind", encodeBuckets)
+)
+
+// What the fan-out did in one tick
+type FanoutTick struct {
+	StartedAt      time.Time     `json:"started_at"`
+	Broadcasts     int           `json:"broadcasts"`
+	Recipients     int           `json:"recipients"`
+	Skipped        int           `json:"skipped"`
+	Held           int           `json:"held"`
+	Dropped        int           `json:"dropped"`
+	EncodedBytes   int           `json:"encoded_bytes"`
+	SentBytes      int           `json:"sent_bytes"`
+	EncodeDuration time.Duration `json:"encode_duration"`
+}
+
+// The fan-out over the last few seconds, tick by tick, and the busiest kinds of message in that time
+type FanoutStats struct {
+	// Oldest first, leaving out ticks nothing was broadcast in
+	Ticks []FanoutTick `json:"ticks"`
+
+	// Bytes queued for clients, by message kind
+	SentBytesByKind map[string]int `json:"sent_bytes_by_kind"`
+}
+
+// Instruments the broadcast fan-out: how much each broadcast is encoded to and how long that takes, how many clients
+// it's passed to, how many are skipped because they can't see the sender, and how many have their send queues full.
+// Totals are exported as metrics, and the last few seconds are kept tick by tick for the admin dashboard. Updates
+// about players a client can see but that aren't relevant enough to them are skipped later, by the client's state,
+// and are counted by mmo_relevancy_updates_skipped_total instead.
+type Fanout struct {
+	// The tick being counted, and the ones before it, oldest first
+	current FanoutTick
+	history []FanoutTick
+
+	// Bytes queued by message kind, for each tick in the history and the current one
+	currentKinds map[string]int
+	historyKinds []map[string]int
+
+	mux sync.Mutex
+}
+
+func NewFanout() *Fanout {
+	return &Fanout{currentKinds: make(map[string]int)}
+}
+
+// Encode the broadcast's message once for every recipient, timing it
+func (f *Fanout) encode(shared *packets.SharedMsg) int {
+	startedAt := time.Now()
+	data, err := shared.Encoded()
+	elapsed := time.Since(startedAt)
+	if err != nil {
+		// The write pumps log the error for each client it's for
+		return 0
+	}
+
+	kind := fanoutKind(shared.Msg)
+	fanoutBroadcastsTotal.With(kind).Inc()
+	fanoutEncodedBytesTotal.With(kind).Add(uint64(len(data)))
+	fanoutEncodeSeconds.Observe(kind, elapsed.Seconds())
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	tick := f.tick()
+	tick.Broadcasts++
+	tick.EncodedBytes += len(data)
+	tick.EncodeDuration += elapsed
+	return len(data)
+}
+
+// Count the broadcast once it's been fanned out
+func (f *Fanout) delivered(message packets.Msg, size int, recipients int, skipped int) {
+	kind := fanoutKind(message)
+	fanoutRecipientsTotal.With(kind).Add(uint64(recipients))
+	fanoutSkippedTotal.With(kind).Add(uint64(skipped))
+	fanoutSentBytesTotal.With(kind).Add(uint64(size * recipients))
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	tick := f.tick()
+	tick.Recipients += recipients
+	tick.Skipped += skipped
+	tick.SentBytes += size * recipients
+	f.currentKinds[kind] += size * recipients
+}
+
+// Count a broadcast that found a client's send queue full, which was either held to retry later or dropped
+func (f *Fanout) Backpressured(held bool) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	tick := f.tick()
+	if held {
+		fanoutBackpressuredTotal.With("held").Inc()
+		tick.Held++
+	} else {
+		fanoutBackpressuredTotal.With("dropped").Inc()
+		tick.Dropped++
+	}
+}
+
+func (f *Fanout) Stats() FanoutStats {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.tick()
+
+	stats := FanoutStats{SentBytesByKind: make(map[string]int)}
+	for _, tick := range append(f.history, f.current) {
+		if tick.Broadcasts > 0 || tick.Held > 0 || tick.Dropped > 0 {
+			stats.Ticks = append(stats.Ticks, tick)
+		}
+	}
+	for _, kinds := range append(f.historyKinds, f.currentKinds) {
+		for kind, bytes := range kinds {
+			stats.SentBytesByKind[kind] += bytes
+		}
+	}
+	return stats
+}
+
+// The tick being counted, moving on to a new one first if it's over. Must be called with the lock held.
+func (f *Fanout) tick() *FanoutTick {
+	startedAt := time.Now().Truncate(TickInterval)
+	if f.current.StartedAt.Equal(startedAt) {
+		return &f.current
+	}
+
+	if !f.current.StartedAt.IsZero() {
+		f.history = append(f.history, f.current)
+		f.historyKinds = append(f.historyKinds, f.currentKinds)
+	}
+
+	// Leave out anything too old to be kept, including ticks from before a long quiet spell
+	cutoff := startedAt.Add(-fanoutHistoryTicks * TickInterval)
+	kept := 0
+	for kept < len(f.history) && f.history[kept].StartedAt.Before(cutoff) {
+		kept++
+	}
+	f.history = f.history[kept:]
+	f.historyKinds = f.historyKinds[kept:]
+
+	f.current = FanoutTick{StartedAt: startedAt}
+	f.currentKinds = make(map[string]int)
+	return &f.current
+}
+
+// The message's kind for metrics, e.g. Player for *packets.Packet_Player
+func fanoutKind(message packets.Msg) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", message), "*packets.Packet_")
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"hash/fnv"
+	"maps"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// How often flags are reloaded from the database, so changes made on another shard are picked up
+	featureFlagsRefreshInterval = 10 * time.Second
+
+	// Where a flag's current setting came from
+	FeatureFlagSourceData  = "data"
+	FeatureFlagSourceAdmin = "admin"
+)
+
+// A flag as it's currently set
+type FeatureFlag struct {
+	Id             string
+	Enabled        bool
+	RolloutPercent int64
+	Capability     string
+	Source         string
+
+	// Who last changed it through the admin API, and when, if they have
+	UpdatedBy string
+	UpdatedAt time.Time
+}
+
+// Which features are on for which players, so features can be shipped dark and turned on, gradually if need be,
+// without redeploying anything. Flags are defined in the game data and can be overridden through the admin API, which
+// keeps its settings in the database. Clients are told which flags are on for them when they log in, and again
+// whenever that changes.
+type FeatureFlags struct {
+	hub *Hub
+
+	flags map[string]FeatureFlag
+	mux   sync.RWMutex
+}
+
+func NewFeatureFlags(hub *Hub) *FeatureFlags {
+	f := &FeatureFlags{
+		hub:   hub,
+		flags: make(map[string]FeatureFlag),
+	}
+	f.merge(nil)
+	return f
+}
+
+// Whether the flag is on for the player, given the capabilities their client logged in with
+func (f *FeatureFlags) Enabled(id string, playerDbId int64, capabilities []string) bool {
+	f.mux.RLock()
+	flag, exists := f.flags[id]
+	f.mux.RUnlock()
+	return exists && flag.enabledFor(playerDbId, capabilities)
+}
+
+// Whether seasonal or flagged content is around for the player right now
+func (f *FeatureFlags) Available(availability *gamedata.Availability, playerDbId int64, capabilities []string) bool {
+	if !availability.InSeason(time.Now()) {
+		return false
+	}
+	return availability.RequiresFlag == "" || f.Enabled(availability.RequiresFlag, playerDbId, capabilities)
+}
+
+// The IDs of every flag that's on for the player, in order
+func (f *FeatureFlags) Evaluate(playerDbId int64, capabilities []string) []string {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	enabled := make([]string, 0, len(f.flags))
+	for id, flag := range f.flags {
+		if flag.enabledFor(playerDbId, capabilities) {
+			enabled = append(enabled, id)
+		}
+	}
+	slices.Sort(enabled)
+	return enabled
+}
+
+// Every flag, in order of ID
+func (f *FeatureFlags) List() []FeatureFlag {
+	f.mux.RLock()
+	defer f.mux.RUnlock()
+
+	result := slices.Collect(maps.Values(f.flags))
+	slices.SortFunc(result, func(a, b FeatureFlag) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return result
+}
+
+// Override a flag, or define a new one, which takes effect for everyone online straight away
+func (f *FeatureFlags) Set(admin string, id string, enabled bool, rolloutPercent int64, capability string) error {
+	dbTx := f.hub.NewDbTx()
+	err := dbTx.Queries.UpsertFeatureFlag(dbTx.Ctx, db.UpsertFeatureFlagParams{
+		ID:             id,
+		Enabled:        enabled,
+		RolloutPercent: rolloutPercent,
+		Capability:     capability,
+		UpdatedBy:      admin,
+		UpdatedAt:      time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+	return f.Refresh()
+}
+
+// Remove an override, putting the flag back to how the game data has it, or removing it if it isn't in there.
+// Returns false if there was no override.
+func (f *FeatureFlags) Reset(id string) (bool, error) {
+	dbTx := f.hub.NewDbTx()
+	deleted, err := dbTx.Queries.DeleteFeatureFlag(dbTx.Ctx, id)
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+	return true, f.Refresh()
+}
+
+// Reload the overrides from the database, and tell everyone online if any flag changed
+func (f *FeatureFlags) Refresh() error {
+	dbTx := f.hub.NewDbTx()
+	overrides, err := dbTx.Queries.GetFeatureFlags(dbTx.Ctx)
+	if err != nil {
+		return err
+	}
+
+	if f.merge(overrides) {
+		f.notifyAll()
+	}
+	return nil
+}
+
+func (f *FeatureFlags) refreshLoop() {
+	ticker := time.NewTicker(featureFlagsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if f.hub.dbHealth.Healthy() {
+			if err := f.Refresh(); err != nil {
+				logging.Hub.Errorf("Error refreshing feature flags: %v", err)
+			}
+		}
+		<-ticker.C
+	}
+}
+
+// Set the flags to the game data's with the overrides on top. Returns whether anything changed.
+func (f *FeatureFlags) merge(overrides []db.FeatureFlag) bool {
+	flags := make(map[string]FeatureFlag, len(f.hub.GameData.FeatureFlags)+len(overrides))
+	for _, flag := range f.hub.GameData.FeatureFlags {
+		rolloutPercent := int64(100)
+		if flag.RolloutPercent != nil {
+			rolloutPercent = *flag.RolloutPercent
+		}
+		flags[flag.Id] = FeatureFlag{
+			Id:             flag.Id,
+			Enabled:        flag.Enabled,
+			RolloutPercent: rolloutPercent,
+			Capability:     flag.Capability,
+			Source:         FeatureFlagSourceData,
+		}
+	}
+	for _, override := range overrides {
+		flags[override.ID] = FeatureFlag{
+			Id:             override.ID,
+			Enabled:        override.Enabled,
+			RolloutPercent: override.RolloutPercent,
+			Capability:     override.Capability,
+			Source:         FeatureFlagSourceAdmin,
+			UpdatedBy:      override.UpdatedBy,
+			UpdatedAt:      time.UnixMilli(override.UpdatedAt),
+		}
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	changed := !maps.EqualFunc(f.flags, flags, func(a, b FeatureFlag) bool {
+		return a.Enabled == b.Enabled && a.RolloutPercent == b.RolloutPercent && a.Capability == b.Capability
+	})
+	f.flags = flags
+	return changed
+}
+
+// Have every player in game ask for their flags again
+func (f *FeatureFlags) notifyAll() {
+	var clientIds []uint64
+	f.hub.SharedGameObjects.Players.ForEach(func(clientId uint64, _ *objects.Player) {
+		clientIds = append(clientIds, clientId)
+	})
+	for _, clientId := range clientIds {
+		if client, exists := f.hub.Clients.Get(clientId); exists {
+			client.ProcessMessage(0, packets.NewFeatureFlagsRequest())
+		}
+	}
+}
+
+func (flag FeatureFlag) enabledFor(playerDbId int64, capabilities []string) bool {
+	if !flag.Enabled {
+		return false
+	}
+	if flag.Capability != "" && !slices.Contains(capabilities, flag.Capability) {
+		return false
+	}
+	return rolloutBucket(flag.Id, playerDbId) < flag.RolloutPercent
+}
+
+// Where the player falls from 0 to 99 for the flag. It's the same every time, so a player doesn't flip between having
+// a feature and not, and it differs between flags, so the same players aren't always the first to get everything.
+func rolloutBucket(flagId string, playerDbId int64) int64 {
+	hash := fnv.New32a()
+	hash.Write([]byte(flagId))
+	hash.Write([]byte{':'})
+	hash.Write(strconv.AppendInt(nil, playerDbId, 10))
+	return int64(hash.Sum32() % 100)
+}
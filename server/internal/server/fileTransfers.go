@@ -0,0 +1,175 @@
+package server
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// How big a file can be pushed to clients, unless configured otherwise
+	DefaultMaxFileSize = 1 << 20
+
+	// How much of a file goes in each chunk. One chunk of each transfer is sent a tick, so a transfer to a client
+	// can't crowd out the game.
+	FileChunkSize = 16 * 1024
+
+	// How long clients have to say they got a file after its last chunk was sent
+	fileReceiptTimeout = time.Minute
+)
+
+// What kinds of files can be pushed to clients, unless configured otherwise
+var DefaultFileContentTypes = []string{"application/json", "text/plain", "text/csv"}
+
+// What files pushed to clients can be called, which is all the client has to go on for where to keep them, so they
+// can't have paths in them
+var fileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9_.-]{0,127}$`)
+
+var (
+	ErrFileTooLarge       = errors.New("file is too large")
+	ErrFileTypeNotAllowed = errors.New("file type is not allowed")
+	ErrInvalidFileName    = errors.New("invalid file name")
+)
+
+var fileTransfersTotal = metrics.NewCounterVec("mmo_file_transfers_total", "Files pushed to clients, by how the transfer ended: received, failed, abandoned or unacknowledged.", "result")
+
+// Pushes small data files, like map updates and localization packs, to connected clients over their connections
+// rather than from a CDN. Each file is offered with its size and checksum, then sent in chunks, one a tick, for the
+// client to put back together, check and acknowledge. Only files of allowed types and sizes can be pushed.
+type FileTransfers struct {
+	hub     *Hub
+	maxSize int
+	types   map[string]struct{}
+
+	nextId atomic.Uint64
+
+	// Transfers waiting to be acknowledged by their clients
+	pending map[uint64]*fileTransfer
+	mux     sync.Mutex
+}
+
+type fileTransfer struct {
+	id       uint64
+	clientId uint64
+	name     string
+}
+
+func NewFileTransfers(hub *Hub) *FileTransfers {
+	f := &FileTransfers{hub: hub, pending: make(map[uint64]*fileTransfer)}
+	f.Configure(0, nil)
+	return f
+}
+
+// Must be called before the hub is run. A size of 0 or no types keeps the defaults.
+func (f *FileTransfers) Configure(maxSize int, contentTypes []string) {
+	f.maxSize = DefaultMaxFileSize
+	if maxSize > 0 {
+		f.maxSize = maxSize
+	}
+	if len(contentTypes) == 0 {
+		contentTypes = DefaultFileContentTypes
+	}
+	f.types = make(map[string]struct{}, len(contentTypes))
+	for _, contentType := range contentTypes {
+		f.types[contentType] = struct{}{}
+	}
+}
+
+// Check the file could be pushed to clients
+func (f *FileTransfers) Validate(name string, contentType string, data []byte) error {
+	if !fileNamePattern.MatchString(name) {
+		return ErrInvalidFileName
+	}
+	if _, allowed := f.types[contentType]; !allowed {
+		return ErrFileTypeNotAllowed
+	}
+	if len(data) > f.maxSize {
+		return fmt.Errorf("%w: %d bytes, at most %d", ErrFileTooLarge, len(data), f.maxSize)
+	}
+	return nil
+}
+
+// Start sending the file to each of the clients in the background, returning the transfer ID for each
+func (f *FileTransfers) Push(clients []ClientInterfacer, name string, contentType string, data []byte) ([]uint64, error) {
+	if err := f.Validate(name, contentType, data); err != nil {
+		return nil, err
+	}
+
+	checksum := sha256.Sum256(data)
+	chunks := (len(data) + FileChunkSize - 1) / FileChunkSize
+	transferIds := make([]uint64, len(clients))
+	for i, client := range clients {
+		transfer := &fileTransfer{id: f.nextId.Add(1), clientId: client.Id(), name: name}
+		transferIds[i] = transfer.id
+
+		f.mux.Lock()
+		f.pending[transfer.id] = transfer
+		f.mux.Unlock()
+
+		client.SocketSend(packets.NewFileOffer(transfer.id, name, contentType, uint64(len(data)), uint32(chunks), checksum[:]))
+		go f.send(client, transfer, data, chunks)
+	}
+	logging.Hub.Printf("Pushing %s (%s, %d bytes) to %d clients", name, contentType, len(data), len(clients))
+	return transferIds, nil
+}
+
+func (f *FileTransfers) send(client ClientInterfacer, transfer *fileTransfer, data []byte, chunks int) {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for index := range chunks {
+		// Nothing more to send once the client is gone
+		if current, exists := f.hub.Clients.Get(transfer.clientId); !exists || current != client {
+			f.finish(transfer.id, "abandoned")
+			return
+		}
+
+		end := min((index+1)*FileChunkSize, len(data))
+		client.SocketSend(packets.NewFileChunk(transfer.id, uint32(index), data[index*FileChunkSize:end]))
+		if index < chunks-1 {
+			<-ticker.C
+		}
+	}
+
+	time.AfterFunc(fileReceiptTimeout, func() {
+		if f.finish(transfer.id, "unacknowledged") {
+			logging.Hub.Printf("Client %d never said whether it got %s (transfer %d)", transfer.clientId, transfer.name, transfer.id)
+		}
+	})
+}
+
+// The client says how the transfer went. Clients can only acknowledge their own transfers.
+func (f *FileTransfers) Received(clientId uint64, message *packets.FileReceivedMessage) {
+	f.mux.Lock()
+	transfer, exists := f.pending[message.TransferId]
+	f.mux.Unlock()
+	if !exists || transfer.clientId != clientId {
+		return
+	}
+
+	if message.Ok {
+		f.finish(transfer.id, "received")
+		return
+	}
+	f.finish(transfer.id, "failed")
+	logging.Hub.Printf("Client %d couldn't take %s (transfer %d): %s", clientId, transfer.name, transfer.id, message.Reason)
+}
+
+// Stop waiting on the transfer, returning false if it had already finished
+func (f *FileTransfers) finish(transferId uint64, result string) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if _, exists := f.pending[transferId]; !exists {
+		return false
+	}
+	delete(f.pending, transferId)
+	fileTransfersTotal.With(result).Inc()
+	return true
+}
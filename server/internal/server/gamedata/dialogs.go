@@ -0,0 +1,175 @@
+package gamedata
+
+import (
+	"errors"
+	"fmt"
+)
+
+// A conversation players have with something in the world, as a tree of nodes joined by the choices players make at
+// each. Dialog interactions open one by its ID.
+type Dialog struct {
+	Id string `json:"id"`
+
+	// The node the conversation starts at
+	Start string `json:"start"`
+
+	Nodes     []*DialogNode `json:"nodes"`
+	nodesById map[string]*DialogNode
+}
+
+func (d *Dialog) Node(id string) (*DialogNode, bool) {
+	node, exists := d.nodesById[id]
+	return node, exists
+}
+
+// What's said at one point in a conversation, and what the player can say back
+type DialogNode struct {
+	Id      string `json:"id"`
+	Speaker string `json:"speaker"`
+	Text    string `json:"text"`
+
+	// A node without choices ends the conversation once it's been said
+	Choices []*DialogChoice `json:"choices"`
+
+	// The script hook run when the conversation reaches the node, if any
+	Hook string `json:"hook"`
+}
+
+func (n *DialogNode) Choice(id string) (*DialogChoice, bool) {
+	for _, choice := range n.Choices {
+		if choice.Id == id {
+			return choice, true
+		}
+	}
+	return nil, false
+}
+
+type DialogChoice struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+
+	// The node the conversation goes on to, or empty to end it
+	Next string `json:"next"`
+
+	// Players are only offered the choice, and can only make it, if they meet these
+	Conditions DialogConditions `json:"conditions"`
+
+	// What making the choice does, all at once or not at all
+	Effects DialogEffects `json:"effects"`
+
+	// The script hook run when the choice is made, if any, which can refuse it
+	Hook string `json:"hook"`
+}
+
+// What a player has to have done or have for a choice. Choices with none of these set are always offered.
+type DialogConditions struct {
+	// At least this many of each item
+	Items map[string]int64 `json:"items"`
+
+	// Each quest at exactly this stage, where 0 is not started
+	QuestStages map[string]int64 `json:"quest_stages"`
+
+	// Each quest at this stage or further along
+	MinQuestStages map[string]int64 `json:"min_quest_stages"`
+}
+
+type DialogEffects struct {
+	// Taken from and given to the player's inventory. Items taken must be had, or the choice isn't made.
+	TakeItems map[string]int64 `json:"take_items"`
+	GiveItems map[string]int64 `json:"give_items"`
+
+	// Moves each quest to the stage
+	SetQuestStages map[string]int64 `json:"set_quest_stages"`
+}
+
+func validateDialog(dialog *Dialog) error {
+	if dialog.Id == "" {
+		return errors.New("missing id")
+	}
+	if len(dialog.Nodes) == 0 {
+		return errors.New("no nodes")
+	}
+
+	dialog.nodesById = make(map[string]*DialogNode, len(dialog.Nodes))
+	for _, node := range dialog.Nodes {
+		if node.Id == "" {
+			return errors.New("nodes: node missing id")
+		}
+		if _, exists := dialog.nodesById[node.Id]; exists {
+			return fmt.Errorf("nodes: duplicate node %q", node.Id)
+		}
+		dialog.nodesById[node.Id] = node
+	}
+	if _, exists := dialog.nodesById[dialog.Start]; !exists {
+		return fmt.Errorf("start: no such node %q", dialog.Start)
+	}
+
+	for _, node := range dialog.Nodes {
+		if node.Text == "" {
+			return fmt.Errorf("nodes: %s: missing text", node.Id)
+		}
+		choiceIds := make(map[string]struct{}, len(node.Choices))
+		for _, choice := range node.Choices {
+			if choice.Id == "" {
+				return fmt.Errorf("nodes: %s: choice missing id", node.Id)
+			}
+			if _, exists := choiceIds[choice.Id]; exists {
+				return fmt.Errorf("nodes: %s: duplicate choice %q", node.Id, choice.Id)
+			}
+			choiceIds[choice.Id] = struct{}{}
+			if err := validateDialogChoice(dialog, choice); err != nil {
+				return fmt.Errorf("nodes: %s: choices: %s: %w", node.Id, choice.Id, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateDialogChoice(dialog *Dialog, choice *DialogChoice) error {
+	if choice.Text == "" {
+		return errors.New("missing text")
+	}
+	if _, exists := dialog.nodesById[choice.Next]; choice.Next != "" && !exists {
+		return fmt.Errorf("next: no such node %q", choice.Next)
+	}
+	if err := validateQuantities("conditions.items", choice.Conditions.Items); err != nil {
+		return err
+	}
+	if err := validateQuestStages("conditions.quest_stages", choice.Conditions.QuestStages); err != nil {
+		return err
+	}
+	if err := validateQuestStages("conditions.min_quest_stages", choice.Conditions.MinQuestStages); err != nil {
+		return err
+	}
+	if err := validateQuantities("effects.take_items", choice.Effects.TakeItems); err != nil {
+		return err
+	}
+	if err := validateQuantities("effects.give_items", choice.Effects.GiveItems); err != nil {
+		return err
+	}
+	return validateQuestStages("effects.set_quest_stages", choice.Effects.SetQuestStages)
+}
+
+func validateQuestStages(field string, stages map[string]int64) error {
+	for questId, stage := range stages {
+		if questId == "" {
+			return fmt.Errorf("%s: empty quest id", field)
+		}
+		if stage < 0 {
+			return fmt.Errorf("%s: stage of %q must not be negative", field, questId)
+		}
+	}
+	return nil
+}
+
+// Check every dialog interaction opens a dialog that exists, which can only be done once they're all loaded
+func validateDialogsOpened(gameData *GameData) error {
+	for _, kind := range gameData.ResourceNodeKinds {
+		for verb, interaction := range kind.Interactions {
+			if _, exists := gameData.Dialogs[interaction.Dialog]; interaction.Dialog != "" && !exists {
+				return fmt.Errorf("%s: resource node %q: interactions: %s: no such dialog %q", resourceNodesFile, kind.Id, verb, interaction.Dialog)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1156 @@
+package gamedata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// The item players pay each other with, e.g. on the auction house. It's held in the inventory like any other item.
+const CurrencyItemId = "coins"
+
+var emoteIdPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// A kind of gatherable node placed around the world, e.g. a tree or an ore vein
+type ResourceNodeKind struct {
+	Id             string           `json:"id"`
+	Radius         float64          `json:"radius"`
+	Count          int              `json:"count"`
+	GatherSeconds  float64          `json:"gather_seconds"`
+	RespawnSeconds float64          `json:"respawn_seconds"`
+	Yields         map[string]int64 `json:"yields"`
+
+	// Extra items that may or may not come with each gather
+	BonusYields []*BonusYield `json:"bonus_yields"`
+
+	// What players can do to the kind's nodes, by verb, e.g. "use" or "inspect". DefaultNodeInteractions if left out.
+	Interactions map[string]*Interaction `json:"interactions"`
+
+	// Makes the kind's nodes bosses, which each character can only be given the yields of once until the lockout
+	// resets, either daily or weekly. Not a boss if left out.
+	Lockout string `json:"lockout"`
+
+	// Out of season the kind's nodes stay depleted, and with a flag only players it's on for can gather them
+	Availability
+
+	// What the kind's nodes are tagged with in the world, for scripts to find them by, e.g. "halloween"
+	Tags []string `json:"tags"`
+}
+
+// How often bosses can be looted again
+const (
+	LockoutDaily  = "daily"
+	LockoutWeekly = "weekly"
+)
+
+// The subsystems interactions are handled by
+const (
+	InteractionLoot    = "loot"
+	InteractionShop    = "shop"
+	InteractionDialog  = "dialog"
+	InteractionInspect = "inspect"
+)
+
+// How close, beyond touching, players have to be to interact with something, unless the interaction says otherwise
+const DefaultInteractionRange = 20
+
+var interactionVerbPattern = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// The most tags anything in the world can have
+const MaxTags = 16
+
+// Something players can do to things in the world
+type Interaction struct {
+	// Which subsystem handles it: loot gathers the node, shop opens the store, dialog says the text, and inspect
+	// describes what it is
+	Handler string `json:"handler"`
+
+	// How close, beyond touching, the player has to be, DefaultInteractionRange if left out
+	Range float64 `json:"range"`
+
+	// What's said, for dialog, or shown alongside the description, for inspect
+	Text string `json:"text"`
+
+	// The dialog opened, for dialog, instead of only saying the text
+	Dialog string `json:"dialog"`
+}
+
+// What players can do to resource nodes whose kinds don't say
+var DefaultNodeInteractions = map[string]*Interaction{
+	"use":     {Handler: InteractionLoot},
+	"inspect": {Handler: InteractionInspect},
+}
+
+type BonusYield struct {
+	ItemId   string `json:"item_id"`
+	Quantity int64  `json:"quantity"`
+
+	// From 0 to 1
+	Chance float64 `json:"chance"`
+}
+
+type PointOfInterest struct {
+	Name string  `json:"name"`
+	Kind string  `json:"kind"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+
+	// Only shown on the minimap in season. Since everyone shares the minimap, flags aren't supported.
+	Availability
+}
+
+// A rectangular region of the world
+type Zone struct {
+	Id               string             `json:"id"`
+	Name             string             `json:"name"`
+	MinX             float64            `json:"min_x"`
+	MinY             float64            `json:"min_y"`
+	MaxX             float64            `json:"max_x"`
+	MaxY             float64            `json:"max_y"`
+	PointsOfInterest []*PointOfInterest `json:"points_of_interest"`
+
+	// How many players each channel of the zone holds before more channels are opened for anyone else coming in.
+	// Uncapped if left out.
+	MaxPopulation int `json:"max_population"`
+
+	// A test zone, only reached by GM command, for accounts with the entitlement it requires. Whoever's in it only sees
+	// each other and what they've spawned there, and nothing they do in it is saved.
+	Sandbox bool `json:"sandbox"`
+
+	// Out of season, or for players without the flag, the zone's area is part of the first zone instead
+	Availability
+}
+
+func (z *Zone) Contains(x, y float64) bool {
+	return x >= z.MinX && x < z.MaxX && y >= z.MinY && y < z.MaxY
+}
+
+// One step of the tutorial new players are taken through, identified by ID so steps can be reordered without
+// losing track of who's done which
+type OnboardingStep struct {
+	Id     string `json:"id"`
+	Prompt string `json:"prompt"`
+}
+
+// One of what players are shown after logging in, in order, before they're let into the world, e.g. a welcome or the
+// server's rules. Each account's shown each step once per version, so changing one enough to need reading again only
+// takes raising its version.
+type WelcomeStep struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	Text  string `json:"text"`
+
+	// 1 if left out
+	Version int64 `json:"version"`
+
+	// Whether players have to accept it to play, e.g. the rules or terms of service. Steps that aren't only need
+	// dismissing.
+	Required bool `json:"required"`
+
+	// What the buttons say, "Accept" and "Decline" if left out, or "Continue" for steps that aren't required
+	AcceptLabel  string `json:"accept_label"`
+	DeclineLabel string `json:"decline_label"`
+}
+
+// An ambient emitter placed in the world, e.g. a campfire or a waterfall, which clients play a positional sound or
+// effect for while they're near it
+type Emitter struct {
+	// What it is, for clients to tell emitters apart, e.g. "campfire"
+	Kind string  `json:"kind"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+
+	// How far it's heard or seen from its position
+	Radius float64 `json:"radius"`
+
+	// The client's assets to play, at least one of them
+	Sound  string `json:"sound"`
+	Effect string `json:"effect"`
+
+	// Between 0 and 1, 1 if left out
+	Volume float64 `json:"volume"`
+
+	// What it's tagged with in the world, for scripts to find it by
+	Tags []string `json:"tags"`
+}
+
+// What killing something a world simulator drives is worth, shared between everyone who damaged it by how much of the
+// damage they did rather than going to whoever dealt the last hit
+type KillReward struct {
+	Id string `json:"id"`
+
+	// Grown by, split between everyone who did damage by their share of it
+	Mass float64 `json:"mass"`
+
+	// Given in full to everyone whose share of the damage earns loot rights
+	Items map[string]int64 `json:"items"`
+
+	// Set for everyone whose share earns quest credit, unless they're further along already
+	QuestStages map[string]int64 `json:"quest_stages"`
+
+	// The share of the damage, between 0 and 1, needed for loot rights and quest credit. 0.1 if left out.
+	MinShare float64 `json:"min_share"`
+}
+
+// A scripted test for the sandbox: waves of actors spawned around whoever runs it, and items for them to test with
+type SandboxScenario struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	Waves []*SandboxWave `json:"waves"`
+
+	// Given to whoever runs it, only for as long as they're in the sandbox
+	Items map[string]int64 `json:"items"`
+}
+
+// Actors spawned together, standing still in a ring around whoever spawned them
+type SandboxWave struct {
+	Name   string  `json:"name"`
+	Count  int     `json:"count"`
+	Radius float64 `json:"radius"`
+	Color  int32   `json:"color"`
+
+	// How far from whoever spawned them, edge to edge. 200 if left out.
+	Distance float64 `json:"distance"`
+
+	// How long after the scenario's started
+	DelaySeconds float64 `json:"delay_seconds"`
+}
+
+// A chat emote or sticker, written in chat as :id:
+type Emote struct {
+	Id string `json:"id"`
+
+	// Which client asset to show for the emote, so the client doesn't need to know the registry by heart
+	Asset string `json:"asset"`
+
+	Unlock EmoteUnlock `json:"unlock"`
+
+	// Out of season, or for players without the flag, the emote is locked whatever it takes to unlock it
+	Availability
+}
+
+// What it takes to be allowed to use an emote. Emotes with none of these set can be used by everyone.
+type EmoteUnlock struct {
+	BestScore int64  `json:"best_score"`
+	ItemId    string `json:"item_id"`
+
+	// Only unlocked when granted to the player, e.g. after buying it
+	Granted bool `json:"granted"`
+}
+
+// Something cosmetic players can buy, for coins or through an app store
+type StoreEntry struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// What buying it grants, either an emote or an entitlement for the whole account, e.g. DLC
+	EmoteId     string `json:"emote_id"`
+	Entitlement string `json:"entitlement"`
+
+	// What it costs in coins, or 0 if it can only be bought through an app store
+	Price int64 `json:"price"`
+
+	// What it's sold as on each app store it's on, by platform, e.g. {"steam": "1234", "google": "crown_emote"}
+	Products map[string]string `json:"products"`
+
+	// Out of season, or for players without the flag, the entry isn't for sale
+	Availability
+}
+
+// What buying the entry grants, for logs
+func (e *StoreEntry) Grants() string {
+	if e.Entitlement != "" {
+		return "entitlement " + e.Entitlement
+	}
+	return "emote " + e.EmoteId
+}
+
+// Something an account can be granted, e.g. DLC, that content can require with requires_entitlement
+type Entitlement struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+
+	// How many more characters the account can have with it
+	CharacterSlots int `json:"character_slots"`
+
+	// Whether the account can log in when the server's full, e.g. for staff and supporters
+	PriorityLogin bool `json:"priority_login"`
+}
+
+// Words that can't be in players' names, in one language. Names are checked against every language's words, whatever
+// language the player's in, since everyone sees everyone else's names.
+type ProfanityList struct {
+	Language string   `json:"language"`
+	Words    []string `json:"words"`
+}
+
+// A flag turning a feature on for some or all players, so it can be shipped dark and turned on later. Flags can also
+// be set through the admin API, which overrides what's defined here.
+type FeatureFlag struct {
+	Id      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+
+	// The share of accounts the flag is on for when enabled, from 0 to 100. Each account always falls on the same side
+	// of the line for a given flag, so raising it only ever turns the flag on for more accounts. Everyone if left out.
+	RolloutPercent *int64 `json:"rollout_percent"`
+
+	// Only on for clients that said they support this when logging in, if set
+	Capability string `json:"capability"`
+}
+
+// What an item takes up in a player's inventory. Items that aren't defined stack without limit and weigh nothing, and
+// coins never take up any room, whatever they're defined as.
+type Item struct {
+	Id string `json:"id"`
+
+	// The most of the item one inventory slot holds, with no limit if left out
+	StackSize int64 `json:"stack_size"`
+
+	// What each one weighs, against the most players can carry
+	Weight float64 `json:"weight"`
+}
+
+type Recipe struct {
+	Id          string           `json:"id"`
+	Ingredients map[string]int64 `json:"ingredients"`
+	Outputs     map[string]int64 `json:"outputs"`
+
+	// Out of season, or for players without the flag, the recipe can't be crafted
+	Availability
+}
+
+// When seasonal or holiday content is around, and who for, so it can be in the game data all year and come and go by
+// itself. Content with none of these set is always around for everyone. The dates are checked by the content itself,
+// and the flag by the server for each player, see FeatureFlags.Available.
+type Availability struct {
+	// Around from and until these times, either of which can be left out, e.g. "2024-12-01T00:00:00Z"
+	AvailableFrom  *time.Time `json:"available_from"`
+	AvailableUntil *time.Time `json:"available_until"`
+
+	// Around between these days every year in UTC, both included, as MM-DD, e.g. "12-20" to "01-06" over new year
+	SeasonStart string `json:"season_start"`
+	SeasonEnd   string `json:"season_end"`
+
+	// Only around for players this feature flag is on for
+	RequiresFlag string `json:"requires_flag"`
+
+	// Only around for accounts with this entitlement, e.g. DLC they've bought
+	RequiresEntitlement string `json:"requires_entitlement"`
+
+	// The season parsed as month*100 + day, or 0 for all year
+	seasonStart int
+	seasonEnd   int
+}
+
+// Whether the content is around at the given time, going by the dates alone
+func (a *Availability) InSeason(now time.Time) bool {
+	if a.AvailableFrom != nil && now.Before(*a.AvailableFrom) {
+		return false
+	}
+	if a.AvailableUntil != nil && !now.Before(*a.AvailableUntil) {
+		return false
+	}
+	if a.seasonStart == 0 {
+		return true
+	}
+
+	now = now.UTC()
+	today := int(now.Month())*100 + now.Day()
+	if a.seasonStart <= a.seasonEnd {
+		return today >= a.seasonStart && today <= a.seasonEnd
+	}
+	return today >= a.seasonStart || today <= a.seasonEnd
+}
+
+func (a *Availability) gated() bool {
+	return a.AvailableFrom != nil || a.AvailableUntil != nil || a.SeasonStart != "" || a.SeasonEnd != "" || a.RequiresFlag != "" || a.RequiresEntitlement != ""
+}
+
+// Game content loaded from JSON files in the data directory
+type GameData struct {
+	ResourceNodeKinds map[string]*ResourceNodeKind
+	Recipes           map[string]*Recipe
+	Items             map[string]*Item
+
+	// In the order they're defined, which is also the order they're matched in
+	Zones []*Zone
+
+	// In the order players are prompted to do them
+	OnboardingSteps []*OnboardingStep
+
+	// In the order they're shown to players
+	Emotes     []*Emote
+	emotesById map[string]*Emote
+
+	FeatureFlags []*FeatureFlag
+
+	// The files all of this was loaded from, which clients need the same copy of
+	Pack *DataPack
+
+	// In the order they're shown to players
+	Store          []*StoreEntry
+	storeEntryById map[string]*StoreEntry
+
+	Entitlements     []*Entitlement
+	entitlementsById map[string]*Entitlement
+
+	Profanity []*ProfanityList
+
+	Dialogs map[string]*Dialog
+
+	// In the order players are shown them
+	Welcome []*WelcomeStep
+
+	Emitters []*Emitter
+
+	KillRewards map[string]*KillReward
+
+	SandboxScenarios map[string]*SandboxScenario
+
+	// Every language's words, normalized the way text is before it's checked against them
+	profaneWords []string
+}
+
+func (d *GameData) Entitlement(id string) (*Entitlement, bool) {
+	entitlement, exists := d.entitlementsById[id]
+	return entitlement, exists
+}
+
+func (d *GameData) StoreEntry(id string) (*StoreEntry, bool) {
+	entry, exists := d.storeEntryById[id]
+	return entry, exists
+}
+
+func (d *GameData) Emote(id string) (*Emote, bool) {
+	emote, exists := d.emotesById[id]
+	return emote, exists
+}
+
+// The zone used when no zones are defined, covering the area players normally spawn in
+var defaultZone = &Zone{Id: "main", Name: "Main", MinX: -3000, MinY: -3000, MaxX: 3000, MaxY: 3000}
+
+// The first zone in season containing the given position. Positions outside every zone belong to the first zone, so
+// everything is always in some zone.
+func (d *GameData) ZoneAt(x, y float64) *Zone {
+	return d.ZoneAtWhere(x, y, nil)
+}
+
+// Like ZoneAt, also skipping zones that aren't available, e.g. to the player in question. The first zone is always
+// available.
+func (d *GameData) ZoneAtWhere(x, y float64, available func(*Zone) bool) *Zone {
+	now := time.Now()
+	for _, zone := range d.Zones {
+		if zone.Contains(x, y) && zone.InSeason(now) && (available == nil || available(zone)) {
+			return zone
+		}
+	}
+	return d.Zones[0]
+}
+
+func (d *GameData) Zone(id string) (*Zone, bool) {
+	for _, zone := range d.Zones {
+		if zone.Id == id {
+			return zone, true
+		}
+	}
+	return nil, false
+}
+
+const (
+	resourceNodesFile = "resource_nodes.json"
+	recipesFile       = "recipes.json"
+	zonesFile         = "zones.json"
+	onboardingFile    = "onboarding.json"
+	emotesFile        = "emotes.json"
+	featureFlagsFile  = "feature_flags.json"
+	storeFile         = "store.json"
+	entitlementsFile  = "entitlements.json"
+	itemsFile         = "items.json"
+
+	// Only the server needs these, so they aren't part of the data pack and clients never have to download them.
+	// Dialogs are sent to players a node at a time, so what's further along in them can't be read ahead of time.
+	// Welcome steps are shown before players are in the world, so they can change without clients updating first.
+	// Emitters are sent to players as they come near them, like anything else in the world. Kill rewards are only
+	// named by world simulators, and players are told what they got. Sandbox scenarios are only run by GMs.
+	profanityFile        = "profanity.json"
+	dialogsFile          = "dialogs.json"
+	welcomeFile          = "welcome.json"
+	emittersFile         = "emitters.json"
+	killRewardsFile      = "kill_rewards.json"
+	sandboxScenariosFile = "sandbox_scenarios.json"
+)
+
+// The share of the damage needed for loot rights and quest credit, unless a kill reward says otherwise
+const defaultKillRewardMinShare = 0.1
+
+// How far from whoever spawned them, edge to edge, a sandbox wave's actors are, unless it says otherwise
+const DefaultSandboxWaveDistance = 200
+
+// The most actors one sandbox wave can have
+const MaxSandboxWaveCount = 200
+
+// Load all game data files from the data directory. Missing files are treated as empty, so a server without any
+// game data still runs, just without those features.
+func Load(dataDirPath string) (*GameData, error) {
+	pack, err := loadPack(dataDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	gameData, err := loadContent(dataDirPath)
+	if err != nil {
+		return nil, err
+	}
+	gameData.Pack = pack
+	return gameData, nil
+}
+
+// Check the game data files in the data directory the same way the server does when it starts, without needing them
+// to have been released, so they can be checked before they are
+func Validate(dataDirPath string) error {
+	_, err := loadContent(dataDirPath)
+	return err
+}
+
+func loadContent(dataDirPath string) (*GameData, error) {
+	gameData := &GameData{
+		ResourceNodeKinds: make(map[string]*ResourceNodeKind),
+		Recipes:           make(map[string]*Recipe),
+		Items:             make(map[string]*Item),
+		emotesById:        make(map[string]*Emote),
+		storeEntryById:    make(map[string]*StoreEntry),
+		entitlementsById:  make(map[string]*Entitlement),
+		Dialogs:           make(map[string]*Dialog),
+	}
+
+	// First, as content everywhere else can require them
+	file, err := loadFile(path.Join(dataDirPath, entitlementsFile), &gameData.Entitlements)
+	if err != nil {
+		return nil, err
+	}
+	for i, entitlement := range gameData.Entitlements {
+		if entitlement.Id == "" {
+			return nil, fmt.Errorf("%s: entitlement missing id", file.at(i))
+		}
+		if entitlement.CharacterSlots < 0 {
+			return nil, fmt.Errorf("%s: entitlement %q: character_slots must not be negative", file.at(i), entitlement.Id)
+		}
+		if _, exists := gameData.entitlementsById[entitlement.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate entitlement %q", file.at(i), entitlement.Id)
+		}
+		gameData.entitlementsById[entitlement.Id] = entitlement
+	}
+
+	var kinds []*ResourceNodeKind
+	if file, err = loadFile(path.Join(dataDirPath, resourceNodesFile), &kinds); err != nil {
+		return nil, err
+	}
+	for i, kind := range kinds {
+		if kind.Interactions == nil {
+			kind.Interactions = DefaultNodeInteractions
+		}
+		if err := validateResourceNodeKind(kind); err != nil {
+			return nil, fmt.Errorf("%s: resource node %q: %w", file.at(i), kind.Id, err)
+		}
+		if _, exists := gameData.ResourceNodeKinds[kind.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate resource node %q", file.at(i), kind.Id)
+		}
+		gameData.ResourceNodeKinds[kind.Id] = kind
+	}
+
+	var recipes []*Recipe
+	if file, err = loadFile(path.Join(dataDirPath, recipesFile), &recipes); err != nil {
+		return nil, err
+	}
+	for i, recipe := range recipes {
+		if err := validateRecipe(recipe); err != nil {
+			return nil, fmt.Errorf("%s: recipe %q: %w", file.at(i), recipe.Id, err)
+		}
+		if _, exists := gameData.Recipes[recipe.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate recipe %q", file.at(i), recipe.Id)
+		}
+		gameData.Recipes[recipe.Id] = recipe
+	}
+
+	var items []*Item
+	if file, err = loadFile(path.Join(dataDirPath, itemsFile), &items); err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		if err := validateItem(item); err != nil {
+			return nil, fmt.Errorf("%s: item %q: %w", file.at(i), item.Id, err)
+		}
+		if _, exists := gameData.Items[item.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate item %q", file.at(i), item.Id)
+		}
+		gameData.Items[item.Id] = item
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, zonesFile), &gameData.Zones); err != nil {
+		return nil, err
+	}
+	zoneIds := make(map[string]struct{}, len(gameData.Zones))
+	for i, zone := range gameData.Zones {
+		if err := validateZone(zone); err != nil {
+			return nil, fmt.Errorf("%s: zone %q: %w", file.at(i), zone.Id, err)
+		}
+		if _, exists := zoneIds[zone.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate zone %q", file.at(i), zone.Id)
+		}
+		zoneIds[zone.Id] = struct{}{}
+	}
+	if len(gameData.Zones) == 0 {
+		gameData.Zones = []*Zone{defaultZone}
+	}
+	if gameData.Zones[0].gated() {
+		return nil, fmt.Errorf("%s: zone %q: the first zone is where everything outside the others is, so it's always available", file.at(0), gameData.Zones[0].Id)
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, onboardingFile), &gameData.OnboardingSteps); err != nil {
+		return nil, err
+	}
+	stepIds := make(map[string]struct{}, len(gameData.OnboardingSteps))
+	for i, step := range gameData.OnboardingSteps {
+		if step.Id == "" {
+			return nil, fmt.Errorf("%s: onboarding step missing id", file.at(i))
+		}
+		if _, exists := stepIds[step.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate onboarding step %q", file.at(i), step.Id)
+		}
+		stepIds[step.Id] = struct{}{}
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, emotesFile), &gameData.Emotes); err != nil {
+		return nil, err
+	}
+	for i, emote := range gameData.Emotes {
+		if !emoteIdPattern.MatchString(emote.Id) {
+			return nil, fmt.Errorf("%s: emote %q: id must be lowercase letters, digits and underscores", file.at(i), emote.Id)
+		}
+		if err := validateAvailability(&emote.Availability); err != nil {
+			return nil, fmt.Errorf("%s: emote %q: %w", file.at(i), emote.Id, err)
+		}
+		if _, exists := gameData.emotesById[emote.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate emote %q", file.at(i), emote.Id)
+		}
+		gameData.emotesById[emote.Id] = emote
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, featureFlagsFile), &gameData.FeatureFlags); err != nil {
+		return nil, err
+	}
+	flagIds := make(map[string]struct{}, len(gameData.FeatureFlags))
+	for i, flag := range gameData.FeatureFlags {
+		if flag.Id == "" {
+			return nil, fmt.Errorf("%s: feature flag missing id", file.at(i))
+		}
+		if flag.RolloutPercent != nil && (*flag.RolloutPercent < 0 || *flag.RolloutPercent > 100) {
+			return nil, fmt.Errorf("%s: feature flag %q: rollout_percent must be between 0 and 100", file.at(i), flag.Id)
+		}
+		if _, exists := flagIds[flag.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate feature flag %q", file.at(i), flag.Id)
+		}
+		flagIds[flag.Id] = struct{}{}
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, storeFile), &gameData.Store); err != nil {
+		return nil, err
+	}
+	for i, entry := range gameData.Store {
+		if err := validateStoreEntry(entry, gameData); err != nil {
+			return nil, fmt.Errorf("%s: store entry %q: %w", file.at(i), entry.Id, err)
+		}
+		if _, exists := gameData.storeEntryById[entry.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate store entry %q", file.at(i), entry.Id)
+		}
+		gameData.storeEntryById[entry.Id] = entry
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, profanityFile), &gameData.Profanity); err != nil {
+		return nil, err
+	}
+	languages := make(map[string]struct{}, len(gameData.Profanity))
+	for i, list := range gameData.Profanity {
+		if list.Language == "" {
+			return nil, fmt.Errorf("%s: profanity list missing language", file.at(i))
+		}
+		if _, exists := languages[list.Language]; exists {
+			return nil, fmt.Errorf("%s: duplicate profanity list %q", file.at(i), list.Language)
+		}
+		languages[list.Language] = struct{}{}
+		for _, word := range list.Words {
+			normalized := normalizeProfanity(word)
+			if normalized == "" {
+				return nil, fmt.Errorf("%s: profanity list %q: %q has no letters in it, so it would match everything", file.at(i), list.Language, word)
+			}
+			gameData.profaneWords = append(gameData.profaneWords, normalized)
+		}
+	}
+
+	var dialogs []*Dialog
+	if file, err = loadFile(path.Join(dataDirPath, dialogsFile), &dialogs); err != nil {
+		return nil, err
+	}
+	for i, dialog := range dialogs {
+		if err := validateDialog(dialog); err != nil {
+			return nil, fmt.Errorf("%s: dialog %q: %w", file.at(i), dialog.Id, err)
+		}
+		if _, exists := gameData.Dialogs[dialog.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate dialog %q", file.at(i), dialog.Id)
+		}
+		gameData.Dialogs[dialog.Id] = dialog
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, welcomeFile), &gameData.Welcome); err != nil {
+		return nil, err
+	}
+	welcomeIds := make(map[string]struct{}, len(gameData.Welcome))
+	for i, step := range gameData.Welcome {
+		if step.Version == 0 {
+			step.Version = 1
+		}
+		if err := validateWelcomeStep(step); err != nil {
+			return nil, fmt.Errorf("%s: welcome step %q: %w", file.at(i), step.Id, err)
+		}
+		if step.AcceptLabel == "" {
+			step.AcceptLabel = "Continue"
+			if step.Required {
+				step.AcceptLabel = "Accept"
+			}
+		}
+		if step.DeclineLabel == "" && step.Required {
+			step.DeclineLabel = "Decline"
+		}
+		if _, exists := welcomeIds[step.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate welcome step %q", file.at(i), step.Id)
+		}
+		welcomeIds[step.Id] = struct{}{}
+	}
+
+	if file, err = loadFile(path.Join(dataDirPath, emittersFile), &gameData.Emitters); err != nil {
+		return nil, err
+	}
+	for i, emitter := range gameData.Emitters {
+		if emitter.Volume == 0 {
+			emitter.Volume = 1
+		}
+		if err := ValidateEmitter(emitter); err != nil {
+			return nil, fmt.Errorf("%s: emitter %q: %w", file.at(i), emitter.Kind, err)
+		}
+	}
+
+	var killRewards []*KillReward
+	if file, err = loadFile(path.Join(dataDirPath, killRewardsFile), &killRewards); err != nil {
+		return nil, err
+	}
+	gameData.KillRewards = make(map[string]*KillReward, len(killRewards))
+	for i, reward := range killRewards {
+		if reward.MinShare == 0 {
+			reward.MinShare = defaultKillRewardMinShare
+		}
+		if err := validateKillReward(reward); err != nil {
+			return nil, fmt.Errorf("%s: kill reward %q: %w", file.at(i), reward.Id, err)
+		}
+		if _, exists := gameData.KillRewards[reward.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate kill reward %q", file.at(i), reward.Id)
+		}
+		gameData.KillRewards[reward.Id] = reward
+	}
+
+	var scenarios []*SandboxScenario
+	if file, err = loadFile(path.Join(dataDirPath, sandboxScenariosFile), &scenarios); err != nil {
+		return nil, err
+	}
+	gameData.SandboxScenarios = make(map[string]*SandboxScenario, len(scenarios))
+	for i, scenario := range scenarios {
+		for _, wave := range scenario.Waves {
+			if wave.Distance == 0 {
+				wave.Distance = DefaultSandboxWaveDistance
+			}
+		}
+		if err := validateSandboxScenario(scenario); err != nil {
+			return nil, fmt.Errorf("%s: sandbox scenario %q: %w", file.at(i), scenario.Id, err)
+		}
+		if _, exists := gameData.SandboxScenarios[scenario.Id]; exists {
+			return nil, fmt.Errorf("%s: duplicate sandbox scenario %q", file.at(i), scenario.Id)
+		}
+		gameData.SandboxScenarios[scenario.Id] = scenario
+	}
+
+	if err := validateEntitlementsRequired(gameData); err != nil {
+		return nil, err
+	}
+	if err := validateDialogsOpened(gameData); err != nil {
+		return nil, err
+	}
+
+	return gameData, nil
+}
+
+// Check everything that requires an entitlement requires one that exists, which can only be done once it's all loaded
+func validateEntitlementsRequired(gameData *GameData) error {
+	check := func(file string, kind string, id string, availability *Availability) error {
+		required := availability.RequiresEntitlement
+		if _, exists := gameData.Entitlement(required); required != "" && !exists {
+			return fmt.Errorf("%s: %s %q: no such entitlement %q", file, kind, id, required)
+		}
+		return nil
+	}
+
+	for _, kind := range gameData.ResourceNodeKinds {
+		if err := check(resourceNodesFile, "resource node", kind.Id, &kind.Availability); err != nil {
+			return err
+		}
+	}
+	for _, recipe := range gameData.Recipes {
+		if err := check(recipesFile, "recipe", recipe.Id, &recipe.Availability); err != nil {
+			return err
+		}
+	}
+	for _, zone := range gameData.Zones {
+		if err := check(zonesFile, "zone", zone.Id, &zone.Availability); err != nil {
+			return err
+		}
+		for _, poi := range zone.PointsOfInterest {
+			if err := check(zonesFile, "point of interest", poi.Name, &poi.Availability); err != nil {
+				return err
+			}
+		}
+	}
+	for _, emote := range gameData.Emotes {
+		if err := check(emotesFile, "emote", emote.Id, &emote.Availability); err != nil {
+			return err
+		}
+	}
+	for _, entry := range gameData.Store {
+		if err := check(storeFile, "store entry", entry.Id, &entry.Availability); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load the file into a pointer to a list, after checking it's the shape the list's items expect, see checkSchema.
+// Errors about the items after that can say where they are with the file's at.
+func loadFile(filePath string, into any) (*dataFile, error) {
+	file := &dataFile{name: path.Base(filePath)}
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	file.data = data
+
+	if err := checkSchema(file, reflect.TypeOf(into).Elem()); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, into); err != nil {
+		return nil, fmt.Errorf("%s: %w", file.name, err)
+	}
+	return file, nil
+}
+
+func validateResourceNodeKind(kind *ResourceNodeKind) error {
+	if kind.Id == "" {
+		return errors.New("missing id")
+	}
+	if kind.Radius <= 0 {
+		return errors.New("radius must be positive")
+	}
+	if kind.Count < 0 {
+		return errors.New("count must not be negative")
+	}
+	if kind.GatherSeconds < 0 || kind.RespawnSeconds < 0 {
+		return errors.New("gather and respawn times must not be negative")
+	}
+	if err := validateAvailability(&kind.Availability); err != nil {
+		return err
+	}
+	switch kind.Lockout {
+	case "", LockoutDaily, LockoutWeekly:
+	default:
+		return fmt.Errorf("unknown lockout %q, expected %s or %s", kind.Lockout, LockoutDaily, LockoutWeekly)
+	}
+	for verb, interaction := range kind.Interactions {
+		if err := validateInteraction(verb, interaction); err != nil {
+			return fmt.Errorf("interactions: %s: %w", verb, err)
+		}
+	}
+	for _, bonus := range kind.BonusYields {
+		if bonus.ItemId == "" || bonus.Quantity <= 0 {
+			return errors.New("bonus_yields: every bonus needs an item_id and a positive quantity")
+		}
+		if bonus.Chance < 0 || bonus.Chance > 1 {
+			return fmt.Errorf("bonus_yields: %s: chance must be between 0 and 1", bonus.ItemId)
+		}
+	}
+	if err := ValidateTags(kind.Tags); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+	return validateQuantities("yields", kind.Yields)
+}
+
+// Also used for tags given to things in the world by scripts and simulators rather than from a file
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("no more than %d tags", MaxTags)
+	}
+	for _, tag := range tags {
+		if !emoteIdPattern.MatchString(tag) {
+			return fmt.Errorf("tag %q: tags must be lowercase letters, digits and underscores, starting with a letter", tag)
+		}
+	}
+	return nil
+}
+
+func validateWelcomeStep(step *WelcomeStep) error {
+	if !emoteIdPattern.MatchString(step.Id) {
+		return errors.New("ids must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if step.Title == "" && step.Text == "" {
+		return errors.New("needs a title or text")
+	}
+	if step.Version < 1 {
+		return errors.New("version must be positive")
+	}
+	if step.DeclineLabel != "" && !step.Required {
+		return errors.New("only required steps can be declined")
+	}
+	return nil
+}
+
+// Also used for emitters placed through the admin API, which aren't loaded from a file
+func ValidateEmitter(emitter *Emitter) error {
+	if !emoteIdPattern.MatchString(emitter.Kind) {
+		return errors.New("kinds must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if emitter.Radius <= 0 {
+		return errors.New("radius must be positive")
+	}
+	if emitter.Sound == "" && emitter.Effect == "" {
+		return errors.New("needs a sound or an effect")
+	}
+	if emitter.Volume < 0 || emitter.Volume > 1 {
+		return errors.New("volume must be between 0 and 1")
+	}
+	if err := ValidateTags(emitter.Tags); err != nil {
+		return fmt.Errorf("tags: %w", err)
+	}
+	return nil
+}
+
+func validateKillReward(reward *KillReward) error {
+	if reward.Id == "" {
+		return errors.New("missing id")
+	}
+	if reward.Mass < 0 {
+		return errors.New("mass must not be negative")
+	}
+	for itemId, quantity := range reward.Items {
+		if quantity <= 0 {
+			return fmt.Errorf("quantity of %q must be positive", itemId)
+		}
+	}
+	if err := validateQuestStages("quest_stages", reward.QuestStages); err != nil {
+		return err
+	}
+	if reward.MinShare < 0 || reward.MinShare > 1 {
+		return errors.New("min_share must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateSandboxScenario(scenario *SandboxScenario) error {
+	if scenario.Id == "" {
+		return errors.New("missing id")
+	}
+	if len(scenario.Waves) == 0 && len(scenario.Items) == 0 {
+		return errors.New("no waves or items")
+	}
+	for i, wave := range scenario.Waves {
+		if err := ValidateSandboxWave(wave); err != nil {
+			return fmt.Errorf("waves: %d: %w", i, err)
+		}
+	}
+	return validateQuantities("items", scenario.Items)
+}
+
+// Also used for waves spawned by GM command rather than from a scenario
+func ValidateSandboxWave(wave *SandboxWave) error {
+	if wave.Count <= 0 || wave.Count > MaxSandboxWaveCount {
+		return fmt.Errorf("count must be between 1 and %d", MaxSandboxWaveCount)
+	}
+	if wave.Radius <= 0 {
+		return errors.New("radius must be positive")
+	}
+	if wave.Distance < 0 {
+		return errors.New("distance must not be negative")
+	}
+	if wave.DelaySeconds < 0 {
+		return errors.New("delay_seconds must not be negative")
+	}
+	return nil
+}
+
+func validateInteraction(verb string, interaction *Interaction) error {
+	if !interactionVerbPattern.MatchString(verb) {
+		return errors.New("verbs must be lowercase letters and underscores")
+	}
+	if interaction == nil {
+		return errors.New("missing handler")
+	}
+	if interaction.Dialog != "" && interaction.Handler != InteractionDialog {
+		return errors.New("only the dialog handler opens a dialog")
+	}
+	switch interaction.Handler {
+	case InteractionLoot, InteractionShop, InteractionInspect:
+	case InteractionDialog:
+		if interaction.Text == "" && interaction.Dialog == "" {
+			return errors.New("dialog needs text or a dialog")
+		}
+	default:
+		return fmt.Errorf("unknown handler %q", interaction.Handler)
+	}
+	if interaction.Range < 0 {
+		return errors.New("range must not be negative")
+	}
+	if interaction.Range == 0 {
+		interaction.Range = DefaultInteractionRange
+	}
+	return nil
+}
+
+func validateRecipe(recipe *Recipe) error {
+	if recipe.Id == "" {
+		return errors.New("missing id")
+	}
+	if len(recipe.Outputs) == 0 {
+		return errors.New("no outputs")
+	}
+	if err := validateAvailability(&recipe.Availability); err != nil {
+		return err
+	}
+	if err := validateQuantities("ingredients", recipe.Ingredients); err != nil {
+		return err
+	}
+	return validateQuantities("outputs", recipe.Outputs)
+}
+
+func validateItem(item *Item) error {
+	if item.Id == "" {
+		return errors.New("missing id")
+	}
+	if item.StackSize < 0 {
+		return errors.New("stack_size must not be negative")
+	}
+	if item.Weight < 0 {
+		return errors.New("weight must not be negative")
+	}
+	if item.Id == CurrencyItemId && (item.StackSize != 0 || item.Weight != 0) {
+		return errors.New("coins never take up room, so can't have a stack_size or weight")
+	}
+	return nil
+}
+
+func validateZone(zone *Zone) error {
+	if zone.Id == "" {
+		return errors.New("missing id")
+	}
+	if zone.MinX >= zone.MaxX || zone.MinY >= zone.MaxY {
+		return errors.New("bounds are empty")
+	}
+	if zone.MaxPopulation < 0 {
+		return errors.New("max_population must not be negative")
+	}
+	if zone.Sandbox && zone.RequiresEntitlement == "" {
+		return errors.New("sandbox zones must require an entitlement, so only those trusted with one can get in")
+	}
+	for _, poi := range zone.PointsOfInterest {
+		if poi.RequiresFlag != "" {
+			return fmt.Errorf("points_of_interest: %s: requires_flag isn't supported, since everyone sees the same minimap", poi.Name)
+		}
+		if err := validateAvailability(&poi.Availability); err != nil {
+			return fmt.Errorf("points_of_interest: %s: %w", poi.Name, err)
+		}
+	}
+	return validateAvailability(&zone.Availability)
+}
+
+func validateStoreEntry(entry *StoreEntry, gameData *GameData) error {
+	if entry.Id == "" {
+		return errors.New("missing id")
+	}
+	if (entry.EmoteId == "") == (entry.Entitlement == "") {
+		return errors.New("must grant either an emote_id or an entitlement")
+	}
+	if _, exists := gameData.Emote(entry.EmoteId); entry.EmoteId != "" && !exists {
+		return fmt.Errorf("no such emote %q", entry.EmoteId)
+	}
+	if _, exists := gameData.Entitlement(entry.Entitlement); entry.Entitlement != "" && !exists {
+		return fmt.Errorf("no such entitlement %q", entry.Entitlement)
+	}
+	if entry.Price < 0 {
+		return errors.New("price must not be negative")
+	}
+	if entry.Price == 0 && len(entry.Products) == 0 {
+		return errors.New("needs a price or products on an app store, or it can't be bought")
+	}
+	for platform, product := range entry.Products {
+		if platform == "" || product == "" {
+			return errors.New("products: every product needs a platform and an ID")
+		}
+	}
+	return validateAvailability(&entry.Availability)
+}
+
+func validateAvailability(availability *Availability) error {
+	from, until := availability.AvailableFrom, availability.AvailableUntil
+	if from != nil && until != nil && !from.Before(*until) {
+		return errors.New("available_from must be before available_until")
+	}
+	if (availability.SeasonStart == "") != (availability.SeasonEnd == "") {
+		return errors.New("season_start and season_end must be set together")
+	}
+	if availability.SeasonStart == "" {
+		return nil
+	}
+
+	var err error
+	if availability.seasonStart, err = parseSeasonDay(availability.SeasonStart); err != nil {
+		return fmt.Errorf("season_start: %w", err)
+	}
+	if availability.seasonEnd, err = parseSeasonDay(availability.SeasonEnd); err != nil {
+		return fmt.Errorf("season_end: %w", err)
+	}
+	return nil
+}
+
+// Parse a day of the year as MM-DD into month*100 + day
+func parseSeasonDay(day string) (int, error) {
+	// Parsed in a leap year, so the 29th of February is a day
+	parsed, err := time.Parse("2006-01-02", "2000-"+day)
+	if err != nil || len(day) != 5 {
+		return 0, fmt.Errorf("%q must be a day of the year as MM-DD", day)
+	}
+	return int(parsed.Month())*100 + parsed.Day(), nil
+}
+
+func validateQuantities(field string, quantities map[string]int64) error {
+	for itemId, quantity := range quantities {
+		if itemId == "" {
+			return fmt.Errorf("%s: empty item id", field)
+		}
+		if quantity <= 0 {
+			return fmt.Errorf("%s: quantity of %q must be positive", field, itemId)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package gamedata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+)
+
+const manifestFile = "manifest.json"
+
+// Every game data file clients need the same copy of as the server, so they agree on what everything is
+var packFiles = []string{resourceNodesFile, recipesFile, zonesFile, onboardingFile, emotesFile, featureFlagsFile, storeFile, entitlementsFile, itemsFile}
+
+var (
+	// The client has older game data than the server, and needs to update before it can play
+	ErrDataOutdated = errors.New("game data is out of date")
+
+	// The client has newer game data than the server, e.g. while the server is being updated
+	ErrDataNewer = errors.New("game data is newer than the server's")
+)
+
+// What's in the manifest in the data directory. The version is bumped with each release of the game data, and the
+// checksums make sure the files are the ones released, rather than some of them being left over from an old release.
+type manifest struct {
+	Version int64 `json:"version"`
+
+	// The hex SHA-256 of each file, by name
+	Files map[string]string `json:"files"`
+}
+
+// The game data files as a whole, as released together
+type DataPack struct {
+	// 0 if there's no manifest
+	Version int64
+
+	// Of every file's name and checksum, so it's the same for the same files whatever the version says
+	Checksum []byte
+
+	// By name
+	Files []*DataFile
+}
+
+type DataFile struct {
+	Name   string
+	Sha256 []byte
+	Size   int64
+}
+
+func (p *DataPack) File(name string) (*DataFile, bool) {
+	for _, file := range p.Files {
+		if file.Name == name {
+			return file, true
+		}
+	}
+	return nil, false
+}
+
+// Whether a client with the given version and checksum of the game data has the same data as the server. Clients
+// that don't say what they have aren't checked.
+func (p *DataPack) Check(version int64, checksum []byte) error {
+	if len(checksum) == 0 || bytes.Equal(checksum, p.Checksum) {
+		return nil
+	}
+	if version > p.Version {
+		return ErrDataNewer
+	}
+	return ErrDataOutdated
+}
+
+// Checksum the pack files in the data directory, and check them against the manifest if there is one
+func loadPack(dataDirPath string) (*DataPack, error) {
+	pack, err := readPack(dataDirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := readManifest(dataDirPath)
+	if err != nil || m == nil {
+		return pack, err
+	}
+	pack.Version = m.Version
+
+	for _, file := range pack.Files {
+		if _, listed := m.Files[file.Name]; !listed {
+			return nil, fmt.Errorf("%s: %s isn't in version %d", manifestFile, file.Name, m.Version)
+		}
+	}
+	for name, checksum := range m.Files {
+		file, exists := pack.File(name)
+		if !exists {
+			return nil, fmt.Errorf("%s: %s is missing from version %d", manifestFile, name, m.Version)
+		}
+		if hex.EncodeToString(file.Sha256) != checksum {
+			return nil, fmt.Errorf("%s: %s has changed since version %d was released", manifestFile, name, m.Version)
+		}
+	}
+	return pack, nil
+}
+
+func readPack(dataDirPath string) (*DataPack, error) {
+	pack := &DataPack{}
+	packChecksum := sha256.New()
+	names := append([]string(nil), packFiles...)
+	sort.Strings(names)
+	for _, name := range names {
+		data, err := os.ReadFile(path.Join(dataDirPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		checksum := sha256.Sum256(data)
+		pack.Files = append(pack.Files, &DataFile{Name: name, Sha256: checksum[:], Size: int64(len(data))})
+		fmt.Fprintf(packChecksum, "%s:%x\n", name, checksum)
+	}
+	pack.Checksum = packChecksum.Sum(nil)
+	return pack, nil
+}
+
+// Write a manifest of the pack files in the data directory as they are now, as the given version, returning the pack
+func WriteManifest(dataDirPath string, version int64) (*DataPack, error) {
+	pack, err := readPack(dataDirPath)
+	if err != nil {
+		return nil, err
+	}
+	pack.Version = version
+
+	m := manifest{Version: version, Files: make(map[string]string, len(pack.Files))}
+	for _, file := range pack.Files {
+		m.Files[file.Name] = hex.EncodeToString(file.Sha256)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return pack, os.WriteFile(path.Join(dataDirPath, manifestFile), append(data, '\n'), 0644)
+}
+
+// The version in the data directory's manifest, or 0 if there isn't one
+func ManifestVersion(dataDirPath string) (int64, error) {
+	m, err := readManifest(dataDirPath)
+	if err != nil || m == nil {
+		return 0, err
+	}
+	return m.Version, nil
+}
+
+// The data directory's manifest, or nil if there isn't one
+func readManifest(dataDirPath string) (*manifest, error) {
+	data, err := os.ReadFile(path.Join(dataDirPath, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("%s: %w", manifestFile, err)
+	}
+	return m, nil
+}
@@ -0,0 +1,53 @@
+package gamedata
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Characters often swapped in for letters to get words past filters
+var profanitySubstitutes = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't',
+}
+
+// Accented letters, by the letter they're accents on, so words can't be got past filters with accents either
+var profanityFolds = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'ç': 'c',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i',
+	'ñ': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u',
+	'ý': 'y', 'ÿ': 'y',
+	'ß': 's',
+}
+
+// Whether the text has any word from any language's profanity list in it, even with its letters spaced out,
+// accented or swapped for lookalikes
+func (d *GameData) Profane(text string) bool {
+	normalized := normalizeProfanity(text)
+	for _, word := range d.profaneWords {
+		if strings.Contains(normalized, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lowercase the text, fold accents and lookalikes into the letters they stand for, and drop everything else
+func normalizeProfanity(text string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if substitute, exists := profanitySubstitutes[r]; exists {
+			r = substitute
+		} else if folded, exists := profanityFolds[r]; exists {
+			r = folded
+		}
+		if unicode.IsLetter(r) {
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
@@ -0,0 +1,373 @@
+package gamedata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The most problems reported for one file, so a file that's badly broken doesn't bury the first few under the rest
+const maxSchemaErrors = 20
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Something wrong with a data file, and where in the file it is
+type SchemaError struct {
+	File   string
+	Line   int
+	Column int
+
+	// Where the value is in the data, e.g. [2].points_of_interest[0].x
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s", e.File, e.Line, e.Column, e.Path, e.Message)
+}
+
+// A data file that's been checked against the shape of what it's loaded into
+type dataFile struct {
+	name string
+	data []byte
+
+	// Where each item of the top level array starts
+	items []int64
+}
+
+// The file and line the item at the index of the top level array starts at, for errors about the item
+func (f *dataFile) at(index int) string {
+	if index < 0 || index >= len(f.items) {
+		return f.name
+	}
+	line, column := f.position(f.items[index])
+	return fmt.Sprintf("%s:%d:%d", f.name, line, column)
+}
+
+func (f *dataFile) position(offset int64) (int, int) {
+	before := f.data[:min(offset, int64(len(f.data)))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+	return line, column
+}
+
+// Checks a data file against the type it's loaded into before it's decoded, so mistakes are reported with where they
+// are in the file rather than turning up as zero values somewhere down the line: fields the type doesn't have, which
+// are usually typos, fields given more than once, values of the wrong type, and anything after the end of the data.
+type schemaChecker struct {
+	file    *dataFile
+	decoder *json.Decoder
+	errors  []error
+}
+
+// Returns every problem found, up to maxSchemaErrors, joined into one error
+func checkSchema(file *dataFile, into reflect.Type) error {
+	decoder := json.NewDecoder(bytes.NewReader(file.data))
+	decoder.UseNumber()
+	c := &schemaChecker{file: file, decoder: decoder}
+
+	if err := c.value(into, "", true); err != nil {
+		return c.syntaxError(err)
+	}
+	if offset := c.offset(); offset < int64(len(file.data)) {
+		c.fail(offset, "", "unexpected data after the end")
+	}
+
+	if len(c.errors) > maxSchemaErrors {
+		more := len(c.errors) - maxSchemaErrors
+		c.errors = append(c.errors[:maxSchemaErrors], fmt.Errorf("%s: and %d more", file.name, more))
+	}
+	return errors.Join(c.errors...)
+}
+
+// Check the next value against the type. Only syntax errors are returned, since nothing after them can be checked;
+// anything else is recorded and the value skipped, so the rest of the file is still checked.
+func (c *schemaChecker) value(t reflect.Type, path string, topLevel bool) error {
+	offset := c.offset()
+	token, err := c.decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token == nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		default:
+			c.fail(offset, path, "can't be null")
+		}
+		return nil
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		text, ok := token.(string)
+		if !ok {
+			c.fail(offset, path, "must be a time such as \"2024-12-01T00:00:00Z\"")
+			return c.skip(token)
+		}
+		if _, err := time.Parse(time.RFC3339, text); err != nil {
+			c.fail(offset, path, fmt.Sprintf("%q must be a time such as \"2024-12-01T00:00:00Z\"", text))
+		}
+		return nil
+	case t.Kind() == reflect.Interface:
+		return c.skip(token)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if token != json.Delim('{') {
+			return c.mismatch(token, offset, path, "an object")
+		}
+		return c.object(path, func(key string, keyOffset int64, fieldPath string) error {
+			field, exists := schemaFields(t)[key]
+			if !exists {
+				c.fail(keyOffset, fieldPath, "unknown field"+suggestField(key, t))
+				return c.skipNext()
+			}
+			return c.value(field, fieldPath, false)
+		})
+	case reflect.Map:
+		if token != json.Delim('{') {
+			return c.mismatch(token, offset, path, "an object")
+		}
+		return c.object(path, func(_ string, _ int64, fieldPath string) error {
+			return c.value(t.Elem(), fieldPath, false)
+		})
+	case reflect.Slice:
+		if token != json.Delim('[') {
+			return c.mismatch(token, offset, path, "a list")
+		}
+		for i := 0; c.decoder.More(); i++ {
+			if topLevel {
+				c.file.items = append(c.file.items, c.offset())
+			}
+			if err := c.value(t.Elem(), fmt.Sprintf("%s[%d]", path, i), false); err != nil {
+				return err
+			}
+		}
+		_, err := c.decoder.Token()
+		return err
+	case reflect.String:
+		if _, ok := token.(string); !ok {
+			return c.mismatch(token, offset, path, "a string")
+		}
+	case reflect.Bool:
+		if _, ok := token.(bool); !ok {
+			return c.mismatch(token, offset, path, "true or false")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := token.(json.Number)
+		if !ok {
+			return c.mismatch(token, offset, path, "a whole number")
+		}
+		if _, err := strconv.ParseInt(string(number), 10, t.Bits()); err != nil {
+			c.failNumber(offset, path, number, err)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, ok := token.(json.Number)
+		if !ok {
+			return c.mismatch(token, offset, path, "a whole number")
+		}
+		if _, err := strconv.ParseUint(string(number), 10, t.Bits()); err != nil {
+			c.failNumber(offset, path, number, err)
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := token.(json.Number); !ok {
+			return c.mismatch(token, offset, path, "a number")
+		}
+	default:
+		return c.skip(token)
+	}
+	return nil
+}
+
+// Check the rest of an object whose opening brace has been read, refusing keys given more than once, since only the
+// last one would count
+func (c *schemaChecker) object(path string, field func(key string, keyOffset int64, fieldPath string) error) error {
+	seen := make(map[string]struct{})
+	for c.decoder.More() {
+		keyOffset := c.offset()
+		token, err := c.decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+		if _, exists := seen[key]; exists {
+			c.fail(keyOffset, fieldPath, "given more than once")
+		}
+		seen[key] = struct{}{}
+
+		if err := field(key, keyOffset, fieldPath); err != nil {
+			return err
+		}
+	}
+	_, err := c.decoder.Token()
+	return err
+}
+
+func (c *schemaChecker) mismatch(token json.Token, offset int64, path string, want string) error {
+	c.fail(offset, path, fmt.Sprintf("must be %s, not %s", want, describeToken(token)))
+	return c.skip(token)
+}
+
+func (c *schemaChecker) skipNext() error {
+	token, err := c.decoder.Token()
+	if err != nil {
+		return err
+	}
+	return c.skip(token)
+}
+
+// Skip the rest of the value the token starts
+func (c *schemaChecker) skip(token json.Token) error {
+	if token != json.Delim('{') && token != json.Delim('[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		token, err := c.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+// Where the next token starts. The decoder's offset is from before any whitespace or separator in between.
+func (c *schemaChecker) offset() int64 {
+	offset := c.decoder.InputOffset()
+	for offset < int64(len(c.file.data)) && strings.IndexByte(" \t\r\n,:", c.file.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (c *schemaChecker) fail(offset int64, path string, message string) {
+	line, column := c.file.position(offset)
+	c.errors = append(c.errors, &SchemaError{File: c.file.name, Line: line, Column: column, Path: path, Message: message})
+}
+
+func (c *schemaChecker) failNumber(offset int64, path string, number json.Number, err error) {
+	if errors.Is(err, strconv.ErrRange) {
+		c.fail(offset, path, fmt.Sprintf("%s is out of range", number))
+	} else {
+		c.fail(offset, path, fmt.Sprintf("must be a whole number, not %s", number))
+	}
+}
+
+// Report the syntax error along with anything found before it, which is as far as the file can be checked
+func (c *schemaChecker) syntaxError(err error) error {
+	offset := c.decoder.InputOffset()
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	}
+
+	message := err.Error()
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		message = "unexpected end of file"
+	}
+	c.fail(offset, "", strings.TrimPrefix(message, "json: "))
+	return errors.Join(c.errors...)
+}
+
+// The fields of the struct by their JSON names, including those of any structs embedded in it
+func schemaFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name, fieldType := range schemaFields(field.Type) {
+				fields[name] = fieldType
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// ", did you mean ...?" with the struct's field closest to the unknown one, if any is close enough to be a typo
+func suggestField(key string, t reflect.Type) string {
+	best, bestDistance := "", 3
+	for name := range schemaFields(t) {
+		if distance := editDistance(key, name); distance < bestDistance || (distance == bestDistance && name < best) {
+			best, bestDistance = name, distance
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %q?", best)
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func describeToken(token json.Token) string {
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			return "an object"
+		}
+		return "a list"
+	case string:
+		return strconv.Quote(token)
+	case json.Number:
+		return string(token)
+	case bool:
+		return strconv.FormatBool(token)
+	}
+	return fmt.Sprint(token)
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"sync"
+	"time"
+)
+
+const (
+	// Heatmap grids are this many cells along each side, however big the zone is
+	heatmapGridSize = 64
+
+	// How often player positions are sampled into the heatmap being built
+	heatmapSampleRate = time.Second
+)
+
+// Where players spent their time in a zone over one aggregation window. Each cell counts how many times a player was
+// seen in it.
+type Heatmap struct {
+	ZoneId  string    `json:"zone_id"`
+	TakenAt time.Time `json:"taken_at"`
+	MinX    float64   `json:"min_x"`
+	MinY    float64   `json:"min_y"`
+	MaxX    float64   `json:"max_x"`
+	MaxY    float64   `json:"max_y"`
+	Width   int       `json:"width"`
+	Height  int       `json:"height"`
+	Cells   []uint32  `json:"cells"`
+}
+
+func newHeatmap(zone *gamedata.Zone) *Heatmap {
+	return &Heatmap{
+		ZoneId: zone.Id,
+		MinX:   zone.MinX,
+		MinY:   zone.MinY,
+		MaxX:   zone.MaxX,
+		MaxY:   zone.MaxY,
+		Width:  heatmapGridSize,
+		Height: heatmapGridSize,
+		Cells:  make([]uint32, heatmapGridSize*heatmapGridSize),
+	}
+}
+
+func (h *Heatmap) add(x, y float64) {
+	col := min(int((x-h.MinX)/(h.MaxX-h.MinX)*float64(h.Width)), h.Width-1)
+	row := min(int((y-h.MinY)/(h.MaxY-h.MinY)*float64(h.Height)), h.Height-1)
+	h.Cells[row*h.Width+col]++
+}
+
+// The cells as little endian uint32s, for storing in the database
+func (h *Heatmap) encodeCells() []byte {
+	data := make([]byte, 0, len(h.Cells)*4)
+	for _, count := range h.Cells {
+		data = binary.LittleEndian.AppendUint32(data, count)
+	}
+	return data
+}
+
+// Rebuild a heatmap stored in the database. The zone's bounds aren't stored, so they're taken from the zone as it's
+// defined now.
+func heatmapFromDb(row db.Heatmap, zone *gamedata.Zone) *Heatmap {
+	heatmap := newHeatmap(zone)
+	heatmap.TakenAt = time.UnixMilli(row.TakenAt)
+	heatmap.Width = int(row.Width)
+	heatmap.Height = int(row.Height)
+	heatmap.Cells = make([]uint32, len(row.Cells)/4)
+	for i := range heatmap.Cells {
+		heatmap.Cells[i] = binary.LittleEndian.Uint32(row.Cells[i*4:])
+	}
+	return heatmap
+}
+
+// Aggregates player positions in each zone into heatmaps, for seeing where players actually go
+type Heatmaps struct {
+	gameData *gamedata.GameData
+	players  *objects.SharedCollection[*objects.Player]
+	queries  *db.Queries
+	watchdog *Watchdog
+
+	// How long each heatmap aggregates positions for
+	interval time.Duration
+
+	// Whether finished heatmaps are kept in the database, rather than only the latest being kept in memory
+	keepHistory bool
+
+	latest map[string]*Heatmap
+	mux    sync.RWMutex
+}
+
+func NewHeatmaps(gameData *gamedata.GameData, players *objects.SharedCollection[*objects.Player], queries *db.Queries, watchdog *Watchdog) *Heatmaps {
+	return &Heatmaps{
+		gameData: gameData,
+		players:  players,
+		queries:  queries,
+		watchdog: watchdog,
+		interval: time.Minute,
+		latest:   make(map[string]*Heatmap),
+	}
+}
+
+// Must be called before the hub is run
+func (h *Heatmaps) Configure(interval time.Duration, keepHistory bool) {
+	h.interval = interval
+	h.keepHistory = keepHistory
+}
+
+// The last finished heatmap of the zone, or false if there isn't one yet
+func (h *Heatmaps) Latest(zoneId string) (*Heatmap, bool) {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	heatmap, exists := h.latest[zoneId]
+	return heatmap, exists
+}
+
+func (h *Heatmaps) aggregateLoop() {
+	sampleTicker := time.NewTicker(heatmapSampleRate)
+	defer sampleTicker.Stop()
+
+	building := h.newHeatmaps()
+	windowStart := time.Now()
+
+	for range sampleTicker.C {
+		done := h.watchdog.Track("heatmaps")
+		h.players.ForEach(func(_ uint64, player *objects.Player) {
+			zone := h.gameData.ZoneAt(player.X, player.Y)
+			if zone.Contains(player.X, player.Y) {
+				building[zone.Id].add(player.X, player.Y)
+			}
+		})
+		done()
+
+		if time.Since(windowStart) < h.interval {
+			continue
+		}
+
+		now := time.Now()
+		for _, heatmap := range building {
+			heatmap.TakenAt = now
+		}
+		h.publish(building)
+
+		building = h.newHeatmaps()
+		windowStart = now
+	}
+}
+
+func (h *Heatmaps) newHeatmaps() map[string]*Heatmap {
+	heatmaps := make(map[string]*Heatmap, len(h.gameData.Zones))
+	for _, zone := range h.gameData.Zones {
+		heatmaps[zone.Id] = newHeatmap(zone)
+	}
+	return heatmaps
+}
+
+func (h *Heatmaps) publish(heatmaps map[string]*Heatmap) {
+	h.mux.Lock()
+	h.latest = heatmaps
+	h.mux.Unlock()
+
+	if !h.keepHistory {
+		return
+	}
+
+	for _, heatmap := range heatmaps {
+		err := h.queries.CreateHeatmap(context.Background(), db.CreateHeatmapParams{
+			ZoneID:  heatmap.ZoneId,
+			TakenAt: heatmap.TakenAt.UnixMilli(),
+			Width:   int64(heatmap.Width),
+			Height:  int64(heatmap.Height),
+			Cells:   heatmap.encodeCells(),
+		})
+		if err != nil {
+			logging.Hub.Errorf("Error saving heatmap of zone %s: %v", heatmap.ZoneId, err)
+		}
+	}
+}
+
+// Heatmaps of the zone kept in the database, oldest first, starting from the given time
+func (h *Heatmaps) History(ctx context.Context, zone *gamedata.Zone, since time.Time, limit int) ([]*Heatmap, error) {
+	rows, err := h.queries.GetHeatmaps(ctx, db.GetHeatmapsParams{
+		ZoneID:  zone.Id,
+		TakenAt: since.UnixMilli(),
+		Limit:   int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	heatmaps := make([]*Heatmap, len(rows))
+	for i, row := range rows {
+		heatmaps[i] = heatmapFromDb(row, zone)
+	}
+	return heatmaps, nil
+}
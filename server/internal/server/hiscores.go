@@ -0,0 +1,28 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"server/internal/server/db"
+	"time"
+)
+
+const (
+	// How often every player's rank on the hiscores is worked out again
+	hiscoreRankInterval = time.Minute
+
+	hiscoreRankJob = "hiscores.rank"
+)
+
+// Rank every player by their best score, so looking up a player's rank doesn't need to count everyone above them.
+// Players who've joined since are ranked on the fly until next time.
+func (h *Hub) rankHiscores(ctx context.Context, _ json.RawMessage) error {
+	dbTx := h.NewDbTx()
+	dbTx.Ctx = ctx
+	return dbTx.InTx(func(queries *db.Queries) error {
+		if err := queries.DeleteHiscoreRanks(ctx); err != nil {
+			return err
+		}
+		return queries.RankHiscores(ctx, time.Now().UnixMilli())
+	})
+}
@@ -3,14 +3,21 @@ package server
 import (
 	"context"
 	"database/sql"
-	_ "embed"
+	"errors"
+	"fmt"
 	"log"
-	"math/rand/v2"
+	"maps"
 	"net/http"
 	"path"
 	"server/internal/server/db"
+	"server/internal/server/diagnostics"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
 	"server/internal/server/objects"
 	"server/pkg/packets"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -18,25 +25,120 @@ import (
 
 const MaxSpores = 1000
 
-//go:embed db/config/schema.sql
-var schemaGenSql string
+// How often the world is topped up with spores, and depleted resource nodes checked for respawning
+const (
+	sporeReplenishInterval      = 2 * time.Second
+	resourceNodeRespawnInterval = time.Second
+)
 
 type DbTx struct {
 	Ctx     context.Context
 	Queries *db.Queries
+	dbPool  *sql.DB
+	health  *dbHealth
+	outbox  *Outbox
+	saves   *Saves
+	batches *WriteBatches
+	clients *objects.SharedCollection[ClientInterfacer]
+
+	// The character this session has loaded, if any, see ClaimCharacter
+	claim atomic.Pointer[characterClaim]
 }
 
 func (h *Hub) NewDbTx() *DbTx {
 	return &DbTx{
 		Ctx:     context.Background(),
-		Queries: db.New(h.dbPool),
+		Queries: db.New(&breakerDb{pool: h.dbPool, health: h.dbHealth}),
+		dbPool:  h.dbPool,
+		health:  h.dbHealth,
+		outbox:  h.Outbox,
+		saves:   h.Saves,
+		batches: h.WriteBatches,
+		clients: h.Clients,
+	}
+}
+
+// Whether the database is reachable. While it isn't, every query fails straight away with ErrDbUnavailable.
+func (t *DbTx) Healthy() bool {
+	return t.health.Healthy()
+}
+
+// Make a write that doesn't need to happen right away, e.g. saving progress. If the database is unavailable the write
+// is queued and made once it's back, unless too many writes are queued already.
+func (t *DbTx) WriteBehind(description string, write func(ctx context.Context, queries *db.Queries) error) error {
+	err := write(t.Ctx, t.Queries)
+	if !errors.Is(err, ErrDbUnavailable) {
+		return err
+	}
+	return t.health.queueWrite(&pendingWrite{description: description, write: write})
+}
+
+// Make a write that can be committed along with everything else made around the same time, see WriteBatches. It's
+// only queued here, so failing to make it is logged rather than returned. Returns an error if it couldn't be queued.
+func (t *DbTx) Batch(description string, write func(ctx context.Context, queries *db.Queries) error) error {
+	return t.batches.add(t, description, write)
+}
+
+// Save something of the player's that can be written later along with everything else in its tier, see Saves. Saves
+// with the same key replace each other until they're written, so only the latest is. Saves of the character the
+// session has claimed fail with ErrStaleCharacter once another session claims it.
+func (t *DbTx) Save(tier SaveTier, playerDbId int64, key string, write func(ctx context.Context, queries *db.Queries) error) error {
+	write, err := t.versioned(playerDbId, key, write)
+	if err != nil {
+		return err
+	}
+	return t.saves.save(t, tier, playerDbId, key, write)
+}
+
+// Record an event for external systems, to be published after the transaction the given queries are part of is
+// committed. Does nothing unless the outbox has somewhere to publish to.
+func (t *DbTx) RecordEvent(queries *db.Queries, topic string, data any) error {
+	return t.outbox.record(t.Ctx, queries, topic, data)
+}
+
+// Run the given function in a database transaction, which is committed if the function returns nil and rolled back
+// otherwise. While the session has claimed a character, the transaction fails with ErrStaleCharacter without running
+// the function once another session claims it, so a stale session can't grant or take items either.
+func (t *DbTx) InTx(fn func(queries *db.Queries) error) error {
+	if !t.Healthy() {
+		return ErrDbUnavailable
+	}
+	if err := t.health.chaos.waitForDb(t.Ctx); err != nil {
+		return err
+	}
+
+	claim := t.claim.Load()
+	if claim != nil && claim.stale.Load() {
+		return ErrStaleCharacter
+	}
+
+	tx, err := t.dbPool.BeginTx(t.Ctx, nil)
+	if err != nil {
+		return err
 	}
+
+	queries := t.Queries.WithTx(tx)
+	if claim != nil {
+		if err := t.checkClaim(t.Ctx, queries, claim, "transaction"); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := fn(queries); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
 }
 
 type SharedGameObjects struct {
 	// The ID of the player is the ID of the client that owns it
 	Players *objects.SharedCollection[*objects.Player]
 	Spores  *objects.SharedCollection[*objects.Spore]
+
+	ResourceNodes *objects.SharedCollection[*objects.ResourceNode]
+	Emitters      *objects.SharedCollection[*objects.Emitter]
 }
 
 // A structure for a state machine to process the client's messages
@@ -53,6 +155,12 @@ type ClientStateHandler interface {
 	OnExit()
 }
 
+// Optionally implemented by state handlers to say which kinds of messages they handle from their own client, so the
+// rest can be dropped without ever being fully decoded
+type MessageKindFilter interface {
+	AcceptsKind(kind packets.MsgKind) bool
+}
+
 type ClientInterfacer interface {
 	Id() uint64
 	ProcessMessage(senderId uint64, message packets.Msg)
@@ -77,6 +185,9 @@ type ClientInterfacer interface {
 	// Pump data from the connected socket directly to the client
 	ReadPump()
 
+	// Handle data as if it was read from the client's socket, e.g. sent by an admin impersonating the client
+	ProcessSocketData(data []byte)
+
 	// Pump data from the client directly to the connected socket
 	WritePump()
 
@@ -85,8 +196,165 @@ type ClientInterfacer interface {
 
 	SharedGameObjects() *SharedGameObjects
 
+	// The hub's authoritative clock
+	ServerTime() time.Time
+
+	// Round trip samples from time syncing with this client
+	ClockSync() *ClockSync
+
+	// The language and time zone the client reported when logging in
+	Locale() *Locale
+
+	// What this client has sent and been sent
+	Bandwidth() *BandwidthSession
+
+	// Game content loaded from the data directory
+	GameData() *gamedata.GameData
+
+	// Online status of players, here and on other shards
+	Presence() *Presence
+
+	// Feeds of hiscores and listings pushed to the clients subscribed to them
+	Subscriptions() *Subscriptions
+
+	// Who can log in when the server's full, and who's idle enough to make room for them
+	PriorityLogin() *PriorityLogin
+
+	// What's been announced to the whole server
+	Announcements() *Announcements
+
+	// Reactions to recent chat messages
+	ChatReactions() *ChatReactions
+
+	// Reports of players misbehaving
+	ModerationCases() *ModerationCases
+
+	// What's been said in each channel
+	ChatHistory() *ChatHistory
+
+	// Caps on coins moving between players
+	CurrencyCaps() *CurrencyCaps
+
+	// Who's near enough to our player to be sent updates about
+	Interest() *Interest
+
+	// What players can carry, and where what they can't goes
+	Inventories() *Inventories
+
+	// What the server believes about actors, for developers' overlays
+	DebugOverlays() *DebugOverlays
+
+	// Which bosses each character has looted this lockout
+	BossLockouts() *BossLockouts
+
+	// Occupancy grids of each zone for drawing minimaps
+	Minimaps() *Minimaps
+
+	// Scheduled restarts of the server
+	Maintenance() *Maintenance
+
+	// Settles listings bought out or expired on the auction house
+	AuctionHouse() *AuctionHouse
+
+	// Times work done on each tick
+	Watchdog() *Watchdog
+
+	// How much of each tick's left for periodic work
+	TickBudget() *TickBudget
+
+	// The handler registered for custom messages of the given type
+	CustomHandler(customType string) (CustomHandler, bool)
+
+	// The hook registered for dialogs to run by the given name
+	DialogHook(name string) (DialogHook, bool)
+
+	// Admins impersonating players
+	Impersonations() *Impersonations
+
+	// Trusted clients driving actors of their own
+	Simulators() *Simulators
+
+	// Who's damaged what lately, so kills are rewarded by contribution
+	DamageAttribution() *DamageAttribution
+
+	// Where each account's logged in
+	Sessions() *Sessions
+
+	// Where GMs test apart from the rest of the world
+	Sandbox() *Sandbox
+
+	// Counts what comes into and goes out of the world, for its long-term statistics
+	WorldStats() *WorldStats
+
+	// Who can have which names
+	Names() *Names
+
+	// Holds back players' progress to write in batches
+	Saves() *Saves
+
+	// Copies of crowded zones
+	Channels() *Channels
+
+	// Which players can see which others
+	Visibility() *Visibility
+
+	// Which features are on for which players
+	FeatureFlags() *FeatureFlags
+
+	// Who can call whom over voice chat
+	Voice() *Voice
+
+	// Players' data exports and account deletions
+	AccountData() *AccountData
+
+	// Places held by an external matchmaker, and the join tokens for them
+	Matchmaking() *Matchmaking
+
+	// Data files being pushed to clients
+	FileTransfers() *FileTransfers
+
+	// Cosmetics players can buy
+	Store() *Store
+
+	// What accounts are entitled to, e.g. DLC
+	Entitlements() *Entitlements
+
+	// Challenges suspicious logins and registrations
+	Captcha() *Captcha
+
+	// What players log in over UDP with in place of their passwords
+	LoginTokens() *LoginTokens
+
+	// Whether nothing sent over the client's connection is encrypted, whatever's in front of the server, as over UDP,
+	// so passwords mustn't be sent over it
+	Plaintext() bool
+
+	// The IP address the client connected from, or empty if it isn't connected over the network
+	RemoteIp() string
+
+	// What the client says it's running on, from the user agent it connected with, or empty if it didn't say
+	Device() string
+
+	// Which client builds can log in
+	ClientVersions() *ClientVersions
+
+	// How much of the server each subsystem can use
+	Budgets() *Budgets
+
+	// Ticks the world when it's run deterministically
+	Simulation() *Simulation
+
+	// Choices put to the player that are waiting on an answer
+	Prompts() *Prompts
+
 	// Close the client's connections and cleanup
 	Close(reason string)
+
+	// Like Close, but first tell the client why, with one of the Kick reason codes, and whether and when to reconnect
+	Kick(code string, reason string)
+
+	// Whether the client's been closed, after which it should only be waiting to be unregistered
+	Closed() bool
 }
 
 // The hub is the central point of communication between all connected clients
@@ -105,105 +373,615 @@ type Hub struct {
 	// Database connection pool
 	dbPool *sql.DB
 
+	// Circuit breaker in front of the database
+	dbHealth *dbHealth
+
 	SharedGameObjects *SharedGameObjects
+
+	// Game content loaded from the data directory
+	GameData *gamedata.GameData
+
+	// Which recipients broadcasts about each actor are delivered to
+	Visibility *Visibility
+
+	// Who's near enough to whom to be broadcast updates about them
+	Interest *Interest
+
+	// Copies of crowded zones
+	Channels *Channels
+
+	// Which players are online, here and on other shards
+	Presence *Presence
+
+	// Who's subscribed to which feeds, and pushing those feeds to them
+	Subscriptions *Subscriptions
+
+	// Lets staff and supporters in when the server's full
+	PriorityLogin *PriorityLogin
+
+	// Everything announced to the whole server, for anyone who missed it
+	Announcements *Announcements
+
+	// Emotes players have reacted to recent chat messages with
+	ChatReactions *ChatReactions
+
+	// What's been said in each channel, for moderators
+	ChatHistory *ChatHistory
+
+	// Caps on coins moving between players, and transfers that look like duping or real money trading
+	CurrencyCaps *CurrencyCaps
+
+	// What the server believes about actors, streamed to developers watching them
+	DebugOverlays *DebugOverlays
+
+	// Which bosses each character has looted since their lockouts last reset
+	BossLockouts *BossLockouts
+
+	// Campfires, waterfalls and the like, sent to players as they come near them
+	Emitters *Emitters
+
+	// Keys community developers read the public API with, each only allowed what its scopes are for
+	ApiKeys *ApiKeys
+
+	// What clients are told about reconnecting as they're kicked
+	Kicks *Kicks
+
+	// Challenges suspicious logins and registrations
+	Captcha *Captcha
+
+	// What players log in over UDP with in place of their passwords
+	LoginTokens *LoginTokens
+
+	// Who's damaged what lately, so kills are rewarded by contribution
+	DamageAttribution *DamageAttribution
+
+	// Runs periodic work on the tick within a budget, putting off what doesn't fit
+	TickBudget *TickBudget
+
+	// Where each account's logged in, and has been lately
+	Sessions *Sessions
+
+	// Lets GMs test apart from the rest of the world, in a zone of their own
+	Sandbox *Sandbox
+
+	// What's in the world by tag, for scripts and subsystems to find it by
+	WorldTags *WorldTags
+
+	// Delivers broadcasts to their recipients on every core
+	BroadcastWorkers *BroadcastWorkers
+
+	// Long-term statistics of the world, rolled up by day
+	WorldStats *WorldStats
+
+	// Reconciles players' live state against what's saved for them
+	ConsistencyChecks *ConsistencyChecks
+
+	// Keeps what players are granted within what they can carry
+	Inventories *Inventories
+
+	// Players reported for misbehaving, and what everyone's been doing in case they are
+	ModerationCases *ModerationCases
+
+	// Cleans up what should have been cleaned up already, like actors left behind by clients that are gone
+	Reaper *Reaper
+
+	Minimaps *Minimaps
+
+	// Ambient and visual cues for players in an area
+	EnvironmentCues *EnvironmentCues
+
+	// Which client builds can log in
+	ClientVersions *ClientVersions
+
+	// How broadcasts are fanned out to clients, for metrics and the admin dashboard
+	Fanout *Fanout
+
+	// Where players spend their time in each zone
+	Heatmaps *Heatmaps
+
+	// Events waiting to be published to external systems
+	Outbox *Outbox
+
+	// Scheduled restarts, and the world's state across them
+	Maintenance *Maintenance
+
+	AuctionHouse *AuctionHouse
+
+	// Writes players' progress in batches, some of it more often than the rest
+	Saves *Saves
+
+	// Commits writes that can wait a tick together, instead of one transaction each
+	WriteBatches *WriteBatches
+
+	// Sends mail to many players at once in the background
+	BulkMailer *BulkMailer
+
+	// Long-running work done in the background, kept in the database until it's done
+	Jobs *Jobs
+
+	// Copies of the database taken every so often
+	Backups *Backups
+
+	// Who can have which names
+	Names *Names
+
+	// Which features are on for which players
+	FeatureFlags *FeatureFlags
+
+	// Signaling for voice calls between players
+	Voice *Voice
+
+	// Exports of players' data, and deletions of their accounts
+	AccountData *AccountData
+
+	// Each account's traffic, and throttling of those using too much
+	Bandwidth *Bandwidth
+
+	// Places held for players by an external matchmaker
+	Matchmaking *Matchmaking
+
+	// Data files pushed to clients over their connections
+	FileTransfers *FileTransfers
+
+	// Sells cosmetics for coins and app store purchases
+	Store *Store
+
+	// What each account is entitled to, e.g. DLC, which content can require
+	Entitlements *Entitlements
+
+	// The game data files, for clients to update theirs from
+	DataFiles *DataFiles
+
+	// Times the work done by states and each subsystem
+	Watchdog *Watchdog
+
+	// Caps on how much of the server each subsystem can use
+	Budgets *Budgets
+
+	// Runs the world deterministically instead, if configured to
+	Simulation *Simulation
+
+	// Tells operators when metrics cross the thresholds they've set
+	Alerts *Alerts
+
+	// Injects failures on command, if enabled, for testing the server copes with them
+	Chaos *Chaos
+
+	// New implementations of states run alongside the current ones
+	Shadows *Shadows
+
+	// Counts and examples of each kind of packet, for debugging the protocol
+	Protocol *ProtocolInspector
+
+	// Admins seeing what players see, and acting as them
+	Impersonations *Impersonations
+
+	// Trusted clients driving actors of their own, e.g. to run AI or physics in other processes
+	Simulators *Simulators
+
+	// The moment the hub was created, which all server times are measured from
+	clockEpoch time.Time
+
+	// Makes the state new clients start in, which is the login state unless set otherwise before the hub is run
+	NewInitialState func() ClientStateHandler
+
+	// Fake accounts to fill the database with once it's initialized, for development, if set before the hub is run
+	DevSeed *SeedOptions
+
+	customHandlers customHandlers
+	dialogHooks    dialogHooks
+
+	// The message being broadcast to every client right now, so they can all share its encoding
+	broadcasting atomic.Pointer[packets.SharedMsg]
 }
 
 func NewHub(dataDirPath string) *Hub {
-	dbPool, err := sql.Open("sqlite", path.Join(dataDirPath, "db.sqlite"))
+	// Transactions take the write lock up front and wait for it, rather than failing straight away when another
+	// transaction is writing at the same time
+	dbPool, err := sql.Open("sqlite", path.Join(dataDirPath, "db.sqlite")+"?_pragma=busy_timeout(5000)&_txlock=immediate")
 	if err != nil {
 		log.Fatalf("Error opening database: %v", err)
 	}
 
-	return &Hub{
-		Clients:        objects.NewSharedCollection[ClientInterfacer](),
-		BroadcastChan:  make(chan *packets.Packet),
-		RegisterChan:   make(chan ClientInterfacer),
-		UnregisterChan: make(chan ClientInterfacer),
-		dbPool:         dbPool,
-		SharedGameObjects: &SharedGameObjects{
-			Players: objects.NewSharedCollection[*objects.Player](),
-			Spores:  objects.NewSharedCollection[*objects.Spore](),
-		},
+	gameData, err := gamedata.Load(dataDirPath)
+	if err != nil {
+		log.Fatalf("Error loading game data: %v", err)
 	}
+
+	clients := objects.NewSharedCollection[ClientInterfacer]()
+	players := objects.NewSharedCollection[*objects.Player]()
+	sharedGameObjects := &SharedGameObjects{
+		Players: players,
+		Spores:  objects.NewSharedCollection[*objects.Spore](),
+
+		ResourceNodes: objects.NewSharedCollection[*objects.ResourceNode](),
+		Emitters:      objects.NewSharedCollection[*objects.Emitter](),
+	}
+
+	watchdog := NewWatchdog()
+	hub := &Hub{
+		Clients:           clients,
+		BroadcastChan:     make(chan *packets.Packet),
+		RegisterChan:      make(chan ClientInterfacer),
+		UnregisterChan:    make(chan ClientInterfacer),
+		dbPool:            dbPool,
+		dbHealth:          newDbHealth(dbPool),
+		SharedGameObjects: sharedGameObjects,
+		GameData:          gameData,
+		Presence:          NewPresence(clients),
+		Minimaps:          NewMinimaps(gameData, sharedGameObjects),
+		DataFiles:         NewDataFiles(dataDirPath, gameData.Pack),
+		Watchdog:          watchdog,
+		Budgets:           NewBudgets(),
+		Alerts:            NewAlerts(),
+		ClientVersions:    NewClientVersions(),
+		Fanout:            NewFanout(),
+		Shadows:           NewShadows(),
+		Protocol:          NewProtocolInspector(),
+		ChatReactions:     NewChatReactions(),
+		Heatmaps:          NewHeatmaps(gameData, players, db.New(dbPool), watchdog),
+		clockEpoch:        time.Now(),
+	}
+	hub.Simulation = NewSimulation(hub)
+	hub.Chaos = NewChaos(hub)
+	hub.dbHealth.chaos = hub.Chaos
+	hub.Channels = NewChannels(hub)
+	hub.Visibility = NewVisibility(players, hub.Channels)
+	hub.Interest = NewInterest(hub)
+	hub.Saves = NewSaves(hub)
+	hub.WriteBatches = NewWriteBatches(hub)
+	hub.Outbox = NewOutbox(db.New(&breakerDb{pool: dbPool, health: hub.dbHealth}), hub.dbHealth)
+	hub.Maintenance = NewMaintenance(hub, dataDirPath)
+	hub.Jobs = NewJobs(hub)
+	hub.Jobs.Register(mailCleanupJob, hub.cleanUpMail, JobOptions{Every: mailCleanupInterval})
+	hub.Jobs.Register(hiscoreRankJob, hub.rankHiscores, JobOptions{Every: hiscoreRankInterval})
+	hub.Backups = NewBackups(hub)
+	hub.AuctionHouse = NewAuctionHouse(hub)
+	hub.BulkMailer = NewBulkMailer(hub)
+	hub.Names = NewNames(hub)
+	hub.FeatureFlags = NewFeatureFlags(hub)
+	hub.Voice = NewVoice(hub)
+	hub.AccountData = NewAccountData(hub)
+	hub.Bandwidth = NewBandwidth(hub)
+	hub.Matchmaking = NewMatchmaking(hub)
+	hub.FileTransfers = NewFileTransfers(hub)
+	hub.Store = NewStore(hub)
+	hub.Entitlements = NewEntitlements(hub)
+	hub.Impersonations = NewImpersonations(hub)
+	hub.Simulators = NewSimulators(hub)
+	hub.Subscriptions = NewSubscriptions(hub)
+	hub.PriorityLogin = NewPriorityLogin(hub)
+	hub.Announcements = NewAnnouncements(hub)
+	hub.EnvironmentCues = NewEnvironmentCues(hub)
+	hub.ModerationCases = NewModerationCases(hub)
+	hub.Reaper = NewReaper(hub)
+	hub.ChatHistory = NewChatHistory(hub)
+	hub.CurrencyCaps = NewCurrencyCaps(hub)
+	hub.DebugOverlays = NewDebugOverlays(hub)
+	hub.BossLockouts = NewBossLockouts(hub)
+	hub.Emitters = NewEmitters(hub)
+	hub.ApiKeys = NewApiKeys(hub)
+	hub.Kicks = NewKicks(hub)
+	hub.Captcha = NewCaptcha(hub)
+	hub.LoginTokens = NewLoginTokens(hub)
+	hub.DamageAttribution = NewDamageAttribution(hub)
+	hub.TickBudget = NewTickBudget(hub)
+	hub.Sessions = NewSessions(hub)
+	hub.Sandbox = NewSandbox(hub)
+	hub.WorldTags = NewWorldTags(hub)
+	hub.BroadcastWorkers = NewBroadcastWorkers(hub)
+	hub.WorldStats = NewWorldStats(hub)
+	hub.ConsistencyChecks = NewConsistencyChecks(hub)
+	hub.Inventories = NewInventories(hub)
+
+	return hub
 }
 
 func (h *Hub) Run() {
-	log.Println("Initializing database...")
-	if _, err := h.dbPool.ExecContext(context.Background(), schemaGenSql); err != nil {
+	logging.Hub.Println("Initializing database...")
+	if _, err := h.dbPool.ExecContext(context.Background(), db.SchemaSql); err != nil {
 		log.Fatalf("Error initializing database: %v", err)
 	}
 
-	log.Println("Placing spores...")
-	for i := 0; i < MaxSpores; i++ {
-		h.SharedGameObjects.Spores.Add(h.newSpore())
+	if h.DevSeed != nil {
+		logging.Hub.Println("Seeding database...")
+		dbTx := h.NewDbTx()
+		result, err := Seed(dbTx.Ctx, dbTx.InTx, h.GameData, *h.DevSeed)
+		if err != nil {
+			log.Fatalf("Error seeding database: %v", err)
+		}
+		logging.Hub.Printf("Seeded %d accounts, %d characters and %d mail", result.Accounts, result.Characters, result.Mail)
+	}
+
+	dbTx := h.NewDbTx()
+	if err := h.Sessions.endStale(dbTx.Ctx, dbTx.Queries); err != nil {
+		logging.Hub.Errorf("Error ending sessions left open: %v", err)
+	}
+
+	// A deterministic simulation starts from nothing but its seed
+	if h.Simulation.Deterministic() || !h.Maintenance.restoreSnapshot() {
+		logging.Hub.Println("Placing spores...")
+		for i := 0; i < MaxSpores && h.Budgets.Spores.Admit(h.SharedGameObjects.Spores.Len()); i++ {
+			h.SharedGameObjects.Spores.Add(h.newSpore())
+		}
+
+		logging.Hub.Println("Placing resource nodes...")
+		for _, kindId := range slices.Sorted(maps.Keys(h.GameData.ResourceNodeKinds)) {
+			kind := h.GameData.ResourceNodeKinds[kindId]
+			for i := 0; i < kind.Count; i++ {
+				h.SharedGameObjects.ResourceNodes.Add(h.newResourceNode(kind))
+			}
+		}
 	}
 
-	go h.replenishSporesLoop(2 * time.Second)
+	// Emitters don't change, so they're placed from the game data whether or not anything else was restored
+	logging.Hub.Println("Placing emitters...")
+	h.Emitters.placeAll()
+	h.WorldTags.tagResourceNodes()
+
+	if h.Simulation.Deterministic() {
+		diagnostics.Go("simulation", h.Simulation.tickLoop)
+	} else {
+		// Put off as need be, so spawning lots at once can't hold up players' snapshots
+		h.TickBudget.Schedule("spores", TickPrioritySpawns, sporeReplenishInterval, func(time.Time) { h.replenishSpores() })
+		h.TickBudget.Schedule("resource nodes", TickPrioritySpawns, resourceNodeRespawnInterval, h.respawnResourceNodes)
+		diagnostics.Go("reaper", h.Reaper.sweepLoop)
+	}
+	diagnostics.Go("heatmaps", h.Heatmaps.aggregateLoop)
+	diagnostics.Go("world stats", h.WorldStats.aggregateLoop)
+	diagnostics.Go("db health", h.dbHealth.monitorLoop)
+	diagnostics.Go("alerts", h.Alerts.watchLoop)
+	diagnostics.Go("outbox", h.Outbox.dispatchLoop)
+	diagnostics.Go("jobs", h.Jobs.runLoop)
+	diagnostics.Go("bulk mail", h.BulkMailer.sendLoop)
+	diagnostics.Go("feature flags", h.FeatureFlags.refreshLoop)
+	diagnostics.Go("voice", h.Voice.enforceLoop)
+	diagnostics.Go("account data", h.AccountData.workLoop)
+	diagnostics.Go("bandwidth", h.Bandwidth.flushLoop)
+	diagnostics.Go("matchmaking", h.Matchmaking.expireLoop)
+	diagnostics.Go("subscriptions", h.Subscriptions.pushLoop)
+	diagnostics.Go("moderation", h.ModerationCases.trailLoop)
+	h.TickBudget.Schedule("emitters", TickPriorityWorld, emitterSyncInterval, func(time.Time) { h.Emitters.sync() })
+	h.Interest.start()
+	if h.DebugOverlays.Enabled() {
+		diagnostics.Go("debug overlays", h.DebugOverlays.sendLoop)
+	}
+	if h.WriteBatches.config.Interval > 0 {
+		h.TickBudget.Schedule("write batches", TickPriorityPersistence, h.WriteBatches.config.Interval, func(time.Time) { h.WriteBatches.flush() })
+	}
+	for _, tier := range h.Saves.tiers {
+		diagnostics.Go("saves", func() { h.Saves.flushLoop(tier) })
+	}
+	diagnostics.Go("tick budget", h.TickBudget.runLoop)
+	h.BroadcastWorkers.start()
+	diagnostics.Go("watchdog", h.Watchdog.watchLoop)
 
-	log.Println("Awaiting client registrations")
+	logging.Hub.Println("Awaiting client registrations")
 	for {
 		select {
 		case client := <-h.RegisterChan:
+			done := h.Watchdog.Track("register")
 			client.Initialize(h.Clients.Add(client))
+			done()
 		case client := <-h.UnregisterChan:
-			h.Clients.Remove(client.Id())
+			h.unregister(client)
 		case packet := <-h.BroadcastChan:
-			h.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
-				if clientId != packet.SenderId {
-					client.ProcessMessage(packet.SenderId, packet.Msg)
-				}
-			})
+			h.deliverBroadcast(packet)
 		}
 	}
 }
 
+// Forget the client once it's closed. In a deterministic simulation that's done straight away, so nothing later in
+// the tick is sent to it.
+func (h *Hub) Unregister(client ClientInterfacer) {
+	if h.Simulation.Deterministic() {
+		h.unregister(client)
+		return
+	}
+	h.UnregisterChan <- client
+}
+
+func (h *Hub) unregister(client ClientInterfacer) {
+	done := h.Watchdog.Track("unregister")
+	h.Clients.Remove(client.Id())
+	h.Presence.UnsubscribeAll(client.Id())
+	h.Subscriptions.UnsubscribeAll(client.Id())
+	h.ChatReactions.Forget(client.Id())
+	h.ModerationCases.Forget(client.Id())
+	h.DebugOverlays.Forget(client.Id())
+	h.Emitters.Forget(client.Id())
+	h.Sandbox.Forget(client.Id())
+	h.Visibility.Forget(client.Id())
+	h.Impersonations.forget(client.Id())
+	h.Sessions.End(client.Id())
+	diagnostics.Release(client, fmt.Sprintf("Client %d", client.Id()))
+	done()
+}
+
+// Forward the packet to every other client that can see its sender. In a deterministic simulation that's done straight
+// away, so they've all had it before the tick is over.
+func (h *Hub) Broadcast(packet *packets.Packet) {
+	if h.Simulation.Deterministic() {
+		h.deliverBroadcast(packet)
+		return
+	}
+	if delay := h.Chaos.broadcastDelay(); delay > 0 {
+		time.AfterFunc(delay, func() { h.BroadcastChan <- packet })
+		return
+	}
+	h.BroadcastChan <- packet
+}
+
+func (h *Hub) deliverBroadcast(packet *packets.Packet) {
+	done := h.Watchdog.Track(fmt.Sprintf("broadcast %T", packet.Msg))
+	shared := packets.NewSharedMsg(packet.Msg)
+	size := h.Fanout.encode(shared)
+	h.broadcasting.Store(shared)
+
+	// Handlers a deterministic simulation's broadcast to can broadcast in turn, which is delivered there and then
+	var recipients, skipped int
+	if h.Simulation.Deterministic() {
+		recipients, skipped = h.BroadcastWorkers.deliverSerially(packet)
+	} else if interested, scoped := h.Interest.recipients(packet); scoped {
+		recipients, skipped = h.BroadcastWorkers.deliverAmong(packet, interested)
+	} else {
+		recipients, skipped = h.BroadcastWorkers.deliver(packet)
+	}
+	h.broadcasting.Store(nil)
+	h.Fanout.delivered(packet.Msg, size, recipients, skipped)
+	done()
+}
+
+// Pass the broadcast packet to the client if it can see the sender. Returns whether it could.
+func (h *Hub) deliverTo(client ClientInterfacer, packet *packets.Packet) bool {
+	if !h.Visibility.CanSee(client.Id(), packet.SenderId) {
+		return false
+	}
+	client.ProcessMessage(packet.SenderId, packet.Msg)
+	return true
+}
+
 func (h *Hub) Serve(getNewClient func(*Hub, http.ResponseWriter, *http.Request) (ClientInterfacer, error), writer http.ResponseWriter, request *http.Request) {
-	log.Println("New client connected from", request.RemoteAddr)
+	logging.Hub.Println("New client connected from", request.RemoteAddr)
 	client, err := getNewClient(h, writer, request)
 
 	if err != nil {
-		log.Printf("Error obtaining client for new connection: %v", err)
+		logging.Hub.Errorf("Error obtaining client for new connection: %v", err)
 		return
 	}
+	h.Connect(client)
+}
 
-	h.RegisterChan <- client
+// Register a client that's just connected, over whichever transport, and start its pumps. Clients made by Serve are
+// connected already, this is for transports with listeners of their own.
+func (h *Hub) Connect(client ClientInterfacer) {
+	// In a deterministic simulation the client only connects on the next tick
+	if h.Simulation.Deterministic() {
+		h.Simulation.connect(client)
+	} else {
+		h.RegisterChan <- client
+	}
 
-	go client.WritePump()
-	go client.ReadPump()
+	diagnostics.GoFor(client, "write pump", client.WritePump)
+	diagnostics.GoFor(client, "read pump", client.ReadPump)
+}
+
+// The client whose player has the given name, if they're in game
+func (h *Hub) ClientByPlayerName(name string) (ClientInterfacer, bool) {
+	var clientId uint64
+	h.SharedGameObjects.Players.ForEach(func(playerId uint64, player *objects.Player) {
+		if strings.EqualFold(player.Name, name) {
+			clientId = playerId
+		}
+	})
+	if clientId == 0 {
+		return nil, false
+	}
+	return h.Clients.Get(clientId)
+}
+
+func (h *Hub) ClientByPlayerDbId(dbId int64) (ClientInterfacer, bool) {
+	var clientId uint64
+	h.SharedGameObjects.Players.ForEach(func(playerId uint64, player *objects.Player) {
+		if player.DbId == dbId {
+			clientId = playerId
+		}
+	})
+	if clientId == 0 {
+		return nil, false
+	}
+	return h.Clients.Get(clientId)
+}
+
+// The message with its encoding shared with every other client it's sent to. That's only the case for a message being
+// broadcast, which states mostly pass straight on to their socket; anything else is encoded just for this client.
+func (h *Hub) SharedMsg(message packets.Msg) *packets.SharedMsg {
+	if shared := h.broadcasting.Load(); shared != nil && shared.Msg == message {
+		return shared
+	}
+	return packets.NewSharedMsg(message)
+}
+
+// Whether the message is the one being broadcast, rather than one sent just to a single client
+func (h *Hub) IsBroadcasting(shared *packets.SharedMsg) bool {
+	return shared != nil && h.broadcasting.Load() == shared
+}
+
+// The authoritative server clock, which clients keep theirs in step with over time sync. It is based on the monotonic
+// clock, so it keeps ticking steadily even if the system's wall clock is adjusted, or the simulation's clock if it's
+// deterministic.
+func (h *Hub) GetServerTime() time.Time {
+	if h.Simulation.Deterministic() {
+		return objects.Sim.Now()
+	}
+	return h.clockEpoch.Add(time.Since(h.clockEpoch))
 }
 
 func (h *Hub) newSpore() *objects.Spore {
-	sporeRadius := max(10+rand.NormFloat64()*3, 5)
+	sporeRadius := max(10+objects.Sim.NormFloat64()*3, 5)
 	x, y := objects.SpawnCoords(sporeRadius, h.SharedGameObjects.Players, h.SharedGameObjects.Spores)
-	return &objects.Spore{X: x, Y: y, Radius: sporeRadius}
+	return &objects.Spore{Position: objects.Position{X: x, Y: y}, Body: objects.Body{Radius: sporeRadius}}
 }
 
-func (h *Hub) replenishSporesLoop(rate time.Duration) {
-	ticker := time.NewTicker(rate)
-	defer ticker.Stop()
+// Add some of the spores missing from the world
+func (h *Hub) replenishSpores() {
+	sporesRemaining := h.SharedGameObjects.Spores.Len()
+	diff := MaxSpores - sporesRemaining
 
-	for range ticker.C {
-		sporesRemaining := h.SharedGameObjects.Spores.Len()
-		diff := MaxSpores - sporesRemaining
+	if diff <= 0 {
+		return
+	}
+
+	logging.Hub.Printf("%d spores remain - going to replenish %d spores", sporesRemaining, diff)
 
-		if diff <= 0 {
-			continue
+	// Don't really want to spawn too many at a time, otherwise it can cause lag spikes
+	for i := 0; i < min(diff, 10); i++ {
+		if !h.Budgets.Spores.Admit(h.SharedGameObjects.Spores.Len()) {
+			break
 		}
+		spore := h.newSpore()
+		sporeId := h.SharedGameObjects.Spores.Add(spore)
 
-		log.Printf("%d spores remain - going to replenish %d spores", sporesRemaining, diff)
+		h.Broadcast(&packets.Packet{
+			SenderId: 0,
+			Msg:      packets.NewSpore(sporeId, spore),
+		})
+	}
+}
 
-		// Don't really want to spawn too many at a time, otherwise it can cause lag spikes
-		for i := 0; i < min(diff, 10); i++ {
-			spore := h.newSpore()
-			sporeId := h.SharedGameObjects.Spores.Add(spore)
+func (h *Hub) newResourceNode(kind *gamedata.ResourceNodeKind) *objects.ResourceNode {
+	x, y := objects.SpawnCoords(kind.Radius, h.SharedGameObjects.Players, h.SharedGameObjects.Spores)
+	return &objects.ResourceNode{
+		Position: objects.Position{X: x, Y: y},
+		Body:     objects.Body{Radius: kind.Radius},
+		Kind:     kind.Id,
+	}
+}
 
-			h.BroadcastChan <- &packets.Packet{
-				SenderId: 0,
-				Msg:      packets.NewSpore(sporeId, spore),
+// Respawn the resource nodes that have been depleted long enough, and deplete those out of season
+func (h *Hub) respawnResourceNodes(now time.Time) {
+	h.SharedGameObjects.ResourceNodes.ForEach(func(nodeId uint64, node *objects.ResourceNode) {
+		kind, exists := h.GameData.ResourceNodeKinds[node.Kind]
+		if !exists {
+			return
+		}
+
+		// Nodes out of season are kept depleted until it comes round again, when they respawn as usual
+		if !kind.InSeason(now) {
+			if node.TryDeplete() {
+				h.Broadcast(&packets.Packet{
+					SenderId: 0,
+					Msg:      packets.NewResourceNode(nodeId, node),
+				})
 			}
+			return
+		}
 
-			// Sleep a little bit to avoid lag spikes
-			time.Sleep(50 * time.Millisecond)
+		if node.TryRespawn(time.Duration(kind.RespawnSeconds * float64(time.Second))) {
+			h.Broadcast(&packets.Packet{
+				SenderId: 0,
+				Msg:      packets.NewResourceNode(nodeId, node),
+			})
 		}
-	}
+	})
 }
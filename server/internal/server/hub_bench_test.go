@@ -0,0 +1,171 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"testing"
+)
+
+// Stands in for a connected client, encoding whatever it's sent the way a websocket client would, but sending it
+// nowhere. Anything else a client can do isn't needed to tick the hub, so isn't implemented.
+type benchClient struct {
+	ClientInterfacer
+	id  uint64
+	hub *Hub
+	buf []byte
+}
+
+func (c *benchClient) Id() uint64 {
+	return c.id
+}
+
+func (c *benchClient) ProcessMessage(senderId uint64, message packets.Msg) {
+	c.buf, _ = c.hub.SharedMsg(message).AppendPacket(c.buf[:0], senderId)
+}
+
+// A hub on its own copy of the game data, ticking deterministically, with the actors spread out over the world
+func newBenchHub(b *testing.B, actors int) *Hub {
+	b.Helper()
+	dataPath := b.TempDir()
+	files, err := filepath.Glob(filepath.Join("..", "..", "data", "*.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dataPath, filepath.Base(file)), data, 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	hub := NewHub(dataPath)
+	b.Cleanup(func() { hub.dbPool.Close() })
+	if err := hub.Simulation.Configure(1, ""); err != nil {
+		b.Fatal(err)
+	}
+
+	// Close enough together that everyone's within each other's area of interest in the smaller crowds
+	side := int(math.Ceil(math.Sqrt(float64(actors))))
+	for i := range actors {
+		client := &benchClient{hub: hub}
+		client.id = hub.Clients.Add(client)
+		hub.SharedGameObjects.Players.Add(&objects.Player{
+			Position: objects.Position{X: float64(i%side) * 40, Y: float64(i/side) * 40},
+			Body:     objects.Body{Radius: 20},
+			Movement: objects.Movement{Direction: float64(i), Speed: 150},
+			Name:     fmt.Sprintf("Player%d", i),
+		}, client.id)
+	}
+	return hub
+}
+
+// Some of the crowd stealthed or invisible, so queries take every rule into account
+func hideSome(hub *Hub) {
+	i := 0
+	hub.SharedGameObjects.Players.ForEach(func(id uint64, _ *objects.Player) {
+		switch i % 10 {
+		case 0:
+			hub.Visibility.SetStealthed(id, 100)
+		case 1:
+			hub.Visibility.SetMode(id, Invisible)
+		}
+		i++
+	})
+}
+
+// What finding everyone a player can see within their area of interest costs, at crowds of different sizes
+func BenchmarkAoiQuery(b *testing.B) {
+	for _, actors := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("%dActors", actors), func(b *testing.B) {
+			hub := newBenchHub(b, actors)
+			hideSome(hub)
+			var recipientIds []uint64
+			hub.Clients.ForEach(func(id uint64, _ ClientInterfacer) {
+				recipientIds = append(recipientIds, id)
+			})
+			aoiRadius := hub.Visibility.AoiRadius()
+
+			seen := 0
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				recipientId := recipientIds[i%len(recipientIds)]
+				recipient, _ := hub.SharedGameObjects.Players.Get(recipientId)
+				seen = 0
+				hub.SharedGameObjects.Players.ForEach(func(actorId uint64, actor *objects.Player) {
+					if math.Hypot(actor.X-recipient.X, actor.Y-recipient.Y) > aoiRadius {
+						return
+					}
+					if hub.Visibility.CanSee(recipientId, actorId) {
+						seen++
+					}
+				})
+			}
+			b.ReportMetric(float64(seen), "seen/query")
+		})
+	}
+}
+
+// What a whole tick costs with every actor moving and each move broadcast to everyone who can see it. Broadcasting
+// to everyone grows with the square of the crowd, so a crowd of 10000 would take most of a minute a tick and isn't
+// run.
+func BenchmarkHubTick(b *testing.B) {
+	for _, actors := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("%dActors", actors), func(b *testing.B) {
+			hub := newBenchHub(b, actors)
+			hideSome(hub)
+			hub.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+				hub.Simulation.EveryTick(id, func() {
+					player.X += player.Speed * math.Cos(player.Direction) * TickInterval.Seconds()
+					player.Y += player.Speed * math.Sin(player.Direction) * TickInterval.Seconds()
+					hub.Broadcast(&packets.Packet{SenderId: id, Msg: packets.NewPlayer(id, player)})
+				})
+			})
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.Simulation.step(nil)
+			}
+		})
+	}
+}
+
+// What delivering one broadcast to a crowd costs on different numbers of workers, where each recipient encodes it for
+// itself. Workers only help as far as there are CPUs for them.
+func BenchmarkBroadcastDelivery(b *testing.B) {
+	counts := []int{1, 2, 4}
+	if procs := runtime.GOMAXPROCS(0); !slices.Contains(counts, procs) {
+		counts = append(counts, procs)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("%dWorkers", workers), func(b *testing.B) {
+			hub := newBenchHub(b, 1000)
+			hub.BroadcastWorkers.Configure(workers)
+			hub.BroadcastWorkers.start()
+			b.Cleanup(hub.BroadcastWorkers.stop)
+
+			var senderId uint64
+			hub.Clients.ForEach(func(id uint64, _ ClientInterfacer) {
+				senderId = id
+			})
+			sender, _ := hub.SharedGameObjects.Players.Get(senderId)
+			packet := &packets.Packet{SenderId: senderId, Msg: packets.NewPlayer(senderId, sender)}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				hub.BroadcastWorkers.deliver(packet)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type ImpersonationStatus string
+
+const (
+	ImpersonationPending  ImpersonationStatus = "pending"
+	ImpersonationActive   ImpersonationStatus = "active"
+	ImpersonationDeclined ImpersonationStatus = "declined"
+	ImpersonationEnded    ImpersonationStatus = "ended"
+)
+
+var (
+	ErrPlayerOffline          = errors.New("player not online")
+	ErrAlreadyImpersonated    = errors.New("player is already being impersonated")
+	ErrImpersonationNotFound  = errors.New("impersonation not found")
+	ErrImpersonationInactive  = errors.New("impersonation not active")
+	ErrImpersonationAttached  = errors.New("impersonation already attached")
+	ErrImpersonationNoControl = errors.New("impersonation doesn't have control")
+)
+
+// How many packets can be waiting to be mirrored to the admin before more are dropped
+const impersonationMirrorSize = 256
+
+// A request by an admin to see what a player sees, and optionally act as them
+type Impersonation struct {
+	Id         string              `json:"id"`
+	Admin      string              `json:"admin"`
+	Reason     string              `json:"reason"`
+	PlayerName string              `json:"player_name"`
+	ClientId   uint64              `json:"client_id"`
+	Control    bool                `json:"control"`
+	Elevated   bool                `json:"elevated"`
+	Status     ImpersonationStatus `json:"status"`
+	CreatedAt  time.Time           `json:"created_at"`
+
+	attached bool
+	mirror   chan *packets.Packet
+}
+
+// Lets admins impersonate players to reproduce what they report exactly as they see it. A player has to consent
+// first, unless the admin has elevated permission, and either way they're told while it's going on and can end it
+// whenever they like. Everything the admin does is audited.
+//
+// While a session is active, every packet sent to the player is mirrored to the admin attached to it. Only packets
+// sent after the admin attaches are mirrored, so the admin doesn't see what was already on the player's screen.
+type Impersonations struct {
+	hub *Hub
+
+	sessions map[string]*Impersonation
+
+	// Client ID -> the pending or active session for that client
+	byClient map[uint64]*Impersonation
+
+	// How many sessions are active, so mirroring costs next to nothing while there are none
+	active atomic.Int32
+
+	mux sync.RWMutex
+}
+
+func NewImpersonations(hub *Hub) *Impersonations {
+	return &Impersonations{
+		hub:      hub,
+		sessions: make(map[string]*Impersonation),
+		byClient: make(map[uint64]*Impersonation),
+	}
+}
+
+// Ask the player to let the admin impersonate them. With elevated permission the session starts straight away.
+func (i *Impersonations) Request(admin string, reason string, playerName string, control bool, elevated bool) (Impersonation, error) {
+	client, online := i.hub.ClientByPlayerName(playerName)
+	if !online {
+		return Impersonation{}, ErrPlayerOffline
+	}
+
+	idBytes := make([]byte, 8)
+	rand.Read(idBytes)
+	session := &Impersonation{
+		Id:         hex.EncodeToString(idBytes),
+		Admin:      admin,
+		Reason:     reason,
+		PlayerName: playerName,
+		ClientId:   client.Id(),
+		Control:    control,
+		Elevated:   elevated,
+		Status:     ImpersonationPending,
+		CreatedAt:  time.Now(),
+		mirror:     make(chan *packets.Packet, impersonationMirrorSize),
+	}
+
+	i.mux.Lock()
+	if _, exists := i.byClient[session.ClientId]; exists {
+		i.mux.Unlock()
+		return Impersonation{}, ErrAlreadyImpersonated
+	}
+	i.sessions[session.Id] = session
+	i.byClient[session.ClientId] = session
+	if elevated {
+		session.Status = ImpersonationActive
+		i.active.Add(1)
+	}
+	snapshot := *session
+	i.mux.Unlock()
+
+	detail := fmt.Sprintf("session %s, control %t, elevated %t, reason: %s", session.Id, control, elevated, reason)
+	i.hub.Audit(admin, "impersonation.requested", playerName, detail)
+
+	if elevated {
+		i.hub.Audit(admin, "impersonation.started", playerName, fmt.Sprintf("session %s without consent", session.Id))
+		client.SocketSend(packets.NewImpersonationStatus(session.Id, admin, true, control))
+	} else {
+		client.SocketSend(packets.NewImpersonationRequest(session.Id, admin, reason, control))
+	}
+
+	return snapshot, nil
+}
+
+// The player's answer to a request. Refusing a session that's already active ends it.
+func (i *Impersonations) Respond(clientId uint64, id string, granted bool) {
+	i.mux.Lock()
+	session, exists := i.byClient[clientId]
+	if !exists || session.Id != id {
+		i.mux.Unlock()
+		return
+	}
+
+	if granted {
+		if session.Status != ImpersonationPending {
+			i.mux.Unlock()
+			return
+		}
+		session.Status = ImpersonationActive
+		i.active.Add(1)
+		i.mux.Unlock()
+
+		i.hub.Audit(session.Admin, "impersonation.consented", session.PlayerName, "session "+id)
+		if client, exists := i.hub.Clients.Get(clientId); exists {
+			client.SocketSend(packets.NewImpersonationStatus(id, session.Admin, true, session.Control))
+		}
+		return
+	}
+
+	wasActive := session.Status == ImpersonationActive
+	if wasActive {
+		i.endLocked(session, ImpersonationEnded)
+	} else {
+		i.endLocked(session, ImpersonationDeclined)
+	}
+	i.mux.Unlock()
+
+	if wasActive {
+		i.hub.Audit(session.Admin, "impersonation.revoked", session.PlayerName, "session "+id+" ended by the player")
+		if client, exists := i.hub.Clients.Get(clientId); exists {
+			client.SocketSend(packets.NewImpersonationStatus(id, session.Admin, false, session.Control))
+		}
+	} else {
+		i.hub.Audit(session.Admin, "impersonation.declined", session.PlayerName, "session "+id)
+	}
+}
+
+// End the session on behalf of the given admin
+func (i *Impersonations) End(id string, by string) error {
+	i.mux.Lock()
+	session, exists := i.sessions[id]
+	if !exists {
+		i.mux.Unlock()
+		return ErrImpersonationNotFound
+	}
+	if session.Status != ImpersonationPending && session.Status != ImpersonationActive {
+		i.mux.Unlock()
+		return nil
+	}
+	i.endLocked(session, ImpersonationEnded)
+	i.mux.Unlock()
+
+	i.hub.Audit(by, "impersonation.ended", session.PlayerName, "session "+id)
+	if client, exists := i.hub.Clients.Get(session.ClientId); exists {
+		client.SocketSend(packets.NewImpersonationStatus(id, session.Admin, false, session.Control))
+	}
+	return nil
+}
+
+// Start mirroring the session's packets to the admin. Only one admin can be attached to a session at a time.
+func (i *Impersonations) Attach(id string) (Impersonation, <-chan *packets.Packet, error) {
+	i.mux.Lock()
+	session, exists := i.sessions[id]
+	if !exists {
+		i.mux.Unlock()
+		return Impersonation{}, nil, ErrImpersonationNotFound
+	}
+	if session.Status != ImpersonationActive {
+		i.mux.Unlock()
+		return Impersonation{}, nil, ErrImpersonationInactive
+	}
+	if session.attached {
+		i.mux.Unlock()
+		return Impersonation{}, nil, ErrImpersonationAttached
+	}
+	session.attached = true
+	snapshot := *session
+	i.mux.Unlock()
+
+	i.hub.Audit(session.Admin, "impersonation.attached", session.PlayerName, "session "+id)
+	return snapshot, session.mirror, nil
+}
+
+// The client being impersonated, if the session is active and the admin is allowed to act as them
+func (i *Impersonations) ControlledClient(id string) (ClientInterfacer, error) {
+	i.mux.RLock()
+	session, exists := i.sessions[id]
+	if !exists {
+		i.mux.RUnlock()
+		return nil, ErrImpersonationNotFound
+	}
+	status, control, clientId := session.Status, session.Control, session.ClientId
+	i.mux.RUnlock()
+
+	if status != ImpersonationActive {
+		return nil, ErrImpersonationInactive
+	}
+	if !control {
+		return nil, ErrImpersonationNoControl
+	}
+	client, exists := i.hub.Clients.Get(clientId)
+	if !exists {
+		return nil, ErrImpersonationInactive
+	}
+	return client, nil
+}
+
+// Copy a packet sent to the client to the admin impersonating them, if there is one. Never blocks: if the admin can't
+// keep up, packets are dropped.
+func (i *Impersonations) Mirror(clientId uint64, senderId uint64, message packets.Msg) {
+	if i.active.Load() == 0 {
+		return
+	}
+
+	i.mux.RLock()
+	defer i.mux.RUnlock()
+
+	session, exists := i.byClient[clientId]
+	if !exists || session.Status != ImpersonationActive || !session.attached {
+		return
+	}
+	select {
+	case session.mirror <- &packets.Packet{SenderId: senderId, Msg: message}:
+	default:
+	}
+}
+
+func (i *Impersonations) Get(id string) (Impersonation, bool) {
+	i.mux.RLock()
+	defer i.mux.RUnlock()
+
+	session, exists := i.sessions[id]
+	if !exists {
+		return Impersonation{}, false
+	}
+	return *session, true
+}
+
+func (i *Impersonations) List() []Impersonation {
+	i.mux.RLock()
+	defer i.mux.RUnlock()
+
+	sessions := make([]Impersonation, 0, len(i.sessions))
+	for _, session := range i.sessions {
+		sessions = append(sessions, *session)
+	}
+	return sessions
+}
+
+// End any session for a client that's disconnected
+func (i *Impersonations) forget(clientId uint64) {
+	i.mux.Lock()
+	session, exists := i.byClient[clientId]
+	if !exists {
+		i.mux.Unlock()
+		return
+	}
+	i.endLocked(session, ImpersonationEnded)
+	i.mux.Unlock()
+
+	i.hub.Audit(session.Admin, "impersonation.ended", session.PlayerName, "session "+session.Id+" ended by the player disconnecting")
+}
+
+// Finished sessions are kept so they can still be looked up, but the mirror is closed so an attached admin is let go
+func (i *Impersonations) endLocked(session *Impersonation, status ImpersonationStatus) {
+	if session.Status == ImpersonationActive {
+		i.active.Add(-1)
+	}
+	session.Status = status
+	delete(i.byClient, session.ClientId)
+	close(session.mirror)
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"math"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// How often the grid's brought up to date with where everyone is, and players told who's come and gone
+const interestSyncInterval = 250 * time.Millisecond
+
+// Only sent to the players interested in whoever they're about, when interest's being managed, since they're sent again
+// every tick anyway and matter to nobody further away
+var interestKinds = packets.NewKindSet(
+	&packets.Packet_Player{},
+)
+
+var interestChangesTotal = metrics.NewCounterVec("mmo_interest_changes_total", "Players coming into or leaving other players' areas of interest, by which.", "change")
+
+// A square of the grid, by how many cells it is from the origin along each axis
+type interestCell struct {
+	x, y int64
+}
+
+// Who one player's client has been told it's interested in, so it's only told what's changed
+type interestView struct {
+	// Which player it was, since a client leaving the world and coming back gets a new one, which knows of nothing
+	player *objects.Player
+
+	known map[uint64]struct{}
+}
+
+// Divides the world into a grid of square cells, so updates about each player are only broadcast to the players near
+// enough to be interested rather than to everyone, which costs the square of the crowd. A player's interested in
+// another when they're within the cells the other's interest radius reaches from the cell it's in, see
+// Visibility.InterestRadius, and can see them. Who's in which cell is brought up to date every so often, and each
+// player's sent an Interest whenever players come in or leave as either crosses from one cell to another, along with
+// where the ones coming in are. Off unless a cell size is configured, in which case everyone's broadcast to everyone.
+type Interest struct {
+	hub      *Hub
+	cellSize float64
+
+	// By actor, the clients whose players are interested in it, as of the last sync
+	watchers map[uint64][]uint64
+	mux      sync.RWMutex
+
+	// By client, only used while syncing
+	views map[uint64]*interestView
+}
+
+func NewInterest(hub *Hub) *Interest {
+	return &Interest{hub: hub, views: make(map[uint64]*interestView)}
+}
+
+// Must be called before the hub is run. 0 leaves interest unmanaged. Cells a fraction of the area of interest,
+// e.g. half of it, keep who's sent updates close to who's actually within it, at the cost of keeping track of more.
+func (i *Interest) Configure(cellSize float64) {
+	i.cellSize = max(cellSize, 0)
+}
+
+// Whether updates about players are only broadcast to those interested in them
+func (i *Interest) Enabled() bool {
+	return i.cellSize > 0
+}
+
+func (i *Interest) start() {
+	if !i.Enabled() {
+		return
+	}
+	// The grid's synced on the clock rather than the simulation's ticks, which would make it nondeterministic
+	if i.hub.Simulation.Deterministic() {
+		logging.Hub.Println("Not managing interest in a deterministic simulation, everyone's broadcast to everyone")
+		i.cellSize = 0
+		return
+	}
+	logging.Hub.Printf("Managing interest on a grid of %.0f cells", i.cellSize)
+	i.hub.TickBudget.Schedule("interest", TickPriorityWorld, interestSyncInterval, func(time.Time) { i.sync() })
+}
+
+// The clients interested in whoever the packet's about, if it's of a kind only they're broadcast to
+func (i *Interest) recipients(packet *packets.Packet) ([]ClientInterfacer, bool) {
+	if !i.Enabled() || packet.SenderId == 0 || !interestKinds.Has(packets.KindOf(packet.Msg)) {
+		return nil, false
+	}
+
+	i.mux.RLock()
+	watcherIds := i.watchers[packet.SenderId]
+	i.mux.RUnlock()
+
+	clients := make([]ClientInterfacer, 0, len(watcherIds))
+	for _, clientId := range watcherIds {
+		if client, exists := i.hub.Clients.Get(clientId); exists {
+			clients = append(clients, client)
+		}
+	}
+	return clients, true
+}
+
+func (i *Interest) cellOf(position objects.Position) interestCell {
+	return interestCell{x: int64(math.Floor(position.X / i.cellSize)), y: int64(math.Floor(position.Y / i.cellSize))}
+}
+
+// Put everyone in the cell they're in now, work out who's interested in whom, and tell each player's client who's
+// come in and who's left since last time
+func (i *Interest) sync() {
+	type actor struct {
+		id     uint64
+		player *objects.Player
+		cell   interestCell
+	}
+	var actors []actor
+	grid := make(map[interestCell][]uint64)
+	i.hub.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+		cell := i.cellOf(player.Position)
+		actors = append(actors, actor{id: id, player: player, cell: cell})
+		grid[cell] = append(grid[cell], id)
+	})
+
+	// Only players with clients are interested in anything, unlike actors a world simulator drives
+	recipients := make(map[uint64]ClientInterfacer)
+	for _, a := range actors {
+		if client, exists := i.hub.Clients.Get(a.id); exists {
+			recipients[a.id] = client
+		}
+	}
+
+	watchers := make(map[uint64][]uint64, len(actors))
+	interested := make(map[uint64]map[uint64]struct{}, len(recipients))
+	watch := func(a actor, cell interestCell) {
+		for _, recipientId := range grid[cell] {
+			if _, exists := recipients[recipientId]; !exists || recipientId == a.id {
+				continue
+			}
+			if !i.hub.Visibility.CanSee(recipientId, a.id) {
+				continue
+			}
+			watchers[a.id] = append(watchers[a.id], recipientId)
+			if interested[recipientId] == nil {
+				interested[recipientId] = make(map[uint64]struct{})
+			}
+			interested[recipientId][a.id] = struct{}{}
+		}
+	}
+	for _, a := range actors {
+		reach := int64(math.Ceil((i.hub.Visibility.InterestRadius(a.id) + a.player.Radius) / i.cellSize))
+
+		// Actors reaching further than the grid's occupied only need the cells that are
+		if side := 2*reach + 1; side*side > int64(len(grid)) {
+			for cell := range grid {
+				if cellDistance(cell, a.cell) <= reach {
+					watch(a, cell)
+				}
+			}
+			continue
+		}
+		for x := a.cell.x - reach; x <= a.cell.x+reach; x++ {
+			for y := a.cell.y - reach; y <= a.cell.y+reach; y++ {
+				watch(a, interestCell{x: x, y: y})
+			}
+		}
+	}
+
+	i.mux.Lock()
+	i.watchers = watchers
+	i.mux.Unlock()
+
+	for _, a := range actors {
+		client, exists := recipients[a.id]
+		if !exists {
+			continue
+		}
+		view, exists := i.views[a.id]
+		if !exists || view.player != a.player {
+			view = &interestView{player: a.player, known: make(map[uint64]struct{})}
+			i.views[a.id] = view
+		}
+
+		now := interested[a.id]
+		var entered, left []uint64
+		for actorId := range now {
+			if _, known := view.known[actorId]; !known {
+				entered = append(entered, actorId)
+			}
+		}
+		for actorId := range view.known {
+			if _, still := now[actorId]; !still {
+				left = append(left, actorId)
+			}
+		}
+		if now == nil {
+			now = make(map[uint64]struct{})
+		}
+		view.known = now
+
+		if len(entered) > 0 || len(left) > 0 {
+			slices.Sort(entered)
+			slices.Sort(left)
+			interestChangesTotal.With("entered").Add(uint64(len(entered)))
+			interestChangesTotal.With("left").Add(uint64(len(left)))
+
+			// The player's own state sends where those coming in are, and stops skipping updates about them
+			client.ProcessMessage(0, packets.NewInterest(entered, left))
+		}
+	}
+
+	// Players who've left the world will be told about everyone again if they come back
+	for clientId := range i.views {
+		if _, exists := recipients[clientId]; !exists {
+			delete(i.views, clientId)
+		}
+	}
+}
+
+// How many cells apart the two are, counting diagonal steps as one
+func cellDistance(a, b interestCell) int64 {
+	dx, dy := a.x-b.x, a.y-b.y
+	return max(dx, -dx, dy, -dy)
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/metrics"
+	"slices"
+)
+
+// Who mail holding what didn't fit in players' inventories is from
+const OverflowSender = "Overflow"
+
+var inventoryOverflowTotal = metrics.NewCounter("mmo_inventory_overflow_total", "Items granted to players that didn't fit in what they can carry, and were mailed to them instead.")
+
+// The most players can carry. Either left at 0 is no limit.
+type CarryLimits struct {
+	// How many stacks, each holding up to its item's stack size
+	Slots int64
+
+	// How much everything together can weigh, by each item's weight
+	MaxWeight float64
+}
+
+// Keeps what players are granted within what they can carry: how many slots their inventory has, each holding a stack
+// of one item up to its stack size, and how much it can all weigh, going by the items in the game data. Whatever
+// doesn't fit is held for them in their mail instead, from OverflowSender, and can be claimed once they've made room, so
+// it's never lost, and never granted twice since it's mailed in the same transaction it would have been granted in.
+// Coins never take up room.
+type Inventories struct {
+	hub    *Hub
+	limits CarryLimits
+}
+
+func NewInventories(hub *Hub) *Inventories {
+	return &Inventories{hub: hub}
+}
+
+// Must be called before the hub is run
+func (i *Inventories) Configure(limits CarryLimits) {
+	i.limits = limits
+}
+
+// Split the items between what fits in the player's inventory as it's saved and what doesn't. Pass queries that are
+// part of the transaction the items are granted in, so what's fitted around can't change in between.
+func (i *Inventories) Fit(ctx context.Context, queries *db.Queries, playerDbId int64, items map[string]int64) (map[string]int64, map[string]int64, error) {
+	return fitSaved(ctx, queries, i.hub.GameData, i.limits, playerDbId, items)
+}
+
+// Grant the player as much of the items as fits, mailing them the rest, and return what was mailed. Pass queries that
+// are part of a transaction if the items are coming from somewhere else at the same time. Call NotifyMail once it's
+// committed if anything was.
+func (i *Inventories) Grant(ctx context.Context, queries *db.Queries, playerDbId int64, items map[string]int64) (map[string]int64, error) {
+	return GrantItems(ctx, queries, i.hub.GameData, i.limits, playerDbId, items)
+}
+
+// Like Inventories.Grant, for tools working on the database without a hub, e.g. adminctl
+func GrantItems(ctx context.Context, queries *db.Queries, gameData *gamedata.GameData, limits CarryLimits, playerDbId int64, items map[string]int64) (map[string]int64, error) {
+	fits, overflow, err := fitSaved(ctx, queries, gameData, limits, playerDbId, items)
+	if err != nil {
+		return nil, err
+	}
+
+	for itemId, quantity := range fits {
+		err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+			PlayerID: playerDbId,
+			ItemID:   itemId,
+			Quantity: quantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, itemId := range slices.Sorted(maps.Keys(overflow)) {
+		quantity := overflow[itemId]
+		subject := fmt.Sprintf("%d %s that didn't fit in your inventory", quantity, itemId)
+		if err := SendMail(ctx, queries, playerDbId, OverflowSender, subject, itemId, quantity); err != nil {
+			return nil, err
+		}
+		inventoryOverflowTotal.Add(uint64(quantity))
+	}
+	return overflow, nil
+}
+
+func fitSaved(ctx context.Context, queries *db.Queries, gameData *gamedata.GameData, limits CarryLimits, playerDbId int64, items map[string]int64) (map[string]int64, map[string]int64, error) {
+	if limits == (CarryLimits{}) {
+		return items, nil, nil
+	}
+
+	rows, err := queries.GetInventoryItems(ctx, playerDbId)
+	if err != nil {
+		return nil, nil, err
+	}
+	held := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		held[row.ItemID] = row.Quantity
+	}
+	fits, overflow := fitItems(gameData, limits, held, items)
+	return fits, overflow, nil
+}
+
+// Split the items between what fits alongside what's held and what doesn't, fitting them in order of ID so the same
+// grant always splits the same way. Anything held beyond the limits already, e.g. from before they were lowered, is
+// kept but leaves no room for more, besides topping up stacks that aren't full.
+func fitItems(gameData *gamedata.GameData, limits CarryLimits, held map[string]int64, items map[string]int64) (map[string]int64, map[string]int64) {
+	var slots int64
+	var weight float64
+	for itemId, quantity := range held {
+		if itemId == gamedata.CurrencyItemId || quantity <= 0 {
+			continue
+		}
+		stackSize, itemWeight := itemBulk(gameData, itemId)
+		slots += slotsFor(stackSize, quantity)
+		weight += itemWeight * float64(quantity)
+	}
+
+	fits := make(map[string]int64, len(items))
+	overflow := make(map[string]int64)
+	for _, itemId := range slices.Sorted(maps.Keys(items)) {
+		quantity := items[itemId]
+		if quantity <= 0 {
+			continue
+		}
+		if itemId == gamedata.CurrencyItemId {
+			fits[itemId] = quantity
+			continue
+		}
+
+		stackSize, itemWeight := itemBulk(gameData, itemId)
+		have := max(held[itemId], 0)
+		room := quantity
+		if limits.Slots > 0 {
+			free := max(limits.Slots-slots, 0)
+			if stackSize == 0 {
+				// One slot holds any number of the item, so it only takes room if it isn't held already
+				if have == 0 && free == 0 {
+					room = 0
+				}
+			} else {
+				topUp := (stackSize - have%stackSize) % stackSize
+				room = min(room, topUp+free*stackSize)
+			}
+		}
+		if limits.MaxWeight > 0 && itemWeight > 0 {
+			// Nudged up a hair so e.g. 10 of 0.1 fit in 1, despite rounding
+			carriable := math.Floor((limits.MaxWeight-weight)/itemWeight + 1e-9)
+			room = min(room, int64(max(carriable, 0)))
+		}
+
+		if room > 0 {
+			fits[itemId] = room
+			slots += slotsFor(stackSize, have+room) - slotsFor(stackSize, have)
+			weight += itemWeight * float64(room)
+		}
+		if room < quantity {
+			overflow[itemId] = quantity - room
+		}
+	}
+	return fits, overflow
+}
+
+// The item's stack size, 0 for no limit, and weight, neither of which undefined items have
+func itemBulk(gameData *gamedata.GameData, itemId string) (int64, float64) {
+	if item, exists := gameData.Items[itemId]; exists {
+		return item.StackSize, item.Weight
+	}
+	return 0, 0
+}
+
+// How many slots that many of an item with the stack size take up
+func slotsFor(stackSize int64, quantity int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+	if stackSize == 0 {
+		return 1
+	}
+	return (quantity + stackSize - 1) / stackSize
+}
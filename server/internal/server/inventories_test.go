@@ -0,0 +1,124 @@
+package server
+
+import (
+	"maps"
+	"server/internal/server/gamedata"
+	"testing"
+)
+
+func TestFitItems(t *testing.T) {
+	gameData := &gamedata.GameData{
+		Items: map[string]*gamedata.Item{
+			"apple":   {Id: "apple", StackSize: 20},
+			"wood":    {Id: "wood", StackSize: 20},
+			"stone":   {Id: "stone", StackSize: 20, Weight: 0.5},
+			"feather": {Id: "feather", StackSize: 100, Weight: 0.1},
+			"gem":     {Id: "gem"},
+		},
+	}
+
+	tests := map[string]struct {
+		limits   CarryLimits
+		held     map[string]int64
+		items    map[string]int64
+		fits     map[string]int64
+		overflow map[string]int64
+	}{
+		"no limits": {
+			items: map[string]int64{"wood": 1000, "stone": 500},
+			fits:  map[string]int64{"wood": 1000, "stone": 500},
+		},
+		"stacks fill slots": {
+			limits:   CarryLimits{Slots: 2},
+			items:    map[string]int64{"wood": 50},
+			fits:     map[string]int64{"wood": 40},
+			overflow: map[string]int64{"wood": 10},
+		},
+		"held stack topped up": {
+			limits:   CarryLimits{Slots: 1},
+			held:     map[string]int64{"wood": 15},
+			items:    map[string]int64{"wood": 10},
+			fits:     map[string]int64{"wood": 5},
+			overflow: map[string]int64{"wood": 5},
+		},
+		"fitted in order of id": {
+			limits:   CarryLimits{Slots: 1},
+			items:    map[string]int64{"wood": 1, "apple": 1},
+			fits:     map[string]int64{"apple": 1},
+			overflow: map[string]int64{"wood": 1},
+		},
+		"weight rounding": {
+			limits:   CarryLimits{MaxWeight: 1},
+			items:    map[string]int64{"feather": 11},
+			fits:     map[string]int64{"feather": 10},
+			overflow: map[string]int64{"feather": 1},
+		},
+		"weight shared between items": {
+			limits:   CarryLimits{MaxWeight: 2},
+			held:     map[string]int64{"stone": 2},
+			items:    map[string]int64{"feather": 5, "stone": 2},
+			fits:     map[string]int64{"feather": 5, "stone": 1},
+			overflow: map[string]int64{"stone": 1},
+		},
+		"coins exempt": {
+			limits: CarryLimits{Slots: 1, MaxWeight: 1},
+			held:   map[string]int64{"wood": 20, "feather": 10},
+			items:  map[string]int64{gamedata.CurrencyItemId: 500},
+			fits:   map[string]int64{gamedata.CurrencyItemId: 500},
+		},
+		"held coins take no room": {
+			limits: CarryLimits{Slots: 1},
+			held:   map[string]int64{gamedata.CurrencyItemId: 500},
+			items:  map[string]int64{"wood": 20},
+			fits:   map[string]int64{"wood": 20},
+		},
+		"slots already over the limit": {
+			limits:   CarryLimits{Slots: 1},
+			held:     map[string]int64{"wood": 60},
+			items:    map[string]int64{"wood": 5, "stone": 1},
+			overflow: map[string]int64{"wood": 5, "stone": 1},
+		},
+		"held stack topped up over the limit": {
+			limits:   CarryLimits{Slots: 1},
+			held:     map[string]int64{"wood": 55},
+			items:    map[string]int64{"wood": 10},
+			fits:     map[string]int64{"wood": 5},
+			overflow: map[string]int64{"wood": 5},
+		},
+		"weight already over the limit": {
+			limits:   CarryLimits{MaxWeight: 1},
+			held:     map[string]int64{"stone": 4},
+			items:    map[string]int64{"feather": 1, "wood": 3},
+			fits:     map[string]int64{"wood": 3},
+			overflow: map[string]int64{"feather": 1},
+		},
+		"unlimited stack in one slot": {
+			limits: CarryLimits{Slots: 1},
+			held:   map[string]int64{"gem": 1000},
+			items:  map[string]int64{"gem": 1000},
+			fits:   map[string]int64{"gem": 1000},
+		},
+		"unlimited stack needs a free slot": {
+			limits:   CarryLimits{Slots: 1},
+			held:     map[string]int64{"wood": 1},
+			items:    map[string]int64{"gem": 5},
+			overflow: map[string]int64{"gem": 5},
+		},
+		"nothing to fit": {
+			limits: CarryLimits{Slots: 1},
+			items:  map[string]int64{"wood": 0, "stone": -3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fits, overflow := fitItems(gameData, test.limits, test.held, test.items)
+			if !maps.Equal(fits, test.fits) {
+				t.Errorf("fits %v, want %v", fits, test.fits)
+			}
+			if !maps.Equal(overflow, test.overflow) {
+				t.Errorf("overflow %v, want %v", overflow, test.overflow)
+			}
+		})
+	}
+}
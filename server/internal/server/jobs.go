@@ -0,0 +1,418 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/diagnostics"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+
+	// How often due jobs are looked for, besides straight after one is queued or finishes
+	jobPollInterval = time.Second
+
+	jobBatchSize = 50
+
+	// How many jobs can run at once across every kind, unless configured otherwise
+	DefaultJobConcurrency = 4
+
+	// Unless the kind of job says otherwise
+	defaultJobMaxAttempts = 5
+	defaultJobTimeout     = 10 * time.Minute
+
+	// Failed jobs are retried after a delay doubling from a second up to this
+	jobMaxBackoff = 10 * time.Minute
+
+	// How long finished jobs that only ran once are kept, so what came of them can still be seen
+	jobRetention = 7 * 24 * time.Hour
+
+	pruneJobsKind = "jobs.prune"
+)
+
+var (
+	ErrUnknownJobKind = errors.New("unknown kind of job")
+	ErrJobNotFailed   = errors.New("job hasn't failed")
+)
+
+var (
+	jobRunsTotal     = metrics.NewCounterVec("mmo_job_runs_total", "Jobs run, by kind.", "kind")
+	jobFailuresTotal = metrics.NewCounterVec("mmo_job_failures_total", "Job runs that failed, by kind.", "kind")
+	jobsRunning      = metrics.NewGaugeVec("mmo_jobs_running", "Jobs running, by kind.", "kind")
+	jobSeconds       = metrics.NewHistogramVec("mmo_job_duration_seconds", "How long jobs took to run, by kind.", "kind", []float64{0.01, 0.1, 1, 10, 60, 600})
+)
+
+// Does a job, given what it was queued with. Returning an error has the job tried again later, so handlers should be
+// safe to run more than once for the same job, and should stop once the context is done.
+type JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+// How a kind of job is run. Zero values keep the defaults.
+type JobOptions struct {
+	// How many jobs of the kind can run at once, 1 if left out
+	Concurrency int
+
+	// How many times a job is tried before it's failed for good, defaultJobMaxAttempts if left out
+	MaxAttempts int
+
+	// How long a job can run for before its context is cancelled, defaultJobTimeout if left out
+	Timeout time.Duration
+
+	// Run the kind every so often, as well as whenever one's queued. Recurring jobs are never failed for good; once
+	// they run out of attempts they wait until they're next due.
+	Every time.Duration
+}
+
+type jobKind struct {
+	name    string
+	handler JobHandler
+	options JobOptions
+	running int
+
+	// Whether a recurring job was asked to run again while it was running
+	again bool
+}
+
+// What's running of a kind of job, for the admin API
+type JobKindStatus struct {
+	Kind        string
+	Running     int
+	Concurrency int
+	Every       time.Duration
+}
+
+// Runs long-running work in the background, e.g. settling auctions and backing up the database. Jobs are kept in the
+// database from when they're queued until well after they're done, so they survive restarts: any running when the
+// server stopped are run again once it's back. Each kind of job can only have so many running at once, as can every
+// kind together, and failed jobs are retried with a backoff.
+type Jobs struct {
+	hub         *Hub
+	kinds       map[string]*jobKind
+	concurrency int
+	running     int
+	mux         sync.Mutex
+	wake        chan struct{}
+}
+
+func NewJobs(hub *Hub) *Jobs {
+	j := &Jobs{
+		hub:         hub,
+		kinds:       make(map[string]*jobKind),
+		concurrency: DefaultJobConcurrency,
+		wake:        make(chan struct{}, 1),
+	}
+	j.Register(pruneJobsKind, j.prune, JobOptions{Every: time.Hour})
+	return j
+}
+
+// Must be called before the hub is run. Left at 0, the concurrency keeps its default.
+func (j *Jobs) Configure(concurrency int) {
+	if concurrency > 0 {
+		j.concurrency = concurrency
+	}
+}
+
+// Must be called before the hub is run. Jobs of kinds that aren't registered stay queued until they are.
+func (j *Jobs) Register(kind string, handler JobHandler, options JobOptions) {
+	if _, exists := j.kinds[kind]; exists {
+		panic(fmt.Sprintf("job kind %s registered twice", kind))
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultJobMaxAttempts
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultJobTimeout
+	}
+	j.kinds[kind] = &jobKind{name: kind, handler: handler, options: options}
+}
+
+// Queue a job of the kind to run as soon as there's room, with the payload encoded as JSON for its handler
+func (j *Jobs) Queue(kind string, payload any) (db.Job, error) {
+	if _, exists := j.kinds[kind]; !exists {
+		return db.Job{}, ErrUnknownJobKind
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return db.Job{}, fmt.Errorf("error encoding %s job: %w", kind, err)
+	}
+
+	dbTx := j.hub.NewDbTx()
+	now := time.Now().UnixMilli()
+	job, err := dbTx.Queries.CreateJob(dbTx.Ctx, db.CreateJobParams{
+		Kind:      kind,
+		Payload:   data,
+		CreatedAt: now,
+		RunAt:     now,
+	})
+	if err != nil {
+		return db.Job{}, err
+	}
+	j.Wake()
+	return job, nil
+}
+
+// Run the recurring kind now rather than when it's next due, or straight after it finishes if it's running
+func (j *Jobs) RunNow(kind string) error {
+	j.mux.Lock()
+	if k, exists := j.kinds[kind]; exists && k.running > 0 {
+		k.again = true
+	}
+	j.mux.Unlock()
+
+	dbTx := j.hub.NewDbTx()
+	if _, err := dbTx.Queries.RunJobNow(dbTx.Ctx, db.RunJobNowParams{RunAt: time.Now().UnixMilli(), Kind: kind}); err != nil {
+		return err
+	}
+	j.Wake()
+	return nil
+}
+
+// Give a job that's failed for good another go, with all its attempts
+func (j *Jobs) Retry(jobId int64) (db.Job, error) {
+	dbTx := j.hub.NewDbTx()
+	retried, err := dbTx.Queries.RetryJob(dbTx.Ctx, db.RetryJobParams{RunAt: time.Now().UnixMilli(), ID: jobId})
+	if err != nil {
+		return db.Job{}, err
+	}
+	job, err := dbTx.Queries.GetJob(dbTx.Ctx, jobId)
+	if err != nil {
+		return db.Job{}, err
+	}
+	if retried == 0 {
+		return job, ErrJobNotFailed
+	}
+	j.Wake()
+	return job, nil
+}
+
+// Every registered kind of job, by name
+func (j *Jobs) Kinds() []JobKindStatus {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	kinds := make([]JobKindStatus, 0, len(j.kinds))
+	for _, kind := range j.kinds {
+		kinds = append(kinds, JobKindStatus{
+			Kind:        kind.name,
+			Running:     kind.running,
+			Concurrency: kind.options.Concurrency,
+			Every:       kind.options.Every,
+		})
+	}
+	sort.Slice(kinds, func(a, b int) bool { return kinds[a].Kind < kinds[b].Kind })
+	return kinds
+}
+
+// Look for due jobs now rather than on the next poll
+func (j *Jobs) Wake() {
+	select {
+	case j.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (j *Jobs) runLoop() {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	ready := false
+	for {
+		if j.hub.dbHealth.Healthy() {
+			if !ready {
+				if err := j.recover(); err != nil {
+					logging.Hub.Errorf("Error recovering jobs: %v", err)
+				} else {
+					ready = true
+				}
+			}
+			if ready {
+				if err := j.startDue(); err != nil {
+					logging.Hub.Errorf("Error starting jobs: %v", err)
+				}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-j.wake:
+		}
+	}
+}
+
+// Put jobs that were running when the server last stopped back in the queue, and schedule each recurring kind
+func (j *Jobs) recover() error {
+	dbTx := j.hub.NewDbTx()
+	reset, err := dbTx.Queries.ResetRunningJobs(dbTx.Ctx)
+	if err != nil {
+		return err
+	}
+	if reset > 0 {
+		logging.Hub.Printf("Running %d jobs again that were cut short when the server last stopped", reset)
+	}
+
+	now := time.Now().UnixMilli()
+	for _, kind := range j.kinds {
+		if kind.options.Every <= 0 {
+			continue
+		}
+		err := dbTx.Queries.ScheduleJob(dbTx.Ctx, db.ScheduleJobParams{
+			Kind:      kind.name,
+			EveryMs:   kind.options.Every.Milliseconds(),
+			CreatedAt: now,
+			RunAt:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("error scheduling %s: %w", kind.name, err)
+		}
+	}
+	return nil
+}
+
+// Start as many due jobs as there's room for, oldest first
+func (j *Jobs) startDue() error {
+	dbTx := j.hub.NewDbTx()
+	due, err := dbTx.Queries.GetDueJobs(dbTx.Ctx, db.GetDueJobsParams{
+		RunAt: time.Now().UnixMilli(),
+		Limit: jobBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, job := range due {
+		kind, exists := j.kinds[job.Kind]
+		if !exists || !j.reserve(kind) {
+			continue
+		}
+
+		started, err := dbTx.Queries.StartJob(dbTx.Ctx, db.StartJobParams{
+			StartedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+			ID:        job.ID,
+		})
+		if err != nil || started == 0 {
+			j.release(kind)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		job.Attempts++
+		diagnostics.Go("jobs", func() { j.run(kind, job) })
+	}
+	return nil
+}
+
+// Take a place for a job of the kind to run, if there's room
+func (j *Jobs) reserve(kind *jobKind) bool {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	if j.running >= j.concurrency || kind.running >= kind.options.Concurrency {
+		return false
+	}
+	j.running++
+	kind.running++
+	jobsRunning.With(kind.name).Add(1)
+	return true
+}
+
+func (j *Jobs) release(kind *jobKind) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	j.running--
+	kind.running--
+	jobsRunning.With(kind.name).Add(-1)
+}
+
+func (j *Jobs) run(kind *jobKind, job db.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), kind.options.Timeout)
+	started := time.Now()
+	err := runJobHandler(ctx, kind.handler, job.Payload)
+	cancel()
+	jobSeconds.Observe(kind.name, time.Since(started).Seconds())
+	jobRunsTotal.With(kind.name).Inc()
+
+	now := time.Now()
+	every := time.Duration(job.EveryMs) * time.Millisecond
+	finished := db.FinishJobParams{
+		Status:     JobPending,
+		Attempts:   job.Attempts,
+		RunAt:      now.Add(every).UnixMilli(),
+		FinishedAt: sql.NullInt64{Int64: now.UnixMilli(), Valid: true},
+		ID:         job.ID,
+	}
+
+	j.mux.Lock()
+	again := kind.again
+	kind.again = false
+	j.mux.Unlock()
+
+	if err != nil {
+		jobFailuresTotal.With(kind.name).Inc()
+		finished.LastError = err.Error()
+	}
+	switch {
+	case err == nil && every > 0:
+		finished.Attempts = 0
+		if again {
+			finished.RunAt = now.UnixMilli()
+		}
+	case err == nil:
+		finished.Status = JobDone
+	case job.Attempts < int64(kind.options.MaxAttempts):
+		backoff := min(time.Second<<min(job.Attempts-1, 20), jobMaxBackoff)
+		finished.RunAt = now.Add(backoff).UnixMilli()
+		logging.Hub.Errorf("Error running %s job %d, retrying in %s: %v", kind.name, job.ID, backoff, err)
+	case every > 0:
+		finished.Attempts = 0
+		logging.Hub.Errorf("Error running %s job %d, giving up until it's next due in %s: %v", kind.name, job.ID, every, err)
+	default:
+		finished.Status = JobFailed
+		logging.Hub.Errorf("Error running %s job %d, failed after %d attempts: %v", kind.name, job.ID, job.Attempts, err)
+	}
+
+	err = j.hub.NewDbTx().WriteBehind(fmt.Sprintf("finishing %s job %d", kind.name, job.ID), func(ctx context.Context, queries *db.Queries) error {
+		return queries.FinishJob(ctx, finished)
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error finishing %s job %d: %v", kind.name, job.ID, err)
+	}
+
+	j.release(kind)
+	j.Wake()
+}
+
+// Run the handler, turning a panic into an error so one bad job can't take the server down
+func runJobHandler(ctx context.Context, handler JobHandler, payload json.RawMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, payload)
+}
+
+// Delete jobs that finished long enough ago
+func (j *Jobs) prune(ctx context.Context, _ json.RawMessage) error {
+	pruned, err := j.hub.NewDbTx().Queries.DeleteFinishedJobs(ctx, sql.NullInt64{Int64: time.Now().Add(-jobRetention).UnixMilli(), Valid: true})
+	if err != nil {
+		return err
+	}
+	if pruned > 0 {
+		logging.Hub.Printf("Deleted %d jobs finished more than %s ago", pruned, jobRetention)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"math/rand/v2"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"time"
+)
+
+// Why clients are kicked, sent to them so they can tell whether to come back, and counted by
+const (
+	// The server's restarting, and will be back soon
+	KickMaintenance = "maintenance"
+
+	// The server's full, or was and the client was disconnected to make room for a player with priority
+	KickCapacity = "capacity"
+
+	// The character was loaded by another session, which is the one to keep playing
+	KickSessionReplaced = "session_replaced"
+
+	// The account's gone, so there's nothing to come back to
+	KickAccountDeleted = "account_deleted"
+
+	// The player logged the session out from another one, so coming back by itself would undo it
+	KickLoggedOut = "logged_out"
+
+	// The account's banned, so logging in again won't get anywhere until the ban's up
+	KickBanned = "banned"
+
+	// Nothing was received from the client for too long
+	KickTimeout = "timeout"
+
+	// The client kept sending packets that couldn't be parsed
+	KickProtocolError = "protocol_error"
+)
+
+// The kind of reason each reason code is, for clients to show a message that fits. Codes not in here are just kicks.
+var kickDisconnectReasons = map[string]packets.DisconnectReason{
+	KickMaintenance:     packets.DisconnectReason_DISCONNECT_REASON_SHUTDOWN,
+	KickSessionReplaced: packets.DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN,
+	KickLoggedOut:       packets.DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN,
+	KickBanned:          packets.DisconnectReason_DISCONNECT_REASON_BANNED,
+	KickTimeout:         packets.DisconnectReason_DISCONNECT_REASON_TIMEOUT,
+	KickProtocolError:   packets.DisconnectReason_DISCONNECT_REASON_PROTOCOL_ERROR,
+}
+
+// How long clients are told to wait before reconnecting after each kind of kick, before the jitter's added. Kicks not
+// in here aren't worth reconnecting after.
+var kickRetryAfter = map[string]time.Duration{
+	KickMaintenance: 30 * time.Second,
+	KickCapacity:    time.Minute,
+}
+
+// The most of the wait that's added at random, so clients kicked together don't all come back together
+const kickRetryJitter = 0.5
+
+var kicksTotal = metrics.NewCounterVec("mmo_kicks_total", "Clients kicked with reconnect guidance, by reason code.", "reason_code")
+
+// Works out what clients are told as they're kicked, so instead of reconnecting straight away, and all at once, they
+// wait as long as they're told to and go where they're told to, or don't come back at all if there's no point
+type Kicks struct {
+	hub *Hub
+
+	// Where clients are told to reconnect to instead of here, if anywhere
+	alternateEndpoint string
+}
+
+func NewKicks(hub *Hub) *Kicks {
+	return &Kicks{hub: hub}
+}
+
+// Must be called before the hub is run
+func (k *Kicks) Configure(alternateEndpoint string) {
+	k.alternateEndpoint = alternateEndpoint
+	if alternateEndpoint != "" {
+		logging.Hub.Printf("Telling kicked clients to reconnect to %s", alternateEndpoint)
+	}
+}
+
+// What to tell a client being kicked for the given reason code, with the reason to show the player
+func (k *Kicks) Hint(code string, reason string) *packets.KickMessage {
+	kicksTotal.With(code).Inc()
+	kick := &packets.KickMessage{ReasonCode: code, Reason: reason, DisconnectReason: packets.DisconnectReason_DISCONNECT_REASON_KICKED}
+	if disconnectReason, exists := kickDisconnectReasons[code]; exists {
+		kick.DisconnectReason = disconnectReason
+	}
+
+	retryAfter, reconnect := kickRetryAfter[code]
+	if !reconnect {
+		return kick
+	}
+	retryAfter += time.Duration(rand.Float64() * kickRetryJitter * float64(retryAfter))
+	kick.Reconnect = true
+	kick.RetryAfterSeconds = uint32(retryAfter.Round(time.Second).Seconds())
+	kick.AlternateEndpoint = k.alternateEndpoint
+	return kick
+}
@@ -0,0 +1,175 @@
+package server
+
+import (
+	"fmt"
+	"server/internal/server/metrics"
+	"strings"
+	"sync"
+	"time"
+)
+
+// As far from UTC as any time zone's clock is
+const maxUtcOffsetMinutes = 14 * 60
+
+// The most locales told apart in the metrics, past which any more are counted as other
+const maxMetricLocales = 200
+
+var (
+	clientLocales    = metrics.NewGaugeVec("mmo_client_locales", "Clients logged in, by the locale they reported.", "locale")
+	clientUtcOffsets = metrics.NewGaugeVec("mmo_client_utc_offsets", "Clients logged in, by the UTC offset they reported in hours.", "offset")
+)
+
+// Regions where clocks are read in 12 hours, as are those of English without a region
+var twelveHourRegions = map[string]bool{
+	"US": true, "CA": true, "AU": true, "NZ": true, "PH": true, "IN": true, "PK": true, "EG": true, "SA": true, "MX": true,
+}
+
+// The locales told apart in the metrics so far
+var metricLocales = struct {
+	seen map[string]bool
+	mux  sync.Mutex
+}{seen: make(map[string]bool)}
+
+// The language and time zone a client reported when logging in, for telling the player when things are scheduled in
+// their own time. Until they're reported, times are told in UTC.
+type Locale struct {
+	// A language tag like en-US, or empty if the client didn't report one that makes sense
+	tag       string
+	utcOffset time.Duration
+
+	// What the client's counted as in the metrics, which it is from logging in until it's closed
+	counted       bool
+	countedTag    string
+	countedOffset string
+
+	mux sync.Mutex
+}
+
+// Take what the client reported at login. Locales that aren't language tags and offsets further out than any time
+// zone's are ignored.
+func (l *Locale) Set(locale string, utcOffsetMinutes int32) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.uncount()
+	l.tag = normalizeLocale(locale)
+	l.utcOffset = 0
+	if utcOffsetMinutes >= -maxUtcOffsetMinutes && utcOffsetMinutes <= maxUtcOffsetMinutes {
+		l.utcOffset = time.Duration(utcOffsetMinutes) * time.Minute
+	}
+
+	l.counted, l.countedTag, l.countedOffset = true, metricLocale(l.tag), metricUtcOffset(l.utcOffset)
+	clientLocales.With(l.countedTag).Add(1)
+	clientUtcOffsets.With(l.countedOffset).Add(1)
+}
+
+// Take the client out of the metrics, once it's closed
+func (l *Locale) Forget() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.uncount()
+}
+
+func (l *Locale) Tag() string {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.tag
+}
+
+func (l *Locale) UtcOffset() time.Duration {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.utcOffset
+}
+
+// The time on the player's clock, e.g. "14:30" or "2:30 PM" depending on how it's read where they are. Times on
+// another day than today on their clock have the date too.
+func (l *Locale) FormatTime(t time.Time) string {
+	l.mux.Lock()
+	tag, utcOffset := l.tag, l.utcOffset
+	l.mux.Unlock()
+
+	zone := time.FixedZone("", int(utcOffset/time.Second))
+	local, now := t.In(zone), time.Now().In(zone)
+
+	layout := "15:04"
+	language, region, _ := strings.Cut(tag, "-")
+	if twelveHourRegions[region] || tag == "en" {
+		layout = "3:04 PM"
+	}
+	if local.YearDay() != now.YearDay() || local.Year() != now.Year() {
+		if region == "US" || tag == "en" {
+			layout = "Jan 2, " + layout
+		} else if language != "" {
+			layout = "2 Jan, " + layout
+		} else {
+			layout = "2006-01-02 " + layout
+		}
+	}
+
+	formatted := local.Format(layout)
+	if tag == "" && utcOffset == 0 {
+		formatted += " UTC"
+	}
+	return formatted
+}
+
+// Must be called with the lock held
+func (l *Locale) uncount() {
+	if !l.counted {
+		return
+	}
+	l.counted = false
+	clientLocales.With(l.countedTag).Add(-1)
+	clientUtcOffsets.With(l.countedOffset).Add(-1)
+}
+
+func metricLocale(tag string) string {
+	if tag == "" {
+		return "unknown"
+	}
+
+	metricLocales.mux.Lock()
+	defer metricLocales.mux.Unlock()
+	if !metricLocales.seen[tag] {
+		if len(metricLocales.seen) >= maxMetricLocales {
+			return "other"
+		}
+		metricLocales.seen[tag] = true
+	}
+	return tag
+}
+
+// In hours, to the nearest quarter of an hour, which every time zone is a whole number of
+func metricUtcOffset(utcOffset time.Duration) string {
+	return fmt.Sprintf("%+g", utcOffset.Round(15*time.Minute).Hours())
+}
+
+// The locale as a language tag like en-US, from how clients might report it, e.g. "en_US.UTF-8". Anything else is
+// empty, which also keeps the metrics from being flooded with made-up locales.
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	language, region, hasRegion := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+
+	if len(language) < 2 || len(language) > 3 || !isLetters(language) {
+		return ""
+	}
+	language = strings.ToLower(language)
+	if !hasRegion {
+		return language
+	}
+	if len(region) != 2 || !isLetters(region) {
+		return ""
+	}
+	return language + "-" + strings.ToUpper(region)
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
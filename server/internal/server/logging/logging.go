@@ -0,0 +1,227 @@
+// Loggers for each module of the server, whose levels can be changed while it's running, e.g. to turn on debug logging
+// for the states without drowning in everything else. Lines logged on every packet go through a sampler, so debug
+// logging can be left on in production without filling the disk.
+package logging
+
+import (
+	"fmt"
+	"log"
+	"server/internal/server/metrics"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+	LevelOff
+)
+
+// Modules only log at info and above, and log one in this many sampled lines, unless changed
+const (
+	DefaultLevel       = LevelInfo
+	DefaultSampleEvery = 100
+)
+
+var levelNames = []string{"debug", "info", "error", "off"}
+
+func (l Level) String() string {
+	if l < 0 || int(l) >= len(levelNames) {
+		return fmt.Sprintf("level(%d)", l)
+	}
+	return levelNames[l]
+}
+
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return Level(level), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q, expected one of %s", name, strings.Join(levelNames, ", "))
+}
+
+var (
+	sampledOutTotal = metrics.NewCounterVec("mmo_log_lines_sampled_out_total", "Sampled log lines left out, by module.", "module")
+	errorsTotal     = metrics.NewCounterVec("mmo_log_errors_total", "Errors logged, by module, whether or not errors are logged at its level.", "module")
+)
+
+var (
+	modules    = make(map[string]*Module)
+	modulesMux sync.Mutex
+)
+
+var (
+	Hub     = NewModule("hub")
+	Clients = NewModule("clients")
+	Db      = NewModule("db")
+	States  = NewModule("states")
+	Admin   = NewModule("admin")
+)
+
+// A part of the server with its own log level. Its own lines have no prefix, and it can make loggers with their own
+// prefixes, e.g. one for each client, which share its level.
+type Module struct {
+	*Logger
+
+	name        string
+	level       atomic.Int32
+	sampleEvery atomic.Int64
+
+	// How many times each sampled line has come up, by its format
+	sampled sync.Map
+}
+
+func NewModule(name string) *Module {
+	modulesMux.Lock()
+	defer modulesMux.Unlock()
+	if _, exists := modules[name]; exists {
+		panic(fmt.Sprintf("log module %s registered twice", name))
+	}
+
+	m := &Module{name: name}
+	m.level.Store(int32(DefaultLevel))
+	m.sampleEvery.Store(DefaultSampleEvery)
+	m.Logger = m.NewLogger("")
+	modules[name] = m
+	return m
+}
+
+// The module with the given name, if there is one
+func Lookup(name string) (*Module, bool) {
+	modulesMux.Lock()
+	defer modulesMux.Unlock()
+	m, exists := modules[name]
+	return m, exists
+}
+
+// Every module, by name
+func Modules() []*Module {
+	modulesMux.Lock()
+	defer modulesMux.Unlock()
+	all := make([]*Module, 0, len(modules))
+	for _, m := range modules {
+		all = append(all, m)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].name < all[j].name })
+	return all
+}
+
+// Set the level of each named module, by module name, e.g. from "hub=info,states=debug"
+func Configure(levels string) error {
+	for _, setting := range strings.Split(levels, ",") {
+		if setting = strings.TrimSpace(setting); setting == "" {
+			continue
+		}
+		name, levelName, found := strings.Cut(setting, "=")
+		if !found {
+			return fmt.Errorf("expected module=level, got %q", setting)
+		}
+		m, exists := Lookup(strings.TrimSpace(name))
+		if !exists {
+			return fmt.Errorf("unknown log module %q", name)
+		}
+		level, err := ParseLevel(strings.TrimSpace(levelName))
+		if err != nil {
+			return err
+		}
+		m.SetLevel(level)
+	}
+	return nil
+}
+
+func (m *Module) Name() string {
+	return m.name
+}
+
+func (m *Module) Level() Level {
+	return Level(m.level.Load())
+}
+
+func (m *Module) SetLevel(level Level) {
+	m.level.Store(int32(level))
+}
+
+func (m *Module) SampleEvery() int64 {
+	return m.sampleEvery.Load()
+}
+
+// Log one in every n sampled lines, or all of them if 1
+func (m *Module) SetSampleEvery(n int64) {
+	m.sampleEvery.Store(max(n, 1))
+}
+
+func (m *Module) Enabled(level Level) bool {
+	return level >= m.Level()
+}
+
+// A logger for the module whose lines start with the prefix
+func (m *Module) NewLogger(prefix string) *Logger {
+	return &Logger{module: m, logger: log.New(standardWriter{}, prefix, log.LstdFlags)}
+}
+
+// Writes wherever the standard logger does at the time, so it can be redirected after modules are made
+type standardWriter struct{}
+
+func (standardWriter) Write(p []byte) (int, error) {
+	return log.Writer().Write(p)
+}
+
+// Whether this occurrence of the sampled line should be logged
+func (m *Module) sample(format string) bool {
+	counter, _ := m.sampled.LoadOrStore(format, &atomic.Int64{})
+	if (counter.(*atomic.Int64).Add(1)-1)%m.SampleEvery() == 0 {
+		return true
+	}
+	sampledOutTotal.With(m.name).Inc()
+	return false
+}
+
+// Logs for a module, at whichever levels the module is logging at
+type Logger struct {
+	module *Module
+	logger *log.Logger
+}
+
+func (l *Logger) SetPrefix(prefix string) {
+	l.logger.SetPrefix(prefix)
+}
+
+func (l *Logger) Debugf(format string, v ...any) {
+	if l.module.Enabled(LevelDebug) {
+		l.logger.Output(2, fmt.Sprintf(format, v...))
+	}
+}
+
+// Log at debug, but only one in every so many times the line comes up, for lines logged far too often to log each one
+func (l *Logger) Sampledf(format string, v ...any) {
+	if l.module.Enabled(LevelDebug) && l.module.sample(format) {
+		l.logger.Output(2, fmt.Sprintf(format, v...))
+	}
+}
+
+// Log at info
+func (l *Logger) Printf(format string, v ...any) {
+	if l.module.Enabled(LevelInfo) {
+		l.logger.Output(2, fmt.Sprintf(format, v...))
+	}
+}
+
+// Log at info
+func (l *Logger) Println(v ...any) {
+	if l.module.Enabled(LevelInfo) {
+		l.logger.Output(2, fmt.Sprintln(v...))
+	}
+}
+
+func (l *Logger) Errorf(format string, v ...any) {
+	errorsTotal.With(l.module.name).Inc()
+	if l.module.Enabled(LevelError) {
+		l.logger.Output(2, fmt.Sprintf(format, v...))
+	}
+}
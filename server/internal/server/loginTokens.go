@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How long a login token's good for, which only has to be long enough to connect over UDP with it
+const loginTokenTtl = 30 * time.Second
+
+// Lets players log in over UDP, which passwords can't be sent over since nothing sent over it is encrypted. Logging in
+// over a secure connection for UDP gets a token instead, good for one login by the same username soon after. Tokens
+// are only kept in memory, so they're lost in a restart.
+type LoginTokens struct {
+	hub *Hub
+
+	tokens map[string]loginToken
+	mux    sync.Mutex
+}
+
+type loginToken struct {
+	username  string
+	expiresAt time.Time
+}
+
+func NewLoginTokens(hub *Hub) *LoginTokens {
+	return &LoginTokens{hub: hub, tokens: make(map[string]loginToken)}
+}
+
+// A token the username can log in with in place of its password, and when it runs out
+func (l *LoginTokens) Issue(username string) (string, time.Time) {
+	raw := make([]byte, 32)
+	rand.Read(raw)
+	token := hex.EncodeToString(raw)
+	now := time.Now()
+	expiresAt := now.Add(loginTokenTtl)
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	// Few enough are ever issued at once that forgetting those run out here is as good as looking for them on a timer
+	for t, issued := range l.tokens {
+		if !now.Before(issued.expiresAt) {
+			delete(l.tokens, t)
+		}
+	}
+	l.tokens[token] = loginToken{username: strings.ToLower(username), expiresAt: expiresAt}
+	return token, expiresAt
+}
+
+// Use up the token, returning whether it's still good and for the username
+func (l *LoginTokens) Redeem(token string, username string) bool {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	issued, exists := l.tokens[token]
+	if !exists {
+		return false
+	}
+	delete(l.tokens, token)
+	return time.Now().Before(issued.expiresAt) && issued.username == strings.ToLower(username)
+}
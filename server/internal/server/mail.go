@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"time"
+)
+
+const (
+	// Unclaimed mail is deleted once it's this old, along with anything attached to it
+	mailRetention = 90 * 24 * time.Hour
+
+	mailCleanupInterval = time.Hour
+	mailCleanupJob      = "mail.cleanup"
+)
+
+// Send mail to a player, optionally with items attached which they get once they claim it. Pass queries that are part
+// of a transaction if the items are being taken from somewhere else at the same time.
+func SendMail(ctx context.Context, queries *db.Queries, playerDbId int64, sender string, subject string, itemId string, quantity int64) error {
+	return queries.CreateMail(ctx, db.CreateMailParams{
+		PlayerID: playerDbId,
+		Sender:   sender,
+		Subject:  subject,
+		ItemID:   itemId,
+		Quantity: quantity,
+		SentAt:   time.Now().UnixMilli(),
+	})
+}
+
+// Let the player know they've got new mail if they're online. Call once the mail has been committed.
+func (h *Hub) NotifyMail(playerDbId int64) {
+	if client, online := h.ClientByPlayerDbId(playerDbId); online {
+		client.ProcessMessage(0, packets.NewMailboxRequest())
+	}
+}
+
+// Delete mail that's gone unclaimed for too long
+func (h *Hub) cleanUpMail(ctx context.Context, _ json.RawMessage) error {
+	deleted, err := h.NewDbTx().Queries.DeleteMailSentBefore(ctx, time.Now().Add(-mailRetention).UnixMilli())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logging.Hub.Printf("Deleted %d mail left unclaimed for more than %s", deleted, mailRetention)
+	}
+	return nil
+}
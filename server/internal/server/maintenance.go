@@ -0,0 +1,202 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The exit code of the server after a scheduled restart, so the orchestrator can tell it apart from a crash
+const RestartExitCode = 75
+
+// How long to wait for everyone's progress to be saved as they're disconnected for a restart
+const restartDrainTimeout = 10 * time.Second
+
+// How long before a restart players start being warned about it
+const restartCountdown = 10 * time.Minute
+
+// How long before a restart actions that could be lost or duplicated by it are refused
+const restartFreezeWindow = time.Minute
+
+// Who restart countdowns are announced by, in the announcement history
+const maintenanceAuthor = "Server"
+
+// When players are warned about an upcoming restart, as time left until it
+var restartAnnouncements = []time.Duration{
+	10 * time.Minute,
+	5 * time.Minute,
+	2 * time.Minute,
+	time.Minute,
+	30 * time.Second,
+	10 * time.Second,
+}
+
+// Schedules restarts of the server, counting down to them in chat and preserving the state of the world across them
+type Maintenance struct {
+	hub *Hub
+
+	// Written just before exiting for a restart and restored on the next boot
+	snapshotPath string
+
+	resumedPlayers resumedPlayers
+
+	scheduled bool
+	frozen    atomic.Bool
+	done      chan struct{}
+	mux       sync.Mutex
+}
+
+func NewMaintenance(hub *Hub, dataDirPath string) *Maintenance {
+	return &Maintenance{
+		hub:          hub,
+		snapshotPath: path.Join(dataDirPath, resumeSnapshotFile),
+		done:         make(chan struct{}),
+	}
+}
+
+// Restart the server at the given time, warning players as it approaches. Only one restart can be scheduled at a
+// time.
+func (m *Maintenance) ScheduleRestart(at time.Time) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.scheduled {
+		return fmt.Errorf("a restart is already scheduled")
+	}
+	m.scheduled = true
+
+	logging.Hub.Printf("Restart scheduled for %s", at.Format(time.RFC3339))
+	go m.countdown(at)
+	return nil
+}
+
+// Whether a restart is imminent, in which case anything that changes players' inventories or the like should be
+// refused until after it
+func (m *Maintenance) Frozen() bool {
+	return m.frozen.Load()
+}
+
+// Closed once the world has been saved for a restart and the process should exit with RestartExitCode
+func (m *Maintenance) Done() <-chan struct{} {
+	return m.done
+}
+
+// Put the player back where they were before the last restart, if they were in game at the time. Returns false if
+// they weren't.
+func (m *Maintenance) ResumePlayer(player *objects.Player) bool {
+	snapshot, exists := m.resumedPlayers.take(player.DbId)
+	if !exists {
+		return false
+	}
+	player.X, player.Y, player.Radius = snapshot.X, snapshot.Y, snapshot.Radius
+	if snapshot.Components != nil {
+		player.Components.CopyFrom(snapshot.Components)
+	}
+	return true
+}
+
+func (m *Maintenance) countdown(at time.Time) {
+	if wait := time.Until(at) - restartCountdown; wait > 0 {
+		time.Sleep(wait)
+	}
+
+	if time.Until(at) <= restartFreezeWindow {
+		m.frozen.Store(true)
+	}
+
+	for _, announcement := range restartAnnouncements {
+		wait := time.Until(at) - announcement
+		if wait < 0 {
+			continue
+		}
+		time.Sleep(wait)
+
+		if announcement <= restartFreezeWindow {
+			m.frozen.Store(true)
+		}
+		m.announceAt(fmt.Sprintf("The server will restart in %s", formatCountdown(announcement)), at)
+	}
+
+	time.Sleep(time.Until(at))
+	m.frozen.Store(true)
+	m.announce("The server is restarting now")
+
+	m.restart()
+}
+
+func (m *Maintenance) announce(message string) {
+	m.hub.Announcements.Announce(maintenanceAuthor, message)
+}
+
+// Announce something happening at the given time, telling each player when that is on their own clock
+func (m *Maintenance) announceAt(message string, at time.Time) {
+	m.hub.Announcements.record(maintenanceAuthor, fmt.Sprintf("%s, at %s", message, at.UTC().Format("15:04 UTC")))
+	m.hub.Clients.ForEach(func(_ uint64, client ClientInterfacer) {
+		client.ProcessMessage(0, packets.NewChat(fmt.Sprintf("%s, at %s", message, client.Locale().FormatTime(at))))
+	})
+}
+
+// Snapshot the world, then disconnect everyone so their progress is saved as they leave the game
+func (m *Maintenance) restart() {
+	logging.Hub.Println("Writing resume snapshot...")
+	if err := takeResumeSnapshot(m.hub.SharedGameObjects).write(m.snapshotPath); err != nil {
+		logging.Hub.Errorf("Error writing resume snapshot, the world will start over after the restart: %v", err)
+	}
+
+	logging.Hub.Println("Disconnecting clients for restart...")
+	m.hub.Clients.ForEach(func(_ uint64, client ClientInterfacer) {
+		go client.Kick(KickMaintenance, "Server restarting")
+	})
+
+	// Clients are only unregistered once they've left their state, which is when their progress is saved
+	deadline := time.Now().Add(restartDrainTimeout)
+	for m.hub.Clients.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if remaining := m.hub.Clients.Len(); remaining > 0 {
+		logging.Hub.Printf("Restarting with %d clients still connected", remaining)
+	}
+
+	logging.Hub.Println("Writing saves held back...")
+	m.hub.Saves.FlushAll()
+	m.hub.WriteBatches.flush()
+	m.hub.Bandwidth.flush()
+
+	close(m.done)
+}
+
+// Restore the world from the snapshot written before the last restart, if there is one. Returns false if there wasn't,
+// in which case the world should be populated from scratch.
+func (m *Maintenance) restoreSnapshot() bool {
+	snapshot, err := consumeResumeSnapshot(m.snapshotPath)
+	if err != nil {
+		logging.Hub.Errorf("Error reading resume snapshot, starting the world over: %v", err)
+		return false
+	}
+	if snapshot == nil {
+		return false
+	}
+
+	snapshot.restore(m.hub.SharedGameObjects)
+	m.resumedPlayers.set(snapshot.Players)
+	logging.Hub.Printf("Restored %d spores, %d resource nodes and %d players from the resume snapshot taken at %s",
+		len(snapshot.Spores), len(snapshot.ResourceNodes), len(snapshot.Players),
+		time.UnixMilli(snapshot.TakenAt).Format(time.RFC3339))
+	return true
+}
+
+func formatCountdown(d time.Duration) string {
+	if d >= time.Minute {
+		minutes := int(d / time.Minute)
+		if minutes == 1 {
+			return "1 minute"
+		}
+		return fmt.Sprintf("%d minutes", minutes)
+	}
+	return fmt.Sprintf("%d seconds", int(d/time.Second))
+}
@@ -0,0 +1,343 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// How long players have to come in with their join tokens if the matchmaker doesn't say
+	DefaultReservationTtl = time.Minute
+	maxReservationTtl     = 10 * time.Minute
+
+	maxReservationPlayers = 100
+
+	// How often reservations are checked for having run out
+	reservationExpiryInterval = time.Second
+
+	MatchmakerPath = "/api/matchmaker/"
+)
+
+var (
+	ErrMatchExists      = errors.New("match already has a reservation")
+	ErrInvalidJoinToken = errors.New("invalid or expired join token")
+)
+
+// Places held in a channel for the players of one match
+type MatchReservation struct {
+	MatchId   string        `json:"match_id"`
+	ZoneId    string        `json:"zone"`
+	Channel   int           `json:"channel"`
+	CreatedAt time.Time     `json:"created_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	Tickets   []*JoinTicket `json:"tickets"`
+}
+
+// One player's place in a match, redeemed by logging in with the token
+type JoinTicket struct {
+	Player string `json:"player"`
+	Token  string `json:"token"`
+
+	// Zero until the player has come in
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// Lets an external matchmaker decide where players go. The matchmaker reserves places for a match's players in a zone,
+// and gets back a join token for each, which it hands to the players' clients. Logging in with one puts the player
+// straight into the channel the places were held in, wherever they left off. Places nobody comes in for are given up
+// once the reservation runs out.
+//
+// Players coming in and reservations running out are published as outbox events, so the matchmaker can follow along
+// with a webhook. Reservations are only kept in memory, so they're lost in a restart, which the matchmaker sees as
+// them running out.
+type Matchmaking struct {
+	hub *Hub
+
+	// The matchmaker's bearer token for the API, which is only served if it's set
+	token string
+
+	matches map[string]*MatchReservation
+	tickets map[string]*MatchReservation
+	mux     sync.Mutex
+
+	apiMux *http.ServeMux
+}
+
+func NewMatchmaking(hub *Hub) *Matchmaking {
+	m := &Matchmaking{
+		hub:     hub,
+		matches: make(map[string]*MatchReservation),
+		tickets: make(map[string]*MatchReservation),
+		apiMux:  http.NewServeMux(),
+	}
+	m.apiMux.HandleFunc("POST "+MatchmakerPath+"reservations", m.serveReserve)
+	m.apiMux.HandleFunc("GET "+MatchmakerPath+"reservations/{match}", m.serveReservation)
+	m.apiMux.HandleFunc("DELETE "+MatchmakerPath+"reservations/{match}", m.serveCancel)
+	return m
+}
+
+// Serve the API to the matchmaker with the given token. Must be called before the hub is run.
+func (m *Matchmaking) EnableApi(token string) {
+	m.token = token
+}
+
+// Hold places in the zone for the players, who must each log in as the player their token is for. The channel can be
+// left at 0 to go in whichever has room for all of them.
+func (m *Matchmaking) Reserve(matchId string, zone *gamedata.Zone, channel int, players []string, ttl time.Duration) (*MatchReservation, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if matchId == "" {
+		matchId = randomToken(8)
+	}
+	if _, exists := m.matches[matchId]; exists {
+		return nil, ErrMatchExists
+	}
+
+	channel, err := m.hub.Channels.Reserve(zone, channel, len(players))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	reservation := &MatchReservation{
+		MatchId:   matchId,
+		ZoneId:    zone.Id,
+		Channel:   channel,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	for _, player := range players {
+		ticket := &JoinTicket{Player: strings.ToLower(player), Token: randomToken(32)}
+		reservation.Tickets = append(reservation.Tickets, ticket)
+		m.tickets[ticket.Token] = reservation
+	}
+	m.matches[matchId] = reservation
+
+	logging.Hub.Printf("Reserved %d places in channel %d of zone %s for match %s", len(players), channel, zone.Id, matchId)
+	return reservation, nil
+}
+
+// A copy of the match's reservation, as it stands
+func (m *Matchmaking) Reservation(matchId string) (MatchReservation, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	reservation, exists := m.matches[matchId]
+	if !exists {
+		return MatchReservation{}, false
+	}
+	return reservation.copy(), true
+}
+
+// Give up the places of the match's players who haven't come in yet. Returns false if there was no such reservation.
+func (m *Matchmaking) Cancel(matchId string) bool {
+	m.mux.Lock()
+	reservation, exists := m.matches[matchId]
+	if exists {
+		m.remove(reservation)
+	}
+	m.mux.Unlock()
+
+	if exists {
+		logging.Hub.Printf("Cancelled the reservation for match %s", matchId)
+	}
+	return exists
+}
+
+// Use up the player's join token. Returns the match the player is coming in for, whose channel they should be put in.
+func (m *Matchmaking) Redeem(token string, player string) (MatchReservation, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	reservation, exists := m.tickets[token]
+	if !exists || !time.Now().Before(reservation.ExpiresAt) {
+		return MatchReservation{}, ErrInvalidJoinToken
+	}
+	var ticket *JoinTicket
+	for _, t := range reservation.Tickets {
+		if t.Token == token {
+			ticket = t
+		}
+	}
+	if ticket.Player != strings.ToLower(player) {
+		return MatchReservation{}, ErrInvalidJoinToken
+	}
+
+	ticket.JoinedAt = time.Now()
+	delete(m.tickets, token)
+
+	m.recordEvent("matchmaker.player_joined", map[string]any{
+		"match_id": reservation.MatchId,
+		"player":   ticket.Player,
+		"zone":     reservation.ZoneId,
+		"channel":  reservation.Channel,
+	})
+	return reservation.copy(), nil
+}
+
+func (m *Matchmaking) expireLoop() {
+	ticker := time.NewTicker(reservationExpiryInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+		m.mux.Lock()
+		for _, reservation := range m.matches {
+			if now.Before(reservation.ExpiresAt) {
+				continue
+			}
+
+			var missing []string
+			for _, ticket := range reservation.Tickets {
+				if ticket.JoinedAt.IsZero() {
+					missing = append(missing, ticket.Player)
+				}
+			}
+			m.remove(reservation)
+			if len(missing) > 0 {
+				logging.Hub.Printf("Reservation for match %s ran out with %d players yet to come in", reservation.MatchId, len(missing))
+				m.recordEvent("matchmaker.reservation_expired", map[string]any{
+					"match_id": reservation.MatchId,
+					"missing":  missing,
+				})
+			}
+		}
+		m.mux.Unlock()
+	}
+}
+
+// Forget the reservation, giving up the places nobody came in for. Must be called with the lock held.
+func (m *Matchmaking) remove(reservation *MatchReservation) {
+	delete(m.matches, reservation.MatchId)
+	unused := 0
+	for _, ticket := range reservation.Tickets {
+		if ticket.JoinedAt.IsZero() {
+			delete(m.tickets, ticket.Token)
+			unused++
+		}
+	}
+	m.hub.Channels.Unreserve(reservation.ZoneId, reservation.Channel, unused)
+}
+
+func (m *Matchmaking) recordEvent(topic string, data any) {
+	dbTx := m.hub.NewDbTx()
+	if err := dbTx.RecordEvent(dbTx.Queries, topic, data); err != nil {
+		logging.Hub.Errorf("Error recording %s event: %v", topic, err)
+	}
+}
+
+func (r *MatchReservation) copy() MatchReservation {
+	c := *r
+	c.Tickets = make([]*JoinTicket, len(r.Tickets))
+	for i, ticket := range r.Tickets {
+		t := *ticket
+		c.Tickets[i] = &t
+	}
+	return c
+}
+
+func randomToken(size int) string {
+	token := make([]byte, size)
+	rand.Read(token)
+	return hex.EncodeToString(token)
+}
+
+type reserveRequest struct {
+	// Made up if left out
+	MatchId string `json:"match_id"`
+
+	Zone string `json:"zone"`
+
+	// Whichever has room if left out
+	Channel int `json:"channel"`
+
+	Players []string `json:"players"`
+
+	// DefaultReservationTtl if left out
+	TtlSeconds int64 `json:"ttl_seconds"`
+}
+
+func (m *Matchmaking) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	token, _ := strings.CutPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if m.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.token)) != 1 {
+		http.Error(writer, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	m.apiMux.ServeHTTP(writer, request)
+}
+
+func (m *Matchmaking) serveReserve(writer http.ResponseWriter, request *http.Request) {
+	var body reserveRequest
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		http.Error(writer, "invalid body", http.StatusBadRequest)
+		return
+	}
+	if len(body.Players) == 0 || len(body.Players) > maxReservationPlayers {
+		http.Error(writer, "players must have between 1 and 100 names", http.StatusBadRequest)
+		return
+	}
+	zone, exists := m.hub.GameData.Zone(body.Zone)
+	if !exists {
+		http.Error(writer, "zone not found", http.StatusNotFound)
+		return
+	}
+	if !zone.InSeason(time.Now()) {
+		http.Error(writer, "zone not available", http.StatusConflict)
+		return
+	}
+	ttl := DefaultReservationTtl
+	if body.TtlSeconds > 0 {
+		ttl = min(time.Duration(body.TtlSeconds)*time.Second, maxReservationTtl)
+	}
+
+	reservation, err := m.Reserve(body.MatchId, zone, body.Channel, body.Players, ttl)
+	switch {
+	case errors.Is(err, ErrMatchExists), errors.Is(err, ErrChannelFull):
+		http.Error(writer, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, ErrNoSuchChannel):
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	case err != nil:
+		logging.Hub.Errorf("Error reserving places for match %s: %v", body.MatchId, err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusCreated)
+	json.NewEncoder(writer).Encode(reservation)
+}
+
+func (m *Matchmaking) serveReservation(writer http.ResponseWriter, request *http.Request) {
+	reservation, exists := m.Reservation(request.PathValue("match"))
+	if !exists {
+		http.Error(writer, "reservation not found", http.StatusNotFound)
+		return
+	}
+
+	// The tokens are only handed out once, when reserving
+	for _, ticket := range reservation.Tickets {
+		ticket.Token = ""
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(writer).Encode(reservation)
+}
+
+func (m *Matchmaking) serveCancel(writer http.ResponseWriter, request *http.Request) {
+	if !m.Cancel(request.PathValue("match")) {
+		http.Error(writer, "reservation not found", http.StatusNotFound)
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,369 @@
+// A minimal metrics registry exposed in the Prometheus text format, so the server can be scraped without pulling in
+// the full Prometheus client library.
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type collector interface {
+	write(builder *strings.Builder)
+	read() Reading
+}
+
+// What a metric reads right now, for the server to keep an eye on itself
+type Reading struct {
+	// counter, gauge or histogram
+	Kind string
+
+	// Of counters and gauges. Counters with labels are summed, and the highest of gauges with labels is taken.
+	Value float64
+
+	// Of histograms, every label's put together
+	Sum   float64
+	Count uint64
+}
+
+var (
+	registry    = make(map[string]collector)
+	registryMux sync.Mutex
+)
+
+func register(name string, c collector) {
+	registryMux.Lock()
+	defer registryMux.Unlock()
+
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("metric %s registered twice", name))
+	}
+	registry[name] = c
+}
+
+// Serves all registered metrics
+func Handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		registryMux.Lock()
+		names := make([]string, 0, len(registry))
+		for name := range registry {
+			names = append(names, name)
+		}
+		collectors := make([]collector, 0, len(names))
+		sort.Strings(names)
+		for _, name := range names {
+			collectors = append(collectors, registry[name])
+		}
+		registryMux.Unlock()
+
+		builder := &strings.Builder{}
+		for _, c := range collectors {
+			c.write(builder)
+		}
+
+		writer.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writer.Write([]byte(builder.String()))
+	})
+}
+
+// What the metric with the name reads, if there is one
+func Read(name string) (Reading, bool) {
+	registryMux.Lock()
+	c, exists := registry[name]
+	registryMux.Unlock()
+	if !exists {
+		return Reading{}, false
+	}
+	return c.read(), true
+}
+
+func writeHeader(builder *strings.Builder, name, help, kind string) {
+	fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// A value that only goes up
+type Counter struct {
+	name  string
+	help  string
+	value atomic.Uint64
+}
+
+func NewCounter(name, help string) *Counter {
+	c := &Counter{name: name, help: help}
+	register(name, c)
+	return c
+}
+
+func (c *Counter) Inc() {
+	c.value.Add(1)
+}
+
+func (c *Counter) Add(n uint64) {
+	c.value.Add(n)
+}
+
+func (c *Counter) Value() uint64 {
+	return c.value.Load()
+}
+
+func (c *Counter) read() Reading {
+	return Reading{Kind: "counter", Value: float64(c.Value())}
+}
+
+func (c *Counter) write(builder *strings.Builder) {
+	writeHeader(builder, c.name, c.help, "counter")
+	fmt.Fprintf(builder, "%s %d\n", c.name, c.Value())
+}
+
+// A value that can go up and down
+type Gauge struct {
+	name string
+	help string
+	bits atomic.Uint64
+}
+
+func NewGauge(name, help string) *Gauge {
+	g := &Gauge{name: name, help: help}
+	register(name, g)
+	return g
+}
+
+func (g *Gauge) Set(value float64) {
+	g.bits.Store(math.Float64bits(value))
+}
+
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		if g.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+func (g *Gauge) Value() float64 {
+	return math.Float64frombits(g.bits.Load())
+}
+
+func (g *Gauge) read() Reading {
+	return Reading{Kind: "gauge", Value: g.Value()}
+}
+
+func (g *Gauge) write(builder *strings.Builder) {
+	writeHeader(builder, g.name, g.help, "gauge")
+	fmt.Fprintf(builder, "%s %g\n", g.name, g.Value())
+}
+
+// A gauge whose value is computed whenever metrics are scraped
+type GaugeFunc struct {
+	name string
+	help string
+	fn   func() float64
+}
+
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	g := &GaugeFunc{name: name, help: help, fn: fn}
+	register(name, g)
+	return g
+}
+
+func (g *GaugeFunc) read() Reading {
+	return Reading{Kind: "gauge", Value: g.fn()}
+}
+
+func (g *GaugeFunc) write(builder *strings.Builder) {
+	writeHeader(builder, g.name, g.help, "gauge")
+	fmt.Fprintf(builder, "%s %g\n", g.name, g.fn())
+}
+
+// A family of counters partitioned by the value of a single label
+type CounterVec struct {
+	name     string
+	help     string
+	label    string
+	counters map[string]*Counter
+	mux      sync.Mutex
+}
+
+func NewCounterVec(name, help, label string) *CounterVec {
+	v := &CounterVec{name: name, help: help, label: label, counters: make(map[string]*Counter)}
+	register(name, v)
+	return v
+}
+
+func (v *CounterVec) With(labelValue string) *Counter {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	c, exists := v.counters[labelValue]
+	if !exists {
+		c = &Counter{name: v.name, help: v.help}
+		v.counters[labelValue] = c
+	}
+	return c
+}
+
+func (v *CounterVec) read() Reading {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	reading := Reading{Kind: "counter"}
+	for _, c := range v.counters {
+		reading.Value += float64(c.Value())
+	}
+	return reading
+}
+
+func (v *CounterVec) write(builder *strings.Builder) {
+	v.mux.Lock()
+	labelValues := make([]string, 0, len(v.counters))
+	for labelValue := range v.counters {
+		labelValues = append(labelValues, labelValue)
+	}
+	v.mux.Unlock()
+	sort.Strings(labelValues)
+
+	writeHeader(builder, v.name, v.help, "counter")
+	for _, labelValue := range labelValues {
+		fmt.Fprintf(builder, "%s{%s=%q} %d\n", v.name, v.label, labelValue, v.With(labelValue).Value())
+	}
+}
+
+// A family of gauges partitioned by the value of a single label
+type GaugeVec struct {
+	name   string
+	help   string
+	label  string
+	gauges map[string]*Gauge
+	mux    sync.Mutex
+}
+
+func NewGaugeVec(name, help, label string) *GaugeVec {
+	v := &GaugeVec{name: name, help: help, label: label, gauges: make(map[string]*Gauge)}
+	register(name, v)
+	return v
+}
+
+func (v *GaugeVec) With(labelValue string) *Gauge {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	g, exists := v.gauges[labelValue]
+	if !exists {
+		g = &Gauge{name: v.name, help: v.help}
+		v.gauges[labelValue] = g
+	}
+	return g
+}
+
+func (v *GaugeVec) read() Reading {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	reading := Reading{Kind: "gauge"}
+	first := true
+	for _, g := range v.gauges {
+		if value := g.Value(); first || value > reading.Value {
+			reading.Value = value
+			first = false
+		}
+	}
+	return reading
+}
+
+func (v *GaugeVec) write(builder *strings.Builder) {
+	v.mux.Lock()
+	labelValues := make([]string, 0, len(v.gauges))
+	for labelValue := range v.gauges {
+		labelValues = append(labelValues, labelValue)
+	}
+	v.mux.Unlock()
+	sort.Strings(labelValues)
+
+	writeHeader(builder, v.name, v.help, "gauge")
+	for _, labelValue := range labelValues {
+		fmt.Fprintf(builder, "%s{%s=%q} %g\n", v.name, v.label, labelValue, v.With(labelValue).Value())
+	}
+}
+
+// Counts of observed values falling into buckets, e.g. how long something took, partitioned by the value of a single
+// label
+type HistogramVec struct {
+	name       string
+	help       string
+	label      string
+	buckets    []float64
+	histograms map[string]*histogram
+	mux        sync.Mutex
+}
+
+type histogram struct {
+	// Cumulative, so each count includes every value less than or equal to the bucket's upper bound
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// The buckets are the upper bounds of each bucket, in ascending order
+func NewHistogramVec(name, help, label string, buckets []float64) *HistogramVec {
+	v := &HistogramVec{name: name, help: help, label: label, buckets: buckets, histograms: make(map[string]*histogram)}
+	register(name, v)
+	return v
+}
+
+func (v *HistogramVec) Observe(labelValue string, value float64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	h, exists := v.histograms[labelValue]
+	if !exists {
+		h = &histogram{counts: make([]uint64, len(v.buckets))}
+		v.histograms[labelValue] = h
+	}
+
+	for i, upperBound := range v.buckets {
+		if value <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.sum += value
+	h.count++
+}
+
+func (v *HistogramVec) read() Reading {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	reading := Reading{Kind: "histogram"}
+	for _, h := range v.histograms {
+		reading.Sum += h.sum
+		reading.Count += h.count
+	}
+	return reading
+}
+
+func (v *HistogramVec) write(builder *strings.Builder) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	labelValues := make([]string, 0, len(v.histograms))
+	for labelValue := range v.histograms {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	writeHeader(builder, v.name, v.help, "histogram")
+	for _, labelValue := range labelValues {
+		h := v.histograms[labelValue]
+		for i, upperBound := range v.buckets {
+			fmt.Fprintf(builder, "%s_bucket{%s=%q,le=\"%g\"} %d\n", v.name, v.label, labelValue, upperBound, h.counts[i])
+		}
+		fmt.Fprintf(builder, "%s_bucket{%s=%q,le=\"+Inf\"} %d\n", v.name, v.label, labelValue, h.count)
+		fmt.Fprintf(builder, "%s_sum{%s=%q} %g\n", v.name, v.label, labelValue, h.sum)
+		fmt.Fprintf(builder, "%s_count{%s=%q} %d\n", v.name, v.label, labelValue, h.count)
+	}
+}
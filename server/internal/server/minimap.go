@@ -0,0 +1,151 @@
+package server
+
+import (
+	"net/http"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// The minimap grid is this many cells along each side, however big the zone is
+	minimapGridSize = 64
+
+	// How long a built minimap is served before it's rebuilt. Spores come and go constantly, but a minimap doesn't
+	// need to keep up with every one of them.
+	minimapTtl = 10 * time.Second
+)
+
+type cachedMinimap struct {
+	message *packets.MinimapMessage
+	json    []byte
+	builtAt time.Time
+}
+
+// Builds downsampled occupancy grids of each zone for clients to draw minimaps from, so the map doesn't need to be
+// bundled with the client. Each zone's grid is built on demand and cached, since every player in the zone gets the
+// same one.
+type Minimaps struct {
+	gameData          *gamedata.GameData
+	sharedGameObjects *SharedGameObjects
+
+	cache map[string]*cachedMinimap
+	mux   sync.Mutex
+}
+
+func NewMinimaps(gameData *gamedata.GameData, sharedGameObjects *SharedGameObjects) *Minimaps {
+	return &Minimaps{
+		gameData:          gameData,
+		sharedGameObjects: sharedGameObjects,
+		cache:             make(map[string]*cachedMinimap),
+	}
+}
+
+// The minimap of the zone with the given ID, or false if there's no such zone
+func (m *Minimaps) Get(zoneId string) (*packets.MinimapMessage, bool) {
+	cached, exists := m.get(zoneId)
+	if !exists {
+		return nil, false
+	}
+	return cached.message, true
+}
+
+func (m *Minimaps) get(zoneId string) (*cachedMinimap, bool) {
+	zone, exists := m.gameData.Zone(zoneId)
+	if !exists {
+		return nil, false
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	cached, exists := m.cache[zoneId]
+	if !exists || time.Since(cached.builtAt) >= minimapTtl {
+		cached = m.build(zone)
+		m.cache[zoneId] = cached
+	}
+
+	return cached, true
+}
+
+func (m *Minimaps) build(zone *gamedata.Zone) *cachedMinimap {
+	counts := make([]int, minimapGridSize*minimapGridSize)
+	cellWidth := (zone.MaxX - zone.MinX) / minimapGridSize
+	cellHeight := (zone.MaxY - zone.MinY) / minimapGridSize
+
+	// Resource nodes are the closest thing to terrain there is, so they count for a lot more than spores do
+	occupy := func(x, y float64, weight int) {
+		if !zone.Contains(x, y) {
+			return
+		}
+		col := min(int((x-zone.MinX)/cellWidth), minimapGridSize-1)
+		row := min(int((y-zone.MinY)/cellHeight), minimapGridSize-1)
+		counts[row*minimapGridSize+col] += weight
+	}
+
+	m.sharedGameObjects.Spores.ForEach(func(_ uint64, spore *objects.Spore) {
+		occupy(spore.X, spore.Y, 1)
+	})
+	m.sharedGameObjects.ResourceNodes.ForEach(func(_ uint64, node *objects.ResourceNode) {
+		occupy(node.X, node.Y, 16)
+	})
+
+	// Scale so the densest cell is fully opaque
+	densest := 1
+	for _, count := range counts {
+		densest = max(densest, count)
+	}
+	cells := make([]byte, len(counts))
+	for i, count := range counts {
+		cells[i] = byte(count * 255 / densest)
+	}
+
+	pointsOfInterest := make([]*packets.PointOfInterestMessage, 0, len(zone.PointsOfInterest))
+	for _, poi := range zone.PointsOfInterest {
+		if poi.InSeason(time.Now()) {
+			pointsOfInterest = append(pointsOfInterest, &packets.PointOfInterestMessage{Name: poi.Name, Kind: poi.Kind, X: poi.X, Y: poi.Y})
+		}
+	}
+
+	message := &packets.MinimapMessage{
+		ZoneId:           zone.Id,
+		ZoneName:         zone.Name,
+		MinX:             zone.MinX,
+		MinY:             zone.MinY,
+		MaxX:             zone.MaxX,
+		MaxY:             zone.MaxY,
+		Width:            minimapGridSize,
+		Height:           minimapGridSize,
+		Cells:            cells,
+		PointsOfInterest: pointsOfInterest,
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		logging.Hub.Errorf("Error marshalling minimap of zone %s: %v", zone.Id, err)
+	}
+
+	return &cachedMinimap{message: message, json: data, builtAt: time.Now()}
+}
+
+// Serve the minimap of the zone named in the path as JSON, for clients that want it before they're in game
+func (m *Minimaps) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	cached, exists := m.get(request.PathValue("zone"))
+	if !exists {
+		http.Error(writer, "zone not found", http.StatusNotFound)
+		return
+	}
+
+	if cached.json == nil {
+		http.Error(writer, "minimap unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", "public, max-age=10")
+	writer.Write(cached.json)
+}
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	ModerationCaseOpen   = "open"
+	ModerationCaseClosed = "closed"
+
+	// How much of what each player's doing is kept, for snapshotting when they're reported
+	moderationPacketHistory = 100
+	moderationTrailLength   = 120
+	moderationTrailInterval = time.Second
+
+	// How long chat is kept for, from everyone, so reports show what was said around what the player said too
+	moderationChatWindow   = 5 * time.Minute
+	maxModerationChatLines = 500
+
+	// Each player can report someone this often, and the same player once in this long
+	moderationReportCooldown = 30 * time.Second
+	moderationRepeatCooldown = 10 * time.Minute
+
+	maxModerationReasonLength = 500
+)
+
+var (
+	ErrReportCooldown   = errors.New("reporting too often")
+	ErrAlreadyReported  = errors.New("already reported that player recently")
+	ErrCaseNotFound     = errors.New("moderation case not found")
+	ErrCaseClosed       = errors.New("moderation case already closed")
+	ErrReportedNotFound = errors.New("reported player isn't in game")
+)
+
+var moderationReportsTotal = metrics.NewCounterVec("mmo_moderation_reports_total", "Players reported by other players, by whether the report was taken or refused.", "result")
+
+// What a reported player was doing around when they were reported, as kept in their case
+type ModerationSnapshot struct {
+	TakenAt int64 `json:"taken_at"`
+
+	Name string  `json:"name"`
+	DbId int64   `json:"db_id"`
+	X    float64 `json:"x"`
+	Y    float64 `json:"y"`
+
+	// Oldest first
+	Packets []ModerationPacket   `json:"packets"`
+	Trail   []ModerationPosition `json:"trail"`
+	Chat    []ModerationChatLine `json:"chat"`
+}
+
+// A packet the reported player's client sent, by its kind, e.g. chat, not what was in it
+type ModerationPacket struct {
+	Kind string `json:"kind"`
+	At   int64  `json:"at"`
+}
+
+type ModerationPosition struct {
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+	At int64   `json:"at"`
+}
+
+// Something said in chat by anyone, not only the reported player
+type ModerationChatLine struct {
+	From    string `json:"from"`
+	Message string `json:"message"`
+	At      int64  `json:"at"`
+}
+
+// What's been kept of one client, most recent last
+type moderationHistory struct {
+	packets []ModerationPacket
+	trail   []ModerationPosition
+	mux     sync.Mutex
+}
+
+// Lets players report others who are misbehaving, opening a case for moderators with the reason they give and a
+// snapshot of what the reported player was doing: the kinds of packets their client sent lately, where they've been
+// and what was said in chat. Only the last little while of each is kept in memory, for every client, since it's only
+// worth anything once someone's reported.
+type ModerationCases struct {
+	hub *Hub
+
+	// By client ID
+	histories sync.Map
+
+	chat    []ModerationChatLine
+	chatMux sync.Mutex
+
+	// When each player last reported anyone, and each player they've reported, by reporter's player ID
+	reportedAt map[int64]time.Time
+	reported   map[[2]int64]time.Time
+	reportMux  sync.Mutex
+}
+
+func NewModerationCases(hub *Hub) *ModerationCases {
+	return &ModerationCases{
+		hub:        hub,
+		reportedAt: make(map[int64]time.Time),
+		reported:   make(map[[2]int64]time.Time),
+	}
+}
+
+func (m *ModerationCases) history(clientId uint64) *moderationHistory {
+	history, _ := m.histories.LoadOrStore(clientId, &moderationHistory{})
+	return history.(*moderationHistory)
+}
+
+// The client sent a packet of the kind
+func (m *ModerationCases) Received(clientId uint64, kind packets.MsgKind) {
+	history := m.history(clientId)
+	history.mux.Lock()
+	defer history.mux.Unlock()
+	history.packets = appendLatest(history.packets, ModerationPacket{Kind: kindName(kind), At: time.Now().UnixMilli()}, moderationPacketHistory)
+}
+
+// Someone said something in chat
+func (m *ModerationCases) Chatted(from string, message string) {
+	m.chatMux.Lock()
+	defer m.chatMux.Unlock()
+
+	now := time.Now()
+	m.chat = appendLatest(m.chat, ModerationChatLine{From: from, Message: message, At: now.UnixMilli()}, maxModerationChatLines)
+	cutoff := now.Add(-moderationChatWindow).UnixMilli()
+	expired := 0
+	for expired < len(m.chat) && m.chat[expired].At < cutoff {
+		expired++
+	}
+	m.chat = m.chat[expired:]
+}
+
+// Append to the slice, dropping the oldest to stay within the limit
+func appendLatest[T any](items []T, item T, limit int) []T {
+	items = append(items, item)
+	if len(items) > limit {
+		items = items[len(items)-limit:]
+	}
+	return items
+}
+
+func (m *ModerationCases) trailLoop() {
+	ticker := time.NewTicker(moderationTrailInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		at := time.Now().UnixMilli()
+		m.hub.SharedGameObjects.Players.ForEach(func(clientId uint64, player *objects.Player) {
+			// Actors driven by simulators can't be reported
+			if _, isClient := m.hub.Clients.Get(clientId); !isClient {
+				return
+			}
+			history := m.history(clientId)
+			history.mux.Lock()
+			history.trail = appendLatest(history.trail, ModerationPosition{X: player.X, Y: player.Y, At: at}, moderationTrailLength)
+			history.mux.Unlock()
+		})
+	}
+}
+
+// Forget what's been kept of the client once it's disconnected
+func (m *ModerationCases) Forget(clientId uint64) {
+	m.histories.Delete(clientId)
+}
+
+// The clients with a history kept
+func (m *ModerationCases) clientIds() []uint64 {
+	var ids []uint64
+	m.histories.Range(func(clientId, _ any) bool {
+		ids = append(ids, clientId.(uint64))
+		return true
+	})
+	return ids
+}
+
+// Take what the reported player was doing now, before it's gone
+func (m *ModerationCases) snapshot(clientId uint64, player *objects.Player) *ModerationSnapshot {
+	snapshot := &ModerationSnapshot{
+		TakenAt: time.Now().UnixMilli(),
+		Name:    player.Name,
+		DbId:    player.DbId,
+		X:       player.X,
+		Y:       player.Y,
+	}
+
+	history := m.history(clientId)
+	history.mux.Lock()
+	snapshot.Packets = append([]ModerationPacket(nil), history.packets...)
+	snapshot.Trail = append([]ModerationPosition(nil), history.trail...)
+	history.mux.Unlock()
+
+	m.chatMux.Lock()
+	snapshot.Chat = append([]ModerationChatLine(nil), m.chat...)
+	m.chatMux.Unlock()
+
+	return snapshot
+}
+
+// Check the reporter can report the player yet, remembering that they have if so
+func (m *ModerationCases) allowReport(reporterDbId int64, reportedDbId int64) error {
+	m.reportMux.Lock()
+	defer m.reportMux.Unlock()
+
+	now := time.Now()
+	for key, reportedAt := range m.reported {
+		if now.Sub(reportedAt) >= moderationRepeatCooldown {
+			delete(m.reported, key)
+		}
+	}
+	for reporter, reportedAt := range m.reportedAt {
+		if now.Sub(reportedAt) >= moderationReportCooldown {
+			delete(m.reportedAt, reporter)
+		}
+	}
+
+	key := [2]int64{reporterDbId, reportedDbId}
+	if _, exists := m.reported[key]; exists {
+		return ErrAlreadyReported
+	}
+	if _, exists := m.reportedAt[reporterDbId]; exists {
+		return ErrReportCooldown
+	}
+	m.reported[key] = now
+	m.reportedAt[reporterDbId] = now
+	return nil
+}
+
+// Open a case against the player the client with the ID is playing, snapshotting what they've been doing. The case
+// is written once the database is available, so a report made during an outage isn't lost.
+func (m *ModerationCases) Report(reporter *objects.Player, reportedClientId uint64, reason string) error {
+	reported, exists := m.hub.SharedGameObjects.Players.Get(reportedClientId)
+	if !exists || reported.DbId == reporter.DbId {
+		moderationReportsTotal.With("not_found").Inc()
+		return ErrReportedNotFound
+	}
+	if err := m.allowReport(reporter.DbId, reported.DbId); err != nil {
+		moderationReportsTotal.With("cooldown").Inc()
+		return err
+	}
+
+	if runes := []rune(reason); len(runes) > maxModerationReasonLength {
+		reason = string(runes[:maxModerationReasonLength])
+	}
+	snapshot, err := json.Marshal(m.snapshot(reportedClientId, reported))
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	logging.Hub.Printf("%s reported %s: %s", reporter.Name, reported.Name, reason)
+	moderationCase := db.CreateModerationCaseParams{
+		ReporterID:   reporter.DbId,
+		ReporterName: reporter.Name,
+		ReportedID:   reported.DbId,
+		ReportedName: reported.Name,
+		Reason:       reason,
+		Snapshot:     string(snapshot),
+		CreatedAt:    time.Now().UnixMilli(),
+	}
+	err = m.hub.NewDbTx().WriteBehind("moderation case", func(ctx context.Context, queries *db.Queries) error {
+		return queries.CreateModerationCase(ctx, moderationCase)
+	})
+	if err != nil {
+		return err
+	}
+	moderationReportsTotal.With("taken").Inc()
+	return nil
+}
+
+// Close an open case with what was done about it
+func (m *ModerationCases) Close(id int64, resolution string, admin string) (db.ModerationCase, error) {
+	dbTx := m.hub.NewDbTx()
+	rows, err := dbTx.Queries.CloseModerationCase(dbTx.Ctx, db.CloseModerationCaseParams{
+		Resolution: resolution,
+		ClosedAt:   sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ID:         id,
+	})
+	if err != nil {
+		return db.ModerationCase{}, err
+	}
+
+	moderationCase, err := dbTx.Queries.GetModerationCase(dbTx.Ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.ModerationCase{}, ErrCaseNotFound
+	} else if err != nil {
+		return db.ModerationCase{}, err
+	}
+	if rows == 0 {
+		return moderationCase, ErrCaseClosed
+	}
+
+	m.hub.Audit(admin, "moderation.closed", moderationCase.ReportedName, fmt.Sprintf("case %d: %s", id, resolution))
+	return moderationCase, nil
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The longest name a player can have
+const MaxNameLength = 20
+
+// Why the name can't be anyone's, whoever has it, or nil if it could be
+func ValidateUsername(username string) error {
+	if len(username) <= 0 {
+		return errors.New("empty")
+	}
+	if len(username) > MaxNameLength {
+		return errors.New("too long")
+	}
+	if username != strings.TrimSpace(username) {
+		return errors.New("leading or trailing whitespace")
+	}
+	return nil
+}
+
+const (
+	// A reserved name can't be claimed by anyone
+	ReservedNameKindName = "name"
+
+	// A reserved term can't appear anywhere in a claimed name, e.g. a trademark
+	ReservedNameKindTerm = "term"
+)
+
+var (
+	ErrNameReserved = errors.New("name reserved")
+	ErrNameTaken    = errors.New("name taken")
+)
+
+// Tried when what the player chose has nothing usable left in it to base suggestions on
+const fallbackNameBase = "Spore"
+
+// How many names are tried for each suggestion made, before settling for fewer suggestions
+const nameSuggestionAttempts = 5
+
+// Decides who can have which names. Names can be reserved so no one can claim them, e.g. staff names or
+// trademarked terms. Names that are taken can be released for someone else to claim once the account holding them
+// has gone long enough without logging in, if that's configured.
+type Names struct {
+	hub *Hub
+
+	// How long an account can go without logging in before its name is released, or 0 if names are never released
+	releaseAfter time.Duration
+}
+
+func NewNames(hub *Hub) *Names {
+	return &Names{hub: hub}
+}
+
+// Must be called before the hub is run
+func (n *Names) Configure(releaseAfter time.Duration) {
+	n.releaseAfter = releaseAfter
+}
+
+// Check the name can be claimed by a new player, or by an existing one changing their name. If an inactive account
+// has it, it's released first: that account is renamed to a name like the ones suggested to players, and can still be
+// logged in to under it. The new name's recorded in the audit log and mailed to the account, and whoever tries to log
+// in with the old name and the account's password is told it, see RenamedTo.
+func (n *Names) Claim(name string) error {
+	name = strings.ToLower(name)
+	dbTx := n.hub.NewDbTx()
+
+	reserved, err := dbTx.Queries.GetReservedNameMatch(dbTx.Ctx, name)
+	if err == nil {
+		logging.Hub.Printf("Refused a claim to name %s, which matches reserved %s %s", name, reserved.Kind, reserved.Name)
+		return ErrNameReserved
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	user, err := dbTx.Queries.GetUserByUsername(dbTx.Ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if n.releaseAfter <= 0 {
+		return ErrNameTaken
+	}
+	player, err := dbTx.Queries.GetPlayerByUserId(dbTx.Ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if _, online := n.hub.ClientByPlayerDbId(player.ID); online {
+		return ErrNameTaken
+	}
+
+	// Accounts that haven't logged in since logins started being recorded can't be told apart from active ones
+	lastLogin, err := dbTx.Queries.GetPlayerLogin(dbTx.Ctx, player.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNameTaken
+	} else if err != nil {
+		return err
+	}
+	inactiveFor := time.Since(time.UnixMilli(lastLogin.LastLoginAt))
+	if inactiveFor < n.releaseAfter {
+		return ErrNameTaken
+	}
+
+	placeholder, err := n.placeholder(player.Name)
+	if err != nil {
+		return fmt.Errorf("error releasing name %s: %w", name, err)
+	}
+	err = dbTx.InTx(func(queries *db.Queries) error {
+		err := queries.UpdateUsername(dbTx.Ctx, db.UpdateUsernameParams{Username: strings.ToLower(placeholder), ID: user.ID})
+		if err != nil {
+			return err
+		}
+		err = queries.UpdatePlayerName(dbTx.Ctx, db.UpdatePlayerNameParams{Name: placeholder, ID: player.ID})
+		if err != nil {
+			return err
+		}
+		err = queries.CreateReleasedName(dbTx.Ctx, db.CreateReleasedNameParams{
+			Name:       name,
+			UserID:     user.ID,
+			ReleasedAt: time.Now().UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		subject := fmt.Sprintf("You were away so long your name %s was given to someone else, you're now %s", player.Name, placeholder)
+		return SendMail(dbTx.Ctx, queries, player.ID, "Support", subject, "", 0)
+	})
+	if err != nil {
+		return fmt.Errorf("error releasing name %s: %w", name, err)
+	}
+
+	detail := fmt.Sprintf("released after %s inactive, account renamed to %s", inactiveFor.Round(time.Hour), placeholder)
+	n.hub.Audit("server", "name.released", player.Name, detail)
+	return nil
+}
+
+// An available name for an account whose name is being released, based on the name it had
+func (n *Names) placeholder(name string) (string, error) {
+	base := nameSuggestionBase(name)
+	if base == "" {
+		base = fallbackNameBase
+	}
+
+	// The base on its own is skipped, since it's often the very name being released
+	for attempt := 1; attempt <= nameSuggestionAttempts*4; attempt++ {
+		candidate := nameSuggestionCandidate(base, attempt)
+		if n.hub.GameData.Profane(candidate) {
+			continue
+		}
+		available, err := n.Available(candidate)
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return candidate, nil
+		}
+	}
+	return "", errors.New("no name available to rename the account to")
+}
+
+// What the account the name was released from is called now, if the password is that account's. Nothing's given
+// away about a released name without the password.
+func (n *Names) RenamedTo(name string, password string) (string, bool) {
+	dbTx := n.hub.NewDbTx()
+	users, err := dbTx.Queries.GetReleasedNameUsers(dbTx.Ctx, strings.ToLower(name))
+	if err != nil {
+		logging.Hub.Errorf("Error getting accounts name %s was released from: %v", name, err)
+		return "", false
+	}
+	for _, user := range users {
+		if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) == nil {
+			return user.Username, true
+		}
+	}
+	return "", false
+}
+
+// Whether a new player could claim the name right now. Unlike Claim this never releases anyone's name, so names held
+// by inactive accounts count as taken.
+func (n *Names) Available(name string) (bool, error) {
+	name = strings.ToLower(name)
+	dbTx := n.hub.NewDbTx()
+
+	if _, err := dbTx.Queries.GetReservedNameMatch(dbTx.Ctx, name); err == nil {
+		return false, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	if _, err := dbTx.Queries.GetUserByUsername(dbTx.Ctx, name); errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// Up to count names like the one chosen that are available, not reserved and not profane, for when it can't be had,
+// so players can pick one straight away instead of guessing again. Names the player would have to guess at anyway,
+// e.g. ones that are profane themselves, get suggestions based on a neutral name instead.
+func (n *Names) Suggest(chosen string, count int) []string {
+	base := nameSuggestionBase(chosen)
+	if base == "" || n.hub.GameData.Profane(base) {
+		base = fallbackNameBase
+	}
+
+	var suggestions []string
+	seen := make(map[string]struct{})
+	for attempt := 0; attempt < count*nameSuggestionAttempts && len(suggestions) < count; attempt++ {
+		candidate := nameSuggestionCandidate(base, attempt)
+		key := strings.ToLower(candidate)
+		if _, exists := seen[key]; exists {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if n.hub.GameData.Profane(candidate) {
+			continue
+		}
+		available, err := n.Available(candidate)
+		if err != nil {
+			logging.Hub.Errorf("Error checking whether name suggestion %s is available: %v", candidate, err)
+			break
+		}
+		if available {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
+
+// The letters, digits and underscores of the chosen name, with room left for a number to go on the end
+func nameSuggestionBase(chosen string) string {
+	var base strings.Builder
+	for _, r := range strings.TrimSpace(chosen) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			base.WriteRune(r)
+		}
+	}
+
+	// Cut by runes, not bytes, so no letter is cut in half
+	runes := []rune(base.String())
+	for len(string(runes)) > MaxNameLength-5 {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+// The base on its own first, in case it's only what the player chose that couldn't be had, then with numbers of
+// more and more digits on the end
+func nameSuggestionCandidate(base string, attempt int) string {
+	if attempt == 0 {
+		return base
+	}
+	digits := min(2+attempt/4, 4)
+	number := strconv.Itoa(rand.IntN(pow10(digits)-pow10(digits-1)) + pow10(digits-1))
+	if attempt%2 == 0 {
+		return base + "_" + number
+	}
+	return base + number
+}
+
+func pow10(exponent int) int {
+	result := 1
+	for range exponent {
+		result *= 10
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+package objects
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The components every object in the world is built from. They're embedded in the object types, so their fields can
+// be used directly, e.g. player.X.
+
+type Position struct {
+	X float64
+	Y float64
+}
+
+// The size of an object, which is what it's consumed by and collides with
+type Body struct {
+	Radius float64
+}
+
+type Movement struct {
+	Direction float64
+	Speed     float64
+}
+
+// An optional part of an object, which gameplay features can attach to the objects they apply to instead of adding
+// fields to the object types themselves. Each kind of component is identified by a unique name, which it's also
+// serialized under.
+type Component interface {
+	ComponentName() string
+}
+
+// Implemented by components that only make sense for the running server, so they're left out when serializing
+type ephemeralComponent interface {
+	Ephemeral()
+}
+
+var componentFactories = map[string]func() Component{}
+
+// Register a kind of component so it can be deserialized. Components must be registered before anything is
+// deserialized, i.e. in an init function.
+func RegisterComponent(factory func() Component) {
+	name := factory().ComponentName()
+	if _, exists := componentFactories[name]; exists {
+		panic(fmt.Sprintf("component %s is already registered", name))
+	}
+	componentFactories[name] = factory
+}
+
+// The optional components attached to an object, at most one of each kind. Safe to use from multiple goroutines.
+type Components struct {
+	components map[string]Component
+	mux        sync.RWMutex
+}
+
+// Attach the component, replacing any other component of the same kind
+func (c *Components) Add(component Component) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.components == nil {
+		c.components = make(map[string]Component)
+	}
+	c.components[component.ComponentName()] = component
+}
+
+func (c *Components) Remove(name string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.components, name)
+}
+
+// Attach all of the other object's components to this one, e.g. when restoring an object
+func (c *Components) CopyFrom(other *Components) {
+	other.mux.RLock()
+	defer other.mux.RUnlock()
+
+	for _, component := range other.components {
+		c.Add(component)
+	}
+}
+
+// The attached component of the given type, e.g. GetComponent[*Health](&player.Components)
+func GetComponent[T Component](c *Components) (T, bool) {
+	var zero T
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	component, exists := c.components[zero.ComponentName()].(T)
+	return component, exists
+}
+
+func (c *Components) MarshalJSON() ([]byte, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	serialized := make(map[string]Component, len(c.components))
+	for name, component := range c.components {
+		if _, ephemeral := component.(ephemeralComponent); !ephemeral {
+			serialized[name] = component
+		}
+	}
+	return json.Marshal(serialized)
+}
+
+func (c *Components) UnmarshalJSON(data []byte) error {
+	var serialized map[string]json.RawMessage
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return err
+	}
+
+	for name, data := range serialized {
+		factory, exists := componentFactories[name]
+		if !exists {
+			return fmt.Errorf("unknown component %s", name)
+		}
+
+		component := factory()
+		if err := json.Unmarshal(data, component); err != nil {
+			return fmt.Errorf("component %s: %w", name, err)
+		}
+		c.Add(component)
+	}
+
+	return nil
+}
+
+func init() {
+	RegisterComponent(func() Component { return &Health{} })
+	RegisterComponent(func() Component { return &Inventory{} })
+	RegisterComponent(func() Component { return &Brain{} })
+	RegisterComponent(func() Component { return &NetworkSync{} })
+}
+
+type Health struct {
+	Current float64 `json:"current"`
+	Max     float64 `json:"max"`
+}
+
+func (*Health) ComponentName() string { return "health" }
+
+// The items an object is carrying. For players this mirrors their inventory in the database, so it can be read
+// without a query.
+type Inventory struct {
+	Items map[string]int64 `json:"items"`
+}
+
+func (*Inventory) ComponentName() string { return "inventory" }
+
+// What a server controlled object is doing, for objects that think for themselves
+type Brain struct {
+	Behaviour string `json:"behaviour"`
+	TargetId  uint64 `json:"target_id"`
+}
+
+func (*Brain) ComponentName() string { return "ai" }
+
+// How the object's state has been sent to clients
+type NetworkSync struct {
+	LastSentAt time.Time
+	Updates    uint64
+}
+
+func (*NetworkSync) ComponentName() string { return "network_sync" }
+
+func (*NetworkSync) Ephemeral() {}
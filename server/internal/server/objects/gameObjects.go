@@ -1,23 +1,96 @@
 package objects
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type Player struct {
-	Name      string
-	X         float64
-	Y         float64
-	Radius    float64
-	Direction float64
-	Speed     float64
-	BestScore int64
-	DbId      int64
-	Color     int32
+	Position
+	Body
+	Movement
+	Name       string
+	BestScore  int64
+	DbId       int64
+	Color      int32
+	Components Components
 }
 
 type Spore struct {
-	X         float64
-	Y         float64
-	Radius    float64
+	Position
+	Body
 	DroppedBy *Player
 	DroppedAt time.Time
 }
+
+// An ambient emitter in the world, e.g. a campfire or a waterfall, which clients near it play the sound and effect
+// of. Its body's radius is how far it's heard or seen from.
+type Emitter struct {
+	Position
+	Body
+	Kind   string
+	Sound  string
+	Effect string
+	Volume float64
+}
+
+// A gatherable node in the world, which is depleted when gathered and respawns in place after a while
+type ResourceNode struct {
+	Position
+	Body
+	Kind       string
+	depletedAt time.Time
+	mux        sync.Mutex
+}
+
+func (n *ResourceNode) Available() bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.depletedAt.IsZero()
+}
+
+// Deplete the node if it's available. Returns false if it was already depleted, e.g. by another player gathering
+// it at the same time.
+func (n *ResourceNode) TryDeplete() bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	if !n.depletedAt.IsZero() {
+		return false
+	}
+	n.depletedAt = Sim.Now()
+	return true
+}
+
+// Make the node available again straight away, e.g. when what it yielded couldn't be granted after all
+func (n *ResourceNode) Restore() {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.depletedAt = time.Time{}
+}
+
+// Make the node available again if it's been depleted for at least the given duration
+func (n *ResourceNode) TryRespawn(after time.Duration) bool {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	if n.depletedAt.IsZero() || Sim.Since(n.depletedAt) < after {
+		return false
+	}
+	n.depletedAt = time.Time{}
+	return true
+}
+
+// When the node was depleted, or the zero time if it's available
+func (n *ResourceNode) DepletedAt() time.Time {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.depletedAt
+}
+
+// Mark the node as depleted since the given time, e.g. when restoring it from a snapshot
+func (n *ResourceNode) SetDepletedAt(depletedAt time.Time) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.depletedAt = depletedAt
+}
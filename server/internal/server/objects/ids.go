@@ -0,0 +1,82 @@
+package objects
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	idNodeBits     = 10
+	idSequenceBits = 12
+
+	// The most nodes that can hand out IDs at once without them clashing
+	MaxIdNodes = 1 << idNodeBits
+
+	maxIdSequence = 1<<idSequenceBits - 1
+)
+
+// IDs count milliseconds from here, which leaves room for about 69 years of them
+var idEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Hands out snowflake-style IDs for everything in the world: the milliseconds since the epoch, then the node handing
+// it out, then a sequence for IDs handed out in the same millisecond. So long as no two hubs running at once share a
+// node, IDs are unique across all of them, and across restarts, without them having to coordinate. The top bit is
+// never set, so IDs also fit in a signed 64 bit integer, which is what the client has.
+type IdGenerator struct {
+	node uint64
+
+	// The millisecond the last ID was handed out in, and how many were handed out in it
+	lastMillis int64
+	sequence   uint64
+	mux        sync.Mutex
+}
+
+func NewIdGenerator(node uint64) (*IdGenerator, error) {
+	if node >= MaxIdNodes {
+		return nil, fmt.Errorf("ID node %d out of range, must be less than %d", node, MaxIdNodes)
+	}
+	return &IdGenerator{node: node}, nil
+}
+
+// The node the generator hands out IDs as
+func (g *IdGenerator) Node() uint64 {
+	return g.node
+}
+
+// What shared collections hand out IDs from. Should be set before anything is added to one if more than one hub can
+// be running at once.
+var Ids = &IdGenerator{}
+
+// Never 0, which is left for messages from the server itself
+func (g *IdGenerator) Next() uint64 {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	// If the wall clock goes back, carry on from where the last ID left off rather than risk handing one out again.
+	// Likewise if the sequence runs out, carry on into the next millisecond early. The clock catches up soon enough.
+	millis := max(Sim.Since(idEpoch).Milliseconds(), g.lastMillis)
+	if millis == g.lastMillis {
+		if g.sequence == maxIdSequence {
+			millis++
+			g.sequence = 0
+		} else {
+			g.sequence++
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastMillis = millis
+
+	return uint64(millis)<<(idNodeBits+idSequenceBits) | g.node<<idSequenceBits | g.sequence
+}
+
+// When the ID was handed out, to the millisecond
+func IdTime(id uint64) time.Time {
+	return idEpoch.Add(time.Duration(id>>(idNodeBits+idSequenceBits)) * time.Millisecond)
+}
+
+// The node that handed out the ID
+func IdNode(id uint64) uint64 {
+	return id >> idSequenceBits & (MaxIdNodes - 1)
+}
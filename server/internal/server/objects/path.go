@@ -0,0 +1,123 @@
+package objects
+
+import "math"
+
+// How many times a path can be bent around something in the way before the rest of it is left straight
+const maxPathDetours = 16
+
+// How much further than touching a path goes around things in the way, so rounding doesn't have it clip them
+const pathMargin = 1.05
+
+// Something round a path has to go around, e.g. a resource node
+type Obstacle struct {
+	Position
+	Radius float64
+}
+
+// The waypoints from one point to another for a body with the radius, going around the obstacles, not including where
+// it starts. A point inside an obstacle is as close as it can be got to instead, e.g. walking up to a resource node to
+// gather it. The path is smoothed, so it only turns where it has to.
+func FindPath(from Position, to Position, radius float64, obstacles []Obstacle) []Position {
+	// The body can always get out of anything it's already in, e.g. having gone over a resource node by steering
+	var inTheWay []Obstacle
+	for _, obstacle := range obstacles {
+		if math.Hypot(from.X-obstacle.X, from.Y-obstacle.Y) >= obstacle.Radius+radius {
+			inTheWay = append(inTheWay, obstacle)
+		}
+	}
+	obstacles = inTheWay
+
+	for _, obstacle := range obstacles {
+		to = pushOutOf(to, from, obstacle, radius)
+	}
+
+	detours := 0
+	path := append([]Position{from}, route(from, to, radius, obstacles, &detours)...)
+	return smoothPath(path, radius, obstacles)[1:]
+}
+
+// The part of a path from one point to another, bending around the first obstacle in the way
+func route(from Position, to Position, radius float64, obstacles []Obstacle, detours *int) []Position {
+	obstacle, blocked := firstInTheWay(from, to, radius, obstacles)
+	if !blocked || from == to || *detours >= maxPathDetours {
+		return []Position{to}
+	}
+	*detours++
+
+	// Around whichever side of the obstacle the straight line is closest to already
+	closest := closestOnSegment(obstacle.Position, from, to)
+	awayX, awayY := closest.X-obstacle.X, closest.Y-obstacle.Y
+	if length := math.Hypot(awayX, awayY); length > 0 {
+		awayX, awayY = awayX/length, awayY/length
+	} else {
+		length = math.Hypot(to.X-from.X, to.Y-from.Y)
+		awayX, awayY = -(to.Y-from.Y)/length, (to.X-from.X)/length
+	}
+	clearance := (obstacle.Radius + radius) * pathMargin * math.Sqrt2
+	around := Position{X: obstacle.X + awayX*clearance, Y: obstacle.Y + awayY*clearance}
+
+	return append(route(from, around, radius, obstacles, detours), route(around, to, radius, obstacles, detours)...)
+}
+
+// Cut every corner that can be cut without going through an obstacle, so the path heads straight for the furthest
+// waypoint it can see
+func smoothPath(path []Position, radius float64, obstacles []Obstacle) []Position {
+	smoothed := []Position{path[0]}
+	for i := 0; i < len(path)-1; {
+		next := i + 1
+		for j := len(path) - 1; j > next; j-- {
+			if _, blocked := firstInTheWay(path[i], path[j], radius, obstacles); !blocked {
+				next = j
+				break
+			}
+		}
+		smoothed = append(smoothed, path[next])
+		i = next
+	}
+	return smoothed
+}
+
+// The obstacle a body with the radius would hit first going from one point to the other in a straight line, if any
+func firstInTheWay(from Position, to Position, radius float64, obstacles []Obstacle) (Obstacle, bool) {
+	var first Obstacle
+	firstDistance, blocked := math.Inf(1), false
+	for _, obstacle := range obstacles {
+		closest := closestOnSegment(obstacle.Position, from, to)
+		if math.Hypot(closest.X-obstacle.X, closest.Y-obstacle.Y) >= obstacle.Radius+radius {
+			continue
+		}
+		if distance := math.Hypot(closest.X-from.X, closest.Y-from.Y); distance < firstDistance {
+			first, firstDistance, blocked = obstacle, distance, true
+		}
+	}
+	return first, blocked
+}
+
+// The closest point to p on the segment from a to b
+func closestOnSegment(p Position, a Position, b Position) Position {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return a
+	}
+	t := max(0, min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/lengthSquared))
+	return Position{X: a.X + t*dx, Y: a.Y + t*dy}
+}
+
+// The point just outside the obstacle closest to p, or p itself if the body's already clear of it there, towards
+// where the body's coming from if p is right in the middle
+func pushOutOf(p Position, from Position, obstacle Obstacle, radius float64) Position {
+	reach := (obstacle.Radius + radius) * pathMargin
+	awayX, awayY := p.X-obstacle.X, p.Y-obstacle.Y
+	length := math.Hypot(awayX, awayY)
+	if length >= obstacle.Radius+radius {
+		return p
+	}
+	if length == 0 {
+		awayX, awayY = from.X-obstacle.X, from.Y-obstacle.Y
+		if length = math.Hypot(awayX, awayY); length == 0 {
+			awayX, length = 1, 1
+		}
+	}
+	return Position{X: obstacle.X + awayX/length*reach, Y: obstacle.Y + awayY/length*reach}
+}
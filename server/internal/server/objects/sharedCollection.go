@@ -1,11 +1,14 @@
 package objects
 
-import "sync"
+import (
+	"maps"
+	"slices"
+	"sync"
+)
 
-// A generic, thread-safe map of objects with auto-incrementing IDs.
+// A generic, thread-safe map of objects with IDs handed out by Ids.
 type SharedCollection[T any] struct {
 	objectsMap map[uint64]T
-	nextId     uint64
 	mapMux     sync.Mutex
 }
 
@@ -20,23 +23,23 @@ func NewSharedCollection[T any](capacity ...int) *SharedCollection[T] {
 
 	return &SharedCollection[T]{
 		objectsMap: newObjMap,
-		nextId:     1,
 	}
 }
 
-// Add an object to the map with the given ID (if provided) or the next available ID.
+// Add an object to the map with the given ID (if provided) or a new one.
 // Returns the ID of the object added.
 func (s *SharedCollection[T]) Add(obj T, id ...uint64) uint64 {
-	s.mapMux.Lock()
-	defer s.mapMux.Unlock()
-
-	thisId := s.nextId
+	var thisId uint64
 	if len(id) > 0 {
 		thisId = id[0]
+	} else {
+		thisId = Ids.Next()
 	}
 
+	s.mapMux.Lock()
+	defer s.mapMux.Unlock()
+
 	s.objectsMap[thisId] = obj
-	s.nextId++
 	return thisId
 }
 
@@ -48,7 +51,20 @@ func (s *SharedCollection[T]) Remove(id uint64) {
 	delete(s.objectsMap, id)
 }
 
-// Call the callback function for each object in the map.
+// Replace the object with the given ID, but only if it's still the one expected, so one that's been removed or
+// replaced since isn't put back. Returns whether it was replaced.
+func (s *SharedCollection[T]) Replace(id uint64, expected T, replacement T) bool {
+	s.mapMux.Lock()
+	defer s.mapMux.Unlock()
+
+	if obj, found := s.objectsMap[id]; !found || any(obj) != any(expected) {
+		return false
+	}
+	s.objectsMap[id] = replacement
+	return true
+}
+
+// Call the callback function for each object in the map, in order of ID if the simulation is deterministic.
 func (s *SharedCollection[T]) ForEach(callback func(uint64, T)) {
 	// Create a local copy while holding the lock
 	s.mapMux.Lock()
@@ -59,6 +75,12 @@ func (s *SharedCollection[T]) ForEach(callback func(uint64, T)) {
 	s.mapMux.Unlock()
 
 	// Iterate over the local copy without holding the lock
+	if Sim.Deterministic() {
+		for _, id := range slices.Sorted(maps.Keys(localCopy)) {
+			callback(id, localCopy[id])
+		}
+		return
+	}
 	for id, obj := range localCopy {
 		callback(id, obj)
 	}
@@ -0,0 +1,84 @@
+package objects
+
+import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Where a deterministic simulation's clock starts, whatever the time really is
+var simulationEpoch = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Where the world gets its randomness and the time from. Normally that's math/rand and the wall clock, but once made
+// deterministic it's a seeded generator and a clock that only moves when the simulation ticks, and collections are
+// iterated in order of ID, so the same inputs always play out the same way.
+type Simulation struct {
+	deterministic atomic.Bool
+
+	rng    *rand.Rand
+	rngMux sync.Mutex
+
+	now      time.Time
+	clockMux sync.RWMutex
+}
+
+// What everything in the world uses
+var Sim = &Simulation{}
+
+// Must be called before anything is added to the world
+func (s *Simulation) MakeDeterministic(seed uint64) {
+	s.rngMux.Lock()
+	s.rng = rand.New(rand.NewPCG(seed, seed))
+	s.rngMux.Unlock()
+
+	s.clockMux.Lock()
+	s.now = simulationEpoch
+	s.clockMux.Unlock()
+
+	s.deterministic.Store(true)
+}
+
+func (s *Simulation) Deterministic() bool {
+	return s.deterministic.Load()
+}
+
+// A random number from 0 up to but not including 1
+func (s *Simulation) Float64() float64 {
+	if !s.Deterministic() {
+		return rand.Float64()
+	}
+	s.rngMux.Lock()
+	defer s.rngMux.Unlock()
+	return s.rng.Float64()
+}
+
+// A normally distributed random number with a mean of 0 and a standard deviation of 1
+func (s *Simulation) NormFloat64() float64 {
+	if !s.Deterministic() {
+		return rand.NormFloat64()
+	}
+	s.rngMux.Lock()
+	defer s.rngMux.Unlock()
+	return s.rng.NormFloat64()
+}
+
+func (s *Simulation) Now() time.Time {
+	if !s.Deterministic() {
+		return time.Now()
+	}
+	s.clockMux.RLock()
+	defer s.clockMux.RUnlock()
+	return s.now
+}
+
+func (s *Simulation) Since(t time.Time) time.Duration {
+	return s.Now().Sub(t)
+}
+
+// Move a deterministic simulation's clock on, once each tick
+func (s *Simulation) Advance(d time.Duration) {
+	s.clockMux.Lock()
+	defer s.clockMux.Unlock()
+	s.now = s.now.Add(d)
+}
@@ -1,12 +1,15 @@
 package objects
 
-import "math/rand/v2"
-
 var getPlayerPosition = func(p *Player) (float64, float64) { return p.X, p.Y }
 var getPlayerRadius = func(p *Player) float64 { return p.Radius }
 var getSporePosition = func(s *Spore) (float64, float64) { return s.X, s.Y }
 var getSporeRadius = func(s *Spore) float64 { return s.Radius }
 
+// Whether there's room for something of the given radius at the given point without touching any of the players
+func IsClear(x float64, y float64, radius float64, players *SharedCollection[*Player]) bool {
+	return !isTooClose(x, y, radius, players, getPlayerPosition, getPlayerRadius)
+}
+
 func isTooClose[T any](x float64, y float64, radius float64, objects *SharedCollection[T], getPosition func(T) (float64, float64), getRadius func(T) float64) bool {
 	// Not too close if there are no objects
 	if objects == nil {
@@ -41,8 +44,8 @@ func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], spor
 
 	tries := 0
 	for {
-		x := bound * (2*rand.Float64() - 1)
-		y := bound * (2*rand.Float64() - 1)
+		x := bound * (2*Sim.Float64() - 1)
+		y := bound * (2*Sim.Float64() - 1)
 
 		if !isTooClose(x, y, radius, playersToAvoid, getPlayerPosition, getPlayerRadius) &&
 			!isTooClose(x, y, radius, sporesToAvoid, getSporePosition, getSporeRadius) {
@@ -57,3 +60,18 @@ func SpawnCoords(radius float64, playersToAvoid *SharedCollection[*Player], spor
 	}
 
 }
+
+// A point inside the bounds clear of the players if one can be found, or else anywhere inside them
+func SpawnCoordsWithin(minX float64, minY float64, maxX float64, maxY float64, radius float64, playersToAvoid *SharedCollection[*Player]) (float64, float64) {
+	const maxTries int = 25
+
+	var x, y float64
+	for range maxTries {
+		x = minX + (maxX-minX)*Sim.Float64()
+		y = minY + (maxY-minY)*Sim.Float64()
+		if !isTooClose(x, y, radius, playersToAvoid, getPlayerPosition, getPlayerRadius) {
+			break
+		}
+	}
+	return x, y
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"time"
+)
+
+const (
+	// How often the outbox is checked for events, besides whenever one is recorded
+	outboxPollInterval = time.Second
+
+	// How many events are taken from the outbox at a time
+	outboxBatchSize = 100
+
+	// How long publishing may take before it counts as failed
+	outboxPublishTimeout = 10 * time.Second
+
+	// Failed publishing is retried after a delay doubling from a second up to this
+	outboxMaxBackoff = time.Minute
+)
+
+var (
+	outboxPublishedTotal = metrics.NewCounter("mmo_outbox_published_total", "Events published from the outbox to every publisher.")
+	outboxFailuresTotal  = metrics.NewCounterVec("mmo_outbox_publish_failures_total", "Failed attempts to publish events from the outbox.", "publisher")
+	outboxPendingGauge   = metrics.NewGauge("mmo_outbox_pending", "Events in the last batch taken from the outbox that are yet to be published.")
+)
+
+// Something that happened in the game, as published to external systems. Events are delivered at least once, so
+// anything receiving them should use the ID to ignore ones it has already seen.
+type OutboxEvent struct {
+	Id        int64           `json:"id"`
+	Topic     string          `json:"topic"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sends events to an external system, e.g. a webhook or message broker
+type OutboxPublisher interface {
+	// Identifies the publisher in logs and metrics
+	Name() string
+
+	// Returns once the event has definitely been received, or an error if it may not have been
+	Publish(ctx context.Context, event *OutboxEvent) error
+}
+
+// Events are recorded in the database in the same transactions as the changes they describe, and are only removed
+// once they've been published, so a crash can never lose one
+type Outbox struct {
+	queries    *db.Queries
+	health     *dbHealth
+	publishers []OutboxPublisher
+	wake       chan struct{}
+}
+
+func NewOutbox(queries *db.Queries, health *dbHealth) *Outbox {
+	return &Outbox{
+		queries: queries,
+		health:  health,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Must be called before the hub is run. Events aren't recorded at all unless there's a publisher.
+func (o *Outbox) AddPublisher(publisher OutboxPublisher) {
+	o.publishers = append(o.publishers, publisher)
+}
+
+func (o *Outbox) Enabled() bool {
+	return len(o.publishers) > 0
+}
+
+func (o *Outbox) record(ctx context.Context, queries *db.Queries, topic string, data any) error {
+	if !o.Enabled() {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error encoding %s event: %w", topic, err)
+	}
+
+	err = queries.CreateOutboxEvent(ctx, db.CreateOutboxEventParams{
+		Topic:     topic,
+		Payload:   payload,
+		CreatedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The transaction may not be committed yet, in which case the event is picked up on the next poll instead
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (o *Outbox) dispatchLoop() {
+	if !o.Enabled() {
+		return
+	}
+
+	for _, publisher := range o.publishers {
+		logging.Hub.Printf("Publishing outbox events to %s", publisher.Name())
+	}
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	var backoff time.Duration
+	var retryAt time.Time
+	for {
+		select {
+		case <-ticker.C:
+		case <-o.wake:
+		}
+
+		if time.Now().Before(retryAt) || !o.health.Healthy() {
+			continue
+		}
+
+		if err := o.dispatch(); err != nil {
+			backoff = min(max(2*backoff, time.Second), outboxMaxBackoff)
+			retryAt = time.Now().Add(backoff)
+			logging.Hub.Errorf("Error publishing outbox events, retrying in %s: %v", backoff, err)
+			continue
+		}
+		backoff = 0
+	}
+}
+
+// Publish everything in the outbox, oldest first. Stops at the first event that can't be published, so events are
+// always published in the order they were recorded.
+func (o *Outbox) dispatch() error {
+	for {
+		rows, err := o.queries.GetOutboxEvents(context.Background(), outboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			outboxPendingGauge.Set(float64(len(rows) - i))
+
+			event := &OutboxEvent{
+				Id:        row.ID,
+				Topic:     row.Topic,
+				CreatedAt: time.UnixMilli(row.CreatedAt),
+				Data:      row.Payload,
+			}
+			if err := o.publish(event); err != nil {
+				return err
+			}
+
+			if err := o.queries.DeleteOutboxEvent(context.Background(), row.ID); err != nil {
+				return err
+			}
+			outboxPublishedTotal.Inc()
+		}
+		outboxPendingGauge.Set(0)
+
+		if len(rows) < outboxBatchSize {
+			return nil
+		}
+	}
+}
+
+// If any publisher fails, the event is sent to all of them again next time
+func (o *Outbox) publish(event *OutboxEvent) error {
+	for _, publisher := range o.publishers {
+		ctx, cancel := context.WithTimeout(context.Background(), outboxPublishTimeout)
+		err := publisher.Publish(ctx, event)
+		cancel()
+		if err != nil {
+			outboxFailuresTotal.With(publisher.Name()).Inc()
+			return fmt.Errorf("error publishing event %d (%s) to %s: %w", event.Id, event.Topic, publisher.Name(), err)
+		}
+	}
+	return nil
+}
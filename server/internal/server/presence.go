@@ -0,0 +1,396 @@
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	presenceGossipInterval = 2 * time.Second
+
+	// If a shard hasn't gossiped for this long, all of its players are considered offline
+	presenceShardExpiry = 5 * presenceGossipInterval
+
+	// The most players one client can be subscribed to at once, so a client can't make every change reach it
+	maxPresenceSubscriptions = 200
+
+	// How big gossip from another shard can be, far more than any shard's players' names take up
+	maxPresenceGossipBytes = 4 << 20
+)
+
+// Tracks which players are online, both on this shard and (when gossip is enabled) on other shards, and tells
+// subscribed clients whenever a player they're interested in comes online or goes offline.
+//
+// Shards periodically push their full list of online players to each other, so the view of other shards is only
+// eventually consistent: a player switching shards may briefly appear offline, or online on both.
+type Presence struct {
+	clients *objects.SharedCollection[ClientInterfacer]
+
+	// Lower-cased player name -> display name, for players on this shard
+	local map[string]string
+
+	// Shard ID -> the last list of online players gossiped by that shard
+	remote map[string]*shardPresence
+
+	// Lower-cased player name -> IDs of the clients interested in that player, and the reverse for cleanup
+	subscribers   map[string]map[uint64]struct{}
+	subscriptions map[uint64]map[string]struct{}
+
+	mux sync.Mutex
+
+	shardId    string
+	peers      []string
+	secret     string
+	httpClient *http.Client
+	logger     *logging.Logger
+}
+
+type shardPresence struct {
+	seq    int64
+	names  map[string]string
+	seenAt time.Time
+}
+
+// The body of a gossip request between shards
+type presenceGossip struct {
+	Shard  string   `json:"shard"`
+	Seq    int64    `json:"seq"`
+	Online []string `json:"online"`
+}
+
+type presenceChange struct {
+	key    string
+	name   string
+	online bool
+}
+
+func NewPresence(clients *objects.SharedCollection[ClientInterfacer]) *Presence {
+	return &Presence{
+		clients:       clients,
+		local:         make(map[string]string),
+		remote:        make(map[string]*shardPresence),
+		subscribers:   make(map[string]map[uint64]struct{}),
+		subscriptions: make(map[uint64]map[string]struct{}),
+		logger:        logging.Hub.NewLogger("Presence: "),
+	}
+}
+
+// Start gossiping this shard's online players to the given peers (base URLs of the other shards) and accepting
+// their gossip in return, see ServeGossip. All shards must share the same secret.
+func (p *Presence) EnableGossip(shardId string, peers []string, secret string) {
+	p.shardId = shardId
+	p.peers = peers
+	p.secret = secret
+	p.httpClient = &http.Client{Timeout: presenceGossipInterval}
+
+	go p.gossipLoop()
+}
+
+func (p *Presence) SetOnline(name string) {
+	p.mux.Lock()
+	key := strings.ToLower(name)
+	wasOnline := p.isOnline(key)
+	p.local[key] = name
+	p.mux.Unlock()
+
+	if !wasOnline {
+		p.notify([]presenceChange{{key: key, name: name, online: true}})
+	}
+}
+
+// The display names of every player online on this shard
+func (p *Presence) localNames() []string {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return slices.Collect(maps.Values(p.local))
+}
+
+func (p *Presence) SetOffline(name string) {
+	p.mux.Lock()
+	key := strings.ToLower(name)
+	delete(p.local, key)
+	isOnline := p.isOnline(key)
+	p.mux.Unlock()
+
+	if !isOnline {
+		p.notify([]presenceChange{{key: key, name: name, online: false}})
+	}
+}
+
+// Subscribe the client to presence changes of the given players. The client is immediately sent their current status.
+// Players past maxPresenceSubscriptions aren't subscribed to, and the client's told so. Hidden players, by lower-cased
+// name, aren't subscribed to either, and are only ever said to be offline.
+func (p *Presence) Subscribe(clientId uint64, names []string, hidden map[string]struct{}) {
+	p.mux.Lock()
+	current := make([]packets.Msg, 0, len(names))
+	refused := 0
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if _, hide := hidden[key]; hide {
+			current = append(current, packets.NewPresence(name, false))
+			continue
+		}
+
+		if _, subscribed := p.subscriptions[clientId][key]; !subscribed && len(p.subscriptions[clientId]) >= maxPresenceSubscriptions {
+			refused++
+			continue
+		}
+
+		if _, exists := p.subscribers[key]; !exists {
+			p.subscribers[key] = make(map[uint64]struct{})
+		}
+		p.subscribers[key][clientId] = struct{}{}
+
+		if _, exists := p.subscriptions[clientId]; !exists {
+			p.subscriptions[clientId] = make(map[string]struct{})
+		}
+		p.subscriptions[clientId][key] = struct{}{}
+
+		current = append(current, packets.NewPresence(name, p.isOnline(key)))
+	}
+	p.mux.Unlock()
+
+	if client, exists := p.clients.Get(clientId); exists {
+		for _, message := range current {
+			client.SocketSend(message)
+		}
+		if refused > 0 {
+			reason := fmt.Sprintf("Can't follow more than %d players at once, %d weren't followed", maxPresenceSubscriptions, refused)
+			client.SocketSend(packets.NewDenyResponse(reason))
+		}
+	}
+}
+
+func (p *Presence) Unsubscribe(clientId uint64, names []string) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for _, name := range names {
+		p.unsubscribe(clientId, strings.ToLower(name))
+	}
+}
+
+// Stop the client following the player, e.g. once the player's blocked it, and have them look offline to it if it was
+func (p *Presence) Hide(clientId uint64, name string) {
+	p.mux.Lock()
+	key := strings.ToLower(name)
+	_, subscribed := p.subscriptions[clientId][key]
+	p.unsubscribe(clientId, key)
+	p.mux.Unlock()
+
+	if client, exists := p.clients.Get(clientId); exists && subscribed {
+		client.SocketSend(packets.NewPresence(name, false))
+	}
+}
+
+// Remove all of the client's subscriptions, e.g. when it disconnects
+func (p *Presence) UnsubscribeAll(clientId uint64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	for key := range p.subscriptions[clientId] {
+		p.unsubscribe(clientId, key)
+	}
+}
+
+// Handler for gossip from other shards
+func (p *Presence) ServeGossip(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if p.secret == "" || subtle.ConstantTimeCompare([]byte(request.Header.Get("X-Presence-Secret")), []byte(p.secret)) != 1 {
+		http.Error(writer, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	gossip := &presenceGossip{}
+	if err := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxPresenceGossipBytes)).Decode(gossip); err != nil {
+		http.Error(writer, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	if gossip.Shard == p.shardId {
+		return
+	}
+
+	names := make(map[string]string, len(gossip.Online))
+	for _, name := range gossip.Online {
+		names[strings.ToLower(name)] = name
+	}
+
+	p.mux.Lock()
+	previous, known := p.remote[gossip.Shard]
+	if known && gossip.Seq <= previous.seq {
+		// Out of order gossip, we already have something newer
+		p.mux.Unlock()
+		return
+	}
+	changes := p.replaceShard(gossip.Shard, &shardPresence{seq: gossip.Seq, names: names, seenAt: time.Now()})
+	p.mux.Unlock()
+
+	p.notify(changes)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Presence) gossipLoop() {
+	ticker := time.NewTicker(presenceGossipInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		p.expireShards()
+
+		p.mux.Lock()
+		gossip := &presenceGossip{
+			Shard:  p.shardId,
+			Seq:    time.Now().UnixNano(), // Survives restarts, unlike a counter
+			Online: make([]string, 0, len(p.local)),
+		}
+		for _, name := range p.local {
+			gossip.Online = append(gossip.Online, name)
+		}
+		p.mux.Unlock()
+
+		body, err := json.Marshal(gossip)
+		if err != nil {
+			p.logger.Errorf("Error marshalling gossip: %v", err)
+			continue
+		}
+
+		for _, peer := range p.peers {
+			go p.sendGossip(peer, body)
+		}
+	}
+}
+
+func (p *Presence) sendGossip(peer string, body []byte) {
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(peer, "/")+"/internal/presence", bytes.NewReader(body))
+	if err != nil {
+		p.logger.Errorf("Error creating gossip request for %s: %v", peer, err)
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Presence-Secret", p.secret)
+
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		p.logger.Errorf("Error gossiping to %s: %v", peer, err)
+		return
+	}
+	response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		p.logger.Printf("Peer %s rejected gossip: %s", peer, response.Status)
+	}
+}
+
+func (p *Presence) expireShards() {
+	p.mux.Lock()
+	changes := make([]presenceChange, 0)
+	for shardId, shard := range p.remote {
+		if time.Since(shard.seenAt) > presenceShardExpiry {
+			p.logger.Printf("No gossip from shard %s since %v, considering its players offline", shardId, shard.seenAt)
+			changes = append(changes, p.replaceShard(shardId, nil)...)
+		}
+	}
+	p.mux.Unlock()
+
+	p.notify(changes)
+}
+
+// Replace (or remove, if nil) what we know about another shard and return whose online status changed as a result.
+// Must be called with the lock held.
+func (p *Presence) replaceShard(shardId string, shard *shardPresence) []presenceChange {
+	before := make(map[string]string)
+	if previous, known := p.remote[shardId]; known {
+		for key, name := range previous.names {
+			if p.isOnline(key) {
+				before[key] = name
+			}
+		}
+	}
+
+	if shard == nil {
+		delete(p.remote, shardId)
+	} else {
+		p.remote[shardId] = shard
+	}
+
+	changes := make([]presenceChange, 0)
+	for key, name := range before {
+		if !p.isOnline(key) {
+			changes = append(changes, presenceChange{key: key, name: name, online: false})
+		}
+	}
+	if shard != nil {
+		for key, name := range shard.names {
+			if _, wasOnline := before[key]; !wasOnline && p.local[key] == "" && p.onlineElsewhere(key, shardId) == 0 {
+				changes = append(changes, presenceChange{key: key, name: name, online: true})
+			}
+		}
+	}
+	return changes
+}
+
+// How many shards other than the given one the player is online on. Must be called with the lock held.
+func (p *Presence) onlineElsewhere(key string, exceptShardId string) int {
+	count := 0
+	for shardId, shard := range p.remote {
+		if _, online := shard.names[key]; online && shardId != exceptShardId {
+			count++
+		}
+	}
+	return count
+}
+
+// Must be called with the lock held
+func (p *Presence) isOnline(key string) bool {
+	if _, online := p.local[key]; online {
+		return true
+	}
+	return p.onlineElsewhere(key, "") > 0
+}
+
+// Must be called with the lock held
+func (p *Presence) unsubscribe(clientId uint64, key string) {
+	delete(p.subscribers[key], clientId)
+	if len(p.subscribers[key]) == 0 {
+		delete(p.subscribers, key)
+	}
+
+	delete(p.subscriptions[clientId], key)
+	if len(p.subscriptions[clientId]) == 0 {
+		delete(p.subscriptions, clientId)
+	}
+}
+
+// Send presence changes to interested clients only. Must be called without the lock held.
+func (p *Presence) notify(changes []presenceChange) {
+	for _, change := range changes {
+		p.mux.Lock()
+		clientIds := make([]uint64, 0, len(p.subscribers[change.key]))
+		for clientId := range p.subscribers[change.key] {
+			clientIds = append(clientIds, clientId)
+		}
+		p.mux.Unlock()
+
+		message := packets.NewPresence(change.name, change.online)
+		for _, clientId := range clientIds {
+			if client, exists := p.clients.Get(clientId); exists {
+				client.SocketSend(message)
+			}
+		}
+	}
+}
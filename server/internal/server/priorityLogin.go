@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"server/internal/server/metrics"
+	"slices"
+	"sync"
+	"time"
+)
+
+var priorityLoginsTotal = metrics.NewCounterVec("mmo_priority_logins_total", "Logins let in over the players budget, by whether an idle player was disconnected to make room.", "evicted")
+
+// Lets accounts with an entitlement giving priority login, e.g. staff and supporters, in when the server's at its
+// players budget, rather than turning them away like everyone else. If configured to, it makes room for each by
+// disconnecting whichever player has been idle the longest, so the server stays at its budget. Both are recorded in
+// the audit log.
+type PriorityLogin struct {
+	hub *Hub
+
+	// How long a player must have been idle for to be disconnected for someone with priority, never if 0
+	evictIdle time.Duration
+
+	// When each player in game last moved or chatted, and whether they have priority themselves, by client ID
+	players map[uint64]*priorityLoginPlayer
+	mux     sync.Mutex
+}
+
+type priorityLoginPlayer struct {
+	name     string
+	priority bool
+	activeAt time.Time
+}
+
+func NewPriorityLogin(hub *Hub) *PriorityLogin {
+	return &PriorityLogin{
+		hub:     hub,
+		players: make(map[uint64]*priorityLoginPlayer),
+	}
+}
+
+// Must be called before the hub is run
+func (p *PriorityLogin) Configure(evictIdle time.Duration) {
+	p.evictIdle = evictIdle
+}
+
+// Whether any of the entitlements gives priority login
+func (p *PriorityLogin) Has(entitlementIds []string) bool {
+	for _, id := range entitlementIds {
+		if entitlement, exists := p.hub.GameData.Entitlement(id); exists && entitlement.PriorityLogin {
+			return true
+		}
+	}
+	return false
+}
+
+// Let the account in over the players budget, making room for it if configured to. Returns false if it doesn't have
+// priority, in which case it should be turned away.
+func (p *PriorityLogin) Admit(username string, entitlementIds []string) bool {
+	if !p.Has(entitlementIds) {
+		return false
+	}
+
+	players := p.hub.Sessions.Count()
+	p.hub.Audit("server", "login.priority", username, fmt.Sprintf("let in over the players budget with %d players online", players))
+
+	clientId, evicted, found := p.longestIdle()
+	if !found {
+		priorityLoginsTotal.With("false").Inc()
+		return true
+	}
+
+	idle := time.Since(evicted.activeAt).Round(time.Second)
+	if client, exists := p.hub.Clients.Get(clientId); exists {
+		go client.Kick(KickCapacity, "Disconnected for being idle to make room on a full server")
+	}
+	p.hub.Audit("server", "login.evicted", evicted.name, fmt.Sprintf("disconnected after %s idle to make room for %s", idle, username))
+	priorityLoginsTotal.With("true").Inc()
+	return true
+}
+
+// The player without priority who's been idle the longest, if any has been idle long enough to be disconnected.
+// They're forgotten straight away, so the same player isn't picked twice.
+func (p *PriorityLogin) longestIdle() (uint64, priorityLoginPlayer, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.evictIdle <= 0 {
+		return 0, priorityLoginPlayer{}, false
+	}
+
+	var longestId uint64
+	var longest *priorityLoginPlayer
+	for clientId, player := range p.players {
+		if player.priority || time.Since(player.activeAt) < p.evictIdle {
+			continue
+		}
+		if longest == nil || player.activeAt.Before(longest.activeAt) {
+			longestId, longest = clientId, player
+		}
+	}
+	if longest == nil {
+		return 0, priorityLoginPlayer{}, false
+	}
+	delete(p.players, longestId)
+	return longestId, *longest, true
+}
+
+// Start watching the player for being idle, from when they enter the game
+func (p *PriorityLogin) Enter(clientId uint64, name string, priority bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.players[clientId] = &priorityLoginPlayer{name: name, priority: priority, activeAt: time.Now()}
+}
+
+// The player did something themselves, so isn't idle
+func (p *PriorityLogin) Active(clientId uint64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if player, exists := p.players[clientId]; exists {
+		player.activeAt = time.Now()
+	}
+}
+
+// The clients of every player being watched
+func (p *PriorityLogin) clientIds() []uint64 {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return slices.Collect(maps.Keys(p.players))
+}
+
+func (p *PriorityLogin) Leave(clientId uint64) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	delete(p.players, clientId)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"errors"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+// The most prompts a player can have open at once, so a bug asking over and over can't bury them
+const maxOpenPrompts = 8
+
+var (
+	ErrPromptTimedOut  = errors.New("prompt timed out")
+	ErrPromptCancelled = errors.New("prompt cancelled")
+	ErrPromptClosed    = errors.New("client closed before answering")
+	ErrTooManyPrompts  = errors.New("too many prompts open")
+	ErrNoPromptOptions = errors.New("prompt has no options")
+)
+
+var promptsTotal = metrics.NewCounterVec("mmo_prompts_total", "Prompts put to players, by how they ended.", "outcome")
+
+type PromptOption struct {
+	// What the player's answer says they chose
+	Id    string
+	Label string
+}
+
+// A choice put to the player, e.g. to confirm a trade or accept an offer of a place in a queue
+type Prompt struct {
+	Title   string
+	Text    string
+	Options []PromptOption
+
+	// How long the player has to answer, or forever if 0
+	Timeout time.Duration
+}
+
+// Called once with the ID of the option the player chose, or an error if they never will, e.g. ErrPromptTimedOut. It's
+// called from whichever goroutine the answer or timeout comes in on, so anything it does to a state should go through
+// the client's ProcessMessage.
+type PromptCallback func(optionId string, err error)
+
+type openPrompt struct {
+	options  []PromptOption
+	callback PromptCallback
+	timer    *time.Timer
+}
+
+// The prompts put to one client and waiting on its answers, so server logic can ask players to choose without a
+// packet of its own for every dialog
+type Prompts struct {
+	send   func(packets.Msg)
+	nextId uint64
+	open   map[uint64]*openPrompt
+	closed bool
+	mux    sync.Mutex
+}
+
+// Prompts sent to a client with the given function, usually its SocketSend
+func NewPrompts(send func(packets.Msg)) *Prompts {
+	return &Prompts{
+		send: send,
+		open: make(map[uint64]*openPrompt),
+	}
+}
+
+// Put the prompt to the player, returning its ID. The callback is called once they answer, or it's closed some other
+// way.
+func (p *Prompts) Ask(prompt Prompt, callback PromptCallback) (uint64, error) {
+	if len(prompt.Options) == 0 {
+		return 0, ErrNoPromptOptions
+	}
+
+	p.mux.Lock()
+	if p.closed {
+		p.mux.Unlock()
+		return 0, ErrPromptClosed
+	}
+	if len(p.open) >= maxOpenPrompts {
+		p.mux.Unlock()
+		return 0, ErrTooManyPrompts
+	}
+	p.nextId++
+	promptId := p.nextId
+	open := &openPrompt{options: prompt.Options, callback: callback}
+	if prompt.Timeout > 0 {
+		open.timer = time.AfterFunc(prompt.Timeout, func() {
+			p.end(promptId, "timed out", ErrPromptTimedOut)
+		})
+	}
+	p.open[promptId] = open
+	p.mux.Unlock()
+
+	options := make([]*packets.PromptOptionMessage, len(prompt.Options))
+	for i, option := range prompt.Options {
+		options[i] = &packets.PromptOptionMessage{Id: option.Id, Label: option.Label}
+	}
+	p.send(packets.NewPrompt(promptId, prompt.Title, prompt.Text, options, prompt.Timeout))
+	return promptId, nil
+}
+
+// The player's answer to one of their prompts. Answers to prompts that are closed, or with an option that isn't one of
+// the prompt's, are ignored and return false.
+func (p *Prompts) Answer(promptId uint64, optionId string) bool {
+	p.mux.Lock()
+	open, exists := p.open[promptId]
+	if !exists || !hasPromptOption(open.options, optionId) {
+		p.mux.Unlock()
+		return false
+	}
+	p.take(promptId, open)
+	p.mux.Unlock()
+
+	promptsTotal.With("answered").Inc()
+	open.callback(optionId, nil)
+	return true
+}
+
+// Take the prompt down before the player answers, e.g. because what it offered is gone
+func (p *Prompts) Cancel(promptId uint64, reason string) {
+	p.end(promptId, reason, ErrPromptCancelled)
+}
+
+// Close every open prompt without telling the client, once it's disconnected
+func (p *Prompts) CloseAll() {
+	p.mux.Lock()
+	p.closed = true
+	open := p.open
+	p.open = make(map[uint64]*openPrompt)
+	p.mux.Unlock()
+
+	for _, prompt := range open {
+		if prompt.timer != nil {
+			prompt.timer.Stop()
+		}
+		promptsTotal.With("closed").Inc()
+		prompt.callback("", ErrPromptClosed)
+	}
+}
+
+func (p *Prompts) end(promptId uint64, reason string, err error) {
+	p.mux.Lock()
+	open, exists := p.open[promptId]
+	if exists {
+		p.take(promptId, open)
+	}
+	p.mux.Unlock()
+	if !exists {
+		return
+	}
+
+	if errors.Is(err, ErrPromptTimedOut) {
+		promptsTotal.With("timed_out").Inc()
+	} else {
+		promptsTotal.With("cancelled").Inc()
+	}
+	p.send(packets.NewPromptClosed(promptId, reason))
+	open.callback("", err)
+}
+
+// Must be called with the lock held
+func (p *Prompts) take(promptId uint64, open *openPrompt) {
+	delete(p.open, promptId)
+	if open.timer != nil {
+		open.timer.Stop()
+	}
+}
+
+func hasPromptOption(options []PromptOption, optionId string) bool {
+	for _, option := range options {
+		if option.Id == optionId {
+			return true
+		}
+	}
+	return false
+}
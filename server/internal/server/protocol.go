@@ -0,0 +1,298 @@
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	ProtocolInspectorPath = "/debug/protocol"
+
+	// How many example payloads are kept of each kind of packet, and how often a new one is taken in each direction
+	protocolExamples        = 10
+	protocolExampleInterval = time.Second
+)
+
+//go:embed protocol.html
+var protocolInspectorHtml []byte
+
+// A development tool showing every kind of packet there is, its schema, how often each is sent each way, and recent
+// examples of them, for tracking down the client and server disagreeing about the protocol. Counting is only done
+// once it's enabled, since the examples have players' messages in them.
+type ProtocolInspector struct {
+	enabled   bool
+	startedAt time.Time
+
+	// Indexed by kind, with unknown kinds counted under 0
+	kinds []*protocolKind
+}
+
+type protocolKind struct {
+	received      atomic.Uint64
+	receivedBytes atomic.Uint64
+	sent          atomic.Uint64
+	sentBytes     atomic.Uint64
+
+	// Received while the client's state wasn't handling them, so they were dropped undecoded
+	ignored atomic.Uint64
+
+	// Unix milliseconds
+	lastSeenAt atomic.Int64
+
+	examples         []protocolExample
+	nextExample      int
+	lastInExampleAt  time.Time
+	lastOutExampleAt time.Time
+	mux              sync.Mutex
+}
+
+type protocolExample struct {
+	Direction string          `json:"direction"`
+	At        time.Time       `json:"at"`
+	SenderId  uint64          `json:"sender_id"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+func NewProtocolInspector() *ProtocolInspector {
+	fields := packetMsgFields()
+	maxKind := 0
+	for i := range fields.Len() {
+		maxKind = max(maxKind, int(fields.Get(i).Number()))
+	}
+
+	kinds := make([]*protocolKind, maxKind+1)
+	for i := range kinds {
+		kinds[i] = &protocolKind{}
+	}
+	return &ProtocolInspector{kinds: kinds}
+}
+
+// Start counting packets. Must be called before the hub is run.
+func (p *ProtocolInspector) Enable() {
+	p.enabled = true
+	p.startedAt = time.Now()
+}
+
+// Count a packet read from a client. The message is nil if the client's state ignored it without decoding it.
+func (p *ProtocolInspector) Received(kind packets.MsgKind, bytes int, senderId uint64, message packets.Msg) {
+	if !p.enabled {
+		return
+	}
+	stats := p.kind(kind)
+	stats.received.Add(1)
+	stats.receivedBytes.Add(uint64(bytes))
+	stats.lastSeenAt.Store(time.Now().UnixMilli())
+	if message == nil {
+		stats.ignored.Add(1)
+		return
+	}
+	stats.sample("in", senderId, message)
+}
+
+// Count a packet written to a client
+func (p *ProtocolInspector) Sent(kind packets.MsgKind, bytes int, senderId uint64, message packets.Msg) {
+	if !p.enabled {
+		return
+	}
+	stats := p.kind(kind)
+	stats.sent.Add(1)
+	stats.sentBytes.Add(uint64(bytes))
+	stats.lastSeenAt.Store(time.Now().UnixMilli())
+	stats.sample("out", senderId, message)
+}
+
+func (p *ProtocolInspector) kind(kind packets.MsgKind) *protocolKind {
+	if kind <= 0 || int(kind) >= len(p.kinds) {
+		return p.kinds[0]
+	}
+	return p.kinds[kind]
+}
+
+// Keep the message as an example, unless one was taken in the same direction too recently
+func (k *protocolKind) sample(direction string, senderId uint64, message packets.Msg) {
+	now := time.Now()
+	k.mux.Lock()
+	lastAt := &k.lastInExampleAt
+	if direction == "out" {
+		lastAt = &k.lastOutExampleAt
+	}
+	if now.Sub(*lastAt) < protocolExampleInterval {
+		k.mux.Unlock()
+		return
+	}
+	*lastAt = now
+	k.mux.Unlock()
+
+	// Copied so secrets can be blanked out without touching the message being sent
+	packet := proto.Clone(&packets.Packet{Msg: message}).(*packets.Packet)
+	redactSecrets(packet.ProtoReflect())
+	payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(packet)
+	if err != nil {
+		logging.Hub.Errorf("Error marshalling example %T packet: %v", message, err)
+		return
+	}
+
+	example := protocolExample{Direction: direction, At: now, SenderId: senderId, Payload: payload}
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	if len(k.examples) < protocolExamples {
+		k.examples = append(k.examples, example)
+	} else {
+		k.examples[k.nextExample] = example
+	}
+	k.nextExample = (k.nextExample + 1) % protocolExamples
+}
+
+// Newest first
+func (k *protocolKind) recentExamples() []protocolExample {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	examples := make([]protocolExample, 0, len(k.examples))
+	for i := range len(k.examples) {
+		examples = append(examples, k.examples[(k.nextExample-1-i+2*len(k.examples))%len(k.examples)])
+	}
+	return examples
+}
+
+// Blank out anything that looks like a password, token or receipt, at any depth
+func redactSecrets(message protoreflect.Message) {
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := string(field.Name())
+		switch {
+		case field.Kind() == protoreflect.StringKind && !field.IsList() && (strings.Contains(name, "password") || strings.Contains(name, "token") || strings.Contains(name, "receipt")):
+			message.Set(field, protoreflect.ValueOfString("[redacted]"))
+		case field.Kind() == protoreflect.MessageKind && field.IsList():
+			list := value.List()
+			for i := range list.Len() {
+				redactSecrets(list.Get(i).Message())
+			}
+		case field.Kind() == protoreflect.MessageKind && !field.IsMap():
+			redactSecrets(value.Message())
+		}
+		return true
+	})
+}
+
+type protocolKindInfo struct {
+	Kind          int               `json:"kind"`
+	Name          string            `json:"name"`
+	Message       string            `json:"message"`
+	Schema        string            `json:"schema"`
+	Received      uint64            `json:"received"`
+	ReceivedBytes uint64            `json:"received_bytes"`
+	Ignored       uint64            `json:"ignored"`
+	Sent          uint64            `json:"sent"`
+	SentBytes     uint64            `json:"sent_bytes"`
+	PerSecond     float64           `json:"per_second"`
+	LastSeenAt    *time.Time        `json:"last_seen_at,omitempty"`
+	Examples      []protocolExample `json:"examples"`
+}
+
+func (p *ProtocolInspector) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Query().Get("format") != "json" {
+		writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+		writer.Write(protocolInspectorHtml)
+		return
+	}
+
+	seconds := max(time.Since(p.startedAt).Seconds(), 1)
+	fields := packetMsgFields()
+	infos := make([]protocolKindInfo, 0, fields.Len()+1)
+	addInfo := func(kind int, name string, message string, schema string) {
+		stats := p.kinds[kind]
+		info := protocolKindInfo{
+			Kind:          kind,
+			Name:          name,
+			Message:       message,
+			Schema:        schema,
+			Received:      stats.received.Load(),
+			ReceivedBytes: stats.receivedBytes.Load(),
+			Ignored:       stats.ignored.Load(),
+			Sent:          stats.sent.Load(),
+			SentBytes:     stats.sentBytes.Load(),
+			Examples:      stats.recentExamples(),
+		}
+		info.PerSecond = float64(info.Received+info.Sent) / seconds
+		if lastSeenAt := stats.lastSeenAt.Load(); lastSeenAt != 0 {
+			at := time.UnixMilli(lastSeenAt)
+			info.LastSeenAt = &at
+		}
+		infos = append(infos, info)
+	}
+
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		addInfo(int(field.Number()), string(field.Name()), string(field.Message().Name()), messageSchema(field.Message()))
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Kind < infos[j].Kind })
+
+	// Only worth showing if anything turned up that this server doesn't know
+	if unknown := p.kinds[0]; unknown.received.Load() > 0 {
+		addInfo(0, "unknown", "", "")
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(infos); err != nil {
+		logging.Hub.Errorf("Error writing protocol inspector response: %v", err)
+	}
+}
+
+// The message's definition in proto syntax, followed by those of the messages it's made of
+func messageSchema(message protoreflect.MessageDescriptor) string {
+	var builder strings.Builder
+	seen := make(map[protoreflect.FullName]bool)
+	queue := []protoreflect.MessageDescriptor{message}
+	for len(queue) > 0 {
+		message, queue = queue[0], queue[1:]
+		if seen[message.FullName()] {
+			continue
+		}
+		seen[message.FullName()] = true
+
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+		fmt.Fprintf(&builder, "message %s {\n", message.Name())
+		fields := message.Fields()
+		for i := range fields.Len() {
+			field := fields.Get(i)
+			typeName := field.Kind().String()
+			if field.Kind() == protoreflect.MessageKind {
+				typeName = string(field.Message().Name())
+				queue = append(queue, field.Message())
+			}
+			label := ""
+			if field.IsList() {
+				label = "repeated "
+			}
+			fmt.Fprintf(&builder, "    %s%s %s = %d;\n", label, typeName, field.Name(), field.Number())
+		}
+		builder.WriteString("}\n")
+	}
+	return builder.String()
+}
+
+func packetMsgFields() protoreflect.FieldDescriptors {
+	return packets.File_packets_proto.Messages().ByName("Packet").Oneofs().ByName("msg").Fields()
+}
+
+// What the kind of packet's called in packets.proto, e.g. chat, or unknown if it's not one
+func kindName(kind packets.MsgKind) string {
+	if field := packetMsgFields().ByNumber(protoreflect.FieldNumber(kind)); field != nil {
+		return string(field.Name())
+	}
+	return "unknown"
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"strconv"
+	"time"
+)
+
+const (
+	// The most of anything the public API sends at once
+	publicApiMaxLimit = 500
+
+	// How far back the market history goes unless asked for less
+	marketHistoryDefaultSince = 7 * 24 * time.Hour
+)
+
+type hiscoreEntry struct {
+	Rank      int64  `json:"rank"`
+	Name      string `json:"name"`
+	BestScore int64  `json:"best_score"`
+}
+
+type hiscorePage struct {
+	Hiscores []hiscoreEntry `json:"hiscores"`
+
+	// When the ranks were last worked out, in Unix milliseconds, or 0 if they haven't been yet
+	RankedAt int64 `json:"ranked_at"`
+}
+
+type marketSale struct {
+	ItemId   string `json:"item_id"`
+	Quantity int64  `json:"quantity"`
+	Price    int64  `json:"price"`
+	SoldAt   int64  `json:"sold_at"`
+}
+
+// Read the limit query parameter, between 1 and publicApiMaxLimit. Writes the error and returns false if it's invalid.
+func publicApiLimit(writer http.ResponseWriter, request *http.Request, fallback int) (int, bool) {
+	param := request.URL.Query().Get("limit")
+	if param == "" {
+		return fallback, true
+	}
+	limit, err := strconv.Atoi(param)
+	if err != nil || limit <= 0 || limit > publicApiMaxLimit {
+		http.Error(writer, "invalid limit", http.StatusBadRequest)
+		return 0, false
+	}
+	return limit, true
+}
+
+func writePublicJson(writer http.ResponseWriter, value any) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		logging.Hub.Errorf("Error writing public API response: %v", err)
+	}
+}
+
+// Query parameters: offset (default 0), limit (default 100)
+func (k *ApiKeys) serveHiscores(writer http.ResponseWriter, request *http.Request, _ db.ApiKey) {
+	limit, ok := publicApiLimit(writer, request, 100)
+	if !ok {
+		return
+	}
+	offset := 0
+	if param := request.URL.Query().Get("offset"); param != "" {
+		var err error
+		if offset, err = strconv.Atoi(param); err != nil || offset < 0 {
+			http.Error(writer, "invalid offset", http.StatusBadRequest)
+			return
+		}
+	}
+
+	rows, err := k.hub.NewDbTx().Queries.GetHiscores(request.Context(), db.GetHiscoresParams{
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error getting hiscores for the public API: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	page := hiscorePage{Hiscores: make([]hiscoreEntry, len(rows))}
+	for i, row := range rows {
+		page.Hiscores[i] = hiscoreEntry{Rank: row.Rank, Name: row.Name, BestScore: row.BestScore}
+		page.RankedAt = row.RankedAt
+	}
+	writePublicJson(writer, page)
+}
+
+// Sales on the auction house, most recent first. Buyers and sellers are left out, so it's only the market that can be
+// followed, not the players trading in it.
+//
+// Query parameters: item (default every item), since (unix milliseconds, default a week ago), limit (default 100)
+func (k *ApiKeys) serveMarketHistory(writer http.ResponseWriter, request *http.Request, _ db.ApiKey) {
+	query := request.URL.Query()
+	limit, ok := publicApiLimit(writer, request, 100)
+	if !ok {
+		return
+	}
+	since := time.Now().Add(-marketHistoryDefaultSince).UnixMilli()
+	if param := query.Get("since"); param != "" {
+		var err error
+		if since, err = strconv.ParseInt(param, 10, 64); err != nil {
+			http.Error(writer, "invalid since", http.StatusBadRequest)
+			return
+		}
+	}
+	pattern := "%"
+	if item := query.Get("item"); item != "" {
+		pattern = EscapeItemPattern(item)
+	}
+
+	rows, err := k.hub.NewDbTx().Queries.GetAuctionSales(request.Context(), db.GetAuctionSalesParams{
+		SoldAt: sql.NullInt64{Int64: since, Valid: true},
+		ItemID: pattern,
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error getting market history for the public API: %v", err)
+		http.Error(writer, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	sales := make([]marketSale, len(rows))
+	for i, row := range rows {
+		sales[i] = marketSale{ItemId: row.ItemID, Quantity: row.Quantity, Price: row.Price, SoldAt: row.SoldAt.Int64}
+	}
+	writePublicJson(writer, map[string][]marketSale{"sales": sales})
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"fmt"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"strings"
+	"time"
+)
+
+const reaperInterval = time.Minute
+
+var reapedTotal = metrics.NewCounterVec("mmo_reaped_total", "Stale state cleaned up by the reaper, by what it was.", "kind")
+
+// What the reaper cleans up
+const (
+	// Players in the world with no client or simulator left driving them
+	reapedActor = "actor"
+
+	// Clients closed but never unregistered
+	reapedSession = "session"
+
+	// Players shown online with nobody in the world by their name
+	reapedPresence = "presence"
+
+	// What the subsystems keep about each client, for clients that are gone
+	reapedClientState = "client_state"
+
+	// Spores still pointing at the players who dropped them after they've left
+	reapedReference = "reference"
+)
+
+// Sweeps the world every so often for what should have been cleaned up and wasn't, like the actor of a client that's
+// gone or a session that was closed but never unregistered, and cleans it up, so a missed cleanup somewhere doesn't
+// pile up until the next restart. Each is logged and counted, since it means a cleanup is being missed somewhere.
+// Anything found is only cleaned up if it's still there on the next sweep, so what's only halfway through being
+// cleaned up as normal, like a client in the middle of leaving, is left to finish.
+type Reaper struct {
+	hub *Hub
+
+	// Found stale on the last sweep, by kind and ID
+	suspects map[string]struct{}
+}
+
+func NewReaper(hub *Hub) *Reaper {
+	return &Reaper{hub: hub, suspects: make(map[string]struct{})}
+}
+
+func (r *Reaper) sweepLoop() {
+	ticker := time.NewTicker(reaperInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.sweep()
+	}
+}
+
+// Clean up whatever's been stale since the last sweep, returning how much of each kind was
+func (r *Reaper) sweep() map[string]int {
+	found := make(map[string]struct{})
+	reaped := make(map[string]int)
+
+	// Whether it was stale last time too, so should go now
+	stale := func(kind string, id any) bool {
+		key := fmt.Sprintf("%s %v", kind, id)
+		if _, suspected := r.suspects[key]; suspected {
+			reaped[kind]++
+			reapedTotal.With(kind).Inc()
+			return true
+		}
+		found[key] = struct{}{}
+		return false
+	}
+
+	connected := func(clientId uint64) bool {
+		_, exists := r.hub.Clients.Get(clientId)
+		return exists
+	}
+
+	r.hub.Clients.ForEach(func(clientId uint64, client ClientInterfacer) {
+		if client.Closed() && stale(reapedSession, clientId) {
+			logging.Hub.Printf("Reaping client %d, closed but never unregistered", clientId)
+			r.hub.Unregister(client)
+		}
+	})
+
+	for _, simulatorId := range r.hub.Simulators.simulatorIds() {
+		if !connected(simulatorId) && stale(reapedActor, simulatorId) {
+			logging.Hub.Printf("Reaping the actors of simulator %d, which is gone", simulatorId)
+			r.hub.Simulators.DespawnAll(simulatorId)
+		}
+	}
+
+	names := make(map[string]struct{})
+	players := make(map[*objects.Player]struct{})
+	r.hub.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+		names[strings.ToLower(player.Name)] = struct{}{}
+		players[player] = struct{}{}
+		if _, driven := r.hub.Simulators.Owner(id); driven || connected(id) {
+			return
+		}
+		if stale(reapedActor, id) {
+			logging.Hub.Printf("Reaping %s (%d), whose client is gone", player.Name, id)
+			r.hub.SharedGameObjects.Players.Remove(id)
+			r.hub.Visibility.Forget(id)
+			r.hub.Broadcast(&packets.Packet{SenderId: id, Msg: packets.NewDisconnect("despawned")})
+		}
+	})
+
+	for _, name := range r.hub.Presence.localNames() {
+		if _, inWorld := names[strings.ToLower(name)]; !inWorld && stale(reapedPresence, name) {
+			logging.Hub.Printf("Reaping %s's presence, with nobody by that name in the world", name)
+			r.hub.Presence.SetOffline(name)
+		}
+	}
+
+	forgotten := func(what string, clientIds []uint64, forget func(uint64)) {
+		for _, clientId := range clientIds {
+			if !connected(clientId) && stale(reapedClientState, what+" "+fmt.Sprint(clientId)) {
+				logging.Hub.Printf("Reaping %s kept for client %d, which is gone", what, clientId)
+				forget(clientId)
+			}
+		}
+	}
+	forgotten("priority login", r.hub.PriorityLogin.clientIds(), r.hub.PriorityLogin.Leave)
+	forgotten("channel", r.hub.Channels.clientIds(), r.hub.Channels.Leave)
+	forgotten("chat reactions", r.hub.ChatReactions.clientIds(), r.hub.ChatReactions.Forget)
+	forgotten("moderation history", r.hub.ModerationCases.clientIds(), r.hub.ModerationCases.Forget)
+	forgotten("debug overlay", r.hub.DebugOverlays.clientIds(), r.hub.DebugOverlays.Forget)
+	forgotten("emitters sent", r.hub.Emitters.clientIds(), r.hub.Emitters.Forget)
+	for _, id := range r.hub.Visibility.ids() {
+		if _, inWorld := r.hub.SharedGameObjects.Players.Get(id); !inWorld && !connected(id) && stale(reapedClientState, "visibility "+fmt.Sprint(id)) {
+			logging.Hub.Printf("Reaping visibility rules kept for %d, which is gone", id)
+			r.hub.Visibility.Forget(id)
+		}
+	}
+	for _, id := range r.hub.DamageAttribution.targetIds() {
+		if _, inWorld := r.hub.SharedGameObjects.Players.Get(id); !inWorld && stale(reapedClientState, "damage "+fmt.Sprint(id)) {
+			logging.Hub.Printf("Reaping damage done to %d, which is gone", id)
+			r.hub.DamageAttribution.Forget(id)
+		}
+	}
+
+	r.hub.SharedGameObjects.Spores.ForEach(func(id uint64, spore *objects.Spore) {
+		if spore.DroppedBy == nil {
+			return
+		}
+		if _, inWorld := players[spore.DroppedBy]; inWorld || !stale(reapedReference, id) {
+			return
+		}
+		// Replaced rather than changed, since whoever has the spore could be reading it
+		cleared := *spore
+		cleared.DroppedBy = nil
+		r.hub.SharedGameObjects.Spores.Replace(id, spore, &cleared)
+	})
+
+	r.suspects = found
+	if len(reaped) > 0 {
+		logging.Hub.Errorf("Reaper cleaned up stale state that should have been cleaned up already: %v", reaped)
+	}
+	return reaped
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"server/internal/server/objects"
+	"strconv"
+)
+
+// Random rolls the client can't predict, which it can check weren't rigged afterwards. The server picks a secret seed
+// ahead of the encounter and sends its hash straight away, before the client has picked the seed of its own the
+// encounter's started with. Rolls are worked out from both seeds, so the server, having committed to its seed before
+// it knew the client's, can't have picked the outcome either. Once the encounter is over, the seed is revealed so the
+// client can check it against the hash and work the rolls out for itself.
+type Encounter struct {
+	Id         uint64
+	ClientSeed string
+
+	seed  [32]byte
+	rolls []float64
+}
+
+// Pick the seed for an encounter yet to start, whose commitment is to be sent before the client picks its own
+func NewEncounter() *Encounter {
+	encounter := &Encounter{Id: objects.Ids.Next()}
+	rand.Read(encounter.seed[:])
+	return encounter
+}
+
+// Start the encounter with the client's seed, which must only be learned after the commitment's been sent
+func (e *Encounter) Start(clientSeed string) {
+	e.ClientSeed = clientSeed
+}
+
+// The SHA-256 hash of the seed, to be sent ahead of the encounter
+func (e *Encounter) Commitment() []byte {
+	hash := sha256.Sum256(e.seed[:])
+	return hash[:]
+}
+
+// The next roll, from 0 up to but not including 1
+func (e *Encounter) Roll() float64 {
+	roll := RollFromSeed(e.seed[:], e.ClientSeed, len(e.rolls))
+	e.rolls = append(e.rolls, roll)
+	return roll
+}
+
+// The seed, which is only safe to send once the encounter is over, and every roll made with it
+func (e *Encounter) Reveal() ([]byte, []float64) {
+	return e.seed[:], e.rolls
+}
+
+// Roll i is the first 8 bytes of HMAC-SHA256(seed, "<client seed>:<i>") as a big-endian integer, keeping the top 53
+// bits so it divides evenly into a float64
+func RollFromSeed(seed []byte, clientSeed string, i int) float64 {
+	mac := hmac.New(sha256.New, seed)
+	mac.Write([]byte(clientSeed + ":" + strconv.Itoa(i)))
+	sum := mac.Sum(nil)
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
+
+// Whether the revealed seed matches the commitment and gives the rolls, as a client would check
+func VerifyRolls(commitment []byte, seed []byte, clientSeed string, rolls []float64) bool {
+	hash := sha256.Sum256(seed)
+	if !hmac.Equal(hash[:], commitment) {
+		return false
+	}
+	for i, roll := range rolls {
+		if RollFromSeed(seed, clientSeed, i) != roll {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"slices"
+	"testing"
+)
+
+// What a client has to check an encounter with once it's over
+type revealedRolls struct {
+	commitment []byte
+	seed       []byte
+	clientSeed string
+	rolls      []float64
+}
+
+func (r revealedRolls) verify() bool {
+	return VerifyRolls(r.commitment, r.seed, r.clientSeed, r.rolls)
+}
+
+// An encounter committed to, started and rolled the way a gather is, then revealed
+func revealedEncounter(t *testing.T) revealedRolls {
+	t.Helper()
+	encounter := NewEncounter()
+	commitment := encounter.Commitment()
+	encounter.Start("client seed")
+	for range 5 {
+		if roll := encounter.Roll(); roll < 0 || roll >= 1 {
+			t.Fatalf("roll %v out of range", roll)
+		}
+	}
+	seed, rolls := encounter.Reveal()
+	return revealedRolls{commitment: commitment, seed: slices.Clone(seed), clientSeed: encounter.ClientSeed, rolls: slices.Clone(rolls)}
+}
+
+func TestVerifyRollsAcceptsEncounter(t *testing.T) {
+	if !revealedEncounter(t).verify() {
+		t.Fatal("rolls from a real encounter didn't verify")
+	}
+}
+
+func TestVerifyRollsRejectsTampering(t *testing.T) {
+	tests := map[string]func(r *revealedRolls){
+		"seed":        func(r *revealedRolls) { r.seed[0] ^= 1 },
+		"commitment":  func(r *revealedRolls) { r.commitment[0] ^= 1 },
+		"client seed": func(r *revealedRolls) { r.clientSeed += "!" },
+		"roll":        func(r *revealedRolls) { r.rolls[2] = 0.5 },
+		"order":       func(r *revealedRolls) { r.rolls[0], r.rolls[1] = r.rolls[1], r.rolls[0] },
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			revealed := revealedEncounter(t)
+			tamper(&revealed)
+			if revealed.verify() {
+				t.Fatalf("rolls with a tampered %s verified", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// The most actors one GM can have spawned in the sandbox at once
+const maxSandboxActors = 1000
+
+var (
+	ErrNotInSandbox         = errors.New("not in the sandbox")
+	ErrTooManySandboxActors = errors.New("too many actors spawned in the sandbox")
+)
+
+var sandboxActorsSpawnedTotal = metrics.NewCounter("mmo_sandbox_actors_spawned_total", "Actors spawned in the sandbox by GM command.")
+
+// One GM's time in the sandbox
+type sandboxVisit struct {
+	zone *gamedata.Zone
+
+	// The waves of scenarios they've run that are still to come
+	timers []*time.Timer
+}
+
+// Lets GMs test in a zone of their own, apart from the rest of the world, spawning waves of actors and running
+// scripted scenarios from the game data. Whoever's in the sandbox only sees others in it and the actors spawned there,
+// and nobody outside sees any of them. The actors a GM spawns are driven by them like a world simulator's, standing
+// still, so they're despawned once the GM leaves the sandbox or the game. Keeping what players do in the sandbox out of
+// their saves is left to their own state.
+type Sandbox struct {
+	hub *Hub
+
+	// By client ID
+	visits map[uint64]*sandboxVisit
+	mux    sync.Mutex
+}
+
+func NewSandbox(hub *Hub) *Sandbox {
+	return &Sandbox{hub: hub, visits: make(map[uint64]*sandboxVisit)}
+}
+
+// The first sandbox zone the check says is available, if any
+func (s *Sandbox) Zone(available func(zone *gamedata.Zone) bool) (*gamedata.Zone, bool) {
+	for _, zone := range s.hub.GameData.Zones {
+		if zone.Sandbox && available(zone) {
+			return zone, true
+		}
+	}
+	return nil, false
+}
+
+// Put the client in the sandbox zone, out of sight of everyone outside it
+func (s *Sandbox) Enter(clientId uint64, zone *gamedata.Zone) {
+	s.mux.Lock()
+	if _, inside := s.visits[clientId]; inside {
+		s.mux.Unlock()
+		return
+	}
+	s.visits[clientId] = &sandboxVisit{zone: zone}
+	s.mux.Unlock()
+
+	s.hub.Visibility.SetSandboxed(clientId, true)
+	s.separate(clientId, "Entered the sandbox")
+	logging.Hub.Printf("Client %d entered sandbox %s", clientId, zone.Id)
+}
+
+// Take the client out of the sandbox, despawning everything they spawned in it
+func (s *Sandbox) Leave(clientId uint64) {
+	if !s.forget(clientId) {
+		return
+	}
+	s.hub.Visibility.SetSandboxed(clientId, false)
+	s.separate(clientId, "Left the sandbox")
+	logging.Hub.Printf("Client %d left the sandbox", clientId)
+}
+
+// Forget the client's visit, if they were in the sandbox, despawning everything they spawned. Returns whether they
+// were.
+func (s *Sandbox) forget(clientId uint64) bool {
+	s.mux.Lock()
+	visit, inside := s.visits[clientId]
+	delete(s.visits, clientId)
+	s.mux.Unlock()
+	if !inside {
+		return false
+	}
+
+	for _, timer := range visit.timers {
+		timer.Stop()
+	}
+	s.despawn(clientId)
+	return true
+}
+
+// Forget the client's visit once they're gone
+func (s *Sandbox) Forget(clientId uint64) {
+	s.forget(clientId)
+}
+
+// The sandbox zone the client's in, if they're in one
+func (s *Sandbox) Inside(clientId uint64) (*gamedata.Zone, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	visit, inside := s.visits[clientId]
+	if !inside {
+		return nil, false
+	}
+	return visit.zone, true
+}
+
+// How many actors the client has spawned in the sandbox that are still there
+func (s *Sandbox) Actors(clientId uint64) int {
+	return len(s.hub.Simulators.drivenBy(clientId))
+}
+
+// Spawn the wave in a ring around the client's player. Returns how many were spawned.
+func (s *Sandbox) SpawnWave(clientId uint64, wave *gamedata.SandboxWave) (int, error) {
+	if _, inside := s.Inside(clientId); !inside {
+		return 0, ErrNotInSandbox
+	}
+	around, exists := s.hub.SharedGameObjects.Players.Get(clientId)
+	if !exists {
+		return 0, ErrNotInSandbox
+	}
+	if s.Actors(clientId)+wave.Count > maxSandboxActors {
+		return 0, ErrTooManySandboxActors
+	}
+
+	distance := around.Radius + wave.Distance + wave.Radius
+	actors := make([]*packets.ActorMessage, wave.Count)
+	for i := range actors {
+		angle := 2 * math.Pi * float64(i) / float64(wave.Count)
+		actors[i] = &packets.ActorMessage{
+			Name:   wave.Name,
+			X:      around.X + distance*math.Cos(angle),
+			Y:      around.Y + distance*math.Sin(angle),
+			Radius: wave.Radius,
+			Color:  wave.Color,
+		}
+	}
+
+	spawned := 0
+	for _, id := range s.hub.Simulators.spawn(clientId, actors, func(actorId uint64) {
+		s.hub.Visibility.SetSandboxed(actorId, true)
+	}) {
+		if id != 0 {
+			spawned++
+		}
+	}
+	sandboxActorsSpawnedTotal.Add(uint64(spawned))
+	return spawned, nil
+}
+
+// Spawn the scenario's waves around the client's player, each after its delay. Waves still to come are called off if
+// they leave the sandbox or clear it first.
+func (s *Sandbox) RunScenario(clientId uint64, scenario *gamedata.SandboxScenario) error {
+	s.mux.Lock()
+	visit, inside := s.visits[clientId]
+	if !inside {
+		s.mux.Unlock()
+		return ErrNotInSandbox
+	}
+	for _, wave := range scenario.Waves {
+		if wave.DelaySeconds == 0 {
+			continue
+		}
+		visit.timers = append(visit.timers, time.AfterFunc(time.Duration(wave.DelaySeconds*float64(time.Second)), func() {
+			if _, err := s.SpawnWave(clientId, wave); err != nil {
+				logging.Hub.Printf("Couldn't spawn a wave of scenario %s for client %d: %v", scenario.Id, clientId, err)
+			}
+		}))
+	}
+	s.mux.Unlock()
+
+	for _, wave := range scenario.Waves {
+		if wave.DelaySeconds > 0 {
+			continue
+		}
+		if _, err := s.SpawnWave(clientId, wave); err != nil {
+			return err
+		}
+	}
+	logging.Hub.Printf("Client %d ran sandbox scenario %s", clientId, scenario.Id)
+	return nil
+}
+
+// Despawn everything the client's spawned in the sandbox, and call off scenario waves still to come
+func (s *Sandbox) Clear(clientId uint64) {
+	s.mux.Lock()
+	visit, inside := s.visits[clientId]
+	if !inside {
+		s.mux.Unlock()
+		return
+	}
+	timers := visit.timers
+	visit.timers = nil
+	s.mux.Unlock()
+
+	for _, timer := range timers {
+		timer.Stop()
+	}
+	s.despawn(clientId)
+}
+
+// Despawn everything the client's spawned. Everyone in the sandbox is told straight away, since the actors are out of
+// it once they're gone, and so would be out of their sight by the time the hub broadcasts that they've gone.
+func (s *Sandbox) despawn(clientId uint64) {
+	actorIds := s.hub.Simulators.drivenBy(clientId)
+	s.hub.Simulators.DespawnAll(clientId)
+
+	s.mux.Lock()
+	visitorIds := append(slices.Collect(maps.Keys(s.visits)), clientId)
+	s.mux.Unlock()
+	for _, visitorId := range visitorIds {
+		visitor, exists := s.hub.Clients.Get(visitorId)
+		if !exists {
+			continue
+		}
+		for _, actorId := range actorIds {
+			visitor.ProcessMessage(actorId, packets.NewDisconnect("despawned"))
+		}
+	}
+}
+
+// Tell the client and everyone on the other side of the sandbox from them that the others are gone, since nothing
+// more is sent about them
+func (s *Sandbox) separate(clientId uint64, reason string) {
+	client, exists := s.hub.Clients.Get(clientId)
+	if !exists {
+		return
+	}
+	sandboxed := s.hub.Visibility.Sandboxed(clientId)
+	var apart []uint64
+	s.hub.SharedGameObjects.Players.ForEach(func(otherId uint64, _ *objects.Player) {
+		if otherId != clientId && s.hub.Visibility.Sandboxed(otherId) != sandboxed {
+			apart = append(apart, otherId)
+		}
+	})
+
+	for _, otherId := range apart {
+		client.ProcessMessage(otherId, packets.NewDisconnect(reason))
+		if other, exists := s.hub.Clients.Get(otherId); exists {
+			other.ProcessMessage(clientId, packets.NewDisconnect(reason))
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+)
+
+// How much is lost if a save isn't written, which decides how long it can be held back for
+type SaveTier int
+
+const (
+	// Changes all the time and losing the last few seconds of it costs little, e.g. where players are
+	SaveTierHot SaveTier = iota
+
+	// Changes now and then and players would notice it going missing, e.g. their best score
+	SaveTierWarm
+
+	// Hardly ever changes, e.g. tutorial progress
+	SaveTierCold
+
+	saveTierCount
+)
+
+func (t SaveTier) String() string {
+	switch t {
+	case SaveTierHot:
+		return "hot"
+	case SaveTierWarm:
+		return "warm"
+	case SaveTierCold:
+		return "cold"
+	}
+	return fmt.Sprintf("tier %d", int(t))
+}
+
+// The most saves held back in each tier. Past this, saves for anything not already held back are written with the next
+// batch of writes.
+const maxPendingSaves = 4096
+
+var (
+	savesWritten = metrics.NewCounterVec("mmo_saves_written_total", "Saves written to the database, by tier.", "tier")
+	savesDropped = metrics.NewCounterVec("mmo_saves_dropped_total", "Saves given up on after failing to be written, or rejected as stale, by tier.", "tier")
+)
+
+type SaveTierConfig struct {
+	// How often the tier's saves are written, or 0 to write each one with the next batch of writes, see WriteBatches
+	Interval time.Duration
+
+	// The most saves written in one transaction. Any more are written in more transactions, one after another.
+	MaxBatch int
+}
+
+type SaveTiers struct {
+	Hot  SaveTierConfig
+	Warm SaveTierConfig
+	Cold SaveTierConfig
+}
+
+var DefaultSaveTiers = SaveTiers{
+	Hot:  SaveTierConfig{Interval: 15 * time.Second, MaxBatch: 500},
+	Warm: SaveTierConfig{Interval: 2 * time.Second, MaxBatch: 100},
+	Cold: SaveTierConfig{Interval: time.Minute, MaxBatch: 100},
+}
+
+// Holds back saves that don't need writing straight away and writes them in batches, each tier as often as it's
+// configured to. Saves for the same thing replace each other while they're held back, so something that changes all
+// the time is only written as often as its tier is. Everything held back for a player is written when they leave the
+// game, and everything else before a restart, so a tier's interval is only what's lost if the server crashes.
+type Saves struct {
+	hub   *Hub
+	tiers [saveTierCount]*saveTier
+}
+
+type saveTier struct {
+	tier   SaveTier
+	config SaveTierConfig
+
+	// Saves held back, and the order they were first held back in
+	pending map[saveKey]*pendingSave
+	order   []saveKey
+	mux     sync.Mutex
+}
+
+type saveKey struct {
+	playerDbId int64
+	key        string
+}
+
+type pendingSave struct {
+	key      saveKey
+	write    func(ctx context.Context, queries *db.Queries) error
+	attempts int
+}
+
+func NewSaves(hub *Hub) *Saves {
+	s := &Saves{hub: hub}
+	for tier := range saveTierCount {
+		s.tiers[tier] = &saveTier{tier: tier, pending: make(map[saveKey]*pendingSave)}
+	}
+	s.Configure(DefaultSaveTiers)
+	return s
+}
+
+// Must be called before the hub is run
+func (s *Saves) Configure(tiers SaveTiers) {
+	s.tiers[SaveTierHot].config = tiers.Hot
+	s.tiers[SaveTierWarm].config = tiers.Warm
+	s.tiers[SaveTierCold].config = tiers.Cold
+	for _, tier := range s.tiers {
+		tier.config.MaxBatch = max(tier.config.MaxBatch, 1)
+	}
+}
+
+func (s *Saves) save(dbTx *DbTx, tier SaveTier, playerDbId int64, key string, write func(ctx context.Context, queries *db.Queries) error) error {
+	description := fmt.Sprintf("%s of player %d", key, playerDbId)
+	t := s.tiers[tier]
+	if t.config.Interval <= 0 {
+		return dbTx.Batch(description, write)
+	}
+
+	k := saveKey{playerDbId: playerDbId, key: key}
+	t.mux.Lock()
+	if pending, exists := t.pending[k]; exists {
+		pending.write = write
+		pending.attempts = 0
+		t.mux.Unlock()
+		return nil
+	}
+	if len(t.pending) < maxPendingSaves {
+		t.pending[k] = &pendingSave{key: k, write: write}
+		t.order = append(t.order, k)
+		t.mux.Unlock()
+		return nil
+	}
+	t.mux.Unlock()
+
+	return dbTx.Batch(description, write)
+}
+
+// Write everything held back for the player, e.g. as they leave the game
+func (s *Saves) FlushPlayer(playerDbId int64) {
+	for _, t := range s.tiers {
+		t.mux.Lock()
+		var batch []*pendingSave
+		remaining := t.order[:0]
+		for _, k := range t.order {
+			if k.playerDbId == playerDbId {
+				batch = append(batch, t.pending[k])
+				delete(t.pending, k)
+			} else {
+				remaining = append(remaining, k)
+			}
+		}
+		t.order = remaining
+		t.mux.Unlock()
+
+		if len(batch) > 0 {
+			s.writeBatch(t, batch)
+		}
+	}
+}
+
+// Write everything held back, e.g. before a restart
+func (s *Saves) FlushAll() {
+	for _, t := range s.tiers {
+		s.flush(t)
+	}
+}
+
+func (s *Saves) flushLoop(t *saveTier) {
+	if t.config.Interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if s.hub.dbHealth.Healthy() {
+			s.flush(t)
+		}
+	}
+}
+
+// Write the tier's held back saves a batch at a time, stopping if a batch can't be written
+func (s *Saves) flush(t *saveTier) {
+	for {
+		batch := t.take(t.config.MaxBatch)
+		if len(batch) == 0 || !s.writeBatch(t, batch) || len(batch) < t.config.MaxBatch {
+			return
+		}
+	}
+}
+
+// The oldest saves held back, up to the given number
+func (t *saveTier) take(n int) []*pendingSave {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	n = min(n, len(t.order))
+	batch := make([]*pendingSave, n)
+	for i, k := range t.order[:n] {
+		batch[i] = t.pending[k]
+		delete(t.pending, k)
+	}
+	t.order = t.order[n:]
+	return batch
+}
+
+// Hold back saves that couldn't be written to try again, unless they've been saved again since
+func (t *saveTier) putBack(saves []*pendingSave) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	keys := make([]saveKey, 0, len(saves))
+	for _, save := range saves {
+		if _, replaced := t.pending[save.key]; !replaced {
+			t.pending[save.key] = save
+			keys = append(keys, save.key)
+		}
+	}
+	t.order = append(keys, t.order...)
+}
+
+// Write the batch in one transaction. If that fails, each save is written on its own so one bad save doesn't hold
+// back the rest. Returns false if the database is unavailable, in which case the whole batch is held back again.
+func (s *Saves) writeBatch(t *saveTier, batch []*pendingSave) bool {
+	dbTx := s.hub.NewDbTx()
+	err := observeBatch(t.tier.String(), len(batch), func() error {
+		return dbTx.InTx(func(queries *db.Queries) error {
+			for _, save := range batch {
+				if err := save.write(dbTx.Ctx, queries); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err == nil {
+		savesWritten.With(t.tier.String()).Add(uint64(len(batch)))
+		return true
+	}
+	if errors.Is(err, ErrDbUnavailable) {
+		t.putBack(batch)
+		return false
+	}
+
+	var retry []*pendingSave
+	for _, save := range batch {
+		err := save.write(dbTx.Ctx, dbTx.Queries)
+		if errors.Is(err, ErrDbUnavailable) {
+			retry = append(retry, save)
+			continue
+		} else if err == nil {
+			savesWritten.With(t.tier.String()).Inc()
+			continue
+		} else if errors.Is(err, ErrStaleCharacter) {
+			savesDropped.With(t.tier.String()).Inc()
+			continue
+		}
+
+		save.attempts++
+		if save.attempts >= maxWriteBehindAttempts {
+			logging.Db.Printf("Giving up on %s save (%s of player %d) after %d attempts: %v", t.tier, save.key.key, save.key.playerDbId, save.attempts, err)
+			savesDropped.With(t.tier.String()).Inc()
+		} else {
+			retry = append(retry, save)
+		}
+	}
+	t.putBack(retry)
+	return true
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// What every seeded account can be logged in to with, unless seeded with another password
+const DefaultSeedPassword = "password"
+
+var (
+	seedMailSenders  = []string{"Postmaster", "Innkeeper", "Quartermaster", "Harbourmaster"}
+	seedMailSubjects = []string{"Welcome!", "A little something", "Your order", "Don't forget", "Thanks for playing"}
+)
+
+// How much fake data to fill a development database with. Seeding is rolling: accounts are named seed1, seed2 and so
+// on, and seeding again tops each of them up to these counts rather than making more, so it's safe to run after every
+// migration or every time a dev server starts.
+type SeedOptions struct {
+	Accounts int
+
+	// For each account, and each character's mailbox
+	Characters int
+	Mail       int
+
+	Password string
+}
+
+// Parse options like "accounts=200,characters=2,mail=5". Characters and mail are 1 and 0 if left out.
+func ParseSeedOptions(spec string) (SeedOptions, error) {
+	options := SeedOptions{Characters: 1, Password: DefaultSeedPassword}
+	for _, setting := range strings.Split(spec, ",") {
+		if setting = strings.TrimSpace(setting); setting == "" {
+			continue
+		}
+		name, value, found := strings.Cut(setting, "=")
+		if !found {
+			return options, fmt.Errorf("expected count=number, got %q", setting)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || count < 0 {
+			return options, fmt.Errorf("%s: invalid count %q", name, value)
+		}
+		switch strings.TrimSpace(name) {
+		case "accounts":
+			options.Accounts = count
+		case "characters":
+			options.Characters = count
+		case "mail":
+			options.Mail = count
+		default:
+			return options, fmt.Errorf("unknown count %q, expected accounts, characters or mail", name)
+		}
+	}
+	return options, nil
+}
+
+// What seeding made this time, which is nothing for whatever was already there
+type SeedResult struct {
+	Accounts   int
+	Characters int
+	Mail       int
+}
+
+// Fill the database with fake accounts, their characters and mail, through the same queries the server uses, so it's
+// always in step with the schema. Each account is seeded in its own transaction, with its own random source, so the
+// same options always seed the same characters however many times seeding was interrupted. Nothing is recorded in the
+// outbox, so webhooks don't hear about players who don't exist.
+func Seed(ctx context.Context, inTx func(fn func(queries *db.Queries) error) error, gameData *gamedata.GameData, options SeedOptions) (SeedResult, error) {
+	var result SeedResult
+	if options.Password == "" {
+		options.Password = DefaultSeedPassword
+	}
+
+	// Hashing is made slow on purpose, so every account shares the one hash rather than taking minutes for thousands
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(options.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return result, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	// Anything the world yields can turn up in inventories and mail
+	itemSet := make(map[string]struct{})
+	for _, kind := range gameData.ResourceNodeKinds {
+		for itemId := range kind.Yields {
+			itemSet[itemId] = struct{}{}
+		}
+	}
+	items := slices.Sorted(maps.Keys(itemSet))
+
+	for i := 1; i <= options.Accounts; i++ {
+		var accountResult SeedResult
+		err := inTx(func(queries *db.Queries) error {
+			accountResult = SeedResult{}
+			return seedAccount(ctx, queries, i, string(passwordHash), items, options, &accountResult)
+		})
+		if err != nil {
+			return result, fmt.Errorf("seed%d: %w", i, err)
+		}
+		result.Accounts += accountResult.Accounts
+		result.Characters += accountResult.Characters
+		result.Mail += accountResult.Mail
+	}
+	return result, nil
+}
+
+func seedAccount(ctx context.Context, queries *db.Queries, i int, passwordHash string, items []string, options SeedOptions, result *SeedResult) error {
+	random := rand.New(rand.NewPCG(uint64(i), 0x5eed))
+	username := fmt.Sprintf("seed%d", i)
+
+	user, err := queries.GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		user, err = queries.CreateUser(ctx, db.CreateUserParams{Username: username, PasswordHash: passwordHash})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		result.Accounts++
+	} else if err != nil {
+		return err
+	}
+
+	characters, err := queries.CountPlayersByUserId(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for c := int(characters) + 1; c <= options.Characters; c++ {
+		name := fmt.Sprintf("Seed%d", i)
+		if c > 1 {
+			name = fmt.Sprintf("Seed%d_%d", i, c)
+		}
+		player, err := queries.CreatePlayer(ctx, db.CreatePlayerParams{
+			UserID: user.ID,
+			Name:   name,
+			Color:  random.Int64N(0x1000000),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create player %s: %w", name, err)
+		}
+		result.Characters++
+
+		// Most players score little and a few a lot, as on a real hiscore board
+		bestScore := int64(random.ExpFloat64() * 500)
+		if err := queries.UpdatePlayerBestScore(ctx, db.UpdatePlayerBestScoreParams{BestScore: bestScore, ID: player.ID}); err != nil {
+			return err
+		}
+		for _, itemId := range items {
+			if random.IntN(2) == 0 {
+				continue
+			}
+			err := queries.AddInventoryItem(ctx, db.AddInventoryItemParams{
+				PlayerID: player.ID,
+				ItemID:   itemId,
+				Quantity: 1 + random.Int64N(50),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	players, err := queries.GetPlayersByUserId(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, player := range players {
+		mail, err := queries.GetMail(ctx, player.ID)
+		if err != nil {
+			return err
+		}
+		for m := len(mail); m < options.Mail; m++ {
+			params := db.CreateMailParams{
+				PlayerID: player.ID,
+				Sender:   seedMailSenders[random.IntN(len(seedMailSenders))],
+				Subject:  seedMailSubjects[random.IntN(len(seedMailSubjects))],
+				SentAt:   time.Now().Add(-time.Duration(random.Int64N(int64(30 * 24 * time.Hour)))).UnixMilli(),
+			}
+			if len(items) > 0 && random.IntN(2) == 0 {
+				params.ItemID = items[random.IntN(len(items))]
+				params.Quantity = 1 + random.Int64N(10)
+			}
+			if err := queries.CreateMail(ctx, params); err != nil {
+				return err
+			}
+			result.Mail++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"net/netip"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	// How long sessions are kept once they've ended, for players to look back on
+	sessionHistory = 30 * 24 * time.Hour
+
+	// The most sessions sent to a player at once
+	maxListedSessions = 20
+
+	// The most of a client's user agent kept as its device
+	maxSessionDevice = 200
+
+	// How much of each IP is kept, which is enough for players to recognise where they logged in from, but not who by
+	sessionIpv4Bits = 24
+	sessionIpv6Bits = 48
+)
+
+var newLoginsTotal = metrics.NewCounter("mmo_new_logins_total", "Logins from an IP or device the account hadn't logged in from before.")
+
+// A session still logged in on this server
+type activeSession struct {
+	userId   int64
+	clientId uint64
+	session  db.Session
+}
+
+// Keeps track of each time an account's logged in, from where and on what, so players can see where they're logged in
+// and log out anywhere they don't recognise. The account's other sessions are told as soon as it's logged in from an
+// IP or device it hasn't been before.
+type Sessions struct {
+	hub *Hub
+
+	// By session ID, and their IDs by client ID
+	active   map[int64]*activeSession
+	byClient map[uint64]int64
+	mux      sync.Mutex
+}
+
+func NewSessions(hub *Hub) *Sessions {
+	return &Sessions{
+		hub:      hub,
+		active:   make(map[int64]*activeSession),
+		byClient: make(map[uint64]int64),
+	}
+}
+
+// End whatever sessions were left open when the server last stopped, since none of them can still be logged in, and
+// forget those that ended too long ago. Any kept with the whole of their IP, from before only networks were, are cut
+// down to theirs. Called as the hub starts.
+func (s *Sessions) endStale(ctx context.Context, queries *db.Queries) error {
+	now := time.Now()
+	if err := queries.EndOpenSessions(ctx, sql.NullInt64{Int64: now.UnixMilli(), Valid: true}); err != nil {
+		return err
+	}
+	if err := queries.DeleteSessionsEndedBefore(ctx, sql.NullInt64{Int64: now.Add(-sessionHistory).UnixMilli(), Valid: true}); err != nil {
+		return err
+	}
+
+	ips, err := queries.GetSessionIps(ctx)
+	if err != nil {
+		return err
+	}
+	for _, ip := range ips {
+		if network := ipNetwork(ip); network != ip {
+			if err := queries.SetSessionIp(ctx, db.SetSessionIpParams{Network: network, Ip: ip}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Start a session for the client that's just logged in to the account. If it's from an IP or device the account
+// hasn't logged in from before, the account's other sessions are told, and so is the outbox.
+func (s *Sessions) Start(ctx context.Context, queries *db.Queries, client ClientInterfacer, userId int64, clientBuild uint32) (db.Session, error) {
+	ip := ipNetwork(client.RemoteIp())
+	device := client.Device()
+	if len(device) > maxSessionDevice {
+		device = device[:maxSessionDevice]
+	}
+
+	seen, err := queries.CountSessionsFrom(ctx, db.CountSessionsFromParams{Ip: ip, Device: device, UserID: userId})
+	if err != nil {
+		return db.Session{}, err
+	}
+	session, err := queries.CreateSession(ctx, db.CreateSessionParams{
+		UserID:      userId,
+		Ip:          ip,
+		Device:      device,
+		ClientBuild: int64(clientBuild),
+		LoggedInAt:  time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return db.Session{}, err
+	}
+
+	s.mux.Lock()
+	s.active[session.ID] = &activeSession{userId: userId, clientId: client.Id(), session: session}
+	s.byClient[client.Id()] = session.ID
+	others := s.othersLocked(userId, session.ID)
+	s.mux.Unlock()
+
+	// An account's first session is from somewhere new by definition, so there's nothing to warn about
+	newIp, newDevice := seen.FromIp == 0, seen.FromDevice == 0
+	if seen.Sessions == 0 || !newIp && !newDevice {
+		return session, nil
+	}
+	newLoginsTotal.Inc()
+	logging.Hub.Printf("User %d logged in from a new IP or device (session %d)", userId, session.ID)
+
+	err = client.DbTx().RecordEvent(queries, "account.new_login", map[string]any{
+		"user_id":    userId,
+		"session_id": session.ID,
+		"ip":         ip,
+		"device":     device,
+		"new_ip":     newIp,
+		"new_device": newDevice,
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error recording new login of user %d: %v", userId, err)
+	}
+
+	notification := packets.NewNewLogin(sessionMessage(session, false), newIp, newDevice)
+	for _, other := range others {
+		if otherClient, exists := s.hub.Clients.Get(other.clientId); exists {
+			otherClient.SocketSend(notification)
+		}
+	}
+	return session, nil
+}
+
+// The account's other sessions still logged in here. Must be called with the lock held.
+func (s *Sessions) othersLocked(userId int64, sessionId int64) []*activeSession {
+	var others []*activeSession
+	for id, active := range s.active {
+		if active.userId == userId && id != sessionId {
+			others = append(others, active)
+		}
+	}
+	return others
+}
+
+// End the client's session, if it had one, once it's gone
+func (s *Sessions) End(clientId uint64) {
+	s.mux.Lock()
+	sessionId, exists := s.byClient[clientId]
+	if exists {
+		delete(s.byClient, clientId)
+		delete(s.active, sessionId)
+	}
+	s.mux.Unlock()
+	if !exists {
+		return
+	}
+
+	endedAt := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+	err := s.hub.NewDbTx().Batch("session end", func(ctx context.Context, queries *db.Queries) error {
+		return queries.EndSession(ctx, db.EndSessionParams{EndedAt: endedAt, ID: sessionId})
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error ending session %d: %v", sessionId, err)
+	}
+}
+
+// How many sessions are logged in here, which is how many players are online
+func (s *Sessions) Count() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.active)
+}
+
+// The account's sessions lately, most recent first, with the client's own marked as current
+func (s *Sessions) List(ctx context.Context, queries *db.Queries, client ClientInterfacer, userId int64) ([]*packets.SessionMessage, error) {
+	rows, err := queries.GetRecentSessions(ctx, db.GetRecentSessionsParams{
+		UserID:  userId,
+		EndedAt: sql.NullInt64{Int64: time.Now().Add(-sessionHistory).UnixMilli(), Valid: true},
+		Limit:   maxListedSessions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mux.Lock()
+	current := s.byClient[client.Id()]
+	s.mux.Unlock()
+
+	sessions := make([]*packets.SessionMessage, len(rows))
+	for i, row := range rows {
+		sessions[i] = sessionMessage(row, row.ID == current)
+	}
+	return sessions, nil
+}
+
+// Log out the account's session, or all of its sessions but the client's own, kicking whoever's logged in with them.
+// Sessions that aren't the account's, or have already ended, are left alone. Returns how many were logged out.
+func (s *Sessions) LogOut(ctx context.Context, queries *db.Queries, client ClientInterfacer, userId int64, sessionId int64, allOthers bool) (int, error) {
+	s.mux.Lock()
+	current := s.byClient[client.Id()]
+	var loggedOut []*activeSession
+	for _, other := range s.othersLocked(userId, current) {
+		if allOthers || other.session.ID == sessionId {
+			loggedOut = append(loggedOut, other)
+			delete(s.active, other.session.ID)
+			delete(s.byClient, other.clientId)
+		}
+	}
+	s.mux.Unlock()
+
+	// Ended straight away rather than once they're gone, so they're already shown as ended
+	endedAt := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+	for _, other := range loggedOut {
+		if err := queries.EndSession(ctx, db.EndSessionParams{EndedAt: endedAt, ID: other.session.ID}); err != nil {
+			return 0, err
+		}
+		if otherClient, exists := s.hub.Clients.Get(other.clientId); exists {
+			go otherClient.Kick(KickLoggedOut, "Logged out from another session")
+		}
+	}
+	if len(loggedOut) > 0 {
+		logging.Hub.Printf("User %d logged out %d of their other sessions", userId, len(loggedOut))
+	}
+	return len(loggedOut), nil
+}
+
+// The network the IP's on, e.g. 203.0.113.0/24, since that's all that's kept of where sessions are from. Anything that
+// isn't an IP, e.g. from a client that isn't connected over the network, is kept as nothing, and a network as it is.
+func ipNetwork(ip string) string {
+	if prefix, err := netip.ParsePrefix(ip); err == nil {
+		return prefix.Masked().String()
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := sessionIpv6Bits
+	if addr.Is4() {
+		bits = sessionIpv4Bits
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
+
+func sessionMessage(session db.Session, current bool) *packets.SessionMessage {
+	return &packets.SessionMessage{
+		Id:          session.ID,
+		Ip:          session.Ip,
+		Device:      session.Device,
+		ClientBuild: uint32(session.ClientBuild),
+		LoggedInAt:  session.LoggedInAt,
+		EndedAt:     session.EndedAt.Int64,
+		Current:     current,
+	}
+}
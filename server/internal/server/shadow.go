@@ -0,0 +1,284 @@
+package server
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime/debug"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// The share of clients shadowed in each state with a shadow registered, unless configured otherwise
+const DefaultShadowFraction = 0.05
+
+var (
+	shadowMessagesTotal    = metrics.NewCounterVec("mmo_shadow_messages_total", "Messages mirrored to a shadow state handler.", "state")
+	shadowDivergencesTotal = metrics.NewCounterVec("mmo_shadow_divergences_total", "Messages a shadow state handler answered differently from the current one.", "state")
+	shadowPanicsTotal      = metrics.NewCounterVec("mmo_shadow_panics_total", "Shadow state handlers that panicked and were stopped.", "state")
+)
+
+// Makes the shadow of a state from the state it's shadowing, which has already been entered
+type ShadowFactory func(current ClientStateHandler) ClientStateHandler
+
+// Runs new implementations of states alongside the current ones to vet them on real traffic. A shadow is handed each
+// message its client's current state is, and whatever the two send in answer is compared, with any difference logged
+// and counted. Nothing the shadow sends goes anywhere.
+//
+// Only what's sent is kept from going anywhere, so shadows must leave the world and the database alone: they see the
+// same hub as the client, and anything they change through it is changed for real. For the same reason a shadow isn't
+// entered or exited, so that it doesn't add the player to the world a second time; its factory should set it up from
+// the state it's shadowing instead.
+type Shadows struct {
+	factories map[string]ShadowFactory
+	fraction  float64
+	mux       sync.RWMutex
+}
+
+func NewShadows() *Shadows {
+	return &Shadows{
+		factories: make(map[string]ShadowFactory),
+		fraction:  DefaultShadowFraction,
+	}
+}
+
+// Must be called before the hub is run. A fraction of 0 keeps the default.
+func (s *Shadows) Configure(fraction float64) {
+	if fraction > 0 {
+		s.fraction = min(fraction, 1)
+	}
+}
+
+// Shadow the state with the given name with what the factory makes, for some of the clients entering it. Replaces any
+// shadow already registered for the state.
+func (s *Shadows) Register(stateName string, factory ShadowFactory) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.factories[stateName] = factory
+}
+
+// Make a shadow for the client's new state, if the state has one registered and the client is picked to be
+// shadowed. Returns nil otherwise.
+func (s *Shadows) Start(client ClientInterfacer, state ClientStateHandler) *Shadow {
+	if state == nil {
+		return nil
+	}
+
+	s.mux.RLock()
+	factory, exists := s.factories[state.Name()]
+	s.mux.RUnlock()
+	if !exists || rand.Float64() >= s.fraction {
+		return nil
+	}
+
+	shadow := &Shadow{stateName: state.Name()}
+	shadowed := &shadowClient{ClientInterfacer: client, shadow: shadow}
+	shadowed.prompts = NewPrompts(shadowed.SocketSend)
+	shadow.client = shadowed
+	if !shadow.guard(func() { shadow.handler = factory(state) }) || shadow.handler == nil {
+		return nil
+	}
+	if !shadow.guard(func() { shadow.handler.SetClient(shadow.client) }) {
+		return nil
+	}
+	logging.Hub.Printf("Client %d: shadowing state %s with %T", client.Id(), state.Name(), shadow.handler)
+	return shadow
+}
+
+// Something a state did in answer to a message that the client or other clients would see
+type shadowOutput struct {
+	// One of send, pass, broadcast, state or close
+	action string
+
+	// Who a message was sent as or passed to, if anyone
+	target uint64
+
+	message packets.Msg
+
+	// The state switched to, or why the client was closed
+	detail string
+}
+
+func (o shadowOutput) equal(other shadowOutput) bool {
+	if o.action != other.action || o.target != other.target || o.detail != other.detail {
+		return false
+	}
+	if o.message == nil || other.message == nil {
+		return o.message == nil && other.message == nil
+	}
+	return proto.Equal(&packets.Packet{Msg: o.message}, &packets.Packet{Msg: other.message})
+}
+
+func (o shadowOutput) String() string {
+	switch {
+	case o.message != nil && o.target != 0:
+		return fmt.Sprintf("%s %T (%d)", o.action, o.message, o.target)
+	case o.message != nil:
+		return fmt.Sprintf("%s %T", o.action, o.message)
+	default:
+		return fmt.Sprintf("%s %s", o.action, o.detail)
+	}
+}
+
+// The shadow of one client's state
+type Shadow struct {
+	stateName string
+	handler   ClientStateHandler
+	client    *shadowClient
+
+	// What the current state and the shadow have done since the message being mirrored came in
+	recording bool
+	current   []shadowOutput
+	shadowed  []shadowOutput
+	mux       sync.Mutex
+
+	// Set once the shadow has panicked, after which it's no longer handed anything
+	stopped atomic.Bool
+}
+
+// Note something the client did for its current state. Only kept while a message is being mirrored.
+func (s *Shadow) Record(action string, target uint64, message packets.Msg, detail string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.recording {
+		s.current = append(s.current, shadowOutput{action, target, message, detail})
+	}
+}
+
+// Have the current state handle the message, then hand it to the shadow and compare what each did. Anything the
+// current state sends from elsewhere while handling it, e.g. from a loop of its own, is counted with what it did, so
+// states sending a lot in the background can show differences that aren't there.
+func (s *Shadow) Mirror(senderId uint64, message packets.Msg, handle func()) {
+	if s.stopped.Load() {
+		handle()
+		return
+	}
+
+	// A message the state sends itself while handling another is part of handling that one, and the shadow handles
+	// its own copy
+	s.mux.Lock()
+	if s.recording {
+		s.mux.Unlock()
+		handle()
+		return
+	}
+	s.recording = true
+	s.current, s.shadowed = nil, nil
+	s.mux.Unlock()
+
+	handle()
+
+	s.mux.Lock()
+	s.recording = false
+	s.mux.Unlock()
+
+	shadowMessagesTotal.With(s.stateName).Inc()
+	if !s.guard(func() { s.handler.HandleMessage(senderId, message) }) {
+		return
+	}
+
+	s.mux.Lock()
+	current, shadowed := s.current, s.shadowed
+	s.current, s.shadowed = nil, nil
+	s.mux.Unlock()
+
+	if diverged(current, shadowed) {
+		shadowDivergencesTotal.With(s.stateName).Inc()
+		logging.Hub.Printf("Client %d: shadow of state %s diverged handling %T from %d: current did [%s], shadow did [%s]",
+			s.client.Id(), s.stateName, message, senderId, describeOutputs(current), describeOutputs(shadowed))
+	}
+}
+
+// Run something of the shadow's, stopping the shadow if it panics rather than taking the client down with it.
+// Returns whether it ran to the end.
+func (s *Shadow) guard(fn func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.stopped.Store(true)
+			shadowPanicsTotal.With(s.stateName).Inc()
+			logging.Hub.Printf("Shadow of state %s panicked, no longer shadowing it: %v\n%s", s.stateName, r, debug.Stack())
+			ok = false
+		}
+	}()
+	fn()
+	return true
+}
+
+func (s *Shadow) recordShadowed(output shadowOutput) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.shadowed = append(s.shadowed, output)
+}
+
+func diverged(current []shadowOutput, shadowed []shadowOutput) bool {
+	if len(current) != len(shadowed) {
+		return true
+	}
+	for i := range current {
+		if !current[i].equal(shadowed[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func describeOutputs(outputs []shadowOutput) string {
+	descriptions := make([]string, len(outputs))
+	for i, output := range outputs {
+		descriptions[i] = output.String()
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// The client as a shadow sees it, where anything that would be seen by the client or another is noted instead
+type shadowClient struct {
+	ClientInterfacer
+	shadow *Shadow
+
+	// Of its own, so they're noted rather than put to the player. The player's answers to theirs are mirrored to the
+	// shadow, and have the same IDs as long as the shadow asks the same as the state.
+	prompts *Prompts
+}
+
+func (c *shadowClient) Prompts() *Prompts {
+	return c.prompts
+}
+
+func (c *shadowClient) SocketSend(message packets.Msg) {
+	c.SocketSendAs(message, c.Id())
+}
+
+func (c *shadowClient) SocketSendAs(message packets.Msg, senderId uint64) {
+	c.shadow.recordShadowed(shadowOutput{action: "send", target: senderId, message: message})
+}
+
+func (c *shadowClient) PassToPeer(message packets.Msg, peerId uint64) {
+	c.shadow.recordShadowed(shadowOutput{action: "pass", target: peerId, message: message})
+}
+
+func (c *shadowClient) Broadcast(message packets.Msg) {
+	c.shadow.recordShadowed(shadowOutput{action: "broadcast", message: message})
+}
+
+func (c *shadowClient) SetState(newState ClientStateHandler) {
+	name := "None"
+	if newState != nil {
+		name = newState.Name()
+	}
+	c.shadow.recordShadowed(shadowOutput{action: "state", detail: name})
+}
+
+func (c *shadowClient) ProcessMessage(senderId uint64, message packets.Msg) {
+	c.shadow.handler.HandleMessage(senderId, message)
+}
+
+func (c *shadowClient) ProcessSocketData(_ []byte) {}
+
+func (c *shadowClient) Close(reason string) {
+	c.shadow.recordShadowed(shadowOutput{action: "close", detail: reason})
+}
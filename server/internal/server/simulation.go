@@ -0,0 +1,352 @@
+package server
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"math"
+	"os"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"slices"
+	"sync"
+	"time"
+)
+
+// What can happen to a client between ticks of a deterministic simulation
+const (
+	SimulationConnect    = "connect"
+	SimulationData       = "data"
+	SimulationDisconnect = "disconnect"
+)
+
+// Something that happened to a client, recorded against the tick it was applied in
+type RecordedEvent struct {
+	Kind     string `json:"kind"`
+	ClientId uint64 `json:"client"`
+
+	// What the client sent, for data
+	Data []byte `json:"data,omitempty"`
+
+	// Why the client went, for disconnects
+	Reason string `json:"reason,omitempty"`
+}
+
+type RecordedTick struct {
+	Tick   uint64          `json:"tick"`
+	Events []RecordedEvent `json:"events,omitempty"`
+
+	// Of the world once the tick was over
+	Checksum string `json:"checksum"`
+}
+
+// Everything needed to play a deterministic simulation over again: the seed it ran from and the node it handed out
+// IDs as, then every tick
+type Recording struct {
+	Seed   uint64
+	IdNode uint64
+	Ticks  []RecordedTick
+}
+
+type recordingHeader struct {
+	Seed   uint64 `json:"seed"`
+	IdNode uint64 `json:"id_node"`
+}
+
+// Read a recording written by a simulation configured to record, which is its header then a line for each tick
+func LoadRecording(path string) (*Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(file))
+	var header recordingHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, fmt.Errorf("error reading header: %w", err)
+	}
+	recording := &Recording{Seed: header.Seed, IdNode: header.IdNode}
+	for decoder.More() {
+		var tick RecordedTick
+		if err := decoder.Decode(&tick); err != nil {
+			return nil, fmt.Errorf("error reading tick %d: %w", len(recording.Ticks)+1, err)
+		}
+		recording.Ticks = append(recording.Ticks, tick)
+	}
+	return recording, nil
+}
+
+// Where a replay stopped evolving the same way as the recording
+type ReplayDivergence struct {
+	Tick     uint64
+	Expected string
+	Got      string
+}
+
+func (d *ReplayDivergence) Error() string {
+	return fmt.Sprintf("diverged at tick %d: expected %s, got %s", d.Tick, d.Expected, d.Got)
+}
+
+type simulationEvent struct {
+	kind   string
+	client ClientInterfacer
+	data   []byte
+	reason string
+}
+
+// Runs the world deterministically, for regression testing gameplay by replaying recorded inputs. Nothing is left to
+// the wall clock, goroutines or map order: clients connect, send and disconnect only between ticks, in the order they
+// did so, and each tick players move, spores are replenished and resource nodes respawn one after the other, in
+// order of ID. Randomness comes from the seed, and the time from a clock that moves on a tick at a time. So the same
+// seed and inputs always make the same world, which is checked against the recording after every tick.
+type Simulation struct {
+	hub *Hub
+
+	tick uint64
+
+	// Received since the last tick
+	events    []simulationEvent
+	eventsMux sync.Mutex
+
+	// Work done each tick, by client ID
+	tickers    map[uint64]func()
+	tickersMux sync.Mutex
+
+	recorder *json.Encoder
+	recordTo *bufio.Writer
+	file     *os.File
+
+	replay    *Recording
+	newClient func() ClientInterfacer
+	replayed  chan error
+}
+
+func NewSimulation(hub *Hub) *Simulation {
+	return &Simulation{
+		hub:      hub,
+		tickers:  make(map[uint64]func()),
+		replayed: make(chan error, 1),
+	}
+}
+
+// Must be called before the hub is run, to run the world deterministically from the seed. Every tick is recorded to
+// the path, if there is one, for replaying later.
+func (s *Simulation) Configure(seed uint64, recordPath string) error {
+	if recordPath != "" {
+		file, err := os.Create(recordPath)
+		if err != nil {
+			return err
+		}
+		s.file = file
+		s.recordTo = bufio.NewWriter(file)
+		s.recorder = json.NewEncoder(s.recordTo)
+		if err := s.recorder.Encode(recordingHeader{Seed: seed, IdNode: objects.Ids.Node()}); err != nil {
+			return err
+		}
+		logging.Hub.Printf("Recording the simulation to %s", recordPath)
+	}
+
+	objects.Sim.MakeDeterministic(seed)
+	logging.Hub.Printf("Running the simulation deterministically from seed %d", seed)
+	return nil
+}
+
+// Must be called before the hub is run, to play the recording over instead of ticking in real time, with clients
+// made by the function connecting where the recording's did. IDs must be handed out as the recording's node. How it
+// went is sent on Replayed once it's over.
+func (s *Simulation) ConfigureReplay(recording *Recording, newClient func() ClientInterfacer) {
+	s.replay = recording
+	s.newClient = newClient
+	objects.Sim.MakeDeterministic(recording.Seed)
+	logging.Hub.Printf("Replaying %d ticks from seed %d", len(recording.Ticks), recording.Seed)
+}
+
+func (s *Simulation) Deterministic() bool {
+	return objects.Sim.Deterministic()
+}
+
+// Nil once a replay has evolved the world the same way as its recording, or the ReplayDivergence where it didn't
+func (s *Simulation) Replayed() <-chan error {
+	return s.replayed
+}
+
+// Handle what the client sent on the next tick
+func (s *Simulation) Receive(client ClientInterfacer, data []byte) {
+	s.queue(simulationEvent{kind: SimulationData, client: client, data: data})
+}
+
+// Close the client on the next tick
+func (s *Simulation) Disconnect(client ClientInterfacer, reason string) {
+	s.queue(simulationEvent{kind: SimulationDisconnect, client: client, reason: reason})
+}
+
+// Do the work every tick from now on, until the returned function is called. Work for a client is done in order of
+// its ID.
+func (s *Simulation) EveryTick(clientId uint64, work func()) (stop func()) {
+	s.tickersMux.Lock()
+	defer s.tickersMux.Unlock()
+	s.tickers[clientId] = work
+	return func() {
+		s.tickersMux.Lock()
+		defer s.tickersMux.Unlock()
+		delete(s.tickers, clientId)
+	}
+}
+
+func (s *Simulation) connect(client ClientInterfacer) {
+	s.queue(simulationEvent{kind: SimulationConnect, client: client})
+}
+
+func (s *Simulation) queue(event simulationEvent) {
+	s.eventsMux.Lock()
+	defer s.eventsMux.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *Simulation) tickLoop() {
+	if s.replay != nil {
+		s.replayed <- s.runReplay()
+		return
+	}
+
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.eventsMux.Lock()
+		events := s.events
+		s.events = nil
+		s.eventsMux.Unlock()
+
+		tick := s.step(events)
+		if s.recorder != nil {
+			s.record(tick)
+		}
+	}
+}
+
+func (s *Simulation) record(tick RecordedTick) {
+	err := s.recorder.Encode(tick)
+	if err == nil {
+		err = s.recordTo.Flush()
+	}
+	if err != nil {
+		logging.Hub.Errorf("Error recording tick %d, no longer recording: %v", tick.Tick, err)
+		s.recorder = nil
+		s.file.Close()
+	}
+}
+
+func (s *Simulation) runReplay() error {
+	// By the IDs they had in the recording, which they should have again
+	clients := make(map[uint64]ClientInterfacer)
+
+	for _, recorded := range s.replay.Ticks {
+		events := make([]simulationEvent, len(recorded.Events))
+		for i, event := range recorded.Events {
+			events[i] = simulationEvent{kind: event.Kind, data: event.Data, reason: event.Reason}
+			if event.Kind == SimulationConnect {
+				clients[event.ClientId] = s.newClient()
+			}
+			client, exists := clients[event.ClientId]
+			if !exists {
+				return fmt.Errorf("tick %d: client %d never connected", recorded.Tick, event.ClientId)
+			}
+			events[i].client = client
+		}
+
+		tick := s.step(events)
+		for i, event := range tick.Events {
+			if event.ClientId != recorded.Events[i].ClientId {
+				expected := fmt.Sprintf("client %d", recorded.Events[i].ClientId)
+				return &ReplayDivergence{Tick: tick.Tick, Expected: expected, Got: fmt.Sprintf("client %d", event.ClientId)}
+			}
+		}
+		if tick.Checksum != recorded.Checksum {
+			return &ReplayDivergence{Tick: tick.Tick, Expected: recorded.Checksum, Got: tick.Checksum}
+		}
+	}
+
+	if len(s.replay.Ticks) == 0 {
+		return errors.New("recording has no ticks")
+	}
+	return nil
+}
+
+// Ticks between each time spores are replenished and resource nodes respawn, as their loops otherwise would
+var (
+	sporeReplenishTicks      = uint64(sporeReplenishInterval / TickInterval)
+	resourceNodeRespawnTicks = uint64(resourceNodeRespawnInterval / TickInterval)
+)
+
+func (s *Simulation) step(events []simulationEvent) RecordedTick {
+	done := s.hub.Watchdog.Track("simulation tick")
+	defer done()
+
+	s.tick++
+	objects.Sim.Advance(TickInterval)
+	tick := RecordedTick{Tick: s.tick, Events: make([]RecordedEvent, len(events))}
+
+	for i, event := range events {
+		switch event.kind {
+		case SimulationConnect:
+			event.client.Initialize(s.hub.Clients.Add(event.client))
+		case SimulationData:
+			event.client.ProcessSocketData(event.data)
+		case SimulationDisconnect:
+			event.client.Close(event.reason)
+		}
+		tick.Events[i] = RecordedEvent{Kind: event.kind, ClientId: event.client.Id(), Data: event.data, Reason: event.reason}
+	}
+
+	s.tickersMux.Lock()
+	tickers := maps.Clone(s.tickers)
+	s.tickersMux.Unlock()
+	for _, clientId := range slices.Sorted(maps.Keys(tickers)) {
+		tickers[clientId]()
+	}
+
+	if s.tick%sporeReplenishTicks == 0 {
+		s.hub.replenishSpores()
+	}
+	if s.tick%resourceNodeRespawnTicks == 0 {
+		s.hub.respawnResourceNodes(objects.Sim.Now())
+	}
+
+	tick.Checksum = s.checksum()
+	return tick
+}
+
+// A hash of everything in the world and where it is, which is the same for worlds that evolved the same way
+func (s *Simulation) checksum() string {
+	hash := fnv.New64a()
+	write := func(id uint64, values ...float64) {
+		buf := binary.BigEndian.AppendUint64(nil, id)
+		for _, value := range values {
+			buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(value))
+		}
+		hash.Write(buf)
+	}
+
+	world := s.hub.SharedGameObjects
+	world.Players.ForEach(func(id uint64, player *objects.Player) {
+		write(id, player.X, player.Y, player.Radius, player.Direction)
+	})
+	world.Spores.ForEach(func(id uint64, spore *objects.Spore) {
+		write(id, spore.X, spore.Y, spore.Radius)
+	})
+	world.ResourceNodes.ForEach(func(id uint64, node *objects.ResourceNode) {
+		available := 0.0
+		if node.Available() {
+			available = 1
+		}
+		write(id, node.X, node.Y, available)
+	})
+	return hex.EncodeToString(hash.Sum(nil))
+}
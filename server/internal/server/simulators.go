@@ -0,0 +1,198 @@
+package server
+
+import (
+	"crypto/subtle"
+	"maps"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+)
+
+// The most actors one world simulator can drive at once
+const MaxSimulatorActors = 10000
+
+var (
+	simulatorActors            = metrics.NewGauge("mmo_simulator_actors", "Actors driven by world simulators.")
+	simulatorActorUpdatesTotal = metrics.NewCounter("mmo_simulator_actor_updates_total", "Actor updates applied from world simulators.")
+)
+
+// Trusted clients which drive many actors at once, e.g. separate processes running AI or physics, which log in with a
+// shared secret rather than an account. Their actors are players as far as everyone else is concerned, except that
+// they're server-authoritative: the simulator driving one decides where it is and what happens to it, without the
+// checks player clients' claims go through, and player clients can't claim anything of it.
+type Simulators struct {
+	hub    *Hub
+	secret string
+
+	// The actors each simulator drives, and which simulator drives each actor
+	actors map[uint64]map[uint64]struct{}
+	owners map[uint64]uint64
+	mux    sync.RWMutex
+}
+
+func NewSimulators(hub *Hub) *Simulators {
+	return &Simulators{
+		hub:    hub,
+		actors: make(map[uint64]map[uint64]struct{}),
+		owners: make(map[uint64]uint64),
+	}
+}
+
+// Let world simulators log in with the secret. They can't at all without one.
+func (s *Simulators) Configure(secret string) {
+	s.secret = secret
+	if secret != "" {
+		logging.Hub.Printf("World simulators can log in")
+	}
+}
+
+// Whether the secret is the one world simulators log in with
+func (s *Simulators) Authenticate(secret string) bool {
+	return s.secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(s.secret)) == 1
+}
+
+// Spawn the actors for the simulator to drive, returning their IDs in the same order. Those past how many it can drive
+// at once aren't spawned, and their IDs are 0.
+func (s *Simulators) Spawn(simulatorId uint64, actors []*packets.ActorMessage) []uint64 {
+	return s.spawn(simulatorId, actors, nil)
+}
+
+// Like Spawn, but with each actor prepared before anyone's told about it, e.g. to set who can see it
+func (s *Simulators) spawn(simulatorId uint64, actors []*packets.ActorMessage, prepare func(actorId uint64)) []uint64 {
+	ids := make([]uint64, len(actors))
+	var spawned []uint64
+
+	s.mux.Lock()
+	driving := s.actors[simulatorId]
+	if driving == nil {
+		driving = make(map[uint64]struct{})
+		s.actors[simulatorId] = driving
+	}
+	for i, actor := range actors {
+		if len(driving) >= MaxSimulatorActors {
+			break
+		}
+		player := &objects.Player{Name: actor.Name, Color: actor.Color}
+		applyActor(player, actor)
+		id := s.hub.SharedGameObjects.Players.Add(player)
+		if err := s.hub.WorldTags.Tag(ObjectActor, id, actor.Tags...); err != nil {
+			logging.Hub.Printf("Spawned actor %d for simulator %d without its tags: %v", id, simulatorId, err)
+		}
+		if prepare != nil {
+			prepare(id)
+		}
+		driving[id] = struct{}{}
+		s.owners[id] = simulatorId
+		ids[i] = id
+		spawned = append(spawned, id)
+	}
+	s.mux.Unlock()
+
+	simulatorActors.Add(float64(len(spawned)))
+	for _, id := range spawned {
+		s.broadcast(id)
+	}
+	return ids
+}
+
+// Move the simulator's actors to where it says they are now. Actors it doesn't drive are left alone. Returns how
+// many were updated.
+func (s *Simulators) Update(simulatorId uint64, actors []*packets.ActorMessage) int {
+	updated := 0
+	for _, actor := range actors {
+		if owner, driven := s.Owner(actor.Id); !driven || owner != simulatorId {
+			continue
+		}
+		player, exists := s.hub.SharedGameObjects.Players.Get(actor.Id)
+		if !exists {
+			continue
+		}
+		applyActor(player, actor)
+		s.broadcast(actor.Id)
+		updated++
+	}
+	simulatorActorUpdatesTotal.Add(uint64(updated))
+	return updated
+}
+
+// Take the simulator's actors out of the world. Actors it doesn't drive are left alone.
+func (s *Simulators) Despawn(simulatorId uint64, ids []uint64) {
+	var despawned []uint64
+	s.mux.Lock()
+	for _, id := range ids {
+		if s.owners[id] != simulatorId {
+			continue
+		}
+		delete(s.owners, id)
+		delete(s.actors[simulatorId], id)
+		despawned = append(despawned, id)
+	}
+	s.mux.Unlock()
+
+	s.remove(despawned)
+}
+
+// Take all of the simulator's actors out of the world, e.g. once it's disconnected
+func (s *Simulators) DespawnAll(simulatorId uint64) {
+	var despawned []uint64
+	s.mux.Lock()
+	for id := range s.actors[simulatorId] {
+		delete(s.owners, id)
+		despawned = append(despawned, id)
+	}
+	delete(s.actors, simulatorId)
+	s.mux.Unlock()
+
+	s.remove(despawned)
+}
+
+// The simulator driving the actor, if it's driven by one rather than by a player's client
+func (s *Simulators) Owner(actorId uint64) (uint64, bool) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	simulatorId, driven := s.owners[actorId]
+	return simulatorId, driven
+}
+
+// The actors the simulator drives
+func (s *Simulators) drivenBy(simulatorId uint64) []uint64 {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return slices.Collect(maps.Keys(s.actors[simulatorId]))
+}
+
+// The simulators driving any actors
+func (s *Simulators) simulatorIds() []uint64 {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return slices.Collect(maps.Keys(s.actors))
+}
+
+func (s *Simulators) remove(ids []uint64) {
+	for _, id := range ids {
+		s.hub.SharedGameObjects.Players.Remove(id)
+		s.hub.Visibility.Forget(id)
+		s.hub.DamageAttribution.Forget(id)
+		s.hub.WorldTags.Forget(id)
+		s.hub.Broadcast(&packets.Packet{SenderId: id, Msg: packets.NewDisconnect("despawned")})
+	}
+	simulatorActors.Add(-float64(len(ids)))
+}
+
+// Tell everyone where the actor is now, the same as a player's client does about its own player
+func (s *Simulators) broadcast(actorId uint64) {
+	if player, exists := s.hub.SharedGameObjects.Players.Get(actorId); exists {
+		s.hub.Broadcast(&packets.Packet{SenderId: actorId, Msg: packets.NewPlayer(actorId, player)})
+	}
+}
+
+func applyActor(player *objects.Player, actor *packets.ActorMessage) {
+	player.X = actor.X
+	player.Y = actor.Y
+	player.Radius = actor.Radius
+	player.Direction = actor.Direction
+	player.Speed = actor.Speed
+}
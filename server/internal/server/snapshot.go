@@ -0,0 +1,158 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"server/internal/server/objects"
+	"sync"
+	"time"
+)
+
+const resumeSnapshotFile = "resume_snapshot.json"
+
+type sporeSnapshot struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius"`
+}
+
+type resourceNodeSnapshot struct {
+	Kind   string  `json:"kind"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius"`
+
+	// Unix milliseconds, or 0 if the node was available. The downtime counts towards the node's respawn.
+	DepletedAt int64 `json:"depleted_at"`
+}
+
+type playerSnapshot struct {
+	DbId       int64               `json:"db_id"`
+	X          float64             `json:"x"`
+	Y          float64             `json:"y"`
+	Radius     float64             `json:"radius"`
+	Components *objects.Components `json:"components"`
+}
+
+// The world as it was just before a scheduled restart, so it can pick up where it left off instead of starting over
+type resumeSnapshot struct {
+	TakenAt       int64                   `json:"taken_at"`
+	Spores        []*sporeSnapshot        `json:"spores"`
+	ResourceNodes []*resourceNodeSnapshot `json:"resource_nodes"`
+	Players       []*playerSnapshot       `json:"players"`
+}
+
+func takeResumeSnapshot(sharedGameObjects *SharedGameObjects) *resumeSnapshot {
+	snapshot := &resumeSnapshot{TakenAt: time.Now().UnixMilli()}
+
+	sharedGameObjects.Spores.ForEach(func(_ uint64, spore *objects.Spore) {
+		snapshot.Spores = append(snapshot.Spores, &sporeSnapshot{X: spore.X, Y: spore.Y, Radius: spore.Radius})
+	})
+
+	sharedGameObjects.ResourceNodes.ForEach(func(_ uint64, node *objects.ResourceNode) {
+		nodeSnapshot := &resourceNodeSnapshot{Kind: node.Kind, X: node.X, Y: node.Y, Radius: node.Radius}
+		if depletedAt := node.DepletedAt(); !depletedAt.IsZero() {
+			nodeSnapshot.DepletedAt = depletedAt.UnixMilli()
+		}
+		snapshot.ResourceNodes = append(snapshot.ResourceNodes, nodeSnapshot)
+	})
+
+	sharedGameObjects.Players.ForEach(func(_ uint64, player *objects.Player) {
+		// Actors driven by world simulators aren't anyone's to resume, they're spawned again by their simulators
+		if player.DbId == 0 {
+			return
+		}
+		snapshot.Players = append(snapshot.Players, &playerSnapshot{
+			DbId:   player.DbId,
+			X:      player.X,
+			Y:      player.Y,
+			Radius: player.Radius,
+
+			Components: &player.Components,
+		})
+	})
+
+	return snapshot
+}
+
+func (s *resumeSnapshot) write(filePath string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a half written snapshot is never restored
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// Read the snapshot at the given path and remove it, so it's only ever restored once. Returns nil without an error
+// if there's no snapshot.
+func consumeResumeSnapshot(filePath string) (*resumeSnapshot, error) {
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &resumeSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, os.Remove(filePath)
+}
+
+func (s *resumeSnapshot) restore(sharedGameObjects *SharedGameObjects) {
+	for _, spore := range s.Spores {
+		sharedGameObjects.Spores.Add(&objects.Spore{
+			Position: objects.Position{X: spore.X, Y: spore.Y},
+			Body:     objects.Body{Radius: spore.Radius},
+		})
+	}
+
+	for _, nodeSnapshot := range s.ResourceNodes {
+		node := &objects.ResourceNode{
+			Position: objects.Position{X: nodeSnapshot.X, Y: nodeSnapshot.Y},
+			Body:     objects.Body{Radius: nodeSnapshot.Radius},
+			Kind:     nodeSnapshot.Kind,
+		}
+		if nodeSnapshot.DepletedAt != 0 {
+			node.SetDepletedAt(time.UnixMilli(nodeSnapshot.DepletedAt))
+		}
+		sharedGameObjects.ResourceNodes.Add(node)
+	}
+}
+
+// Where players were when the server went down for a restart, keyed by player DB ID, to put them back there when
+// they log in again
+type resumedPlayers struct {
+	players map[int64]*playerSnapshot
+	mux     sync.Mutex
+}
+
+func (r *resumedPlayers) set(players []*playerSnapshot) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.players = make(map[int64]*playerSnapshot, len(players))
+	for _, player := range players {
+		r.players[player.DbId] = player
+	}
+}
+
+// Each player is only resumed once: after that they spawn like normal
+func (r *resumedPlayers) take(dbId int64) (*playerSnapshot, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	player, exists := r.players[dbId]
+	delete(r.players, dbId)
+	return player, exists
+}
@@ -2,16 +2,18 @@ package states
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
 	"server/internal/server"
 	"server/internal/server/db"
+	"server/internal/server/logging"
 	"server/pkg/packets"
 )
 
 type BrowsingHiscores struct {
 	client  server.ClientInterfacer
-	logger  *log.Logger
+	logger  *logging.Logger
 	queries *db.Queries
 	dbCtx   context.Context
 }
@@ -23,7 +25,7 @@ func (b *BrowsingHiscores) Name() string {
 func (b *BrowsingHiscores) SetClient(client server.ClientInterfacer) {
 	b.client = client
 	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), b.Name())
-	b.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	b.logger = logging.States.NewLogger(loggingPrefix)
 	b.queries = client.DbTx().Queries
 	b.dbCtx = client.DbTx().Ctx
 }
@@ -38,6 +40,18 @@ func (b *BrowsingHiscores) HandleMessage(senderId uint64, message packets.Msg) {
 		b.handleFinishedBrowsingHiscoresMessage(senderId, message)
 	case *packets.Packet_SearchHiscore:
 		b.handleSearchHiscore(senderId, message)
+	case *packets.Packet_TimeSyncRequest:
+		handleTimeSyncRequest(b.client, senderId, message)
+	case *packets.Packet_Subscribe:
+		handleSubscribe(b.client, senderId, message)
+	case *packets.Packet_Unsubscribe:
+		handleUnsubscribe(b.client, senderId, message)
+	case *packets.Packet_Custom:
+		handleCustom(b.client, senderId, message)
+	case *packets.Packet_ImpersonationConsent:
+		handleImpersonationConsent(b.client, senderId, message)
+	case *packets.Packet_PromptResponse:
+		handlePromptResponse(b.client, senderId, message)
 	}
 }
 
@@ -52,14 +66,18 @@ func (b *BrowsingHiscores) handleSearchHiscore(senderId uint64, message *packets
 	player, err := b.queries.GetPlayerByName(b.dbCtx, message.SearchHiscore.Name)
 
 	if err != nil {
-		b.logger.Printf("Error getting player %s: %v", message.SearchHiscore.Name, err)
+		b.logger.Errorf("Error getting player %s: %v", message.SearchHiscore.Name, err)
 		b.client.SocketSend(packets.NewDenyResponse("No player found with that name"))
 		return
 	}
 
-	playerRank, err := b.queries.GetPlayerRank(b.dbCtx, player.ID)
+	// Ranks are worked out every so often, so players who've joined since have to be ranked now
+	playerRank, err := b.queries.GetHiscoreRank(b.dbCtx, player.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		playerRank, err = b.queries.GetPlayerRank(b.dbCtx, player.ID)
+	}
 	if err != nil {
-		b.logger.Printf("Error getting rank of player %s: %v", player.Name, err)
+		b.logger.Errorf("Error getting rank of player %s: %v", player.Name, err)
 		b.client.SocketSend(packets.NewDenyResponse("Player is unranked"))
 		return
 	}
@@ -75,7 +93,7 @@ func (b *BrowsingHiscores) sendTopScores(limit, offset int64) {
 		Offset: offset,
 	})
 	if err != nil {
-		b.logger.Printf("Error getting top %d scores from rank %d: %v", limit, offset, err)
+		b.logger.Errorf("Error getting top %d scores from rank %d: %v", limit, offset, err)
 		b.client.SocketSend(packets.NewDenyResponse("Failed to get top scores - please try again later"))
 		return
 	}
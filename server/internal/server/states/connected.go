@@ -2,23 +2,44 @@ package states
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"server/internal/server"
 	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
 	"server/internal/server/objects"
 	"server/pkg/packets"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// What players are told when something can't be done because the database is down
+const dbUnavailableMessage = "The server can't reach its database right now - please try again in a moment"
+
+var loginFailuresTotal = metrics.NewCounterVec("mmo_login_failures_total", "Logins refused, by why: credentials, banned, join_token, login_token or simulator_secret.", "reason")
+
+// How many other names are suggested when the one chosen can't be had
+const nameSuggestionCount = 3
+
+var dataPackChecksTotal = metrics.NewCounterVec("mmo_data_pack_checks_total", "Logins by how the client's game data compared to the server's: matched, unchecked, outdated or newer.", "result")
+
 type Connected struct {
 	client  server.ClientInterfacer
-	logger  *log.Logger
+	logger  *logging.Logger
 	queries *db.Queries
 	dbCtx   context.Context
+
+	// The login or register request held back until a CAPTCHA's passed, if the client's been challenged
+	challenged packets.Msg
+
+	// Whether the request being handled is the one held back for a CAPTCHA that's been passed, which isn't challenged
+	// again. A pass only covers that request, so e.g. a bot that solves one can't go on to guess passwords unchallenged.
+	captchaPassed bool
 }
 
 func (c *Connected) Name() string {
@@ -28,7 +49,7 @@ func (c *Connected) Name() string {
 func (c *Connected) SetClient(client server.ClientInterfacer) {
 	c.client = client
 	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), c.Name())
-	c.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	c.logger = logging.States.NewLogger(loggingPrefix)
 	c.queries = client.DbTx().Queries
 	c.dbCtx = client.DbTx().Ctx
 }
@@ -43,8 +64,24 @@ func (c *Connected) HandleMessage(senderId uint64, message packets.Msg) {
 		c.handleLoginRequest(senderId, message)
 	case *packets.Packet_RegisterRequest:
 		c.handleRegisterRequest(senderId, message)
+	case *packets.Packet_SimulatorLoginRequest:
+		c.handleSimulatorLoginRequest(senderId, message)
 	case *packets.Packet_HiscoreBoardRequest:
 		c.handleHiscoreBoardRequest(senderId, message)
+	case *packets.Packet_CaptchaResponse:
+		c.handleCaptchaResponse(senderId, message)
+	case *packets.Packet_TimeSyncRequest:
+		handleTimeSyncRequest(c.client, senderId, message)
+	case *packets.Packet_Subscribe:
+		handleSubscribe(c.client, senderId, message)
+	case *packets.Packet_Unsubscribe:
+		handleUnsubscribe(c.client, senderId, message)
+	case *packets.Packet_Custom:
+		handleCustom(c.client, senderId, message)
+	case *packets.Packet_ImpersonationConsent:
+		handleImpersonationConsent(c.client, senderId, message)
+	case *packets.Packet_PromptResponse:
+		handlePromptResponse(c.client, senderId, message)
 	}
 }
 
@@ -57,42 +94,203 @@ func (c *Connected) handleLoginRequest(senderId uint64, message *packets.Packet_
 		return
 	}
 
+	if !c.client.DbTx().Healthy() {
+		c.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	if !c.checkClientBuild(message.LoginRequest.ClientBuild, true) {
+		return
+	}
+
+	if !c.checkDataPack(message.LoginRequest) {
+		return
+	}
+
 	username := message.LoginRequest.Username
 
+	// A login token was had by logging in with the password over a secure connection, which was challenged already
+	tokenLogin := message.LoginRequest.LoginToken != ""
+	if tokenLogin && !c.client.LoginTokens().Redeem(message.LoginRequest.LoginToken, username) {
+		c.logger.Printf("User %s tried to log in with an invalid login token", username)
+		loginFailuresTotal.With("login_token").Inc()
+		c.client.SocketSend(packets.NewDenyResponse("Invalid or expired login token"))
+		return
+	} else if !tokenLogin && c.client.Plaintext() {
+		c.client.SocketSend(packets.NewDenyResponse("Log in over a secure connection for a login token first"))
+		return
+	}
+
+	ip := c.client.RemoteIp()
+	if !tokenLogin && c.client.Captcha().Suspicious(ip) && c.challenge(message, "failures") {
+		return
+	}
+
+	// Who's turned away for the server being full can only be known once they've logged in, since some accounts
+	// have priority. Players are counted by their sessions, since actors driven by simulators are players too.
+	full := !c.client.Budgets().Players.Admit(c.client.Sessions().Count())
+
 	genericFailMessage := packets.NewDenyResponse("Incorrect username or password")
 
 	user, err := c.queries.GetUserByUsername(c.dbCtx, strings.ToLower(username))
 	if err != nil {
-		c.logger.Printf("Error getting user by username: %v", err)
+		// Accounts that don't exist are challenged like logins from somewhere new, so challenges don't give away
+		// which do
+		if errors.Is(err, sql.ErrNoRows) && !tokenLogin && c.challenge(message, "new_ip") {
+			return
+		}
+		c.logger.Errorf("Error getting user by username: %v", err)
+		loginFailuresTotal.With("credentials").Inc()
+		c.client.Captcha().Failed(ip)
+		if errors.Is(err, sql.ErrNoRows) && !tokenLogin && c.denyRenamed(username, message.LoginRequest.Password) {
+			return
+		}
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(message.LoginRequest.Password))
-	if err != nil {
-		c.logger.Printf("Incorrect password for user %s", username)
+	if !tokenLogin {
+		// Checked before the password, so a correct password isn't given away by a challenge coming after it
+		if known, err := c.client.Captcha().KnownIp(c.dbCtx, c.queries, user.ID, ip); err != nil {
+			c.logger.Errorf("Error getting where user %s has logged in from: %v", username, err)
+		} else if !known && c.challenge(message, "new_ip") {
+			return
+		}
+
+		err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(message.LoginRequest.Password))
+		if err != nil {
+			c.logger.Printf("Incorrect password for user %s", username)
+			loginFailuresTotal.With("credentials").Inc()
+			c.client.Captcha().Failed(ip)
+			if c.denyRenamed(username, message.LoginRequest.Password) {
+				return
+			}
+			c.client.SocketSend(genericFailMessage)
+			return
+		}
+	}
+
+	ban, err := c.queries.GetActiveBan(c.dbCtx, db.GetActiveBanParams{
+		UserID:    user.ID,
+		ExpiresAt: sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+	})
+	if err == nil {
+		c.logger.Printf("Banned user %s tried to log in", username)
+		loginFailuresTotal.With("banned").Inc()
+		c.client.SocketSend(packets.NewDenyResponse(banReason(ban)))
+		go c.client.Kick(server.KickBanned, banReason(ban))
+		return
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		c.logger.Errorf("Error checking bans for user %s: %v", username, err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
+	// The rest's checked when they log in with the token
+	if message.LoginRequest.ForUdp {
+		token, expiresAt := c.client.LoginTokens().Issue(username)
+		c.logger.Printf("Issued user %s a login token for UDP", username)
+		c.client.SocketSend(packets.NewLoginToken(token, expiresAt.UnixMilli()))
+		return
+	}
+
+	if full {
+		entitlementIds, err := c.client.Entitlements().List(c.dbCtx, c.queries, user.ID)
+		if err != nil {
+			c.logger.Errorf("Error getting entitlements of user %s: %v", username, err)
+		}
+		if !c.client.PriorityLogin().Admit(username, entitlementIds) {
+			// A login's always answered, however the budget sheds, or the client would wait on it for good. Dropping
+			// only leaves the client connected to try again.
+			c.client.SocketSend(packets.NewDenyResponse("The server is full, please try again later"))
+			if c.client.Budgets().Players.Rejects() {
+				go c.client.Kick(server.KickCapacity, "The server is full")
+			}
+			return
+		}
+		c.logger.Printf("User %s has priority, letting them in over the players budget", username)
+	}
+
 	player, err := c.queries.GetPlayerByUserId(c.dbCtx, user.ID)
 	if err != nil {
-		c.logger.Printf("Error getting player for user %s: %v", username, err)
+		c.logger.Errorf("Error getting player for user %s: %v", username, err)
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
+	var reservation *server.MatchReservation
+	if token := message.LoginRequest.JoinToken; token != "" {
+		redeemed, err := c.client.Matchmaking().Redeem(token, player.Name)
+		if err != nil {
+			c.logger.Printf("User %s tried to log in with an invalid join token", username)
+			loginFailuresTotal.With("join_token").Inc()
+			c.client.SocketSend(packets.NewDenyResponse("Invalid or expired join token"))
+			return
+		}
+		reservation = &redeemed
+	}
+
+	// Checked before the login's let through, so nobody gets in for their acceptance of the rules not being known
+	welcomeSteps, err := pendingWelcomeSteps(c.dbCtx, c.queries, c.client.GameData(), user.ID)
+	if err != nil {
+		c.logger.Errorf("Error getting welcome steps accepted by user %s: %v", username, err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
 	c.logger.Printf("User %s logged in successfully!", username)
+	c.client.Locale().Set(message.LoginRequest.Locale, message.LoginRequest.UtcOffsetMinutes)
 	c.client.SocketSend(packets.NewOkResponse())
 
-	c.client.SetState(&InGame{
+	login := db.RecordPlayerLoginParams{PlayerID: player.ID, LastLoginAt: time.Now().UnixMilli()}
+	err = c.client.DbTx().Save(server.SaveTierWarm, player.ID, "last_login", func(ctx context.Context, queries *db.Queries) error {
+		return queries.RecordPlayerLogin(ctx, login)
+	})
+	if err != nil {
+		c.logger.Errorf("Error recording login of player %s: %v", player.Name, err)
+	}
+	if err := c.client.Captcha().RecordLogin(c.dbCtx, c.queries, user.ID, ip); err != nil {
+		c.logger.Errorf("Error recording where player %s logged in from: %v", player.Name, err)
+	}
+	if _, err := c.client.Sessions().Start(c.dbCtx, c.queries, c.client, user.ID, message.LoginRequest.ClientBuild); err != nil {
+		c.logger.Errorf("Error starting session for player %s: %v", player.Name, err)
+	}
+
+	var lastPosition *objects.Position
+	if saved, err := c.queries.GetPlayerPosition(c.dbCtx, player.ID); err == nil {
+		lastPosition = &objects.Position{X: saved.X, Y: saved.Y}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		c.logger.Errorf("Error getting last position of player %s: %v", player.Name, err)
+	}
+
+	inGame := &InGame{
 		player: &objects.Player{
 			Name:      player.Name,
 			DbId:      player.ID,
 			BestScore: player.BestScore,
 			Color:     int32(player.Color),
 		},
-	})
+		userId:       user.ID,
+		capabilities: clientCapabilities(message.LoginRequest.Capabilities),
+		lastPosition: lastPosition,
+		reservation:  reservation,
+	}
+	if len(welcomeSteps) > 0 {
+		c.client.SetState(&Welcoming{userId: user.ID, steps: welcomeSteps, next: inGame})
+		return
+	}
+	c.client.SetState(inGame)
+}
+
+// Tell whoever logged in with a name released from their account what it's called now. Returns whether they were.
+func (c *Connected) denyRenamed(username string, password string) bool {
+	renamed, found := c.client.Names().RenamedTo(username, password)
+	if !found {
+		return false
+	}
+	c.logger.Printf("User %s tried to log in with their released name %s", renamed, username)
+	c.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Your name was given to someone else while you were away, log in as %s instead", renamed)))
+	return true
 }
 
 func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Packet_RegisterRequest) {
@@ -101,24 +299,56 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
+	if !c.client.DbTx().Healthy() {
+		c.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	if !c.checkClientBuild(message.RegisterRequest.ClientBuild, false) {
+		return
+	}
+
+	if c.client.Plaintext() {
+		c.client.SocketSend(packets.NewDenyResponse("Register over a secure connection, then log in with a login token"))
+		return
+	}
+
+	ip := c.client.RemoteIp()
+	if c.client.Captcha().SuspiciousRegistration(ip) && c.challenge(message, "registration") {
+		return
+	}
+
 	username := message.RegisterRequest.Username
-	err := validateUsername(username)
+	err := server.ValidateUsername(username)
 
 	if err != nil {
 		reason := fmt.Sprintf("Invalid username: %v", err)
 		c.logger.Println(reason)
-		c.client.SocketSend(packets.NewDenyResponse(reason))
+		c.denyName(reason, username)
 		return
 	}
 
-	if _, err := c.queries.GetUserByUsername(c.dbCtx, strings.ToLower(username)); err == nil {
-		c.logger.Printf("User already exists: %v", err)
-		c.client.SocketSend(packets.NewDenyResponse("User already exists"))
+	if c.client.GameData().Profane(username) {
+		c.logger.Printf("Refused profane username %s", username)
+		c.denyName("That name isn't allowed", username)
 		return
 	}
 
 	genericFailMessage := packets.NewDenyResponse("Failed to register user (internal server error) - please try again later")
 
+	if err := c.client.Names().Claim(username); errors.Is(err, server.ErrNameTaken) {
+		c.logger.Printf("User already exists: %s", username)
+		c.denyName("User already exists", username)
+		return
+	} else if errors.Is(err, server.ErrNameReserved) {
+		c.denyName("That name is reserved", username)
+		return
+	} else if err != nil {
+		c.logger.Errorf("Error claiming name %s: %v", username, err)
+		c.client.SocketSend(genericFailMessage)
+		return
+	}
+
 	// Add new user
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(message.RegisterRequest.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -127,46 +357,184 @@ func (c *Connected) handleRegisterRequest(senderId uint64, message *packets.Pack
 		return
 	}
 
-	user, err := c.queries.CreateUser(c.dbCtx, db.CreateUserParams{
-		Username:     strings.ToLower(username),
-		PasswordHash: string(passwordHash),
+	// The user and their player are created together, so there's never a user who can't log in
+	err = c.client.DbTx().InTx(func(queries *db.Queries) error {
+		user, err := queries.CreateUser(c.dbCtx, db.CreateUserParams{
+			Username:     strings.ToLower(username),
+			PasswordHash: string(passwordHash),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+
+		if err := c.client.Entitlements().CheckCharacterSlot(c.dbCtx, queries, user.ID); err != nil {
+			return err
+		}
+
+		// Where they registered from is where they'll most likely log in from first
+		if err := c.client.Captcha().RecordLogin(c.dbCtx, queries, user.ID, ip); err != nil {
+			return fmt.Errorf("failed to record registration IP: %w", err)
+		}
+
+		player, err := queries.CreatePlayer(c.dbCtx, db.CreatePlayerParams{
+			UserID: user.ID,
+			Name:   username,
+			Color:  int64(message.RegisterRequest.Color),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create player: %w", err)
+		}
+
+		return c.client.DbTx().RecordEvent(queries, "player.registered", map[string]any{
+			"player_id": player.ID,
+			"player":    player.Name,
+		})
 	})
 
 	if err != nil {
-		c.logger.Printf("Failed to create user: %v", err)
+		c.logger.Printf("Failed to register user %s: %v", username, err)
 		c.client.SocketSend(genericFailMessage)
 		return
 	}
 
-	_, err = c.queries.CreatePlayer(c.dbCtx, db.CreatePlayerParams{
-		UserID: user.ID,
-		Name:   username,
-		Color:  int64(message.RegisterRequest.Color),
-	})
+	c.logger.Printf("User %s registered successfully!", username)
+	c.client.Captcha().Registered(ip)
+	c.client.SocketSend(packets.NewOkResponse())
+}
 
-	if err != nil {
-		c.logger.Printf("Failed to create player for user %s: %v", username, err)
-		c.client.SocketSend(genericFailMessage)
+func (c *Connected) handleSimulatorLoginRequest(senderId uint64, message *packets.Packet_SimulatorLoginRequest) {
+	if senderId != c.client.Id() {
+		c.logger.Printf("Received simulator login request from another client (Id %d)", senderId)
 		return
 	}
 
-	c.logger.Printf("User %s registered successfully!", username)
+	if c.client.Plaintext() {
+		c.client.SocketSend(packets.NewDenyResponse("The simulator secret can't be sent over an unencrypted connection"))
+		return
+	}
+
+	if !c.client.Simulators().Authenticate(message.SimulatorLoginRequest.Secret) {
+		c.logger.Printf("World simulator %q gave the wrong secret", message.SimulatorLoginRequest.Name)
+		loginFailuresTotal.With("simulator_secret").Inc()
+		c.client.SocketSend(packets.NewDenyResponse("Incorrect simulator secret"))
+		return
+	}
+
+	name := message.SimulatorLoginRequest.Name
+	if name == "" {
+		name = fmt.Sprintf("simulator-%d", c.client.Id())
+	}
 	c.client.SocketSend(packets.NewOkResponse())
+	c.client.SetState(&Simulating{name: name})
+}
+
+// Hold the request back and challenge the client with a CAPTCHA, unless they're off or the client passed one for this
+// request. Returns whether the client was challenged.
+func (c *Connected) challenge(request packets.Msg, reason string) bool {
+	if !c.client.Captcha().Enabled() || c.captchaPassed {
+		return false
+	}
+	c.logger.Printf("Challenging %T with a CAPTCHA (%s)", request, reason)
+	c.challenged = request
+	c.client.Captcha().Challenge(c.client, reason)
+	return true
+}
+
+// Carry on with the request that was held back if the CAPTCHA was passed. If it wasn't the request's dropped, and has
+// to be sent again for another challenge.
+func (c *Connected) handleCaptchaResponse(senderId uint64, message *packets.Packet_CaptchaResponse) {
+	if senderId != c.client.Id() || c.challenged == nil {
+		return
+	}
+	request := c.challenged
+	c.challenged = nil
+
+	ip := c.client.RemoteIp()
+	passed, err := c.client.Captcha().Verify(c.dbCtx, message.CaptchaResponse.Token, ip)
+	if err != nil {
+		c.logger.Errorf("Error verifying CAPTCHA response: %v", err)
+		c.client.SocketSend(packets.NewDenyResponse("The CAPTCHA couldn't be checked - please try again"))
+		return
+	}
+	if !passed {
+		c.logger.Printf("Failed the CAPTCHA")
+		c.client.Captcha().Failed(ip)
+		c.client.SocketSend(packets.NewDenyResponse("The CAPTCHA wasn't completed - please try again"))
+		return
+	}
+
+	c.captchaPassed = true
+	c.HandleMessage(senderId, request)
+	c.captchaPassed = false
 }
 
 func (c *Connected) handleHiscoreBoardRequest(senderId uint64, message *packets.Packet_HiscoreBoardRequest) {
 	c.client.SetState(&BrowsingHiscores{})
 }
 
-func validateUsername(username string) error {
-	if len(username) <= 0 {
-		return errors.New("empty")
+// Refuse the name, suggesting others like it, so the player doesn't have to keep guessing
+func (c *Connected) denyName(reason string, username string) {
+	c.client.SocketSend(packets.NewNameDenied(reason, c.client.Names().Suggest(username, nameSuggestionCount)))
+}
+
+func banReason(ban db.Ban) string {
+	if !ban.ExpiresAt.Valid {
+		return fmt.Sprintf("You are banned: %s", ban.Reason)
 	}
-	if len(username) > 20 {
-		return errors.New("too long")
+	expiresAt := time.Unix(ban.ExpiresAt.Int64, 0).UTC()
+	return fmt.Sprintf("You are banned until %s: %s", expiresAt.Format(time.RFC1123), ban.Reason)
+}
+
+// Make sure the client's build is one the server accepts, telling it where to get the latest if it's too old, and
+// warning it to update soon if it's only going to be too old. Returns false if it can't go on with the build it has.
+func (c *Connected) checkClientBuild(build uint32, warn bool) bool {
+	status, policy := c.client.ClientVersions().Check(build, time.Now())
+	switch status {
+	case server.ClientVersionUpdateSoon:
+		if warn {
+			c.logger.Printf("Client build %d will be too old from %s", build, policy.UpdateDeadline.Format(time.RFC3339))
+			c.client.SocketSend(packets.NewClientUpdate(false, policy.MinBuild, policy.UpdateDeadline.Unix(), policy.DownloadUrl))
+		}
+		return true
+	case server.ClientVersionOutdated:
+		c.logger.Printf("Client build %d is too old, the oldest accepted is %d", build, policy.MinBuild)
+		c.client.SocketSend(packets.NewClientUpdate(true, policy.MinBuild, 0, policy.DownloadUrl))
+		c.client.SocketSend(packets.NewDenyResponse("Your game is out of date - please update to keep playing"))
+		return false
+	case server.ClientVersionTooNew:
+		c.logger.Printf("Client build %d is newer than the newest accepted, %d", build, policy.MaxBuild)
+		c.client.SocketSend(packets.NewDenyResponse("The server is being updated - please try again in a few minutes"))
+		return false
 	}
-	if username != strings.TrimSpace(username) {
-		return errors.New("leading or trailing whitespace")
+	return true
+}
+
+// Make sure the client has the same game data as the server, telling it what to download if it's behind. Returns
+// false if it can't log in with the data it has.
+func (c *Connected) checkDataPack(message *packets.LoginRequestMessage) bool {
+	pack := c.client.GameData().Pack
+	err := pack.Check(message.DataVersion, message.DataChecksum)
+	switch {
+	case len(message.DataChecksum) == 0:
+		dataPackChecksTotal.With("unchecked").Inc()
+		return true
+	case err == nil:
+		dataPackChecksTotal.With("matched").Inc()
+		return true
+	case errors.Is(err, gamedata.ErrDataNewer):
+		dataPackChecksTotal.With("newer").Inc()
+		c.logger.Printf("Client has game data version %d, newer than ours (%d)", message.DataVersion, pack.Version)
+		c.client.SocketSend(packets.NewDenyResponse("The server is being updated - please try again in a few minutes"))
+		return false
+	}
+
+	dataPackChecksTotal.With("outdated").Inc()
+	c.logger.Printf("Client has game data version %d, sending it version %d to update to", message.DataVersion, pack.Version)
+	files := make([]*packets.DataFileMessage, len(pack.Files))
+	for i, file := range pack.Files {
+		files[i] = &packets.DataFileMessage{Name: file.Name, Sha256: file.Sha256, Size: uint64(file.Size)}
 	}
-	return nil
+	c.client.SocketSend(packets.NewDataPack(pack.Version, pack.Checksum, files, server.DataFilesPath))
+	c.client.SocketSend(packets.NewDenyResponse("Your game data is out of date - please update and try again"))
+	return false
 }
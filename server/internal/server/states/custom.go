@@ -0,0 +1,16 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// Custom messages are handed to whatever was registered for their type with Hub.HandleCustom, in every state.
+// Messages of types nobody registered are dropped.
+func handleCustom(client server.ClientInterfacer, senderId uint64, message *packets.Packet_Custom) {
+	handler, exists := client.CustomHandler(message.Custom.Type)
+	if !exists {
+		return
+	}
+	handler(client, senderId, message.Custom.Payload)
+}
@@ -0,0 +1,15 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// Players can answer an admin's request to impersonate them, or end a session that's going on, in every state
+func handleImpersonationConsent(client server.ClientInterfacer, senderId uint64, message *packets.Packet_ImpersonationConsent) {
+	if senderId != client.Id() {
+		return
+	}
+	consent := message.ImpersonationConsent
+	client.Impersonations().Respond(client.Id(), consent.RequestId, consent.Granted)
+}
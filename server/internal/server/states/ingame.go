@@ -3,11 +3,11 @@ package states
 import (
 	"context"
 	"fmt"
-	"log"
 	"math"
-	"math/rand/v2"
 	"server/internal/server"
 	"server/internal/server/db"
+	"server/internal/server/diagnostics"
+	"server/internal/server/logging"
 	"server/internal/server/objects"
 	"server/pkg/packets"
 	"time"
@@ -16,8 +16,42 @@ import (
 type InGame struct {
 	client                 server.ClientInterfacer
 	player                 *objects.Player
-	logger                 *log.Logger
+	logger                 *logging.Logger
 	cancelPlayerUpdateLoop context.CancelFunc
+	cancelWorldState       context.CancelFunc
+	gathering              *gatherAttempt
+	nextEncounter          *server.Encounter
+	blocked                blockList
+	emotes                 emoteSet
+	relevance              relevancyTracker
+	path                   movePath
+	dialog                 *openDialog
+
+	// What the client said it supports when logging in, which some feature flags are only on for
+	capabilities []string
+
+	// The account the player's on, and what it's entitled to, which some content is only around for
+	userId       int64
+	entitlements entitlementSet
+
+	// Where the player was when they last left the game, if they're logging back in
+	lastPosition *objects.Position
+
+	// Where an external matchmaker held a place for the player, if they came in with a join token
+	reservation *server.MatchReservation
+
+	// The zone the player is in, and which of its channels
+	zoneId  string
+	channel int
+
+	// The voice party the player is in, if any, and until when they're muted from voice chat, if they are. A mute
+	// that doesn't expire is until the zero time.
+	voiceParty      string
+	voiceMuted      bool
+	voiceMutedUntil time.Time
+
+	// How to put the player back once they leave the sandbox, if they're in it
+	sandbox *sandboxTrip
 }
 
 func (g *InGame) Name() string {
@@ -27,31 +61,88 @@ func (g *InGame) Name() string {
 func (g *InGame) SetClient(client server.ClientInterfacer) {
 	g.client = client
 	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), g.Name())
-	g.logger = log.New(log.Writer(), loggingPrefix, log.LstdFlags)
+	g.logger = logging.States.NewLogger(loggingPrefix)
 }
 
 func (g *InGame) OnEnter() {
-	log.Printf("Adding player %s to the shared collection", g.player.Name)
-	go g.client.SharedGameObjects().Players.Add(g.player, g.client.Id())
+	logging.States.Printf("Adding player %s to the shared collection", g.player.Name)
+	g.client.SharedGameObjects().Players.Add(g.player, g.client.Id())
+	g.client.Presence().SetOnline(g.player.Name)
+	g.client.DebugOverlays().TrackPath(g.client.Id(), g.path.remaining)
+	g.client.Bandwidth().Attach(g.player.DbId)
+
+	// From now on only this session can save the player, in case they're somehow still being played somewhere else
+	if err := g.client.DbTx().ClaimCharacter(g.client.Id(), g.player.DbId); err != nil {
+		g.logger.Errorf("Error claiming player %s, their saves won't be checked for conflicts: %v", g.player.Name, err)
+	}
+
+	// Before anything that could require an entitlement, like the zone the player's in
+	g.loadEntitlements()
+	g.client.PriorityLogin().Enter(g.client.Id(), g.player.Name, g.client.PriorityLogin().Has(g.entitlements.list()))
 
 	// Set the initial properties of the player
 	g.player.X, g.player.Y = objects.SpawnCoords(g.player.Radius, g.client.SharedGameObjects().Players, nil)
-	g.player.Speed = 150.0
+	g.player.Speed = playerSpeed
 	g.player.Radius = 20.0
+	g.player.Components.Add(&objects.NetworkSync{})
 
-	// Send the player's initial state to the client
-	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	// Put the player back where they left off, unless someone else is in the way now
+	if g.lastPosition != nil && objects.IsClear(g.lastPosition.X, g.lastPosition.Y, g.player.Radius, g.client.SharedGameObjects().Players) {
+		g.player.Position = *g.lastPosition
+	}
+
+	// Pick up where the player left off if they were kicked out by a restart
+	if g.client.Maintenance().ResumePlayer(g.player) {
+		g.logger.Printf("Resumed player %s from before the restart", g.player.Name)
+	}
+
+	// Players a matchmaker sent go where it held their place, whatever they were doing before
+	if g.reservation != nil {
+		if zone, exists := g.client.GameData().Zone(g.reservation.ZoneId); exists {
+			g.player.X, g.player.Y = objects.SpawnCoordsWithin(zone.MinX, zone.MinY, zone.MaxX, zone.MaxY, g.player.Radius, g.client.SharedGameObjects().Players)
+			g.zoneId = zone.Id
+			g.channel = g.client.Channels().EnterReserved(g.client.Id(), zone, g.reservation.Channel)
+			g.logger.Printf("Player %s joined match %s in channel %d of zone %s", g.player.Name, g.reservation.MatchId, g.channel, zone.Id)
+		}
+	}
+	g.enterZone(g.zoneAt(g.player.X, g.player.Y))
 
-	// Send the spores to the client in the background
-	go g.sendInitialSpores(20, 50*time.Millisecond)
+	// Send the player's initial state to the client, with the features they have ahead of everything else
+	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	g.sendFeatureFlags()
+	g.sendEntitlements()
+
+	// Send what's already in the world to the client in the background, what matters most first
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancelWorldState = cancel
+	diagnostics.GoFor(g.client, "world state", func() { g.streamWorldState(ctx) })
+
+	g.sendInventory()
+	g.commitNextEncounter()
+	g.sendQuestStages()
+	g.sendBossLockouts()
+	g.syncBlockList()
+	g.promptOnboardingStep()
+	g.refreshEmotes()
+	g.sendMailbox()
+	g.enterVoice()
+	g.enterAccountData()
 }
 
 func (g *InGame) HandleMessage(senderId uint64, message packets.Msg) {
+	// Nothing done in the sandbox is kept, so nothing that would be is done there
+	if senderId == g.client.Id() && g.sandbox != nil && sandboxBlockedKinds.Has(packets.KindOf(message)) {
+		g.client.SocketSend(packets.NewDenyResponse("Not while in the sandbox"))
+		return
+	}
+
 	switch message := message.(type) {
 	case *packets.Packet_Player:
 		g.handlePlayer(senderId, message)
 	case *packets.Packet_PlayerDirection:
 		g.handlePlayerDirection(senderId, message)
+	case *packets.Packet_MoveTo:
+		g.handleMoveTo(senderId, message)
 	case *packets.Packet_Chat:
 		g.handleChat(senderId, message)
 	case *packets.Packet_SporeConsumed:
@@ -62,15 +153,130 @@ func (g *InGame) HandleMessage(senderId uint64, message packets.Msg) {
 		g.handleSpore(senderId, message)
 	case *packets.Packet_Disconnect:
 		g.handleDisconnect(senderId, message)
+	case *packets.Packet_ResourceNode:
+		g.handleResourceNode(senderId, message)
+	case *packets.Packet_GatherStart:
+		g.handleGatherStart(senderId, message)
+	case *packets.Packet_GatherFinish:
+		g.handleGatherFinish(senderId, message)
+	case *packets.Packet_CraftRequest:
+		g.handleCraftRequest(senderId, message)
+	case *packets.Packet_BlockPlayer:
+		g.handleBlockPlayer(senderId, message)
+	case *packets.Packet_UnblockPlayer:
+		g.handleUnblockPlayer(senderId, message)
+	case *packets.Packet_MinimapRequest:
+		g.handleMinimapRequest(senderId, message)
+	case *packets.Packet_EnvironmentCue:
+		g.handleEnvironmentCue(senderId, message)
+	case *packets.Packet_KillCredit:
+		g.handleKillCredit(senderId, message)
+	case *packets.Packet_CompleteOnboardingStep:
+		g.handleCompleteOnboardingStep(senderId, message)
+	case *packets.Packet_EmoteUnlocked:
+		g.handleEmoteUnlocked(senderId, message)
+	case *packets.Packet_AuctionListRequest:
+		g.handleAuctionListRequest(senderId, message)
+	case *packets.Packet_AuctionSearchRequest:
+		g.handleAuctionSearchRequest(senderId, message)
+	case *packets.Packet_AuctionBuyout:
+		g.handleAuctionBuyout(senderId, message)
+	case *packets.Packet_MailboxRequest:
+		g.handleMailboxRequest(senderId, message)
+	case *packets.Packet_ClaimMail:
+		g.handleClaimMail(senderId, message)
+	case *packets.Packet_FeatureFlagsRequest:
+		g.handleFeatureFlagsRequest(senderId, message)
+	case *packets.Packet_EntitlementListRequest:
+		g.handleEntitlementListRequest(senderId, message)
+	case *packets.Packet_ChannelListRequest:
+		g.handleChannelListRequest(senderId, message)
+	case *packets.Packet_ChannelSwitch:
+		g.handleChannelSwitch(senderId, message)
+	case *packets.Packet_VoiceSignal:
+		g.handleVoiceSignal(senderId, message)
+	case *packets.Packet_VoiceParty:
+		g.handleVoiceParty(senderId, message)
+	case *packets.Packet_VoiceStatus:
+		g.handleVoiceStatus(senderId, message)
+	case *packets.Packet_Inventory:
+		g.handleInventory(senderId, message)
+	case *packets.Packet_Interest:
+		g.handleInterest(senderId, message)
+	case *packets.Packet_IceServersRequest:
+		g.handleIceServersRequest(senderId, message)
+	case *packets.Packet_DataExportRequest:
+		g.handleDataExportRequest(senderId, message)
+	case *packets.Packet_AccountDeletionRequest:
+		g.handleAccountDeletionRequest(senderId, message)
+	case *packets.Packet_AccountDeletionCancel:
+		g.handleAccountDeletionCancel(senderId, message)
+	case *packets.Packet_AccountDataStatusRequest:
+		g.handleAccountDataStatusRequest(senderId, message)
+	case *packets.Packet_FileReceived:
+		g.handleFileReceived(senderId, message)
+	case *packets.Packet_StoreRequest:
+		g.handleStoreRequest(senderId, message)
+	case *packets.Packet_StorePurchase:
+		g.handleStorePurchase(senderId, message)
+	case *packets.Packet_StoreReceipt:
+		g.handleStoreReceipt(senderId, message)
+	case *packets.Packet_Interact:
+		g.handleInteract(senderId, message)
+	case *packets.Packet_DialogChoose:
+		g.handleDialogChoose(senderId, message)
+	case *packets.Packet_TimeSyncRequest:
+		handleTimeSyncRequest(g.client, senderId, message)
+	case *packets.Packet_PresenceSubscribe:
+		g.handlePresenceSubscribe(senderId, message)
+	case *packets.Packet_PresenceUnsubscribe:
+		g.handlePresenceUnsubscribe(senderId, message)
+	case *packets.Packet_ChatReact:
+		g.handleChatReact(senderId, message)
+	case *packets.Packet_ChatReaction:
+		g.handleChatReaction(senderId, message)
+	case *packets.Packet_ReportPlayer:
+		g.handleReportPlayer(senderId, message)
+	case *packets.Packet_DebugOverlayRequest:
+		g.handleDebugOverlayRequest(senderId, message)
+	case *packets.Packet_AnnouncementHistoryRequest:
+		g.handleAnnouncementHistoryRequest(senderId, message)
+	case *packets.Packet_ListSessions:
+		g.handleListSessions(senderId, message)
+	case *packets.Packet_LogoutSession:
+		g.handleLogoutSession(senderId, message)
+	case *packets.Packet_SandboxCommand:
+		g.handleSandboxCommand(senderId, message)
+	case *packets.Packet_Subscribe:
+		handleSubscribe(g.client, senderId, message)
+	case *packets.Packet_Unsubscribe:
+		handleUnsubscribe(g.client, senderId, message)
+	case *packets.Packet_Custom:
+		handleCustom(g.client, senderId, message)
+	case *packets.Packet_ImpersonationConsent:
+		handleImpersonationConsent(g.client, senderId, message)
+	case *packets.Packet_PromptResponse:
+		handlePromptResponse(g.client, senderId, message)
 	}
 }
 
 func (g *InGame) OnExit() {
+	g.returnFromSandbox()
 	if g.cancelPlayerUpdateLoop != nil {
 		g.cancelPlayerUpdateLoop()
 	}
+	if g.cancelWorldState != nil {
+		g.cancelWorldState()
+	}
 	g.client.SharedGameObjects().Players.Remove(g.client.Id())
+	g.client.Presence().SetOffline(g.player.Name)
+	g.client.Presence().UnsubscribeAll(g.client.Id())
+	g.client.PriorityLogin().Leave(g.client.Id())
+	g.client.Channels().Leave(g.client.Id())
+	g.client.Voice().Leave(g.client.Id())
 	g.syncPlayerBestScore()
+	g.client.Saves().FlushPlayer(g.player.DbId)
+	g.client.DbTx().ReleaseCharacter()
 }
 
 func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
@@ -79,7 +285,7 @@ func (g *InGame) handlePlayer(senderId uint64, message *packets.Packet_Player) {
 		return
 	}
 
-	g.client.SocketSendAs(message, senderId)
+	g.sendRelevantPlayer(senderId, message)
 }
 
 func (g *InGame) handlePlayerDirection(senderId uint64, message *packets.Packet_PlayerDirection) {
@@ -88,24 +294,83 @@ func (g *InGame) handlePlayerDirection(senderId uint64, message *packets.Packet_
 		return
 	}
 
+	g.client.PriorityLogin().Active(g.client.Id())
+
+	// Steering takes over from any path the player was being walked along
+	g.path.set(nil)
 	g.player.Direction = message.PlayerDirection.Direction
+	g.player.Speed = playerSpeed
+	g.logger.Sampledf("Changed direction to %.2f at (%.0f, %.0f)", g.player.Direction, g.player.X, g.player.Y)
 
-	// If this is the first time receiving a player direction message from our client, start the player update loop
-	if g.cancelPlayerUpdateLoop == nil {
+	g.startPlayerUpdates()
+}
+
+// The first time the player moves, start the player update loop, or in a deterministic simulation have the player
+// updated along with everyone else each tick
+func (g *InGame) startPlayerUpdates() {
+	if g.cancelPlayerUpdateLoop == nil && g.client.Simulation().Deterministic() {
+		delta := server.TickInterval.Seconds()
+		g.cancelPlayerUpdateLoop = g.client.Simulation().EveryTick(g.client.Id(), func() { g.syncPlayer(delta) })
+	} else if g.cancelPlayerUpdateLoop == nil {
 		ctx, cancel := context.WithCancel(context.Background())
 		g.cancelPlayerUpdateLoop = cancel
-		go g.playerUpdateLoop(ctx)
+		diagnostics.GoFor(g.client, "player updates", func() { g.playerUpdateLoop(ctx) })
 	}
 }
 
 func (g *InGame) handleChat(senderId uint64, message *packets.Packet_Chat) {
 	if senderId == g.client.Id() {
+		g.client.PriorityLogin().Active(g.client.Id())
+		if emoteId, err := g.forbiddenEmote(message.Chat.Msg); err != nil {
+			g.logger.Printf("Refused chat with emote %s: %v", emoteId, err)
+			g.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("You can't use :%s:", emoteId)))
+			return
+		}
+		if budget := g.client.Budgets().Chat; !budget.Admit(0) {
+			if budget.Rejects() {
+				g.client.SocketSend(packets.NewDenyResponse("Chat is busy, please try again in a moment"))
+			}
+			return
+		}
+
+		// So it can be reacted to, including by our own client, which only finds out its ID from us
+		message.Chat.Id = objects.Ids.Next()
+		g.client.ChatReactions().Sent(message.Chat.Id)
+		g.client.SocketSend(packets.NewChatSent(message.Chat.Id))
 		g.client.Broadcast(message)
-	} else {
+		g.client.ModerationCases().Chatted(g.player.Name, message.Chat.Msg)
+		if zoneId, channel, inChannel := g.client.Channels().Current(g.client.Id()); inChannel {
+			g.client.ChatHistory().Record(zoneId, channel, g.userId, g.player.DbId, g.player.Name, message.Chat.Msg)
+		}
+	} else if !g.isBlocked(senderId) {
+		g.relevance.interacted(senderId)
 		g.client.SocketSendAs(message, senderId)
 	}
 }
 
+func (g *InGame) handleMinimapRequest(senderId uint64, _ *packets.Packet_MinimapRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	zone := g.zoneAt(g.player.X, g.player.Y)
+	minimap, exists := g.client.Minimaps().Get(zone.Id)
+	if !exists {
+		g.logger.Printf("No minimap for zone %s", zone.Id)
+		return
+	}
+
+	g.client.SocketSend(packets.NewMinimap(minimap))
+}
+
+// Sent by the server when something happens around our player that the client should play
+func (g *InGame) handleEnvironmentCue(senderId uint64, message *packets.Packet_EnvironmentCue) {
+	if senderId != 0 {
+		return
+	}
+	g.client.SocketSend(message)
+}
+
 func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_SporeConsumed) {
 	if senderId != g.client.Id() {
 		g.client.SocketSendAs(message, senderId)
@@ -120,6 +385,7 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 	spore, err := g.getSpore(sporeId)
 	if err != nil {
 		g.logger.Println(errMsg + err.Error())
+		g.rejected(message, err.Error())
 		return
 	}
 
@@ -127,6 +393,7 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 	err = g.validatePlayerCloseToObject(spore.X, spore.Y, spore.Radius, 10)
 	if err != nil {
 		g.logger.Println(errMsg + err.Error())
+		g.rejected(message, err.Error())
 		return
 	}
 
@@ -134,6 +401,7 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 	err = g.validatePlayerDropCooldown(spore, 10)
 	if err != nil {
 		g.logger.Println(errMsg + err.Error())
+		g.rejected(message, err.Error())
 		return
 	}
 
@@ -141,7 +409,7 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 	sporeMass := radToMass(spore.Radius)
 	g.player.Radius = g.nextRadius(sporeMass)
 
-	go g.client.SharedGameObjects().Spores.Remove(sporeId)
+	g.client.SharedGameObjects().Spores.Remove(sporeId)
 
 	g.client.Broadcast(message)
 
@@ -151,13 +419,20 @@ func (g *InGame) handleSporeConsumed(senderId uint64, message *packets.Packet_Sp
 func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_PlayerConsumed) {
 	if senderId != g.client.Id() {
 		g.client.SocketSendAs(message, senderId)
+		g.relevance.forget(message.PlayerConsumed.PlayerId)
 
 		if message.PlayerConsumed.PlayerId == g.client.Id() {
 			g.logger.Println("Player was consumed, respawning")
 			g.client.SetState(&InGame{
 				player: &objects.Player{
-					Name: g.player.Name,
+					Name:      g.player.Name,
+					DbId:      g.player.DbId,
+					BestScore: g.player.BestScore,
+					Color:     g.player.Color,
 				},
+				capabilities: g.capabilities,
+				channel:      g.channel,
+				voiceParty:   g.voiceParty,
 			})
 		}
 
@@ -169,9 +444,25 @@ func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_P
 
 	// First check if the player exists
 	otherId := message.PlayerConsumed.PlayerId
+	g.relevance.interacted(otherId)
 	other, err := g.getOtherPlayer(otherId)
 	if err != nil {
 		g.logger.Println(errMsg + err.Error())
+		g.rejected(message, err.Error())
+		return
+	}
+
+	// What happens to actors driven by a world simulator is up to the simulator
+	if _, driven := g.client.Simulators().Owner(otherId); driven {
+		g.logger.Println(errMsg + "player is driven by a world simulator")
+		g.rejected(message, "player is driven by a world simulator")
+		return
+	}
+
+	// Players in another channel aren't there as far as we're concerned
+	if !g.client.Channels().Together(g.client.Id(), otherId) {
+		g.logger.Println(errMsg + "player is in another channel")
+		g.rejected(message, "player is in another channel")
 		return
 	}
 
@@ -179,6 +470,7 @@ func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_P
 	err = g.validatePlayerCloseToObject(other.X, other.Y, other.Radius, 10)
 	if err != nil {
 		g.logger.Println(errMsg + err.Error())
+		g.rejected(message, err.Error())
 		return
 	}
 
@@ -187,13 +479,14 @@ func (g *InGame) handlePlayerConsumed(senderId uint64, message *packets.Packet_P
 	otherMass := radToMass(other.Radius)
 	if ourMass <= otherMass*1.5 {
 		g.logger.Printf(errMsg+"player not massive enough to consume the other player (our radius: %f, other radius: %f)", g.player.Radius, other.Radius)
+		g.rejected(message, fmt.Sprintf("player not massive enough to consume the other player (our radius: %f, other radius: %f)", g.player.Radius, other.Radius))
 		return
 	}
 
 	// If we made it this far, the player consumption is valid, so grow the player, remove the consumed other, and broadcast the event
 	g.player.Radius = g.nextRadius(otherMass)
 
-	go g.client.SharedGameObjects().Players.Remove(otherId)
+	g.client.SharedGameObjects().Players.Remove(otherId)
 
 	g.client.Broadcast(message)
 
@@ -209,19 +502,24 @@ func (g *InGame) handleDisconnect(senderId uint64, message *packets.Packet_Disco
 		g.client.Broadcast(message)
 		g.client.SetState(&Connected{})
 	} else {
+		g.relevance.forget(senderId)
 		go g.client.SocketSendAs(message, senderId)
 	}
 }
 
 func (g *InGame) playerUpdateLoop(ctx context.Context) {
-	const delta float64 = 0.05
-	ticker := time.NewTicker(time.Duration(delta*1000) * time.Millisecond)
+	delta := server.TickInterval.Seconds()
+	ticker := time.NewTicker(server.TickInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
+			done := g.client.Watchdog().Track("player sync")
+			startedAt := time.Now()
 			g.syncPlayer(delta)
+			g.client.TickBudget().Charge(time.Since(startedAt))
+			done()
 		case <-ctx.Done():
 			return
 		}
@@ -229,21 +527,35 @@ func (g *InGame) playerUpdateLoop(ctx context.Context) {
 }
 
 func (g *InGame) syncPlayer(delta float64) {
-	newX := g.player.X + g.player.Speed*math.Cos(g.player.Direction)*delta
-	newY := g.player.Y + g.player.Speed*math.Sin(g.player.Direction)*delta
+	distance := g.followPath(g.player.Speed * delta)
+	newX := g.player.X + distance*math.Cos(g.player.Direction)
+	newY := g.player.Y + distance*math.Sin(g.player.Direction)
+
+	// Players can't wander out of the sandbox, only leave it
+	sandbox, sandboxed := g.client.Sandbox().Inside(g.client.Id())
+	if sandboxed {
+		newX = max(sandbox.MinX, min(newX, sandbox.MaxX-1))
+		newY = max(sandbox.MinY, min(newY, sandbox.MaxY-1))
+	}
 
 	g.player.X = newX
 	g.player.Y = newY
+	g.savePosition()
+
+	if zone := g.zoneAt(newX, newY); zone.Id != g.zoneId {
+		g.enterZone(zone)
+	}
 
-	// Drop a spore
+	// Drop a spore, unless there are too many about already, in which case it's kept rather than shed
 	probability := g.player.Radius / float64(server.MaxSpores*5)
-	if rand.Float64() < probability && g.player.Radius > 10 {
+	budgets := g.client.Budgets()
+	if !sandboxed && objects.Sim.Float64() < probability && g.player.Radius > 10 &&
+		budgets.Spores.Admit(g.client.SharedGameObjects().Spores.Len()) && budgets.SporeDrops.Admit(0) {
 		spore := &objects.Spore{
-			X:         g.player.X,
-			Y:         g.player.Y,
-			Radius:    min(5+g.player.Radius/50, 15),
+			Position:  g.player.Position,
+			Body:      objects.Body{Radius: min(5+g.player.Radius/50, 15)},
 			DroppedBy: g.player,
-			DroppedAt: time.Now(),
+			DroppedAt: objects.Sim.Now(),
 		}
 		sporeId := g.client.SharedGameObjects().Spores.Add(spore)
 		g.client.Broadcast(packets.NewSpore(sporeId, spore))
@@ -255,24 +567,10 @@ func (g *InGame) syncPlayer(delta float64) {
 	updatePlayer := packets.NewPlayer(g.client.Id(), g.player)
 	g.client.Broadcast(updatePlayer)
 	go g.client.SocketSend(updatePlayer)
-}
 
-func (g *InGame) sendInitialSpores(batchSize int, delay time.Duration) {
-	sporesBatch := make(map[uint64]*objects.Spore, batchSize)
-
-	g.client.SharedGameObjects().Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
-		sporesBatch[sporeId] = spore
-
-		if len(sporesBatch) >= batchSize {
-			g.client.SocketSend(packets.NewSporesBatch(sporesBatch))
-			sporesBatch = make(map[uint64]*objects.Spore, batchSize)
-			time.Sleep(delay)
-		}
-	})
-
-	// Send any remaining spores
-	if len(sporesBatch) > 0 {
-		g.client.SocketSend(packets.NewSporesBatch(sporesBatch))
+	if networkSync, ok := objects.GetComponent[*objects.NetworkSync](&g.player.Components); ok {
+		networkSync.LastSentAt = objects.Sim.Now()
+		networkSync.Updates++
 	}
 }
 
@@ -292,12 +590,15 @@ func (g *InGame) getOtherPlayer(playerId uint64) (*objects.Player, error) {
 	return player, nil
 }
 
+// Players keep moving while what they did is on its way to us, so they're given as much further as they could have
+// moved in that time. The latency's capped, as it's worked out from round trips the client reports.
 func (g *InGame) validatePlayerCloseToObject(objX, objY, objRadius, buffer float64) error {
 	realDX := g.player.X - objX
 	realDY := g.player.Y - objY
 	realDistSq := realDX*realDX + realDY*realDY
 
-	thresholdDist := g.player.Radius + buffer + objRadius
+	latency := min(g.client.ClockSync().Latency(), maxLagCompensation)
+	thresholdDist := g.player.Radius + buffer + objRadius + g.player.Speed*latency.Seconds()
 	thresholdDistSq := thresholdDist * thresholdDist
 
 	if realDistSq > thresholdDistSq {
@@ -308,9 +609,9 @@ func (g *InGame) validatePlayerCloseToObject(objX, objY, objRadius, buffer float
 
 func (g *InGame) validatePlayerDropCooldown(spore *objects.Spore, buffer float64) error {
 	minAcceptableDistance := spore.Radius + g.player.Radius + buffer
-	minAcceptableTime := time.Duration(minAcceptableDistance/g.player.Speed*1000) * time.Millisecond
-	if spore.DroppedBy == g.player && time.Since(spore.DroppedAt) < minAcceptableTime {
-		return fmt.Errorf("player dropped the spore too recently (time: %v, min acceptable time: %v)", time.Since(spore.DroppedAt), minAcceptableTime)
+	minAcceptableTime := time.Duration(minAcceptableDistance/playerSpeed*1000) * time.Millisecond
+	if sinceDropped := objects.Sim.Since(spore.DroppedAt); spore.DroppedBy == g.player && sinceDropped < minAcceptableTime {
+		return fmt.Errorf("player dropped the spore too recently (time: %v, min acceptable time: %v)", sinceDropped, minAcceptableTime)
 	}
 	return nil
 }
@@ -329,16 +630,43 @@ func (g *InGame) nextRadius(massDiff float64) float64 {
 	return massToRad(newMass)
 }
 
+// Only the latest position is kept until the hot tier is written, so this can be called as often as the player moves
+func (g *InGame) savePosition() {
+	if g.inSandbox() {
+		return
+	}
+	params := db.SavePlayerPositionParams{
+		PlayerID: g.player.DbId,
+		X:        g.player.X,
+		Y:        g.player.Y,
+		SavedAt:  time.Now().UnixMilli(),
+	}
+	err := g.client.DbTx().Save(server.SaveTierHot, g.player.DbId, "position", func(ctx context.Context, queries *db.Queries) error {
+		return queries.SavePlayerPosition(ctx, params)
+	})
+	if err != nil {
+		g.logger.Errorf("Error saving player position: %v", err)
+	}
+}
+
 func (g *InGame) syncPlayerBestScore() {
+	if g.inSandbox() {
+		return
+	}
 	currentScore := int64(math.Round(radToMass(g.player.Radius)))
 	if currentScore > g.player.BestScore {
 		g.player.BestScore = currentScore
-		err := g.client.DbTx().Queries.UpdatePlayerBestScore(g.client.DbTx().Ctx, db.UpdatePlayerBestScoreParams{
+		params := db.UpdatePlayerBestScoreParams{
 			ID:        g.player.DbId,
 			BestScore: g.player.BestScore,
+		}
+		err := g.client.DbTx().Save(server.SaveTierWarm, g.player.DbId, "best_score", func(ctx context.Context, queries *db.Queries) error {
+			return queries.UpdatePlayerBestScore(ctx, params)
 		})
 		if err != nil {
-			g.logger.Printf("Error updating player best score: %v", err)
+			g.logger.Errorf("Error updating player best score: %v", err)
 		}
+		g.client.Subscriptions().HiscoreChanged(g.player.Name, g.player.BestScore)
+		g.refreshEmotes()
 	}
 }
@@ -0,0 +1,134 @@
+package states
+
+import (
+	"errors"
+	"server/internal/server"
+	"server/pkg/packets"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// How long players have to wait between asking for exports of their data
+const dataExportCooldown = time.Hour
+
+// Let the player know their account is going to be deleted, so they can still change their mind
+func (g *InGame) enterAccountData() {
+	status, err := g.client.AccountData().Status(g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting account data status: %v", err)
+		return
+	}
+	if !status.DeletionAt.IsZero() {
+		g.sendAccountDataStatus(status)
+	}
+}
+
+func (g *InGame) handleDataExportRequest(senderId uint64, _ *packets.Packet_DataExportRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	status, err := g.client.AccountData().Status(g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting account data status: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not request an export right now"))
+		return
+	}
+	if !status.ExportRequestedAt.IsZero() && time.Since(status.ExportRequestedAt) < dataExportCooldown {
+		g.client.SocketSend(packets.NewDenyResponse("You can only request an export once an hour"))
+		return
+	}
+
+	if _, err := g.client.AccountData().RequestExport(g.player.Name, g.player.DbId); err != nil {
+		if !errors.Is(err, server.ErrDataExportPending) {
+			g.logger.Errorf("Error requesting data export: %v", err)
+			g.client.SocketSend(packets.NewDenyResponse("Could not request an export right now"))
+			return
+		}
+	}
+	g.logger.Printf("Requested an export of their data")
+	g.refreshAccountDataStatus()
+}
+
+// The password is asked for again, so no one can delete an account just by getting hold of a logged in client
+func (g *InGame) handleAccountDeletionRequest(senderId uint64, message *packets.Packet_AccountDeletionRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	tx := g.client.DbTx()
+	player, err := tx.Queries.GetPlayer(tx.Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting player: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not delete your account right now"))
+		return
+	}
+	user, err := tx.Queries.GetUser(tx.Ctx, player.UserID)
+	if err != nil {
+		g.logger.Errorf("Error getting user: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not delete your account right now"))
+		return
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(message.AccountDeletionRequest.Password)) != nil {
+		g.client.SocketSend(packets.NewDenyResponse("Incorrect password"))
+		return
+	}
+
+	deleteAt, err := g.client.AccountData().RequestDeletion(g.player.Name, g.player.DbId)
+	if err != nil && !errors.Is(err, server.ErrAccountDeletionPending) {
+		g.logger.Errorf("Error requesting account deletion: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not delete your account right now"))
+		return
+	}
+	g.logger.Printf("Requested their account be deleted at %s", deleteAt.Format(time.RFC3339))
+	g.refreshAccountDataStatus()
+}
+
+func (g *InGame) handleAccountDeletionCancel(senderId uint64, _ *packets.Packet_AccountDeletionCancel) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	cancelled, err := g.client.AccountData().CancelDeletion(g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error cancelling account deletion: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not cancel the deletion right now"))
+		return
+	}
+	if !cancelled {
+		g.client.SocketSend(packets.NewDenyResponse("Your account isn't going to be deleted"))
+		return
+	}
+	g.logger.Printf("Cancelled their account's deletion")
+	g.refreshAccountDataStatus()
+}
+
+// Sent by our client, or by the server itself when an export is ready
+func (g *InGame) handleAccountDataStatusRequest(senderId uint64, _ *packets.Packet_AccountDataStatusRequest) {
+	if senderId != g.client.Id() && senderId != 0 {
+		return
+	}
+	g.refreshAccountDataStatus()
+}
+
+func (g *InGame) refreshAccountDataStatus() {
+	status, err := g.client.AccountData().Status(g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting account data status: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not get your account data status right now"))
+		return
+	}
+	g.sendAccountDataStatus(status)
+}
+
+func (g *InGame) sendAccountDataStatus(status server.AccountDataStatus) {
+	var exportExpiresAt, deletionAt int64
+	if !status.ExportExpiresAt.IsZero() {
+		exportExpiresAt = status.ExportExpiresAt.UnixMilli()
+	}
+	if !status.DeletionAt.IsZero() {
+		deletionAt = status.DeletionAt.UnixMilli()
+	}
+	g.client.SocketSend(packets.NewAccountDataStatus(status.ExportStatus, status.ExportUrl, exportExpiresAt, deletionAt))
+}
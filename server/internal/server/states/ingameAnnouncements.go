@@ -0,0 +1,37 @@
+package states
+
+import (
+	"server/pkg/packets"
+)
+
+// Look back through what was announced, e.g. restarts and events announced while the player was offline
+func (g *InGame) handleAnnouncementHistoryRequest(senderId uint64, message *packets.Packet_AnnouncementHistoryRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if !g.client.Announcements().Allow("player " + g.player.Name) {
+		g.client.SocketSend(packets.NewDenyResponse("Too many requests, please try again in a minute"))
+		return
+	}
+
+	request := message.AnnouncementHistoryRequest
+	rows, page, pages, err := g.client.Announcements().History(g.client.DbTx().Ctx, g.client.DbTx().Queries, int(request.Page), request.Since)
+	if err != nil {
+		g.logger.Errorf("Error getting announcements: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to get announcements - please try again later"))
+		return
+	}
+
+	announcements := make([]*packets.AnnouncementMessage, len(rows))
+	for i, row := range rows {
+		announcements[i] = &packets.AnnouncementMessage{
+			Id:        uint64(row.ID),
+			Author:    row.Author,
+			Message:   row.Message,
+			CreatedAt: row.CreatedAt,
+		}
+	}
+
+	g.client.SocketSend(packets.NewAnnouncementHistory(announcements, page, pages))
+}
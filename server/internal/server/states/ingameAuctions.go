@@ -0,0 +1,266 @@
+package states
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+	"strings"
+	"time"
+)
+
+const (
+	auctionPageSize = 20
+
+	// How long listings last if the client doesn't say, and the most they can
+	defaultAuctionHours = 24
+	maxAuctionHours     = 72
+
+	maxAuctionPrice = 1_000_000_000
+)
+
+var (
+	errMissingItems   = errors.New("missing items")
+	errNotEnoughCoins = errors.New("not enough coins")
+	errListingGone    = errors.New("listing no longer available")
+	errOwnListing     = errors.New("own listing")
+)
+
+const currencyDailyLimitMessage = "You've moved as many coins as you can today, try again tomorrow"
+
+func (g *InGame) handleAuctionListRequest(senderId uint64, message *packets.Packet_AuctionListRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return
+	}
+
+	request := message.AuctionListRequest
+	if request.ItemId == "" || request.ItemId == gamedata.CurrencyItemId {
+		g.client.SocketSend(packets.NewDenyResponse("That can't be listed"))
+		return
+	}
+	if request.Quantity <= 0 || request.Price <= 0 || request.Price > maxAuctionPrice {
+		g.client.SocketSend(packets.NewDenyResponse("Invalid quantity or price"))
+		return
+	}
+	if !g.client.CurrencyCaps().Allows(request.Price) {
+		g.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("Listings can't sell for more than %d coins", g.client.CurrencyCaps().Limits().MaxPerTransfer)))
+		return
+	}
+
+	hours := request.Hours
+	if hours == 0 {
+		hours = defaultAuctionHours
+	}
+	if hours > maxAuctionHours {
+		g.client.SocketSend(packets.NewDenyResponse("Listings can't last that long"))
+		return
+	}
+
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	// The items are held by the listing until it's sold or expires
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(hours) * time.Hour).UnixMilli()
+	var listingId int64
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		removed, err := queries.RemoveInventoryItem(g.client.DbTx().Ctx, db.RemoveInventoryItemParams{
+			Quantity: request.Quantity,
+			PlayerID: g.player.DbId,
+			ItemID:   request.ItemId,
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return errMissingItems
+		}
+
+		listingId, err = queries.CreateAuctionListing(g.client.DbTx().Ctx, db.CreateAuctionListingParams{
+			SellerID:  g.player.DbId,
+			ItemID:    request.ItemId,
+			Quantity:  request.Quantity,
+			Price:     request.Price,
+			ListedAt:  now.UnixMilli(),
+			ExpiresAt: expiresAt,
+		})
+		return err
+	})
+
+	if errors.Is(err, errMissingItems) {
+		g.client.SocketSend(packets.NewDenyResponse("You don't have that many"))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error listing %s on the auction house: %v", request.ItemId, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to list - please try again later"))
+		return
+	}
+
+	g.client.SocketSend(packets.NewOkResponse())
+	g.sendInventory()
+
+	if g.client.Subscriptions().Watched(server.FeedAuctions) {
+		g.client.Subscriptions().Publish(server.FeedAuctions, packets.NewAuctionListing(&packets.AuctionListingMessage{
+			Id:        uint64(listingId),
+			Seller:    g.player.Name,
+			ItemId:    request.ItemId,
+			Quantity:  request.Quantity,
+			Price:     request.Price,
+			ExpiresAt: expiresAt,
+		}))
+	}
+}
+
+func (g *InGame) handleAuctionSearchRequest(senderId uint64, message *packets.Packet_AuctionSearchRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	now := time.Now().UnixMilli()
+	// Searches match item IDs containing the query
+	pattern := "%" + server.EscapeItemPattern(strings.ToLower(message.AuctionSearchRequest.Query)) + "%"
+
+	total, err := g.client.DbTx().Queries.CountAuctionListings(g.client.DbTx().Ctx, db.CountAuctionListingsParams{
+		ExpiresAt: now,
+		ItemID:    pattern,
+	})
+	if err != nil {
+		g.logger.Errorf("Error counting auction listings: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to search - please try again later"))
+		return
+	}
+
+	pages := max(int((total+auctionPageSize-1)/auctionPageSize), 1)
+	page := min(int(message.AuctionSearchRequest.Page), pages-1)
+
+	rows, err := g.client.DbTx().Queries.SearchAuctionListings(g.client.DbTx().Ctx, db.SearchAuctionListingsParams{
+		ExpiresAt: now,
+		ItemID:    pattern,
+		Limit:     auctionPageSize,
+		Offset:    int64(page * auctionPageSize),
+	})
+	if err != nil {
+		g.logger.Errorf("Error searching auction listings: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to search - please try again later"))
+		return
+	}
+
+	listings := make([]*packets.AuctionListingMessage, len(rows))
+	for i, row := range rows {
+		listings[i] = &packets.AuctionListingMessage{
+			Id:        uint64(row.ID),
+			Seller:    row.SellerName,
+			ItemId:    row.ItemID,
+			Quantity:  row.Quantity,
+			Price:     row.Price,
+			ExpiresAt: row.ExpiresAt,
+		}
+	}
+
+	g.client.SocketSend(packets.NewAuctionSearchResults(listings, page, pages))
+}
+
+func (g *InGame) handleAuctionBuyout(senderId uint64, message *packets.Packet_AuctionBuyout) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return
+	}
+
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	// The coins are held by the listing until it's settled and they're sent on to the seller
+	listingId := int64(message.AuctionBuyout.ListingId)
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		listing, err := queries.GetAuctionListing(g.client.DbTx().Ctx, listingId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errListingGone
+		} else if err != nil {
+			return err
+		}
+		if listing.SellerID == g.player.DbId {
+			return errOwnListing
+		}
+
+		removed, err := queries.RemoveInventoryItem(g.client.DbTx().Ctx, db.RemoveInventoryItemParams{
+			Quantity: listing.Price,
+			PlayerID: g.player.DbId,
+			ItemID:   gamedata.CurrencyItemId,
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return errNotEnoughCoins
+		}
+
+		err = g.client.CurrencyCaps().Transfer(g.client.DbTx(), queries, server.CurrencyTransfer{
+			UserId:           g.userId,
+			PlayerDbId:       g.player.DbId,
+			Kind:             server.CurrencyAuctionBuyout,
+			Amount:           listing.Price,
+			CounterpartyDbId: listing.SellerID,
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UnixMilli()
+		bought, err := queries.BuyAuctionListing(g.client.DbTx().Ctx, db.BuyAuctionListingParams{
+			BuyerID:   sql.NullInt64{Int64: g.player.DbId, Valid: true},
+			SoldAt:    sql.NullInt64{Int64: now, Valid: true},
+			ID:        listingId,
+			ExpiresAt: now,
+			SellerID:  g.player.DbId,
+		})
+		if err != nil {
+			return err
+		}
+		if bought == 0 {
+			return errListingGone
+		}
+		return nil
+	})
+
+	if errors.Is(err, errListingGone) {
+		g.client.SocketSend(packets.NewDenyResponse("That listing is no longer available"))
+		return
+	} else if errors.Is(err, errOwnListing) {
+		g.client.SocketSend(packets.NewDenyResponse("You can't buy your own listing"))
+		return
+	} else if errors.Is(err, errNotEnoughCoins) {
+		g.client.SocketSend(packets.NewDenyResponse("Not enough coins"))
+		return
+	} else if errors.Is(err, server.ErrCurrencyTransferCap) {
+		g.client.SocketSend(packets.NewDenyResponse("That's more coins than can change hands at once"))
+		return
+	} else if errors.Is(err, server.ErrCurrencyDailyLimit) {
+		g.client.SocketSend(packets.NewDenyResponse(currencyDailyLimitMessage))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error buying out auction listing %d: %v", listingId, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to buy - please try again later"))
+		return
+	}
+
+	g.client.SocketSend(packets.NewOkResponse())
+	g.sendInventory()
+	g.client.Subscriptions().Publish(server.FeedAuctions, packets.NewAuctionListingClosed(uint64(listingId), "sold"))
+	g.client.AuctionHouse().Wake()
+}
@@ -0,0 +1,121 @@
+package states
+
+import (
+	"server/internal/server/db"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+)
+
+// The players our player has blocked, keyed by player DB ID. Checked from other clients' goroutines whenever they
+// send us something, hence the lock.
+type blockList struct {
+	names map[int64]string
+	mux   sync.RWMutex
+}
+
+func (b *blockList) has(playerDbId int64) bool {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	_, blocked := b.names[playerDbId]
+	return blocked
+}
+
+func (b *blockList) set(rows []db.GetBlockedPlayersRow) []string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.names = make(map[int64]string, len(rows))
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		b.names[row.ID] = row.Name
+		names = append(names, row.Name)
+	}
+	return names
+}
+
+// Whether messages from the given client should be withheld from our player. Anything players can send each other
+// directly (chat for now; whispers, trades and invites when they exist) should be checked against this before it's
+// delivered.
+func (g *InGame) isBlocked(senderId uint64) bool {
+	sender, exists := g.client.SharedGameObjects().Players.Get(senderId)
+	if !exists {
+		return false
+	}
+	return g.blocked.has(sender.DbId)
+}
+
+func (g *InGame) handleBlockPlayer(senderId uint64, message *packets.Packet_BlockPlayer) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	name := message.BlockPlayer.Name
+	other, err := g.client.DbTx().Queries.GetPlayerByName(g.client.DbTx().Ctx, name)
+	if err != nil {
+		g.logger.Errorf("Error getting player %s to block: %v", name, err)
+		g.client.SocketSend(packets.NewDenyResponse("No player found with that name"))
+		return
+	}
+
+	if other.ID == g.player.DbId {
+		g.client.SocketSend(packets.NewDenyResponse("You can't block yourself"))
+		return
+	}
+
+	err = g.client.DbTx().Queries.CreateBlock(g.client.DbTx().Ctx, db.CreateBlockParams{
+		PlayerID:        g.player.DbId,
+		BlockedPlayerID: other.ID,
+	})
+	if err != nil {
+		g.logger.Errorf("Error blocking player %s: %v", name, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to block player - please try again later"))
+		return
+	}
+
+	// Whoever's blocked can't keep following our player either
+	g.client.SharedGameObjects().Players.ForEach(func(playerId uint64, player *objects.Player) {
+		if player.DbId == other.ID {
+			g.client.Presence().Hide(playerId, g.player.Name)
+		}
+	})
+
+	g.syncBlockList()
+}
+
+func (g *InGame) handleUnblockPlayer(senderId uint64, message *packets.Packet_UnblockPlayer) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	name := message.UnblockPlayer.Name
+	other, err := g.client.DbTx().Queries.GetPlayerByName(g.client.DbTx().Ctx, name)
+	if err != nil {
+		g.logger.Errorf("Error getting player %s to unblock: %v", name, err)
+		g.client.SocketSend(packets.NewDenyResponse("No player found with that name"))
+		return
+	}
+
+	err = g.client.DbTx().Queries.DeleteBlock(g.client.DbTx().Ctx, db.DeleteBlockParams{
+		PlayerID:        g.player.DbId,
+		BlockedPlayerID: other.ID,
+	})
+	if err != nil {
+		g.logger.Errorf("Error unblocking player %s: %v", name, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to unblock player - please try again later"))
+		return
+	}
+
+	g.syncBlockList()
+}
+
+// Reload the block list from the database and send it to the client
+func (g *InGame) syncBlockList() {
+	rows, err := g.client.DbTx().Queries.GetBlockedPlayers(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting blocked players: %v", err)
+		return
+	}
+
+	g.client.SocketSend(packets.NewBlockList(g.blocked.set(rows)))
+}
@@ -0,0 +1,29 @@
+package states
+
+import (
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+)
+
+// Whether our character's looted the boss since its lockout last reset. If that can't be told, they're treated as if
+// they have, rather than risk them looting it twice.
+func (g *InGame) lockedOut(kind *gamedata.ResourceNodeKind) bool {
+	locked, err := g.client.BossLockouts().Locked(g.client.DbTx().Ctx, g.client.DbTx().Queries, g.player.DbId, kind)
+	if err != nil {
+		g.logger.Errorf("Error getting lockout for boss %s: %v", kind.Id, err)
+		return true
+	}
+	return locked
+}
+
+// Tell the client which bosses our character can loot, for showing what's still available this week
+func (g *InGame) sendBossLockouts() {
+	lockouts, err := g.client.BossLockouts().List(g.client.DbTx().Ctx, g.client.DbTx().Queries, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting boss lockouts: %v", err)
+		return
+	}
+	if len(lockouts) > 0 {
+		g.client.SocketSend(packets.NewBossLockouts(lockouts))
+	}
+}
@@ -0,0 +1,78 @@
+package states
+
+import (
+	"errors"
+	"server/internal/server"
+	"server/internal/server/gamedata"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"strings"
+)
+
+// Put the player in a channel of the zone they've just come into, keeping the same channel number if there's room
+func (g *InGame) enterZone(zone *gamedata.Zone) {
+	g.zoneId = zone.Id
+	g.channel = g.client.Channels().Enter(g.client.Id(), zone, g.channel)
+	g.sendChannelList()
+}
+
+func (g *InGame) handleChannelListRequest(senderId uint64, _ *packets.Packet_ChannelListRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.sendChannelList()
+}
+
+// Switch to a channel by its number, or to whichever channel another player in the same zone is in. Joining another
+// player can go over the channel's cap a little, so groups can play together.
+func (g *InGame) handleChannelSwitch(senderId uint64, message *packets.Packet_ChannelSwitch) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	number, priority := int(message.ChannelSwitch.Channel), false
+	if name := message.ChannelSwitch.JoinPlayer; name != "" {
+		var otherId uint64
+		g.client.SharedGameObjects().Players.ForEach(func(playerId uint64, player *objects.Player) {
+			if strings.EqualFold(player.Name, name) {
+				otherId = playerId
+			}
+		})
+		if otherId == 0 {
+			g.client.SocketSend(packets.NewDenyResponse("That player isn't online"))
+			return
+		}
+		zoneId, otherNumber, inGame := g.client.Channels().Current(otherId)
+		if !inGame || zoneId != g.zoneId {
+			g.client.SocketSend(packets.NewDenyResponse("That player isn't in this zone"))
+			return
+		}
+		number, priority = otherNumber, true
+	}
+
+	err := g.client.Channels().Switch(g.client.Id(), number, priority)
+	if errors.Is(err, server.ErrNoSuchChannel) {
+		g.client.SocketSend(packets.NewDenyResponse("There's no such channel"))
+		return
+	} else if errors.Is(err, server.ErrChannelFull) {
+		g.client.SocketSend(packets.NewDenyResponse("That channel is full"))
+		return
+	}
+
+	g.channel = number
+	g.client.SocketSend(packets.NewOkResponse())
+	g.sendChannelList()
+}
+
+func (g *InGame) sendChannelList() {
+	channels := g.client.Channels().List(g.zoneId)
+	infos := make([]*packets.ChannelInfoMessage, len(channels))
+	for i, channel := range channels {
+		infos[i] = &packets.ChannelInfoMessage{
+			Number:        uint32(channel.Number),
+			Population:    uint32(channel.Population),
+			MaxPopulation: uint32(channel.MaxPopulation),
+		}
+	}
+	g.client.SocketSend(packets.NewChannelList(g.zoneId, g.channel, infos))
+}
@@ -0,0 +1,62 @@
+package states
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// React to a recent chat message with an emote our player can use, or take the reaction back. Everyone in the
+// channel is told how the count changed.
+func (g *InGame) handleChatReact(senderId uint64, message *packets.Packet_ChatReact) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	react := message.ChatReact
+	if _, exists := g.client.GameData().Emote(react.EmoteId); !exists {
+		g.client.SocketSend(packets.NewDenyResponse("No such emote"))
+		return
+	}
+	// Reactions are held to the same rules as emotes in chat
+	if _, err := g.forbiddenEmote(":" + react.EmoteId + ":"); err != nil {
+		g.logger.Printf("Refused reaction with emote %s: %v", react.EmoteId, err)
+		g.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("You can't react with :%s:", react.EmoteId)))
+		return
+	}
+
+	count, wait, err := g.client.ChatReactions().React(g.client.Id(), g.player.DbId, react.MessageId, react.EmoteId, react.Remove)
+	switch {
+	case errors.Is(err, server.ErrReactionCooldown):
+		seconds := int(math.Ceil(wait.Seconds()))
+		g.client.SocketSend(packets.NewDenyResponse(fmt.Sprintf("You're reacting too fast, try again in %d seconds", seconds)))
+		return
+	case errors.Is(err, server.ErrChatMessageGone):
+		g.client.SocketSend(packets.NewDenyResponse("That message can't be reacted to anymore"))
+		return
+	case errors.Is(err, server.ErrAlreadyReacted), errors.Is(err, server.ErrNotReacted):
+		// Nothing changed, e.g. the client sent the same thing twice
+		return
+	case err != nil:
+		g.logger.Errorf("Error reacting to chat message %d: %v", react.MessageId, err)
+		return
+	}
+
+	delta := int32(1)
+	if react.Remove {
+		delta = -1
+	}
+	reaction := packets.NewChatReaction(react.MessageId, react.EmoteId, delta, count)
+	g.client.SocketSend(reaction)
+	g.client.Broadcast(reaction)
+}
+
+// Someone else in the channel reacted to a message, so the counts shown here need updating
+func (g *InGame) handleChatReaction(senderId uint64, message *packets.Packet_ChatReaction) {
+	if senderId == g.client.Id() {
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
+}
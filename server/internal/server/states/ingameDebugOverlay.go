@@ -0,0 +1,45 @@
+package states
+
+import (
+	"server/pkg/packets"
+)
+
+// Start or stop streaming what the server believes about an actor to our client, for drawing a debug overlay. Only
+// for developers with the debug overlay secret.
+func (g *InGame) handleDebugOverlayRequest(senderId uint64, message *packets.Packet_DebugOverlayRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	request := message.DebugOverlayRequest
+	overlays := g.client.DebugOverlays()
+	if !overlays.Authenticate(request.Secret) {
+		g.logger.Printf("Refused the debug overlay, the secret was wrong or it's off")
+		g.client.SocketSend(packets.NewDenyResponse("The debug overlay isn't available"))
+		return
+	}
+
+	if !request.Enabled {
+		overlays.Unwatch(g.client.Id())
+		g.client.SocketSend(packets.NewOkResponse())
+		return
+	}
+
+	actorId := request.ActorId
+	if actorId == 0 {
+		actorId = g.client.Id()
+	}
+	if _, exists := g.client.SharedGameObjects().Players.Get(actorId); !exists {
+		g.client.SocketSend(packets.NewDenyResponse("That actor isn't in the world"))
+		return
+	}
+
+	g.logger.Printf("Streaming the debug overlay of actor %d", actorId)
+	overlays.Watch(g.client.Id(), actorId)
+	g.client.SocketSend(packets.NewOkResponse())
+}
+
+// Show our client sending the packet was refused on the debug overlay, for the reason
+func (g *InGame) rejected(message packets.Msg, reason string) {
+	g.client.DebugOverlays().Rejected(g.client.Id(), packets.KindOf(message), reason)
+}
@@ -0,0 +1,294 @@
+package states
+
+import (
+	"errors"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"time"
+)
+
+var dialogChoicesTotal = metrics.NewCounterVec("mmo_dialog_choices_total", "Dialog choices players tried to make, by what came of it: made, unavailable, too_far, refused or failed.", "result")
+
+var errMissingDialogItems = errors.New("missing items the choice takes")
+
+// The conversation our player is having, and where in it they've got to
+type openDialog struct {
+	targetId uint64
+	dialog   *gamedata.Dialog
+	node     *gamedata.DialogNode
+
+	// How close, beyond touching, the player has to stay to what they're talking to, from the interaction that
+	// opened the dialog
+	buffer float64
+}
+
+// What a player has that choices' conditions are checked against, as of when it was read
+type dialogProgress struct {
+	items       map[string]int64
+	questStages map[string]int64
+}
+
+func (p *dialogProgress) meets(conditions *gamedata.DialogConditions) bool {
+	for itemId, quantity := range conditions.Items {
+		if p.items[itemId] < quantity {
+			return false
+		}
+	}
+	for questId, stage := range conditions.QuestStages {
+		if p.questStages[questId] != stage {
+			return false
+		}
+	}
+	for questId, stage := range conditions.MinQuestStages {
+		if p.questStages[questId] < stage {
+			return false
+		}
+	}
+	return true
+}
+
+// Start the conversation with the target from the beginning, leaving any other the player was having
+func (g *InGame) openDialog(targetId uint64, dialog *gamedata.Dialog, buffer float64) {
+	start, _ := dialog.Node(dialog.Start)
+	g.dialog = &openDialog{targetId: targetId, dialog: dialog, buffer: buffer}
+	g.enterDialogNode(start)
+}
+
+func (g *InGame) enterDialogNode(node *gamedata.DialogNode) {
+	d := g.dialog
+	d.node = node
+
+	progress, err := g.dialogProgress()
+	if err != nil {
+		g.logger.Errorf("Error getting progress for dialog %s: %v", d.dialog.Id, err)
+		g.closeDialog("Something went wrong - please try again later")
+		return
+	}
+
+	var choices []*packets.DialogChoiceMessage
+	for _, choice := range node.Choices {
+		if progress.meets(&choice.Conditions) {
+			choices = append(choices, &packets.DialogChoiceMessage{Id: choice.Id, Text: choice.Text})
+		}
+	}
+	g.client.SocketSend(packets.NewDialog(d.targetId, d.dialog.Id, node.Id, node.Speaker, node.Text, choices))
+
+	if node.Hook != "" {
+		event := server.DialogEvent{DialogId: d.dialog.Id, NodeId: node.Id, TargetId: d.targetId}
+		if err := g.runDialogHook(node.Hook, event); err != nil {
+			g.logger.Errorf("Error running hook %s of dialog %s at node %s: %v", node.Hook, d.dialog.Id, node.Id, err)
+		}
+	}
+
+	// Nothing more can be said, either because the conversation's over or because the player can't say any of it yet
+	if len(choices) == 0 {
+		g.closeDialog("")
+	}
+}
+
+func (g *InGame) closeDialog(reason string) {
+	if g.dialog == nil {
+		return
+	}
+	g.client.SocketSend(packets.NewDialogClosed(g.dialog.dialog.Id, reason))
+	g.dialog = nil
+}
+
+func (g *InGame) handleDialogChoose(senderId uint64, message *packets.Packet_DialogChoose) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	d := g.dialog
+	if d == nil {
+		g.client.SocketSend(packets.NewDialogClosed("", "You're not talking to anyone"))
+		return
+	}
+
+	// Whatever the client shows, only choices the player can make right now are made
+	choice, exists := d.node.Choice(message.DialogChoose.ChoiceId)
+	if !exists {
+		g.logger.Printf("Tried to make unknown choice %s at node %s of dialog %s", message.DialogChoose.ChoiceId, d.node.Id, d.dialog.Id)
+		dialogChoicesTotal.With("unavailable").Inc()
+		g.client.SocketSend(packets.NewDenyResponse("You can't say that"))
+		return
+	}
+
+	target, found := g.interactionTarget(d.targetId)
+	if !found {
+		dialogChoicesTotal.With("too_far").Inc()
+		g.closeDialog("There's no one there any more")
+		return
+	}
+	if err := g.validatePlayerCloseToObject(target.x, target.y, target.radius, d.buffer); err != nil {
+		g.logger.Printf("Could not make choice %s of dialog %s: %v", choice.Id, d.dialog.Id, err)
+		dialogChoicesTotal.With("too_far").Inc()
+		g.closeDialog("Too far away")
+		return
+	}
+
+	effects := &choice.Effects
+	changes := len(effects.TakeItems) > 0 || len(effects.GiveItems) > 0 || len(effects.SetQuestStages) > 0
+	if changes && g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return
+	}
+	if changes && !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	progress, err := g.dialogProgress()
+	if err != nil {
+		g.logger.Errorf("Error getting progress for dialog %s: %v", d.dialog.Id, err)
+		dialogChoicesTotal.With("failed").Inc()
+		g.client.SocketSend(packets.NewDenyResponse("Something went wrong - please try again later"))
+		return
+	}
+	if !progress.meets(&choice.Conditions) {
+		dialogChoicesTotal.With("unavailable").Inc()
+		g.client.SocketSend(packets.NewDenyResponse("You can't say that right now"))
+		return
+	}
+
+	if choice.Hook != "" {
+		event := server.DialogEvent{DialogId: d.dialog.Id, NodeId: d.node.Id, ChoiceId: choice.Id, TargetId: d.targetId}
+		if err := g.runDialogHook(choice.Hook, event); err != nil {
+			g.logger.Printf("Hook %s refused choice %s of dialog %s: %v", choice.Hook, choice.Id, d.dialog.Id, err)
+			dialogChoicesTotal.With("refused").Inc()
+			g.client.SocketSend(packets.NewDenyResponse(err.Error()))
+			return
+		}
+	}
+
+	var overflow map[string]int64
+	if changes {
+		if overflow, err = g.applyDialogEffects(d.dialog, choice); errors.Is(err, errMissingDialogItems) {
+			dialogChoicesTotal.With("unavailable").Inc()
+			g.client.SocketSend(packets.NewDenyResponse("You don't have what that takes"))
+			return
+		} else if err != nil {
+			g.logger.Errorf("Error applying choice %s of dialog %s: %v", choice.Id, d.dialog.Id, err)
+			dialogChoicesTotal.With("failed").Inc()
+			g.client.SocketSend(packets.NewDenyResponse("Something went wrong - please try again later"))
+			return
+		}
+		g.client.WorldStats().ItemsDestroyed(effects.TakeItems)
+		g.client.WorldStats().ItemsCreated(effects.GiveItems)
+	}
+	dialogChoicesTotal.With("made").Inc()
+
+	if len(effects.TakeItems) > 0 || len(effects.GiveItems) > 0 {
+		g.sendInventory()
+	}
+	g.overflowed(overflow)
+	for questId, stage := range effects.SetQuestStages {
+		g.client.SocketSend(packets.NewQuestStage(questId, stage))
+	}
+
+	if next, exists := d.dialog.Node(choice.Next); exists {
+		g.enterDialogNode(next)
+	} else {
+		g.closeDialog("")
+	}
+}
+
+// Apply what the choice does in one transaction, so items are never taken without the quest moving along or the
+// other way round. Returns what was given that didn't fit in our player's inventory, and was mailed to them instead.
+func (g *InGame) applyDialogEffects(dialog *gamedata.Dialog, choice *gamedata.DialogChoice) (map[string]int64, error) {
+	effects := &choice.Effects
+	var overflow map[string]int64
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		ctx := g.client.DbTx().Ctx
+		for itemId, quantity := range effects.TakeItems {
+			removed, err := queries.RemoveInventoryItem(ctx, db.RemoveInventoryItemParams{
+				Quantity: quantity,
+				PlayerID: g.player.DbId,
+				ItemID:   itemId,
+			})
+			if err != nil {
+				return err
+			}
+			if removed == 0 {
+				return errMissingDialogItems
+			}
+		}
+		var err error
+		if overflow, err = g.addItems(queries, effects.GiveItems); err != nil {
+			return err
+		}
+		for questId, stage := range effects.SetQuestStages {
+			err := queries.SetQuestStage(ctx, db.SetQuestStageParams{
+				PlayerID:  g.player.DbId,
+				QuestID:   questId,
+				Stage:     stage,
+				UpdatedAt: time.Now().Unix(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return g.client.DbTx().RecordEvent(queries, "dialog.choice_made", map[string]any{
+			"player_id":   g.player.DbId,
+			"player":      g.player.Name,
+			"dialog_id":   dialog.Id,
+			"choice_id":   choice.Id,
+			"took":        effects.TakeItems,
+			"gave":        effects.GiveItems,
+			"quest_stage": effects.SetQuestStages,
+		})
+	})
+	return overflow, err
+}
+
+// Hooks the game data names but nothing registered are refused, so a choice never goes through with its script
+// missing
+func (g *InGame) runDialogHook(name string, event server.DialogEvent) error {
+	hook, exists := g.client.DialogHook(name)
+	if !exists {
+		g.logger.Errorf("No hook registered for %s, which dialog %s runs", name, event.DialogId)
+		return errors.New("You can't say that right now")
+	}
+	return hook(g.client, event)
+}
+
+func (g *InGame) dialogProgress() (*dialogProgress, error) {
+	ctx := g.client.DbTx().Ctx
+	queries := g.client.DbTx().Queries
+
+	itemRows, err := queries.GetInventoryItems(ctx, g.player.DbId)
+	if err != nil {
+		return nil, err
+	}
+	questRows, err := queries.GetQuestStages(ctx, g.player.DbId)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &dialogProgress{
+		items:       make(map[string]int64, len(itemRows)),
+		questStages: make(map[string]int64, len(questRows)),
+	}
+	for _, row := range itemRows {
+		progress.items[row.ItemID] = row.Quantity
+	}
+	for _, row := range questRows {
+		progress.questStages[row.QuestID] = row.Stage
+	}
+	return progress, nil
+}
+
+// Tell the client how far the player is through each quest they've started
+func (g *InGame) sendQuestStages() {
+	rows, err := g.client.DbTx().Queries.GetQuestStages(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting quest stages: %v", err)
+		return
+	}
+	for _, row := range rows {
+		g.client.SocketSend(packets.NewQuestStage(row.QuestID, row.Stage))
+	}
+}
@@ -0,0 +1,135 @@
+package states
+
+import (
+	"fmt"
+	"regexp"
+	"server/internal/server/gamedata"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+)
+
+// How emotes are written in chat
+var emoteTokenPattern = regexp.MustCompile(`:([a-z][a-z0-9_]*):`)
+
+// The emotes our player can use. Updated from other goroutines when the player's best score is synced, hence the lock.
+type emoteSet struct {
+	ids map[string]struct{}
+	mux sync.RWMutex
+}
+
+func (e *emoteSet) has(emoteId string) bool {
+	e.mux.RLock()
+	defer e.mux.RUnlock()
+	_, unlocked := e.ids[emoteId]
+	return unlocked
+}
+
+func (e *emoteSet) loaded() bool {
+	e.mux.RLock()
+	defer e.mux.RUnlock()
+	return e.ids != nil
+}
+
+// Replace the set, returning the emotes that weren't in it before and whether this is the first time it's been set
+func (e *emoteSet) set(ids map[string]struct{}) ([]string, bool) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	first := e.ids == nil
+	var added []string
+	for id := range ids {
+		if _, existed := e.ids[id]; !existed {
+			added = append(added, id)
+		}
+	}
+	e.ids = ids
+	return added, first
+}
+
+// The first emote in the chat message our player isn't allowed to use, if any
+func (g *InGame) forbiddenEmote(msg string) (string, error) {
+	for _, match := range emoteTokenPattern.FindAllStringSubmatch(msg, -1) {
+		emoteId := match[1]
+		emote, exists := g.client.GameData().Emote(emoteId)
+		if !exists {
+			return emoteId, fmt.Errorf("no such emote")
+		}
+		if !g.available(&emote.Availability) {
+			return emoteId, fmt.Errorf("emote not available")
+		}
+		if !g.emotes.has(emoteId) {
+			return emoteId, fmt.Errorf("emote not unlocked")
+		}
+	}
+	return "", nil
+}
+
+// Work out which emotes the player has unlocked. The first time, the whole registry is sent to the client; after that
+// only newly unlocked emotes are.
+func (g *InGame) refreshEmotes() {
+	emotes := g.client.GameData().Emotes
+	if len(emotes) == 0 {
+		return
+	}
+
+	grantedIds, err := g.client.DbTx().Queries.GetUnlockedEmotes(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting unlocked emotes: %v", err)
+		return
+	}
+	granted := make(map[string]struct{}, len(grantedIds))
+	for _, emoteId := range grantedIds {
+		granted[emoteId] = struct{}{}
+	}
+
+	unlocked := make(map[string]struct{}, len(emotes))
+	for _, emote := range emotes {
+		if !g.available(&emote.Availability) {
+			continue
+		}
+		if _, isGranted := granted[emote.Id]; isGranted || g.meetsEmoteUnlock(emote.Unlock) {
+			unlocked[emote.Id] = struct{}{}
+		}
+	}
+
+	added, first := g.emotes.set(unlocked)
+
+	if first {
+		emoteMessages := make([]*packets.EmoteMessage, len(emotes))
+		for i, emote := range emotes {
+			_, isUnlocked := unlocked[emote.Id]
+			emoteMessages[i] = &packets.EmoteMessage{Id: emote.Id, Asset: emote.Asset, Unlocked: isUnlocked}
+		}
+		g.client.SocketSend(packets.NewEmotes(emoteMessages))
+		return
+	}
+
+	for _, emoteId := range added {
+		g.client.SocketSend(packets.NewEmoteUnlocked(emoteId))
+	}
+}
+
+func (g *InGame) meetsEmoteUnlock(unlock gamedata.EmoteUnlock) bool {
+	if unlock.Granted {
+		return false
+	}
+	if unlock.BestScore > 0 && g.player.BestScore < unlock.BestScore {
+		return false
+	}
+	if unlock.ItemId != "" {
+		inventory, exists := objects.GetComponent[*objects.Inventory](&g.player.Components)
+		if !exists || inventory.Items[unlock.ItemId] <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// The server tells us when an emote has been granted to our player from outside the game, e.g. by the admin API
+func (g *InGame) handleEmoteUnlocked(senderId uint64, _ *packets.Packet_EmoteUnlocked) {
+	if senderId != 0 {
+		return
+	}
+	g.refreshEmotes()
+}
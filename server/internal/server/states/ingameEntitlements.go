@@ -0,0 +1,62 @@
+package states
+
+import (
+	"server/pkg/packets"
+	"slices"
+	"sync"
+)
+
+// What our player's account is entitled to, which can change while they're in game, e.g. when they buy DLC
+type entitlementSet struct {
+	ids []string
+	mux sync.RWMutex
+}
+
+func (s *entitlementSet) has(id string) bool {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return slices.Contains(s.ids, id)
+}
+
+func (s *entitlementSet) list() []string {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return slices.Clone(s.ids)
+}
+
+// Returns whether they're any different from before
+func (s *entitlementSet) set(ids []string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	changed := !slices.Equal(s.ids, ids)
+	s.ids = ids
+	return changed
+}
+
+// Sent by our client, or by the server itself when the account's entitlements have changed
+func (g *InGame) handleEntitlementListRequest(senderId uint64, _ *packets.Packet_EntitlementListRequest) {
+	if senderId != g.client.Id() && senderId != 0 {
+		return
+	}
+
+	// Emotes requiring an entitlement may have come or gone, and the zone the player's in does next time they move
+	if g.loadEntitlements() {
+		g.refreshEmotes()
+	}
+	g.sendEntitlements()
+}
+
+// Returns whether the account's entitlements are any different from before. If they can't be loaded the player goes
+// without, which is only until they're next loaded.
+func (g *InGame) loadEntitlements() bool {
+	ids, err := g.client.Entitlements().List(g.client.DbTx().Ctx, g.client.DbTx().Queries, g.userId)
+	if err != nil {
+		g.logger.Errorf("Error loading entitlements: %v", err)
+	}
+	return g.entitlements.set(ids)
+}
+
+func (g *InGame) sendEntitlements() {
+	ids := g.entitlements.list()
+	g.client.SocketSend(packets.NewEntitlementList(ids, g.client.Entitlements().CharacterSlots(ids)))
+}
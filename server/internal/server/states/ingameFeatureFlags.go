@@ -0,0 +1,61 @@
+package states
+
+import (
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+)
+
+const (
+	// Anything a client claims it can do past these is ignored
+	maxClientCapabilities = 32
+	maxCapabilityLength   = 64
+)
+
+// The capabilities the client logged in with, capped so a client can't make every flag check slow
+func clientCapabilities(claimed []string) []string {
+	capabilities := make([]string, 0, min(len(claimed), maxClientCapabilities))
+	for _, capability := range claimed {
+		if len(capabilities) == maxClientCapabilities {
+			break
+		}
+		if capability != "" && len(capability) <= maxCapabilityLength {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	return capabilities
+}
+
+// Sent by our client, or by the server itself when flags are changed
+func (g *InGame) handleFeatureFlagsRequest(senderId uint64, _ *packets.Packet_FeatureFlagsRequest) {
+	if senderId != g.client.Id() && senderId != 0 {
+		return
+	}
+	g.sendFeatureFlags()
+
+	// Emotes behind flags may have come or gone
+	if senderId == 0 {
+		g.refreshEmotes()
+	}
+}
+
+func (g *InGame) sendFeatureFlags() {
+	enabled := g.client.FeatureFlags().Evaluate(g.player.DbId, g.capabilities)
+	g.client.SocketSend(packets.NewFeatureFlags(enabled))
+}
+
+// Whether seasonal, flagged or entitled content is around for our player
+func (g *InGame) available(availability *gamedata.Availability) bool {
+	if required := availability.RequiresEntitlement; required != "" && !g.entitlements.has(required) {
+		return false
+	}
+	return g.client.FeatureFlags().Available(availability, g.player.DbId, g.capabilities)
+}
+
+// The zone the position is in as far as our player is concerned, leaving out zones that aren't around for them, and
+// sandbox zones unless they're in that one
+func (g *InGame) zoneAt(x float64, y float64) *gamedata.Zone {
+	sandbox, _ := g.client.Sandbox().Inside(g.client.Id())
+	return g.client.GameData().ZoneAtWhere(x, y, func(zone *gamedata.Zone) bool {
+		return (!zone.Sandbox || zone == sandbox) && g.available(&zone.Availability)
+	})
+}
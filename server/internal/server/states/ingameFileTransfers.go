@@ -0,0 +1,11 @@
+package states
+
+import "server/pkg/packets"
+
+// Our client telling us how a file the server pushed to it went
+func (g *InGame) handleFileReceived(senderId uint64, message *packets.Packet_FileReceived) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.client.FileTransfers().Received(senderId, message.FileReceived)
+}
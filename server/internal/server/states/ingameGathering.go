@@ -0,0 +1,352 @@
+package states
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"time"
+)
+
+// How far (beyond touching) the player may be from a node while gathering it
+const gatherRangeBuffer = 20
+
+// Any more of the client's seed than this is ignored
+const maxClientSeedLength = 64
+
+type gatherAttempt struct {
+	nodeId    uint64
+	startedAt time.Time
+
+	// Rolls for the node's bonus yields, if it has any
+	encounter *server.Encounter
+}
+
+var errMissingIngredients = errors.New("missing ingredients")
+
+const lockedOutMessage = "You've already looted that since it last reset"
+
+func (g *InGame) handleResourceNode(senderId uint64, message *packets.Packet_ResourceNode) {
+	g.client.SocketSendAs(message, senderId)
+}
+
+func (g *InGame) handleGatherStart(senderId uint64, message *packets.Packet_GatherStart) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if reason, ok := g.startGathering(message.GatherStart.NodeId, message.GatherStart.ClientSeed); !ok {
+		g.client.SocketSend(packets.NewDenyResponse(reason))
+	}
+}
+
+// Start gathering the node, or say why our player can't
+func (g *InGame) startGathering(nodeId uint64, clientSeed string) (string, bool) {
+	node, err := g.getResourceNode(nodeId)
+	if err != nil {
+		g.logger.Printf("Could not start gathering: %v", err)
+		return "Nothing to gather there", false
+	}
+
+	if !node.Available() {
+		return "That has already been gathered", false
+	}
+
+	if kind, exists := g.client.GameData().ResourceNodeKinds[node.Kind]; exists {
+		if !g.available(&kind.Availability) {
+			return "You can't gather that right now", false
+		}
+		if g.lockedOut(kind) {
+			return lockedOutMessage, false
+		}
+	}
+
+	if err := g.validatePlayerCloseToObject(node.X, node.Y, node.Radius, gatherRangeBuffer); err != nil {
+		g.logger.Printf("Could not start gathering: %v", err)
+		return "Too far away to gather that", false
+	}
+
+	// Whatever was being gathered before is over, so its rolls can be checked
+	if g.gathering != nil && g.gathering.encounter != nil {
+		g.revealRolls(g.gathering.encounter)
+	}
+
+	g.gathering = &gatherAttempt{nodeId: nodeId, startedAt: objects.Sim.Now()}
+	if kind, exists := g.client.GameData().ResourceNodeKinds[node.Kind]; exists && len(kind.BonusYields) > 0 {
+		if len(clientSeed) > maxClientSeedLength {
+			clientSeed = clientSeed[:maxClientSeedLength]
+		}
+		g.nextEncounter.Start(clientSeed)
+		g.gathering.encounter = g.nextEncounter
+		g.commitNextEncounter()
+	}
+	return "", true
+}
+
+// Pick the seed for the next gather with bonus yields, and send its commitment, so it's settled before the client
+// picks the seed it'll start that gather with
+func (g *InGame) commitNextEncounter() {
+	g.nextEncounter = server.NewEncounter()
+	g.client.SocketSend(packets.NewRollCommit(g.nextEncounter.Id, g.nextEncounter.Commitment()))
+}
+
+func (g *InGame) revealRolls(encounter *server.Encounter) {
+	seed, rolls := encounter.Reveal()
+	g.client.SocketSend(packets.NewRollReveal(encounter.Id, seed, encounter.ClientSeed, rolls))
+}
+
+func (g *InGame) handleGatherFinish(senderId uint64, message *packets.Packet_GatherFinish) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	errMsg := "Could not verify gathering: "
+
+	attempt := g.gathering
+	g.gathering = nil
+	if attempt != nil && attempt.encounter != nil {
+		defer g.revealRolls(attempt.encounter)
+	}
+
+	if g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return
+	}
+
+	if attempt == nil || attempt.nodeId != message.GatherFinish.NodeId {
+		g.logger.Println(errMsg + "gathering was never started on this node")
+		return
+	}
+
+	node, err := g.getResourceNode(attempt.nodeId)
+	if err != nil {
+		g.logger.Println(errMsg + err.Error())
+		return
+	}
+
+	kind, exists := g.client.GameData().ResourceNodeKinds[node.Kind]
+	if !exists {
+		g.logger.Printf(errMsg+"unknown resource node kind %s", node.Kind)
+		return
+	}
+
+	// The player has to stay in range for the whole time it takes to gather
+	gatherTime := time.Duration(kind.GatherSeconds * float64(time.Second))
+	if elapsed := objects.Sim.Since(attempt.startedAt); elapsed < gatherTime {
+		g.logger.Printf(errMsg+"finished too quickly (time: %v, min acceptable time: %v)", elapsed, gatherTime)
+		return
+	}
+
+	if err := g.validatePlayerCloseToObject(node.X, node.Y, node.Radius, gatherRangeBuffer); err != nil {
+		g.logger.Println(errMsg + err.Error())
+		g.client.SocketSend(packets.NewDenyResponse("Moved too far away while gathering"))
+		return
+	}
+
+	// Don't use up the node if the items can't be saved
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	// Checked again since the lockout may have reset, or the boss been looted, since gathering started
+	if g.lockedOut(kind) {
+		g.client.SocketSend(packets.NewDenyResponse(lockedOutMessage))
+		return
+	}
+
+	if !node.TryDeplete() {
+		g.client.SocketSend(packets.NewDenyResponse("Someone else gathered that first"))
+		return
+	}
+
+	items := maps.Clone(kind.Yields)
+	if items == nil {
+		items = make(map[string]int64)
+	}
+	if attempt.encounter != nil {
+		for _, bonus := range kind.BonusYields {
+			if attempt.encounter.Roll() < bonus.Chance {
+				items[bonus.ItemId] += bonus.Quantity
+			}
+		}
+	}
+
+	var overflow map[string]int64
+	err = g.client.DbTx().InTx(func(queries *db.Queries) error {
+		var err error
+		if overflow, err = g.addItems(queries, items); err != nil {
+			return err
+		}
+		if err := g.client.BossLockouts().Record(g.client.DbTx().Ctx, queries, g.player.DbId, kind); err != nil {
+			return err
+		}
+		event := map[string]any{
+			"player_id": g.player.DbId,
+			"player":    g.player.Name,
+			"node_kind": node.Kind,
+			"items":     items,
+		}
+		// Kept so the outcome can be proven fair later on
+		if attempt.encounter != nil {
+			seed, rolls := attempt.encounter.Reveal()
+			event["encounter"] = map[string]any{
+				"id":          attempt.encounter.Id,
+				"commitment":  hex.EncodeToString(attempt.encounter.Commitment()),
+				"seed":        hex.EncodeToString(seed),
+				"client_seed": attempt.encounter.ClientSeed,
+				"rolls":       rolls,
+			}
+		}
+		return g.client.DbTx().RecordEvent(queries, "items.gathered", event)
+	})
+	if err != nil {
+		// Nothing was granted, so the node's left for gathering again, and nobody was ever told it was used up
+		node.Restore()
+		g.logger.Errorf("Error granting gathered items: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to gather - please try again later"))
+		return
+	}
+	g.client.WorldStats().ItemsCreated(items)
+
+	nodeMessage := packets.NewResourceNode(attempt.nodeId, node)
+	g.client.Broadcast(nodeMessage)
+	g.client.SocketSend(nodeMessage)
+	g.sendInventory()
+	g.overflowed(overflow)
+	if kind.Lockout != "" {
+		g.sendBossLockouts()
+	}
+}
+
+func (g *InGame) handleCraftRequest(senderId uint64, message *packets.Packet_CraftRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return
+	}
+
+	recipeId := message.CraftRequest.RecipeId
+	recipe, exists := g.client.GameData().Recipes[recipeId]
+	if !exists {
+		g.logger.Printf("Tried to craft unknown recipe %s", recipeId)
+		g.client.SocketSend(packets.NewDenyResponse("Unknown recipe"))
+		return
+	}
+	if !g.available(&recipe.Availability) {
+		g.client.SocketSend(packets.NewDenyResponse("That recipe isn't available right now"))
+		return
+	}
+
+	// Ingredients and outputs change in one transaction, so items are never lost or duplicated halfway through
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	var overflow map[string]int64
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		for itemId, quantity := range recipe.Ingredients {
+			removed, err := queries.RemoveInventoryItem(g.client.DbTx().Ctx, db.RemoveInventoryItemParams{
+				Quantity: quantity,
+				PlayerID: g.player.DbId,
+				ItemID:   itemId,
+			})
+			if err != nil {
+				return err
+			}
+			if removed == 0 {
+				return errMissingIngredients
+			}
+		}
+		var err error
+		if overflow, err = g.addItems(queries, recipe.Outputs); err != nil {
+			return err
+		}
+		return g.client.DbTx().RecordEvent(queries, "items.crafted", map[string]any{
+			"player_id":   g.player.DbId,
+			"player":      g.player.Name,
+			"recipe_id":   recipeId,
+			"ingredients": recipe.Ingredients,
+			"outputs":     recipe.Outputs,
+		})
+	})
+
+	if errors.Is(err, errMissingIngredients) {
+		g.client.SocketSend(packets.NewDenyResponse("Not enough ingredients"))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error crafting %s: %v", recipeId, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to craft - please try again later"))
+		return
+	}
+	g.client.WorldStats().ItemsDestroyed(recipe.Ingredients)
+	g.client.WorldStats().ItemsCreated(recipe.Outputs)
+
+	g.sendInventory()
+	g.overflowed(overflow)
+}
+
+// Grant our player as much of the items as they can carry, mailing them the rest, and return what was mailed
+func (g *InGame) addItems(queries *db.Queries, items map[string]int64) (map[string]int64, error) {
+	return g.client.Inventories().Grant(g.client.DbTx().Ctx, queries, g.player.DbId, items)
+}
+
+// Let our player know what didn't fit in their inventory is waiting in their mail. Call once it's been committed.
+func (g *InGame) overflowed(overflow map[string]int64) {
+	if len(overflow) == 0 {
+		return
+	}
+	g.client.SocketSendAs(packets.NewChat("Your inventory is full, so what didn't fit has been sent to your mail"), 0)
+	g.sendMailbox()
+}
+
+// Sent by the server itself when the inventory's been changed without our client knowing, e.g. repaired by a
+// consistency check, to reload it
+func (g *InGame) handleInventory(senderId uint64, _ *packets.Packet_Inventory) {
+	if senderId != 0 {
+		return
+	}
+	g.sendInventory()
+}
+
+func (g *InGame) sendInventory() {
+	// The sandbox has an inventory of its own
+	if g.sandbox != nil {
+		g.client.SocketSend(packets.NewInventory(g.sandbox.items))
+		return
+	}
+
+	rows, err := g.client.DbTx().Queries.GetInventoryItems(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting inventory: %v", err)
+		return
+	}
+
+	items := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		items[row.ItemID] = row.Quantity
+	}
+	g.player.Components.Add(&objects.Inventory{Items: items})
+	g.client.SocketSend(packets.NewInventory(items))
+
+	// Some emotes are unlocked by having items. The first refresh is left to OnEnter.
+	if g.emotes.loaded() {
+		g.refreshEmotes()
+	}
+}
+
+func (g *InGame) getResourceNode(nodeId uint64) (*objects.ResourceNode, error) {
+	node, exists := g.client.SharedGameObjects().ResourceNodes.Get(nodeId)
+	if !exists {
+		return nil, fmt.Errorf("resource node with ID %d does not exist", nodeId)
+	}
+	return node, nil
+}
@@ -0,0 +1,132 @@
+package states
+
+import (
+	"fmt"
+	"maps"
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+	"slices"
+	"strconv"
+)
+
+// What players can do to each other. Players have no kind in the game data to say otherwise.
+var playerInteractions = map[string]*gamedata.Interaction{
+	"inspect": {Handler: gamedata.InteractionInspect, Range: gamedata.DefaultInteractionRange},
+}
+
+// Something in the world our player is interacting with
+type interactionTarget struct {
+	kind   string
+	x, y   float64
+	radius float64
+
+	// By verb
+	interactions map[string]*gamedata.Interaction
+
+	// What inspecting it shows
+	describe func() []*packets.InteractionFieldMessage
+}
+
+func (g *InGame) handleInteract(senderId uint64, message *packets.Packet_Interact) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	targetId, verb := message.Interact.TargetId, message.Interact.Verb
+	target, found := g.interactionTarget(targetId)
+	if !found {
+		g.client.SocketSend(packets.NewInteractionDenied(targetId, verb, "There's nothing there"))
+		return
+	}
+
+	interaction, can := target.interactions[verb]
+	if !can {
+		g.client.SocketSend(packets.NewInteractionDenied(targetId, verb, "You can't do that"))
+		return
+	}
+
+	if err := g.validatePlayerCloseToObject(target.x, target.y, target.radius, interaction.Range); err != nil {
+		g.logger.Printf("Could not %s %d: %v", verb, targetId, err)
+		g.client.SocketSend(packets.NewInteractionDenied(targetId, verb, "Too far away"))
+		return
+	}
+
+	switch interaction.Handler {
+	case gamedata.InteractionLoot:
+		if reason, ok := g.startGathering(targetId, ""); !ok {
+			g.client.SocketSend(packets.NewInteractionDenied(targetId, verb, reason))
+			return
+		}
+		var fields []*packets.InteractionFieldMessage
+		if kind, exists := g.client.GameData().ResourceNodeKinds[target.kind]; exists {
+			fields = append(fields, interactionField("gather_seconds", strconv.FormatFloat(kind.GatherSeconds, 'f', -1, 64)))
+		}
+		g.client.SocketSend(packets.NewInteractionResult(targetId, verb, interaction.Handler, interaction.Text, fields))
+	case gamedata.InteractionShop:
+		g.client.SocketSend(packets.NewInteractionResult(targetId, verb, interaction.Handler, interaction.Text, nil))
+		g.sendStore()
+	case gamedata.InteractionDialog:
+		g.client.SocketSend(packets.NewInteractionResult(targetId, verb, interaction.Handler, interaction.Text, nil))
+		if dialog, exists := g.client.GameData().Dialogs[interaction.Dialog]; exists {
+			g.openDialog(targetId, dialog, interaction.Range)
+		}
+	case gamedata.InteractionInspect:
+		g.client.SocketSend(packets.NewInteractionResult(targetId, verb, interaction.Handler, interaction.Text, target.describe()))
+	}
+}
+
+// The resource node or other player with the ID, if there is one
+func (g *InGame) interactionTarget(targetId uint64) (*interactionTarget, bool) {
+	if node, err := g.getResourceNode(targetId); err == nil {
+		kind, exists := g.client.GameData().ResourceNodeKinds[node.Kind]
+		if !exists {
+			return nil, false
+		}
+		return &interactionTarget{
+			kind:         node.Kind,
+			x:            node.X,
+			y:            node.Y,
+			radius:       node.Radius,
+			interactions: kind.Interactions,
+			describe: func() []*packets.InteractionFieldMessage {
+				state := "ready"
+				if !node.Available() {
+					state = "depleted"
+				}
+				fields := []*packets.InteractionFieldMessage{
+					interactionField("kind", node.Kind),
+					interactionField("state", state),
+				}
+				for _, itemId := range slices.Sorted(maps.Keys(kind.Yields)) {
+					fields = append(fields, interactionField("yields."+itemId, strconv.FormatInt(kind.Yields[itemId], 10)))
+				}
+				return fields
+			},
+		}, true
+	}
+
+	if targetId == g.client.Id() {
+		return nil, false
+	}
+	if player, err := g.getOtherPlayer(targetId); err == nil {
+		return &interactionTarget{
+			kind:         "player",
+			x:            player.X,
+			y:            player.Y,
+			radius:       player.Radius,
+			interactions: playerInteractions,
+			describe: func() []*packets.InteractionFieldMessage {
+				return []*packets.InteractionFieldMessage{
+					interactionField("kind", "player"),
+					interactionField("name", player.Name),
+					interactionField("best_score", fmt.Sprint(player.BestScore)),
+				}
+			},
+		}, true
+	}
+	return nil, false
+}
+
+func interactionField(name string, value string) *packets.InteractionFieldMessage {
+	return &packets.InteractionFieldMessage{Name: name, Value: value}
+}
@@ -0,0 +1,86 @@
+package states
+
+import (
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"time"
+)
+
+// Sent by the server when our player shared in a kill. They grow by their share of the mass straight away, and are
+// given the items and further quest stages in one transaction, so neither's ever granted without the other.
+func (g *InGame) handleKillCredit(senderId uint64, message *packets.Packet_KillCredit) {
+	if senderId != 0 || g.sandbox != nil {
+		return
+	}
+	credit := message.KillCredit
+
+	if credit.Mass > 0 {
+		g.player.Radius = g.nextRadius(credit.Mass)
+		go g.syncPlayerBestScore()
+	}
+
+	items := make(map[string]int64, len(credit.Items))
+	for _, item := range credit.Items {
+		items[item.ItemId] += item.Quantity
+	}
+	var advanced []*packets.QuestStageMessage
+	var overflow map[string]int64
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		ctx := g.client.DbTx().Ctx
+		var err error
+		if overflow, err = g.addItems(queries, items); err != nil {
+			return err
+		}
+
+		// Credit only ever moves a quest along, never back to a stage the player's already past
+		current := make(map[string]int64)
+		if len(credit.QuestStages) > 0 {
+			rows, err := queries.GetQuestStages(ctx, g.player.DbId)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				current[row.QuestID] = row.Stage
+			}
+		}
+		for _, stage := range credit.QuestStages {
+			if current[stage.QuestId] >= stage.Stage {
+				continue
+			}
+			err := queries.SetQuestStage(ctx, db.SetQuestStageParams{
+				PlayerID:  g.player.DbId,
+				QuestID:   stage.QuestId,
+				Stage:     stage.Stage,
+				UpdatedAt: time.Now().Unix(),
+			})
+			if err != nil {
+				return err
+			}
+			advanced = append(advanced, stage)
+		}
+
+		return g.client.DbTx().RecordEvent(queries, "kill.credited", map[string]any{
+			"player_id": g.player.DbId,
+			"player":    g.player.Name,
+			"actor_id":  credit.ActorId,
+			"share":     credit.Share,
+			"mass":      credit.Mass,
+			"items":     items,
+		})
+	})
+	if err != nil {
+		g.logger.Errorf("Error granting credit for killing %d: %v", credit.ActorId, err)
+		g.client.SocketSend(packets.NewKillCredit(credit.ActorId, credit.Share, credit.Mass, nil, nil))
+		return
+	}
+	g.client.WorldStats().ItemsCreated(items)
+
+	g.client.SocketSend(message)
+	if len(items) > 0 {
+		g.sendInventory()
+	}
+	g.overflowed(overflow)
+	for _, stage := range advanced {
+		g.client.SocketSend(packets.NewQuestStage(stage.QuestId, stage.Stage))
+	}
+}
@@ -0,0 +1,130 @@
+package states
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+)
+
+var errInventoryFull = errors.New("no room in the inventory")
+
+// Sent by our client, or by the server itself when new mail arrives
+func (g *InGame) handleMailboxRequest(senderId uint64, _ *packets.Packet_MailboxRequest) {
+	if senderId != g.client.Id() && senderId != 0 {
+		return
+	}
+	g.sendMailbox()
+}
+
+func (g *InGame) handleClaimMail(senderId uint64, message *packets.Packet_ClaimMail) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return
+	}
+
+	// The mail is gone once it's claimed, so its items can only ever be added once. Only as many as fit are taken, and
+	// the rest are left in the mail for later.
+	var left int64
+	err := g.client.DbTx().InTx(func(queries *db.Queries) error {
+		ctx := g.client.DbTx().Ctx
+		params := db.ClaimMailParams{ID: int64(message.ClaimMail.MailId), PlayerID: g.player.DbId}
+		mail, err := queries.GetMailById(ctx, db.GetMailByIdParams{ID: params.ID, PlayerID: params.PlayerID})
+		if err != nil {
+			return err
+		}
+
+		if mail.ItemID == "" || mail.Quantity <= 0 {
+			_, err := queries.ClaimMail(ctx, params)
+			return err
+		}
+
+		fits, _, err := g.client.Inventories().Fit(ctx, queries, g.player.DbId, map[string]int64{mail.ItemID: mail.Quantity})
+		if err != nil {
+			return err
+		}
+		taken := fits[mail.ItemID]
+		if taken == 0 {
+			return errInventoryFull
+		}
+		if left = mail.Quantity - taken; left > 0 {
+			var updated int64
+			updated, err = queries.TakeMailItems(ctx, db.TakeMailItemsParams{Quantity: taken, ID: params.ID, PlayerID: params.PlayerID})
+			if err == nil && updated == 0 {
+				err = sql.ErrNoRows
+			}
+		} else {
+			_, err = queries.ClaimMail(ctx, params)
+		}
+		if err != nil {
+			return err
+		}
+
+		// Coins from the auction house came from another player, where coins from anyone else were granted by us
+		if mail.ItemID == gamedata.CurrencyItemId && mail.Sender == server.AuctionHouseSender {
+			err := g.client.CurrencyCaps().Transfer(g.client.DbTx(), queries, server.CurrencyTransfer{
+				UserId:     g.userId,
+				PlayerDbId: g.player.DbId,
+				Kind:       server.CurrencyAuctionProceeds,
+				Amount:     taken,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		_, err = g.addItems(queries, map[string]int64{mail.ItemID: taken})
+		return err
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		g.client.SocketSend(packets.NewDenyResponse("That mail has already been claimed"))
+		return
+	} else if errors.Is(err, errInventoryFull) {
+		g.client.SocketSend(packets.NewDenyResponse("There's no room in your inventory for that"))
+		return
+	} else if errors.Is(err, server.ErrCurrencyTransferCap) {
+		g.client.SocketSend(packets.NewDenyResponse("That's more coins than can change hands at once, please contact support"))
+		return
+	} else if errors.Is(err, server.ErrCurrencyDailyLimit) {
+		g.client.SocketSend(packets.NewDenyResponse(currencyDailyLimitMessage))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error claiming mail %d: %v", message.ClaimMail.MailId, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to claim - please try again later"))
+		return
+	}
+
+	if left > 0 {
+		g.client.SocketSendAs(packets.NewChat(fmt.Sprintf("Your inventory is full, so %d are still waiting in that mail", left)), 0)
+	}
+	g.sendMailbox()
+	g.sendInventory()
+}
+
+func (g *InGame) sendMailbox() {
+	rows, err := g.client.DbTx().Queries.GetMail(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting mail: %v", err)
+		return
+	}
+
+	mail := make([]*packets.MailMessage, len(rows))
+	for i, row := range rows {
+		mail[i] = &packets.MailMessage{
+			Id:       uint64(row.ID),
+			Sender:   row.Sender,
+			Subject:  row.Subject,
+			ItemId:   row.ItemID,
+			Quantity: row.Quantity,
+			SentAt:   row.SentAt,
+		}
+	}
+	g.client.SocketSend(packets.NewMailbox(mail))
+}
@@ -0,0 +1,46 @@
+package states
+
+import (
+	"errors"
+	"server/internal/server"
+	"server/pkg/packets"
+	"strings"
+)
+
+// Report another player for misbehaving, opening a case for moderators with what they've been doing lately
+func (g *InGame) handleReportPlayer(senderId uint64, message *packets.Packet_ReportPlayer) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	report := message.ReportPlayer
+	if report.PlayerId == g.client.Id() {
+		g.client.SocketSend(packets.NewDenyResponse("You can't report yourself"))
+		return
+	}
+	reason := strings.TrimSpace(report.Reason)
+	if reason == "" {
+		g.client.SocketSend(packets.NewDenyResponse("Please say why you're reporting them"))
+		return
+	}
+
+	err := g.client.ModerationCases().Report(g.player, report.PlayerId, reason)
+	switch {
+	case errors.Is(err, server.ErrReportedNotFound):
+		g.client.SocketSend(packets.NewDenyResponse("That player isn't here anymore"))
+		return
+	case errors.Is(err, server.ErrAlreadyReported):
+		g.client.SocketSend(packets.NewDenyResponse("You've already reported them, thanks"))
+		return
+	case errors.Is(err, server.ErrReportCooldown):
+		g.client.SocketSend(packets.NewDenyResponse("You're reporting too fast, try again in a moment"))
+		return
+	case err != nil:
+		g.logger.Errorf("Error reporting player %d: %v", report.PlayerId, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to send your report, please try again later"))
+		return
+	}
+
+	g.logger.Printf("Reported player %d", report.PlayerId)
+	g.client.SocketSend(packets.NewOkResponse())
+}
@@ -0,0 +1,119 @@
+package states
+
+import (
+	"fmt"
+	"math"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+// How fast players move, when they're moving
+const playerSpeed = 150.0
+
+// The most latency allowed for when checking players are close enough to what they're doing something with
+const maxLagCompensation = 250 * time.Millisecond
+
+// How far away a point players are walked to can be, so paths aren't worked out across the whole world at once
+const maxMoveToDistance = 5000.0
+
+// Where the player's being walked to, if they asked to be instead of steering themselves
+type movePath struct {
+	waypoints []objects.Position
+	mux       sync.Mutex
+}
+
+func (p *movePath) set(waypoints []objects.Position) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.waypoints = waypoints
+}
+
+func (p *movePath) following() bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return len(p.waypoints) > 0
+}
+
+// The waypoints still to go, nearest first
+func (p *movePath) remaining() []objects.Position {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	return append([]objects.Position(nil), p.waypoints...)
+}
+
+func (p *movePath) next() (objects.Position, bool) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if len(p.waypoints) == 0 {
+		return objects.Position{}, false
+	}
+	return p.waypoints[0], true
+}
+
+// Move on to the waypoint after the next one, returning whether there was one
+func (p *movePath) advance() bool {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if len(p.waypoints) > 0 {
+		p.waypoints = p.waypoints[1:]
+	}
+	return len(p.waypoints) > 0
+}
+
+// Walk the player to the point over the next ticks, around resource nodes, so a click-to-move client only has to send
+// where it was clicked rather than a direction every time it changes. A point on a resource node has the player stop
+// next to it.
+func (g *InGame) handleMoveTo(senderId uint64, message *packets.Packet_MoveTo) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.client.PriorityLogin().Active(g.client.Id())
+
+	target := objects.Position{X: message.MoveTo.X, Y: message.MoveTo.Y}
+	if math.IsNaN(target.X) || math.IsNaN(target.Y) || math.IsInf(target.X, 0) || math.IsInf(target.Y, 0) {
+		g.client.SocketSend(packets.NewDenyResponse("You can't move there"))
+		g.rejected(message, "target isn't a number")
+		return
+	}
+	if math.Hypot(target.X-g.player.X, target.Y-g.player.Y) > maxMoveToDistance {
+		g.client.SocketSend(packets.NewDenyResponse("That's too far away to move to in one go"))
+		g.rejected(message, fmt.Sprintf("target is more than %.0f away", maxMoveToDistance))
+		return
+	}
+
+	var obstacles []objects.Obstacle
+	g.client.SharedGameObjects().ResourceNodes.ForEach(func(_ uint64, node *objects.ResourceNode) {
+		obstacles = append(obstacles, objects.Obstacle{Position: node.Position, Radius: node.Radius})
+	})
+	waypoints := objects.FindPath(g.player.Position, target, g.player.Radius, obstacles)
+	g.path.set(waypoints)
+	g.player.Speed = playerSpeed
+	g.logger.Sampledf("Moving to (%.0f, %.0f) from (%.0f, %.0f) by %d waypoints", target.X, target.Y, g.player.X, g.player.Y, len(waypoints))
+
+	g.startPlayerUpdates()
+}
+
+// Point the player at the next waypoint of their path, returning how far to move this tick, which is only as far as
+// the waypoint so it isn't overshot. The player stops once they're at the end of the path.
+func (g *InGame) followPath(distance float64) float64 {
+	waypoint, following := g.path.next()
+	if !following {
+		return distance
+	}
+
+	dx, dy := waypoint.X-g.player.X, waypoint.Y-g.player.Y
+	remaining := math.Hypot(dx, dy)
+	if remaining > 0 {
+		g.player.Direction = math.Atan2(dy, dx)
+	}
+	if remaining > distance {
+		return distance
+	}
+
+	if !g.path.advance() {
+		g.player.Speed = 0
+	}
+	return remaining
+}
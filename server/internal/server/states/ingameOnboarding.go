@@ -0,0 +1,69 @@
+package states
+
+import (
+	"context"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"time"
+)
+
+// Prompt the player with the first tutorial step they haven't done yet, in the order the steps are currently defined
+// in. Players who've done every step aren't sent anything, so they skip the tutorial. Steps just completed count as
+// done whether or not they're in the database yet.
+func (g *InGame) promptOnboardingStep(justCompleted ...string) {
+	steps := g.client.GameData().OnboardingSteps
+	if len(steps) == 0 {
+		return
+	}
+
+	completedIds, err := g.client.DbTx().Queries.GetCompletedOnboardingSteps(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting completed onboarding steps: %v", err)
+		return
+	}
+
+	completed := make(map[string]struct{}, len(completedIds)+len(justCompleted))
+	for _, stepId := range append(completedIds, justCompleted...) {
+		completed[stepId] = struct{}{}
+	}
+
+	for i, step := range steps {
+		if _, done := completed[step.Id]; !done {
+			g.client.SocketSend(packets.NewOnboardingStep(step.Id, step.Prompt, i+1, len(steps)))
+			return
+		}
+	}
+}
+
+func (g *InGame) handleCompleteOnboardingStep(senderId uint64, message *packets.Packet_CompleteOnboardingStep) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	stepId := message.CompleteOnboardingStep.StepId
+	exists := false
+	for _, step := range g.client.GameData().OnboardingSteps {
+		exists = exists || step.Id == stepId
+	}
+	if !exists {
+		g.logger.Printf("Tried to complete unknown onboarding step %s", stepId)
+		return
+	}
+
+	params := db.CompleteOnboardingStepParams{
+		PlayerID:    g.player.DbId,
+		StepID:      stepId,
+		CompletedAt: time.Now().Unix(),
+	}
+	err := g.client.DbTx().Save(server.SaveTierCold, g.player.DbId, "onboarding_step:"+stepId, func(ctx context.Context, queries *db.Queries) error {
+		return queries.CompleteOnboardingStep(ctx, params)
+	})
+	if err != nil {
+		g.logger.Errorf("Error completing onboarding step %s: %v", stepId, err)
+		return
+	}
+
+	// The step may not have been written yet
+	g.promptOnboardingStep(stepId)
+}
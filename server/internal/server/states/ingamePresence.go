@@ -0,0 +1,33 @@
+package states
+
+import (
+	"server/pkg/packets"
+	"strings"
+)
+
+// Presence subscriptions are only taken in game, so only players can follow each other, and last until the player
+// leaves the game. Players who've blocked ours always look offline to them.
+
+func (g *InGame) handlePresenceSubscribe(senderId uint64, message *packets.Packet_PresenceSubscribe) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	blockedBy, err := g.client.DbTx().Queries.GetPlayersBlocking(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting players who've blocked ours: %v", err)
+		return
+	}
+	hidden := make(map[string]struct{}, len(blockedBy))
+	for _, name := range blockedBy {
+		hidden[strings.ToLower(name)] = struct{}{}
+	}
+	g.client.Presence().Subscribe(g.client.Id(), message.PresenceSubscribe.Names, hidden)
+}
+
+func (g *InGame) handlePresenceUnsubscribe(senderId uint64, message *packets.Packet_PresenceUnsubscribe) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.client.Presence().Unsubscribe(g.client.Id(), message.PresenceUnsubscribe.Names)
+}
@@ -0,0 +1,139 @@
+package states
+
+import (
+	"math"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	// How long after interacting with a player they stay more relevant
+	relevancyInteractionWindow = 10 * time.Second
+
+	// A player within this angle of where ours is heading is taken to be what ours is going for
+	relevancyTargetAngle = math.Pi / 12
+)
+
+// How often updates about a player are passed on, by how relevant they are to ours. Tiers are checked in order, and
+// the first one the score reaches applies.
+var relevancyTiers = []struct {
+	minScore float64
+	every    uint64
+}{
+	{minScore: 0.6, every: 1},
+	{minScore: 0.3, every: 3},
+	{minScore: 0, every: 10},
+}
+
+var relevancySkippedTotal = metrics.NewCounter("mmo_relevancy_updates_skipped_total", "Player updates not sent to a client because the player wasn't relevant enough to them.")
+
+// Decides which updates about other players are worth sending to our client. Nearby players, ones we've interacted
+// with recently and the one we're heading for are updated every tick, while the rest are only updated every few.
+type relevancyTracker struct {
+	interactions map[uint64]time.Time
+	received     map[uint64]uint64
+	mux          sync.Mutex
+}
+
+func (r *relevancyTracker) interacted(playerId uint64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.interactions == nil {
+		r.interactions = make(map[uint64]time.Time)
+	}
+	r.interactions[playerId] = time.Now()
+}
+
+func (r *relevancyTracker) forget(playerId uint64) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.interactions, playerId)
+	delete(r.received, playerId)
+}
+
+// Whether this update about the player should be sent, given how relevant they are. The first update about a player
+// is always sent.
+func (r *relevancyTracker) shouldSend(playerId uint64, score float64) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.received == nil {
+		r.received = make(map[uint64]uint64)
+	}
+	count := r.received[playerId]
+	r.received[playerId] = count + 1
+
+	for _, tier := range relevancyTiers {
+		if score >= tier.minScore {
+			return count%tier.every == 0
+		}
+	}
+	return true
+}
+
+func (r *relevancyTracker) interactedRecently(playerId uint64) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	at, exists := r.interactions[playerId]
+	return exists && time.Since(at) < relevancyInteractionWindow
+}
+
+// From 0 (irrelevant) to 1 (as relevant as can be). Players further than our player's area of interest, as stretched
+// or shrunk by how noticeable they are, only count as relevant if something else makes them so.
+func (g *InGame) relevancy(playerId uint64, other *packets.PlayerMessage) float64 {
+	dx := other.X - g.player.X
+	dy := other.Y - g.player.Y
+	distance := max(math.Hypot(dx, dy)-other.Radius-g.player.Radius, 0)
+	aoiRadius := g.client.Visibility().InterestRadius(playerId)
+	if distance > aoiRadius {
+		distance = aoiRadius
+	}
+
+	// Whoever our player is heading straight for is what they care about most. Players don't move until the client
+	// first sends a direction.
+	moving := g.cancelPlayerUpdateLoop != nil && g.player.Speed > 0
+	if moving && distance < aoiRadius {
+		angle := math.Abs(math.Remainder(math.Atan2(dy, dx)-g.player.Direction, 2*math.Pi))
+		if angle <= relevancyTargetAngle {
+			return 1
+		}
+	}
+
+	score := 1 - distance/aoiRadius
+	if g.relevance.interactedRecently(playerId) {
+		score += 0.5
+	}
+	return min(score, 1)
+}
+
+// Pass the update about the other player on to our client if it's relevant enough
+func (g *InGame) sendRelevantPlayer(senderId uint64, message *packets.Packet_Player) {
+	if !g.relevance.shouldSend(senderId, g.relevancy(senderId, message.Player)) {
+		relevancySkippedTotal.Inc()
+		return
+	}
+	g.client.SocketSendAs(message, senderId)
+}
+
+// Sent by the server as players come into and leave our player's area of interest, when it's managing interest. Those
+// coming in are sent where they are straight away, rather than waiting on their next update.
+func (g *InGame) handleInterest(senderId uint64, message *packets.Packet_Interest) {
+	if senderId != 0 {
+		return
+	}
+
+	g.client.SocketSend(message)
+	for _, playerId := range message.Interest.Left {
+		g.relevance.forget(playerId)
+	}
+	for _, playerId := range message.Interest.Entered {
+		// So the next update about them is sent whatever their relevance, as if they'd never been seen
+		g.relevance.forget(playerId)
+		if player, exists := g.client.SharedGameObjects().Players.Get(playerId); exists {
+			g.client.SocketSendAs(packets.NewPlayer(playerId, player), playerId)
+		}
+	}
+}
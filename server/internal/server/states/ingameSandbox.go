@@ -0,0 +1,200 @@
+package states
+
+import (
+	"fmt"
+	"maps"
+	"server/internal/server/gamedata"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"strconv"
+)
+
+// Where our player was before they went into the sandbox, so they can be put back as they were, and what they've been
+// given in it, which is only ever kept here
+type sandboxTrip struct {
+	returnTo objects.Position
+	radius   float64
+	items    map[string]int64
+}
+
+// Whether our player's in the sandbox. Asks the hub rather than the trip, since it's also checked while syncing the
+// player.
+func (g *InGame) inSandbox() bool {
+	_, inside := g.client.Sandbox().Inside(g.client.Id())
+	return inside
+}
+
+// A GM command for the sandbox. Apart from leaving it, they're only for accounts with the entitlement a sandbox zone
+// requires.
+func (g *InGame) handleSandboxCommand(senderId uint64, message *packets.Packet_SandboxCommand) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	command := message.SandboxCommand
+	if command.Command == "leave" {
+		g.leaveSandbox()
+		g.sendSandboxStatus("Left the sandbox")
+		return
+	}
+
+	zone, exists := g.client.Sandbox().Zone(func(zone *gamedata.Zone) bool {
+		return g.available(&zone.Availability)
+	})
+	if !exists {
+		g.logger.Printf("Refused sandbox command %q, player %s isn't allowed in the sandbox", command.Command, g.player.Name)
+		g.client.SocketSend(packets.NewDenyResponse("The sandbox isn't available"))
+		return
+	}
+
+	if command.Command == "enter" {
+		g.enterSandbox(zone)
+		g.sendSandboxStatus("Entered " + zone.Name)
+		return
+	}
+	if !g.inSandbox() {
+		g.client.SocketSend(packets.NewDenyResponse("Enter the sandbox first"))
+		return
+	}
+
+	var status string
+	var err error
+	switch command.Command {
+	case "wave":
+		status, err = g.sandboxWave(command.Args)
+	case "give":
+		status, err = g.sandboxGive(command.Args)
+	case "scenario":
+		status, err = g.sandboxScenario(command.Args)
+	case "clear":
+		g.client.Sandbox().Clear(g.client.Id())
+		clear(g.sandbox.items)
+		g.sendInventory()
+		status = "Cleared the sandbox"
+	default:
+		err = fmt.Errorf("unknown command %q", command.Command)
+	}
+	if err != nil {
+		g.client.SocketSend(packets.NewDenyResponse("Sandbox command failed: " + err.Error()))
+		return
+	}
+	g.sendSandboxStatus(status)
+}
+
+func (g *InGame) enterSandbox(zone *gamedata.Zone) {
+	if g.inSandbox() {
+		return
+	}
+	g.sandbox = &sandboxTrip{returnTo: g.player.Position, radius: g.player.Radius, items: make(map[string]int64)}
+	g.client.Sandbox().Enter(g.client.Id(), zone)
+	g.logger.Printf("Player %s entered sandbox %s", g.player.Name, zone.Id)
+
+	g.path.set(nil)
+	g.player.X, g.player.Y = objects.SpawnCoordsWithin(zone.MinX, zone.MinY, zone.MaxX, zone.MaxY, g.player.Radius, g.client.SharedGameObjects().Players)
+	g.enterZone(zone)
+	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	g.sendInventory()
+}
+
+// Take our player out of the sandbox, if they're in it, and tell the client where they are now
+func (g *InGame) leaveSandbox() {
+	if !g.returnFromSandbox() {
+		return
+	}
+	g.enterZone(g.zoneAt(g.player.X, g.player.Y))
+	g.client.SocketSend(packets.NewPlayer(g.client.Id(), g.player))
+	g.sendInventory()
+}
+
+// Put our player back where and as they were before they went into the sandbox, if they're in it. Returns whether they
+// were.
+func (g *InGame) returnFromSandbox() bool {
+	if g.sandbox == nil {
+		return false
+	}
+	trip := g.sandbox
+	g.sandbox = nil
+	g.client.Sandbox().Leave(g.client.Id())
+	g.logger.Printf("Player %s left the sandbox", g.player.Name)
+
+	g.path.set(nil)
+	g.player.Position = trip.returnTo
+	g.player.Radius = trip.radius
+	return true
+}
+
+// wave count [radius] [name]
+func (g *InGame) sandboxWave(args []string) (string, error) {
+	if len(args) < 1 {
+		return "", fmt.Errorf("usage: wave count [radius] [name]")
+	}
+	wave := &gamedata.SandboxWave{Name: "Dummy", Radius: 20, Distance: gamedata.DefaultSandboxWaveDistance, Color: g.player.Color}
+	count, err := strconv.Atoi(args[0])
+	if err != nil {
+		return "", fmt.Errorf("count must be a number")
+	}
+	wave.Count = count
+	if len(args) > 1 {
+		if wave.Radius, err = strconv.ParseFloat(args[1], 64); err != nil {
+			return "", fmt.Errorf("radius must be a number")
+		}
+	}
+	if len(args) > 2 {
+		wave.Name = args[2]
+	}
+	if err := gamedata.ValidateSandboxWave(wave); err != nil {
+		return "", err
+	}
+
+	spawned, err := g.client.Sandbox().SpawnWave(g.client.Id(), wave)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Spawned %d %s", spawned, wave.Name), nil
+}
+
+// give item_id quantity
+func (g *InGame) sandboxGive(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", fmt.Errorf("usage: give item_id quantity")
+	}
+	quantity, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || quantity <= 0 {
+		return "", fmt.Errorf("quantity must be a positive number")
+	}
+	g.sandbox.items[args[0]] += quantity
+	g.sendInventory()
+	return fmt.Sprintf("Gave %d %s", quantity, args[0]), nil
+}
+
+// scenario id
+func (g *InGame) sandboxScenario(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("usage: scenario id")
+	}
+	scenario, exists := g.client.GameData().SandboxScenarios[args[0]]
+	if !exists {
+		return "", fmt.Errorf("no such scenario %q", args[0])
+	}
+	if err := g.client.Sandbox().RunScenario(g.client.Id(), scenario); err != nil {
+		return "", err
+	}
+	if len(scenario.Items) > 0 {
+		for itemId, quantity := range scenario.Items {
+			g.sandbox.items[itemId] += quantity
+		}
+		g.sendInventory()
+	}
+	return "Started " + scenario.Name, nil
+}
+
+func (g *InGame) sendSandboxStatus(status string) {
+	scenarios := slices.Sorted(maps.Keys(g.client.GameData().SandboxScenarios))
+	zone, inside := g.client.Sandbox().Inside(g.client.Id())
+	zoneId := ""
+	if inside {
+		zoneId = zone.Id
+	}
+	g.client.SocketSend(packets.NewSandboxStatus(inside, zoneId, g.client.Sandbox().Actors(g.client.Id()), scenarios, status))
+}
@@ -0,0 +1,36 @@
+package states
+
+import "server/pkg/packets"
+
+func (g *InGame) handleListSessions(senderId uint64, _ *packets.Packet_ListSessions) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.sendSessions()
+}
+
+// Log out one of the account's other sessions, or all of them, then send what's left
+func (g *InGame) handleLogoutSession(senderId uint64, message *packets.Packet_LogoutSession) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	request := message.LogoutSession
+	_, err := g.client.Sessions().LogOut(g.client.DbTx().Ctx, g.client.DbTx().Queries, g.client, g.userId, request.SessionId, request.AllOthers)
+	if err != nil {
+		g.logger.Errorf("Error logging out sessions of player %s: %v", g.player.Name, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to log out - please try again later"))
+		return
+	}
+	g.sendSessions()
+}
+
+func (g *InGame) sendSessions() {
+	sessions, err := g.client.Sessions().List(g.client.DbTx().Ctx, g.client.DbTx().Queries, g.client, g.userId)
+	if err != nil {
+		g.logger.Errorf("Error getting sessions of player %s: %v", g.player.Name, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to get sessions - please try again later"))
+		return
+	}
+	g.client.SocketSend(packets.NewSessions(sessions))
+}
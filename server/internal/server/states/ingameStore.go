@@ -0,0 +1,149 @@
+package states
+
+import (
+	"errors"
+	"server/internal/server"
+	"server/internal/server/gamedata"
+	"server/pkg/packets"
+	"sort"
+)
+
+// The most a receipt from an app store can be, which is plenty for any of them
+const maxStoreReceiptLength = 64 * 1024
+
+func (g *InGame) handleStoreRequest(senderId uint64, _ *packets.Packet_StoreRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+	g.sendStore()
+}
+
+// Send what's for sale to our player, and which of it they already have
+func (g *InGame) sendStore() {
+	emoteIds, err := g.client.DbTx().Queries.GetUnlockedEmotes(g.client.DbTx().Ctx, g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting unlocked emotes: %v", err)
+		g.client.SocketSend(packets.NewDenyResponse("Could not load the store right now"))
+		return
+	}
+	owned := make(map[string]struct{}, len(emoteIds))
+	for _, emoteId := range emoteIds {
+		owned[emoteId] = struct{}{}
+	}
+
+	var entries []*packets.StoreEntryMessage
+	for _, entry := range g.client.GameData().Store {
+		if !g.available(&entry.Availability) {
+			continue
+		}
+		products := make([]*packets.StoreProductMessage, 0, len(entry.Products))
+		for platform, productId := range entry.Products {
+			products = append(products, &packets.StoreProductMessage{Platform: platform, ProductId: productId})
+		}
+		sort.Slice(products, func(i, j int) bool { return products[i].Platform < products[j].Platform })
+
+		_, isOwned := owned[entry.EmoteId]
+		if entry.Entitlement != "" {
+			isOwned = g.entitlements.has(entry.Entitlement)
+		}
+		entries = append(entries, &packets.StoreEntryMessage{
+			Id:          entry.Id,
+			Name:        entry.Name,
+			EmoteId:     entry.EmoteId,
+			Entitlement: entry.Entitlement,
+			Price:       entry.Price,
+			Products:    products,
+			Owned:       isOwned,
+		})
+	}
+	g.client.SocketSend(packets.NewStore(entries))
+}
+
+// The entry our player asked for, if it's for sale to them. Denies the request if it isn't.
+func (g *InGame) storeEntry(entryId string) (*gamedata.StoreEntry, bool) {
+	if g.client.Maintenance().Frozen() {
+		g.client.SocketSend(packets.NewDenyResponse("The server is about to restart, try again after"))
+		return nil, false
+	}
+	if !g.client.DbTx().Healthy() {
+		g.client.SocketSend(packets.NewDenyResponse(dbUnavailableMessage))
+		return nil, false
+	}
+
+	entry, exists := g.client.GameData().StoreEntry(entryId)
+	if !exists || !g.available(&entry.Availability) {
+		g.client.SocketSend(packets.NewDenyResponse("That isn't for sale"))
+		return nil, false
+	}
+	return entry, true
+}
+
+func (g *InGame) handleStorePurchase(senderId uint64, message *packets.Packet_StorePurchase) {
+	if senderId != g.client.Id() {
+		return
+	}
+	entry, ok := g.storeEntry(message.StorePurchase.EntryId)
+	if !ok {
+		return
+	}
+
+	err := g.client.Store().Buy(g.player.DbId, g.player.Name, entry)
+	if errors.Is(err, server.ErrNotForCoins) {
+		g.client.SocketSend(packets.NewDenyResponse("That can't be bought with coins"))
+		return
+	} else if errors.Is(err, server.ErrAlreadyOwned) {
+		g.client.SocketSend(packets.NewDenyResponse("You already have that"))
+		return
+	} else if errors.Is(err, server.ErrNotEnoughCoins) {
+		g.client.SocketSend(packets.NewDenyResponse("Not enough coins"))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error buying store entry %s: %v", entry.Id, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to buy - please try again later"))
+		return
+	}
+
+	g.logger.Printf("Bought %s for %d coins", entry.Id, entry.Price)
+	g.client.SocketSend(packets.NewOkResponse())
+
+	// Which also unlocks the emote for the client
+	g.sendInventory()
+	if entry.Entitlement != "" {
+		g.handleEntitlementListRequest(0, nil)
+	}
+}
+
+func (g *InGame) handleStoreReceipt(senderId uint64, message *packets.Packet_StoreReceipt) {
+	if senderId != g.client.Id() {
+		return
+	}
+	claim := message.StoreReceipt
+	if claim.Receipt == "" || len(claim.Receipt) > maxStoreReceiptLength {
+		g.client.SocketSend(packets.NewDenyResponse("Invalid receipt"))
+		return
+	}
+	entry, ok := g.storeEntry(claim.EntryId)
+	if !ok {
+		return
+	}
+
+	receipt, err := g.client.Store().SubmitReceipt(g.player.DbId, entry, claim.Platform, claim.Receipt)
+	if errors.Is(err, server.ErrNotOnPlatform) {
+		g.client.SocketSend(packets.NewDenyResponse("That isn't sold there"))
+		return
+	} else if errors.Is(err, server.ErrAlreadyOwned) {
+		g.client.SocketSend(packets.NewDenyResponse("You already have that"))
+		return
+	} else if errors.Is(err, server.ErrReceiptUsed) {
+		g.logger.Printf("Submitted a %s receipt for %s that's already been used", claim.Platform, entry.Id)
+		g.client.SocketSend(packets.NewDenyResponse("That receipt has already been used"))
+		return
+	} else if err != nil {
+		g.logger.Errorf("Error submitting %s receipt for %s: %v", claim.Platform, entry.Id, err)
+		g.client.SocketSend(packets.NewDenyResponse("Failed to check your purchase - please try again later"))
+		return
+	}
+
+	g.logger.Printf("Submitted %s receipt %d for %s", receipt.Platform, receipt.ID, entry.Id)
+	g.client.SocketSend(packets.NewStoreReceiptStatus(uint64(receipt.ID), receipt.EntryID, receipt.Status, receipt.Reason))
+}
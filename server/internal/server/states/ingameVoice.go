@@ -0,0 +1,126 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+	"time"
+)
+
+const (
+	// Longer than any SDP offer or ICE candidate a WebRTC library should send
+	maxVoicePayloadLength = 16 * 1024
+
+	maxVoicePartyLength = 32
+)
+
+// Load the player's mute and put them back in their party, if they were in one before respawning
+func (g *InGame) enterVoice() {
+	mute, muted, err := g.client.Voice().Mute(g.player.DbId)
+	if err != nil {
+		g.logger.Errorf("Error getting voice mute: %v", err)
+	}
+	g.voiceMuted, g.voiceMutedUntil = muted, mute.ExpiresAt
+
+	if g.voiceParty != "" {
+		g.client.Voice().JoinParty(g.client.Id(), g.voiceParty)
+	}
+	if g.voiceMuted || g.voiceParty != "" {
+		g.sendVoiceStatus(mute.Reason)
+	}
+}
+
+// Sent by our client to the peer it's for, and relayed from other clients with the peer set to them
+func (g *InGame) handleVoiceSignal(senderId uint64, message *packets.Packet_VoiceSignal) {
+	if senderId != g.client.Id() {
+		if g.isBlocked(senderId) {
+			g.client.Voice().Signal(senderId, g.client.Id(), server.VoiceSignalHangup)
+			return
+		}
+		g.client.SocketSendAs(message, senderId)
+		return
+	}
+
+	signal := message.VoiceSignal
+	switch signal.Kind {
+	case server.VoiceSignalOffer, server.VoiceSignalAnswer, server.VoiceSignalIce, server.VoiceSignalHangup:
+	default:
+		g.client.SocketSend(packets.NewDenyResponse("Unknown voice signal"))
+		return
+	}
+	if len(signal.Payload) > maxVoicePayloadLength {
+		g.client.SocketSend(packets.NewDenyResponse("Voice signal too long"))
+		return
+	}
+
+	// Hanging up is always allowed, so a muted player's calls can still end cleanly
+	if signal.Kind != server.VoiceSignalHangup && g.isVoiceMuted() {
+		g.client.SocketSend(packets.NewDenyResponse("You are muted from voice chat"))
+		return
+	}
+
+	if err := g.client.Voice().Signal(g.client.Id(), signal.PeerId, signal.Kind); err != nil {
+		g.client.SocketSend(packets.NewDenyResponse("Too far away to talk to them"))
+		return
+	}
+	g.client.PassToPeer(packets.NewVoiceSignal(g.client.Id(), signal.Kind, signal.Payload), signal.PeerId)
+}
+
+func (g *InGame) handleVoiceParty(senderId uint64, message *packets.Packet_VoiceParty) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	party := message.VoiceParty.Party
+	if len(party) > maxVoicePartyLength {
+		g.client.SocketSend(packets.NewDenyResponse("Party name too long"))
+		return
+	}
+
+	g.voiceParty = party
+	g.client.Voice().JoinParty(g.client.Id(), party)
+	g.sendVoiceStatus("")
+}
+
+// Sent by the server itself when the player is muted or unmuted
+func (g *InGame) handleVoiceStatus(senderId uint64, message *packets.Packet_VoiceStatus) {
+	if senderId != 0 {
+		return
+	}
+
+	status := message.VoiceStatus
+	g.voiceMuted = status.Muted
+	g.voiceMutedUntil = time.Time{}
+	if status.MutedUntil > 0 {
+		g.voiceMutedUntil = time.UnixMilli(status.MutedUntil)
+	}
+	if g.voiceMuted {
+		g.client.Voice().HangUpAll(g.client.Id())
+	}
+	g.sendVoiceStatus(status.Reason)
+}
+
+// Muted players aren't given TURN credentials, since they can't set up calls to use them for anyway
+func (g *InGame) handleIceServersRequest(senderId uint64, _ *packets.Packet_IceServersRequest) {
+	if senderId != g.client.Id() {
+		return
+	}
+
+	servers, expiresAt := g.client.Voice().IceServers(g.player.DbId, !g.isVoiceMuted())
+	var expiresAtMillis int64
+	if !expiresAt.IsZero() {
+		expiresAtMillis = expiresAt.UnixMilli()
+	}
+	g.client.SocketSend(packets.NewIceServers(servers, expiresAtMillis))
+}
+
+func (g *InGame) isVoiceMuted() bool {
+	return g.voiceMuted && (g.voiceMutedUntil.IsZero() || time.Now().Before(g.voiceMutedUntil))
+}
+
+func (g *InGame) sendVoiceStatus(reason string) {
+	var mutedUntil int64
+	if !g.voiceMutedUntil.IsZero() {
+		mutedUntil = g.voiceMutedUntil.UnixMilli()
+	}
+	g.client.SocketSend(packets.NewVoiceStatus(g.isVoiceMuted(), mutedUntil, reason, g.voiceParty))
+}
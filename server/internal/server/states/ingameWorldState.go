@@ -0,0 +1,136 @@
+package states
+
+import (
+	"context"
+	"math"
+	"server/internal/server"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"sort"
+	"time"
+)
+
+// How many objects are sent each tick while streaming the world to a player who's just come in
+const worldStateBatchSize = 40
+
+// What gets sent first. Objects are sent by priority, then by distance.
+type worldStatePriority int
+
+const (
+	worldStateNearActor worldStatePriority = iota
+	worldStateNearProp
+	worldStateDistant
+)
+
+// One thing in the world the client is yet to be told about
+type worldStateObject struct {
+	id       uint64
+	priority worldStatePriority
+	distance float64
+
+	// Exactly one of these is set
+	player *objects.Player
+	node   *objects.ResourceNode
+	spore  *objects.Spore
+}
+
+// Tell the client about everything already in the world a batch at a time, so that joining a crowded zone doesn't
+// mean one huge packet the client has to wait on before it can see anything. Players near ours come first, then the
+// props around them, then everything further away, nearest first.
+func (g *InGame) streamWorldState(ctx context.Context) {
+	queue := g.worldStateQueue()
+	ticker := time.NewTicker(server.TickInterval)
+	defer ticker.Stop()
+
+	for len(queue) > 0 {
+		n := min(worldStateBatchSize, len(queue))
+		g.sendWorldStateBatch(queue[:n])
+		queue = queue[n:]
+		if len(queue) == 0 {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *InGame) worldStateQueue() []worldStateObject {
+	// Objects outside our player's area of interest are only sent once everything nearer has been. Other players
+	// stretch or shrink it by how noticeable they are.
+	aoiRadius := g.client.Visibility().AoiRadius()
+
+	var queue []worldStateObject
+	add := func(id uint64, position objects.Position, radius float64, near worldStatePriority, object worldStateObject) {
+		nearDistance := aoiRadius
+		if object.player != nil {
+			nearDistance = g.client.Visibility().InterestRadius(id)
+		}
+		object.id = id
+		object.distance = max(math.Hypot(position.X-g.player.X, position.Y-g.player.Y)-radius-g.player.Radius, 0)
+		object.priority = near
+		if object.distance > nearDistance {
+			object.priority = worldStateDistant
+		}
+		queue = append(queue, object)
+	}
+
+	shared := g.client.SharedGameObjects()
+
+	// When interest's managed, players are sent as they come into our player's area of interest instead
+	if !g.client.Interest().Enabled() {
+		shared.Players.ForEach(func(playerId uint64, player *objects.Player) {
+			if playerId != g.client.Id() && g.client.Visibility().CanSee(g.client.Id(), playerId) {
+				add(playerId, player.Position, player.Radius, worldStateNearActor, worldStateObject{player: player})
+			}
+		})
+	}
+	shared.ResourceNodes.ForEach(func(nodeId uint64, node *objects.ResourceNode) {
+		add(nodeId, node.Position, node.Radius, worldStateNearProp, worldStateObject{node: node})
+	})
+	shared.Spores.ForEach(func(sporeId uint64, spore *objects.Spore) {
+		add(sporeId, spore.Position, spore.Radius, worldStateNearProp, worldStateObject{spore: spore})
+	})
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].priority != queue[j].priority {
+			return queue[i].priority < queue[j].priority
+		}
+		return queue[i].distance < queue[j].distance
+	})
+	return queue
+}
+
+// Send the batch, as few packets as the kinds of objects in it allow. Anything that's gone since the queue was made
+// is left out, since the client has already been told it's gone, or never needed to be told it was there.
+func (g *InGame) sendWorldStateBatch(batch []worldStateObject) {
+	shared := g.client.SharedGameObjects()
+	nodes := make(map[uint64]*objects.ResourceNode)
+	spores := make(map[uint64]*objects.Spore)
+	for _, object := range batch {
+		switch {
+		case object.player != nil:
+			if player, exists := shared.Players.Get(object.id); exists {
+				g.client.SocketSendAs(packets.NewPlayer(object.id, player), object.id)
+			}
+		case object.node != nil:
+			if _, exists := shared.ResourceNodes.Get(object.id); exists {
+				nodes[object.id] = object.node
+			}
+		case object.spore != nil:
+			if _, exists := shared.Spores.Get(object.id); exists {
+				spores[object.id] = object.spore
+			}
+		}
+	}
+
+	if len(nodes) > 0 {
+		g.client.SocketSend(packets.NewResourceNodesBatch(nodes))
+	}
+	if len(spores) > 0 {
+		g.client.SocketSend(packets.NewSporesBatch(spores))
+	}
+}
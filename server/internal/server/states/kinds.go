@@ -0,0 +1,125 @@
+package states
+
+import "server/pkg/packets"
+
+// What each state accepts from its own client's socket. Anything else is dropped before it's fully decoded, see
+// server.MessageKindFilter. Messages from other clients reach HandleMessage regardless.
+
+// Messages about the client itself rather than any one state, accepted in every state
+var clientScopedKinds = packets.NewKindSet(
+	&packets.Packet_TimeSyncRequest{},
+	&packets.Packet_Subscribe{},
+	&packets.Packet_Unsubscribe{},
+	&packets.Packet_Custom{},
+	&packets.Packet_ImpersonationConsent{},
+	&packets.Packet_PromptResponse{},
+)
+
+var connectedKinds = clientScopedKinds.Union(packets.NewKindSet(
+	&packets.Packet_LoginRequest{},
+	&packets.Packet_RegisterRequest{},
+	&packets.Packet_SimulatorLoginRequest{},
+	&packets.Packet_HiscoreBoardRequest{},
+	&packets.Packet_CaptchaResponse{},
+))
+
+// Only answers to the welcome steps, which are prompts, until the player's through them
+var welcomingKinds = clientScopedKinds
+
+var browsingHiscoresKinds = clientScopedKinds.Union(packets.NewKindSet(
+	&packets.Packet_FinishedBrowsingHiscores{},
+	&packets.Packet_SearchHiscore{},
+))
+
+var inGameKinds = clientScopedKinds.Union(packets.NewKindSet(
+	&packets.Packet_PlayerDirection{},
+	&packets.Packet_MoveTo{},
+	&packets.Packet_Chat{},
+	&packets.Packet_SporeConsumed{},
+	&packets.Packet_PlayerConsumed{},
+	&packets.Packet_Disconnect{},
+	&packets.Packet_GatherStart{},
+	&packets.Packet_GatherFinish{},
+	&packets.Packet_CraftRequest{},
+	&packets.Packet_BlockPlayer{},
+	&packets.Packet_UnblockPlayer{},
+	&packets.Packet_MinimapRequest{},
+	&packets.Packet_CompleteOnboardingStep{},
+	&packets.Packet_AuctionListRequest{},
+	&packets.Packet_AuctionSearchRequest{},
+	&packets.Packet_AuctionBuyout{},
+	&packets.Packet_MailboxRequest{},
+	&packets.Packet_ClaimMail{},
+	&packets.Packet_FeatureFlagsRequest{},
+	&packets.Packet_EntitlementListRequest{},
+	&packets.Packet_ChannelListRequest{},
+	&packets.Packet_ChannelSwitch{},
+	&packets.Packet_VoiceSignal{},
+	&packets.Packet_VoiceParty{},
+	&packets.Packet_IceServersRequest{},
+	&packets.Packet_DataExportRequest{},
+	&packets.Packet_AccountDeletionRequest{},
+	&packets.Packet_AccountDeletionCancel{},
+	&packets.Packet_AccountDataStatusRequest{},
+	&packets.Packet_FileReceived{},
+	&packets.Packet_StoreRequest{},
+	&packets.Packet_StorePurchase{},
+	&packets.Packet_StoreReceipt{},
+	&packets.Packet_Interact{},
+	&packets.Packet_DialogChoose{},
+	&packets.Packet_AnnouncementHistoryRequest{},
+	&packets.Packet_ChatReact{},
+	&packets.Packet_ReportPlayer{},
+	&packets.Packet_DebugOverlayRequest{},
+	&packets.Packet_ListSessions{},
+	&packets.Packet_LogoutSession{},
+	&packets.Packet_SandboxCommand{},
+	&packets.Packet_PresenceSubscribe{},
+	&packets.Packet_PresenceUnsubscribe{},
+))
+
+// What our own client can't do while in the sandbox, since it would be kept, like gathering or buying something
+var sandboxBlockedKinds = packets.NewKindSet(
+	&packets.Packet_GatherStart{},
+	&packets.Packet_GatherFinish{},
+	&packets.Packet_CraftRequest{},
+	&packets.Packet_AuctionBuyout{},
+	&packets.Packet_ClaimMail{},
+	&packets.Packet_StorePurchase{},
+	&packets.Packet_StoreReceipt{},
+	&packets.Packet_CompleteOnboardingStep{},
+	&packets.Packet_DialogChoose{},
+	&packets.Packet_Interact{},
+	&packets.Packet_ChannelSwitch{},
+)
+
+// World simulators only drive their actors, so apart from keeping time they're not in on anything players are
+var simulatingKinds = packets.NewKindSet(
+	&packets.Packet_TimeSyncRequest{},
+	&packets.Packet_Custom{},
+	&packets.Packet_ActorSpawnRequest{},
+	&packets.Packet_ActorUpdates{},
+	&packets.Packet_ActorDespawn{},
+	&packets.Packet_ActorDamage{},
+	&packets.Packet_ActorKilled{},
+)
+
+func (c *Connected) AcceptsKind(kind packets.MsgKind) bool {
+	return connectedKinds.Has(kind)
+}
+
+func (w *Welcoming) AcceptsKind(kind packets.MsgKind) bool {
+	return welcomingKinds.Has(kind)
+}
+
+func (b *BrowsingHiscores) AcceptsKind(kind packets.MsgKind) bool {
+	return browsingHiscoresKinds.Has(kind)
+}
+
+func (g *InGame) AcceptsKind(kind packets.MsgKind) bool {
+	return inGameKinds.Has(kind)
+}
+
+func (s *Simulating) AcceptsKind(kind packets.MsgKind) bool {
+	return simulatingKinds.Has(kind)
+}
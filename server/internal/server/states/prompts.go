@@ -0,0 +1,15 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// Players can answer prompts put to them in every state
+func handlePromptResponse(client server.ClientInterfacer, senderId uint64, message *packets.Packet_PromptResponse) {
+	if senderId != client.Id() {
+		return
+	}
+	response := message.PromptResponse
+	client.Prompts().Answer(response.PromptId, response.OptionId)
+}
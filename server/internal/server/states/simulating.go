@@ -0,0 +1,137 @@
+package states
+
+import (
+	"fmt"
+	"server/internal/server"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+)
+
+// A trusted world simulator, which drives actors of its own instead of a player. It's told about players the same as
+// a player's client is, so it can have its actors react to them.
+type Simulating struct {
+	client server.ClientInterfacer
+	name   string
+	logger *logging.Logger
+}
+
+func (s *Simulating) Name() string {
+	return "Simulating"
+}
+
+func (s *Simulating) SetClient(client server.ClientInterfacer) {
+	s.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s %s]: ", client.Id(), s.Name(), s.name)
+	s.logger = logging.States.NewLogger(loggingPrefix)
+}
+
+func (s *Simulating) OnEnter() {
+	s.logger.Printf("World simulator %s connected", s.name)
+}
+
+func (s *Simulating) HandleMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_ActorSpawnRequest:
+		s.handleActorSpawnRequest(senderId, message)
+	case *packets.Packet_ActorUpdates:
+		s.handleActorUpdates(senderId, message)
+	case *packets.Packet_ActorDespawn:
+		s.handleActorDespawn(senderId, message)
+	case *packets.Packet_ActorDamage:
+		s.handleActorDamage(senderId, message)
+	case *packets.Packet_ActorKilled:
+		s.handleActorKilled(senderId, message)
+	case *packets.Packet_Player, *packets.Packet_PlayerConsumed, *packets.Packet_Disconnect:
+		s.handleWorldUpdate(senderId, message)
+	case *packets.Packet_TimeSyncRequest:
+		handleTimeSyncRequest(s.client, senderId, message)
+	case *packets.Packet_Custom:
+		handleCustom(s.client, senderId, message)
+	}
+}
+
+func (s *Simulating) OnExit() {
+	s.client.Simulators().DespawnAll(s.client.Id())
+	s.logger.Printf("World simulator %s disconnected", s.name)
+}
+
+func (s *Simulating) handleActorSpawnRequest(senderId uint64, message *packets.Packet_ActorSpawnRequest) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	ids := s.client.Simulators().Spawn(s.client.Id(), message.ActorSpawnRequest.Actors)
+	s.client.SocketSend(packets.NewActorSpawnResponse(ids))
+}
+
+func (s *Simulating) handleActorUpdates(senderId uint64, message *packets.Packet_ActorUpdates) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	actors := message.ActorUpdates.Actors
+	if updated := s.client.Simulators().Update(s.client.Id(), actors); updated < len(actors) {
+		s.logger.Sampledf("Ignored updates to %d actors we don't drive", len(actors)-updated)
+	}
+}
+
+func (s *Simulating) handleActorDespawn(senderId uint64, message *packets.Packet_ActorDespawn) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	s.client.Simulators().Despawn(s.client.Id(), message.ActorDespawn.Ids)
+}
+
+// Only players count as attackers, so simulators can't have their actors share in each other's kills
+func (s *Simulating) handleActorDamage(senderId uint64, message *packets.Packet_ActorDamage) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	damage := message.ActorDamage
+	if owner, driven := s.client.Simulators().Owner(damage.ActorId); !driven || owner != s.client.Id() {
+		s.logger.Sampledf("Ignored damage to actor %d we don't drive", damage.ActorId)
+		return
+	}
+	if _, driven := s.client.Simulators().Owner(damage.AttackerId); driven {
+		return
+	}
+	if _, inWorld := s.client.SharedGameObjects().Players.Get(damage.AttackerId); !inWorld {
+		return
+	}
+	s.client.DamageAttribution().Record(damage.ActorId, damage.AttackerId, damage.Amount)
+}
+
+func (s *Simulating) handleActorKilled(senderId uint64, message *packets.Packet_ActorKilled) {
+	if senderId != s.client.Id() {
+		return
+	}
+
+	killed := message.ActorKilled
+	if owner, driven := s.client.Simulators().Owner(killed.ActorId); !driven || owner != s.client.Id() {
+		s.logger.Printf("Ignored the death of actor %d we don't drive", killed.ActorId)
+		return
+	}
+
+	// Shared out before the actor's despawned, which forgets who damaged it
+	if killed.RewardId != "" {
+		if reward, exists := s.client.GameData().KillRewards[killed.RewardId]; exists {
+			s.client.DamageAttribution().Reward(killed.ActorId, reward)
+		} else {
+			s.logger.Errorf("Actor %d died with unknown kill reward %s", killed.ActorId, killed.RewardId)
+		}
+	}
+	s.client.Simulators().Despawn(s.client.Id(), []uint64{killed.ActorId})
+}
+
+// Pass on what players are up to, but not what our own actors are, since we already know
+func (s *Simulating) handleWorldUpdate(senderId uint64, message packets.Msg) {
+	if senderId == s.client.Id() {
+		return
+	}
+	if ownerId, driven := s.client.Simulators().Owner(senderId); driven && ownerId == s.client.Id() {
+		return
+	}
+	s.client.SocketSendAs(message, senderId)
+}
@@ -0,0 +1,23 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+)
+
+// Like presence, feed subscriptions belong to the client rather than a state, so they're handled in every state and
+// last until the client unsubscribes or disconnects
+
+func handleSubscribe(client server.ClientInterfacer, senderId uint64, message *packets.Packet_Subscribe) {
+	if senderId != client.Id() {
+		return
+	}
+	client.Subscriptions().Subscribe(client.Id(), message.Subscribe.Feeds)
+}
+
+func handleUnsubscribe(client server.ClientInterfacer, senderId uint64, message *packets.Packet_Unsubscribe) {
+	if senderId != client.Id() {
+		return
+	}
+	client.Subscriptions().Unsubscribe(client.Id(), message.Unsubscribe.Feeds)
+}
@@ -0,0 +1,20 @@
+package states
+
+import (
+	"server/internal/server"
+	"server/pkg/packets"
+	"time"
+)
+
+// Time sync is answered the same way in every state, so clients can keep their clocks in step from the moment they
+// connect. The client is expected to do several round trips, reporting the round trip time it measured for the
+// previous one, and estimate its offset from the server clock from the fastest of them.
+func handleTimeSyncRequest(client server.ClientInterfacer, senderId uint64, message *packets.Packet_TimeSyncRequest) {
+	if senderId != client.Id() {
+		return
+	}
+
+	request := message.TimeSyncRequest
+	client.ClockSync().AddRttSample(time.Duration(request.LastRtt) * time.Millisecond)
+	client.SocketSend(packets.NewTimeSyncResponse(request.Seq, request.ClientTime, client.ServerTime()))
+}
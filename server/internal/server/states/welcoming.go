@@ -0,0 +1,150 @@
+package states
+
+import (
+	"context"
+	"fmt"
+	"server/internal/server"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"time"
+)
+
+const (
+	welcomeAccept  = "accept"
+	welcomeDecline = "decline"
+)
+
+// Between logging in and the world, showing the player each welcome step their account hasn't seen the current
+// version of, in order, e.g. the server's rules. They're only let in once every required step's been accepted, and
+// declining one sends them back to logging in.
+type Welcoming struct {
+	client server.ClientInterfacer
+	logger *logging.Logger
+	userId int64
+
+	// Still to be shown, in order
+	steps []*gamedata.WelcomeStep
+
+	// Where the player goes once they're through
+	next *InGame
+}
+
+// The welcome steps the account hasn't accepted the current version of, in order
+func pendingWelcomeSteps(ctx context.Context, queries *db.Queries, gameData *gamedata.GameData, userId int64) ([]*gamedata.WelcomeStep, error) {
+	if len(gameData.Welcome) == 0 {
+		return nil, nil
+	}
+	acceptances, err := queries.GetWelcomeAcceptances(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	accepted := make(map[string]int64, len(acceptances))
+	for _, acceptance := range acceptances {
+		accepted[acceptance.StepID] = acceptance.Version
+	}
+
+	var pending []*gamedata.WelcomeStep
+	for _, step := range gameData.Welcome {
+		if accepted[step.Id] < step.Version {
+			pending = append(pending, step)
+		}
+	}
+	return pending, nil
+}
+
+func (w *Welcoming) Name() string {
+	return "Welcoming"
+}
+
+func (w *Welcoming) SetClient(client server.ClientInterfacer) {
+	w.client = client
+	loggingPrefix := fmt.Sprintf("Client %d [%s]: ", client.Id(), w.Name())
+	w.logger = logging.States.NewLogger(loggingPrefix)
+}
+
+func (w *Welcoming) OnEnter() {
+	w.showNext()
+}
+
+func (w *Welcoming) HandleMessage(senderId uint64, message packets.Msg) {
+	switch message := message.(type) {
+	case *packets.Packet_TimeSyncRequest:
+		handleTimeSyncRequest(w.client, senderId, message)
+	case *packets.Packet_Subscribe:
+		handleSubscribe(w.client, senderId, message)
+	case *packets.Packet_Unsubscribe:
+		handleUnsubscribe(w.client, senderId, message)
+	case *packets.Packet_Custom:
+		handleCustom(w.client, senderId, message)
+	case *packets.Packet_ImpersonationConsent:
+		handleImpersonationConsent(w.client, senderId, message)
+	case *packets.Packet_PromptResponse:
+		handlePromptResponse(w.client, senderId, message)
+	}
+}
+
+func (w *Welcoming) OnExit() {
+}
+
+// Put the next step to the player, or let them into the world if there are none left
+func (w *Welcoming) showNext() {
+	if len(w.steps) == 0 {
+		w.client.SetState(w.next)
+		return
+	}
+
+	step := w.steps[0]
+	options := []server.PromptOption{{Id: welcomeAccept, Label: step.AcceptLabel}}
+	if step.Required {
+		options = append(options, server.PromptOption{Id: welcomeDecline, Label: step.DeclineLabel})
+	}
+
+	// Answers come in through the prompt response, on the same goroutine as everything else our client sends, so
+	// they're handled like any other message
+	prompt := server.Prompt{Title: step.Title, Text: step.Text, Options: options}
+	_, err := w.client.Prompts().Ask(prompt, func(optionId string, err error) {
+		if err == nil {
+			w.answered(step, optionId)
+		}
+	})
+	if err != nil {
+		w.logger.Errorf("Error showing welcome step %s: %v", step.Id, err)
+		w.turnAway("Something went wrong logging in - please try again later")
+	}
+}
+
+func (w *Welcoming) answered(step *gamedata.WelcomeStep, optionId string) {
+	if optionId == welcomeDecline {
+		w.logger.Printf("Declined version %d of welcome step %s", step.Version, step.Id)
+		w.turnAway(fmt.Sprintf("You have to accept %q to play", step.Title))
+		return
+	}
+
+	err := w.client.DbTx().Queries.AcceptWelcomeStep(w.client.DbTx().Ctx, db.AcceptWelcomeStepParams{
+		UserID:     w.userId,
+		StepID:     step.Id,
+		Version:    step.Version,
+		AcceptedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		w.logger.Errorf("Error recording acceptance of welcome step %s: %v", step.Id, err)
+		// A required step has to be on record as accepted, but there's no harm in showing the rest again next time
+		if step.Required {
+			w.turnAway(dbUnavailableMessage)
+			return
+		}
+	} else if step.Required {
+		w.logger.Printf("Accepted version %d of welcome step %s", step.Version, step.Id)
+	}
+
+	w.steps = w.steps[1:]
+	w.showNext()
+}
+
+// Send the player back to logging in, saying why
+func (w *Welcoming) turnAway(reason string) {
+	w.client.SocketSend(packets.NewDenyResponse(reason))
+	w.client.SetState(&Connected{})
+}
@@ -0,0 +1,298 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/pkg/packets"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	StoreReceiptPending  = "pending"
+	StoreReceiptApproved = "approved"
+	StoreReceiptRejected = "rejected"
+
+	// How long a receipt validator has to answer before the receipt is left for the admin API
+	receiptValidationTimeout = 30 * time.Second
+)
+
+var (
+	ErrAlreadyOwned     = errors.New("already owned")
+	ErrNotEnoughCoins   = errors.New("not enough coins")
+	ErrNotForCoins      = errors.New("not sold for coins")
+	ErrNotOnPlatform    = errors.New("not sold on that platform")
+	ErrReceiptUsed      = errors.New("receipt has already been used")
+	ErrReceiptResolved  = errors.New("receipt has already been resolved")
+	ErrInvalidReceipt   = errors.New("invalid receipt")
+	ErrReceiptNotFound  = errors.New("receipt not found")
+	ErrStoreEntryAbsent = errors.New("store entry no longer exists")
+)
+
+// Checks receipts from an app store, e.g. by asking Steam, Google or Apple whether the purchase went through
+type ReceiptValidator interface {
+	// Return nil if the receipt is for a real purchase of the product, and an error wrapping ErrInvalidReceipt if it
+	// isn't. Any other error means it couldn't be checked, and the receipt is left pending for the admin API.
+	Validate(ctx context.Context, productId string, receipt string) error
+}
+
+// Sells cosmetics from the game data's store, either for coins or through app stores. Coin purchases are settled
+// straight away. Receipts from app stores are kept as pending until they're checked, by the validator registered for
+// their platform if there is one, or otherwise by an external service through the admin API. Each receipt can only
+// ever be claimed once. Every grant is recorded in the audit log.
+type Store struct {
+	hub        *Hub
+	validators map[string]ReceiptValidator
+	mux        sync.RWMutex
+}
+
+func NewStore(hub *Hub) *Store {
+	return &Store{hub: hub, validators: make(map[string]ReceiptValidator)}
+}
+
+// Check receipts from the given platform with the validator, replacing any registered before
+func (s *Store) RegisterValidator(platform string, validator ReceiptValidator) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.validators[platform] = validator
+}
+
+// Whether the player, or for entitlements their account, has been granted what the entry sells
+func (s *Store) Owns(ctx context.Context, queries *db.Queries, playerDbId int64, entry *gamedata.StoreEntry) (bool, error) {
+	if entry.Entitlement != "" {
+		player, err := queries.GetPlayer(ctx, playerDbId)
+		if err != nil {
+			return false, err
+		}
+		entitlementIds, err := s.hub.Entitlements.List(ctx, queries, player.UserID)
+		if err != nil {
+			return false, err
+		}
+		return slices.Contains(entitlementIds, entry.Entitlement), nil
+	}
+
+	emoteIds, err := queries.GetUnlockedEmotes(ctx, playerDbId)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(emoteIds, entry.EmoteId), nil
+}
+
+// Buy the entry for the player with their coins
+func (s *Store) Buy(playerDbId int64, playerName string, entry *gamedata.StoreEntry) error {
+	if entry.Price == 0 {
+		return ErrNotForCoins
+	}
+
+	dbTx := s.hub.NewDbTx()
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		owned, err := s.Owns(dbTx.Ctx, queries, playerDbId, entry)
+		if err != nil {
+			return err
+		}
+		if owned {
+			return ErrAlreadyOwned
+		}
+
+		removed, err := queries.RemoveInventoryItem(dbTx.Ctx, db.RemoveInventoryItemParams{
+			Quantity: entry.Price,
+			PlayerID: playerDbId,
+			ItemID:   gamedata.CurrencyItemId,
+		})
+		if err != nil {
+			return err
+		}
+		if removed == 0 {
+			return ErrNotEnoughCoins
+		}
+		return s.grant(dbTx, queries, playerDbId, entry, "coins")
+	})
+	if err != nil {
+		return err
+	}
+
+	s.hub.Audit("store", "store.granted", playerName, fmt.Sprintf("%s (%s) for %d coins", entry.Id, entry.Grants(), entry.Price))
+	return nil
+}
+
+// Keep the receipt for the entry as pending, and have it checked if there's a validator for its platform
+func (s *Store) SubmitReceipt(playerDbId int64, entry *gamedata.StoreEntry, platform string, receipt string) (db.StoreReceipt, error) {
+	if _, sold := entry.Products[platform]; !sold {
+		return db.StoreReceipt{}, ErrNotOnPlatform
+	}
+
+	dbTx := s.hub.NewDbTx()
+	owned, err := s.Owns(dbTx.Ctx, dbTx.Queries, playerDbId, entry)
+	if err != nil {
+		return db.StoreReceipt{}, err
+	}
+	if owned {
+		return db.StoreReceipt{}, ErrAlreadyOwned
+	}
+
+	// Receipts of deleted accounts are only kept hashed, which the unique constraint can't catch
+	used, err := dbTx.Queries.CountStoreReceipts(dbTx.Ctx, db.CountStoreReceiptsParams{
+		Platform: platform,
+		Receipt:  hashReceipt(receipt),
+	})
+	if err != nil {
+		return db.StoreReceipt{}, err
+	}
+	if used > 0 {
+		return db.StoreReceipt{}, ErrReceiptUsed
+	}
+
+	stored, err := dbTx.Queries.CreateStoreReceipt(dbTx.Ctx, db.CreateStoreReceiptParams{
+		PlayerID:  playerDbId,
+		EntryID:   entry.Id,
+		Platform:  platform,
+		Receipt:   receipt,
+		CreatedAt: time.Now().UnixMilli(),
+	})
+	if err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		return db.StoreReceipt{}, ErrReceiptUsed
+	} else if err != nil {
+		return db.StoreReceipt{}, err
+	}
+
+	s.mux.RLock()
+	validator, exists := s.validators[platform]
+	s.mux.RUnlock()
+	if exists {
+		go s.validate(validator, stored, entry.Products[platform])
+	}
+	return stored, nil
+}
+
+// What's kept of a deleted account's receipt, enough to still turn it away if it's used again
+func hashReceipt(receipt string) string {
+	hash := sha256.Sum256([]byte(receipt))
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+func (s *Store) validate(validator ReceiptValidator, receipt db.StoreReceipt, productId string) {
+	ctx, cancel := context.WithTimeout(context.Background(), receiptValidationTimeout)
+	defer cancel()
+
+	err := validator.Validate(ctx, productId, receipt.Receipt)
+	if err != nil && !errors.Is(err, ErrInvalidReceipt) {
+		logging.Hub.Errorf("Error validating %s receipt %d, leaving it for the admin API: %v", receipt.Platform, receipt.ID, err)
+		return
+	}
+
+	reason := ""
+	if err != nil {
+		reason = err.Error()
+	}
+	if _, err := s.Resolve(receipt.ID, err == nil, reason, receipt.Platform+" validator"); err != nil {
+		logging.Hub.Errorf("Error resolving %s receipt %d: %v", receipt.Platform, receipt.ID, err)
+	}
+}
+
+// Settle a pending receipt, granting its entry if it's approved, and let the player know if they're here
+func (s *Store) Resolve(receiptId int64, approved bool, reason string, resolvedBy string) (db.StoreReceipt, error) {
+	dbTx := s.hub.NewDbTx()
+	var receipt db.StoreReceipt
+	var entry *gamedata.StoreEntry
+	err := dbTx.InTx(func(queries *db.Queries) error {
+		var err error
+		receipt, err = queries.GetStoreReceipt(dbTx.Ctx, receiptId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrReceiptNotFound
+		} else if err != nil {
+			return err
+		}
+
+		var exists bool
+		if entry, exists = s.hub.GameData.StoreEntry(receipt.EntryID); !exists && approved {
+			return ErrStoreEntryAbsent
+		}
+
+		receipt.Status = StoreReceiptRejected
+		if approved {
+			receipt.Status = StoreReceiptApproved
+		}
+		receipt.Reason = reason
+		receipt.ResolvedAt = sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+		resolved, err := queries.ResolveStoreReceipt(dbTx.Ctx, db.ResolveStoreReceiptParams{
+			Status:     receipt.Status,
+			Reason:     receipt.Reason,
+			ResolvedAt: receipt.ResolvedAt,
+			ID:         receipt.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if resolved == 0 {
+			return ErrReceiptResolved
+		}
+
+		if approved {
+			return s.grant(dbTx, queries, receipt.PlayerID, entry, receipt.Platform)
+		}
+		return nil
+	})
+	if err != nil {
+		return db.StoreReceipt{}, err
+	}
+
+	if approved {
+		s.hub.Audit(resolvedBy, "store.granted", fmt.Sprintf("player %d", receipt.PlayerID), fmt.Sprintf("%s (%s) for %s receipt %d", entry.Id, entry.Grants(), receipt.Platform, receipt.ID))
+	} else {
+		s.hub.Audit(resolvedBy, "store.receipt_rejected", fmt.Sprintf("player %d", receipt.PlayerID), fmt.Sprintf("%s receipt %d for %s: %s", receipt.Platform, receipt.ID, receipt.EntryID, reason))
+	}
+
+	if client, online := s.hub.ClientByPlayerDbId(receipt.PlayerID); online {
+		client.SocketSend(packets.NewStoreReceiptStatus(uint64(receipt.ID), receipt.EntryID, receipt.Status, receipt.Reason))
+		if approved && entry.Entitlement != "" {
+			client.ProcessMessage(0, packets.NewEntitlementListRequest())
+		} else if approved {
+			client.ProcessMessage(0, packets.NewEmoteUnlocked(entry.EmoteId))
+		}
+	}
+	return receipt, nil
+}
+
+// Give the player what the entry sells, as part of the transaction paying for it
+func (s *Store) grant(dbTx *DbTx, queries *db.Queries, playerDbId int64, entry *gamedata.StoreEntry, paidWith string) error {
+	if entry.Entitlement != "" {
+		player, err := queries.GetPlayer(dbTx.Ctx, playerDbId)
+		if err != nil {
+			return err
+		}
+		err = s.hub.Entitlements.grant(dbTx, queries, player.UserID, entry.Entitlement, "store "+paidWith)
+		if err != nil {
+			return err
+		}
+		return dbTx.RecordEvent(queries, "store.granted", map[string]any{
+			"player_id":   playerDbId,
+			"entry_id":    entry.Id,
+			"entitlement": entry.Entitlement,
+			"paid_with":   paidWith,
+		})
+	}
+
+	err := queries.UnlockEmote(dbTx.Ctx, db.UnlockEmoteParams{
+		PlayerID:   playerDbId,
+		EmoteID:    entry.EmoteId,
+		UnlockedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	return dbTx.RecordEvent(queries, "store.granted", map[string]any{
+		"player_id": playerDbId,
+		"entry_id":  entry.Id,
+		"emote_id":  entry.EmoteId,
+		"paid_with": paidWith,
+	})
+}
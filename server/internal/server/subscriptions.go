@@ -0,0 +1,176 @@
+package server
+
+import (
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// The feeds clients can subscribe to
+const (
+	// Players' new best scores and where that puts them, as HiscoreMessages
+	FeedHiscores = "hiscores"
+
+	// Listings put up on the auction house, as AuctionListingMessages, and those sold or expired, as
+	// AuctionListingClosedMessages
+	FeedAuctions = "auctions"
+)
+
+var feeds = []string{FeedHiscores, FeedAuctions}
+
+// How often hiscore changes are pushed. Players' best scores go up with nearly everything they eat, so only their
+// latest in each interval is.
+const hiscoreFeedInterval = time.Second
+
+var feedUpdatesTotal = metrics.NewCounterVec("mmo_feed_updates_total", "Updates pushed to clients subscribed to each feed.", "feed")
+
+// Pushes updates to the clients interested in them, rather than having every client poll for them with requests.
+// Subscriptions belong to the client rather than its state, so they last until it unsubscribes or disconnects.
+type Subscriptions struct {
+	hub *Hub
+
+	// Who's subscribed to each feed, and which feeds each client is subscribed to
+	subscribers   map[string]map[uint64]struct{}
+	subscriptions map[uint64]map[string]struct{}
+
+	// The latest best score of each player since the hiscore feed was last pushed, by name
+	hiscores map[string]int64
+
+	mux sync.Mutex
+}
+
+func NewSubscriptions(hub *Hub) *Subscriptions {
+	return &Subscriptions{
+		hub:           hub,
+		subscribers:   make(map[string]map[uint64]struct{}),
+		subscriptions: make(map[uint64]map[string]struct{}),
+		hiscores:      make(map[string]int64),
+	}
+}
+
+// Subscribe the client to the feeds, telling it which it's subscribed to now. Feeds that don't exist are refused.
+func (s *Subscriptions) Subscribe(clientId uint64, names []string) {
+	replies := make([]packets.Msg, 0, len(names))
+	s.mux.Lock()
+	for _, feed := range names {
+		if !slices.Contains(feeds, feed) {
+			replies = append(replies, packets.NewSubscription(feed, false, "No such feed"))
+			continue
+		}
+		if _, exists := s.subscribers[feed]; !exists {
+			s.subscribers[feed] = make(map[uint64]struct{})
+		}
+		s.subscribers[feed][clientId] = struct{}{}
+		if _, exists := s.subscriptions[clientId]; !exists {
+			s.subscriptions[clientId] = make(map[string]struct{})
+		}
+		s.subscriptions[clientId][feed] = struct{}{}
+		replies = append(replies, packets.NewSubscription(feed, true, ""))
+	}
+	s.mux.Unlock()
+
+	if client, exists := s.hub.Clients.Get(clientId); exists {
+		for _, reply := range replies {
+			client.SocketSend(reply)
+		}
+	}
+}
+
+func (s *Subscriptions) Unsubscribe(clientId uint64, names []string) {
+	s.mux.Lock()
+	for _, feed := range names {
+		s.unsubscribe(clientId, feed)
+	}
+	s.mux.Unlock()
+
+	if client, exists := s.hub.Clients.Get(clientId); exists {
+		for _, feed := range names {
+			client.SocketSend(packets.NewSubscription(feed, false, ""))
+		}
+	}
+}
+
+// Remove all of the client's subscriptions, e.g. when it disconnects
+func (s *Subscriptions) UnsubscribeAll(clientId uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for feed := range s.subscriptions[clientId] {
+		s.unsubscribe(clientId, feed)
+	}
+}
+
+func (s *Subscriptions) unsubscribe(clientId uint64, feed string) {
+	delete(s.subscribers[feed], clientId)
+	if len(s.subscribers[feed]) == 0 {
+		delete(s.subscribers, feed)
+	}
+	delete(s.subscriptions[clientId], feed)
+	if len(s.subscriptions[clientId]) == 0 {
+		delete(s.subscriptions, clientId)
+	}
+}
+
+// Whether anyone's subscribed to the feed, so updates no one would get needn't be worked out
+func (s *Subscriptions) Watched(feed string) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return len(s.subscribers[feed]) > 0
+}
+
+// Push the update to everyone subscribed to the feed
+func (s *Subscriptions) Publish(feed string, message packets.Msg) {
+	s.mux.Lock()
+	clientIds := make([]uint64, 0, len(s.subscribers[feed]))
+	for clientId := range s.subscribers[feed] {
+		clientIds = append(clientIds, clientId)
+	}
+	s.mux.Unlock()
+
+	for _, clientId := range clientIds {
+		if client, exists := s.hub.Clients.Get(clientId); exists {
+			client.SocketSend(message)
+		}
+	}
+	feedUpdatesTotal.With(feed).Add(uint64(len(clientIds)))
+}
+
+// The player has a new best score, to be pushed to the hiscore feed with everyone else's next time it is
+func (s *Subscriptions) HiscoreChanged(name string, bestScore int64) {
+	if !s.Watched(FeedHiscores) {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.hiscores[name] = max(s.hiscores[name], bestScore)
+}
+
+func (s *Subscriptions) pushLoop() {
+	ticker := time.NewTicker(hiscoreFeedInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.pushHiscores()
+	}
+}
+
+func (s *Subscriptions) pushHiscores() {
+	s.mux.Lock()
+	changed := s.hiscores
+	s.hiscores = make(map[string]int64)
+	s.mux.Unlock()
+
+	dbTx := s.hub.NewDbTx()
+	for name, bestScore := range changed {
+		// Ranked by score rather than by player, as the player's own best score may not have been saved yet
+		rank, err := dbTx.Queries.GetRankForScore(dbTx.Ctx, bestScore)
+		if err != nil {
+			logging.Hub.Errorf("Error ranking %s's best score for the hiscore feed: %v", name, err)
+			continue
+		}
+		s.Publish(FeedHiscores, packets.NewHiscore(uint64(rank), name, uint64(bestScore)))
+	}
+}
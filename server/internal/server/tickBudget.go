@@ -0,0 +1,158 @@
+package server
+
+import (
+	"cmp"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// How much of each tick the hub's own periodic work gets, unless configured otherwise. The rest is left for syncing
+// players and sending their snapshots, which happen every tick whatever else is going on.
+const DefaultTickBudget = 20 * time.Millisecond
+
+// The most ticks in a row work can be put off before it's run anyway, over budget or not, so it's late but never starved
+const maxTickDeferrals = 10
+
+// How much each run counts towards what a subsystem is expected to cost next time, between 0 and 1
+const tickCostSmoothing = 0.2
+
+// What's put off first when a tick's over budget. Subsystems of lower priority wait for those of higher priority, and
+// critical ones are never put off at all.
+type TickPriority int
+
+const (
+	TickPriorityCritical TickPriority = iota
+
+	// Keeping what players can see of the world in step with it, e.g. emitters coming into view
+	TickPriorityWorld
+
+	// Replenishing spores and respawning resource nodes, which can wait a tick without anyone noticing
+	TickPrioritySpawns
+
+	// Writing to the database what can be written late
+	TickPriorityPersistence
+)
+
+var (
+	tickBudgetSpent    = metrics.NewHistogramVec("mmo_tick_budget_spent_seconds", "How much of each tick's budget was spent, by what on: scheduled work or work charged from elsewhere, e.g. syncing players.", "source", durationBuckets)
+	tickDeferralsTotal = metrics.NewCounterVec("mmo_tick_deferrals_total", "Times a subsystem's work was put off to the next tick because the tick was over budget.", "subsystem")
+	tickForcedTotal    = metrics.NewCounterVec("mmo_tick_forced_total", "Times a subsystem's work was run over budget because it had been put off too many ticks in a row.", "subsystem")
+)
+
+// A subsystem's periodic work, run on the ticks it's due
+type tickTask struct {
+	subsystem string
+	priority  TickPriority
+
+	// Run every this many ticks
+	every uint64
+	run   func(now time.Time)
+
+	// When it's next due, and how many ticks in a row it's been put off since
+	dueAt    uint64
+	deferred int
+
+	// What it's cost lately, learned from timing its runs
+	expectedCost time.Duration
+}
+
+// Runs the hub's periodic work on the tick, most important first, within a budget for each tick. What each subsystem
+// costs is learned from timing its runs, so once a tick's spent, or would be by the next subsystem, what's left of
+// lower priority is put off to the next tick rather than run late, and players' snapshots go out on time even when
+// everything's due at once. Work done elsewhere on the tick, e.g. syncing players, is charged to it too.
+type TickBudget struct {
+	hub    *Hub
+	budget time.Duration
+
+	tasks []*tickTask
+	tick  uint64
+	mux   sync.Mutex
+
+	// Spent on the current tick outside the scheduler, in nanoseconds
+	charged atomic.Int64
+}
+
+func NewTickBudget(hub *Hub) *TickBudget {
+	return &TickBudget{hub: hub, budget: DefaultTickBudget}
+}
+
+// Must be called before the hub is run. A budget of 0 or less means everything due on a tick is run on it.
+func (b *TickBudget) Configure(budget time.Duration) {
+	b.budget = budget
+	if budget > 0 {
+		logging.Hub.Printf("Budgeting %s of each %s tick for periodic work", budget, TickInterval)
+	}
+}
+
+// Have the subsystem's work run every interval, rounded to the nearest tick. Must be called before the hub is run.
+func (b *TickBudget) Schedule(subsystem string, priority TickPriority, interval time.Duration, run func(now time.Time)) {
+	every := max(uint64((interval+TickInterval/2)/TickInterval), 1)
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.tasks = append(b.tasks, &tickTask{subsystem: subsystem, priority: priority, every: every, run: run, dueAt: every})
+	slices.SortStableFunc(b.tasks, func(a, b *tickTask) int {
+		return cmp.Compare(a.priority, b.priority)
+	})
+}
+
+// Count work done outside the scheduler towards the current tick's budget
+func (b *TickBudget) Charge(elapsed time.Duration) {
+	b.charged.Add(int64(elapsed))
+}
+
+func (b *TickBudget) runLoop() {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		b.runTick(now)
+	}
+}
+
+// Run what's due this tick, putting off what doesn't fit in the budget
+func (b *TickBudget) runTick(now time.Time) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.tick++
+	charged := time.Duration(b.charged.Swap(0))
+	spent := charged
+
+	for _, task := range b.tasks {
+		if task.dueAt > b.tick {
+			continue
+		}
+
+		if b.budget > 0 && task.priority != TickPriorityCritical && spent+task.expectedCost > b.budget {
+			if task.deferred < maxTickDeferrals {
+				task.deferred++
+				tickDeferralsTotal.With(task.subsystem).Inc()
+				continue
+			}
+			tickForcedTotal.With(task.subsystem).Inc()
+			logging.Hub.Printf("Running %s over budget after putting it off %d ticks", task.subsystem, task.deferred)
+		}
+
+		done := b.hub.Watchdog.Track(task.subsystem)
+		startedAt := time.Now()
+		task.run(now)
+		cost := time.Since(startedAt)
+		done()
+
+		spent += cost
+		if task.expectedCost == 0 {
+			task.expectedCost = cost
+		} else {
+			task.expectedCost += time.Duration(tickCostSmoothing * float64(cost-task.expectedCost))
+		}
+		task.deferred = 0
+		task.dueAt = b.tick + task.every
+	}
+
+	tickBudgetSpent.Observe("charged", charged.Seconds())
+	tickBudgetSpent.Observe("scheduled", (spent - charged).Seconds())
+}
@@ -0,0 +1,305 @@
+package server
+
+import (
+	"maps"
+	"math"
+	"server/internal/server/objects"
+	"slices"
+	"sync"
+)
+
+// How far (edge to edge) from a player others stay fully relevant to them unless configured otherwise, see
+// Visibility.AoiRadius
+const DefaultAoiRadius = 1500
+
+const (
+	// The radius players start at. Actors bigger than this stay relevant further away, in proportion to their size.
+	interestBaseRadius = 20
+
+	// The furthest an actor's size can stretch how far it stays relevant, as a multiple of the area of interest
+	interestMaxSizeScale = 3
+)
+
+type VisibilityMode int
+
+const (
+	// Seen by everyone
+	Visible VisibilityMode = iota
+
+	// Only seen by players within the actor's stealth radius
+	Stealthed
+
+	// Only seen by recipients explicitly allowed to see invisible actors, e.g. other GMs
+	Invisible
+)
+
+type visibilityRule struct {
+	mode          VisibilityMode
+	stealthRadius float64
+	hiddenFrom    map[uint64]struct{}
+
+	// How much further than its size alone the actor stays relevant, or 0 if it hasn't been set
+	prominence float64
+}
+
+// Decides, per recipient, whether packets about an actor should be delivered at all. This is evaluated by the hub
+// while fanning out broadcasts, so hidden actors never reach the recipient's socket instead of relying on the client
+// to filter them out.
+//
+// Actors and recipients are both identified by their client ID, since that's also the ID of the player they own.
+type Visibility struct {
+	players *objects.SharedCollection[*objects.Player]
+
+	// Players in different channels of a zone never see each other
+	channels *Channels
+
+	rules map[uint64]*visibilityRule
+
+	// Recipients who can see invisible actors
+	seesInvisible map[uint64]struct{}
+
+	// Who's in the sandbox, and what's been spawned in it, which only see each other
+	sandboxed map[uint64]struct{}
+
+	// Each player's area of interest, the distance out to which updates about others are sent more often the nearer
+	// they are. Those further away are still seen, just updated least often. It's stretched or shrunk per actor by
+	// what makes it easier or harder to notice, see InterestRadius.
+	aoiRadius float64
+
+	mux sync.RWMutex
+}
+
+func NewVisibility(players *objects.SharedCollection[*objects.Player], channels *Channels) *Visibility {
+	return &Visibility{
+		players:       players,
+		channels:      channels,
+		rules:         make(map[uint64]*visibilityRule),
+		seesInvisible: make(map[uint64]struct{}),
+		sandboxed:     make(map[uint64]struct{}),
+		aoiRadius:     DefaultAoiRadius,
+	}
+}
+
+// Must be called before the hub is run. DefaultAoiRadius if 0.
+func (v *Visibility) Configure(aoiRadius float64) {
+	if aoiRadius > 0 {
+		v.aoiRadius = aoiRadius
+	}
+}
+
+func (v *Visibility) AoiRadius() float64 {
+	return v.aoiRadius
+}
+
+func (v *Visibility) SetMode(actorId uint64, mode VisibilityMode) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.rule(actorId).mode = mode
+}
+
+// Stealth the actor so it's only seen by players within the given distance of it
+func (v *Visibility) SetStealthed(actorId uint64, radius float64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	rule := v.rule(actorId)
+	rule.mode = Stealthed
+	rule.stealthRadius = radius
+}
+
+// Make the actor stay relevant further away than its size alone would, by the scale, e.g. 2 for riding a big mount.
+// 1 puts it back to normal.
+func (v *Visibility) SetProminence(actorId uint64, scale float64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.rule(actorId).prominence = scale
+}
+
+func (v *Visibility) SetSeesInvisible(recipientId uint64, seesInvisible bool) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if seesInvisible {
+		v.seesInvisible[recipientId] = struct{}{}
+	} else {
+		delete(v.seesInvisible, recipientId)
+	}
+}
+
+// Put the actor in the sandbox or take them out of it. Those in it and those outside it never see each other.
+func (v *Visibility) SetSandboxed(actorId uint64, sandboxed bool) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if sandboxed {
+		v.sandboxed[actorId] = struct{}{}
+	} else {
+		delete(v.sandboxed, actorId)
+	}
+}
+
+func (v *Visibility) Sandboxed(actorId uint64) bool {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	_, sandboxed := v.sandboxed[actorId]
+	return sandboxed
+}
+
+// Hide the actor from one recipient only, regardless of its mode, e.g. because the recipient blocked it
+func (v *Visibility) HideFrom(actorId uint64, recipientId uint64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.rule(actorId).hiddenFrom[recipientId] = struct{}{}
+}
+
+func (v *Visibility) UnhideFrom(actorId uint64, recipientId uint64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if rule, exists := v.rules[actorId]; exists {
+		delete(rule.hiddenFrom, recipientId)
+	}
+}
+
+// Drop all rules involving the client, whether as an actor or a recipient
+// The actors and recipients with rules set for them
+func (v *Visibility) ids() []uint64 {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	ids := slices.Collect(maps.Keys(v.rules))
+	for id := range v.seesInvisible {
+		if _, exists := v.rules[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	for id := range v.sandboxed {
+		_, hasRule := v.rules[id]
+		_, seesInvisible := v.seesInvisible[id]
+		if !hasRule && !seesInvisible {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (v *Visibility) Forget(clientId uint64) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	delete(v.rules, clientId)
+	delete(v.seesInvisible, clientId)
+	delete(v.sandboxed, clientId)
+	for _, rule := range v.rules {
+		delete(rule.hiddenFrom, clientId)
+	}
+}
+
+func (v *Visibility) CanSee(recipientId uint64, actorId uint64) bool {
+	// Packets from the server itself are about no actor in particular
+	if actorId == 0 || actorId == recipientId {
+		return true
+	}
+	if !v.channels.Together(recipientId, actorId) {
+		return false
+	}
+
+	v.mux.RLock()
+	_, recipientSandboxed := v.sandboxed[recipientId]
+	_, actorSandboxed := v.sandboxed[actorId]
+	if recipientSandboxed != actorSandboxed {
+		v.mux.RUnlock()
+		return false
+	}
+	rule, exists := v.rules[actorId]
+	if !exists {
+		v.mux.RUnlock()
+		return true
+	}
+	_, hidden := rule.hiddenFrom[recipientId]
+	_, seesInvisible := v.seesInvisible[recipientId]
+	mode, stealthRadius := rule.mode, rule.stealthRadius
+	v.mux.RUnlock()
+
+	if hidden {
+		return false
+	}
+
+	switch mode {
+	case Invisible:
+		return seesInvisible
+	case Stealthed:
+		return seesInvisible || v.withinDistance(recipientId, actorId, stealthRadius)
+	default:
+		return true
+	}
+}
+
+func (v *Visibility) withinDistance(recipientId uint64, actorId uint64, distance float64) bool {
+	recipient, recipientExists := v.players.Get(recipientId)
+	actor, actorExists := v.players.Get(actorId)
+	if !recipientExists || !actorExists {
+		return false
+	}
+
+	dx := recipient.X - actor.X
+	dy := recipient.Y - actor.Y
+	return dx*dx+dy*dy <= distance*distance
+}
+
+// How far (edge to edge) from recipients the actor stays relevant to them, i.e. the area of interest as it applies to
+// this actor. Bigger and more prominent actors are noticed from further away, and stealthed ones only from as near as
+// they can be seen. It depends on the actor alone, so two players can be relevant to each other at different
+// distances: a huge player is noticed by a small one long before the small one is by them.
+func (v *Visibility) InterestRadius(actorId uint64) float64 {
+	radius := v.aoiRadius
+	if actor, exists := v.players.Get(actorId); exists && actor.Radius > interestBaseRadius {
+		radius *= min(actor.Radius/interestBaseRadius, interestMaxSizeScale)
+	}
+
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	rule, exists := v.rules[actorId]
+	if !exists {
+		return radius
+	}
+	if rule.prominence > 0 {
+		radius *= rule.prominence
+	}
+	if rule.mode == Stealthed {
+		radius = min(radius, rule.stealthRadius)
+	}
+	return radius
+}
+
+// How far (edge to edge) the actor is from the recipient, and whether that's within the actor's interest radius, so
+// it's one the recipient should be kept up to date about. Players that aren't in the world are never within.
+func (v *Visibility) WithinInterest(recipientId uint64, actorId uint64) (float64, bool) {
+	recipient, recipientExists := v.players.Get(recipientId)
+	actor, actorExists := v.players.Get(actorId)
+	if !recipientExists || !actorExists {
+		return 0, false
+	}
+	distance := max(math.Hypot(actor.X-recipient.X, actor.Y-recipient.Y)-actor.Radius-recipient.Radius, 0)
+	return distance, distance <= v.InterestRadius(actorId)
+}
+
+// The actor's mode, and how near players have to be to see it if it's stealthed
+func (v *Visibility) Mode(actorId uint64) (VisibilityMode, float64) {
+	v.mux.RLock()
+	defer v.mux.RUnlock()
+	rule, exists := v.rules[actorId]
+	if !exists {
+		return Visible, 0
+	}
+	return rule.mode, rule.stealthRadius
+}
+
+// Must be called with the write lock held
+func (v *Visibility) rule(actorId uint64) *visibilityRule {
+	rule, exists := v.rules[actorId]
+	if !exists {
+		rule = &visibilityRule{hiddenFrom: make(map[uint64]struct{})}
+		v.rules[actorId] = rule
+	}
+	return rule
+}
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"server/internal/server/db"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+const (
+	// How close players have to be to talk without being in a party together
+	voiceProximityRange = 800
+
+	// How often calls are checked to still be allowed, hanging up any that have gone out of range
+	voiceScopeCheckInterval = 2 * time.Second
+
+	VoiceSignalOffer  = "offer"
+	VoiceSignalAnswer = "answer"
+	VoiceSignalIce    = "ice"
+	VoiceSignalHangup = "hangup"
+)
+
+var ErrVoiceOutOfScope = errors.New("too far away to talk")
+
+// A mute put on a player by an admin
+type VoiceMute struct {
+	MutedBy string
+	Reason  string
+
+	// Zero if the mute doesn't expire
+	ExpiresAt time.Time
+}
+
+// Relays the signaling clients need to set up voice calls between each other over WebRTC. The audio itself goes
+// peer-to-peer, or through a TURN relay for players who can't reach each other directly, so the server only decides
+// who can call whom: players in the same party, or close enough to each other otherwise, who can see each other at
+// all. Calls that stop being allowed, because the players moved apart or one of them was muted, are hung up on both
+// ends, so clients don't have to be trusted to do it.
+type Voice struct {
+	hub *Hub
+
+	// Members of each party, and the party each client is in
+	parties map[string]map[uint64]struct{}
+	partyOf map[uint64]string
+
+	// The clients each client is in a call with, in both directions
+	calls map[uint64]map[uint64]struct{}
+
+	mux sync.Mutex
+
+	// The STUN and TURN servers clients are told to use, see IceServers
+	relay VoiceRelayConfig
+}
+
+func NewVoice(hub *Hub) *Voice {
+	return &Voice{
+		hub:     hub,
+		parties: make(map[string]map[uint64]struct{}),
+		partyOf: make(map[uint64]string),
+		calls:   make(map[uint64]map[uint64]struct{}),
+	}
+}
+
+// Put the client in the party, or just take them out of theirs if the party given is empty. Calls with their old
+// party that are now out of range are hung up.
+func (v *Voice) JoinParty(clientId uint64, party string) {
+	v.mux.Lock()
+	v.leaveParty(clientId)
+	if party != "" {
+		members, exists := v.parties[party]
+		if !exists {
+			members = make(map[uint64]struct{})
+			v.parties[party] = members
+		}
+		members[clientId] = struct{}{}
+		v.partyOf[clientId] = party
+	}
+	v.mux.Unlock()
+
+	v.enforce(clientId)
+}
+
+func (v *Voice) Party(clientId uint64) string {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	return v.partyOf[clientId]
+}
+
+// Check a signal from one client to another is allowed, and keep track of the call it's part of
+func (v *Voice) Signal(fromId uint64, toId uint64, kind string) error {
+	if kind == VoiceSignalHangup {
+		v.mux.Lock()
+		v.endCall(fromId, toId)
+		v.mux.Unlock()
+		return nil
+	}
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	if !v.inScope(fromId, toId) {
+		return ErrVoiceOutOfScope
+	}
+	v.startCall(fromId, toId)
+	return nil
+}
+
+// Hang up every call the client is in, e.g. because they've been muted
+func (v *Voice) HangUpAll(clientId uint64) {
+	v.mux.Lock()
+	peerIds := make([]uint64, 0, len(v.calls[clientId]))
+	for peerId := range v.calls[clientId] {
+		peerIds = append(peerIds, peerId)
+		v.endCall(clientId, peerId)
+	}
+	v.mux.Unlock()
+
+	for _, peerId := range peerIds {
+		v.hangUp(clientId, peerId)
+	}
+}
+
+// Hang up the client's calls and take them out of their party, e.g. as they leave the game
+func (v *Voice) Leave(clientId uint64) {
+	v.HangUpAll(clientId)
+
+	v.mux.Lock()
+	defer v.mux.Unlock()
+	v.leaveParty(clientId)
+}
+
+// The player's mute, if they're muted right now
+func (v *Voice) Mute(playerDbId int64) (VoiceMute, bool, error) {
+	dbTx := v.hub.NewDbTx()
+	row, err := dbTx.Queries.GetVoiceMute(dbTx.Ctx, playerDbId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return VoiceMute{}, false, nil
+	} else if err != nil {
+		return VoiceMute{}, false, err
+	}
+
+	mute := VoiceMute{MutedBy: row.MutedBy, Reason: row.Reason}
+	if row.ExpiresAt > 0 {
+		mute.ExpiresAt = time.UnixMilli(row.ExpiresAt)
+		if !time.Now().Before(mute.ExpiresAt) {
+			return VoiceMute{}, false, nil
+		}
+	}
+	return mute, true, nil
+}
+
+// Mute the player from voice chat, for the given time or for good if it's 0. If they're online they're told, and
+// every call they're in is hung up.
+func (v *Voice) SetMute(admin string, playerDbId int64, reason string, duration time.Duration) error {
+	now := time.Now()
+	var expiresAt int64
+	if duration > 0 {
+		expiresAt = now.Add(duration).UnixMilli()
+	}
+
+	dbTx := v.hub.NewDbTx()
+	err := dbTx.Queries.UpsertVoiceMute(dbTx.Ctx, db.UpsertVoiceMuteParams{
+		PlayerID:  playerDbId,
+		MutedBy:   admin,
+		Reason:    reason,
+		ExpiresAt: expiresAt,
+		CreatedAt: now.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if client, online := v.hub.ClientByPlayerDbId(playerDbId); online {
+		client.ProcessMessage(0, packets.NewVoiceStatus(true, expiresAt, reason, v.Party(client.Id())))
+	}
+	return nil
+}
+
+// Lift the player's mute. Returns false if they weren't muted.
+func (v *Voice) Unmute(playerDbId int64) (bool, error) {
+	dbTx := v.hub.NewDbTx()
+	deleted, err := dbTx.Queries.DeleteVoiceMute(dbTx.Ctx, playerDbId)
+	if err != nil || deleted == 0 {
+		return false, err
+	}
+
+	if client, online := v.hub.ClientByPlayerDbId(playerDbId); online {
+		client.ProcessMessage(0, packets.NewVoiceStatus(false, 0, "", v.Party(client.Id())))
+	}
+	return true, nil
+}
+
+func (v *Voice) enforceLoop() {
+	ticker := time.NewTicker(voiceScopeCheckInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		v.mux.Lock()
+		clientIds := make([]uint64, 0, len(v.calls))
+		for clientId := range v.calls {
+			clientIds = append(clientIds, clientId)
+		}
+		v.mux.Unlock()
+
+		for _, clientId := range clientIds {
+			v.enforce(clientId)
+		}
+	}
+}
+
+// Hang up the client's calls that are no longer allowed
+func (v *Voice) enforce(clientId uint64) {
+	v.mux.Lock()
+	var peerIds []uint64
+	for peerId := range v.calls[clientId] {
+		if !v.inScope(clientId, peerId) {
+			peerIds = append(peerIds, peerId)
+			v.endCall(clientId, peerId)
+		}
+	}
+	v.mux.Unlock()
+
+	for _, peerId := range peerIds {
+		v.hangUp(clientId, peerId)
+	}
+}
+
+// Tell both ends of a call it's over, as though each had hung up on the other
+func (v *Voice) hangUp(clientId uint64, peerId uint64) {
+	if client, exists := v.hub.Clients.Get(clientId); exists {
+		client.ProcessMessage(peerId, packets.NewVoiceSignal(peerId, VoiceSignalHangup, ""))
+	}
+	if peer, exists := v.hub.Clients.Get(peerId); exists {
+		peer.ProcessMessage(clientId, packets.NewVoiceSignal(clientId, VoiceSignalHangup, ""))
+	}
+}
+
+// Whether the clients can talk: they can see each other, and are in the same party or within range. Must be called
+// with the lock held.
+func (v *Voice) inScope(clientId uint64, peerId uint64) bool {
+	if clientId == peerId {
+		return false
+	}
+	if !v.hub.Visibility.CanSee(clientId, peerId) || !v.hub.Visibility.CanSee(peerId, clientId) {
+		return false
+	}
+	if party, exists := v.partyOf[clientId]; exists && party == v.partyOf[peerId] {
+		return true
+	}
+	return v.hub.Visibility.withinDistance(clientId, peerId, voiceProximityRange)
+}
+
+// Must be called with the lock held
+func (v *Voice) startCall(clientId uint64, peerId uint64) {
+	for _, pair := range [][2]uint64{{clientId, peerId}, {peerId, clientId}} {
+		peers, exists := v.calls[pair[0]]
+		if !exists {
+			peers = make(map[uint64]struct{})
+			v.calls[pair[0]] = peers
+		}
+		peers[pair[1]] = struct{}{}
+	}
+}
+
+// Must be called with the lock held
+func (v *Voice) endCall(clientId uint64, peerId uint64) {
+	for _, pair := range [][2]uint64{{clientId, peerId}, {peerId, clientId}} {
+		if peers, exists := v.calls[pair[0]]; exists {
+			delete(peers, pair[1])
+			if len(peers) == 0 {
+				delete(v.calls, pair[0])
+			}
+		}
+	}
+}
+
+// Must be called with the lock held
+func (v *Voice) leaveParty(clientId uint64) {
+	party, exists := v.partyOf[clientId]
+	if !exists {
+		return
+	}
+	delete(v.partyOf, clientId)
+
+	members := v.parties[party]
+	delete(members, clientId)
+	if len(members) == 0 {
+		delete(v.parties, party)
+	}
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"time"
+)
+
+const defaultTurnCredentialTtl = 12 * time.Hour
+
+var turnCredentialsIssuedTotal = metrics.NewCounter("mmo_turn_credentials_issued_total", "TURN credentials handed out to clients.")
+
+type VoiceRelayConfig struct {
+	// Given to every client for finding their own public address, e.g. "stun:stun.example.com:3478"
+	StunUrls []string
+
+	// Relays for clients that can't reach each other directly, e.g. behind symmetric NATs, like
+	// "turn:turn.example.com:3478?transport=udp" or "turns:turn.example.com:5349". They all have to share the secret.
+	TurnUrls []string
+
+	// The static-auth-secret the TURN servers are run with, with use-auth-secret set for coturn. TURN servers are left
+	// out unless it's set.
+	TurnSecret string
+
+	// How long TURN credentials are accepted for. defaultTurnCredentialTtl if left out.
+	CredentialTtl time.Duration
+}
+
+// Must be called before the hub is run
+func (v *Voice) ConfigureRelay(config VoiceRelayConfig) {
+	if config.CredentialTtl <= 0 {
+		config.CredentialTtl = defaultTurnCredentialTtl
+	}
+	if len(config.TurnUrls) > 0 && config.TurnSecret == "" {
+		logging.Hub.Printf("TURN servers configured without a secret, only STUN servers will be given to clients")
+	}
+	v.relay = config
+}
+
+// The STUN and TURN servers for the player's client to give its WebRTC library, and when the TURN credentials expire,
+// or the zero time if there aren't any. Credentials follow the TURN REST API coturn implements: the username is the
+// expiry and the player's ID, so a relay's logs can be traced back to whoever used it, and the password is an HMAC of
+// the username with the shared secret, so the TURN servers can check it without asking the game server. A player can
+// be left out of the relays, e.g. while they're muted, and only be given the STUN servers.
+func (v *Voice) IceServers(playerId int64, relay bool) ([]*packets.IceServerMessage, time.Time) {
+	servers := make([]*packets.IceServerMessage, 0, 2)
+	if len(v.relay.StunUrls) > 0 {
+		servers = append(servers, &packets.IceServerMessage{Urls: v.relay.StunUrls})
+	}
+	if !relay || len(v.relay.TurnUrls) == 0 || v.relay.TurnSecret == "" {
+		return servers, time.Time{}
+	}
+
+	expiresAt := time.Now().Add(v.relay.CredentialTtl)
+	username := fmt.Sprintf("%d:%d", expiresAt.Unix(), playerId)
+	mac := hmac.New(sha1.New, []byte(v.relay.TurnSecret))
+	mac.Write([]byte(username))
+	servers = append(servers, &packets.IceServerMessage{
+		Urls:       v.relay.TurnUrls,
+		Username:   username,
+		Credential: base64.StdEncoding.EncodeToString(mac.Sum(nil)),
+	})
+	turnCredentialsIssuedTotal.Inc()
+	return servers, expiresAt
+}
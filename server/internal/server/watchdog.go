@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/pkg/packets"
+	"sync"
+	"time"
+)
+
+// The rate the world is simulated at. Work done on every tick should take well under this, or the world falls behind.
+const TickInterval = 50 * time.Millisecond
+
+// How long a state may take to handle one message before it's logged as slow, unless configured otherwise
+const DefaultSlowHandlerThreshold = 50 * time.Millisecond
+
+var durationBuckets = []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+var (
+	handlerDurations  = metrics.NewHistogramVec("mmo_state_handle_seconds", "How long each state took to handle a message.", "state", durationBuckets)
+	slowHandlersTotal = metrics.NewCounterVec("mmo_slow_handlers_total", "Messages a state took longer than the slow handler threshold to handle.", "state")
+	tickDurations     = metrics.NewHistogramVec("mmo_tick_subsystem_seconds", "How long each subsystem took for one tick's worth of work.", "subsystem", durationBuckets)
+	tickOverrunsTotal = metrics.NewCounterVec("mmo_tick_overruns_total", "Times a subsystem took longer than a tick for one tick's worth of work.", "subsystem")
+)
+
+// Times the work done by states and each subsystem of the hub, and calls out whatever is holding things up. Work still
+// running after a tick is logged straight away, rather than only once it's done, so a hang shows up as it happens.
+type Watchdog struct {
+	slowHandlerThreshold time.Duration
+
+	running map[uint64]*trackedWork
+	lastId  uint64
+	mux     sync.Mutex
+}
+
+type trackedWork struct {
+	subsystem string
+	startedAt time.Time
+	reported  bool
+}
+
+func NewWatchdog() *Watchdog {
+	return &Watchdog{
+		slowHandlerThreshold: DefaultSlowHandlerThreshold,
+		running:              make(map[uint64]*trackedWork),
+	}
+}
+
+// Must be called before the hub is run
+func (w *Watchdog) Configure(slowHandlerThreshold time.Duration) {
+	w.slowHandlerThreshold = slowHandlerThreshold
+}
+
+// Start timing a tick's worth of work by the subsystem, e.g. fanning out a broadcast. Call the returned function once
+// it's done.
+func (w *Watchdog) Track(subsystem string) func() {
+	startedAt := time.Now()
+
+	w.mux.Lock()
+	w.lastId++
+	id := w.lastId
+	work := &trackedWork{subsystem: subsystem, startedAt: startedAt}
+	w.running[id] = work
+	w.mux.Unlock()
+
+	return func() {
+		elapsed := time.Since(startedAt)
+
+		w.mux.Lock()
+		delete(w.running, id)
+		reported := work.reported
+		w.mux.Unlock()
+
+		tickDurations.Observe(subsystem, elapsed.Seconds())
+		if elapsed > TickInterval {
+			tickOverrunsTotal.With(subsystem).Inc()
+			if reported {
+				logging.Hub.Printf("Watchdog: %s finished after %s", subsystem, elapsed)
+			} else {
+				logging.Hub.Printf("Watchdog: %s took %s, longer than a tick (%s)", subsystem, elapsed, TickInterval)
+			}
+		}
+	}
+}
+
+// Start timing a state handling a message. Call the returned function once it's done.
+func (w *Watchdog) TrackHandler(stateName string, message packets.Msg) func() {
+	startedAt := time.Now()
+	return func() {
+		elapsed := time.Since(startedAt)
+		handlerDurations.Observe(stateName, elapsed.Seconds())
+		if elapsed > w.slowHandlerThreshold {
+			slowHandlersTotal.With(stateName).Inc()
+			logging.Hub.Printf("Watchdog: %s took %s to handle %T", stateName, elapsed, message)
+		}
+	}
+}
+
+func (w *Watchdog) watchLoop() {
+	ticker := time.NewTicker(TickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.mux.Lock()
+		var stalled []string
+		for _, work := range w.running {
+			if elapsed := time.Since(work.startedAt); !work.reported && elapsed > TickInterval {
+				work.reported = true
+				stalled = append(stalled, fmt.Sprintf("%s (%s so far)", work.subsystem, elapsed.Round(time.Millisecond)))
+			}
+		}
+		w.mux.Unlock()
+
+		for _, work := range stalled {
+			logging.Hub.Printf("Watchdog: tick overrun, still running %s", work)
+		}
+	}
+}
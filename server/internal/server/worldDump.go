@@ -0,0 +1,212 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"server/internal/server/objects"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Everything in the world as the server has it, for debugging clients that have drifted out of sync with it.
+// Objects are keyed by the same IDs clients know them by, so a client can write what it thinks the world is in the
+// same format, leaving out whatever it doesn't know, and the two can be diffed with DiffWorldDumps.
+type WorldDump struct {
+	TakenAt int64 `json:"taken_at"`
+
+	// Players by their clients' IDs
+	Players       map[uint64]*PlayerDump       `json:"players"`
+	Spores        map[uint64]*SporeDump        `json:"spores"`
+	ResourceNodes map[uint64]*ResourceNodeDump `json:"resource_nodes"`
+}
+
+type PlayerDump struct {
+	Name      string  `json:"name"`
+	DbId      int64   `json:"db_id"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Radius    float64 `json:"radius"`
+	Direction float64 `json:"direction"`
+	Speed     float64 `json:"speed"`
+	Color     int32   `json:"color"`
+	BestScore int64   `json:"best_score"`
+
+	Components *objects.Components `json:"components"`
+}
+
+type SporeDump struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius"`
+
+	// The name of the player who dropped it and when, in Unix milliseconds, or empty and 0 for spores the server
+	// placed
+	DroppedBy string `json:"dropped_by"`
+	DroppedAt int64  `json:"dropped_at"`
+}
+
+type ResourceNodeDump struct {
+	Kind   string  `json:"kind"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Radius float64 `json:"radius"`
+
+	// When it was depleted, in Unix milliseconds, or 0 if it's available
+	DepletedAt int64 `json:"depleted_at"`
+}
+
+// Each collection is gone through in turn rather than all at once, so objects moving between them while the dump is
+// taken, like a spore being eaten, can be in neither or both
+func (h *Hub) DumpWorld() *WorldDump {
+	dump := &WorldDump{
+		TakenAt:       time.Now().UnixMilli(),
+		Players:       make(map[uint64]*PlayerDump),
+		Spores:        make(map[uint64]*SporeDump),
+		ResourceNodes: make(map[uint64]*ResourceNodeDump),
+	}
+
+	h.SharedGameObjects.Players.ForEach(func(id uint64, player *objects.Player) {
+		dump.Players[id] = &PlayerDump{
+			Name:      player.Name,
+			DbId:      player.DbId,
+			X:         player.X,
+			Y:         player.Y,
+			Radius:    player.Radius,
+			Direction: player.Direction,
+			Speed:     player.Speed,
+			Color:     player.Color,
+			BestScore: player.BestScore,
+
+			Components: &player.Components,
+		}
+	})
+
+	h.SharedGameObjects.Spores.ForEach(func(id uint64, spore *objects.Spore) {
+		sporeDump := &SporeDump{X: spore.X, Y: spore.Y, Radius: spore.Radius}
+		if spore.DroppedBy != nil {
+			sporeDump.DroppedBy = spore.DroppedBy.Name
+			sporeDump.DroppedAt = spore.DroppedAt.UnixMilli()
+		}
+		dump.Spores[id] = sporeDump
+	})
+
+	h.SharedGameObjects.ResourceNodes.ForEach(func(id uint64, node *objects.ResourceNode) {
+		nodeDump := &ResourceNodeDump{Kind: node.Kind, X: node.X, Y: node.Y, Radius: node.Radius}
+		if depletedAt := node.DepletedAt(); !depletedAt.IsZero() {
+			nodeDump.DepletedAt = depletedAt.UnixMilli()
+		}
+		dump.ResourceNodes[id] = nodeDump
+	})
+
+	return dump
+}
+
+// One way two dumps of the world differ. Objects only in one of them have the other left nil.
+type WorldDiff struct {
+	// Like players.7 for a whole object, or players.7.x for one of its fields
+	Path   string `json:"path"`
+	First  any    `json:"first"`
+	Second any    `json:"second"`
+}
+
+// Whether the object is only in one of the dumps, rather than a field of it being different
+func (d WorldDiff) Missing() bool {
+	return d.First == nil || d.Second == nil
+}
+
+func (d WorldDiff) String() string {
+	switch {
+	case d.First == nil:
+		return fmt.Sprintf("%s: only in second", d.Path)
+	case d.Second == nil:
+		return fmt.Sprintf("%s: only in first", d.Path)
+	}
+	first, firstIsNumber := d.First.(float64)
+	second, secondIsNumber := d.Second.(float64)
+	if firstIsNumber && secondIsNumber {
+		return fmt.Sprintf("%s: %v != %v (off by %g)", d.Path, first, second, math.Abs(first-second))
+	}
+	return fmt.Sprintf("%s: %v != %v", d.Path, d.First, d.Second)
+}
+
+// Compare two dumps of the world, by path. Either can be partial, like what a client thinks the world is, so fields
+// only one of them has are left out, but objects only one of them has aren't. Numbers closer together than the
+// tolerance are taken as the same, so rounding and interpolation don't drown out real differences.
+func DiffWorldDumps(first []byte, second []byte, tolerance float64) ([]WorldDiff, error) {
+	var firstDump, secondDump map[string]any
+	if err := json.Unmarshal(first, &firstDump); err != nil {
+		return nil, fmt.Errorf("first dump: %w", err)
+	}
+	if err := json.Unmarshal(second, &secondDump); err != nil {
+		return nil, fmt.Errorf("second dump: %w", err)
+	}
+
+	var diffs []WorldDiff
+	for _, collection := range slices.Sorted(maps.Keys(firstDump)) {
+		firstObjects, isCollection := firstDump[collection].(map[string]any)
+		secondObjects, bothCollections := secondDump[collection].(map[string]any)
+		if !isCollection || !bothCollections {
+			// Like when each was taken, which is bound to be different
+			continue
+		}
+
+		ids := slices.Collect(maps.Keys(firstObjects))
+		for id := range secondObjects {
+			if _, exists := firstObjects[id]; !exists {
+				ids = append(ids, id)
+			}
+		}
+		slices.SortFunc(ids, compareWorldDumpIds)
+
+		for _, id := range ids {
+			path := collection + "." + id
+			firstObject, inFirst := firstObjects[id]
+			secondObject, inSecond := secondObjects[id]
+			if !inFirst || !inSecond {
+				diffs = append(diffs, WorldDiff{Path: path, First: firstObject, Second: secondObject})
+				continue
+			}
+			diffs = diffWorldValues(diffs, path, firstObject, secondObject, tolerance)
+		}
+	}
+	return diffs, nil
+}
+
+func diffWorldValues(diffs []WorldDiff, path string, first any, second any, tolerance float64) []WorldDiff {
+	firstFields, firstIsObject := first.(map[string]any)
+	secondFields, secondIsObject := second.(map[string]any)
+	if firstIsObject && secondIsObject {
+		for _, field := range slices.Sorted(maps.Keys(firstFields)) {
+			if secondValue, exists := secondFields[field]; exists {
+				diffs = diffWorldValues(diffs, path+"."+field, firstFields[field], secondValue, tolerance)
+			}
+		}
+		return diffs
+	}
+
+	firstNumber, firstIsNumber := first.(float64)
+	secondNumber, secondIsNumber := second.(float64)
+	if firstIsNumber && secondIsNumber {
+		if math.Abs(firstNumber-secondNumber) > tolerance {
+			diffs = append(diffs, WorldDiff{Path: path, First: first, Second: second})
+		}
+		return diffs
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		diffs = append(diffs, WorldDiff{Path: path, First: first, Second: second})
+	}
+	return diffs
+}
+
+// IDs are numbers, so they're sorted like them, shortest first
+func compareWorldDumpIds(a string, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,232 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"server/internal/server/db"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	WorldStatsPath = "/api/stats"
+
+	// How often what's been counted is added to today's rollup
+	worldStatsFlushInterval = 5 * time.Minute
+
+	// How often the players online are counted towards the day's peak
+	worldStatsSampleInterval = 10 * time.Second
+
+	// Days are kept by UTC day, like bandwidth usage
+	worldStatsDayLayout = time.DateOnly
+
+	// The most days of history kept in memory and served at once
+	MaxWorldStatsDays = 365
+
+	defaultWorldStatsDays = 30
+)
+
+// One day's rollup of the world's statistics
+type WorldStatsDay struct {
+	Day string `json:"day"`
+
+	// Characters ever created by the end of the day, including any since deleted
+	PlayersTotal int64 `json:"players_total"`
+
+	// The most players online at once during the day
+	PeakPlayers int64 `json:"peak_players"`
+
+	// Items that came into the world during the day, e.g. gathered, crafted or given as rewards, and those that went
+	// out of it, e.g. used up crafting or handed over in dialogs. Coins aren't counted, their supply is.
+	ItemsCreated   int64 `json:"items_created"`
+	ItemsDestroyed int64 `json:"items_destroyed"`
+
+	// Coins held by players or waiting in their mail, as of the last update
+	CurrencySupply int64 `json:"currency_supply"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func worldStatsDayFromDb(row db.WorldStat) WorldStatsDay {
+	return WorldStatsDay{
+		Day:            row.Day,
+		PlayersTotal:   row.PlayersTotal,
+		PeakPlayers:    row.PeakPlayers,
+		ItemsCreated:   row.ItemsCreated,
+		ItemsDestroyed: row.ItemsDestroyed,
+		CurrencySupply: row.CurrencySupply,
+		UpdatedAt:      time.UnixMilli(row.UpdatedAt),
+	}
+}
+
+// Aggregates long-term statistics of the whole world, like how many players it's ever had and how much coin there is,
+// into a row in the database for each day, so how the world's grown can be graphed at WorldStatsPath and on the admin
+// dashboard. What's counted as it happens is kept in memory and added to today's row every so often, along with totals
+// taken from the database then. The history's kept in memory too, so serving it never reaches the database.
+type WorldStats struct {
+	hub *Hub
+
+	// Since the last flush
+	peakPlayers    int64
+	itemsCreated   int64
+	itemsDestroyed int64
+	mux            sync.Mutex
+
+	// Oldest first, as of the last flush
+	history    []WorldStatsDay
+	historyMux sync.RWMutex
+}
+
+func NewWorldStats(hub *Hub) *WorldStats {
+	return &WorldStats{hub: hub}
+}
+
+// Count the items as having come into the world. Coins are left out, since their supply is taken as a whole.
+func (s *WorldStats) ItemsCreated(items map[string]int64) {
+	created := countItems(items)
+	s.mux.Lock()
+	s.itemsCreated += created
+	s.mux.Unlock()
+}
+
+// Count the items as having gone out of the world. Coins are left out, since their supply is taken as a whole.
+func (s *WorldStats) ItemsDestroyed(items map[string]int64) {
+	destroyed := countItems(items)
+	s.mux.Lock()
+	s.itemsDestroyed += destroyed
+	s.mux.Unlock()
+}
+
+func countItems(items map[string]int64) int64 {
+	var count int64
+	for itemId, quantity := range items {
+		if itemId != gamedata.CurrencyItemId {
+			count += quantity
+		}
+	}
+	return count
+}
+
+// The history from the given number of days ago up to and including today, oldest first
+func (s *WorldStats) History(days int) []WorldStatsDay {
+	since := time.Now().UTC().AddDate(0, 0, -days).Format(worldStatsDayLayout)
+	s.historyMux.RLock()
+	defer s.historyMux.RUnlock()
+	for i, day := range s.history {
+		if day.Day > since {
+			return append([]WorldStatsDay(nil), s.history[i:]...)
+		}
+	}
+	return []WorldStatsDay{}
+}
+
+func (s *WorldStats) aggregateLoop() {
+	s.load()
+
+	sampleTicker := time.NewTicker(worldStatsSampleInterval)
+	defer sampleTicker.Stop()
+	flushedAt := time.Now()
+
+	for now := range sampleTicker.C {
+		s.sample()
+		if now.Sub(flushedAt) < worldStatsFlushInterval || !s.hub.dbHealth.Healthy() {
+			continue
+		}
+		s.flush()
+		flushedAt = now
+	}
+}
+
+// Count the players online towards the peak
+func (s *WorldStats) sample() {
+	online := int64(s.hub.Sessions.Count())
+	s.mux.Lock()
+	s.peakPlayers = max(s.peakPlayers, online)
+	s.mux.Unlock()
+}
+
+// Add everything counted since last time to today's row, along with the totals as they are now. Anything counted is
+// kept until next time if the write fails.
+func (s *WorldStats) flush() {
+	s.mux.Lock()
+	peak, created, destroyed := s.peakPlayers, s.itemsCreated, s.itemsDestroyed
+	s.peakPlayers, s.itemsCreated, s.itemsDestroyed = 0, 0, 0
+	s.mux.Unlock()
+
+	// IDs are never handed out again, so the highest is how many characters there have ever been. Deleting the
+	// latest would lower it, so it's never let go below the last day's.
+	dbTx := s.hub.NewDbTx()
+	playersTotal, err := dbTx.Queries.GetPlayersEver(dbTx.Ctx)
+	if err == nil {
+		s.historyMux.RLock()
+		if len(s.history) > 0 {
+			playersTotal = max(playersTotal, s.history[len(s.history)-1].PlayersTotal)
+		}
+		s.historyMux.RUnlock()
+
+		var supply int64
+		if supply, err = dbTx.Queries.GetCurrencySupply(dbTx.Ctx, gamedata.CurrencyItemId); err == nil {
+			err = dbTx.Queries.AddWorldStats(dbTx.Ctx, db.AddWorldStatsParams{
+				Day:            time.Now().UTC().Format(worldStatsDayLayout),
+				PlayersTotal:   playersTotal,
+				PeakPlayers:    peak,
+				ItemsCreated:   created,
+				ItemsDestroyed: destroyed,
+				CurrencySupply: supply,
+				UpdatedAt:      time.Now().UnixMilli(),
+			})
+		}
+	}
+	if err != nil {
+		logging.Hub.Errorf("Error rolling up world stats, trying again next time: %v", err)
+		s.mux.Lock()
+		s.peakPlayers = max(s.peakPlayers, peak)
+		s.itemsCreated += created
+		s.itemsDestroyed += destroyed
+		s.mux.Unlock()
+		return
+	}
+	s.load()
+}
+
+// Refresh the history kept in memory from the database
+func (s *WorldStats) load() {
+	dbTx := s.hub.NewDbTx()
+	rows, err := dbTx.Queries.GetWorldStats(dbTx.Ctx, db.GetWorldStatsParams{
+		Day:   time.Now().UTC().AddDate(0, 0, -MaxWorldStatsDays).Format(worldStatsDayLayout),
+		Limit: MaxWorldStatsDays + 1,
+	})
+	if err != nil {
+		logging.Hub.Errorf("Error loading world stats: %v", err)
+		return
+	}
+
+	history := make([]WorldStatsDay, len(rows))
+	for i, row := range rows {
+		history[i] = worldStatsDayFromDb(row)
+	}
+	s.historyMux.Lock()
+	s.history = history
+	s.historyMux.Unlock()
+}
+
+// Query parameters: days (default 30, at most 365)
+func (s *WorldStats) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	days := defaultWorldStatsDays
+	if param := request.URL.Query().Get("days"); param != "" {
+		var err error
+		if days, err = strconv.Atoi(param); err != nil || days <= 0 || days > MaxWorldStatsDays {
+			http.Error(writer, "invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", "public, max-age=60")
+	if err := json.NewEncoder(writer).Encode(map[string]any{"days": s.History(days)}); err != nil {
+		logging.Hub.Errorf("Error writing world stats: %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"cmp"
+	"server/internal/server/gamedata"
+	"server/internal/server/logging"
+	"server/internal/server/objects"
+	"server/pkg/packets"
+	"slices"
+	"sync"
+)
+
+// What kind of thing in the world something tagged is, since each kind's kept in a collection of its own
+type ObjectKind string
+
+const (
+	// A player, or an actor driven by a world simulator or spawned in the sandbox
+	ObjectActor        ObjectKind = "actor"
+	ObjectSpore        ObjectKind = "spore"
+	ObjectResourceNode ObjectKind = "resource_node"
+	ObjectEmitter      ObjectKind = "emitter"
+)
+
+// What to find tagged things by. Everything but the tag can be left out, and what's set narrows down what's found.
+type TagQuery struct {
+	Tag string
+
+	Kind ObjectKind
+
+	// Only what's in the zone
+	ZoneId string
+
+	// Only what's within the radius of the position, edge to edge, if the radius is more than 0
+	X, Y, Radius float64
+}
+
+// Something in the world found by its tag, where it was when it was found
+type TaggedObject struct {
+	Id   uint64
+	Kind ObjectKind
+	objects.Position
+	Radius float64
+	Tags   []string
+}
+
+// One thing's tags
+type objectTags struct {
+	kind ObjectKind
+	tags map[string]struct{}
+}
+
+// Tags what's in the world, e.g. "halloween" or "boss", so scripts running world events and the hub's own subsystems
+// can find everything with a tag without going through every collection, say to despawn it all once the event's over.
+// Tags come from the game data as things are placed, from simulators as they spawn their actors, or from scripts at
+// any time. Things are forgotten once they're known to be gone, or otherwise the next time a query finds them missing.
+type WorldTags struct {
+	hub *Hub
+
+	// Tagged things by ID, and their IDs by tag
+	objects map[uint64]*objectTags
+	byTag   map[string]map[uint64]struct{}
+	mux     sync.RWMutex
+}
+
+func NewWorldTags(hub *Hub) *WorldTags {
+	return &WorldTags{
+		hub:     hub,
+		objects: make(map[uint64]*objectTags),
+		byTag:   make(map[string]map[uint64]struct{}),
+	}
+}
+
+// Give the thing of the kind the tags, on top of any it has already. Returns an error, and tags nothing, if any of
+// them aren't valid tags.
+func (t *WorldTags) Tag(kind ObjectKind, id uint64, tags ...string) error {
+	if err := gamedata.ValidateTags(tags); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	object, exists := t.objects[id]
+	if !exists {
+		object = &objectTags{kind: kind, tags: make(map[string]struct{})}
+		t.objects[id] = object
+	}
+	for _, tag := range tags {
+		object.tags[tag] = struct{}{}
+		ids := t.byTag[tag]
+		if ids == nil {
+			ids = make(map[uint64]struct{})
+			t.byTag[tag] = ids
+		}
+		ids[id] = struct{}{}
+	}
+	return nil
+}
+
+// Take the tags off the thing, or all of them if none are given
+func (t *WorldTags) Untag(id uint64, tags ...string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.untagLocked(id, tags)
+}
+
+// Must be called with the lock held
+func (t *WorldTags) untagLocked(id uint64, tags []string) {
+	object, exists := t.objects[id]
+	if !exists {
+		return
+	}
+	if len(tags) == 0 {
+		for tag := range object.tags {
+			tags = append(tags, tag)
+		}
+	}
+	for _, tag := range tags {
+		delete(object.tags, tag)
+		delete(t.byTag[tag], id)
+		if len(t.byTag[tag]) == 0 {
+			delete(t.byTag, tag)
+		}
+	}
+	if len(object.tags) == 0 {
+		delete(t.objects, id)
+	}
+}
+
+// Forget the thing's tags once it's gone
+func (t *WorldTags) Forget(id uint64) {
+	t.Untag(id)
+}
+
+// The thing's tags, sorted
+func (t *WorldTags) TagsOf(id uint64) []string {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	object, exists := t.objects[id]
+	if !exists {
+		return nil
+	}
+	tags := make([]string, 0, len(object.tags))
+	for tag := range object.tags {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// Everything with the query's tag that matches the rest of it, sorted by ID. Only what has the tag is looked at, so
+// this is as quick as there are few things with it, however much else is in the world.
+func (t *WorldTags) Query(query TagQuery) []TaggedObject {
+	var zone *gamedata.Zone
+	if query.ZoneId != "" {
+		var exists bool
+		if zone, exists = t.hub.GameData.Zone(query.ZoneId); !exists {
+			return nil
+		}
+	}
+
+	t.mux.RLock()
+	candidates := make(map[uint64]ObjectKind, len(t.byTag[query.Tag]))
+	for id := range t.byTag[query.Tag] {
+		if kind := t.objects[id].kind; query.Kind == "" || kind == query.Kind {
+			candidates[id] = kind
+		}
+	}
+	t.mux.RUnlock()
+
+	var found []TaggedObject
+	var gone []uint64
+	for id, kind := range candidates {
+		position, radius, exists := t.locate(kind, id)
+		if !exists {
+			gone = append(gone, id)
+			continue
+		}
+		if zone != nil && !zone.Contains(position.X, position.Y) {
+			continue
+		}
+		if query.Radius > 0 {
+			dx, dy := position.X-query.X, position.Y-query.Y
+			reach := query.Radius + radius
+			if dx*dx+dy*dy > reach*reach {
+				continue
+			}
+		}
+		found = append(found, TaggedObject{Id: id, Kind: kind, Position: position, Radius: radius, Tags: t.TagsOf(id)})
+	}
+
+	if len(gone) > 0 {
+		t.mux.Lock()
+		for _, id := range gone {
+			t.untagLocked(id, nil)
+		}
+		t.mux.Unlock()
+	}
+
+	slices.SortFunc(found, func(a, b TaggedObject) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return found
+}
+
+// Where the thing of the kind is, and how big, if it's still in the world
+func (t *WorldTags) locate(kind ObjectKind, id uint64) (objects.Position, float64, bool) {
+	gameObjects := t.hub.SharedGameObjects
+	switch kind {
+	case ObjectActor:
+		if player, exists := gameObjects.Players.Get(id); exists {
+			return player.Position, player.Radius, true
+		}
+	case ObjectSpore:
+		if spore, exists := gameObjects.Spores.Get(id); exists {
+			return spore.Position, spore.Radius, true
+		}
+	case ObjectResourceNode:
+		if node, exists := gameObjects.ResourceNodes.Get(id); exists {
+			return node.Position, node.Radius, true
+		}
+	case ObjectEmitter:
+		if emitter, exists := gameObjects.Emitters.Get(id); exists {
+			return emitter.Position, emitter.Radius, true
+		}
+	}
+	return objects.Position{}, 0, false
+}
+
+// Take everything the query finds out of the world, e.g. everything tagged for an event once it's over, returning how
+// many were despawned. Actors driven by world simulators are despawned for them, and players' own are never despawned.
+func (t *WorldTags) Despawn(query TagQuery) int {
+	despawned := 0
+	for _, object := range t.Query(query) {
+		if t.despawn(object) {
+			despawned++
+		}
+	}
+	if despawned > 0 {
+		logging.Hub.Printf("Despawned %d objects tagged %s", despawned, query.Tag)
+	}
+	return despawned
+}
+
+func (t *WorldTags) despawn(object TaggedObject) bool {
+	gameObjects := t.hub.SharedGameObjects
+	switch object.Kind {
+	case ObjectActor:
+		simulatorId, driven := t.hub.Simulators.Owner(object.Id)
+		if !driven {
+			return false
+		}
+		t.hub.Simulators.Despawn(simulatorId, []uint64{object.Id})
+	case ObjectEmitter:
+		if err := t.hub.Emitters.Remove(object.Id); err != nil {
+			return false
+		}
+	case ObjectSpore:
+		gameObjects.Spores.Remove(object.Id)
+		t.hub.Broadcast(&packets.Packet{SenderId: object.Id, Msg: packets.NewDisconnect("despawned")})
+	case ObjectResourceNode:
+		gameObjects.ResourceNodes.Remove(object.Id)
+		t.hub.Broadcast(&packets.Packet{SenderId: object.Id, Msg: packets.NewDisconnect("despawned")})
+	default:
+		return false
+	}
+	t.Forget(object.Id)
+	return true
+}
+
+// Tag every resource node in the world by its kind's tags, once they've been placed or restored
+func (t *WorldTags) tagResourceNodes() {
+	t.hub.SharedGameObjects.ResourceNodes.ForEach(func(id uint64, node *objects.ResourceNode) {
+		if kind, exists := t.hub.GameData.ResourceNodeKinds[node.Kind]; exists {
+			t.Tag(ObjectResourceNode, id, kind.Tags...)
+		}
+	})
+}
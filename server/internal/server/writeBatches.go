@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"server/internal/server/db"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"sync"
+	"time"
+)
+
+// The label batches of writes made through DbTx.Batch are measured under, alongside each save tier's
+const writeBatchLabel = "tick"
+
+var batchSizeBuckets = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000}
+
+var (
+	dbBatchSize          = metrics.NewHistogramVec("mmo_db_batch_size", "How many writes were committed together in one transaction, by batch.", "batch", batchSizeBuckets)
+	dbBatchCommitSeconds = metrics.NewHistogramVec("mmo_db_batch_commit_seconds", "How long batches of writes took to commit, by batch.", "batch", durationBuckets)
+	dbBatchFailures      = metrics.NewCounterVec("mmo_db_batch_failures_total", "Batches of writes that couldn't be committed together, so each write was made on its own, by batch.", "batch")
+)
+
+type WriteBatchConfig struct {
+	// How often pending writes are committed, or 0 to make each one straight away
+	Interval time.Duration
+
+	// The most writes committed in one transaction. Any more are committed in more transactions, one after another.
+	MaxBatch int
+}
+
+var DefaultWriteBatchConfig = WriteBatchConfig{Interval: TickInterval, MaxBatch: 500}
+
+// Coalesces writes that don't have to be made on their own, e.g. chat being kept for moderators or saves written as
+// soon as they're made, into a few transactions each interval instead of one each. Everything made during a tick is
+// most often committed together. A write's only as late as the interval, and everything pending is written before a
+// restart.
+type WriteBatches struct {
+	hub    *Hub
+	config WriteBatchConfig
+
+	// In the order they were made
+	pending []*pendingWrite
+	mux     sync.Mutex
+}
+
+func NewWriteBatches(hub *Hub) *WriteBatches {
+	return &WriteBatches{hub: hub, config: DefaultWriteBatchConfig}
+}
+
+// Must be called before the hub is run
+func (b *WriteBatches) Configure(config WriteBatchConfig) {
+	config.MaxBatch = max(config.MaxBatch, 1)
+	b.config = config
+	if config.Interval > 0 {
+		logging.Hub.Printf("Committing batched writes every %s, up to %d at once", config.Interval, config.MaxBatch)
+	}
+}
+
+func (b *WriteBatches) add(dbTx *DbTx, description string, write func(ctx context.Context, queries *db.Queries) error) error {
+	b.mux.Lock()
+	if b.config.Interval <= 0 || len(b.pending) >= maxWriteBehindQueue {
+		b.mux.Unlock()
+		return dbTx.WriteBehind(description, write)
+	}
+	b.pending = append(b.pending, &pendingWrite{description: description, write: write})
+	b.mux.Unlock()
+	return nil
+}
+
+// Commit everything pending a batch at a time
+func (b *WriteBatches) flush() {
+	for {
+		batch := b.take(b.config.MaxBatch)
+		if len(batch) == 0 {
+			return
+		}
+		b.commit(batch)
+		if len(batch) < b.config.MaxBatch {
+			return
+		}
+	}
+}
+
+// The oldest pending writes, up to the given number
+func (b *WriteBatches) take(n int) []*pendingWrite {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	n = min(n, len(b.pending))
+	batch := b.pending[:n:n]
+	b.pending = b.pending[n:]
+	return batch
+}
+
+// Commit the batch in one transaction. If that fails, each write's made on its own so one bad write doesn't take the
+// rest with it, and any that can't be because the database is unavailable are queued until it's back.
+func (b *WriteBatches) commit(batch []*pendingWrite) {
+	dbTx := b.hub.NewDbTx()
+	err := observeBatch(writeBatchLabel, len(batch), func() error {
+		return dbTx.InTx(func(queries *db.Queries) error {
+			for _, write := range batch {
+				if err := write.write(dbTx.Ctx, queries); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err == nil {
+		return
+	}
+
+	for _, write := range batch {
+		err := dbTx.WriteBehind(write.description, write.write)
+		if err != nil && !errors.Is(err, ErrStaleCharacter) {
+			logging.Db.Errorf("Error making batched write (%s): %v", write.description, err)
+		}
+	}
+}
+
+// Commit a batch of writes, measuring how many there were and how long it took
+func observeBatch(label string, size int, commit func() error) error {
+	started := time.Now()
+	if err := commit(); err != nil {
+		dbBatchFailures.With(label).Inc()
+		return err
+	}
+	dbBatchSize.Observe(label, float64(size))
+	dbBatchCommitSeconds.Observe(label, time.Since(started).Seconds())
+	return nil
+}
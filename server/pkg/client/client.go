@@ -0,0 +1,367 @@
+// Package client talks to the MMO server the way the game client does, for bots, integration tests and other tools
+// written in Go. It takes care of the connection and the wire format, so callers only deal in packets.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"server/pkg/packets"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// Returned by Login and Register when the server turns the request down, with the reason it gave
+type DeniedError struct {
+	Reason string
+
+	// Names that could be registered instead, if it was the name that was turned down
+	NameSuggestions []string
+}
+
+func (e *DeniedError) Error() string {
+	return "denied: " + e.Reason
+}
+
+// Returned by Login and Register when the server wants a CAPTCHA solved first, which is then sent with SolveCaptcha
+type CaptchaRequiredError struct {
+	Provider string
+	SiteKey  string
+}
+
+func (e *CaptchaRequiredError) Error() string {
+	return "a " + e.Provider + " CAPTCHA is required"
+}
+
+var ErrClosed = errors.New("connection closed")
+
+// Why the connection closed when the server kicked us, with whether and when it said to reconnect
+type KickedError struct {
+	Kick *packets.KickMessage
+}
+
+func (e *KickedError) Error() string {
+	return fmt.Sprintf("%s: kicked (%s): %s", ErrClosed, e.Kick.ReasonCode, e.Kick.Reason)
+}
+
+func (e *KickedError) Unwrap() error {
+	return ErrClosed
+}
+
+// How long to wait before reconnecting, or false if the server said not to
+func (e *KickedError) RetryAfter() (time.Duration, bool) {
+	return time.Duration(e.Kick.RetryAfterSeconds) * time.Second, e.Kick.Reconnect
+}
+
+// Called with each packet the server sends, from the goroutine reading the connection, so it mustn't block for long
+type Handler func(senderId uint64, message packets.Msg)
+
+// A connection to the server as one player
+type Client struct {
+	conn *websocket.Conn
+
+	// The ID the server gave us on connecting, which other clients see us as
+	id uint64
+
+	handlers []Handler
+	waiters  map[*waiter]struct{}
+	mux      sync.Mutex
+
+	writeMux sync.Mutex
+
+	// WebSocket message payloads, not counting framing
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+
+	done chan struct{}
+	err  error
+
+	// What the server said as it kicked us, if it did
+	kick *packets.KickMessage
+}
+
+type waiter struct {
+	match  func(*packets.Packet) bool
+	packet chan *packets.Packet
+}
+
+// Connect to the server's WebSocket endpoint, e.g. ws://localhost:8080/ws, and wait for it to hand out our ID
+func Dial(ctx context.Context, url string, header http.Header) (*Client, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		conn:    conn,
+		waiters: make(map[*waiter]struct{}),
+		done:    make(chan struct{}),
+	}
+
+	// The first packet is always our ID, before anything else can be sent
+	data, err := c.read()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error reading client ID: %w", err)
+	}
+	packet, err := decode(data)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	idMessage, ok := packet.Msg.(*packets.Packet_Id)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("expected client ID, got %T", packet.Msg)
+	}
+	c.id = idMessage.Id.Id
+
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *Client) Id() uint64 {
+	return c.id
+}
+
+// Register a handler for every packet from now on
+func (c *Client) Handle(handler Handler) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.handlers = append(c.handlers, handler)
+}
+
+// Register a handler for one kind of message, e.g. On(c, func(senderId uint64, message *packets.Packet_Chat) {...})
+func On[T packets.Msg](c *Client, handler func(senderId uint64, message T)) {
+	c.Handle(func(senderId uint64, message packets.Msg) {
+		if message, ok := message.(T); ok {
+			handler(senderId, message)
+		}
+	})
+}
+
+// Answer every prompt the server puts to us from now on with its first option, which for the welcome steps shown after
+// logging in is accepting them, so bots can get into the game on servers with rules to accept
+func (c *Client) AcceptPrompts() {
+	On(c, func(_ uint64, message *packets.Packet_Prompt) {
+		if options := message.Prompt.Options; len(options) > 0 {
+			c.Send(&packets.Packet_PromptResponse{PromptResponse: &packets.PromptResponseMessage{
+				PromptId: message.Prompt.PromptId,
+				OptionId: options[0].Id,
+			}})
+		}
+	})
+}
+
+// Send a message as ourselves
+func (c *Client) Send(message packets.Msg) error {
+	data, err := proto.Marshal(&packets.Packet{Msg: message})
+	if err != nil {
+		return err
+	}
+
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+	if err := c.conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+		return err
+	}
+	c.bytesSent.Add(int64(len(data)))
+	return nil
+}
+
+// How much we've sent and been sent since connecting
+func (c *Client) BytesSent() int64 {
+	return c.bytesSent.Load()
+}
+
+func (c *Client) BytesReceived() int64 {
+	return c.bytesReceived.Load()
+}
+
+// Wait for the next packet that matches, received after the call
+func (c *Client) Wait(ctx context.Context, match func(*packets.Packet) bool) (*packets.Packet, error) {
+	w := c.addWaiter(match)
+	defer c.removeWaiter(w)
+	return c.await(ctx, w)
+}
+
+// Wait for the next message of one kind, e.g. Expect[*packets.Packet_Player](ctx, c)
+func Expect[T packets.Msg](ctx context.Context, c *Client) (T, error) {
+	packet, err := c.Wait(ctx, func(packet *packets.Packet) bool {
+		_, ok := packet.Msg.(T)
+		return ok
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return packet.Msg.(T), nil
+}
+
+// Send a message and wait for the server to say whether it went through
+func (c *Client) Request(ctx context.Context, message packets.Msg) error {
+	isResponse := func(packet *packets.Packet) bool {
+		switch packet.Msg.(type) {
+		case *packets.Packet_OkResponse, *packets.Packet_DenyResponse, *packets.Packet_CaptchaChallenge:
+			return packet.SenderId == c.id
+		}
+		return false
+	}
+
+	// Start waiting first, since the answer can come back before Send even returns
+	w := c.addWaiter(isResponse)
+	defer c.removeWaiter(w)
+
+	if err := c.Send(message); err != nil {
+		return err
+	}
+	packet, err := c.await(ctx, w)
+	if err != nil {
+		return err
+	}
+	if deny, ok := packet.Msg.(*packets.Packet_DenyResponse); ok {
+		return &DeniedError{Reason: deny.DenyResponse.Reason, NameSuggestions: deny.DenyResponse.NameSuggestions}
+	}
+	if challenge, ok := packet.Msg.(*packets.Packet_CaptchaChallenge); ok {
+		return &CaptchaRequiredError{Provider: challenge.CaptchaChallenge.Provider, SiteKey: challenge.CaptchaChallenge.SiteKey}
+	}
+	return nil
+}
+
+// Log in, which puts the player in the game once they're through any welcome steps, see AcceptPrompts. Capabilities
+// are optional features the tool supports, which decide which feature flags it gets.
+func (c *Client) Login(ctx context.Context, username string, password string, capabilities ...string) error {
+	return c.Request(ctx, packets.NewLoginRequest(username, password, capabilities...))
+}
+
+// Send the token from solving the CAPTCHA a login or registration was challenged with, and wait for how the login or
+// registration went
+func (c *Client) SolveCaptcha(ctx context.Context, token string) error {
+	return c.Request(ctx, packets.NewCaptchaResponse(token))
+}
+
+// Log in as a trusted world simulator, which can then spawn and drive actors of its own
+func (c *Client) LoginSimulator(ctx context.Context, secret string, name string) error {
+	return c.Request(ctx, packets.NewSimulatorLoginRequest(secret, name))
+}
+
+func (c *Client) Register(ctx context.Context, username string, password string, color int32) error {
+	return c.Request(ctx, packets.NewRegisterRequest(username, password, color))
+}
+
+// Closed once the connection is, by either side
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Why the connection closed, once it has. A *KickedError if the server kicked us.
+func (c *Client) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *Client) Close() error {
+	c.writeMux.Lock()
+	c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.writeMux.Unlock()
+	return c.conn.Close()
+}
+
+func (c *Client) addWaiter(match func(*packets.Packet) bool) *waiter {
+	w := &waiter{match: match, packet: make(chan *packets.Packet, 1)}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.waiters[w] = struct{}{}
+	return w
+}
+
+func (c *Client) removeWaiter(w *waiter) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.waiters, w)
+}
+
+func (c *Client) await(ctx context.Context, w *waiter) (*packets.Packet, error) {
+	select {
+	case packet := <-w.packet:
+		return packet, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done:
+		return nil, c.Err()
+	}
+}
+
+func (c *Client) readLoop() {
+	for {
+		data, err := c.read()
+		if err != nil {
+			c.err = ErrClosed
+			// Closing it ourselves isn't worth an error beyond that
+			if c.kick != nil {
+				c.err = &KickedError{Kick: c.kick}
+			} else if !websocket.IsCloseError(err, websocket.CloseNormalClosure) && !errors.Is(err, net.ErrClosed) {
+				c.err = fmt.Errorf("%w: %w", ErrClosed, err)
+			}
+			close(c.done)
+			return
+		}
+
+		// A packet that doesn't decode is skipped rather than giving up on the connection, as the server does
+		packet, err := decode(data)
+		if err != nil {
+			continue
+		}
+		if kick, ok := packet.Msg.(*packets.Packet_Kick); ok {
+			c.kick = kick.Kick
+		}
+
+		c.mux.Lock()
+		handlers := c.handlers
+		for w := range c.waiters {
+			if w.match(packet) {
+				select {
+				case w.packet <- packet:
+				default:
+				}
+			}
+		}
+		c.mux.Unlock()
+
+		for _, handler := range handlers {
+			handler(packet.SenderId, packet.Msg)
+		}
+	}
+}
+
+func (c *Client) read() ([]byte, error) {
+	_, data, err := c.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	c.bytesReceived.Add(int64(len(data)))
+
+	// The server ends every packet with a newline, which isn't part of the encoding
+	if len(data) > 0 {
+		data = data[:len(data)-1]
+	}
+	return data, nil
+}
+
+func decode(data []byte) (*packets.Packet, error) {
+	packet := &packets.Packet{}
+	if err := proto.Unmarshal(data, packet); err != nil {
+		return nil, fmt.Errorf("error unmarshalling packet: %w", err)
+	}
+	return packet, nil
+}
@@ -0,0 +1,721 @@
+// Package mmoserver runs the MMO server inside another Go program, which can add its own states, packets and HTTP
+// endpoints on top of the built-in game. cmd/main.go is the server as it ships, built on this package.
+package mmoserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"server/internal/server"
+	"server/internal/server/admin"
+	"server/internal/server/clients"
+	"server/internal/server/diagnostics"
+	"server/internal/server/logging"
+	"server/internal/server/metrics"
+	"server/internal/server/objects"
+	"server/internal/server/states"
+	"strconv"
+	"time"
+)
+
+type (
+	Hub                 = server.Hub
+	ClientInterfacer    = server.ClientInterfacer
+	ClientStateHandler  = server.ClientStateHandler
+	MessageKindFilter   = server.MessageKindFilter
+	DbTx                = server.DbTx
+	CustomHandler       = server.CustomHandler
+	WebSocketLimits     = clients.WebSocketLimits
+	OutboxPublisher     = server.OutboxPublisher
+	SaveTiers           = server.SaveTiers
+	SaveTierConfig      = server.SaveTierConfig
+	WriteBatchConfig    = server.WriteBatchConfig
+	OutboxEvent         = server.OutboxEvent
+	ShadowFactory       = server.ShadowFactory
+	ReceiptValidator    = server.ReceiptValidator
+	Prompt              = server.Prompt
+	PromptOption        = server.PromptOption
+	PromptCallback      = server.PromptCallback
+	ClientVersionPolicy = server.ClientVersionPolicy
+	CaptchaConfig       = server.CaptchaConfig
+	CurrencyLimits      = server.CurrencyLimits
+	CarryLimits         = server.CarryLimits
+	LockoutReset        = server.LockoutReset
+	ObjectKind          = server.ObjectKind
+	TagQuery            = server.TagQuery
+	TaggedObject        = server.TaggedObject
+	VoiceRelayConfig    = server.VoiceRelayConfig
+
+	// Makes a client for a new connection, i.e. a transport, see Server.HandleTransport
+	NewClientFunc = func(*Hub, http.ResponseWriter, *http.Request) (ClientInterfacer, error)
+)
+
+// What can be tagged in the world, see Hub.WorldTags
+const (
+	ObjectActor        = server.ObjectActor
+	ObjectSpore        = server.ObjectSpore
+	ObjectResourceNode = server.ObjectResourceNode
+	ObjectEmitter      = server.ObjectEmitter
+)
+
+// The exit code to use after ErrRestart, so the orchestrator can tell a scheduled restart apart from a crash
+const RestartExitCode = server.RestartExitCode
+
+const DefaultSlowHandlerThreshold = server.DefaultSlowHandlerThreshold
+
+const DefaultTickBudget = server.DefaultTickBudget
+
+const (
+	DefaultAccountDeletionDelay = server.DefaultAccountDeletionDelay
+	DefaultDataExportTtl        = server.DefaultDataExportTtl
+
+	DefaultBandwidthThrottleRate = server.DefaultBandwidthThrottleRate
+
+	DefaultShadowFraction = server.DefaultShadowFraction
+
+	DefaultMaxFileSize = server.DefaultMaxFileSize
+
+	DefaultMutexProfileFraction = diagnostics.DefaultMutexProfileFraction
+
+	DefaultJobConcurrency = server.DefaultJobConcurrency
+
+	DefaultBackupInterval = server.DefaultBackupInterval
+	DefaultBackupKeep     = server.DefaultBackupKeep
+
+	DefaultAlertInterval = server.DefaultAlertInterval
+	DefaultAlertCooldown = server.DefaultAlertCooldown
+
+	DefaultSeedPassword = server.DefaultSeedPassword
+
+	DefaultAoiRadius = server.DefaultAoiRadius
+
+	DefaultChatRetention = server.DefaultChatRetention
+)
+
+// What kinds of files can be pushed to clients unless configured otherwise
+var DefaultFileContentTypes = server.DefaultFileContentTypes
+
+// How many hubs can hand out IDs at once, each as a different node
+const MaxIdNodes = objects.MaxIdNodes
+
+// Returned by ListenAndServe once the world has been saved for a scheduled restart
+var ErrRestart = errors.New("server stopped for a scheduled restart")
+
+// For receipt validators to wrap when a receipt isn't for a real purchase, see ReceiptValidator
+var ErrInvalidReceipt = server.ErrInvalidReceipt
+
+// What prompt callbacks are called with when the player didn't answer, see ClientInterfacer.Prompts
+var (
+	ErrPromptTimedOut  = server.ErrPromptTimedOut
+	ErrPromptCancelled = server.ErrPromptCancelled
+	ErrPromptClosed    = server.ErrPromptClosed
+)
+
+var DefaultWebSocketLimits = clients.DefaultWebSocketLimits
+
+var DefaultSaveTiers = server.DefaultSaveTiers
+
+var DefaultWriteBatchConfig = server.DefaultWriteBatchConfig
+
+const DefaultCaptchaFailureThreshold = server.DefaultCaptchaFailureThreshold
+
+var DefaultCurrencyLimits = server.DefaultCurrencyLimits
+
+var DefaultLockoutReset = server.DefaultLockoutReset
+
+// Parse a lockout reset like "tuesday 15:00", in UTC
+var ParseLockoutReset = server.ParseLockoutReset
+
+type Config struct {
+	Port int
+
+	// Where the database and game data files are
+	DataPath string
+
+	// The Godot HTML5 export to serve at /, if any
+	ClientPath string
+
+	// Serve over TLS directly if both are set, rather than leaving it to a reverse proxy
+	CertPath string
+	KeyPath  string
+
+	// With TLS, also listen for plain HTTP on this port and redirect it to HTTPS, unless 0
+	HttpRedirectPort int
+
+	// Also let native clients connect over UDP on this port, unless 0. Browsers can only use websockets.
+	UdpPort int
+
+	// Also let browsers connect over WebTransport on this UDP port, unless 0, at clients.WebTransportPath. Only served
+	// with CertPath and KeyPath, since browsers only use WebTransport over TLS.
+	WebTransportPort int
+
+	// Presence gossip between shards, disabled unless a secret and peers are configured
+	ShardId        string
+	PresencePeers  []string
+	PresenceSecret string
+
+	// Which node this hub hands out IDs as, less than MaxIdNodes. Every hub running at once needs its own for
+	// their IDs to be unique across all of them.
+	IdNode uint64
+
+	// Restart the server this long after it starts, or never if 0
+	RestartInterval time.Duration
+
+	WebSocketLimits WebSocketLimits
+
+	// Only let browsers connect from pages served by the server itself or from AllowedOrigins, e.g.
+	// "https://play.example.com", rather than from any site. Native clients can always connect.
+	CheckOrigin    bool
+	AllowedOrigins []string
+
+	// How long a state may take to handle a message before it's logged as slow
+	SlowHandlerThreshold time.Duration
+
+	// How much of each tick the hub's periodic work, e.g. spawning and batched writes, can take before what's left is
+	// put off to the next tick. DefaultTickBudget if left out, or everything due is run on each tick if below 0.
+	TickBudget time.Duration
+
+	// How many workers each broadcast is delivered to its recipients on. One for each CPU if left out, or 1 to deliver
+	// them on the hub's goroutine.
+	BroadcastWorkers int
+
+	// The STUN and TURN servers players are given for voice calls, so those behind NATs that can't be traversed
+	// directly can still talk through a relay. Calls are only attempted directly if left out.
+	VoiceRelay VoiceRelayConfig
+
+	// How often online players' live state is checked against what's saved for them, and whether what's drifted is
+	// repaired. DefaultConsistencyCheckInterval if left out, or only when an admin asks if below 0.
+	ConsistencyInterval time.Duration
+	ConsistencyRepair   bool
+
+	// The admin API and dashboard are disabled unless a token is configured
+	AdminToken string
+
+	// Also accepted by the admin API, and needed for anything sensitive enough to skip asking players first, like
+	// impersonating them without their consent. Left empty, nothing can.
+	AdminElevatedToken string
+
+	// The API for an external matchmaker to hold places for players is disabled unless a token is configured
+	MatchmakerToken string
+
+	// Trusted world simulators log in with this secret to drive actors of their own, e.g. AI or physics running in
+	// other processes. They can't log in if left out.
+	SimulatorSecret string
+
+	// How long each player heatmap covers, and whether they're all kept in the database
+	HeatmapInterval time.Duration
+	HeatmapHistory  bool
+
+	// Where events from the outbox are published to, if anywhere. More publishers can be added to the hub's outbox
+	// before the server is started.
+	OutboxWebhooks []string
+	OutboxNatsUrl  string
+
+	// How long an account can go without logging in before its name is released for someone else to claim, or 0 to
+	// never release names
+	NameReleaseAfter time.Duration
+
+	// How often players' progress is written, by how much losing it would matter: where they are, their best score
+	// and when they last logged in, and their tutorial progress. DefaultSaveTiers if left out.
+	SaveTiers SaveTiers
+
+	// How often writes that can wait a tick, e.g. chat kept for moderators and saves not held back, are committed
+	// together, and how many at once. DefaultWriteBatchConfig if left out.
+	WriteBatch WriteBatchConfig
+
+	// Where clients kicked for maintenance or capacity are told to reconnect to instead, e.g. another shard's
+	// websocket URL, or here again if left out
+	ReconnectEndpoint string
+
+	// How long players have to change their minds after asking for their accounts to be deleted, and how long
+	// exports of their data can be downloaded for. DefaultAccountDeletionDelay and DefaultDataExportTtl if left out.
+	AccountDeletionDelay time.Duration
+	DataExportTtl        time.Duration
+
+	// How many bytes each account can send and be sent in a day before what it's sent is throttled, or 0 for no
+	// limit, and how many bytes a second throttled accounts are sent. DefaultBandwidthThrottleRate if left out.
+	BandwidthDailyLimit   int64
+	BandwidthThrottleRate int64
+
+	// Serve the protocol inspector at /debug/protocol, which shows every kind of packet with recent examples of each.
+	// Only for development, as anyone can see it and the examples have what players sent in them.
+	DebugProtocol bool
+
+	// Also serve the protocol as JSON over websockets at /ws-debug, for web tools, websocat and prototypes without
+	// protobuf. Only for development, as anyone can connect from any site and it costs far more than protobuf.
+	DebugJson bool
+
+	// Stream what the server believes about an actor, for drawing a debug overlay over the game, to clients that ask
+	// with this secret. Off if left out. Only for development, as it shows where anyone is, stealthed or not.
+	DebugOverlaySecret string
+
+	// Let the admin API inject failures with the elevated token, dropping clients, stalling the database and delaying
+	// broadcasts, for testing the server copes. Only for development and staging, never with players on.
+	Chaos bool
+
+	// Serve goroutine counts by subsystem at /debug/goroutines and Go's profiles at /debug/pprof/, sampling one in
+	// every MutexProfileFraction lock contention events for the mutex profile. Only for development, as anyone can see
+	// them. DefaultMutexProfileFraction if left out.
+	DebugDiagnostics     bool
+	MutexProfileFraction int
+
+	// The share of clients, between 0 and 1, whose states are shadowed when a shadow is registered for them, see
+	// Server.ShadowState. DefaultShadowFraction if left out.
+	ShadowFraction float64
+
+	// The biggest file that can be pushed to clients, in bytes, and the content types that can be.
+	// DefaultMaxFileSize and DefaultFileContentTypes if left out.
+	MaxFileSize      int
+	FileContentTypes []string
+
+	// What each module logs, e.g. "hub=info,states=debug", with modules left out logging at info. Levels can be
+	// changed through the admin API while the server's running.
+	LogLevels string
+
+	// How many background jobs, like settling auctions, can run at once. DefaultJobConcurrency if left out.
+	JobConcurrency int
+
+	// Back the database up to this directory every BackupInterval, keeping the latest BackupKeep backups, or never if
+	// left out. DefaultBackupInterval and DefaultBackupKeep if left out.
+	BackupPath     string
+	BackupInterval time.Duration
+	BackupKeep     int
+
+	// Caps on how much of the server each subsystem can use, e.g. "players=500,spores=3000,chat=50/s:drop", with /s
+	// for caps per second and what's over budget rejected unless it says to drop it. The budgets are players, spores,
+	// spore_drops and chat, and any left out are unlimited.
+	Budgets string
+
+	// Run the world deterministically from Seed, for regression testing gameplay rather than for players. Every input
+	// and the world after each tick is recorded to RecordPath if given, which cmd/replay can play over again against a
+	// copy of the data directory as it was when recording started.
+	Deterministic bool
+	Seed          uint64
+	RecordPath    string
+
+	// Alert when metrics cross thresholds, e.g. "mmo_log_errors_total>1/s,mmo_db_healthy<1", with /s for the rate a
+	// counter goes up or a histogram is observed at, and histograms otherwise compared by their average. Rules are
+	// checked every AlertInterval, and one that's still broken alerts again after AlertCooldown.
+	// DefaultAlertInterval and DefaultAlertCooldown if left out.
+	AlertRules    string
+	AlertInterval time.Duration
+	AlertCooldown time.Duration
+
+	// Where alerts are POSTed as JSON, if anywhere
+	AlertWebhooks []string
+
+	// Email alerts to AlertEmailTo through the SMTP server at AlertSmtpAddr, logging in as AlertSmtpUser if given, or
+	// don't if left out
+	AlertSmtpAddr     string
+	AlertSmtpUser     string
+	AlertSmtpPassword string
+	AlertEmailFrom    string
+	AlertEmailTo      []string
+
+	// Which client builds can log in, changeable through the admin API while the server's running. Any can if left
+	// out.
+	ClientVersions ClientVersionPolicy
+
+	// The CAPTCHA provider suspicious logins and registrations are challenged with. Nothing's challenged if left out.
+	Captcha CaptchaConfig
+
+	// How far from each player others are updated more often the nearer they are, for trading bandwidth for
+	// smoothness. DefaultAoiRadius if left out.
+	AoiRadius float64
+
+	// How big the squares the world's divided into are, so player updates are only broadcast to the players in the
+	// squares near enough to be interested, e.g. half the area of interest. Everyone's broadcast to everyone if left out.
+	InterestCellSize float64
+
+	// Fill the database with fake accounts when the server starts, for development, e.g.
+	// "accounts=200,characters=2,mail=5". They're named seed1, seed2 and so on, with DefaultSeedPassword, and topped up
+	// rather than made again each start. None if left out.
+	DevSeed string
+
+	// Accounts with an entitlement giving priority login are let in over the players budget. If this is set, each
+	// one makes room by disconnecting the player who's been idle the longest, if they've been idle at least this
+	// long. Nobody is disconnected if left out.
+	PriorityLoginEvictIdle time.Duration
+
+	// How long what's said in chat is kept for moderators to export. DefaultChatRetention if left out.
+	ChatRetention time.Duration
+
+	// Caps on coins moving between players through the auction house, and the thresholds past which transfers are
+	// flagged as possible duping or real money trading. DefaultCurrencyLimits if left out.
+	CurrencyLimits CurrencyLimits
+
+	// How many slots players' inventories have and how much they can carry, going by the stack size and weight of each
+	// item in the game data. What's granted beyond them is mailed to players to claim once they've made room. No limits
+	// if left out.
+	CarryLimits CarryLimits
+
+	// When bosses' loot lockouts reset, weekly ones on its weekday and daily ones every day, at its time of day in
+	// UTC. DefaultLockoutReset if left out.
+	LockoutReset *LockoutReset
+}
+
+type Server struct {
+	Hub *Hub
+
+	// Every endpoint is served from here, so more can be added before the server is started
+	Mux *http.ServeMux
+
+	config Config
+}
+
+func New(config Config) *Server {
+	if config.WebSocketLimits == (WebSocketLimits{}) {
+		config.WebSocketLimits = DefaultWebSocketLimits
+	}
+	if config.HeatmapInterval <= 0 {
+		config.HeatmapInterval = time.Minute
+	}
+	if config.SlowHandlerThreshold <= 0 {
+		config.SlowHandlerThreshold = server.DefaultSlowHandlerThreshold
+	}
+	if config.TickBudget == 0 {
+		config.TickBudget = server.DefaultTickBudget
+	}
+	if config.SaveTiers == (SaveTiers{}) {
+		config.SaveTiers = DefaultSaveTiers
+	}
+	if config.WriteBatch == (WriteBatchConfig{}) {
+		config.WriteBatch = DefaultWriteBatchConfig
+	}
+	if config.CurrencyLimits == (CurrencyLimits{}) {
+		config.CurrencyLimits = DefaultCurrencyLimits
+	}
+	if config.LockoutReset == nil {
+		config.LockoutReset = &DefaultLockoutReset
+	}
+
+	if err := logging.Configure(config.LogLevels); err != nil {
+		log.Fatalf("Error configuring log levels: %v", err)
+	}
+
+	ids, err := objects.NewIdGenerator(config.IdNode)
+	if err != nil {
+		log.Fatalf("Error setting up IDs: %v", err)
+	}
+	objects.Ids = ids
+
+	s := &Server{
+		Hub:    server.NewHub(config.DataPath),
+		Mux:    http.NewServeMux(),
+		config: config,
+	}
+	hub := s.Hub
+
+	// Define handler for serving the HTML5 export
+	if config.ClientPath != "" {
+		if _, err := os.Stat(config.ClientPath); err != nil {
+			if !os.IsNotExist(err) {
+				log.Fatalf("Error checking for HTML export: %v", err)
+			}
+		} else {
+			log.Printf("Serving HTML5 export from %s", config.ClientPath)
+			s.Mux.Handle("/", addHeaders(http.StripPrefix("/", http.FileServer(http.Dir(config.ClientPath)))))
+		}
+	}
+
+	// Define handler for Prometheus metrics
+	s.Mux.Handle("/metrics", metrics.Handler())
+
+	// Define handlers for the admin API and dashboard
+	if config.AdminToken != "" {
+		log.Println("Serving the admin API and dashboard at /admin/")
+		s.Mux.Handle("/admin/", admin.NewApi(hub, config.AdminToken, config.AdminElevatedToken))
+	}
+
+	if config.Chaos {
+		hub.Chaos.Enable()
+	}
+
+	hub.Simulators.Configure(config.SimulatorSecret)
+	hub.Visibility.Configure(config.AoiRadius)
+	hub.Interest.Configure(config.InterestCellSize)
+	hub.PriorityLogin.Configure(config.PriorityLoginEvictIdle)
+	hub.ChatHistory.Configure(config.ChatRetention)
+	hub.CurrencyCaps.Configure(config.CurrencyLimits)
+	hub.Inventories.Configure(config.CarryLimits)
+	hub.BossLockouts.Configure(*config.LockoutReset)
+	hub.DebugOverlays.Configure(config.DebugOverlaySecret)
+
+	// Define handler for the protocol inspector
+	if config.DebugProtocol {
+		log.Println("Serving the protocol inspector at " + server.ProtocolInspectorPath + ", which shouldn't be done in production")
+		hub.Protocol.Enable()
+		s.Mux.Handle("GET "+server.ProtocolInspectorPath, hub.Protocol)
+	}
+
+	// Define handlers for goroutine counts and profiles
+	if config.DebugDiagnostics {
+		log.Println("Serving diagnostics at " + diagnostics.GoroutinesPath + " and " + diagnostics.ProfilesPath + ", which shouldn't be done in production")
+		fraction := config.MutexProfileFraction
+		if fraction <= 0 {
+			fraction = DefaultMutexProfileFraction
+		}
+		diagnostics.EnableMutexProfile(fraction)
+		handler := diagnostics.Handler()
+		s.Mux.Handle("GET "+diagnostics.GoroutinesPath, handler)
+		s.Mux.Handle(diagnostics.ProfilesPath, handler)
+	}
+
+	// Define handlers for an external matchmaker holding places for players
+	if config.MatchmakerToken != "" {
+		log.Println("Serving the matchmaker API at " + server.MatchmakerPath)
+		hub.Matchmaking.EnableApi(config.MatchmakerToken)
+		s.Mux.Handle(server.MatchmakerPath, hub.Matchmaking)
+	}
+
+	// Define handler for minimaps of each zone
+	s.Mux.Handle("GET /api/map/{zone}", hub.Minimaps)
+
+	// Define handler for players catching up on announcements they missed
+	s.Mux.Handle("GET "+server.AnnouncementsPath, hub.Announcements)
+
+	// Define handler for graphing how the world's grown
+	s.Mux.Handle("GET "+server.WorldStatsPath, hub.WorldStats)
+
+	// Define handler for clients updating their game data
+	s.Mux.Handle("GET "+server.DataFilesPath+"{file}", hub.DataFiles)
+
+	// Define handlers for community developers with API keys
+	s.Mux.Handle(server.PublicApiPath, hub.ApiKeys)
+
+	// Define handler for players downloading exports of their data
+	s.Mux.Handle("GET "+server.DataExportPath+"{token}", hub.AccountData)
+
+	// Define handler for WebSocket connections
+	if config.CheckOrigin {
+		s.HandleTransport("/ws", clients.WebSocketClientWithOrigins(config.WebSocketLimits, config.AllowedOrigins))
+	} else {
+		s.HandleTransport("/ws", clients.WebSocketClientWithLimits(config.WebSocketLimits))
+	}
+	if config.DebugJson {
+		log.Println("Serving the JSON debug transport at " + clients.DebugWebSocketPath + ", which shouldn't be done in production")
+		s.HandleTransport(clients.DebugWebSocketPath, clients.DebugWebSocketClient(config.WebSocketLimits))
+	}
+
+	// Define handler for presence gossip from other shards
+	if config.PresenceSecret != "" && len(config.PresencePeers) > 0 {
+		shardId := config.ShardId
+		if shardId == "" {
+			shardId, _ = os.Hostname()
+		}
+		log.Printf("Gossiping presence as shard %s with %d peers", shardId, len(config.PresencePeers))
+		hub.Presence.EnableGossip(shardId, config.PresencePeers, config.PresenceSecret)
+		s.Mux.HandleFunc("/internal/presence", hub.Presence.ServeGossip)
+	}
+
+	hub.Heatmaps.Configure(config.HeatmapInterval, config.HeatmapHistory)
+	hub.Watchdog.Configure(config.SlowHandlerThreshold)
+	hub.TickBudget.Configure(config.TickBudget)
+	hub.BroadcastWorkers.Configure(config.BroadcastWorkers)
+	hub.Voice.ConfigureRelay(config.VoiceRelay)
+	hub.ConsistencyChecks.Configure(config.ConsistencyInterval, config.ConsistencyRepair)
+	hub.Names.Configure(config.NameReleaseAfter)
+	hub.Saves.Configure(config.SaveTiers)
+	hub.WriteBatches.Configure(config.WriteBatch)
+	hub.Kicks.Configure(config.ReconnectEndpoint)
+	hub.AccountData.Configure(config.AccountDeletionDelay, config.DataExportTtl)
+	hub.Bandwidth.Configure(config.BandwidthDailyLimit, config.BandwidthThrottleRate)
+	hub.Shadows.Configure(config.ShadowFraction)
+	hub.FileTransfers.Configure(config.MaxFileSize, config.FileContentTypes)
+	if err := hub.Budgets.Configure(config.Budgets); err != nil {
+		log.Fatalf("Error configuring budgets: %v", err)
+	}
+	hub.Jobs.Configure(config.JobConcurrency)
+	if config.Deterministic {
+		if err := hub.Simulation.Configure(config.Seed, config.RecordPath); err != nil {
+			log.Fatalf("Error configuring the simulation: %v", err)
+		}
+	}
+	hub.Backups.Configure(config.BackupPath, config.BackupInterval, config.BackupKeep)
+	if err := hub.ClientVersions.Configure(config.ClientVersions); err != nil {
+		log.Fatalf("Error configuring client versions: %v", err)
+	}
+	if err := hub.Captcha.Configure(config.Captcha); err != nil {
+		log.Fatalf("Error configuring CAPTCHAs: %v", err)
+	}
+	if err := hub.Alerts.Configure(config.AlertRules, config.AlertInterval, config.AlertCooldown); err != nil {
+		log.Fatalf("Error configuring alerts: %v", err)
+	}
+	for _, webhook := range config.AlertWebhooks {
+		hub.Alerts.AddNotifier(server.NewWebhookAlertNotifier(webhook))
+	}
+	if config.AlertSmtpAddr != "" && len(config.AlertEmailTo) > 0 {
+		hub.Alerts.AddNotifier(server.NewEmailAlertNotifier(config.AlertSmtpAddr, config.AlertSmtpUser,
+			config.AlertSmtpPassword, config.AlertEmailFrom, config.AlertEmailTo))
+	}
+
+	for _, webhook := range config.OutboxWebhooks {
+		hub.Outbox.AddPublisher(server.NewWebhookPublisher(webhook))
+	}
+	if config.OutboxNatsUrl != "" {
+		publisher, err := server.NewNatsPublisher(config.OutboxNatsUrl, "mmo")
+		if err != nil {
+			log.Fatalf("Error setting up NATS publisher: %v", err)
+		}
+		hub.Outbox.AddPublisher(publisher)
+	}
+
+	if config.DevSeed != "" {
+		options, err := server.ParseSeedOptions(config.DevSeed)
+		if err != nil {
+			log.Fatalf("Error configuring dev seeding: %v", err)
+		}
+		hub.DevSeed = &options
+	}
+
+	return s
+}
+
+// Serve clients connecting at the given path with the given transport
+func (s *Server) HandleTransport(pattern string, newClient NewClientFunc) {
+	s.Mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.Hub.Serve(newClient, w, r)
+	})
+}
+
+// Handle custom messages of the given type from clients in any state
+func (s *Server) HandleCustom(customType string, handler CustomHandler) {
+	s.Hub.HandleCustom(customType, handler)
+}
+
+// Start new clients in a state of your own rather than the login state
+func (s *Server) SetInitialState(newState func() ClientStateHandler) {
+	s.Hub.NewInitialState = newState
+}
+
+// Run a new implementation of the named state alongside the current one for some clients, logging anything it does
+// differently, e.g. to vet a refactor on real traffic before switching over. The shadow mustn't change the world or
+// the database, as only what it sends is kept from going anywhere.
+func (s *Server) ShadowState(stateName string, newShadow ShadowFactory) {
+	s.Hub.Shadows.Register(stateName, newShadow)
+}
+
+// Check receipts for store purchases made on the given platform, e.g. "steam", as they come in. Receipts from
+// platforms without a validator are left pending for an external service to settle through the admin API.
+func (s *Server) ValidateReceipts(platform string, validator ReceiptValidator) {
+	s.Hub.Store.RegisterValidator(platform, validator)
+}
+
+// The built-in state clients log in or register from, for custom states to send clients back to
+func NewLoginState() ClientStateHandler {
+	return &states.Connected{}
+}
+
+// Run the game and serve until the server fails or stops for a scheduled restart, in which case ErrRestart is
+// returned
+func (s *Server) ListenAndServe() error {
+	if s.config.RestartInterval > 0 {
+		s.Hub.Maintenance.ScheduleRestart(time.Now().Add(s.config.RestartInterval))
+	}
+
+	go s.Hub.Run()
+
+	var udpListener *clients.UdpListener
+	if s.config.UdpPort != 0 {
+		var err error
+		if udpListener, err = clients.ListenUdp(s.Hub, fmt.Sprintf(":%d", s.config.UdpPort), s.config.WebSocketLimits); err != nil {
+			return err
+		}
+		go func() {
+			log.Printf("Listening for UDP clients on %s", udpListener.Addr())
+			if err := udpListener.Serve(); err != nil {
+				log.Printf("Error listening for UDP clients: %v", err)
+			}
+		}()
+	}
+
+	var wtListener *clients.WebTransportListener
+	if s.config.WebTransportPort != 0 && (s.config.CertPath == "" || s.config.KeyPath == "") {
+		log.Printf("Not listening for WebTransport clients without a cert and key")
+	} else if s.config.WebTransportPort != 0 {
+		wtListener = clients.ListenWebTransport(s.Hub, fmt.Sprintf(":%d", s.config.WebTransportPort), s.config.CertPath, s.config.KeyPath,
+			s.config.WebSocketLimits, s.config.CheckOrigin, s.config.AllowedOrigins)
+		go func() {
+			log.Printf("Listening for WebTransport clients on :%d", s.config.WebTransportPort)
+			if err := wtListener.Serve(); err != nil {
+				log.Printf("Error listening for WebTransport clients: %v", err)
+			}
+		}()
+	}
+
+	addr := fmt.Sprintf(":%d", s.config.Port)
+	httpServers := []*http.Server{{Addr: addr, Handler: s.Mux}}
+
+	useTls := s.config.CertPath != "" && s.config.KeyPath != ""
+	if useTls && s.config.HttpRedirectPort != 0 {
+		redirectServer := &http.Server{
+			Addr:    fmt.Sprintf(":%d", s.config.HttpRedirectPort),
+			Handler: http.HandlerFunc(s.redirectToHttps),
+		}
+		httpServers = append(httpServers, redirectServer)
+
+		go func() {
+			log.Printf("Redirecting HTTP on %s to HTTPS", redirectServer.Addr)
+			if err := redirectServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Error redirecting HTTP to HTTPS: %v", err)
+			}
+		}()
+	}
+
+	// Stop serving once everything's been saved for a restart
+	go func() {
+		<-s.Hub.Maintenance.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, httpServer := range httpServers {
+			httpServer.Shutdown(ctx)
+		}
+		if udpListener != nil {
+			udpListener.Close()
+		}
+		if wtListener != nil {
+			wtListener.Close()
+		}
+	}()
+
+	var err error
+	if useTls {
+		log.Printf("Starting server on %s using cert at %s and key at %s", addr, s.config.CertPath, s.config.KeyPath)
+		err = httpServers[0].ListenAndServeTLS(s.config.CertPath, s.config.KeyPath)
+	} else {
+		log.Printf("Starting server on %s without TLS", addr)
+		err = httpServers[0].ListenAndServe()
+	}
+
+	select {
+	case <-s.Hub.Maintenance.Done():
+		return ErrRestart
+	default:
+		return err
+	}
+}
+
+// Send plain HTTP requests to the same place on the TLS port
+func (s *Server) redirectToHttps(w http.ResponseWriter, r *http.Request) {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	if s.config.Port != 443 {
+		host = net.JoinHostPort(host, strconv.Itoa(s.config.Port))
+	}
+	http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+}
+
+// Add headers required for the HTML5 export to work with threads
+func addHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cross-Origin-Opener-Policy", "same-origin")
+		w.Header().Set("Cross-Origin-Embedder-Policy", "require-corp")
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,92 @@
+package mmoserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"server/internal/server/diagnostics"
+	"server/pkg/client"
+	"server/pkg/packets"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A server on its own copy of the game data, with a fresh database, running until the test's done
+func newTestServer(t *testing.T) string {
+	t.Helper()
+	dataPath := t.TempDir()
+	files, err := filepath.Glob(filepath.Join("..", "..", "data", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dataPath, filepath.Base(file)), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := New(Config{DataPath: dataPath})
+	go s.Hub.Run()
+
+	httpServer := httptest.NewServer(s.Mux)
+	t.Cleanup(httpServer.Close)
+	return "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/ws"
+}
+
+func TestNoGoroutinesLeakAcrossConnections(t *testing.T) {
+	url := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// Connecting the first time also waits for the hub to be up and running
+	c, err := client.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Register(ctx, "leaker", "password", 0); err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+	<-c.Done()
+
+	diagnostics.VerifyNoLeaks(t)
+	for range 5 {
+		c, err := client.Dial(ctx, url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Our player is sent straight after logging in and accepting the rules, so could come before we'd start
+		// waiting for it afterwards
+		c.AcceptPrompts()
+		entered := make(chan struct{}, 1)
+		client.On(c, func(senderId uint64, _ *packets.Packet_Player) {
+			if senderId == c.Id() {
+				select {
+				case entered <- struct{}{}:
+				default:
+				}
+			}
+		})
+		if err := c.Login(ctx, "leaker", "password"); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-entered:
+		case <-ctx.Done():
+			t.Fatal(ctx.Err())
+		}
+
+		// Which starts the player's update loop
+		if err := c.Send(&packets.Packet_PlayerDirection{PlayerDirection: &packets.PlayerDirectionMessage{Direction: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		c.Close()
+		<-c.Done()
+	}
+}
@@ -0,0 +1,47 @@
+package packets
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// A message whose encoding is worked out the first time it's needed and then shared by everyone it's sent to. A
+// broadcast reaches every client with the same message and only the sender ID differs, so rather than marshalling the
+// whole packet again for each recipient, the sender ID is written in front of the shared encoding.
+type SharedMsg struct {
+	Msg Msg
+
+	once sync.Once
+	data []byte
+	err  error
+}
+
+func NewSharedMsg(message Msg) *SharedMsg {
+	return &SharedMsg{Msg: message}
+}
+
+// The message encoded as a packet without a sender ID. Safe to call from any number of goroutines at once, and the
+// result must not be modified.
+func (s *SharedMsg) Encoded() ([]byte, error) {
+	s.once.Do(func() {
+		s.data, s.err = proto.Marshal(&Packet{Msg: s.Msg})
+	})
+	return s.data, s.err
+}
+
+// Append the packet from the given sender to the buffer. This is byte for byte what marshalling the whole packet
+// gives, since fields are always written in order of their numbers and the sender ID comes first.
+func (s *SharedMsg) AppendPacket(buf []byte, senderId uint64) ([]byte, error) {
+	data, err := s.Encoded()
+	if err != nil {
+		return buf, err
+	}
+
+	if senderId != 0 {
+		buf = protowire.AppendTag(buf, senderIdField, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, senderId)
+	}
+	return append(buf, data...), nil
+}
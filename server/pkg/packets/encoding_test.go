@@ -0,0 +1,128 @@
+package packets
+
+import (
+	"bytes"
+	"fmt"
+	"server/internal/server/objects"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Each benchmark packet decoded, to send on again the way a broadcast would
+func benchmarkMessages(tb testing.TB) map[string]*Packet {
+	messages := make(map[string]*Packet)
+	for name, data := range benchmarkPackets(tb) {
+		packet := &Packet{}
+		if err := proto.Unmarshal(data, packet); err != nil {
+			tb.Fatal(err)
+		}
+		messages[name] = packet
+	}
+	return messages
+}
+
+// What writing a broadcast used to cost for each recipient
+func BenchmarkMarshalPerRecipient(b *testing.B) {
+	for name, packet := range benchmarkMessages(b) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := proto.Marshal(&Packet{SenderId: packet.SenderId, Msg: packet.Msg}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// What it costs now, once the first recipient has encoded the message
+func BenchmarkSharedMsgPerRecipient(b *testing.B) {
+	for name, packet := range benchmarkMessages(b) {
+		b.Run(name, func(b *testing.B) {
+			shared := NewSharedMsg(packet.Msg)
+			buf, err := shared.AppendPacket(nil, packet.SenderId)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if buf, err = shared.AppendPacket(buf[:0], packet.SenderId); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSharedMsgMatchesMarshal(t *testing.T) {
+	for name, packet := range benchmarkMessages(t) {
+		shared := NewSharedMsg(packet.Msg)
+		for _, senderId := range []uint64{0, 1, packet.SenderId, 1 << 40} {
+			want, err := proto.Marshal(&Packet{SenderId: senderId, Msg: packet.Msg})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := shared.AppendPacket(nil, senderId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s from %d: got %x, want %x", name, senderId, got, want)
+			}
+		}
+	}
+}
+
+// Keeps the broadcast hot path from regressing: with a buffer that's big enough, nothing should be allocated
+func TestSharedMsgAppendDoesNotAllocate(t *testing.T) {
+	for name, packet := range benchmarkMessages(t) {
+		shared := NewSharedMsg(packet.Msg)
+		buf, err := shared.AppendPacket(nil, packet.SenderId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			buf, _ = shared.AppendPacket(buf[:0], packet.SenderId)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: %.1f allocations per recipient, want 0", name, allocs)
+		}
+	}
+}
+
+// What telling a player who's just come in about everyone already in the world costs, one packet per actor, at
+// crowds of different sizes
+func BenchmarkSnapshotEncoding(b *testing.B) {
+	for _, actors := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("%dActors", actors), func(b *testing.B) {
+			players := make([]*objects.Player, actors)
+			for i := range players {
+				players[i] = &objects.Player{
+					Position: objects.Position{X: float64(i%100) * 50, Y: float64(i/100) * 50},
+					Body:     objects.Body{Radius: 20},
+					Movement: objects.Movement{Direction: float64(i), Speed: 150},
+					Name:     fmt.Sprintf("Player%d", i),
+				}
+			}
+
+			var buf []byte
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buf = buf[:0]
+				for id, player := range players {
+					packet := &Packet{SenderId: uint64(id + 1), Msg: NewPlayer(uint64(id+1), player)}
+					var err error
+					if buf, err = (proto.MarshalOptions{}).MarshalAppend(buf, packet); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+			b.ReportMetric(float64(len(buf)), "bytes/snapshot")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package packets
+
+import (
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Identifies which message a packet carries: the field number of the message in the Packet's oneof
+type MsgKind protowire.Number
+
+const senderIdField protowire.Number = 1
+
+// Looked up lazily, since the file descriptor is only built in the generated init function
+var packetMsgOneof = sync.OnceValue(func() protoreflect.OneofDescriptor {
+	return File_packets_proto.Messages().ByName("Packet").Oneofs().ByName("msg")
+})
+
+var errMalformedPacket = errors.New("malformed packet")
+
+// The outer layer of a packet, which is all that's needed to decide what to do with it. Parsing it only walks the
+// top-level wire fields, without decoding (or allocating) the message itself.
+type Envelope struct {
+	SenderId uint64
+	Kind     MsgKind
+	data     []byte
+}
+
+func ParseEnvelope(data []byte) (Envelope, error) {
+	envelope := Envelope{data: data}
+
+	for len(data) > 0 {
+		number, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return Envelope{}, errMalformedPacket
+		}
+		data = data[n:]
+
+		if number == senderIdField && wireType == protowire.VarintType {
+			senderId, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return Envelope{}, errMalformedPacket
+			}
+			envelope.SenderId = senderId
+			data = data[n:]
+			continue
+		}
+
+		n = protowire.ConsumeFieldValue(number, wireType, data)
+		if n < 0 {
+			return Envelope{}, errMalformedPacket
+		}
+		data = data[n:]
+
+		// As with any oneof, the last one on the wire wins
+		if packetMsgOneof().Fields().ByNumber(number) != nil {
+			envelope.Kind = MsgKind(number)
+		}
+	}
+
+	return envelope, nil
+}
+
+// Fully decode the packet
+func (e Envelope) Decode() (*Packet, error) {
+	packet := &Packet{}
+	if err := proto.Unmarshal(e.data, packet); err != nil {
+		return nil, err
+	}
+	return packet, nil
+}
+
+// The kind of the given message, e.g. to build a KindSet
+func KindOf(message Msg) MsgKind {
+	packet := &Packet{Msg: message}
+	field := packet.ProtoReflect().WhichOneof(packetMsgOneof())
+	if field == nil {
+		return 0
+	}
+	return MsgKind(field.Number())
+}
+
+type KindSet map[MsgKind]struct{}
+
+// Build a set of message kinds from example messages of each kind, e.g. NewKindSet(&Packet_Chat{})
+func NewKindSet(messages ...Msg) KindSet {
+	set := make(KindSet, len(messages))
+	for _, message := range messages {
+		set[KindOf(message)] = struct{}{}
+	}
+	return set
+}
+
+func (s KindSet) Union(other KindSet) KindSet {
+	union := make(KindSet, len(s)+len(other))
+	for kind := range s {
+		union[kind] = struct{}{}
+	}
+	for kind := range other {
+		union[kind] = struct{}{}
+	}
+	return union
+}
+
+func (s KindSet) Has(kind MsgKind) bool {
+	_, has := s[kind]
+	return has
+}
@@ -0,0 +1,94 @@
+package packets
+
+import (
+	"server/internal/server/objects"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func benchmarkPackets(tb testing.TB) map[string][]byte {
+	spores := make(map[uint64]*objects.Spore, 50)
+	for i := uint64(1); i <= 50; i++ {
+		spores[i] = &objects.Spore{Position: objects.Position{X: float64(i), Y: float64(i)}, Body: objects.Body{Radius: 10}}
+	}
+
+	player := &objects.Player{
+		Position: objects.Position{X: 120.5, Y: -340.25},
+		Body:     objects.Body{Radius: 25},
+		Movement: objects.Movement{Direction: 2.1, Speed: 150},
+		Name:     "Benchmarker",
+		Color:    0x336699,
+	}
+
+	messages := map[string]Msg{
+		"Chat":        NewChat("Hello, world! This is a reasonably typical chat message."),
+		"Direction":   &Packet_PlayerDirection{PlayerDirection: &PlayerDirectionMessage{Direction: 1.5}},
+		"Player":      NewPlayer(42, player),
+		"SporesBatch": NewSporesBatch(spores),
+	}
+
+	encoded := make(map[string][]byte, len(messages))
+	for name, message := range messages {
+		data, err := proto.Marshal(&Packet{SenderId: 42, Msg: message})
+		if err != nil {
+			tb.Fatal(err)
+		}
+		encoded[name] = data
+	}
+	return encoded
+}
+
+// What every inbound message used to cost
+func BenchmarkFullDecode(b *testing.B) {
+	for name, data := range benchmarkPackets(b) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				packet := &Packet{}
+				if err := proto.Unmarshal(data, packet); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// What a message dropped by the current state's MessageKindFilter costs now
+func BenchmarkParseEnvelope(b *testing.B) {
+	for name, data := range benchmarkPackets(b) {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseEnvelope(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	for name, data := range benchmarkPackets(nil) {
+		envelope, err := ParseEnvelope(data)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		packet, err := envelope.Decode()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if envelope.SenderId != packet.SenderId {
+			t.Errorf("%s: envelope sender ID %d, packet sender ID %d", name, envelope.SenderId, packet.SenderId)
+		}
+		if envelope.Kind != KindOf(packet.Msg) {
+			t.Errorf("%s: envelope kind %d, packet kind %d", name, envelope.Kind, KindOf(packet.Msg))
+		}
+	}
+
+	if _, err := ParseEnvelope([]byte{0xff}); err == nil {
+		t.Error("expected an error parsing a truncated packet")
+	}
+}
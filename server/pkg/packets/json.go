@@ -0,0 +1,28 @@
+package packets
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fields are named as they are in packets.proto, e.g. {"sender_id": "42", "chat": {"msg": "hi"}}. 64 bit IDs are
+// written as strings, as JSON numbers can't hold all of them, but are read either way.
+var (
+	jsonMarshal   = protojson.MarshalOptions{UseProtoNames: true}
+	jsonUnmarshal = protojson.UnmarshalOptions{}
+)
+
+// The packet from the sender in protobuf's JSON mapping, for tools that don't have protobuf
+func MarshalJson(senderId uint64, message Msg) ([]byte, error) {
+	return jsonMarshal.Marshal(&Packet{SenderId: senderId, Msg: message})
+}
+
+// Turn a packet in protobuf's JSON mapping into its usual encoding, so it's handled exactly like a packet sent that way.
+// Unknown fields are an error, rather than quietly leaving out what the sender meant to send.
+func JsonToWire(data []byte) ([]byte, error) {
+	packet := &Packet{}
+	if err := jsonUnmarshal.Unmarshal(data, packet); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(packet)
+}
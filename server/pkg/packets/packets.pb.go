@@ -20,12 +20,80 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What kind of thing closed our connection, for showing the player a message that fits rather than "connection lost"
+type DisconnectReason int32
+
+const (
+	DisconnectReason_DISCONNECT_REASON_UNSPECIFIED DisconnectReason = 0
+	// By the server or an admin, e.g. to make room on a full server or because the account's gone
+	DisconnectReason_DISCONNECT_REASON_KICKED DisconnectReason = 1
+	DisconnectReason_DISCONNECT_REASON_BANNED DisconnectReason = 2
+	// Nothing was received from us for too long
+	DisconnectReason_DISCONNECT_REASON_TIMEOUT DisconnectReason = 3
+	// The server's going down, e.g. to restart
+	DisconnectReason_DISCONNECT_REASON_SHUTDOWN DisconnectReason = 4
+	// We kept sending what the server couldn't make sense of
+	DisconnectReason_DISCONNECT_REASON_PROTOCOL_ERROR DisconnectReason = 5
+	// The account or character was taken over by another session, or logged out from one
+	DisconnectReason_DISCONNECT_REASON_DUPLICATE_LOGIN DisconnectReason = 6
+)
+
+// Enum value maps for DisconnectReason.
+var (
+	DisconnectReason_name = map[int32]string{
+		0: "DISCONNECT_REASON_UNSPECIFIED",
+		1: "DISCONNECT_REASON_KICKED",
+		2: "DISCONNECT_REASON_BANNED",
+		3: "DISCONNECT_REASON_TIMEOUT",
+		4: "DISCONNECT_REASON_SHUTDOWN",
+		5: "DISCONNECT_REASON_PROTOCOL_ERROR",
+		6: "DISCONNECT_REASON_DUPLICATE_LOGIN",
+	}
+	DisconnectReason_value = map[string]int32{
+		"DISCONNECT_REASON_UNSPECIFIED":     0,
+		"DISCONNECT_REASON_KICKED":          1,
+		"DISCONNECT_REASON_BANNED":          2,
+		"DISCONNECT_REASON_TIMEOUT":         3,
+		"DISCONNECT_REASON_SHUTDOWN":        4,
+		"DISCONNECT_REASON_PROTOCOL_ERROR":  5,
+		"DISCONNECT_REASON_DUPLICATE_LOGIN": 6,
+	}
+)
+
+func (x DisconnectReason) Enum() *DisconnectReason {
+	p := new(DisconnectReason)
+	*p = x
+	return p
+}
+
+func (x DisconnectReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DisconnectReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_packets_proto_enumTypes[0].Descriptor()
+}
+
+func (DisconnectReason) Type() protoreflect.EnumType {
+	return &file_packets_proto_enumTypes[0]
+}
+
+func (x DisconnectReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DisconnectReason.Descriptor instead.
+func (DisconnectReason) EnumDescriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{0}
+}
+
 type ChatMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Msg string `protobuf:"bytes,1,opt,name=msg,proto3" json:"msg,omitempty"`
+	Id  uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
 func (x *ChatMessage) Reset() {
@@ -65,6 +133,13 @@ func (x *ChatMessage) GetMsg() string {
 	return ""
 }
 
+func (x *ChatMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
 type IdMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -110,13 +185,29 @@ func (x *IdMessage) GetId() uint64 {
 	return 0
 }
 
+// data_version and data_checksum are of the game data the client has, if it has any, see DataPackMessage. locale is
+// a language tag like en-US, and utc_offset_minutes how far ahead of UTC the player's clock is, for showing them
+// scheduled times in their own.
+//
+// Passwords aren't accepted over UDP, since nothing sent over it is encrypted. Clients log in over a secure connection
+// with for_udp set first, which only checks the credentials and answers with a LoginTokenMessage, then log in over UDP
+// with the login_token in place of the password.
 type LoginRequestMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Username         string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password         string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Capabilities     []string `protobuf:"bytes,3,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	JoinToken        string   `protobuf:"bytes,4,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	DataVersion      int64    `protobuf:"varint,5,opt,name=data_version,json=dataVersion,proto3" json:"data_version,omitempty"`
+	DataChecksum     []byte   `protobuf:"bytes,6,opt,name=data_checksum,json=dataChecksum,proto3" json:"data_checksum,omitempty"`
+	Locale           string   `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`
+	UtcOffsetMinutes int32    `protobuf:"varint,8,opt,name=utc_offset_minutes,json=utcOffsetMinutes,proto3" json:"utc_offset_minutes,omitempty"`
+	ClientBuild      uint32   `protobuf:"varint,9,opt,name=client_build,json=clientBuild,proto3" json:"client_build,omitempty"`
+	ForUdp           bool     `protobuf:"varint,10,opt,name=for_udp,json=forUdp,proto3" json:"for_udp,omitempty"`
+	LoginToken       string   `protobuf:"bytes,11,opt,name=login_token,json=loginToken,proto3" json:"login_token,omitempty"`
 }
 
 func (x *LoginRequestMessage) Reset() {
@@ -163,19 +254,137 @@ func (x *LoginRequestMessage) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequestMessage) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *LoginRequestMessage) GetJoinToken() string {
+	if x != nil {
+		return x.JoinToken
+	}
+	return ""
+}
+
+func (x *LoginRequestMessage) GetDataVersion() int64 {
+	if x != nil {
+		return x.DataVersion
+	}
+	return 0
+}
+
+func (x *LoginRequestMessage) GetDataChecksum() []byte {
+	if x != nil {
+		return x.DataChecksum
+	}
+	return nil
+}
+
+func (x *LoginRequestMessage) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *LoginRequestMessage) GetUtcOffsetMinutes() int32 {
+	if x != nil {
+		return x.UtcOffsetMinutes
+	}
+	return 0
+}
+
+func (x *LoginRequestMessage) GetClientBuild() uint32 {
+	if x != nil {
+		return x.ClientBuild
+	}
+	return 0
+}
+
+func (x *LoginRequestMessage) GetForUdp() bool {
+	if x != nil {
+		return x.ForUdp
+	}
+	return false
+}
+
+func (x *LoginRequestMessage) GetLoginToken() string {
+	if x != nil {
+		return x.LoginToken
+	}
+	return ""
+}
+
+// Good for one login by the same username until expires_at, in Unix milliseconds
+type LoginTokenMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *LoginTokenMessage) Reset() {
+	*x = LoginTokenMessage{}
+	mi := &file_packets_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginTokenMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginTokenMessage) ProtoMessage() {}
+
+func (x *LoginTokenMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginTokenMessage.ProtoReflect.Descriptor instead.
+func (*LoginTokenMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoginTokenMessage) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginTokenMessage) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 type RegisterRequestMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	Color    int32  `protobuf:"varint,3,opt,name=color,proto3" json:"color,omitempty"`
+	Username    string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password    string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Color       int32  `protobuf:"varint,3,opt,name=color,proto3" json:"color,omitempty"`
+	ClientBuild uint32 `protobuf:"varint,4,opt,name=client_build,json=clientBuild,proto3" json:"client_build,omitempty"`
 }
 
 func (x *RegisterRequestMessage) Reset() {
 	*x = RegisterRequestMessage{}
-	mi := &file_packets_proto_msgTypes[3]
+	mi := &file_packets_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -187,7 +396,7 @@ func (x *RegisterRequestMessage) String() string {
 func (*RegisterRequestMessage) ProtoMessage() {}
 
 func (x *RegisterRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[3]
+	mi := &file_packets_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +409,7 @@ func (x *RegisterRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterRequestMessage.ProtoReflect.Descriptor instead.
 func (*RegisterRequestMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{3}
+	return file_packets_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RegisterRequestMessage) GetUsername() string {
@@ -224,6 +433,13 @@ func (x *RegisterRequestMessage) GetColor() int32 {
 	return 0
 }
 
+func (x *RegisterRequestMessage) GetClientBuild() uint32 {
+	if x != nil {
+		return x.ClientBuild
+	}
+	return 0
+}
+
 type OkResponseMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -232,7 +448,7 @@ type OkResponseMessage struct {
 
 func (x *OkResponseMessage) Reset() {
 	*x = OkResponseMessage{}
-	mi := &file_packets_proto_msgTypes[4]
+	mi := &file_packets_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -244,7 +460,7 @@ func (x *OkResponseMessage) String() string {
 func (*OkResponseMessage) ProtoMessage() {}
 
 func (x *OkResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[4]
+	mi := &file_packets_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,20 +473,22 @@ func (x *OkResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OkResponseMessage.ProtoReflect.Descriptor instead.
 func (*OkResponseMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{4}
+	return file_packets_proto_rawDescGZIP(), []int{5}
 }
 
+// name_suggestions are names that could be registered instead, if it was the name that was refused
 type DenyResponseMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reason          string   `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	NameSuggestions []string `protobuf:"bytes,2,rep,name=name_suggestions,json=nameSuggestions,proto3" json:"name_suggestions,omitempty"`
 }
 
 func (x *DenyResponseMessage) Reset() {
 	*x = DenyResponseMessage{}
-	mi := &file_packets_proto_msgTypes[5]
+	mi := &file_packets_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +500,7 @@ func (x *DenyResponseMessage) String() string {
 func (*DenyResponseMessage) ProtoMessage() {}
 
 func (x *DenyResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[5]
+	mi := &file_packets_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +513,7 @@ func (x *DenyResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DenyResponseMessage.ProtoReflect.Descriptor instead.
 func (*DenyResponseMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{5}
+	return file_packets_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DenyResponseMessage) GetReason() string {
@@ -305,6 +523,13 @@ func (x *DenyResponseMessage) GetReason() string {
 	return ""
 }
 
+func (x *DenyResponseMessage) GetNameSuggestions() []string {
+	if x != nil {
+		return x.NameSuggestions
+	}
+	return nil
+}
+
 type PlayerMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -322,7 +547,7 @@ type PlayerMessage struct {
 
 func (x *PlayerMessage) Reset() {
 	*x = PlayerMessage{}
-	mi := &file_packets_proto_msgTypes[6]
+	mi := &file_packets_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -334,7 +559,7 @@ func (x *PlayerMessage) String() string {
 func (*PlayerMessage) ProtoMessage() {}
 
 func (x *PlayerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[6]
+	mi := &file_packets_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,7 +572,7 @@ func (x *PlayerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerMessage.ProtoReflect.Descriptor instead.
 func (*PlayerMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{6}
+	return file_packets_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PlayerMessage) GetId() uint64 {
@@ -416,7 +641,7 @@ type PlayerDirectionMessage struct {
 
 func (x *PlayerDirectionMessage) Reset() {
 	*x = PlayerDirectionMessage{}
-	mi := &file_packets_proto_msgTypes[7]
+	mi := &file_packets_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -428,7 +653,7 @@ func (x *PlayerDirectionMessage) String() string {
 func (*PlayerDirectionMessage) ProtoMessage() {}
 
 func (x *PlayerDirectionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[7]
+	mi := &file_packets_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +666,7 @@ func (x *PlayerDirectionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerDirectionMessage.ProtoReflect.Descriptor instead.
 func (*PlayerDirectionMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{7}
+	return file_packets_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PlayerDirectionMessage) GetDirection() float64 {
@@ -451,6 +676,61 @@ func (x *PlayerDirectionMessage) GetDirection() float64 {
 	return 0
 }
 
+// Instead of steering with direction messages, have the server walk the player to the point, around anything in the way,
+// where they stop until told to move again
+type MoveToMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *MoveToMessage) Reset() {
+	*x = MoveToMessage{}
+	mi := &file_packets_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveToMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveToMessage) ProtoMessage() {}
+
+func (x *MoveToMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveToMessage.ProtoReflect.Descriptor instead.
+func (*MoveToMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MoveToMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MoveToMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
 type SporeMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -464,7 +744,7 @@ type SporeMessage struct {
 
 func (x *SporeMessage) Reset() {
 	*x = SporeMessage{}
-	mi := &file_packets_proto_msgTypes[8]
+	mi := &file_packets_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -476,7 +756,7 @@ func (x *SporeMessage) String() string {
 func (*SporeMessage) ProtoMessage() {}
 
 func (x *SporeMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[8]
+	mi := &file_packets_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +769,7 @@ func (x *SporeMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SporeMessage.ProtoReflect.Descriptor instead.
 func (*SporeMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{8}
+	return file_packets_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SporeMessage) GetId() uint64 {
@@ -530,7 +810,7 @@ type SporeConsumedMessage struct {
 
 func (x *SporeConsumedMessage) Reset() {
 	*x = SporeConsumedMessage{}
-	mi := &file_packets_proto_msgTypes[9]
+	mi := &file_packets_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -542,7 +822,7 @@ func (x *SporeConsumedMessage) String() string {
 func (*SporeConsumedMessage) ProtoMessage() {}
 
 func (x *SporeConsumedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[9]
+	mi := &file_packets_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +835,7 @@ func (x *SporeConsumedMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SporeConsumedMessage.ProtoReflect.Descriptor instead.
 func (*SporeConsumedMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{9}
+	return file_packets_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SporeConsumedMessage) GetSporeId() uint64 {
@@ -575,7 +855,7 @@ type SporesBatchMessage struct {
 
 func (x *SporesBatchMessage) Reset() {
 	*x = SporesBatchMessage{}
-	mi := &file_packets_proto_msgTypes[10]
+	mi := &file_packets_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -587,7 +867,7 @@ func (x *SporesBatchMessage) String() string {
 func (*SporesBatchMessage) ProtoMessage() {}
 
 func (x *SporesBatchMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[10]
+	mi := &file_packets_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -600,7 +880,7 @@ func (x *SporesBatchMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SporesBatchMessage.ProtoReflect.Descriptor instead.
 func (*SporesBatchMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{10}
+	return file_packets_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SporesBatchMessage) GetSpores() []*SporeMessage {
@@ -620,7 +900,7 @@ type PlayerConsumedMessage struct {
 
 func (x *PlayerConsumedMessage) Reset() {
 	*x = PlayerConsumedMessage{}
-	mi := &file_packets_proto_msgTypes[11]
+	mi := &file_packets_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -632,7 +912,7 @@ func (x *PlayerConsumedMessage) String() string {
 func (*PlayerConsumedMessage) ProtoMessage() {}
 
 func (x *PlayerConsumedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[11]
+	mi := &file_packets_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,7 +925,7 @@ func (x *PlayerConsumedMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerConsumedMessage.ProtoReflect.Descriptor instead.
 func (*PlayerConsumedMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{11}
+	return file_packets_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PlayerConsumedMessage) GetPlayerId() uint64 {
@@ -663,7 +943,7 @@ type HiscoreBoardRequestMessage struct {
 
 func (x *HiscoreBoardRequestMessage) Reset() {
 	*x = HiscoreBoardRequestMessage{}
-	mi := &file_packets_proto_msgTypes[12]
+	mi := &file_packets_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -675,7 +955,7 @@ func (x *HiscoreBoardRequestMessage) String() string {
 func (*HiscoreBoardRequestMessage) ProtoMessage() {}
 
 func (x *HiscoreBoardRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[12]
+	mi := &file_packets_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +968,7 @@ func (x *HiscoreBoardRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HiscoreBoardRequestMessage.ProtoReflect.Descriptor instead.
 func (*HiscoreBoardRequestMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{12}
+	return file_packets_proto_rawDescGZIP(), []int{14}
 }
 
 type HiscoreMessage struct {
@@ -703,7 +983,7 @@ type HiscoreMessage struct {
 
 func (x *HiscoreMessage) Reset() {
 	*x = HiscoreMessage{}
-	mi := &file_packets_proto_msgTypes[13]
+	mi := &file_packets_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +995,7 @@ func (x *HiscoreMessage) String() string {
 func (*HiscoreMessage) ProtoMessage() {}
 
 func (x *HiscoreMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[13]
+	mi := &file_packets_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +1008,7 @@ func (x *HiscoreMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HiscoreMessage.ProtoReflect.Descriptor instead.
 func (*HiscoreMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{13}
+	return file_packets_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HiscoreMessage) GetRank() uint64 {
@@ -762,7 +1042,7 @@ type HiscoreBoardMessage struct {
 
 func (x *HiscoreBoardMessage) Reset() {
 	*x = HiscoreBoardMessage{}
-	mi := &file_packets_proto_msgTypes[14]
+	mi := &file_packets_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -774,7 +1054,7 @@ func (x *HiscoreBoardMessage) String() string {
 func (*HiscoreBoardMessage) ProtoMessage() {}
 
 func (x *HiscoreBoardMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[14]
+	mi := &file_packets_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +1067,7 @@ func (x *HiscoreBoardMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HiscoreBoardMessage.ProtoReflect.Descriptor instead.
 func (*HiscoreBoardMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{14}
+	return file_packets_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *HiscoreBoardMessage) GetHiscores() []*HiscoreMessage {
@@ -805,7 +1085,7 @@ type FinishedBrowsingHiscoresMessage struct {
 
 func (x *FinishedBrowsingHiscoresMessage) Reset() {
 	*x = FinishedBrowsingHiscoresMessage{}
-	mi := &file_packets_proto_msgTypes[15]
+	mi := &file_packets_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -817,7 +1097,7 @@ func (x *FinishedBrowsingHiscoresMessage) String() string {
 func (*FinishedBrowsingHiscoresMessage) ProtoMessage() {}
 
 func (x *FinishedBrowsingHiscoresMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[15]
+	mi := &file_packets_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1110,7 @@ func (x *FinishedBrowsingHiscoresMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FinishedBrowsingHiscoresMessage.ProtoReflect.Descriptor instead.
 func (*FinishedBrowsingHiscoresMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{15}
+	return file_packets_proto_rawDescGZIP(), []int{17}
 }
 
 type SearchHiscoreMessage struct {
@@ -843,7 +1123,7 @@ type SearchHiscoreMessage struct {
 
 func (x *SearchHiscoreMessage) Reset() {
 	*x = SearchHiscoreMessage{}
-	mi := &file_packets_proto_msgTypes[16]
+	mi := &file_packets_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -855,7 +1135,7 @@ func (x *SearchHiscoreMessage) String() string {
 func (*SearchHiscoreMessage) ProtoMessage() {}
 
 func (x *SearchHiscoreMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[16]
+	mi := &file_packets_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,7 +1148,7 @@ func (x *SearchHiscoreMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchHiscoreMessage.ProtoReflect.Descriptor instead.
 func (*SearchHiscoreMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{16}
+	return file_packets_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SearchHiscoreMessage) GetName() string {
@@ -888,7 +1168,7 @@ type DisconnectMessage struct {
 
 func (x *DisconnectMessage) Reset() {
 	*x = DisconnectMessage{}
-	mi := &file_packets_proto_msgTypes[17]
+	mi := &file_packets_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -900,7 +1180,7 @@ func (x *DisconnectMessage) String() string {
 func (*DisconnectMessage) ProtoMessage() {}
 
 func (x *DisconnectMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[17]
+	mi := &file_packets_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -913,7 +1193,7 @@ func (x *DisconnectMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectMessage.ProtoReflect.Descriptor instead.
 func (*DisconnectMessage) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{17}
+	return file_packets_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DisconnectMessage) GetReason() string {
@@ -923,50 +1203,299 @@ func (x *DisconnectMessage) GetReason() string {
 	return ""
 }
 
-type Packet struct {
+type TimeSyncRequestMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SenderId uint64 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
-	// Types that are assignable to Msg:
-	//
-	//	*Packet_Chat
-	//	*Packet_Id
-	//	*Packet_LoginRequest
-	//	*Packet_RegisterRequest
-	//	*Packet_OkResponse
-	//	*Packet_DenyResponse
-	//	*Packet_Player
-	//	*Packet_PlayerDirection
-	//	*Packet_Spore
-	//	*Packet_SporeConsumed
-	//	*Packet_SporesBatch
-	//	*Packet_PlayerConsumed
-	//	*Packet_HiscoreBoardRequest
-	//	*Packet_Hiscore
-	//	*Packet_HiscoreBoard
-	//	*Packet_FinishedBrowsingHiscores
-	//	*Packet_SearchHiscore
-	//	*Packet_Disconnect
-	Msg isPacket_Msg `protobuf_oneof:"msg"`
+	Seq        uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	ClientTime int64  `protobuf:"varint,2,opt,name=client_time,json=clientTime,proto3" json:"client_time,omitempty"`
+	LastRtt    int64  `protobuf:"varint,3,opt,name=last_rtt,json=lastRtt,proto3" json:"last_rtt,omitempty"`
 }
 
-func (x *Packet) Reset() {
-	*x = Packet{}
-	mi := &file_packets_proto_msgTypes[18]
+func (x *TimeSyncRequestMessage) Reset() {
+	*x = TimeSyncRequestMessage{}
+	mi := &file_packets_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) String() string {
+func (x *TimeSyncRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Packet) ProtoMessage() {}
+func (*TimeSyncRequestMessage) ProtoMessage() {}
 
-func (x *Packet) ProtoReflect() protoreflect.Message {
-	mi := &file_packets_proto_msgTypes[18]
+func (x *TimeSyncRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSyncRequestMessage.ProtoReflect.Descriptor instead.
+func (*TimeSyncRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TimeSyncRequestMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TimeSyncRequestMessage) GetClientTime() int64 {
+	if x != nil {
+		return x.ClientTime
+	}
+	return 0
+}
+
+func (x *TimeSyncRequestMessage) GetLastRtt() int64 {
+	if x != nil {
+		return x.LastRtt
+	}
+	return 0
+}
+
+type TimeSyncResponseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq        uint64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	ClientTime int64  `protobuf:"varint,2,opt,name=client_time,json=clientTime,proto3" json:"client_time,omitempty"`
+	ServerTime int64  `protobuf:"varint,3,opt,name=server_time,json=serverTime,proto3" json:"server_time,omitempty"`
+}
+
+func (x *TimeSyncResponseMessage) Reset() {
+	*x = TimeSyncResponseMessage{}
+	mi := &file_packets_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSyncResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSyncResponseMessage) ProtoMessage() {}
+
+func (x *TimeSyncResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSyncResponseMessage.ProtoReflect.Descriptor instead.
+func (*TimeSyncResponseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TimeSyncResponseMessage) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *TimeSyncResponseMessage) GetClientTime() int64 {
+	if x != nil {
+		return x.ClientTime
+	}
+	return 0
+}
+
+func (x *TimeSyncResponseMessage) GetServerTime() int64 {
+	if x != nil {
+		return x.ServerTime
+	}
+	return 0
+}
+
+type PresenceSubscribeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *PresenceSubscribeMessage) Reset() {
+	*x = PresenceSubscribeMessage{}
+	mi := &file_packets_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceSubscribeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceSubscribeMessage) ProtoMessage() {}
+
+func (x *PresenceSubscribeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceSubscribeMessage.ProtoReflect.Descriptor instead.
+func (*PresenceSubscribeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PresenceSubscribeMessage) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type PresenceUnsubscribeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *PresenceUnsubscribeMessage) Reset() {
+	*x = PresenceUnsubscribeMessage{}
+	mi := &file_packets_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceUnsubscribeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceUnsubscribeMessage) ProtoMessage() {}
+
+func (x *PresenceUnsubscribeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceUnsubscribeMessage.ProtoReflect.Descriptor instead.
+func (*PresenceUnsubscribeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PresenceUnsubscribeMessage) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type PresenceMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Online bool   `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+}
+
+func (x *PresenceMessage) Reset() {
+	*x = PresenceMessage{}
+	mi := &file_packets_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceMessage) ProtoMessage() {}
+
+func (x *PresenceMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceMessage.ProtoReflect.Descriptor instead.
+func (*PresenceMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PresenceMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PresenceMessage) GetOnline() bool {
+	if x != nil {
+		return x.Online
+	}
+	return false
+}
+
+type ResourceNodeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind      string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	X         float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y         float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius    float64 `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Available bool    `protobuf:"varint,6,opt,name=available,proto3" json:"available,omitempty"`
+}
+
+func (x *ResourceNodeMessage) Reset() {
+	*x = ResourceNodeMessage{}
+	mi := &file_packets_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceNodeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceNodeMessage) ProtoMessage() {}
+
+func (x *ResourceNodeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -977,225 +1506,8506 @@ func (x *Packet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
-func (*Packet) Descriptor() ([]byte, []int) {
-	return file_packets_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ResourceNodeMessage.ProtoReflect.Descriptor instead.
+func (*ResourceNodeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResourceNodeMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResourceNodeMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ResourceNodeMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *ResourceNodeMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *ResourceNodeMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *ResourceNodeMessage) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+type ResourceNodesBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceNodes []*ResourceNodeMessage `protobuf:"bytes,1,rep,name=resource_nodes,json=resourceNodes,proto3" json:"resource_nodes,omitempty"`
+}
+
+func (x *ResourceNodesBatchMessage) Reset() {
+	*x = ResourceNodesBatchMessage{}
+	mi := &file_packets_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceNodesBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceNodesBatchMessage) ProtoMessage() {}
+
+func (x *ResourceNodesBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceNodesBatchMessage.ProtoReflect.Descriptor instead.
+func (*ResourceNodesBatchMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ResourceNodesBatchMessage) GetResourceNodes() []*ResourceNodeMessage {
+	if x != nil {
+		return x.ResourceNodes
+	}
+	return nil
+}
+
+// The client seed is mixed into any rolls for the gather, so the server can't have picked the outcome in advance
+type GatherStartMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId     uint64 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ClientSeed string `protobuf:"bytes,2,opt,name=client_seed,json=clientSeed,proto3" json:"client_seed,omitempty"`
+}
+
+func (x *GatherStartMessage) Reset() {
+	*x = GatherStartMessage{}
+	mi := &file_packets_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GatherStartMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatherStartMessage) ProtoMessage() {}
+
+func (x *GatherStartMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatherStartMessage.ProtoReflect.Descriptor instead.
+func (*GatherStartMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GatherStartMessage) GetNodeId() uint64 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+func (x *GatherStartMessage) GetClientSeed() string {
+	if x != nil {
+		return x.ClientSeed
+	}
+	return ""
+}
+
+type GatherFinishMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId uint64 `protobuf:"varint,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+}
+
+func (x *GatherFinishMessage) Reset() {
+	*x = GatherFinishMessage{}
+	mi := &file_packets_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GatherFinishMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GatherFinishMessage) ProtoMessage() {}
+
+func (x *GatherFinishMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GatherFinishMessage.ProtoReflect.Descriptor instead.
+func (*GatherFinishMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GatherFinishMessage) GetNodeId() uint64 {
+	if x != nil {
+		return x.NodeId
+	}
+	return 0
+}
+
+type ItemStackMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ItemId   string `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity int64  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *ItemStackMessage) Reset() {
+	*x = ItemStackMessage{}
+	mi := &file_packets_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemStackMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemStackMessage) ProtoMessage() {}
+
+func (x *ItemStackMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemStackMessage.ProtoReflect.Descriptor instead.
+func (*ItemStackMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ItemStackMessage) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ItemStackMessage) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type InventoryMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*ItemStackMessage `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *InventoryMessage) Reset() {
+	*x = InventoryMessage{}
+	mi := &file_packets_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryMessage) ProtoMessage() {}
+
+func (x *InventoryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryMessage.ProtoReflect.Descriptor instead.
+func (*InventoryMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *InventoryMessage) GetItems() []*ItemStackMessage {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CraftRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecipeId string `protobuf:"bytes,1,opt,name=recipe_id,json=recipeId,proto3" json:"recipe_id,omitempty"`
+}
+
+func (x *CraftRequestMessage) Reset() {
+	*x = CraftRequestMessage{}
+	mi := &file_packets_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CraftRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CraftRequestMessage) ProtoMessage() {}
+
+func (x *CraftRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CraftRequestMessage.ProtoReflect.Descriptor instead.
+func (*CraftRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CraftRequestMessage) GetRecipeId() string {
+	if x != nil {
+		return x.RecipeId
+	}
+	return ""
+}
+
+type BlockPlayerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *BlockPlayerMessage) Reset() {
+	*x = BlockPlayerMessage{}
+	mi := &file_packets_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockPlayerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockPlayerMessage) ProtoMessage() {}
+
+func (x *BlockPlayerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockPlayerMessage.ProtoReflect.Descriptor instead.
+func (*BlockPlayerMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *BlockPlayerMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UnblockPlayerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *UnblockPlayerMessage) Reset() {
+	*x = UnblockPlayerMessage{}
+	mi := &file_packets_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockPlayerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockPlayerMessage) ProtoMessage() {}
+
+func (x *UnblockPlayerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockPlayerMessage.ProtoReflect.Descriptor instead.
+func (*UnblockPlayerMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UnblockPlayerMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type BlockListMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *BlockListMessage) Reset() {
+	*x = BlockListMessage{}
+	mi := &file_packets_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockListMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockListMessage) ProtoMessage() {}
+
+func (x *BlockListMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockListMessage.ProtoReflect.Descriptor instead.
+func (*BlockListMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BlockListMessage) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type MinimapRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MinimapRequestMessage) Reset() {
+	*x = MinimapRequestMessage{}
+	mi := &file_packets_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinimapRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimapRequestMessage) ProtoMessage() {}
+
+func (x *MinimapRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinimapRequestMessage.ProtoReflect.Descriptor instead.
+func (*MinimapRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{35}
+}
+
+type PointOfInterestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Kind string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	X    float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y    float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *PointOfInterestMessage) Reset() {
+	*x = PointOfInterestMessage{}
+	mi := &file_packets_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointOfInterestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointOfInterestMessage) ProtoMessage() {}
+
+func (x *PointOfInterestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointOfInterestMessage.ProtoReflect.Descriptor instead.
+func (*PointOfInterestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PointOfInterestMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PointOfInterestMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PointOfInterestMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PointOfInterestMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type OnboardingStepMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StepId string `protobuf:"bytes,1,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+	Prompt string `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Step   uint32 `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+	Steps  uint32 `protobuf:"varint,4,opt,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *OnboardingStepMessage) Reset() {
+	*x = OnboardingStepMessage{}
+	mi := &file_packets_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnboardingStepMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnboardingStepMessage) ProtoMessage() {}
+
+func (x *OnboardingStepMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnboardingStepMessage.ProtoReflect.Descriptor instead.
+func (*OnboardingStepMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OnboardingStepMessage) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+func (x *OnboardingStepMessage) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *OnboardingStepMessage) GetStep() uint32 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+func (x *OnboardingStepMessage) GetSteps() uint32 {
+	if x != nil {
+		return x.Steps
+	}
+	return 0
+}
+
+type CompleteOnboardingStepMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StepId string `protobuf:"bytes,1,opt,name=step_id,json=stepId,proto3" json:"step_id,omitempty"`
+}
+
+func (x *CompleteOnboardingStepMessage) Reset() {
+	*x = CompleteOnboardingStepMessage{}
+	mi := &file_packets_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteOnboardingStepMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteOnboardingStepMessage) ProtoMessage() {}
+
+func (x *CompleteOnboardingStepMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteOnboardingStepMessage.ProtoReflect.Descriptor instead.
+func (*CompleteOnboardingStepMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CompleteOnboardingStepMessage) GetStepId() string {
+	if x != nil {
+		return x.StepId
+	}
+	return ""
+}
+
+type EmoteMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Asset    string `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Unlocked bool   `protobuf:"varint,3,opt,name=unlocked,proto3" json:"unlocked,omitempty"`
+}
+
+func (x *EmoteMessage) Reset() {
+	*x = EmoteMessage{}
+	mi := &file_packets_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmoteMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmoteMessage) ProtoMessage() {}
+
+func (x *EmoteMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmoteMessage.ProtoReflect.Descriptor instead.
+func (*EmoteMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *EmoteMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EmoteMessage) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *EmoteMessage) GetUnlocked() bool {
+	if x != nil {
+		return x.Unlocked
+	}
+	return false
+}
+
+type EmotesMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emotes []*EmoteMessage `protobuf:"bytes,1,rep,name=emotes,proto3" json:"emotes,omitempty"`
+}
+
+func (x *EmotesMessage) Reset() {
+	*x = EmotesMessage{}
+	mi := &file_packets_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmotesMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmotesMessage) ProtoMessage() {}
+
+func (x *EmotesMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmotesMessage.ProtoReflect.Descriptor instead.
+func (*EmotesMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *EmotesMessage) GetEmotes() []*EmoteMessage {
+	if x != nil {
+		return x.Emotes
+	}
+	return nil
+}
+
+type EmoteUnlockedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmoteId string `protobuf:"bytes,1,opt,name=emote_id,json=emoteId,proto3" json:"emote_id,omitempty"`
+}
+
+func (x *EmoteUnlockedMessage) Reset() {
+	*x = EmoteUnlockedMessage{}
+	mi := &file_packets_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmoteUnlockedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmoteUnlockedMessage) ProtoMessage() {}
+
+func (x *EmoteUnlockedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmoteUnlockedMessage.ProtoReflect.Descriptor instead.
+func (*EmoteUnlockedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *EmoteUnlockedMessage) GetEmoteId() string {
+	if x != nil {
+		return x.EmoteId
+	}
+	return ""
+}
+
+type CustomMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *CustomMessage) Reset() {
+	*x = CustomMessage{}
+	mi := &file_packets_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomMessage) ProtoMessage() {}
+
+func (x *CustomMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomMessage.ProtoReflect.Descriptor instead.
+func (*CustomMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CustomMessage) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CustomMessage) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type AuctionListRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ItemId   string `protobuf:"bytes,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity int64  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price    int64  `protobuf:"varint,3,opt,name=price,proto3" json:"price,omitempty"`
+	Hours    uint32 `protobuf:"varint,4,opt,name=hours,proto3" json:"hours,omitempty"`
+}
+
+func (x *AuctionListRequestMessage) Reset() {
+	*x = AuctionListRequestMessage{}
+	mi := &file_packets_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionListRequestMessage) ProtoMessage() {}
+
+func (x *AuctionListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionListRequestMessage.ProtoReflect.Descriptor instead.
+func (*AuctionListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AuctionListRequestMessage) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AuctionListRequestMessage) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AuctionListRequestMessage) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AuctionListRequestMessage) GetHours() uint32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+type AuctionSearchRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page  uint32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *AuctionSearchRequestMessage) Reset() {
+	*x = AuctionSearchRequestMessage{}
+	mi := &file_packets_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionSearchRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionSearchRequestMessage) ProtoMessage() {}
+
+func (x *AuctionSearchRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionSearchRequestMessage.ProtoReflect.Descriptor instead.
+func (*AuctionSearchRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AuctionSearchRequestMessage) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AuctionSearchRequestMessage) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type AuctionListingMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seller    string `protobuf:"bytes,2,opt,name=seller,proto3" json:"seller,omitempty"`
+	ItemId    string `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity  int64  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price     int64  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *AuctionListingMessage) Reset() {
+	*x = AuctionListingMessage{}
+	mi := &file_packets_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionListingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionListingMessage) ProtoMessage() {}
+
+func (x *AuctionListingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionListingMessage.ProtoReflect.Descriptor instead.
+func (*AuctionListingMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AuctionListingMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuctionListingMessage) GetSeller() string {
+	if x != nil {
+		return x.Seller
+	}
+	return ""
+}
+
+func (x *AuctionListingMessage) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *AuctionListingMessage) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AuctionListingMessage) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AuctionListingMessage) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type AuctionSearchResultsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Listings []*AuctionListingMessage `protobuf:"bytes,1,rep,name=listings,proto3" json:"listings,omitempty"`
+	Page     uint32                   `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Pages    uint32                   `protobuf:"varint,3,opt,name=pages,proto3" json:"pages,omitempty"`
+}
+
+func (x *AuctionSearchResultsMessage) Reset() {
+	*x = AuctionSearchResultsMessage{}
+	mi := &file_packets_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionSearchResultsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionSearchResultsMessage) ProtoMessage() {}
+
+func (x *AuctionSearchResultsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionSearchResultsMessage.ProtoReflect.Descriptor instead.
+func (*AuctionSearchResultsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AuctionSearchResultsMessage) GetListings() []*AuctionListingMessage {
+	if x != nil {
+		return x.Listings
+	}
+	return nil
+}
+
+func (x *AuctionSearchResultsMessage) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AuctionSearchResultsMessage) GetPages() uint32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+type AuctionBuyoutMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListingId uint64 `protobuf:"varint,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+}
+
+func (x *AuctionBuyoutMessage) Reset() {
+	*x = AuctionBuyoutMessage{}
+	mi := &file_packets_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionBuyoutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionBuyoutMessage) ProtoMessage() {}
+
+func (x *AuctionBuyoutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionBuyoutMessage.ProtoReflect.Descriptor instead.
+func (*AuctionBuyoutMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuctionBuyoutMessage) GetListingId() uint64 {
+	if x != nil {
+		return x.ListingId
+	}
+	return 0
+}
+
+type MailboxRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MailboxRequestMessage) Reset() {
+	*x = MailboxRequestMessage{}
+	mi := &file_packets_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailboxRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailboxRequestMessage) ProtoMessage() {}
+
+func (x *MailboxRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailboxRequestMessage.ProtoReflect.Descriptor instead.
+func (*MailboxRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{48}
+}
+
+type MailMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sender   string `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Subject  string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	ItemId   string `protobuf:"bytes,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Quantity int64  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SentAt   int64  `protobuf:"varint,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+}
+
+func (x *MailMessage) Reset() {
+	*x = MailMessage{}
+	mi := &file_packets_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailMessage) ProtoMessage() {}
+
+func (x *MailMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailMessage.ProtoReflect.Descriptor instead.
+func (*MailMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *MailMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MailMessage) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *MailMessage) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *MailMessage) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *MailMessage) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *MailMessage) GetSentAt() int64 {
+	if x != nil {
+		return x.SentAt
+	}
+	return 0
+}
+
+type MailboxMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mail []*MailMessage `protobuf:"bytes,1,rep,name=mail,proto3" json:"mail,omitempty"`
+}
+
+func (x *MailboxMessage) Reset() {
+	*x = MailboxMessage{}
+	mi := &file_packets_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MailboxMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MailboxMessage) ProtoMessage() {}
+
+func (x *MailboxMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MailboxMessage.ProtoReflect.Descriptor instead.
+func (*MailboxMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *MailboxMessage) GetMail() []*MailMessage {
+	if x != nil {
+		return x.Mail
+	}
+	return nil
+}
+
+type ClaimMailMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MailId uint64 `protobuf:"varint,1,opt,name=mail_id,json=mailId,proto3" json:"mail_id,omitempty"`
+}
+
+func (x *ClaimMailMessage) Reset() {
+	*x = ClaimMailMessage{}
+	mi := &file_packets_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimMailMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimMailMessage) ProtoMessage() {}
+
+func (x *ClaimMailMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimMailMessage.ProtoReflect.Descriptor instead.
+func (*ClaimMailMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ClaimMailMessage) GetMailId() uint64 {
+	if x != nil {
+		return x.MailId
+	}
+	return 0
+}
+
+type ImpersonationRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Admin     string `protobuf:"bytes,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Control   bool   `protobuf:"varint,4,opt,name=control,proto3" json:"control,omitempty"`
+}
+
+func (x *ImpersonationRequestMessage) Reset() {
+	*x = ImpersonationRequestMessage{}
+	mi := &file_packets_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonationRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonationRequestMessage) ProtoMessage() {}
+
+func (x *ImpersonationRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonationRequestMessage.ProtoReflect.Descriptor instead.
+func (*ImpersonationRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImpersonationRequestMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ImpersonationRequestMessage) GetAdmin() string {
+	if x != nil {
+		return x.Admin
+	}
+	return ""
+}
+
+func (x *ImpersonationRequestMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ImpersonationRequestMessage) GetControl() bool {
+	if x != nil {
+		return x.Control
+	}
+	return false
+}
+
+type ImpersonationConsentMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Granted   bool   `protobuf:"varint,2,opt,name=granted,proto3" json:"granted,omitempty"`
+}
+
+func (x *ImpersonationConsentMessage) Reset() {
+	*x = ImpersonationConsentMessage{}
+	mi := &file_packets_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonationConsentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonationConsentMessage) ProtoMessage() {}
+
+func (x *ImpersonationConsentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonationConsentMessage.ProtoReflect.Descriptor instead.
+func (*ImpersonationConsentMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImpersonationConsentMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ImpersonationConsentMessage) GetGranted() bool {
+	if x != nil {
+		return x.Granted
+	}
+	return false
+}
+
+type ImpersonationStatusMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestId string `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Admin     string `protobuf:"bytes,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	Active    bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Control   bool   `protobuf:"varint,4,opt,name=control,proto3" json:"control,omitempty"`
+}
+
+func (x *ImpersonationStatusMessage) Reset() {
+	*x = ImpersonationStatusMessage{}
+	mi := &file_packets_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonationStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonationStatusMessage) ProtoMessage() {}
+
+func (x *ImpersonationStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonationStatusMessage.ProtoReflect.Descriptor instead.
+func (*ImpersonationStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ImpersonationStatusMessage) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ImpersonationStatusMessage) GetAdmin() string {
+	if x != nil {
+		return x.Admin
+	}
+	return ""
+}
+
+func (x *ImpersonationStatusMessage) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ImpersonationStatusMessage) GetControl() bool {
+	if x != nil {
+		return x.Control
+	}
+	return false
+}
+
+type FeatureFlagsRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *FeatureFlagsRequestMessage) Reset() {
+	*x = FeatureFlagsRequestMessage{}
+	mi := &file_packets_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlagsRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlagsRequestMessage) ProtoMessage() {}
+
+func (x *FeatureFlagsRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlagsRequestMessage.ProtoReflect.Descriptor instead.
+func (*FeatureFlagsRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{55}
+}
+
+type FeatureFlagsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled []string `protobuf:"bytes,1,rep,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *FeatureFlagsMessage) Reset() {
+	*x = FeatureFlagsMessage{}
+	mi := &file_packets_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureFlagsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlagsMessage) ProtoMessage() {}
+
+func (x *FeatureFlagsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlagsMessage.ProtoReflect.Descriptor instead.
+func (*FeatureFlagsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *FeatureFlagsMessage) GetEnabled() []string {
+	if x != nil {
+		return x.Enabled
+	}
+	return nil
+}
+
+type EntitlementListRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EntitlementListRequestMessage) Reset() {
+	*x = EntitlementListRequestMessage{}
+	mi := &file_packets_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntitlementListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntitlementListRequestMessage) ProtoMessage() {}
+
+func (x *EntitlementListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntitlementListRequestMessage.ProtoReflect.Descriptor instead.
+func (*EntitlementListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{57}
+}
+
+// What the account has been entitled to, e.g. DLC it's bought, and how many characters it can have because of it
+type EntitlementListMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entitlements   []string `protobuf:"bytes,1,rep,name=entitlements,proto3" json:"entitlements,omitempty"`
+	CharacterSlots uint32   `protobuf:"varint,2,opt,name=character_slots,json=characterSlots,proto3" json:"character_slots,omitempty"`
+}
+
+func (x *EntitlementListMessage) Reset() {
+	*x = EntitlementListMessage{}
+	mi := &file_packets_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntitlementListMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntitlementListMessage) ProtoMessage() {}
+
+func (x *EntitlementListMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntitlementListMessage.ProtoReflect.Descriptor instead.
+func (*EntitlementListMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *EntitlementListMessage) GetEntitlements() []string {
+	if x != nil {
+		return x.Entitlements
+	}
+	return nil
+}
+
+func (x *EntitlementListMessage) GetCharacterSlots() uint32 {
+	if x != nil {
+		return x.CharacterSlots
+	}
+	return 0
+}
+
+type ChannelListRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ChannelListRequestMessage) Reset() {
+	*x = ChannelListRequestMessage{}
+	mi := &file_packets_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelListRequestMessage) ProtoMessage() {}
+
+func (x *ChannelListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelListRequestMessage.ProtoReflect.Descriptor instead.
+func (*ChannelListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{59}
+}
+
+type ChannelInfoMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number        uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Population    uint32 `protobuf:"varint,2,opt,name=population,proto3" json:"population,omitempty"`
+	MaxPopulation uint32 `protobuf:"varint,3,opt,name=max_population,json=maxPopulation,proto3" json:"max_population,omitempty"`
+}
+
+func (x *ChannelInfoMessage) Reset() {
+	*x = ChannelInfoMessage{}
+	mi := &file_packets_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelInfoMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelInfoMessage) ProtoMessage() {}
+
+func (x *ChannelInfoMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelInfoMessage.ProtoReflect.Descriptor instead.
+func (*ChannelInfoMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ChannelInfoMessage) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *ChannelInfoMessage) GetPopulation() uint32 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *ChannelInfoMessage) GetMaxPopulation() uint32 {
+	if x != nil {
+		return x.MaxPopulation
+	}
+	return 0
+}
+
+type ChannelListMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneId   string                `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Current  uint32                `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
+	Channels []*ChannelInfoMessage `protobuf:"bytes,3,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *ChannelListMessage) Reset() {
+	*x = ChannelListMessage{}
+	mi := &file_packets_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelListMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelListMessage) ProtoMessage() {}
+
+func (x *ChannelListMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelListMessage.ProtoReflect.Descriptor instead.
+func (*ChannelListMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ChannelListMessage) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *ChannelListMessage) GetCurrent() uint32 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *ChannelListMessage) GetChannels() []*ChannelInfoMessage {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type ChannelSwitchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Channel    uint32 `protobuf:"varint,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	JoinPlayer string `protobuf:"bytes,2,opt,name=join_player,json=joinPlayer,proto3" json:"join_player,omitempty"`
+}
+
+func (x *ChannelSwitchMessage) Reset() {
+	*x = ChannelSwitchMessage{}
+	mi := &file_packets_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChannelSwitchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelSwitchMessage) ProtoMessage() {}
+
+func (x *ChannelSwitchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelSwitchMessage.ProtoReflect.Descriptor instead.
+func (*ChannelSwitchMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ChannelSwitchMessage) GetChannel() uint32 {
+	if x != nil {
+		return x.Channel
+	}
+	return 0
+}
+
+func (x *ChannelSwitchMessage) GetJoinPlayer() string {
+	if x != nil {
+		return x.JoinPlayer
+	}
+	return ""
+}
+
+// The SHA-256 hash of the seed rolls for the next encounter will be made with, sent ahead of it so it's settled before
+// the client picks the client_seed it starts the encounter with. The next is sent as soon as one starts.
+type RollCommitMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncounterId uint64 `protobuf:"varint,1,opt,name=encounter_id,json=encounterId,proto3" json:"encounter_id,omitempty"`
+	Commitment  []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+}
+
+func (x *RollCommitMessage) Reset() {
+	*x = RollCommitMessage{}
+	mi := &file_packets_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollCommitMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollCommitMessage) ProtoMessage() {}
+
+func (x *RollCommitMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollCommitMessage.ProtoReflect.Descriptor instead.
+func (*RollCommitMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RollCommitMessage) GetEncounterId() uint64 {
+	if x != nil {
+		return x.EncounterId
+	}
+	return 0
+}
+
+func (x *RollCommitMessage) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// Roll i is the first 8 bytes of HMAC-SHA256(seed, "<client_seed>:<i>") as a big-endian integer, shifted right by 11
+// bits and divided by 2^53
+type RollRevealMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncounterId uint64    `protobuf:"varint,1,opt,name=encounter_id,json=encounterId,proto3" json:"encounter_id,omitempty"`
+	Seed        []byte    `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	ClientSeed  string    `protobuf:"bytes,3,opt,name=client_seed,json=clientSeed,proto3" json:"client_seed,omitempty"`
+	Rolls       []float64 `protobuf:"fixed64,4,rep,packed,name=rolls,proto3" json:"rolls,omitempty"`
+}
+
+func (x *RollRevealMessage) Reset() {
+	*x = RollRevealMessage{}
+	mi := &file_packets_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollRevealMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollRevealMessage) ProtoMessage() {}
+
+func (x *RollRevealMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollRevealMessage.ProtoReflect.Descriptor instead.
+func (*RollRevealMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RollRevealMessage) GetEncounterId() uint64 {
+	if x != nil {
+		return x.EncounterId
+	}
+	return 0
+}
+
+func (x *RollRevealMessage) GetSeed() []byte {
+	if x != nil {
+		return x.Seed
+	}
+	return nil
+}
+
+func (x *RollRevealMessage) GetClientSeed() string {
+	if x != nil {
+		return x.ClientSeed
+	}
+	return ""
+}
+
+func (x *RollRevealMessage) GetRolls() []float64 {
+	if x != nil {
+		return x.Rolls
+	}
+	return nil
+}
+
+// kind is "offer", "answer", "ice" or "hangup", and payload the SDP or ICE candidate as the client's WebRTC library
+// gives it. Sent to the server with the peer it's for, and relayed with the peer it's from.
+type VoiceSignalMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PeerId  uint64 `protobuf:"varint,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Kind    string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Payload string `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *VoiceSignalMessage) Reset() {
+	*x = VoiceSignalMessage{}
+	mi := &file_packets_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoiceSignalMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceSignalMessage) ProtoMessage() {}
+
+func (x *VoiceSignalMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceSignalMessage.ProtoReflect.Descriptor instead.
+func (*VoiceSignalMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *VoiceSignalMessage) GetPeerId() uint64 {
+	if x != nil {
+		return x.PeerId
+	}
+	return 0
+}
+
+func (x *VoiceSignalMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *VoiceSignalMessage) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+// Join a voice party, whose members can talk at any distance, or leave it with no party
+type VoicePartyMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Party string `protobuf:"bytes,1,opt,name=party,proto3" json:"party,omitempty"`
+}
+
+func (x *VoicePartyMessage) Reset() {
+	*x = VoicePartyMessage{}
+	mi := &file_packets_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoicePartyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoicePartyMessage) ProtoMessage() {}
+
+func (x *VoicePartyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoicePartyMessage.ProtoReflect.Descriptor instead.
+func (*VoicePartyMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *VoicePartyMessage) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+// muted_until is 0 if the mute doesn't expire
+type VoiceStatusMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Muted      bool   `protobuf:"varint,1,opt,name=muted,proto3" json:"muted,omitempty"`
+	MutedUntil int64  `protobuf:"varint,2,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Party      string `protobuf:"bytes,4,opt,name=party,proto3" json:"party,omitempty"`
+}
+
+func (x *VoiceStatusMessage) Reset() {
+	*x = VoiceStatusMessage{}
+	mi := &file_packets_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoiceStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceStatusMessage) ProtoMessage() {}
+
+func (x *VoiceStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceStatusMessage.ProtoReflect.Descriptor instead.
+func (*VoiceStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *VoiceStatusMessage) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *VoiceStatusMessage) GetMutedUntil() int64 {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return 0
+}
+
+func (x *VoiceStatusMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *VoiceStatusMessage) GetParty() string {
+	if x != nil {
+		return x.Party
+	}
+	return ""
+}
+
+// Ask for the STUN and TURN servers to give the WebRTC library before setting up a call, so players behind NATs that
+// can't be traversed directly can still be reached through a relay
+type IceServersRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *IceServersRequestMessage) Reset() {
+	*x = IceServersRequestMessage{}
+	mi := &file_packets_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IceServersRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IceServersRequestMessage) ProtoMessage() {}
+
+func (x *IceServersRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IceServersRequestMessage.ProtoReflect.Descriptor instead.
+func (*IceServersRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{68}
+}
+
+// Given to the WebRTC library as one of its ICE servers as is. Username and credential are empty for STUN servers.
+type IceServerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Urls       []string `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	Username   string   `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Credential string   `protobuf:"bytes,3,opt,name=credential,proto3" json:"credential,omitempty"`
+}
+
+func (x *IceServerMessage) Reset() {
+	*x = IceServerMessage{}
+	mi := &file_packets_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IceServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IceServerMessage) ProtoMessage() {}
+
+func (x *IceServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IceServerMessage.ProtoReflect.Descriptor instead.
+func (*IceServerMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *IceServerMessage) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *IceServerMessage) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *IceServerMessage) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+// expires_at is when the TURN credentials stop being accepted, in unix milliseconds, or 0 if there are none. Ask again
+// before then for calls set up later.
+type IceServersMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers   []*IceServerMessage `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	ExpiresAt int64               `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *IceServersMessage) Reset() {
+	*x = IceServersMessage{}
+	mi := &file_packets_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IceServersMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IceServersMessage) ProtoMessage() {}
+
+func (x *IceServersMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IceServersMessage.ProtoReflect.Descriptor instead.
+func (*IceServersMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *IceServersMessage) GetServers() []*IceServerMessage {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+func (x *IceServersMessage) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type DataExportRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DataExportRequestMessage) Reset() {
+	*x = DataExportRequestMessage{}
+	mi := &file_packets_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExportRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExportRequestMessage) ProtoMessage() {}
+
+func (x *DataExportRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExportRequestMessage.ProtoReflect.Descriptor instead.
+func (*DataExportRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{71}
+}
+
+// The password has to be given again, so no one can delete an account just by getting hold of a logged in client
+type AccountDeletionRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *AccountDeletionRequestMessage) Reset() {
+	*x = AccountDeletionRequestMessage{}
+	mi := &file_packets_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletionRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletionRequestMessage) ProtoMessage() {}
+
+func (x *AccountDeletionRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletionRequestMessage.ProtoReflect.Descriptor instead.
+func (*AccountDeletionRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *AccountDeletionRequestMessage) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type AccountDeletionCancelMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AccountDeletionCancelMessage) Reset() {
+	*x = AccountDeletionCancelMessage{}
+	mi := &file_packets_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletionCancelMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletionCancelMessage) ProtoMessage() {}
+
+func (x *AccountDeletionCancelMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletionCancelMessage.ProtoReflect.Descriptor instead.
+func (*AccountDeletionCancelMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{73}
+}
+
+type AccountDataStatusRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AccountDataStatusRequestMessage) Reset() {
+	*x = AccountDataStatusRequestMessage{}
+	mi := &file_packets_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDataStatusRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDataStatusRequestMessage) ProtoMessage() {}
+
+func (x *AccountDataStatusRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDataStatusRequestMessage.ProtoReflect.Descriptor instead.
+func (*AccountDataStatusRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{74}
+}
+
+// export_url is a path on the server the export can be downloaded from once export_status is "ready". deletion_at is
+// when the account will be deleted, or 0 if it isn't going to be.
+type AccountDataStatusMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExportStatus    string `protobuf:"bytes,1,opt,name=export_status,json=exportStatus,proto3" json:"export_status,omitempty"`
+	ExportUrl       string `protobuf:"bytes,2,opt,name=export_url,json=exportUrl,proto3" json:"export_url,omitempty"`
+	ExportExpiresAt int64  `protobuf:"varint,3,opt,name=export_expires_at,json=exportExpiresAt,proto3" json:"export_expires_at,omitempty"`
+	DeletionAt      int64  `protobuf:"varint,4,opt,name=deletion_at,json=deletionAt,proto3" json:"deletion_at,omitempty"`
+}
+
+func (x *AccountDataStatusMessage) Reset() {
+	*x = AccountDataStatusMessage{}
+	mi := &file_packets_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDataStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDataStatusMessage) ProtoMessage() {}
+
+func (x *AccountDataStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDataStatusMessage.ProtoReflect.Descriptor instead.
+func (*AccountDataStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *AccountDataStatusMessage) GetExportStatus() string {
+	if x != nil {
+		return x.ExportStatus
+	}
+	return ""
+}
+
+func (x *AccountDataStatusMessage) GetExportUrl() string {
+	if x != nil {
+		return x.ExportUrl
+	}
+	return ""
+}
+
+func (x *AccountDataStatusMessage) GetExportExpiresAt() int64 {
+	if x != nil {
+		return x.ExportExpiresAt
+	}
+	return 0
+}
+
+func (x *AccountDataStatusMessage) GetDeletionAt() int64 {
+	if x != nil {
+		return x.DeletionAt
+	}
+	return 0
+}
+
+// A file the server is about to send, in chunks of FileChunkMessage. sha256 is of the whole file, for the client to
+// check once it has every chunk.
+type FileOfferMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId  uint64 `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size        uint64 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Chunks      uint32 `protobuf:"varint,5,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	Sha256      []byte `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *FileOfferMessage) Reset() {
+	*x = FileOfferMessage{}
+	mi := &file_packets_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileOfferMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileOfferMessage) ProtoMessage() {}
+
+func (x *FileOfferMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileOfferMessage.ProtoReflect.Descriptor instead.
+func (*FileOfferMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *FileOfferMessage) GetTransferId() uint64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *FileOfferMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileOfferMessage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileOfferMessage) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileOfferMessage) GetChunks() uint32 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+func (x *FileOfferMessage) GetSha256() []byte {
+	if x != nil {
+		return x.Sha256
+	}
+	return nil
+}
+
+type FileChunkMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId uint64 `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Index      uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Data       []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *FileChunkMessage) Reset() {
+	*x = FileChunkMessage{}
+	mi := &file_packets_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunkMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunkMessage) ProtoMessage() {}
+
+func (x *FileChunkMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunkMessage.ProtoReflect.Descriptor instead.
+func (*FileChunkMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *FileChunkMessage) GetTransferId() uint64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *FileChunkMessage) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *FileChunkMessage) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Sent by the client once it has the whole file, or has given up on it, e.g. because the checksum didn't match
+type FileReceivedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransferId uint64 `protobuf:"varint,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	Ok         bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *FileReceivedMessage) Reset() {
+	*x = FileReceivedMessage{}
+	mi := &file_packets_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileReceivedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileReceivedMessage) ProtoMessage() {}
+
+func (x *FileReceivedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileReceivedMessage.ProtoReflect.Descriptor instead.
+func (*FileReceivedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *FileReceivedMessage) GetTransferId() uint64 {
+	if x != nil {
+		return x.TransferId
+	}
+	return 0
+}
+
+func (x *FileReceivedMessage) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *FileReceivedMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StoreRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *StoreRequestMessage) Reset() {
+	*x = StoreRequestMessage{}
+	mi := &file_packets_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreRequestMessage) ProtoMessage() {}
+
+func (x *StoreRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreRequestMessage.ProtoReflect.Descriptor instead.
+func (*StoreRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{79}
+}
+
+type StoreProductMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platform  string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	ProductId string `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+}
+
+func (x *StoreProductMessage) Reset() {
+	*x = StoreProductMessage{}
+	mi := &file_packets_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreProductMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreProductMessage) ProtoMessage() {}
+
+func (x *StoreProductMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreProductMessage.ProtoReflect.Descriptor instead.
+func (*StoreProductMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *StoreProductMessage) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *StoreProductMessage) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+// price is in coins, or 0 if the entry can only be bought on one of the app stores in products
+type StoreEntryMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	EmoteId     string                 `protobuf:"bytes,3,opt,name=emote_id,json=emoteId,proto3" json:"emote_id,omitempty"`
+	Price       int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	Products    []*StoreProductMessage `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	Owned       bool                   `protobuf:"varint,6,opt,name=owned,proto3" json:"owned,omitempty"`
+	Entitlement string                 `protobuf:"bytes,7,opt,name=entitlement,proto3" json:"entitlement,omitempty"`
+}
+
+func (x *StoreEntryMessage) Reset() {
+	*x = StoreEntryMessage{}
+	mi := &file_packets_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreEntryMessage) ProtoMessage() {}
+
+func (x *StoreEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreEntryMessage.ProtoReflect.Descriptor instead.
+func (*StoreEntryMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *StoreEntryMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StoreEntryMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoreEntryMessage) GetEmoteId() string {
+	if x != nil {
+		return x.EmoteId
+	}
+	return ""
+}
+
+func (x *StoreEntryMessage) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StoreEntryMessage) GetProducts() []*StoreProductMessage {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *StoreEntryMessage) GetOwned() bool {
+	if x != nil {
+		return x.Owned
+	}
+	return false
+}
+
+func (x *StoreEntryMessage) GetEntitlement() string {
+	if x != nil {
+		return x.Entitlement
+	}
+	return ""
+}
+
+type StoreMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*StoreEntryMessage `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *StoreMessage) Reset() {
+	*x = StoreMessage{}
+	mi := &file_packets_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreMessage) ProtoMessage() {}
+
+func (x *StoreMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreMessage.ProtoReflect.Descriptor instead.
+func (*StoreMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *StoreMessage) GetEntries() []*StoreEntryMessage {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Buy the entry for coins
+type StorePurchaseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntryId string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+}
+
+func (x *StorePurchaseMessage) Reset() {
+	*x = StorePurchaseMessage{}
+	mi := &file_packets_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorePurchaseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorePurchaseMessage) ProtoMessage() {}
+
+func (x *StorePurchaseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorePurchaseMessage.ProtoReflect.Descriptor instead.
+func (*StorePurchaseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *StorePurchaseMessage) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+// Claim the entry with a receipt from buying it on an app store
+type StoreReceiptMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EntryId  string `protobuf:"bytes,1,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Platform string `protobuf:"bytes,2,opt,name=platform,proto3" json:"platform,omitempty"`
+	Receipt  string `protobuf:"bytes,3,opt,name=receipt,proto3" json:"receipt,omitempty"`
+}
+
+func (x *StoreReceiptMessage) Reset() {
+	*x = StoreReceiptMessage{}
+	mi := &file_packets_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreReceiptMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreReceiptMessage) ProtoMessage() {}
+
+func (x *StoreReceiptMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreReceiptMessage.ProtoReflect.Descriptor instead.
+func (*StoreReceiptMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *StoreReceiptMessage) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *StoreReceiptMessage) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *StoreReceiptMessage) GetReceipt() string {
+	if x != nil {
+		return x.Receipt
+	}
+	return ""
+}
+
+// status is pending until the receipt has been checked, then approved or rejected
+type StoreReceiptStatusMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceiptId uint64 `protobuf:"varint,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	EntryId   string `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	Status    string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Reason    string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *StoreReceiptStatusMessage) Reset() {
+	*x = StoreReceiptStatusMessage{}
+	mi := &file_packets_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreReceiptStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreReceiptStatusMessage) ProtoMessage() {}
+
+func (x *StoreReceiptStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreReceiptStatusMessage.ProtoReflect.Descriptor instead.
+func (*StoreReceiptStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *StoreReceiptStatusMessage) GetReceiptId() uint64 {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return 0
+}
+
+func (x *StoreReceiptStatusMessage) GetEntryId() string {
+	if x != nil {
+		return x.EntryId
+	}
+	return ""
+}
+
+func (x *StoreReceiptStatusMessage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StoreReceiptStatusMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// The client's build is older than the server accepts. If required is false it can keep playing until deadline, a
+// Unix time, otherwise it's turned away until it updates to at least min_build from download_url.
+type ClientUpdateMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Required    bool   `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	MinBuild    uint32 `protobuf:"varint,2,opt,name=min_build,json=minBuild,proto3" json:"min_build,omitempty"`
+	Deadline    int64  `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	DownloadUrl string `protobuf:"bytes,4,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+}
+
+func (x *ClientUpdateMessage) Reset() {
+	*x = ClientUpdateMessage{}
+	mi := &file_packets_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientUpdateMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientUpdateMessage) ProtoMessage() {}
+
+func (x *ClientUpdateMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientUpdateMessage.ProtoReflect.Descriptor instead.
+func (*ClientUpdateMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ClientUpdateMessage) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ClientUpdateMessage) GetMinBuild() uint32 {
+	if x != nil {
+		return x.MinBuild
+	}
+	return 0
+}
+
+func (x *ClientUpdateMessage) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *ClientUpdateMessage) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+type DataFileMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Sha256 []byte `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Size   uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *DataFileMessage) Reset() {
+	*x = DataFileMessage{}
+	mi := &file_packets_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataFileMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataFileMessage) ProtoMessage() {}
+
+func (x *DataFileMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataFileMessage.ProtoReflect.Descriptor instead.
+func (*DataFileMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *DataFileMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataFileMessage) GetSha256() []byte {
+	if x != nil {
+		return x.Sha256
+	}
+	return nil
+}
+
+func (x *DataFileMessage) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// The server's game data, sent when a client logging in has different data. Files whose checksums differ from the
+// client's copies can be downloaded from url followed by their names.
+type DataPackMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  int64              `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Checksum []byte             `protobuf:"bytes,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Files    []*DataFileMessage `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	Url      string             `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *DataPackMessage) Reset() {
+	*x = DataPackMessage{}
+	mi := &file_packets_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataPackMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataPackMessage) ProtoMessage() {}
+
+func (x *DataPackMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataPackMessage.ProtoReflect.Descriptor instead.
+func (*DataPackMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *DataPackMessage) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataPackMessage) GetChecksum() []byte {
+	if x != nil {
+		return x.Checksum
+	}
+	return nil
+}
+
+func (x *DataPackMessage) GetFiles() []*DataFileMessage {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *DataPackMessage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Do something to an object in the world, e.g. "use" a resource node or "inspect" another player
+type InteractMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId uint64 `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Verb     string `protobuf:"bytes,2,opt,name=verb,proto3" json:"verb,omitempty"`
+}
+
+func (x *InteractMessage) Reset() {
+	*x = InteractMessage{}
+	mi := &file_packets_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractMessage) ProtoMessage() {}
+
+func (x *InteractMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractMessage.ProtoReflect.Descriptor instead.
+func (*InteractMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *InteractMessage) GetTargetId() uint64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *InteractMessage) GetVerb() string {
+	if x != nil {
+		return x.Verb
+	}
+	return ""
+}
+
+type InteractionFieldMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *InteractionFieldMessage) Reset() {
+	*x = InteractionFieldMessage{}
+	mi := &file_packets_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionFieldMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionFieldMessage) ProtoMessage() {}
+
+func (x *InteractionFieldMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionFieldMessage.ProtoReflect.Descriptor instead.
+func (*InteractionFieldMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *InteractionFieldMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InteractionFieldMessage) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// What came of an interaction. kind is the subsystem that handled it: loot, shop, dialog or inspect. If ok is false,
+// reason says why it couldn't be done.
+type InteractionResultMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId uint64                     `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Verb     string                     `protobuf:"bytes,2,opt,name=verb,proto3" json:"verb,omitempty"`
+	Ok       bool                       `protobuf:"varint,3,opt,name=ok,proto3" json:"ok,omitempty"`
+	Reason   string                     `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Kind     string                     `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	Text     string                     `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Fields   []*InteractionFieldMessage `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *InteractionResultMessage) Reset() {
+	*x = InteractionResultMessage{}
+	mi := &file_packets_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InteractionResultMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InteractionResultMessage) ProtoMessage() {}
+
+func (x *InteractionResultMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InteractionResultMessage.ProtoReflect.Descriptor instead.
+func (*InteractionResultMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *InteractionResultMessage) GetTargetId() uint64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *InteractionResultMessage) GetVerb() string {
+	if x != nil {
+		return x.Verb
+	}
+	return ""
+}
+
+func (x *InteractionResultMessage) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *InteractionResultMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *InteractionResultMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *InteractionResultMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *InteractionResultMessage) GetFields() []*InteractionFieldMessage {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type PromptOptionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+}
+
+func (x *PromptOptionMessage) Reset() {
+	*x = PromptOptionMessage{}
+	mi := &file_packets_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptOptionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptOptionMessage) ProtoMessage() {}
+
+func (x *PromptOptionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptOptionMessage.ProtoReflect.Descriptor instead.
+func (*PromptOptionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *PromptOptionMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PromptOptionMessage) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+// Asks the player to pick one of the options, e.g. to confirm a trade. Unless timeout_ms is 0, the prompt is closed if
+// it isn't answered in time.
+type PromptMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PromptId  uint64                 `protobuf:"varint,1,opt,name=prompt_id,json=promptId,proto3" json:"prompt_id,omitempty"`
+	Title     string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text      string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Options   []*PromptOptionMessage `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty"`
+	TimeoutMs uint32                 `protobuf:"varint,5,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+}
+
+func (x *PromptMessage) Reset() {
+	*x = PromptMessage{}
+	mi := &file_packets_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptMessage) ProtoMessage() {}
+
+func (x *PromptMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptMessage.ProtoReflect.Descriptor instead.
+func (*PromptMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *PromptMessage) GetPromptId() uint64 {
+	if x != nil {
+		return x.PromptId
+	}
+	return 0
+}
+
+func (x *PromptMessage) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *PromptMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PromptMessage) GetOptions() []*PromptOptionMessage {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *PromptMessage) GetTimeoutMs() uint32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type PromptResponseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PromptId uint64 `protobuf:"varint,1,opt,name=prompt_id,json=promptId,proto3" json:"prompt_id,omitempty"`
+	OptionId string `protobuf:"bytes,2,opt,name=option_id,json=optionId,proto3" json:"option_id,omitempty"`
+}
+
+func (x *PromptResponseMessage) Reset() {
+	*x = PromptResponseMessage{}
+	mi := &file_packets_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptResponseMessage) ProtoMessage() {}
+
+func (x *PromptResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptResponseMessage.ProtoReflect.Descriptor instead.
+func (*PromptResponseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *PromptResponseMessage) GetPromptId() uint64 {
+	if x != nil {
+		return x.PromptId
+	}
+	return 0
+}
+
+func (x *PromptResponseMessage) GetOptionId() string {
+	if x != nil {
+		return x.OptionId
+	}
+	return ""
+}
+
+// The prompt can't be answered any more, e.g. because it timed out, and should be taken down
+type PromptClosedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PromptId uint64 `protobuf:"varint,1,opt,name=prompt_id,json=promptId,proto3" json:"prompt_id,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PromptClosedMessage) Reset() {
+	*x = PromptClosedMessage{}
+	mi := &file_packets_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromptClosedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromptClosedMessage) ProtoMessage() {}
+
+func (x *PromptClosedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromptClosedMessage.ProtoReflect.Descriptor instead.
+func (*PromptClosedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *PromptClosedMessage) GetPromptId() uint64 {
+	if x != nil {
+		return x.PromptId
+	}
+	return 0
+}
+
+func (x *PromptClosedMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// An ambient or visual cue for the client to play where it happened: switch to an ambient track, play a particle
+// event at x, y, or shake the screen. Fields left empty or 0 aren't part of the cue.
+type EnvironmentCueMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AmbientTrack   string  `protobuf:"bytes,1,opt,name=ambient_track,json=ambientTrack,proto3" json:"ambient_track,omitempty"`
+	ParticleEvent  string  `protobuf:"bytes,2,opt,name=particle_event,json=particleEvent,proto3" json:"particle_event,omitempty"`
+	X              float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y              float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	ShakeIntensity float64 `protobuf:"fixed64,5,opt,name=shake_intensity,json=shakeIntensity,proto3" json:"shake_intensity,omitempty"`
+	ShakeMs        uint32  `protobuf:"varint,6,opt,name=shake_ms,json=shakeMs,proto3" json:"shake_ms,omitempty"`
+}
+
+func (x *EnvironmentCueMessage) Reset() {
+	*x = EnvironmentCueMessage{}
+	mi := &file_packets_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnvironmentCueMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnvironmentCueMessage) ProtoMessage() {}
+
+func (x *EnvironmentCueMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnvironmentCueMessage.ProtoReflect.Descriptor instead.
+func (*EnvironmentCueMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *EnvironmentCueMessage) GetAmbientTrack() string {
+	if x != nil {
+		return x.AmbientTrack
+	}
+	return ""
+}
+
+func (x *EnvironmentCueMessage) GetParticleEvent() string {
+	if x != nil {
+		return x.ParticleEvent
+	}
+	return ""
+}
+
+func (x *EnvironmentCueMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *EnvironmentCueMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *EnvironmentCueMessage) GetShakeIntensity() float64 {
+	if x != nil {
+		return x.ShakeIntensity
+	}
+	return 0
+}
+
+func (x *EnvironmentCueMessage) GetShakeMs() uint32 {
+	if x != nil {
+		return x.ShakeMs
+	}
+	return 0
+}
+
+// Logs in a trusted world simulator, e.g. a separate process running AI or physics, which drives actors of its own
+type SimulatorLoginRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SimulatorLoginRequestMessage) Reset() {
+	*x = SimulatorLoginRequestMessage{}
+	mi := &file_packets_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimulatorLoginRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimulatorLoginRequestMessage) ProtoMessage() {}
+
+func (x *SimulatorLoginRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimulatorLoginRequestMessage.ProtoReflect.Descriptor instead.
+func (*SimulatorLoginRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *SimulatorLoginRequestMessage) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *SimulatorLoginRequestMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// An actor driven by a world simulator, which players see the same as any other player. Tags are only read when it's
+// spawned, and are what scripts can find it by.
+type ActorMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name      string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	X         float64  `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y         float64  `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius    float64  `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Direction float64  `protobuf:"fixed64,6,opt,name=direction,proto3" json:"direction,omitempty"`
+	Speed     float64  `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	Color     int32    `protobuf:"varint,8,opt,name=color,proto3" json:"color,omitempty"`
+	Tags      []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *ActorMessage) Reset() {
+	*x = ActorMessage{}
+	mi := &file_packets_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorMessage) ProtoMessage() {}
+
+func (x *ActorMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorMessage.ProtoReflect.Descriptor instead.
+func (*ActorMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *ActorMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ActorMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetDirection() float64 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetColor() int32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+func (x *ActorMessage) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Spawns the actors, whose ids are left out, and is answered with their ids in the same order, 0 for any that
+// couldn't be spawned
+type ActorSpawnRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actors []*ActorMessage `protobuf:"bytes,1,rep,name=actors,proto3" json:"actors,omitempty"`
+}
+
+func (x *ActorSpawnRequestMessage) Reset() {
+	*x = ActorSpawnRequestMessage{}
+	mi := &file_packets_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorSpawnRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorSpawnRequestMessage) ProtoMessage() {}
+
+func (x *ActorSpawnRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorSpawnRequestMessage.ProtoReflect.Descriptor instead.
+func (*ActorSpawnRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ActorSpawnRequestMessage) GetActors() []*ActorMessage {
+	if x != nil {
+		return x.Actors
+	}
+	return nil
+}
+
+type ActorSpawnResponseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ActorSpawnResponseMessage) Reset() {
+	*x = ActorSpawnResponseMessage{}
+	mi := &file_packets_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorSpawnResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorSpawnResponseMessage) ProtoMessage() {}
+
+func (x *ActorSpawnResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorSpawnResponseMessage.ProtoReflect.Descriptor instead.
+func (*ActorSpawnResponseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ActorSpawnResponseMessage) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// Where the simulator's actors are now, any number at once
+type ActorUpdatesMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Actors []*ActorMessage `protobuf:"bytes,1,rep,name=actors,proto3" json:"actors,omitempty"`
+}
+
+func (x *ActorUpdatesMessage) Reset() {
+	*x = ActorUpdatesMessage{}
+	mi := &file_packets_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorUpdatesMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorUpdatesMessage) ProtoMessage() {}
+
+func (x *ActorUpdatesMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorUpdatesMessage.ProtoReflect.Descriptor instead.
+func (*ActorUpdatesMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ActorUpdatesMessage) GetActors() []*ActorMessage {
+	if x != nil {
+		return x.Actors
+	}
+	return nil
+}
+
+type ActorDespawnMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *ActorDespawnMessage) Reset() {
+	*x = ActorDespawnMessage{}
+	mi := &file_packets_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorDespawnMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorDespawnMessage) ProtoMessage() {}
+
+func (x *ActorDespawnMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorDespawnMessage.ProtoReflect.Descriptor instead.
+func (*ActorDespawnMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ActorDespawnMessage) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DialogChoiceMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *DialogChoiceMessage) Reset() {
+	*x = DialogChoiceMessage{}
+	mi := &file_packets_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DialogChoiceMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DialogChoiceMessage) ProtoMessage() {}
+
+func (x *DialogChoiceMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DialogChoiceMessage.ProtoReflect.Descriptor instead.
+func (*DialogChoiceMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *DialogChoiceMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DialogChoiceMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Where a conversation with target_id has got to. Only the choices the player can make right now are sent, and once
+// one's been made the next node is sent, or the dialog is closed.
+type DialogMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetId uint64                 `protobuf:"varint,1,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	DialogId string                 `protobuf:"bytes,2,opt,name=dialog_id,json=dialogId,proto3" json:"dialog_id,omitempty"`
+	NodeId   string                 `protobuf:"bytes,3,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Speaker  string                 `protobuf:"bytes,4,opt,name=speaker,proto3" json:"speaker,omitempty"`
+	Text     string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Choices  []*DialogChoiceMessage `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`
+}
+
+func (x *DialogMessage) Reset() {
+	*x = DialogMessage{}
+	mi := &file_packets_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DialogMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DialogMessage) ProtoMessage() {}
+
+func (x *DialogMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DialogMessage.ProtoReflect.Descriptor instead.
+func (*DialogMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *DialogMessage) GetTargetId() uint64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *DialogMessage) GetDialogId() string {
+	if x != nil {
+		return x.DialogId
+	}
+	return ""
+}
+
+func (x *DialogMessage) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *DialogMessage) GetSpeaker() string {
+	if x != nil {
+		return x.Speaker
+	}
+	return ""
+}
+
+func (x *DialogMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DialogMessage) GetChoices() []*DialogChoiceMessage {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+// Make one of the choices of the node the open dialog is at
+type DialogChooseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChoiceId string `protobuf:"bytes,1,opt,name=choice_id,json=choiceId,proto3" json:"choice_id,omitempty"`
+}
+
+func (x *DialogChooseMessage) Reset() {
+	*x = DialogChooseMessage{}
+	mi := &file_packets_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DialogChooseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DialogChooseMessage) ProtoMessage() {}
+
+func (x *DialogChooseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DialogChooseMessage.ProtoReflect.Descriptor instead.
+func (*DialogChooseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *DialogChooseMessage) GetChoiceId() string {
+	if x != nil {
+		return x.ChoiceId
+	}
+	return ""
+}
+
+// The dialog is over, either because it ended or, with a reason, because the choice couldn't be made
+type DialogClosedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DialogId string `protobuf:"bytes,1,opt,name=dialog_id,json=dialogId,proto3" json:"dialog_id,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DialogClosedMessage) Reset() {
+	*x = DialogClosedMessage{}
+	mi := &file_packets_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DialogClosedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DialogClosedMessage) ProtoMessage() {}
+
+func (x *DialogClosedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DialogClosedMessage.ProtoReflect.Descriptor instead.
+func (*DialogClosedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *DialogClosedMessage) GetDialogId() string {
+	if x != nil {
+		return x.DialogId
+	}
+	return ""
+}
+
+func (x *DialogClosedMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// How far the player is through a quest now, where 0 is not started
+type QuestStageMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	QuestId string `protobuf:"bytes,1,opt,name=quest_id,json=questId,proto3" json:"quest_id,omitempty"`
+	Stage   int64  `protobuf:"varint,2,opt,name=stage,proto3" json:"stage,omitempty"`
+}
+
+func (x *QuestStageMessage) Reset() {
+	*x = QuestStageMessage{}
+	mi := &file_packets_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuestStageMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestStageMessage) ProtoMessage() {}
+
+func (x *QuestStageMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestStageMessage.ProtoReflect.Descriptor instead.
+func (*QuestStageMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *QuestStageMessage) GetQuestId() string {
+	if x != nil {
+		return x.QuestId
+	}
+	return ""
+}
+
+func (x *QuestStageMessage) GetStage() int64 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+// Have updates to each feed, e.g. "hiscores" or "auctions", pushed to us until we unsubscribe or disconnect. Each is
+// answered with whether we're subscribed now.
+type SubscribeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feeds []string `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+}
+
+func (x *SubscribeMessage) Reset() {
+	*x = SubscribeMessage{}
+	mi := &file_packets_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeMessage) ProtoMessage() {}
+
+func (x *SubscribeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeMessage.ProtoReflect.Descriptor instead.
+func (*SubscribeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *SubscribeMessage) GetFeeds() []string {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+type UnsubscribeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feeds []string `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+}
+
+func (x *UnsubscribeMessage) Reset() {
+	*x = UnsubscribeMessage{}
+	mi := &file_packets_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeMessage) ProtoMessage() {}
+
+func (x *UnsubscribeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeMessage.ProtoReflect.Descriptor instead.
+func (*UnsubscribeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *UnsubscribeMessage) GetFeeds() []string {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+type SubscriptionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Feed       string `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	Subscribed bool   `protobuf:"varint,2,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	Reason     string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *SubscriptionMessage) Reset() {
+	*x = SubscriptionMessage{}
+	mi := &file_packets_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscriptionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionMessage) ProtoMessage() {}
+
+func (x *SubscriptionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionMessage.ProtoReflect.Descriptor instead.
+func (*SubscriptionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *SubscriptionMessage) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *SubscriptionMessage) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+func (x *SubscriptionMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// A listing gone from the auction house feed, because it was "sold" or "expired"
+type AuctionListingClosedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *AuctionListingClosedMessage) Reset() {
+	*x = AuctionListingClosedMessage{}
+	mi := &file_packets_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionListingClosedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionListingClosedMessage) ProtoMessage() {}
+
+func (x *AuctionListingClosedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionListingClosedMessage.ProtoReflect.Descriptor instead.
+func (*AuctionListingClosedMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *AuctionListingClosedMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuctionListingClosedMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AnnouncementHistoryRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page  uint32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Since int64  `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *AnnouncementHistoryRequestMessage) Reset() {
+	*x = AnnouncementHistoryRequestMessage{}
+	mi := &file_packets_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnouncementHistoryRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnouncementHistoryRequestMessage) ProtoMessage() {}
+
+func (x *AnnouncementHistoryRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnouncementHistoryRequestMessage.ProtoReflect.Descriptor instead.
+func (*AnnouncementHistoryRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *AnnouncementHistoryRequestMessage) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AnnouncementHistoryRequestMessage) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type AnnouncementMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author    string `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Message   string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *AnnouncementMessage) Reset() {
+	*x = AnnouncementMessage{}
+	mi := &file_packets_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnouncementMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnouncementMessage) ProtoMessage() {}
+
+func (x *AnnouncementMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnouncementMessage.ProtoReflect.Descriptor instead.
+func (*AnnouncementMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *AnnouncementMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AnnouncementMessage) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AnnouncementMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AnnouncementMessage) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AnnouncementHistoryMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Announcements []*AnnouncementMessage `protobuf:"bytes,1,rep,name=announcements,proto3" json:"announcements,omitempty"`
+	Page          uint32                 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Pages         uint32                 `protobuf:"varint,3,opt,name=pages,proto3" json:"pages,omitempty"`
+}
+
+func (x *AnnouncementHistoryMessage) Reset() {
+	*x = AnnouncementHistoryMessage{}
+	mi := &file_packets_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnouncementHistoryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnouncementHistoryMessage) ProtoMessage() {}
+
+func (x *AnnouncementHistoryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnouncementHistoryMessage.ProtoReflect.Descriptor instead.
+func (*AnnouncementHistoryMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *AnnouncementHistoryMessage) GetAnnouncements() []*AnnouncementMessage {
+	if x != nil {
+		return x.Announcements
+	}
+	return nil
+}
+
+func (x *AnnouncementHistoryMessage) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *AnnouncementHistoryMessage) GetPages() uint32 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+type ChatSentMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ChatSentMessage) Reset() {
+	*x = ChatSentMessage{}
+	mi := &file_packets_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatSentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatSentMessage) ProtoMessage() {}
+
+func (x *ChatSentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatSentMessage.ProtoReflect.Descriptor instead.
+func (*ChatSentMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *ChatSentMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ChatReactMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId uint64 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	EmoteId   string `protobuf:"bytes,2,opt,name=emote_id,json=emoteId,proto3" json:"emote_id,omitempty"`
+	Remove    bool   `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *ChatReactMessage) Reset() {
+	*x = ChatReactMessage{}
+	mi := &file_packets_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatReactMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatReactMessage) ProtoMessage() {}
+
+func (x *ChatReactMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatReactMessage.ProtoReflect.Descriptor instead.
+func (*ChatReactMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ChatReactMessage) GetMessageId() uint64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ChatReactMessage) GetEmoteId() string {
+	if x != nil {
+		return x.EmoteId
+	}
+	return ""
+}
+
+func (x *ChatReactMessage) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type ChatReactionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageId uint64 `protobuf:"varint,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	EmoteId   string `protobuf:"bytes,2,opt,name=emote_id,json=emoteId,proto3" json:"emote_id,omitempty"`
+	Delta     int32  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	Count     uint32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ChatReactionMessage) Reset() {
+	*x = ChatReactionMessage{}
+	mi := &file_packets_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatReactionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatReactionMessage) ProtoMessage() {}
+
+func (x *ChatReactionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatReactionMessage.ProtoReflect.Descriptor instead.
+func (*ChatReactionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ChatReactionMessage) GetMessageId() uint64 {
+	if x != nil {
+		return x.MessageId
+	}
+	return 0
+}
+
+func (x *ChatReactionMessage) GetEmoteId() string {
+	if x != nil {
+		return x.EmoteId
+	}
+	return ""
+}
+
+func (x *ChatReactionMessage) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *ChatReactionMessage) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReportPlayerMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerId uint64 `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ReportPlayerMessage) Reset() {
+	*x = ReportPlayerMessage{}
+	mi := &file_packets_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPlayerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPlayerMessage) ProtoMessage() {}
+
+func (x *ReportPlayerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPlayerMessage.ProtoReflect.Descriptor instead.
+func (*ReportPlayerMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *ReportPlayerMessage) GetPlayerId() uint64 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ReportPlayerMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Stream what the server believes about an actor, our own player if actor_id is 0, or stop if not enabled. Needs the
+// server's debug overlay secret.
+type DebugOverlayRequestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Secret  string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	ActorId uint64 `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Enabled bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *DebugOverlayRequestMessage) Reset() {
+	*x = DebugOverlayRequestMessage{}
+	mi := &file_packets_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugOverlayRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugOverlayRequestMessage) ProtoMessage() {}
+
+func (x *DebugOverlayRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugOverlayRequestMessage.ProtoReflect.Descriptor instead.
+func (*DebugOverlayRequestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *DebugOverlayRequestMessage) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *DebugOverlayRequestMessage) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *DebugOverlayRequestMessage) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// Something the actor collides with, e.g. a "player" or "resource_node"
+type DebugColliderMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind   string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	X      float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y      float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius float64 `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+}
+
+func (x *DebugColliderMessage) Reset() {
+	*x = DebugColliderMessage{}
+	mi := &file_packets_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugColliderMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugColliderMessage) ProtoMessage() {}
+
+func (x *DebugColliderMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugColliderMessage.ProtoReflect.Descriptor instead.
+func (*DebugColliderMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *DebugColliderMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DebugColliderMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DebugColliderMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *DebugColliderMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *DebugColliderMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+type DebugWaypointMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float64 `protobuf:"fixed64,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *DebugWaypointMessage) Reset() {
+	*x = DebugWaypointMessage{}
+	mi := &file_packets_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugWaypointMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugWaypointMessage) ProtoMessage() {}
+
+func (x *DebugWaypointMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[121]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugWaypointMessage.ProtoReflect.Descriptor instead.
+func (*DebugWaypointMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *DebugWaypointMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *DebugWaypointMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// Something the actor's client sent that the server refused, by what it was and why
+type DebugRejectionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	At     int64  `protobuf:"varint,3,opt,name=at,proto3" json:"at,omitempty"`
+}
+
+func (x *DebugRejectionMessage) Reset() {
+	*x = DebugRejectionMessage{}
+	mi := &file_packets_proto_msgTypes[122]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugRejectionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugRejectionMessage) ProtoMessage() {}
+
+func (x *DebugRejectionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[122]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugRejectionMessage.ProtoReflect.Descriptor instead.
+func (*DebugRejectionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{122}
+}
+
+func (x *DebugRejectionMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DebugRejectionMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DebugRejectionMessage) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+// visibility is "visible", "stealthed" or "invisible". aoi_radius is how far the actor keeps up with others, and
+// interest_radius how far others keep up with it, which its size, prominence and stealth stretch or shrink.
+type DebugOverlayMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId        uint64                   `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	X              float64                  `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y              float64                  `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	Radius         float64                  `protobuf:"fixed64,4,opt,name=radius,proto3" json:"radius,omitempty"`
+	AoiRadius      float64                  `protobuf:"fixed64,5,opt,name=aoi_radius,json=aoiRadius,proto3" json:"aoi_radius,omitempty"`
+	Visibility     string                   `protobuf:"bytes,6,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	StealthRadius  float64                  `protobuf:"fixed64,7,opt,name=stealth_radius,json=stealthRadius,proto3" json:"stealth_radius,omitempty"`
+	Colliders      []*DebugColliderMessage  `protobuf:"bytes,8,rep,name=colliders,proto3" json:"colliders,omitempty"`
+	Waypoints      []*DebugWaypointMessage  `protobuf:"bytes,9,rep,name=waypoints,proto3" json:"waypoints,omitempty"`
+	Rejections     []*DebugRejectionMessage `protobuf:"bytes,10,rep,name=rejections,proto3" json:"rejections,omitempty"`
+	InterestRadius float64                  `protobuf:"fixed64,11,opt,name=interest_radius,json=interestRadius,proto3" json:"interest_radius,omitempty"`
+}
+
+func (x *DebugOverlayMessage) Reset() {
+	*x = DebugOverlayMessage{}
+	mi := &file_packets_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugOverlayMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugOverlayMessage) ProtoMessage() {}
+
+func (x *DebugOverlayMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[123]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugOverlayMessage.ProtoReflect.Descriptor instead.
+func (*DebugOverlayMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *DebugOverlayMessage) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetAoiRadius() float64 {
+	if x != nil {
+		return x.AoiRadius
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetVisibility() string {
+	if x != nil {
+		return x.Visibility
+	}
+	return ""
+}
+
+func (x *DebugOverlayMessage) GetStealthRadius() float64 {
+	if x != nil {
+		return x.StealthRadius
+	}
+	return 0
+}
+
+func (x *DebugOverlayMessage) GetColliders() []*DebugColliderMessage {
+	if x != nil {
+		return x.Colliders
+	}
+	return nil
+}
+
+func (x *DebugOverlayMessage) GetWaypoints() []*DebugWaypointMessage {
+	if x != nil {
+		return x.Waypoints
+	}
+	return nil
+}
+
+func (x *DebugOverlayMessage) GetRejections() []*DebugRejectionMessage {
+	if x != nil {
+		return x.Rejections
+	}
+	return nil
+}
+
+func (x *DebugOverlayMessage) GetInterestRadius() float64 {
+	if x != nil {
+		return x.InterestRadius
+	}
+	return 0
+}
+
+// Whether our character has looted a boss since its lockout last reset, and when it next resets, in Unix milliseconds
+type BossLockoutMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BossId   string `protobuf:"bytes,1,opt,name=boss_id,json=bossId,proto3" json:"boss_id,omitempty"`
+	Locked   bool   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"`
+	ResetsAt int64  `protobuf:"varint,3,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+}
+
+func (x *BossLockoutMessage) Reset() {
+	*x = BossLockoutMessage{}
+	mi := &file_packets_proto_msgTypes[124]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BossLockoutMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BossLockoutMessage) ProtoMessage() {}
+
+func (x *BossLockoutMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[124]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BossLockoutMessage.ProtoReflect.Descriptor instead.
+func (*BossLockoutMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{124}
+}
+
+func (x *BossLockoutMessage) GetBossId() string {
+	if x != nil {
+		return x.BossId
+	}
+	return ""
+}
+
+func (x *BossLockoutMessage) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *BossLockoutMessage) GetResetsAt() int64 {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return 0
+}
+
+// Every boss in the world, sent on entering it and whenever one's looted
+type BossLockoutsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lockouts []*BossLockoutMessage `protobuf:"bytes,1,rep,name=lockouts,proto3" json:"lockouts,omitempty"`
+}
+
+func (x *BossLockoutsMessage) Reset() {
+	*x = BossLockoutsMessage{}
+	mi := &file_packets_proto_msgTypes[125]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BossLockoutsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BossLockoutsMessage) ProtoMessage() {}
+
+func (x *BossLockoutsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[125]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BossLockoutsMessage.ProtoReflect.Descriptor instead.
+func (*BossLockoutsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{125}
+}
+
+func (x *BossLockoutsMessage) GetLockouts() []*BossLockoutMessage {
+	if x != nil {
+		return x.Lockouts
+	}
+	return nil
+}
+
+// An ambient emitter, e.g. a campfire or a waterfall, for the client to play the sound and effect of positionally
+// while it's within radius. Either of sound and effect can be empty, and volume is between 0 and 1.
+type EmitterMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind   string  `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	X      float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y      float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Radius float64 `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius,omitempty"`
+	Sound  string  `protobuf:"bytes,6,opt,name=sound,proto3" json:"sound,omitempty"`
+	Effect string  `protobuf:"bytes,7,opt,name=effect,proto3" json:"effect,omitempty"`
+	Volume float64 `protobuf:"fixed64,8,opt,name=volume,proto3" json:"volume,omitempty"`
+}
+
+func (x *EmitterMessage) Reset() {
+	*x = EmitterMessage{}
+	mi := &file_packets_proto_msgTypes[126]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmitterMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitterMessage) ProtoMessage() {}
+
+func (x *EmitterMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[126]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitterMessage.ProtoReflect.Descriptor instead.
+func (*EmitterMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{126}
+}
+
+func (x *EmitterMessage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *EmitterMessage) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *EmitterMessage) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *EmitterMessage) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *EmitterMessage) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *EmitterMessage) GetSound() string {
+	if x != nil {
+		return x.Sound
+	}
+	return ""
+}
+
+func (x *EmitterMessage) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+func (x *EmitterMessage) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+// Emitters that have come into our area of interest, or been placed in it
+type EmitterSpawnMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emitters []*EmitterMessage `protobuf:"bytes,1,rep,name=emitters,proto3" json:"emitters,omitempty"`
+}
+
+func (x *EmitterSpawnMessage) Reset() {
+	*x = EmitterSpawnMessage{}
+	mi := &file_packets_proto_msgTypes[127]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmitterSpawnMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitterSpawnMessage) ProtoMessage() {}
+
+func (x *EmitterSpawnMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[127]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitterSpawnMessage.ProtoReflect.Descriptor instead.
+func (*EmitterSpawnMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{127}
+}
+
+func (x *EmitterSpawnMessage) GetEmitters() []*EmitterMessage {
+	if x != nil {
+		return x.Emitters
+	}
+	return nil
+}
+
+// Emitters that have left our area of interest, or been removed, which should stop playing
+type EmitterDespawnMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *EmitterDespawnMessage) Reset() {
+	*x = EmitterDespawnMessage{}
+	mi := &file_packets_proto_msgTypes[128]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmitterDespawnMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmitterDespawnMessage) ProtoMessage() {}
+
+func (x *EmitterDespawnMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[128]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmitterDespawnMessage.ProtoReflect.Descriptor instead.
+func (*EmitterDespawnMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{128}
+}
+
+func (x *EmitterDespawnMessage) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// Players that have come into our area of interest, who we'll be sent updates about from now on, starting with where
+// they are, and those that have left it, who we won't be until they come back, so should be removed until then. Only
+// sent when the server's managing interest, otherwise we're sent everyone.
+type InterestMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entered []uint64 `protobuf:"varint,1,rep,packed,name=entered,proto3" json:"entered,omitempty"`
+	Left    []uint64 `protobuf:"varint,2,rep,packed,name=left,proto3" json:"left,omitempty"`
+}
+
+func (x *InterestMessage) Reset() {
+	*x = InterestMessage{}
+	mi := &file_packets_proto_msgTypes[129]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InterestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterestMessage) ProtoMessage() {}
+
+func (x *InterestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[129]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterestMessage.ProtoReflect.Descriptor instead.
+func (*InterestMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{129}
+}
+
+func (x *InterestMessage) GetEntered() []uint64 {
+	if x != nil {
+		return x.Entered
+	}
+	return nil
+}
+
+func (x *InterestMessage) GetLeft() []uint64 {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+// Sent just before the server closes our connection whenever it can be, saying why and whether and when to come back.
+// reason_code is "maintenance", "capacity", "session_replaced", "account_deleted", "logged_out", "banned", "timeout" or
+// "protocol_error", disconnect_reason is the kind of reason it is, and reason is for showing the player. If reconnect
+// is set, wait retry_after_seconds, which is already spread out so every kicked client doesn't come back at once, then
+// connect to alternate_endpoint if it's set or here again otherwise.
+type KickMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReasonCode        string           `protobuf:"bytes,1,opt,name=reason_code,json=reasonCode,proto3" json:"reason_code,omitempty"`
+	Reason            string           `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reconnect         bool             `protobuf:"varint,3,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
+	RetryAfterSeconds uint32           `protobuf:"varint,4,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	AlternateEndpoint string           `protobuf:"bytes,5,opt,name=alternate_endpoint,json=alternateEndpoint,proto3" json:"alternate_endpoint,omitempty"`
+	DisconnectReason  DisconnectReason `protobuf:"varint,6,opt,name=disconnect_reason,json=disconnectReason,proto3,enum=packets.DisconnectReason" json:"disconnect_reason,omitempty"`
+}
+
+func (x *KickMessage) Reset() {
+	*x = KickMessage{}
+	mi := &file_packets_proto_msgTypes[130]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KickMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KickMessage) ProtoMessage() {}
+
+func (x *KickMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[130]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KickMessage.ProtoReflect.Descriptor instead.
+func (*KickMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{130}
+}
+
+func (x *KickMessage) GetReasonCode() string {
+	if x != nil {
+		return x.ReasonCode
+	}
+	return ""
+}
+
+func (x *KickMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *KickMessage) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *KickMessage) GetRetryAfterSeconds() uint32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+func (x *KickMessage) GetAlternateEndpoint() string {
+	if x != nil {
+		return x.AlternateEndpoint
+	}
+	return ""
+}
+
+func (x *KickMessage) GetDisconnectReason() DisconnectReason {
+	if x != nil {
+		return x.DisconnectReason
+	}
+	return DisconnectReason_DISCONNECT_REASON_UNSPECIFIED
+}
+
+// Sent instead of an answer to a login or register request that looks suspicious, e.g. from somewhere the account
+// hasn't logged in from before. Show the provider's ("hcaptcha" or "turnstile") widget with the site key, then send its
+// token back in a captcha response, after which the request carries on as if it had just been sent.
+type CaptchaChallengeMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	SiteKey  string `protobuf:"bytes,2,opt,name=site_key,json=siteKey,proto3" json:"site_key,omitempty"`
+}
+
+func (x *CaptchaChallengeMessage) Reset() {
+	*x = CaptchaChallengeMessage{}
+	mi := &file_packets_proto_msgTypes[131]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptchaChallengeMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptchaChallengeMessage) ProtoMessage() {}
+
+func (x *CaptchaChallengeMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[131]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptchaChallengeMessage.ProtoReflect.Descriptor instead.
+func (*CaptchaChallengeMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{131}
+}
+
+func (x *CaptchaChallengeMessage) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *CaptchaChallengeMessage) GetSiteKey() string {
+	if x != nil {
+		return x.SiteKey
+	}
+	return ""
+}
+
+type CaptchaResponseMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *CaptchaResponseMessage) Reset() {
+	*x = CaptchaResponseMessage{}
+	mi := &file_packets_proto_msgTypes[132]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptchaResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptchaResponseMessage) ProtoMessage() {}
+
+func (x *CaptchaResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[132]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptchaResponseMessage.ProtoReflect.Descriptor instead.
+func (*CaptchaResponseMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{132}
+}
+
+func (x *CaptchaResponseMessage) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// From a world simulator: a player damaged an actor it drives, which counts towards their share of the kill
+type ActorDamageMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId    uint64  `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	AttackerId uint64  `protobuf:"varint,2,opt,name=attacker_id,json=attackerId,proto3" json:"attacker_id,omitempty"`
+	Amount     float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *ActorDamageMessage) Reset() {
+	*x = ActorDamageMessage{}
+	mi := &file_packets_proto_msgTypes[133]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorDamageMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorDamageMessage) ProtoMessage() {}
+
+func (x *ActorDamageMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[133]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorDamageMessage.ProtoReflect.Descriptor instead.
+func (*ActorDamageMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{133}
+}
+
+func (x *ActorDamageMessage) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *ActorDamageMessage) GetAttackerId() uint64 {
+	if x != nil {
+		return x.AttackerId
+	}
+	return 0
+}
+
+func (x *ActorDamageMessage) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// From a world simulator: an actor it drives died, so it's despawned and the kill reward it names, if any, is shared
+// between the players who damaged it lately by how much of the damage they did
+type ActorKilledMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId  uint64 `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	RewardId string `protobuf:"bytes,2,opt,name=reward_id,json=rewardId,proto3" json:"reward_id,omitempty"`
+}
+
+func (x *ActorKilledMessage) Reset() {
+	*x = ActorKilledMessage{}
+	mi := &file_packets_proto_msgTypes[134]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActorKilledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActorKilledMessage) ProtoMessage() {}
+
+func (x *ActorKilledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[134]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActorKilledMessage.ProtoReflect.Descriptor instead.
+func (*ActorKilledMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{134}
+}
+
+func (x *ActorKilledMessage) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *ActorKilledMessage) GetRewardId() string {
+	if x != nil {
+		return x.RewardId
+	}
+	return ""
+}
+
+// Our share of a kill: how much of the damage we did, between 0 and 1, and what it earned us. Items and quest stages
+// are only given to those with a big enough share.
+type KillCreditMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId     uint64               `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	Share       float64              `protobuf:"fixed64,2,opt,name=share,proto3" json:"share,omitempty"`
+	Mass        float64              `protobuf:"fixed64,3,opt,name=mass,proto3" json:"mass,omitempty"`
+	Items       []*ItemStackMessage  `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	QuestStages []*QuestStageMessage `protobuf:"bytes,5,rep,name=quest_stages,json=questStages,proto3" json:"quest_stages,omitempty"`
+}
+
+func (x *KillCreditMessage) Reset() {
+	*x = KillCreditMessage{}
+	mi := &file_packets_proto_msgTypes[135]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KillCreditMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KillCreditMessage) ProtoMessage() {}
+
+func (x *KillCreditMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[135]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KillCreditMessage.ProtoReflect.Descriptor instead.
+func (*KillCreditMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{135}
+}
+
+func (x *KillCreditMessage) GetActorId() uint64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *KillCreditMessage) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+func (x *KillCreditMessage) GetMass() float64 {
+	if x != nil {
+		return x.Mass
+	}
+	return 0
+}
+
+func (x *KillCreditMessage) GetItems() []*ItemStackMessage {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *KillCreditMessage) GetQuestStages() []*QuestStageMessage {
+	if x != nil {
+		return x.QuestStages
+	}
+	return nil
+}
+
+// One time our account was logged in: from where, on what, and when, with ended_at 0 if it's still going. current is
+// the session being sent it. Only the network the IP was on is kept, e.g. 203.0.113.0/24, so that's all ip is.
+type SessionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ip          string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Device      string `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`
+	ClientBuild uint32 `protobuf:"varint,4,opt,name=client_build,json=clientBuild,proto3" json:"client_build,omitempty"`
+	LoggedInAt  int64  `protobuf:"varint,5,opt,name=logged_in_at,json=loggedInAt,proto3" json:"logged_in_at,omitempty"`
+	EndedAt     int64  `protobuf:"varint,6,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	Current     bool   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`
+}
+
+func (x *SessionMessage) Reset() {
+	*x = SessionMessage{}
+	mi := &file_packets_proto_msgTypes[136]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionMessage) ProtoMessage() {}
+
+func (x *SessionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[136]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionMessage.ProtoReflect.Descriptor instead.
+func (*SessionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{136}
+}
+
+func (x *SessionMessage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SessionMessage) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionMessage) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *SessionMessage) GetClientBuild() uint32 {
+	if x != nil {
+		return x.ClientBuild
+	}
+	return 0
+}
+
+func (x *SessionMessage) GetLoggedInAt() int64 {
+	if x != nil {
+		return x.LoggedInAt
+	}
+	return 0
+}
+
+func (x *SessionMessage) GetEndedAt() int64 {
+	if x != nil {
+		return x.EndedAt
+	}
+	return 0
+}
+
+func (x *SessionMessage) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+// Ask for our account's sessions lately, most recent first, which are sent back in a sessions message
+type ListSessionsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListSessionsMessage) Reset() {
+	*x = ListSessionsMessage{}
+	mi := &file_packets_proto_msgTypes[137]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsMessage) ProtoMessage() {}
+
+func (x *ListSessionsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[137]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsMessage.ProtoReflect.Descriptor instead.
+func (*ListSessionsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{137}
+}
+
+type SessionsMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*SessionMessage `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *SessionsMessage) Reset() {
+	*x = SessionsMessage{}
+	mi := &file_packets_proto_msgTypes[138]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionsMessage) ProtoMessage() {}
+
+func (x *SessionsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[138]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionsMessage.ProtoReflect.Descriptor instead.
+func (*SessionsMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{138}
+}
+
+func (x *SessionsMessage) GetSessions() []*SessionMessage {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+// Log out one of our account's other sessions, or all of them, after which the sessions are sent again
+type LogoutSessionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId int64 `protobuf:"varint,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	AllOthers bool  `protobuf:"varint,2,opt,name=all_others,json=allOthers,proto3" json:"all_others,omitempty"`
+}
+
+func (x *LogoutSessionMessage) Reset() {
+	*x = LogoutSessionMessage{}
+	mi := &file_packets_proto_msgTypes[139]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutSessionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutSessionMessage) ProtoMessage() {}
+
+func (x *LogoutSessionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[139]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutSessionMessage.ProtoReflect.Descriptor instead.
+func (*LogoutSessionMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{139}
+}
+
+func (x *LogoutSessionMessage) GetSessionId() int64 {
+	if x != nil {
+		return x.SessionId
+	}
+	return 0
+}
+
+func (x *LogoutSessionMessage) GetAllOthers() bool {
+	if x != nil {
+		return x.AllOthers
+	}
+	return false
+}
+
+// Our account was just logged in from an IP or device it hasn't been before
+type NewLoginMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Session   *SessionMessage `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	NewIp     bool            `protobuf:"varint,2,opt,name=new_ip,json=newIp,proto3" json:"new_ip,omitempty"`
+	NewDevice bool            `protobuf:"varint,3,opt,name=new_device,json=newDevice,proto3" json:"new_device,omitempty"`
+}
+
+func (x *NewLoginMessage) Reset() {
+	*x = NewLoginMessage{}
+	mi := &file_packets_proto_msgTypes[140]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewLoginMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewLoginMessage) ProtoMessage() {}
+
+func (x *NewLoginMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[140]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewLoginMessage.ProtoReflect.Descriptor instead.
+func (*NewLoginMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{140}
+}
+
+func (x *NewLoginMessage) GetSession() *SessionMessage {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *NewLoginMessage) GetNewIp() bool {
+	if x != nil {
+		return x.NewIp
+	}
+	return false
+}
+
+func (x *NewLoginMessage) GetNewDevice() bool {
+	if x != nil {
+		return x.NewDevice
+	}
+	return false
+}
+
+// A GM command for the sandbox, from an account with the entitlement a sandbox zone requires: "enter" or "leave" it,
+// or while in it, "wave" count [radius] [name] to spawn actors around us, "give" item_id quantity, "scenario" id to
+// run a scripted scenario, or "clear" to despawn everything we've spawned and take back everything we've been given
+type SandboxCommandMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Command string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+}
+
+func (x *SandboxCommandMessage) Reset() {
+	*x = SandboxCommandMessage{}
+	mi := &file_packets_proto_msgTypes[141]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SandboxCommandMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxCommandMessage) ProtoMessage() {}
+
+func (x *SandboxCommandMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[141]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxCommandMessage.ProtoReflect.Descriptor instead.
+func (*SandboxCommandMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{141}
+}
+
+func (x *SandboxCommandMessage) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *SandboxCommandMessage) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// After each sandbox command: whether we're in the sandbox, how many actors we've spawned in it, which scenarios can be
+// run, and what the command did
+type SandboxStatusMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inside    bool     `protobuf:"varint,1,opt,name=inside,proto3" json:"inside,omitempty"`
+	ZoneId    string   `protobuf:"bytes,2,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Actors    uint32   `protobuf:"varint,3,opt,name=actors,proto3" json:"actors,omitempty"`
+	Scenarios []string `protobuf:"bytes,4,rep,name=scenarios,proto3" json:"scenarios,omitempty"`
+	Message   string   `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *SandboxStatusMessage) Reset() {
+	*x = SandboxStatusMessage{}
+	mi := &file_packets_proto_msgTypes[142]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SandboxStatusMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxStatusMessage) ProtoMessage() {}
+
+func (x *SandboxStatusMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[142]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxStatusMessage.ProtoReflect.Descriptor instead.
+func (*SandboxStatusMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{142}
+}
+
+func (x *SandboxStatusMessage) GetInside() bool {
+	if x != nil {
+		return x.Inside
+	}
+	return false
+}
+
+func (x *SandboxStatusMessage) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *SandboxStatusMessage) GetActors() uint32 {
+	if x != nil {
+		return x.Actors
+	}
+	return 0
+}
+
+func (x *SandboxStatusMessage) GetScenarios() []string {
+	if x != nil {
+		return x.Scenarios
+	}
+	return nil
+}
+
+func (x *SandboxStatusMessage) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type MinimapMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ZoneId           string                    `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	ZoneName         string                    `protobuf:"bytes,2,opt,name=zone_name,json=zoneName,proto3" json:"zone_name,omitempty"`
+	MinX             float64                   `protobuf:"fixed64,3,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY             float64                   `protobuf:"fixed64,4,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX             float64                   `protobuf:"fixed64,5,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY             float64                   `protobuf:"fixed64,6,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	Width            uint32                    `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height           uint32                    `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	Cells            []byte                    `protobuf:"bytes,9,opt,name=cells,proto3" json:"cells,omitempty"`
+	PointsOfInterest []*PointOfInterestMessage `protobuf:"bytes,10,rep,name=points_of_interest,json=pointsOfInterest,proto3" json:"points_of_interest,omitempty"`
+}
+
+func (x *MinimapMessage) Reset() {
+	*x = MinimapMessage{}
+	mi := &file_packets_proto_msgTypes[143]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinimapMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinimapMessage) ProtoMessage() {}
+
+func (x *MinimapMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[143]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinimapMessage.ProtoReflect.Descriptor instead.
+func (*MinimapMessage) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{143}
+}
+
+func (x *MinimapMessage) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *MinimapMessage) GetZoneName() string {
+	if x != nil {
+		return x.ZoneName
+	}
+	return ""
+}
+
+func (x *MinimapMessage) GetMinX() float64 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetMinY() float64 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetMaxX() float64 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetMaxY() float64 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MinimapMessage) GetCells() []byte {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+func (x *MinimapMessage) GetPointsOfInterest() []*PointOfInterestMessage {
+	if x != nil {
+		return x.PointsOfInterest
+	}
+	return nil
+}
+
+type Packet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SenderId uint64 `protobuf:"varint,1,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	// Types that are assignable to Msg:
+	//
+	//	*Packet_Chat
+	//	*Packet_Id
+	//	*Packet_LoginRequest
+	//	*Packet_RegisterRequest
+	//	*Packet_OkResponse
+	//	*Packet_DenyResponse
+	//	*Packet_Player
+	//	*Packet_PlayerDirection
+	//	*Packet_Spore
+	//	*Packet_SporeConsumed
+	//	*Packet_SporesBatch
+	//	*Packet_PlayerConsumed
+	//	*Packet_HiscoreBoardRequest
+	//	*Packet_Hiscore
+	//	*Packet_HiscoreBoard
+	//	*Packet_FinishedBrowsingHiscores
+	//	*Packet_SearchHiscore
+	//	*Packet_Disconnect
+	//	*Packet_TimeSyncRequest
+	//	*Packet_TimeSyncResponse
+	//	*Packet_PresenceSubscribe
+	//	*Packet_PresenceUnsubscribe
+	//	*Packet_Presence
+	//	*Packet_ResourceNode
+	//	*Packet_ResourceNodesBatch
+	//	*Packet_GatherStart
+	//	*Packet_GatherFinish
+	//	*Packet_Inventory
+	//	*Packet_CraftRequest
+	//	*Packet_BlockPlayer
+	//	*Packet_UnblockPlayer
+	//	*Packet_BlockList
+	//	*Packet_MinimapRequest
+	//	*Packet_Minimap
+	//	*Packet_OnboardingStep
+	//	*Packet_CompleteOnboardingStep
+	//	*Packet_Emotes
+	//	*Packet_EmoteUnlocked
+	//	*Packet_Custom
+	//	*Packet_AuctionListRequest
+	//	*Packet_AuctionSearchRequest
+	//	*Packet_AuctionSearchResults
+	//	*Packet_AuctionBuyout
+	//	*Packet_MailboxRequest
+	//	*Packet_Mailbox
+	//	*Packet_ClaimMail
+	//	*Packet_ImpersonationRequest
+	//	*Packet_ImpersonationConsent
+	//	*Packet_ImpersonationStatus
+	//	*Packet_FeatureFlagsRequest
+	//	*Packet_FeatureFlags
+	//	*Packet_ChannelListRequest
+	//	*Packet_ChannelList
+	//	*Packet_ChannelSwitch
+	//	*Packet_RollCommit
+	//	*Packet_RollReveal
+	//	*Packet_VoiceSignal
+	//	*Packet_VoiceParty
+	//	*Packet_VoiceStatus
+	//	*Packet_DataExportRequest
+	//	*Packet_AccountDeletionRequest
+	//	*Packet_AccountDeletionCancel
+	//	*Packet_AccountDataStatusRequest
+	//	*Packet_AccountDataStatus
+	//	*Packet_FileOffer
+	//	*Packet_FileChunk
+	//	*Packet_FileReceived
+	//	*Packet_StoreRequest
+	//	*Packet_Store
+	//	*Packet_StorePurchase
+	//	*Packet_StoreReceipt
+	//	*Packet_StoreReceiptStatus
+	//	*Packet_DataPack
+	//	*Packet_Interact
+	//	*Packet_InteractionResult
+	//	*Packet_Prompt
+	//	*Packet_PromptResponse
+	//	*Packet_PromptClosed
+	//	*Packet_MoveTo
+	//	*Packet_EntitlementListRequest
+	//	*Packet_EntitlementList
+	//	*Packet_EnvironmentCue
+	//	*Packet_ClientUpdate
+	//	*Packet_SimulatorLoginRequest
+	//	*Packet_ActorSpawnRequest
+	//	*Packet_ActorSpawnResponse
+	//	*Packet_ActorUpdates
+	//	*Packet_ActorDespawn
+	//	*Packet_Dialog
+	//	*Packet_DialogChoose
+	//	*Packet_DialogClosed
+	//	*Packet_QuestStage
+	//	*Packet_Subscribe
+	//	*Packet_Unsubscribe
+	//	*Packet_Subscription
+	//	*Packet_AuctionListing
+	//	*Packet_AuctionListingClosed
+	//	*Packet_AnnouncementHistoryRequest
+	//	*Packet_AnnouncementHistory
+	//	*Packet_ChatSent
+	//	*Packet_ChatReact
+	//	*Packet_ChatReaction
+	//	*Packet_ReportPlayer
+	//	*Packet_DebugOverlayRequest
+	//	*Packet_DebugOverlay
+	//	*Packet_BossLockouts
+	//	*Packet_EmitterSpawn
+	//	*Packet_EmitterDespawn
+	//	*Packet_Kick
+	//	*Packet_CaptchaChallenge
+	//	*Packet_CaptchaResponse
+	//	*Packet_ActorDamage
+	//	*Packet_ActorKilled
+	//	*Packet_KillCredit
+	//	*Packet_ListSessions
+	//	*Packet_Sessions
+	//	*Packet_LogoutSession
+	//	*Packet_NewLogin
+	//	*Packet_SandboxCommand
+	//	*Packet_SandboxStatus
+	//	*Packet_IceServersRequest
+	//	*Packet_IceServers
+	//	*Packet_Interest
+	//	*Packet_LoginToken
+	Msg isPacket_Msg `protobuf_oneof:"msg"`
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
+	mi := &file_packets_proto_msgTypes[144]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Packet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet) ProtoMessage() {}
+
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_packets_proto_msgTypes[144]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_packets_proto_rawDescGZIP(), []int{144}
+}
+
+func (x *Packet) GetSenderId() uint64 {
+	if x != nil {
+		return x.SenderId
+	}
+	return 0
+}
+
+func (m *Packet) GetMsg() isPacket_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (x *Packet) GetChat() *ChatMessage {
+	if x, ok := x.GetMsg().(*Packet_Chat); ok {
+		return x.Chat
+	}
+	return nil
+}
+
+func (x *Packet) GetId() *IdMessage {
+	if x, ok := x.GetMsg().(*Packet_Id); ok {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_LoginRequest); ok {
+		return x.LoginRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_RegisterRequest); ok {
+		return x.RegisterRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetOkResponse() *OkResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_OkResponse); ok {
+		return x.OkResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetDenyResponse() *DenyResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_DenyResponse); ok {
+		return x.DenyResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayer() *PlayerMessage {
+	if x, ok := x.GetMsg().(*Packet_Player); ok {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
+	if x, ok := x.GetMsg().(*Packet_PlayerDirection); ok {
+		return x.PlayerDirection
+	}
+	return nil
+}
+
+func (x *Packet) GetSpore() *SporeMessage {
+	if x, ok := x.GetMsg().(*Packet_Spore); ok {
+		return x.Spore
+	}
+	return nil
+}
+
+func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
+	if x, ok := x.GetMsg().(*Packet_SporeConsumed); ok {
+		return x.SporeConsumed
+	}
+	return nil
+}
+
+func (x *Packet) GetSporesBatch() *SporesBatchMessage {
+	if x, ok := x.GetMsg().(*Packet_SporesBatch); ok {
+		return x.SporesBatch
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
+	if x, ok := x.GetMsg().(*Packet_PlayerConsumed); ok {
+		return x.PlayerConsumed
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscoreBoardRequest() *HiscoreBoardRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_HiscoreBoardRequest); ok {
+		return x.HiscoreBoardRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscore() *HiscoreMessage {
+	if x, ok := x.GetMsg().(*Packet_Hiscore); ok {
+		return x.Hiscore
+	}
+	return nil
+}
+
+func (x *Packet) GetHiscoreBoard() *HiscoreBoardMessage {
+	if x, ok := x.GetMsg().(*Packet_HiscoreBoard); ok {
+		return x.HiscoreBoard
+	}
+	return nil
+}
+
+func (x *Packet) GetFinishedBrowsingHiscores() *FinishedBrowsingHiscoresMessage {
+	if x, ok := x.GetMsg().(*Packet_FinishedBrowsingHiscores); ok {
+		return x.FinishedBrowsingHiscores
+	}
+	return nil
+}
+
+func (x *Packet) GetSearchHiscore() *SearchHiscoreMessage {
+	if x, ok := x.GetMsg().(*Packet_SearchHiscore); ok {
+		return x.SearchHiscore
+	}
+	return nil
+}
+
+func (x *Packet) GetDisconnect() *DisconnectMessage {
+	if x, ok := x.GetMsg().(*Packet_Disconnect); ok {
+		return x.Disconnect
+	}
+	return nil
+}
+
+func (x *Packet) GetTimeSyncRequest() *TimeSyncRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_TimeSyncRequest); ok {
+		return x.TimeSyncRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetTimeSyncResponse() *TimeSyncResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_TimeSyncResponse); ok {
+		return x.TimeSyncResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetPresenceSubscribe() *PresenceSubscribeMessage {
+	if x, ok := x.GetMsg().(*Packet_PresenceSubscribe); ok {
+		return x.PresenceSubscribe
+	}
+	return nil
+}
+
+func (x *Packet) GetPresenceUnsubscribe() *PresenceUnsubscribeMessage {
+	if x, ok := x.GetMsg().(*Packet_PresenceUnsubscribe); ok {
+		return x.PresenceUnsubscribe
+	}
+	return nil
+}
+
+func (x *Packet) GetPresence() *PresenceMessage {
+	if x, ok := x.GetMsg().(*Packet_Presence); ok {
+		return x.Presence
+	}
+	return nil
+}
+
+func (x *Packet) GetResourceNode() *ResourceNodeMessage {
+	if x, ok := x.GetMsg().(*Packet_ResourceNode); ok {
+		return x.ResourceNode
+	}
+	return nil
+}
+
+func (x *Packet) GetResourceNodesBatch() *ResourceNodesBatchMessage {
+	if x, ok := x.GetMsg().(*Packet_ResourceNodesBatch); ok {
+		return x.ResourceNodesBatch
+	}
+	return nil
+}
+
+func (x *Packet) GetGatherStart() *GatherStartMessage {
+	if x, ok := x.GetMsg().(*Packet_GatherStart); ok {
+		return x.GatherStart
+	}
+	return nil
+}
+
+func (x *Packet) GetGatherFinish() *GatherFinishMessage {
+	if x, ok := x.GetMsg().(*Packet_GatherFinish); ok {
+		return x.GatherFinish
+	}
+	return nil
+}
+
+func (x *Packet) GetInventory() *InventoryMessage {
+	if x, ok := x.GetMsg().(*Packet_Inventory); ok {
+		return x.Inventory
+	}
+	return nil
+}
+
+func (x *Packet) GetCraftRequest() *CraftRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_CraftRequest); ok {
+		return x.CraftRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetBlockPlayer() *BlockPlayerMessage {
+	if x, ok := x.GetMsg().(*Packet_BlockPlayer); ok {
+		return x.BlockPlayer
+	}
+	return nil
+}
+
+func (x *Packet) GetUnblockPlayer() *UnblockPlayerMessage {
+	if x, ok := x.GetMsg().(*Packet_UnblockPlayer); ok {
+		return x.UnblockPlayer
+	}
+	return nil
+}
+
+func (x *Packet) GetBlockList() *BlockListMessage {
+	if x, ok := x.GetMsg().(*Packet_BlockList); ok {
+		return x.BlockList
+	}
+	return nil
+}
+
+func (x *Packet) GetMinimapRequest() *MinimapRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_MinimapRequest); ok {
+		return x.MinimapRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetMinimap() *MinimapMessage {
+	if x, ok := x.GetMsg().(*Packet_Minimap); ok {
+		return x.Minimap
+	}
+	return nil
+}
+
+func (x *Packet) GetOnboardingStep() *OnboardingStepMessage {
+	if x, ok := x.GetMsg().(*Packet_OnboardingStep); ok {
+		return x.OnboardingStep
+	}
+	return nil
+}
+
+func (x *Packet) GetCompleteOnboardingStep() *CompleteOnboardingStepMessage {
+	if x, ok := x.GetMsg().(*Packet_CompleteOnboardingStep); ok {
+		return x.CompleteOnboardingStep
+	}
+	return nil
+}
+
+func (x *Packet) GetEmotes() *EmotesMessage {
+	if x, ok := x.GetMsg().(*Packet_Emotes); ok {
+		return x.Emotes
+	}
+	return nil
+}
+
+func (x *Packet) GetEmoteUnlocked() *EmoteUnlockedMessage {
+	if x, ok := x.GetMsg().(*Packet_EmoteUnlocked); ok {
+		return x.EmoteUnlocked
+	}
+	return nil
+}
+
+func (x *Packet) GetCustom() *CustomMessage {
+	if x, ok := x.GetMsg().(*Packet_Custom); ok {
+		return x.Custom
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionListRequest() *AuctionListRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionListRequest); ok {
+		return x.AuctionListRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionSearchRequest() *AuctionSearchRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionSearchRequest); ok {
+		return x.AuctionSearchRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionSearchResults() *AuctionSearchResultsMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionSearchResults); ok {
+		return x.AuctionSearchResults
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionBuyout() *AuctionBuyoutMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionBuyout); ok {
+		return x.AuctionBuyout
+	}
+	return nil
+}
+
+func (x *Packet) GetMailboxRequest() *MailboxRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_MailboxRequest); ok {
+		return x.MailboxRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetMailbox() *MailboxMessage {
+	if x, ok := x.GetMsg().(*Packet_Mailbox); ok {
+		return x.Mailbox
+	}
+	return nil
+}
+
+func (x *Packet) GetClaimMail() *ClaimMailMessage {
+	if x, ok := x.GetMsg().(*Packet_ClaimMail); ok {
+		return x.ClaimMail
+	}
+	return nil
+}
+
+func (x *Packet) GetImpersonationRequest() *ImpersonationRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_ImpersonationRequest); ok {
+		return x.ImpersonationRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetImpersonationConsent() *ImpersonationConsentMessage {
+	if x, ok := x.GetMsg().(*Packet_ImpersonationConsent); ok {
+		return x.ImpersonationConsent
+	}
+	return nil
+}
+
+func (x *Packet) GetImpersonationStatus() *ImpersonationStatusMessage {
+	if x, ok := x.GetMsg().(*Packet_ImpersonationStatus); ok {
+		return x.ImpersonationStatus
+	}
+	return nil
+}
+
+func (x *Packet) GetFeatureFlagsRequest() *FeatureFlagsRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_FeatureFlagsRequest); ok {
+		return x.FeatureFlagsRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetFeatureFlags() *FeatureFlagsMessage {
+	if x, ok := x.GetMsg().(*Packet_FeatureFlags); ok {
+		return x.FeatureFlags
+	}
+	return nil
+}
+
+func (x *Packet) GetChannelListRequest() *ChannelListRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_ChannelListRequest); ok {
+		return x.ChannelListRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetChannelList() *ChannelListMessage {
+	if x, ok := x.GetMsg().(*Packet_ChannelList); ok {
+		return x.ChannelList
+	}
+	return nil
+}
+
+func (x *Packet) GetChannelSwitch() *ChannelSwitchMessage {
+	if x, ok := x.GetMsg().(*Packet_ChannelSwitch); ok {
+		return x.ChannelSwitch
+	}
+	return nil
+}
+
+func (x *Packet) GetRollCommit() *RollCommitMessage {
+	if x, ok := x.GetMsg().(*Packet_RollCommit); ok {
+		return x.RollCommit
+	}
+	return nil
+}
+
+func (x *Packet) GetRollReveal() *RollRevealMessage {
+	if x, ok := x.GetMsg().(*Packet_RollReveal); ok {
+		return x.RollReveal
+	}
+	return nil
+}
+
+func (x *Packet) GetVoiceSignal() *VoiceSignalMessage {
+	if x, ok := x.GetMsg().(*Packet_VoiceSignal); ok {
+		return x.VoiceSignal
+	}
+	return nil
+}
+
+func (x *Packet) GetVoiceParty() *VoicePartyMessage {
+	if x, ok := x.GetMsg().(*Packet_VoiceParty); ok {
+		return x.VoiceParty
+	}
+	return nil
+}
+
+func (x *Packet) GetVoiceStatus() *VoiceStatusMessage {
+	if x, ok := x.GetMsg().(*Packet_VoiceStatus); ok {
+		return x.VoiceStatus
+	}
+	return nil
+}
+
+func (x *Packet) GetDataExportRequest() *DataExportRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_DataExportRequest); ok {
+		return x.DataExportRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAccountDeletionRequest() *AccountDeletionRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_AccountDeletionRequest); ok {
+		return x.AccountDeletionRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAccountDeletionCancel() *AccountDeletionCancelMessage {
+	if x, ok := x.GetMsg().(*Packet_AccountDeletionCancel); ok {
+		return x.AccountDeletionCancel
+	}
+	return nil
+}
+
+func (x *Packet) GetAccountDataStatusRequest() *AccountDataStatusRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_AccountDataStatusRequest); ok {
+		return x.AccountDataStatusRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAccountDataStatus() *AccountDataStatusMessage {
+	if x, ok := x.GetMsg().(*Packet_AccountDataStatus); ok {
+		return x.AccountDataStatus
+	}
+	return nil
+}
+
+func (x *Packet) GetFileOffer() *FileOfferMessage {
+	if x, ok := x.GetMsg().(*Packet_FileOffer); ok {
+		return x.FileOffer
+	}
+	return nil
+}
+
+func (x *Packet) GetFileChunk() *FileChunkMessage {
+	if x, ok := x.GetMsg().(*Packet_FileChunk); ok {
+		return x.FileChunk
+	}
+	return nil
+}
+
+func (x *Packet) GetFileReceived() *FileReceivedMessage {
+	if x, ok := x.GetMsg().(*Packet_FileReceived); ok {
+		return x.FileReceived
+	}
+	return nil
+}
+
+func (x *Packet) GetStoreRequest() *StoreRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_StoreRequest); ok {
+		return x.StoreRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetStore() *StoreMessage {
+	if x, ok := x.GetMsg().(*Packet_Store); ok {
+		return x.Store
+	}
+	return nil
+}
+
+func (x *Packet) GetStorePurchase() *StorePurchaseMessage {
+	if x, ok := x.GetMsg().(*Packet_StorePurchase); ok {
+		return x.StorePurchase
+	}
+	return nil
+}
+
+func (x *Packet) GetStoreReceipt() *StoreReceiptMessage {
+	if x, ok := x.GetMsg().(*Packet_StoreReceipt); ok {
+		return x.StoreReceipt
+	}
+	return nil
+}
+
+func (x *Packet) GetStoreReceiptStatus() *StoreReceiptStatusMessage {
+	if x, ok := x.GetMsg().(*Packet_StoreReceiptStatus); ok {
+		return x.StoreReceiptStatus
+	}
+	return nil
+}
+
+func (x *Packet) GetDataPack() *DataPackMessage {
+	if x, ok := x.GetMsg().(*Packet_DataPack); ok {
+		return x.DataPack
+	}
+	return nil
+}
+
+func (x *Packet) GetInteract() *InteractMessage {
+	if x, ok := x.GetMsg().(*Packet_Interact); ok {
+		return x.Interact
+	}
+	return nil
+}
+
+func (x *Packet) GetInteractionResult() *InteractionResultMessage {
+	if x, ok := x.GetMsg().(*Packet_InteractionResult); ok {
+		return x.InteractionResult
+	}
+	return nil
+}
+
+func (x *Packet) GetPrompt() *PromptMessage {
+	if x, ok := x.GetMsg().(*Packet_Prompt); ok {
+		return x.Prompt
+	}
+	return nil
+}
+
+func (x *Packet) GetPromptResponse() *PromptResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_PromptResponse); ok {
+		return x.PromptResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetPromptClosed() *PromptClosedMessage {
+	if x, ok := x.GetMsg().(*Packet_PromptClosed); ok {
+		return x.PromptClosed
+	}
+	return nil
+}
+
+func (x *Packet) GetMoveTo() *MoveToMessage {
+	if x, ok := x.GetMsg().(*Packet_MoveTo); ok {
+		return x.MoveTo
+	}
+	return nil
+}
+
+func (x *Packet) GetEntitlementListRequest() *EntitlementListRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_EntitlementListRequest); ok {
+		return x.EntitlementListRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetEntitlementList() *EntitlementListMessage {
+	if x, ok := x.GetMsg().(*Packet_EntitlementList); ok {
+		return x.EntitlementList
+	}
+	return nil
+}
+
+func (x *Packet) GetEnvironmentCue() *EnvironmentCueMessage {
+	if x, ok := x.GetMsg().(*Packet_EnvironmentCue); ok {
+		return x.EnvironmentCue
+	}
+	return nil
+}
+
+func (x *Packet) GetClientUpdate() *ClientUpdateMessage {
+	if x, ok := x.GetMsg().(*Packet_ClientUpdate); ok {
+		return x.ClientUpdate
+	}
+	return nil
+}
+
+func (x *Packet) GetSimulatorLoginRequest() *SimulatorLoginRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_SimulatorLoginRequest); ok {
+		return x.SimulatorLoginRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetActorSpawnRequest() *ActorSpawnRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorSpawnRequest); ok {
+		return x.ActorSpawnRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetActorSpawnResponse() *ActorSpawnResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorSpawnResponse); ok {
+		return x.ActorSpawnResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetActorUpdates() *ActorUpdatesMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorUpdates); ok {
+		return x.ActorUpdates
+	}
+	return nil
+}
+
+func (x *Packet) GetActorDespawn() *ActorDespawnMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorDespawn); ok {
+		return x.ActorDespawn
+	}
+	return nil
+}
+
+func (x *Packet) GetDialog() *DialogMessage {
+	if x, ok := x.GetMsg().(*Packet_Dialog); ok {
+		return x.Dialog
+	}
+	return nil
+}
+
+func (x *Packet) GetDialogChoose() *DialogChooseMessage {
+	if x, ok := x.GetMsg().(*Packet_DialogChoose); ok {
+		return x.DialogChoose
+	}
+	return nil
+}
+
+func (x *Packet) GetDialogClosed() *DialogClosedMessage {
+	if x, ok := x.GetMsg().(*Packet_DialogClosed); ok {
+		return x.DialogClosed
+	}
+	return nil
+}
+
+func (x *Packet) GetQuestStage() *QuestStageMessage {
+	if x, ok := x.GetMsg().(*Packet_QuestStage); ok {
+		return x.QuestStage
+	}
+	return nil
+}
+
+func (x *Packet) GetSubscribe() *SubscribeMessage {
+	if x, ok := x.GetMsg().(*Packet_Subscribe); ok {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *Packet) GetUnsubscribe() *UnsubscribeMessage {
+	if x, ok := x.GetMsg().(*Packet_Unsubscribe); ok {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+func (x *Packet) GetSubscription() *SubscriptionMessage {
+	if x, ok := x.GetMsg().(*Packet_Subscription); ok {
+		return x.Subscription
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionListing() *AuctionListingMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionListing); ok {
+		return x.AuctionListing
+	}
+	return nil
+}
+
+func (x *Packet) GetAuctionListingClosed() *AuctionListingClosedMessage {
+	if x, ok := x.GetMsg().(*Packet_AuctionListingClosed); ok {
+		return x.AuctionListingClosed
+	}
+	return nil
+}
+
+func (x *Packet) GetAnnouncementHistoryRequest() *AnnouncementHistoryRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_AnnouncementHistoryRequest); ok {
+		return x.AnnouncementHistoryRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetAnnouncementHistory() *AnnouncementHistoryMessage {
+	if x, ok := x.GetMsg().(*Packet_AnnouncementHistory); ok {
+		return x.AnnouncementHistory
+	}
+	return nil
+}
+
+func (x *Packet) GetChatSent() *ChatSentMessage {
+	if x, ok := x.GetMsg().(*Packet_ChatSent); ok {
+		return x.ChatSent
+	}
+	return nil
+}
+
+func (x *Packet) GetChatReact() *ChatReactMessage {
+	if x, ok := x.GetMsg().(*Packet_ChatReact); ok {
+		return x.ChatReact
+	}
+	return nil
+}
+
+func (x *Packet) GetChatReaction() *ChatReactionMessage {
+	if x, ok := x.GetMsg().(*Packet_ChatReaction); ok {
+		return x.ChatReaction
+	}
+	return nil
+}
+
+func (x *Packet) GetReportPlayer() *ReportPlayerMessage {
+	if x, ok := x.GetMsg().(*Packet_ReportPlayer); ok {
+		return x.ReportPlayer
+	}
+	return nil
+}
+
+func (x *Packet) GetDebugOverlayRequest() *DebugOverlayRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_DebugOverlayRequest); ok {
+		return x.DebugOverlayRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetDebugOverlay() *DebugOverlayMessage {
+	if x, ok := x.GetMsg().(*Packet_DebugOverlay); ok {
+		return x.DebugOverlay
+	}
+	return nil
+}
+
+func (x *Packet) GetBossLockouts() *BossLockoutsMessage {
+	if x, ok := x.GetMsg().(*Packet_BossLockouts); ok {
+		return x.BossLockouts
+	}
+	return nil
+}
+
+func (x *Packet) GetEmitterSpawn() *EmitterSpawnMessage {
+	if x, ok := x.GetMsg().(*Packet_EmitterSpawn); ok {
+		return x.EmitterSpawn
+	}
+	return nil
+}
+
+func (x *Packet) GetEmitterDespawn() *EmitterDespawnMessage {
+	if x, ok := x.GetMsg().(*Packet_EmitterDespawn); ok {
+		return x.EmitterDespawn
+	}
+	return nil
+}
+
+func (x *Packet) GetKick() *KickMessage {
+	if x, ok := x.GetMsg().(*Packet_Kick); ok {
+		return x.Kick
+	}
+	return nil
+}
+
+func (x *Packet) GetCaptchaChallenge() *CaptchaChallengeMessage {
+	if x, ok := x.GetMsg().(*Packet_CaptchaChallenge); ok {
+		return x.CaptchaChallenge
+	}
+	return nil
+}
+
+func (x *Packet) GetCaptchaResponse() *CaptchaResponseMessage {
+	if x, ok := x.GetMsg().(*Packet_CaptchaResponse); ok {
+		return x.CaptchaResponse
+	}
+	return nil
+}
+
+func (x *Packet) GetActorDamage() *ActorDamageMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorDamage); ok {
+		return x.ActorDamage
+	}
+	return nil
+}
+
+func (x *Packet) GetActorKilled() *ActorKilledMessage {
+	if x, ok := x.GetMsg().(*Packet_ActorKilled); ok {
+		return x.ActorKilled
+	}
+	return nil
+}
+
+func (x *Packet) GetKillCredit() *KillCreditMessage {
+	if x, ok := x.GetMsg().(*Packet_KillCredit); ok {
+		return x.KillCredit
+	}
+	return nil
+}
+
+func (x *Packet) GetListSessions() *ListSessionsMessage {
+	if x, ok := x.GetMsg().(*Packet_ListSessions); ok {
+		return x.ListSessions
+	}
+	return nil
+}
+
+func (x *Packet) GetSessions() *SessionsMessage {
+	if x, ok := x.GetMsg().(*Packet_Sessions); ok {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *Packet) GetLogoutSession() *LogoutSessionMessage {
+	if x, ok := x.GetMsg().(*Packet_LogoutSession); ok {
+		return x.LogoutSession
+	}
+	return nil
+}
+
+func (x *Packet) GetNewLogin() *NewLoginMessage {
+	if x, ok := x.GetMsg().(*Packet_NewLogin); ok {
+		return x.NewLogin
+	}
+	return nil
+}
+
+func (x *Packet) GetSandboxCommand() *SandboxCommandMessage {
+	if x, ok := x.GetMsg().(*Packet_SandboxCommand); ok {
+		return x.SandboxCommand
+	}
+	return nil
+}
+
+func (x *Packet) GetSandboxStatus() *SandboxStatusMessage {
+	if x, ok := x.GetMsg().(*Packet_SandboxStatus); ok {
+		return x.SandboxStatus
+	}
+	return nil
+}
+
+func (x *Packet) GetIceServersRequest() *IceServersRequestMessage {
+	if x, ok := x.GetMsg().(*Packet_IceServersRequest); ok {
+		return x.IceServersRequest
+	}
+	return nil
+}
+
+func (x *Packet) GetIceServers() *IceServersMessage {
+	if x, ok := x.GetMsg().(*Packet_IceServers); ok {
+		return x.IceServers
+	}
+	return nil
+}
+
+func (x *Packet) GetInterest() *InterestMessage {
+	if x, ok := x.GetMsg().(*Packet_Interest); ok {
+		return x.Interest
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginToken() *LoginTokenMessage {
+	if x, ok := x.GetMsg().(*Packet_LoginToken); ok {
+		return x.LoginToken
+	}
+	return nil
+}
+
+type isPacket_Msg interface {
+	isPacket_Msg()
+}
+
+type Packet_Chat struct {
+	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+}
+
+type Packet_Id struct {
+	Id *IdMessage `protobuf:"bytes,3,opt,name=id,proto3,oneof"`
+}
+
+type Packet_LoginRequest struct {
+	LoginRequest *LoginRequestMessage `protobuf:"bytes,4,opt,name=login_request,json=loginRequest,proto3,oneof"`
+}
+
+type Packet_RegisterRequest struct {
+	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,5,opt,name=register_request,json=registerRequest,proto3,oneof"`
+}
+
+type Packet_OkResponse struct {
+	OkResponse *OkResponseMessage `protobuf:"bytes,6,opt,name=ok_response,json=okResponse,proto3,oneof"`
+}
+
+type Packet_DenyResponse struct {
+	DenyResponse *DenyResponseMessage `protobuf:"bytes,7,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+}
+
+type Packet_Player struct {
+	Player *PlayerMessage `protobuf:"bytes,8,opt,name=player,proto3,oneof"`
+}
+
+type Packet_PlayerDirection struct {
+	PlayerDirection *PlayerDirectionMessage `protobuf:"bytes,9,opt,name=player_direction,json=playerDirection,proto3,oneof"`
+}
+
+type Packet_Spore struct {
+	Spore *SporeMessage `protobuf:"bytes,10,opt,name=spore,proto3,oneof"`
+}
+
+type Packet_SporeConsumed struct {
+	SporeConsumed *SporeConsumedMessage `protobuf:"bytes,11,opt,name=spore_consumed,json=sporeConsumed,proto3,oneof"`
+}
+
+type Packet_SporesBatch struct {
+	SporesBatch *SporesBatchMessage `protobuf:"bytes,12,opt,name=spores_batch,json=sporesBatch,proto3,oneof"`
+}
+
+type Packet_PlayerConsumed struct {
+	PlayerConsumed *PlayerConsumedMessage `protobuf:"bytes,13,opt,name=player_consumed,json=playerConsumed,proto3,oneof"`
+}
+
+type Packet_HiscoreBoardRequest struct {
+	HiscoreBoardRequest *HiscoreBoardRequestMessage `protobuf:"bytes,14,opt,name=hiscore_board_request,json=hiscoreBoardRequest,proto3,oneof"`
+}
+
+type Packet_Hiscore struct {
+	Hiscore *HiscoreMessage `protobuf:"bytes,15,opt,name=hiscore,proto3,oneof"`
+}
+
+type Packet_HiscoreBoard struct {
+	HiscoreBoard *HiscoreBoardMessage `protobuf:"bytes,16,opt,name=hiscore_board,json=hiscoreBoard,proto3,oneof"`
+}
+
+type Packet_FinishedBrowsingHiscores struct {
+	FinishedBrowsingHiscores *FinishedBrowsingHiscoresMessage `protobuf:"bytes,17,opt,name=finished_browsing_hiscores,json=finishedBrowsingHiscores,proto3,oneof"`
+}
+
+type Packet_SearchHiscore struct {
+	SearchHiscore *SearchHiscoreMessage `protobuf:"bytes,18,opt,name=search_hiscore,json=searchHiscore,proto3,oneof"`
+}
+
+type Packet_Disconnect struct {
+	Disconnect *DisconnectMessage `protobuf:"bytes,19,opt,name=disconnect,proto3,oneof"`
+}
+
+type Packet_TimeSyncRequest struct {
+	TimeSyncRequest *TimeSyncRequestMessage `protobuf:"bytes,20,opt,name=time_sync_request,json=timeSyncRequest,proto3,oneof"`
+}
+
+type Packet_TimeSyncResponse struct {
+	TimeSyncResponse *TimeSyncResponseMessage `protobuf:"bytes,21,opt,name=time_sync_response,json=timeSyncResponse,proto3,oneof"`
+}
+
+type Packet_PresenceSubscribe struct {
+	PresenceSubscribe *PresenceSubscribeMessage `protobuf:"bytes,22,opt,name=presence_subscribe,json=presenceSubscribe,proto3,oneof"`
+}
+
+type Packet_PresenceUnsubscribe struct {
+	PresenceUnsubscribe *PresenceUnsubscribeMessage `protobuf:"bytes,23,opt,name=presence_unsubscribe,json=presenceUnsubscribe,proto3,oneof"`
+}
+
+type Packet_Presence struct {
+	Presence *PresenceMessage `protobuf:"bytes,24,opt,name=presence,proto3,oneof"`
+}
+
+type Packet_ResourceNode struct {
+	ResourceNode *ResourceNodeMessage `protobuf:"bytes,25,opt,name=resource_node,json=resourceNode,proto3,oneof"`
+}
+
+type Packet_ResourceNodesBatch struct {
+	ResourceNodesBatch *ResourceNodesBatchMessage `protobuf:"bytes,26,opt,name=resource_nodes_batch,json=resourceNodesBatch,proto3,oneof"`
+}
+
+type Packet_GatherStart struct {
+	GatherStart *GatherStartMessage `protobuf:"bytes,27,opt,name=gather_start,json=gatherStart,proto3,oneof"`
+}
+
+type Packet_GatherFinish struct {
+	GatherFinish *GatherFinishMessage `protobuf:"bytes,28,opt,name=gather_finish,json=gatherFinish,proto3,oneof"`
+}
+
+type Packet_Inventory struct {
+	Inventory *InventoryMessage `protobuf:"bytes,29,opt,name=inventory,proto3,oneof"`
+}
+
+type Packet_CraftRequest struct {
+	CraftRequest *CraftRequestMessage `protobuf:"bytes,30,opt,name=craft_request,json=craftRequest,proto3,oneof"`
+}
+
+type Packet_BlockPlayer struct {
+	BlockPlayer *BlockPlayerMessage `protobuf:"bytes,31,opt,name=block_player,json=blockPlayer,proto3,oneof"`
+}
+
+type Packet_UnblockPlayer struct {
+	UnblockPlayer *UnblockPlayerMessage `protobuf:"bytes,32,opt,name=unblock_player,json=unblockPlayer,proto3,oneof"`
+}
+
+type Packet_BlockList struct {
+	BlockList *BlockListMessage `protobuf:"bytes,33,opt,name=block_list,json=blockList,proto3,oneof"`
+}
+
+type Packet_MinimapRequest struct {
+	MinimapRequest *MinimapRequestMessage `protobuf:"bytes,34,opt,name=minimap_request,json=minimapRequest,proto3,oneof"`
+}
+
+type Packet_Minimap struct {
+	Minimap *MinimapMessage `protobuf:"bytes,35,opt,name=minimap,proto3,oneof"`
+}
+
+type Packet_OnboardingStep struct {
+	OnboardingStep *OnboardingStepMessage `protobuf:"bytes,36,opt,name=onboarding_step,json=onboardingStep,proto3,oneof"`
+}
+
+type Packet_CompleteOnboardingStep struct {
+	CompleteOnboardingStep *CompleteOnboardingStepMessage `protobuf:"bytes,37,opt,name=complete_onboarding_step,json=completeOnboardingStep,proto3,oneof"`
+}
+
+type Packet_Emotes struct {
+	Emotes *EmotesMessage `protobuf:"bytes,38,opt,name=emotes,proto3,oneof"`
+}
+
+type Packet_EmoteUnlocked struct {
+	EmoteUnlocked *EmoteUnlockedMessage `protobuf:"bytes,39,opt,name=emote_unlocked,json=emoteUnlocked,proto3,oneof"`
+}
+
+type Packet_Custom struct {
+	Custom *CustomMessage `protobuf:"bytes,40,opt,name=custom,proto3,oneof"`
+}
+
+type Packet_AuctionListRequest struct {
+	AuctionListRequest *AuctionListRequestMessage `protobuf:"bytes,41,opt,name=auction_list_request,json=auctionListRequest,proto3,oneof"`
+}
+
+type Packet_AuctionSearchRequest struct {
+	AuctionSearchRequest *AuctionSearchRequestMessage `protobuf:"bytes,42,opt,name=auction_search_request,json=auctionSearchRequest,proto3,oneof"`
+}
+
+type Packet_AuctionSearchResults struct {
+	AuctionSearchResults *AuctionSearchResultsMessage `protobuf:"bytes,43,opt,name=auction_search_results,json=auctionSearchResults,proto3,oneof"`
+}
+
+type Packet_AuctionBuyout struct {
+	AuctionBuyout *AuctionBuyoutMessage `protobuf:"bytes,44,opt,name=auction_buyout,json=auctionBuyout,proto3,oneof"`
+}
+
+type Packet_MailboxRequest struct {
+	MailboxRequest *MailboxRequestMessage `protobuf:"bytes,45,opt,name=mailbox_request,json=mailboxRequest,proto3,oneof"`
+}
+
+type Packet_Mailbox struct {
+	Mailbox *MailboxMessage `protobuf:"bytes,46,opt,name=mailbox,proto3,oneof"`
+}
+
+type Packet_ClaimMail struct {
+	ClaimMail *ClaimMailMessage `protobuf:"bytes,47,opt,name=claim_mail,json=claimMail,proto3,oneof"`
+}
+
+type Packet_ImpersonationRequest struct {
+	ImpersonationRequest *ImpersonationRequestMessage `protobuf:"bytes,48,opt,name=impersonation_request,json=impersonationRequest,proto3,oneof"`
+}
+
+type Packet_ImpersonationConsent struct {
+	ImpersonationConsent *ImpersonationConsentMessage `protobuf:"bytes,49,opt,name=impersonation_consent,json=impersonationConsent,proto3,oneof"`
+}
+
+type Packet_ImpersonationStatus struct {
+	ImpersonationStatus *ImpersonationStatusMessage `protobuf:"bytes,50,opt,name=impersonation_status,json=impersonationStatus,proto3,oneof"`
+}
+
+type Packet_FeatureFlagsRequest struct {
+	FeatureFlagsRequest *FeatureFlagsRequestMessage `protobuf:"bytes,51,opt,name=feature_flags_request,json=featureFlagsRequest,proto3,oneof"`
+}
+
+type Packet_FeatureFlags struct {
+	FeatureFlags *FeatureFlagsMessage `protobuf:"bytes,52,opt,name=feature_flags,json=featureFlags,proto3,oneof"`
+}
+
+type Packet_ChannelListRequest struct {
+	ChannelListRequest *ChannelListRequestMessage `protobuf:"bytes,53,opt,name=channel_list_request,json=channelListRequest,proto3,oneof"`
+}
+
+type Packet_ChannelList struct {
+	ChannelList *ChannelListMessage `protobuf:"bytes,54,opt,name=channel_list,json=channelList,proto3,oneof"`
+}
+
+type Packet_ChannelSwitch struct {
+	ChannelSwitch *ChannelSwitchMessage `protobuf:"bytes,55,opt,name=channel_switch,json=channelSwitch,proto3,oneof"`
+}
+
+type Packet_RollCommit struct {
+	RollCommit *RollCommitMessage `protobuf:"bytes,56,opt,name=roll_commit,json=rollCommit,proto3,oneof"`
+}
+
+type Packet_RollReveal struct {
+	RollReveal *RollRevealMessage `protobuf:"bytes,57,opt,name=roll_reveal,json=rollReveal,proto3,oneof"`
+}
+
+type Packet_VoiceSignal struct {
+	VoiceSignal *VoiceSignalMessage `protobuf:"bytes,58,opt,name=voice_signal,json=voiceSignal,proto3,oneof"`
+}
+
+type Packet_VoiceParty struct {
+	VoiceParty *VoicePartyMessage `protobuf:"bytes,59,opt,name=voice_party,json=voiceParty,proto3,oneof"`
+}
+
+type Packet_VoiceStatus struct {
+	VoiceStatus *VoiceStatusMessage `protobuf:"bytes,60,opt,name=voice_status,json=voiceStatus,proto3,oneof"`
+}
+
+type Packet_DataExportRequest struct {
+	DataExportRequest *DataExportRequestMessage `protobuf:"bytes,61,opt,name=data_export_request,json=dataExportRequest,proto3,oneof"`
+}
+
+type Packet_AccountDeletionRequest struct {
+	AccountDeletionRequest *AccountDeletionRequestMessage `protobuf:"bytes,62,opt,name=account_deletion_request,json=accountDeletionRequest,proto3,oneof"`
+}
+
+type Packet_AccountDeletionCancel struct {
+	AccountDeletionCancel *AccountDeletionCancelMessage `protobuf:"bytes,63,opt,name=account_deletion_cancel,json=accountDeletionCancel,proto3,oneof"`
+}
+
+type Packet_AccountDataStatusRequest struct {
+	AccountDataStatusRequest *AccountDataStatusRequestMessage `protobuf:"bytes,64,opt,name=account_data_status_request,json=accountDataStatusRequest,proto3,oneof"`
+}
+
+type Packet_AccountDataStatus struct {
+	AccountDataStatus *AccountDataStatusMessage `protobuf:"bytes,65,opt,name=account_data_status,json=accountDataStatus,proto3,oneof"`
+}
+
+type Packet_FileOffer struct {
+	FileOffer *FileOfferMessage `protobuf:"bytes,66,opt,name=file_offer,json=fileOffer,proto3,oneof"`
+}
+
+type Packet_FileChunk struct {
+	FileChunk *FileChunkMessage `protobuf:"bytes,67,opt,name=file_chunk,json=fileChunk,proto3,oneof"`
+}
+
+type Packet_FileReceived struct {
+	FileReceived *FileReceivedMessage `protobuf:"bytes,68,opt,name=file_received,json=fileReceived,proto3,oneof"`
+}
+
+type Packet_StoreRequest struct {
+	StoreRequest *StoreRequestMessage `protobuf:"bytes,69,opt,name=store_request,json=storeRequest,proto3,oneof"`
+}
+
+type Packet_Store struct {
+	Store *StoreMessage `protobuf:"bytes,70,opt,name=store,proto3,oneof"`
+}
+
+type Packet_StorePurchase struct {
+	StorePurchase *StorePurchaseMessage `protobuf:"bytes,71,opt,name=store_purchase,json=storePurchase,proto3,oneof"`
+}
+
+type Packet_StoreReceipt struct {
+	StoreReceipt *StoreReceiptMessage `protobuf:"bytes,72,opt,name=store_receipt,json=storeReceipt,proto3,oneof"`
+}
+
+type Packet_StoreReceiptStatus struct {
+	StoreReceiptStatus *StoreReceiptStatusMessage `protobuf:"bytes,73,opt,name=store_receipt_status,json=storeReceiptStatus,proto3,oneof"`
+}
+
+type Packet_DataPack struct {
+	DataPack *DataPackMessage `protobuf:"bytes,74,opt,name=data_pack,json=dataPack,proto3,oneof"`
+}
+
+type Packet_Interact struct {
+	Interact *InteractMessage `protobuf:"bytes,75,opt,name=interact,proto3,oneof"`
+}
+
+type Packet_InteractionResult struct {
+	InteractionResult *InteractionResultMessage `protobuf:"bytes,76,opt,name=interaction_result,json=interactionResult,proto3,oneof"`
+}
+
+type Packet_Prompt struct {
+	Prompt *PromptMessage `protobuf:"bytes,77,opt,name=prompt,proto3,oneof"`
+}
+
+type Packet_PromptResponse struct {
+	PromptResponse *PromptResponseMessage `protobuf:"bytes,78,opt,name=prompt_response,json=promptResponse,proto3,oneof"`
+}
+
+type Packet_PromptClosed struct {
+	PromptClosed *PromptClosedMessage `protobuf:"bytes,79,opt,name=prompt_closed,json=promptClosed,proto3,oneof"`
+}
+
+type Packet_MoveTo struct {
+	MoveTo *MoveToMessage `protobuf:"bytes,80,opt,name=move_to,json=moveTo,proto3,oneof"`
+}
+
+type Packet_EntitlementListRequest struct {
+	EntitlementListRequest *EntitlementListRequestMessage `protobuf:"bytes,81,opt,name=entitlement_list_request,json=entitlementListRequest,proto3,oneof"`
+}
+
+type Packet_EntitlementList struct {
+	EntitlementList *EntitlementListMessage `protobuf:"bytes,82,opt,name=entitlement_list,json=entitlementList,proto3,oneof"`
 }
 
-func (x *Packet) GetSenderId() uint64 {
-	if x != nil {
-		return x.SenderId
-	}
-	return 0
+type Packet_EnvironmentCue struct {
+	EnvironmentCue *EnvironmentCueMessage `protobuf:"bytes,83,opt,name=environment_cue,json=environmentCue,proto3,oneof"`
 }
 
-func (m *Packet) GetMsg() isPacket_Msg {
-	if m != nil {
-		return m.Msg
-	}
-	return nil
+type Packet_ClientUpdate struct {
+	ClientUpdate *ClientUpdateMessage `protobuf:"bytes,84,opt,name=client_update,json=clientUpdate,proto3,oneof"`
 }
 
-func (x *Packet) GetChat() *ChatMessage {
-	if x, ok := x.GetMsg().(*Packet_Chat); ok {
-		return x.Chat
-	}
-	return nil
+type Packet_SimulatorLoginRequest struct {
+	SimulatorLoginRequest *SimulatorLoginRequestMessage `protobuf:"bytes,85,opt,name=simulator_login_request,json=simulatorLoginRequest,proto3,oneof"`
 }
 
-func (x *Packet) GetId() *IdMessage {
-	if x, ok := x.GetMsg().(*Packet_Id); ok {
-		return x.Id
-	}
-	return nil
+type Packet_ActorSpawnRequest struct {
+	ActorSpawnRequest *ActorSpawnRequestMessage `protobuf:"bytes,86,opt,name=actor_spawn_request,json=actorSpawnRequest,proto3,oneof"`
 }
 
-func (x *Packet) GetLoginRequest() *LoginRequestMessage {
-	if x, ok := x.GetMsg().(*Packet_LoginRequest); ok {
-		return x.LoginRequest
-	}
-	return nil
+type Packet_ActorSpawnResponse struct {
+	ActorSpawnResponse *ActorSpawnResponseMessage `protobuf:"bytes,87,opt,name=actor_spawn_response,json=actorSpawnResponse,proto3,oneof"`
 }
 
-func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
-	if x, ok := x.GetMsg().(*Packet_RegisterRequest); ok {
-		return x.RegisterRequest
-	}
-	return nil
+type Packet_ActorUpdates struct {
+	ActorUpdates *ActorUpdatesMessage `protobuf:"bytes,88,opt,name=actor_updates,json=actorUpdates,proto3,oneof"`
 }
 
-func (x *Packet) GetOkResponse() *OkResponseMessage {
-	if x, ok := x.GetMsg().(*Packet_OkResponse); ok {
-		return x.OkResponse
-	}
-	return nil
+type Packet_ActorDespawn struct {
+	ActorDespawn *ActorDespawnMessage `protobuf:"bytes,89,opt,name=actor_despawn,json=actorDespawn,proto3,oneof"`
 }
 
-func (x *Packet) GetDenyResponse() *DenyResponseMessage {
-	if x, ok := x.GetMsg().(*Packet_DenyResponse); ok {
-		return x.DenyResponse
-	}
-	return nil
+type Packet_Dialog struct {
+	Dialog *DialogMessage `protobuf:"bytes,90,opt,name=dialog,proto3,oneof"`
 }
 
-func (x *Packet) GetPlayer() *PlayerMessage {
-	if x, ok := x.GetMsg().(*Packet_Player); ok {
-		return x.Player
-	}
-	return nil
+type Packet_DialogChoose struct {
+	DialogChoose *DialogChooseMessage `protobuf:"bytes,91,opt,name=dialog_choose,json=dialogChoose,proto3,oneof"`
 }
 
-func (x *Packet) GetPlayerDirection() *PlayerDirectionMessage {
-	if x, ok := x.GetMsg().(*Packet_PlayerDirection); ok {
-		return x.PlayerDirection
-	}
-	return nil
+type Packet_DialogClosed struct {
+	DialogClosed *DialogClosedMessage `protobuf:"bytes,92,opt,name=dialog_closed,json=dialogClosed,proto3,oneof"`
 }
 
-func (x *Packet) GetSpore() *SporeMessage {
-	if x, ok := x.GetMsg().(*Packet_Spore); ok {
-		return x.Spore
-	}
-	return nil
+type Packet_QuestStage struct {
+	QuestStage *QuestStageMessage `protobuf:"bytes,93,opt,name=quest_stage,json=questStage,proto3,oneof"`
 }
 
-func (x *Packet) GetSporeConsumed() *SporeConsumedMessage {
-	if x, ok := x.GetMsg().(*Packet_SporeConsumed); ok {
-		return x.SporeConsumed
-	}
-	return nil
+type Packet_Subscribe struct {
+	Subscribe *SubscribeMessage `protobuf:"bytes,94,opt,name=subscribe,proto3,oneof"`
 }
 
-func (x *Packet) GetSporesBatch() *SporesBatchMessage {
-	if x, ok := x.GetMsg().(*Packet_SporesBatch); ok {
-		return x.SporesBatch
-	}
-	return nil
+type Packet_Unsubscribe struct {
+	Unsubscribe *UnsubscribeMessage `protobuf:"bytes,95,opt,name=unsubscribe,proto3,oneof"`
 }
 
-func (x *Packet) GetPlayerConsumed() *PlayerConsumedMessage {
-	if x, ok := x.GetMsg().(*Packet_PlayerConsumed); ok {
-		return x.PlayerConsumed
-	}
-	return nil
+type Packet_Subscription struct {
+	Subscription *SubscriptionMessage `protobuf:"bytes,96,opt,name=subscription,proto3,oneof"`
 }
 
-func (x *Packet) GetHiscoreBoardRequest() *HiscoreBoardRequestMessage {
-	if x, ok := x.GetMsg().(*Packet_HiscoreBoardRequest); ok {
-		return x.HiscoreBoardRequest
-	}
-	return nil
+type Packet_AuctionListing struct {
+	AuctionListing *AuctionListingMessage `protobuf:"bytes,97,opt,name=auction_listing,json=auctionListing,proto3,oneof"`
 }
 
-func (x *Packet) GetHiscore() *HiscoreMessage {
-	if x, ok := x.GetMsg().(*Packet_Hiscore); ok {
-		return x.Hiscore
-	}
-	return nil
+type Packet_AuctionListingClosed struct {
+	AuctionListingClosed *AuctionListingClosedMessage `protobuf:"bytes,98,opt,name=auction_listing_closed,json=auctionListingClosed,proto3,oneof"`
 }
 
-func (x *Packet) GetHiscoreBoard() *HiscoreBoardMessage {
-	if x, ok := x.GetMsg().(*Packet_HiscoreBoard); ok {
-		return x.HiscoreBoard
-	}
-	return nil
+type Packet_AnnouncementHistoryRequest struct {
+	AnnouncementHistoryRequest *AnnouncementHistoryRequestMessage `protobuf:"bytes,99,opt,name=announcement_history_request,json=announcementHistoryRequest,proto3,oneof"`
 }
 
-func (x *Packet) GetFinishedBrowsingHiscores() *FinishedBrowsingHiscoresMessage {
-	if x, ok := x.GetMsg().(*Packet_FinishedBrowsingHiscores); ok {
-		return x.FinishedBrowsingHiscores
-	}
-	return nil
+type Packet_AnnouncementHistory struct {
+	AnnouncementHistory *AnnouncementHistoryMessage `protobuf:"bytes,100,opt,name=announcement_history,json=announcementHistory,proto3,oneof"`
 }
 
-func (x *Packet) GetSearchHiscore() *SearchHiscoreMessage {
-	if x, ok := x.GetMsg().(*Packet_SearchHiscore); ok {
-		return x.SearchHiscore
-	}
-	return nil
+type Packet_ChatSent struct {
+	ChatSent *ChatSentMessage `protobuf:"bytes,101,opt,name=chat_sent,json=chatSent,proto3,oneof"`
 }
 
-func (x *Packet) GetDisconnect() *DisconnectMessage {
-	if x, ok := x.GetMsg().(*Packet_Disconnect); ok {
-		return x.Disconnect
-	}
-	return nil
+type Packet_ChatReact struct {
+	ChatReact *ChatReactMessage `protobuf:"bytes,102,opt,name=chat_react,json=chatReact,proto3,oneof"`
 }
 
-type isPacket_Msg interface {
-	isPacket_Msg()
+type Packet_ChatReaction struct {
+	ChatReaction *ChatReactionMessage `protobuf:"bytes,103,opt,name=chat_reaction,json=chatReaction,proto3,oneof"`
 }
 
-type Packet_Chat struct {
-	Chat *ChatMessage `protobuf:"bytes,2,opt,name=chat,proto3,oneof"`
+type Packet_ReportPlayer struct {
+	ReportPlayer *ReportPlayerMessage `protobuf:"bytes,104,opt,name=report_player,json=reportPlayer,proto3,oneof"`
 }
 
-type Packet_Id struct {
-	Id *IdMessage `protobuf:"bytes,3,opt,name=id,proto3,oneof"`
+type Packet_DebugOverlayRequest struct {
+	DebugOverlayRequest *DebugOverlayRequestMessage `protobuf:"bytes,105,opt,name=debug_overlay_request,json=debugOverlayRequest,proto3,oneof"`
 }
 
-type Packet_LoginRequest struct {
-	LoginRequest *LoginRequestMessage `protobuf:"bytes,4,opt,name=login_request,json=loginRequest,proto3,oneof"`
+type Packet_DebugOverlay struct {
+	DebugOverlay *DebugOverlayMessage `protobuf:"bytes,106,opt,name=debug_overlay,json=debugOverlay,proto3,oneof"`
 }
 
-type Packet_RegisterRequest struct {
-	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,5,opt,name=register_request,json=registerRequest,proto3,oneof"`
+type Packet_BossLockouts struct {
+	BossLockouts *BossLockoutsMessage `protobuf:"bytes,107,opt,name=boss_lockouts,json=bossLockouts,proto3,oneof"`
 }
 
-type Packet_OkResponse struct {
-	OkResponse *OkResponseMessage `protobuf:"bytes,6,opt,name=ok_response,json=okResponse,proto3,oneof"`
+type Packet_EmitterSpawn struct {
+	EmitterSpawn *EmitterSpawnMessage `protobuf:"bytes,108,opt,name=emitter_spawn,json=emitterSpawn,proto3,oneof"`
 }
 
-type Packet_DenyResponse struct {
-	DenyResponse *DenyResponseMessage `protobuf:"bytes,7,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+type Packet_EmitterDespawn struct {
+	EmitterDespawn *EmitterDespawnMessage `protobuf:"bytes,109,opt,name=emitter_despawn,json=emitterDespawn,proto3,oneof"`
 }
 
-type Packet_Player struct {
-	Player *PlayerMessage `protobuf:"bytes,8,opt,name=player,proto3,oneof"`
+type Packet_Kick struct {
+	Kick *KickMessage `protobuf:"bytes,110,opt,name=kick,proto3,oneof"`
 }
 
-type Packet_PlayerDirection struct {
-	PlayerDirection *PlayerDirectionMessage `protobuf:"bytes,9,opt,name=player_direction,json=playerDirection,proto3,oneof"`
+type Packet_CaptchaChallenge struct {
+	CaptchaChallenge *CaptchaChallengeMessage `protobuf:"bytes,111,opt,name=captcha_challenge,json=captchaChallenge,proto3,oneof"`
 }
 
-type Packet_Spore struct {
-	Spore *SporeMessage `protobuf:"bytes,10,opt,name=spore,proto3,oneof"`
+type Packet_CaptchaResponse struct {
+	CaptchaResponse *CaptchaResponseMessage `protobuf:"bytes,112,opt,name=captcha_response,json=captchaResponse,proto3,oneof"`
 }
 
-type Packet_SporeConsumed struct {
-	SporeConsumed *SporeConsumedMessage `protobuf:"bytes,11,opt,name=spore_consumed,json=sporeConsumed,proto3,oneof"`
+type Packet_ActorDamage struct {
+	ActorDamage *ActorDamageMessage `protobuf:"bytes,113,opt,name=actor_damage,json=actorDamage,proto3,oneof"`
 }
 
-type Packet_SporesBatch struct {
-	SporesBatch *SporesBatchMessage `protobuf:"bytes,12,opt,name=spores_batch,json=sporesBatch,proto3,oneof"`
+type Packet_ActorKilled struct {
+	ActorKilled *ActorKilledMessage `protobuf:"bytes,114,opt,name=actor_killed,json=actorKilled,proto3,oneof"`
 }
 
-type Packet_PlayerConsumed struct {
-	PlayerConsumed *PlayerConsumedMessage `protobuf:"bytes,13,opt,name=player_consumed,json=playerConsumed,proto3,oneof"`
+type Packet_KillCredit struct {
+	KillCredit *KillCreditMessage `protobuf:"bytes,115,opt,name=kill_credit,json=killCredit,proto3,oneof"`
 }
 
-type Packet_HiscoreBoardRequest struct {
-	HiscoreBoardRequest *HiscoreBoardRequestMessage `protobuf:"bytes,14,opt,name=hiscore_board_request,json=hiscoreBoardRequest,proto3,oneof"`
+type Packet_ListSessions struct {
+	ListSessions *ListSessionsMessage `protobuf:"bytes,116,opt,name=list_sessions,json=listSessions,proto3,oneof"`
 }
 
-type Packet_Hiscore struct {
-	Hiscore *HiscoreMessage `protobuf:"bytes,15,opt,name=hiscore,proto3,oneof"`
+type Packet_Sessions struct {
+	Sessions *SessionsMessage `protobuf:"bytes,117,opt,name=sessions,proto3,oneof"`
 }
 
-type Packet_HiscoreBoard struct {
-	HiscoreBoard *HiscoreBoardMessage `protobuf:"bytes,16,opt,name=hiscore_board,json=hiscoreBoard,proto3,oneof"`
+type Packet_LogoutSession struct {
+	LogoutSession *LogoutSessionMessage `protobuf:"bytes,118,opt,name=logout_session,json=logoutSession,proto3,oneof"`
 }
 
-type Packet_FinishedBrowsingHiscores struct {
-	FinishedBrowsingHiscores *FinishedBrowsingHiscoresMessage `protobuf:"bytes,17,opt,name=finished_browsing_hiscores,json=finishedBrowsingHiscores,proto3,oneof"`
+type Packet_NewLogin struct {
+	NewLogin *NewLoginMessage `protobuf:"bytes,119,opt,name=new_login,json=newLogin,proto3,oneof"`
 }
 
-type Packet_SearchHiscore struct {
-	SearchHiscore *SearchHiscoreMessage `protobuf:"bytes,18,opt,name=search_hiscore,json=searchHiscore,proto3,oneof"`
+type Packet_SandboxCommand struct {
+	SandboxCommand *SandboxCommandMessage `protobuf:"bytes,120,opt,name=sandbox_command,json=sandboxCommand,proto3,oneof"`
 }
 
-type Packet_Disconnect struct {
-	Disconnect *DisconnectMessage `protobuf:"bytes,19,opt,name=disconnect,proto3,oneof"`
+type Packet_SandboxStatus struct {
+	SandboxStatus *SandboxStatusMessage `protobuf:"bytes,121,opt,name=sandbox_status,json=sandboxStatus,proto3,oneof"`
+}
+
+type Packet_IceServersRequest struct {
+	IceServersRequest *IceServersRequestMessage `protobuf:"bytes,122,opt,name=ice_servers_request,json=iceServersRequest,proto3,oneof"`
+}
+
+type Packet_IceServers struct {
+	IceServers *IceServersMessage `protobuf:"bytes,123,opt,name=ice_servers,json=iceServers,proto3,oneof"`
+}
+
+type Packet_Interest struct {
+	Interest *InterestMessage `protobuf:"bytes,124,opt,name=interest,proto3,oneof"`
+}
+
+type Packet_LoginToken struct {
+	LoginToken *LoginTokenMessage `protobuf:"bytes,125,opt,name=login_token,json=loginToken,proto3,oneof"`
 }
 
 func (*Packet_Chat) isPacket_Msg() {}
@@ -1234,160 +10044,1643 @@ func (*Packet_SearchHiscore) isPacket_Msg() {}
 
 func (*Packet_Disconnect) isPacket_Msg() {}
 
+func (*Packet_TimeSyncRequest) isPacket_Msg() {}
+
+func (*Packet_TimeSyncResponse) isPacket_Msg() {}
+
+func (*Packet_PresenceSubscribe) isPacket_Msg() {}
+
+func (*Packet_PresenceUnsubscribe) isPacket_Msg() {}
+
+func (*Packet_Presence) isPacket_Msg() {}
+
+func (*Packet_ResourceNode) isPacket_Msg() {}
+
+func (*Packet_ResourceNodesBatch) isPacket_Msg() {}
+
+func (*Packet_GatherStart) isPacket_Msg() {}
+
+func (*Packet_GatherFinish) isPacket_Msg() {}
+
+func (*Packet_Inventory) isPacket_Msg() {}
+
+func (*Packet_CraftRequest) isPacket_Msg() {}
+
+func (*Packet_BlockPlayer) isPacket_Msg() {}
+
+func (*Packet_UnblockPlayer) isPacket_Msg() {}
+
+func (*Packet_BlockList) isPacket_Msg() {}
+
+func (*Packet_MinimapRequest) isPacket_Msg() {}
+
+func (*Packet_Minimap) isPacket_Msg() {}
+
+func (*Packet_OnboardingStep) isPacket_Msg() {}
+
+func (*Packet_CompleteOnboardingStep) isPacket_Msg() {}
+
+func (*Packet_Emotes) isPacket_Msg() {}
+
+func (*Packet_EmoteUnlocked) isPacket_Msg() {}
+
+func (*Packet_Custom) isPacket_Msg() {}
+
+func (*Packet_AuctionListRequest) isPacket_Msg() {}
+
+func (*Packet_AuctionSearchRequest) isPacket_Msg() {}
+
+func (*Packet_AuctionSearchResults) isPacket_Msg() {}
+
+func (*Packet_AuctionBuyout) isPacket_Msg() {}
+
+func (*Packet_MailboxRequest) isPacket_Msg() {}
+
+func (*Packet_Mailbox) isPacket_Msg() {}
+
+func (*Packet_ClaimMail) isPacket_Msg() {}
+
+func (*Packet_ImpersonationRequest) isPacket_Msg() {}
+
+func (*Packet_ImpersonationConsent) isPacket_Msg() {}
+
+func (*Packet_ImpersonationStatus) isPacket_Msg() {}
+
+func (*Packet_FeatureFlagsRequest) isPacket_Msg() {}
+
+func (*Packet_FeatureFlags) isPacket_Msg() {}
+
+func (*Packet_ChannelListRequest) isPacket_Msg() {}
+
+func (*Packet_ChannelList) isPacket_Msg() {}
+
+func (*Packet_ChannelSwitch) isPacket_Msg() {}
+
+func (*Packet_RollCommit) isPacket_Msg() {}
+
+func (*Packet_RollReveal) isPacket_Msg() {}
+
+func (*Packet_VoiceSignal) isPacket_Msg() {}
+
+func (*Packet_VoiceParty) isPacket_Msg() {}
+
+func (*Packet_VoiceStatus) isPacket_Msg() {}
+
+func (*Packet_DataExportRequest) isPacket_Msg() {}
+
+func (*Packet_AccountDeletionRequest) isPacket_Msg() {}
+
+func (*Packet_AccountDeletionCancel) isPacket_Msg() {}
+
+func (*Packet_AccountDataStatusRequest) isPacket_Msg() {}
+
+func (*Packet_AccountDataStatus) isPacket_Msg() {}
+
+func (*Packet_FileOffer) isPacket_Msg() {}
+
+func (*Packet_FileChunk) isPacket_Msg() {}
+
+func (*Packet_FileReceived) isPacket_Msg() {}
+
+func (*Packet_StoreRequest) isPacket_Msg() {}
+
+func (*Packet_Store) isPacket_Msg() {}
+
+func (*Packet_StorePurchase) isPacket_Msg() {}
+
+func (*Packet_StoreReceipt) isPacket_Msg() {}
+
+func (*Packet_StoreReceiptStatus) isPacket_Msg() {}
+
+func (*Packet_DataPack) isPacket_Msg() {}
+
+func (*Packet_Interact) isPacket_Msg() {}
+
+func (*Packet_InteractionResult) isPacket_Msg() {}
+
+func (*Packet_Prompt) isPacket_Msg() {}
+
+func (*Packet_PromptResponse) isPacket_Msg() {}
+
+func (*Packet_PromptClosed) isPacket_Msg() {}
+
+func (*Packet_MoveTo) isPacket_Msg() {}
+
+func (*Packet_EntitlementListRequest) isPacket_Msg() {}
+
+func (*Packet_EntitlementList) isPacket_Msg() {}
+
+func (*Packet_EnvironmentCue) isPacket_Msg() {}
+
+func (*Packet_ClientUpdate) isPacket_Msg() {}
+
+func (*Packet_SimulatorLoginRequest) isPacket_Msg() {}
+
+func (*Packet_ActorSpawnRequest) isPacket_Msg() {}
+
+func (*Packet_ActorSpawnResponse) isPacket_Msg() {}
+
+func (*Packet_ActorUpdates) isPacket_Msg() {}
+
+func (*Packet_ActorDespawn) isPacket_Msg() {}
+
+func (*Packet_Dialog) isPacket_Msg() {}
+
+func (*Packet_DialogChoose) isPacket_Msg() {}
+
+func (*Packet_DialogClosed) isPacket_Msg() {}
+
+func (*Packet_QuestStage) isPacket_Msg() {}
+
+func (*Packet_Subscribe) isPacket_Msg() {}
+
+func (*Packet_Unsubscribe) isPacket_Msg() {}
+
+func (*Packet_Subscription) isPacket_Msg() {}
+
+func (*Packet_AuctionListing) isPacket_Msg() {}
+
+func (*Packet_AuctionListingClosed) isPacket_Msg() {}
+
+func (*Packet_AnnouncementHistoryRequest) isPacket_Msg() {}
+
+func (*Packet_AnnouncementHistory) isPacket_Msg() {}
+
+func (*Packet_ChatSent) isPacket_Msg() {}
+
+func (*Packet_ChatReact) isPacket_Msg() {}
+
+func (*Packet_ChatReaction) isPacket_Msg() {}
+
+func (*Packet_ReportPlayer) isPacket_Msg() {}
+
+func (*Packet_DebugOverlayRequest) isPacket_Msg() {}
+
+func (*Packet_DebugOverlay) isPacket_Msg() {}
+
+func (*Packet_BossLockouts) isPacket_Msg() {}
+
+func (*Packet_EmitterSpawn) isPacket_Msg() {}
+
+func (*Packet_EmitterDespawn) isPacket_Msg() {}
+
+func (*Packet_Kick) isPacket_Msg() {}
+
+func (*Packet_CaptchaChallenge) isPacket_Msg() {}
+
+func (*Packet_CaptchaResponse) isPacket_Msg() {}
+
+func (*Packet_ActorDamage) isPacket_Msg() {}
+
+func (*Packet_ActorKilled) isPacket_Msg() {}
+
+func (*Packet_KillCredit) isPacket_Msg() {}
+
+func (*Packet_ListSessions) isPacket_Msg() {}
+
+func (*Packet_Sessions) isPacket_Msg() {}
+
+func (*Packet_LogoutSession) isPacket_Msg() {}
+
+func (*Packet_NewLogin) isPacket_Msg() {}
+
+func (*Packet_SandboxCommand) isPacket_Msg() {}
+
+func (*Packet_SandboxStatus) isPacket_Msg() {}
+
+func (*Packet_IceServersRequest) isPacket_Msg() {}
+
+func (*Packet_IceServers) isPacket_Msg() {}
+
+func (*Packet_Interest) isPacket_Msg() {}
+
+func (*Packet_LoginToken) isPacket_Msg() {}
+
 var File_packets_proto protoreflect.FileDescriptor
 
 var file_packets_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x1f, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74,
+	0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x2f, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x74,
 	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0x1b, 0x0a, 0x09, 0x49, 0x64, 0x4d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1b, 0x0a, 0x09, 0x49, 0x64, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x4d, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73,
-	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x66, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x13, 0x0a,
-	0x11, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x2d, 0x0a, 0x13, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
-	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
-	0x6e, 0x22, 0xb1, 0x01, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0xfb, 0x02, 0x0a, 0x13, 0x4c, 0x6f, 0x67, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x6f,
+	0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6a, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x64, 0x61, 0x74, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x75, 0x74, 0x63,
+	0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x75, 0x74, 0x63, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x66, 0x6f,
+	0x72, 0x5f, 0x75, 0x64, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x55, 0x64, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x48, 0x0a, 0x11, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x89,
+	0x01, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
+	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x4f, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x58, 0x0a, 0x13, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x29,
+	0x0a, 0x10, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6e, 0x61, 0x6d, 0x65, 0x53, 0x75,
+	0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x0d, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64,
+	0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x36, 0x0a,
+	0x16, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2b, 0x0a, 0x0d, 0x4d, 0x6f, 0x76, 0x65, 0x54, 0x6f, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x01, 0x79, 0x22, 0x52, 0x0a, 0x0c, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43,
+	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x12, 0x53, 0x70, 0x6f,
+	0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x2d, 0x0a, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x34,
+	0x0a, 0x15, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x1c, 0x0a, 0x1a, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42,
+	0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x4e, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x22, 0x4a, 0x0a, 0x13, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x68, 0x69, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x21,
+	0x0a, 0x1f, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69,
+	0x6e, 0x67, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x2a, 0x0a, 0x14, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2b, 0x0a,
+	0x11, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x16, 0x54, 0x69,
+	0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x72, 0x74, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x52,
+	0x74, 0x74, 0x22, 0x6d, 0x0a, 0x17, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73, 0x65, 0x71, 0x12,
+	0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x30, 0x0a, 0x18, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x1a, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x55,
+	0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x22, 0x60, 0x0a, 0x19, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x43, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x12, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x73, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x53, 0x65, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x13, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x10, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74,
+	0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65,
+	0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22,
+	0x43, 0x0a, 0x10, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x74, 0x65,
+	0x6d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x22, 0x32, 0x0a, 0x13, 0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x49, 0x64, 0x22, 0x28, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x28,
+	0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x4d, 0x69, 0x6e, 0x69,
+	0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x5c, 0x0a, 0x16, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x22,
+	0x72, 0x0a, 0x15, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65,
+	0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x74, 0x65, 0x70,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x65, 0x70, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65,
+	0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x73, 0x74,
+	0x65, 0x70, 0x73, 0x22, 0x38, 0x0a, 0x1d, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x4f,
+	0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65, 0x70, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x65, 0x70, 0x49, 0x64, 0x22, 0x50, 0x0a,
+	0x0c, 0x45, 0x6d, 0x6f, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x22,
+	0x3e, 0x0a, 0x0d, 0x45, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2d, 0x0a, 0x06, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x6f, 0x74, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x22,
+	0x31, 0x0a, 0x14, 0x45, 0x6d, 0x6f, 0x74, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x49, 0x64, 0x22, 0x3d, 0x0a, 0x0d, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0x7c, 0x0a, 0x19, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x22,
+	0x47, 0x0a, 0x1b, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0xa9, 0x01, 0x0a, 0x15, 0x41, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6c, 0x6c, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74,
+	0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65,
+	0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x41, 0x74, 0x22, 0x83, 0x01, 0x0a, 0x1b, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x22, 0x35, 0x0a, 0x14, 0x41, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x75, 0x79, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x49,
+	0x64, 0x22, 0x17, 0x0a, 0x15, 0x4d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x0b, 0x4d,
+	0x61, 0x69, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x17, 0x0a, 0x07,
+	0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69,
+	0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22, 0x3a, 0x0a, 0x0e, 0x4d, 0x61,
+	0x69, 0x6c, 0x62, 0x6f, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x04,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x04, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x2b, 0x0a, 0x10, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4d,
+	0x61, 0x69, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61,
+	0x69, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x61, 0x69,
+	0x6c, 0x49, 0x64, 0x22, 0x84, 0x01, 0x0a, 0x1b, 0x49, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x56, 0x0a, 0x1b, 0x49, 0x6d,
+	0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x61, 0x6e,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x67, 0x72, 0x61, 0x6e, 0x74,
+	0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x1a, 0x49, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x22, 0x1c, 0x0a, 0x1a, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2f, 0x0a, 0x13, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x1f, 0x0a, 0x1d, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x65, 0x0a, 0x16, 0x45, 0x6e, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63,
+	0x74, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x6f, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x63, 0x68, 0x61, 0x72, 0x61, 0x63, 0x74, 0x65, 0x72, 0x53, 0x6c, 0x6f, 0x74, 0x73, 0x22,
+	0x1b, 0x0a, 0x19, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x73, 0x0a, 0x12,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6f,
+	0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x61,
+	0x78, 0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x80, 0x01, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x73, 0x22, 0x51, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53,
+	0x77, 0x69, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6a, 0x6f, 0x69,
+	0x6e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x22, 0x56, 0x0a, 0x11, 0x52, 0x6f, 0x6c, 0x6c, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x65, 0x6e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x65, 0x6e, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x81, 0x01, 0x0a, 0x11, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6e, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x65, 0x6e, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x05, 0x72, 0x6f,
+	0x6c, 0x6c, 0x73, 0x22, 0x5b, 0x0a, 0x12, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0x29, 0x0a, 0x11, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x50, 0x61, 0x72, 0x74, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x22, 0x79, 0x0a, 0x12, 0x56,
+	0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x6d, 0x75, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x75, 0x74, 0x65, 0x64,
+	0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x75,
+	0x74, 0x65, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x61, 0x72, 0x74, 0x79, 0x22, 0x1a, 0x0a, 0x18, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0x62, 0x0a, 0x10, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73,
+	0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x67, 0x0a, 0x11, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22,
+	0x1a, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3b, 0x0a, 0x1d, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x1e, 0x0a, 0x1c, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x21, 0x0a, 0x1f, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xab, 0x01, 0x0a, 0x18,
+	0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x2a, 0x0a, 0x11,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x45,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x74, 0x22, 0xae, 0x01, 0x0a, 0x10, 0x46, 0x69,
+	0x6c, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x22, 0x5d, 0x0a, 0x10, 0x46, 0x69,
+	0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x5e, 0x0a, 0x13, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f,
+	0x6b, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x22, 0x50, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x49, 0x64, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a,
+	0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x12, 0x20, 0x0a,
+	0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22,
+	0x44, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x34, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x75,
+	0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x22, 0x66, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74,
+	0x22, 0x85, 0x01, 0x0a, 0x19, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x13, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x6d, 0x69, 0x6e, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61,
+	0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x22, 0x51, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61,
+	0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x0f,
+	0x44, 0x61, 0x74, 0x61, 0x50, 0x61, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x2e, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x42, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x61, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x22, 0x43, 0x0a, 0x17, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0xd5, 0x01, 0x0a, 0x18, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65,
+	0x72, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x38,
+	0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3b, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x22, 0xad, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x36,
+	0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x4d, 0x73, 0x22, 0x51, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x13, 0x50, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0xc3, 0x01, 0x0a, 0x15, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e,
+	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x6d, 0x62, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6d, 0x62, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x63, 0x6c, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x5f,
+	0x69, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0e, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x79, 0x12,
+	0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x4d, 0x73, 0x22, 0x4a, 0x0a, 0x1c, 0x53, 0x69,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70,
+	0x65, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x49, 0x0a,
+	0x18, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x2d, 0x0a, 0x19, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x04, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x44, 0x0a, 0x13, 0x41, 0x63, 0x74, 0x6f, 0x72,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x27, 0x0a,
+	0x13, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x39, 0x0a, 0x13, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67,
+	0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x22, 0xc8, 0x01, 0x0a, 0x0d, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x70, 0x65, 0x61, 0x6b, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x36, 0x0a, 0x07, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x13,
+	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x68, 0x6f, 0x6f, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x64,
+	0x22, 0x4a, 0x0a, 0x13, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x61, 0x6c, 0x6f,
+	0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x61, 0x6c,
+	0x6f, 0x67, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x11,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x22, 0x28, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x22, 0x2a, 0x0a, 0x12,
+	0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x22, 0x61, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x65, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66,
+	0x65, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x45, 0x0a, 0x1b, 0x41,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x6c, 0x6f,
+	0x73, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x21, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x22, 0x76, 0x0a, 0x13, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x8a, 0x01, 0x0a, 0x1a, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x42, 0x0a, 0x0d, 0x61, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x61,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x74, 0x53, 0x65,
+	0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x64, 0x0a, 0x10, 0x43, 0x68, 0x61,
+	0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22,
+	0x7b, 0x0a, 0x13, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4a, 0x0a, 0x13,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x69, 0x0a, 0x1a, 0x44, 0x65, 0x62, 0x75,
+	0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0x6e, 0x0a, 0x14, 0x44, 0x65, 0x62, 0x75, 0x67, 0x43, 0x6f, 0x6c, 0x6c,
+	0x69, 0x64, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64,
+	0x69, 0x75, 0x73, 0x22, 0x32, 0x0a, 0x14, 0x44, 0x65, 0x62, 0x75, 0x67, 0x57, 0x61, 0x79, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x22, 0x53, 0x0a, 0x15, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x61, 0x74, 0x22, 0xad, 0x03, 0x0a,
+	0x13, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12,
+	0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a,
+	0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64,
+	0x69, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6f, 0x69, 0x5f, 0x72, 0x61, 0x64, 0x69, 0x75,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x61, 0x6f, 0x69, 0x52, 0x61, 0x64, 0x69,
+	0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x72, 0x61,
+	0x64, 0x69, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x63, 0x6f, 0x6c,
+	0x6c, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x43, 0x6f, 0x6c, 0x6c,
+	0x69, 0x64, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x09, 0x63, 0x6f, 0x6c,
+	0x6c, 0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x09, 0x77, 0x61, 0x79, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x57, 0x61, 0x79, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x09, 0x77, 0x61, 0x79, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x12, 0x3e, 0x0a, 0x0a, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x5f,
+	0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x52, 0x61, 0x64, 0x69, 0x75, 0x73, 0x22, 0x62, 0x0a, 0x12,
+	0x42, 0x6f, 0x73, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x6f, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x6f, 0x73, 0x73, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x41, 0x74,
+	0x22, 0x4e, 0x0a, 0x13, 0x42, 0x6f, 0x73, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x73,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x6f,
+	0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x42, 0x6f, 0x73, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x73,
+	0x22, 0xae, 0x01, 0x0a, 0x0e, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
 	0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
 	0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x64,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
-	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65,
-	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
-	0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x22, 0x36, 0x0a, 0x16, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44,
-	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x52, 0x0a,
-	0x0c, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0c, 0x0a,
-	0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64,
-	0x69, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75,
-	0x73, 0x22, 0x31, 0x0a, 0x14, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
-	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x70, 0x6f,
-	0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x70, 0x6f,
-	0x72, 0x65, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x12, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61,
-	0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x70,
-	0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x52, 0x06, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x15, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x64, 0x22,
-	0x1c, 0x0a, 0x1a, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4e, 0x0a,
-	0x0e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x72,
-	0x61, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x4a, 0x0a,
-	0x13, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
-	0x08, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x1f, 0x46, 0x69, 0x6e,
-	0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x2a, 0x0a, 0x14,
-	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xde, 0x09, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a,
-	0x04, 0x63, 0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x49, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x43, 0x0a, 0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
-	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x73, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
-	0x52, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x53, 0x70, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05,
-	0x73, 0x70, 0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x63,
-	0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e,
-	0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d,
-	0x73, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x40, 0x0a,
-	0x0c, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70,
-	0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x48, 0x00, 0x52, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
-	0x49, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d,
-	0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
-	0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65,
-	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x15, 0x68, 0x69,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x6f, 0x75, 0x6e,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x22, 0x4a, 0x0a, 0x13, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x53, 0x70, 0x61, 0x77,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x65, 0x6d, 0x69, 0x74,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x08, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x73, 0x22, 0x29, 0x0a,
+	0x15, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x03, 0x69, 0x64, 0x73, 0x22, 0x3f, 0x0a, 0x0f, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x22, 0x8b, 0x02, 0x0a, 0x0b, 0x4b, 0x69,
+	0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x72,
+	0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x2d, 0x0a, 0x12, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6c,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x46, 0x0a, 0x11, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x50, 0x0a, 0x17, 0x43, 0x61, 0x70, 0x74, 0x63,
+	0x68, 0x61, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x19,
+	0x0a, 0x08, 0x73, 0x69, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x2e, 0x0a, 0x16, 0x43, 0x61, 0x70,
+	0x74, 0x63, 0x68, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x68, 0x0a, 0x12, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x44, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x74,
+	0x74, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x61, 0x74, 0x74, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x4c, 0x0a, 0x12, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x4b, 0x69, 0x6c, 0x6c,
+	0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x49,
+	0x64, 0x22, 0xc8, 0x01, 0x0a, 0x11, 0x4b, 0x69, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x73, 0x12, 0x2f, 0x0a, 0x05,
+	0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x3d, 0x0a,
+	0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x73, 0x22, 0xc2, 0x01, 0x0a,
+	0x0e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x6f,
+	0x67, 0x67, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x67, 0x65, 0x64, 0x49, 0x6e, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x22, 0x15, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x46, 0x0a, 0x0f, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0x54, 0x0a, 0x14, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x5f, 0x6f,
+	0x74, 0x68, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6c, 0x6c,
+	0x4f, 0x74, 0x68, 0x65, 0x72, 0x73, 0x22, 0x7a, 0x0a, 0x0f, 0x4e, 0x65, 0x77, 0x4c, 0x6f, 0x67,
+	0x69, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x06,
+	0x6e, 0x65, 0x77, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6e, 0x65,
+	0x77, 0x49, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x22, 0x45, 0x0a, 0x15, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x43, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x53, 0x61,
+	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x73, 0x69, 0x64, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f,
+	0x6e, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e,
+	0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x06, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0xad, 0x02, 0x0a, 0x0e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x61, 0x70, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x7a, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x7a, 0x6f, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x13, 0x0a, 0x05,
+	0x6d, 0x69, 0x6e, 0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x69, 0x6e,
+	0x58, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x04, 0x6d, 0x69, 0x6e, 0x59, 0x12, 0x13, 0x0a, 0x05, 0x6d, 0x61, 0x78, 0x5f, 0x78, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x61, 0x78, 0x58, 0x12, 0x13, 0x0a, 0x05, 0x6d,
+	0x61, 0x78, 0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x61, 0x78, 0x59,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x6f,
+	0x66, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x52, 0x10, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x4f, 0x66, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x65, 0x73, 0x74, 0x22, 0xe9, 0x45, 0x0a, 0x06, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x63,
+	0x68, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x04, 0x63, 0x68, 0x61, 0x74, 0x12, 0x24, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x64,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x02, 0x69, 0x64, 0x12, 0x43, 0x0a,
+	0x0d, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c,
+	0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3d, 0x0a, 0x0b, 0x6f, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0a, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x43, 0x0a, 0x0d, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x44, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x6e, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x10, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70,
+	0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x70,
+	0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x73, 0x75, 0x6d, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75,
+	0x6d, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x70,
+	0x6f, 0x72, 0x65, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x73,
+	0x70, 0x6f, 0x72, 0x65, 0x73, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x70, 0x6f, 0x72,
+	0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0b, 0x73, 0x70, 0x6f, 0x72, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x49, 0x0a,
+	0x0f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x73, 0x75, 0x6d, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x15, 0x68, 0x69, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13,
+	0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48,
+	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x68, 0x69, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0c, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x68, 0x0a,
+	0x1a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x6f, 0x77, 0x73, 0x69,
+	0x6e, 0x67, 0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x18, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48,
+	0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x3c, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x4d, 0x0a,
+	0x11, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x74, 0x69, 0x6d,
+	0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x50, 0x0a, 0x12,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x74, 0x69,
+	0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
+	0x0a, 0x12, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x11, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x58, 0x0a, 0x14, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x75,
+	0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65,
+	0x6e, 0x63, 0x65, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63,
+	0x65, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x36, 0x0a, 0x08,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x65, 0x73,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f,
+	0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x56, 0x0a, 0x14, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x5f, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x40, 0x0a, 0x0c, 0x67, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x53, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x67, 0x61, 0x74, 0x68, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x43, 0x0a, 0x0d, 0x67, 0x61, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x66, 0x69,
+	0x6e, 0x69, 0x73, 0x68, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x47, 0x61, 0x74, 0x68, 0x65, 0x72, 0x46, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x67, 0x61, 0x74, 0x68,
+	0x65, 0x72, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x12, 0x39, 0x0a, 0x09, 0x69, 0x6e, 0x76, 0x65,
+	0x6e, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x69, 0x6e, 0x76, 0x65, 0x6e, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x0d, 0x63, 0x72, 0x61, 0x66, 0x74, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x72, 0x61, 0x66, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x72, 0x61, 0x66,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x46, 0x0a, 0x0e, 0x75, 0x6e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x20, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x55, 0x6e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0d, 0x75, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x12, 0x3a, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x49,
+	0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x61, 0x70, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x69, 0x6d,
+	0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x6d, 0x69, 0x6e,
+	0x69, 0x6d, 0x61, 0x70, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6d, 0x61, 0x70, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x69, 0x6d, 0x61, 0x70, 0x12, 0x49,
+	0x0a, 0x0f, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x65,
+	0x70, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x4f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65, 0x70,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x6f, 0x6e, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65, 0x70, 0x12, 0x62, 0x0a, 0x18, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x6f, 0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x73, 0x74, 0x65, 0x70, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x4f, 0x6e,
+	0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65, 0x70, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x4f,
+	0x6e, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x65, 0x70, 0x12, 0x30, 0x0a,
+	0x06, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x12,
+	0x46, 0x0a, 0x0e, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x45, 0x6d, 0x6f, 0x74, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x12, 0x56, 0x0a, 0x14, 0x61, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x61,
+	0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x5c, 0x0a, 0x16, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x2a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x61, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x5c, 0x0a, 0x16, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x46, 0x0a,
+	0x0e, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x75, 0x79, 0x6f, 0x75, 0x74, 0x18,
+	0x2c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x41, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x75, 0x79, 0x6f, 0x75, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x75, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x49, 0x0a, 0x0f, 0x6d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78,
+	0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
-	0x52, 0x13, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65,
-	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x2e, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x07, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x68, 0x69,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x48, 0x69, 0x73, 0x63,
-	0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x0c, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x12,
-	0x68, 0x0a, 0x1a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x62, 0x72, 0x6f, 0x77,
-	0x73, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x11, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69,
-	0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e, 0x67, 0x48, 0x69,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
-	0x18, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x42, 0x72, 0x6f, 0x77, 0x73, 0x69, 0x6e,
-	0x67, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x5f, 0x68, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x48, 0x00, 0x52, 0x0d, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x48, 0x69, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18,
-	0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x42,
-	0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x42, 0x0d, 0x5a, 0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x0e, 0x6d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x33, 0x0a, 0x07, 0x6d, 0x61, 0x69, 0x6c, 0x62, 0x6f, 0x78, 0x18, 0x2e, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4d, 0x61, 0x69, 0x6c,
+	0x62, 0x6f, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x61,
+	0x69, 0x6c, 0x62, 0x6f, 0x78, 0x12, 0x3a, 0x0a, 0x0a, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x6d,
+	0x61, 0x69, 0x6c, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x4d, 0x61, 0x69, 0x6c, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x4d, 0x61, 0x69,
+	0x6c, 0x12, 0x5b, 0x0a, 0x15, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x30, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x65, 0x72,
+	0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73,
+	0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x5b,
+	0x0a, 0x15, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x31, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x58, 0x0a, 0x14, 0x69,
+	0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x49, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x13, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x59, 0x0a, 0x15, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x33,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x66, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x43, 0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x18, 0x34, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x56, 0x0a, 0x14, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x35, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a,
+	0x0c, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x36, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x46, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x73, 0x77, 0x69, 0x74, 0x63,
+	0x68, 0x18, 0x37, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x53, 0x77, 0x69, 0x74, 0x63, 0x68, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x38, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x6f, 0x6c, 0x6c,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x6c, 0x5f, 0x72,
+	0x65, 0x76, 0x65, 0x61, 0x6c, 0x18, 0x39, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x6f, 0x6c, 0x6c, 0x52,
+	0x65, 0x76, 0x65, 0x61, 0x6c, 0x12, 0x40, 0x0a, 0x0c, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x12, 0x3d, 0x0a, 0x0b, 0x76, 0x6f, 0x69, 0x63, 0x65,
+	0x5f, 0x70, 0x61, 0x72, 0x74, 0x79, 0x18, 0x3b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x50, 0x61, 0x72, 0x74,
+	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x76, 0x6f, 0x69, 0x63,
+	0x65, 0x50, 0x61, 0x72, 0x74, 0x79, 0x12, 0x40, 0x0a, 0x0c, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x3c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x76, 0x6f, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x53, 0x0a, 0x13, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x3d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x11, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x62, 0x0a,
+	0x18, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x3e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x5f, 0x0a, 0x17, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x18, 0x3f, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x15, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x12, 0x69, 0x0a, 0x1b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x18, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x53, 0x0a,
+	0x13, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x41, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x11, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x65, 0x72,
+	0x18, 0x42, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x3a,
+	0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x43, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x43, 0x0a, 0x0d, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x44, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12,
+	0x43, 0x0a, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x45, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x46, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x70, 0x75, 0x72,
+	0x63, 0x68, 0x61, 0x73, 0x65, 0x18, 0x47, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x75, 0x72, 0x63, 0x68,
+	0x61, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18, 0x48, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74,
+	0x12, 0x56, 0x0a, 0x14, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x49, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x70, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x70, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x18, 0x4a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x50, 0x61, 0x63, 0x6b, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x63,
+	0x6b, 0x12, 0x36, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x18, 0x4b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x12, 0x52, 0x0a, 0x12, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x4c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x30, 0x0a,
+	0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x18, 0x4d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x12,
+	0x49, 0x0a, 0x0f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x4e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x4f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48,
+	0x00, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12,
+	0x31, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x50, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x54,
+	0x6f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x65,
+	0x54, 0x6f, 0x12, 0x62, 0x0a, 0x18, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x51,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45,
+	0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x16,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a, 0x10, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x52, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x49, 0x0a, 0x0f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x63, 0x75, 0x65, 0x18, 0x53, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d,
+	0x65, 0x6e, 0x74, 0x43, 0x75, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0e, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x75, 0x65, 0x12,
+	0x43, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x54, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x5f, 0x0a, 0x17, 0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f,
+	0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x55, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x15,
+	0x73, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x53, 0x0a, 0x13, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x73,
+	0x70, 0x61, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x56, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74,
+	0x6f, 0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x11, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x70,
+	0x61, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x56, 0x0a, 0x14, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x5f, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x57, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x58, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x43, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x5f, 0x64, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x18, 0x59, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x65,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x12, 0x30, 0x0a, 0x06,
+	0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x18, 0x5a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x12, 0x43,
+	0x0a, 0x0d, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x5f, 0x63, 0x68, 0x6f, 0x6f, 0x73, 0x65, 0x18,
+	0x5b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x68, 0x6f, 0x6f, 0x73, 0x65, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x68, 0x6f,
+	0x6f, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x5f, 0x63, 0x6c,
+	0x6f, 0x73, 0x65, 0x64, 0x18, 0x5c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x69, 0x61, 0x6c, 0x6f, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x69, 0x61, 0x6c,
+	0x6f, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x5d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x18, 0x5e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x3f, 0x0a, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x18, 0x5f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x55, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x12, 0x42, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x60, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x0f, 0x61, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x61, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0e, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x5c, 0x0a, 0x16, 0x61, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69,
+	0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x62, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65,
+	0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x14, 0x61, 0x75, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64,
+	0x12, 0x6e, 0x0a, 0x1c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x63, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x1a, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x58, 0x0a, 0x14, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x37, 0x0a, 0x09, 0x63, 0x68,
+	0x61, 0x74, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x53, 0x65, 0x6e, 0x74,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x08, 0x63, 0x68, 0x61, 0x74, 0x53,
+	0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x63,
+	0x74, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x12,
+	0x43, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x67, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x74, 0x52, 0x65, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x18, 0x68, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x59, 0x0a, 0x15, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x69, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x13, 0x64, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0d, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x6c, 0x61, 0x79, 0x18, 0x6a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c,
+	0x61, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x64, 0x65, 0x62,
+	0x75, 0x67, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x12, 0x43, 0x0a, 0x0d, 0x62, 0x6f, 0x73,
+	0x73, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x6b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x42, 0x6f, 0x73, 0x73, 0x4c,
+	0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0c, 0x62, 0x6f, 0x73, 0x73, 0x4c, 0x6f, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x43,
+	0x0a, 0x0d, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x18,
+	0x6c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x53, 0x70,
+	0x61, 0x77, 0x6e, 0x12, 0x49, 0x0a, 0x0f, 0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x64,
+	0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x18, 0x6d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x44, 0x65,
+	0x73, 0x70, 0x61, 0x77, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e,
+	0x65, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x72, 0x44, 0x65, 0x73, 0x70, 0x61, 0x77, 0x6e, 0x12, 0x2a,
+	0x0a, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x18, 0x6e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4b, 0x69, 0x63, 0x6b, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x63, 0x6b, 0x12, 0x4f, 0x0a, 0x11, 0x63, 0x61,
+	0x70, 0x74, 0x63, 0x68, 0x61, 0x5f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x18,
+	0x6f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x43, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x10, 0x63, 0x61, 0x70, 0x74, 0x63,
+	0x68, 0x61, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x4c, 0x0a, 0x10, 0x63,
+	0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x70, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x43, 0x61, 0x70, 0x74, 0x63, 0x68, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x61, 0x70, 0x74, 0x63, 0x68,
+	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x5f, 0x64, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x71, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f, 0x72, 0x44,
+	0x61, 0x6d, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0b,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x61, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0c, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x72, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x6f,
+	0x72, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x3d, 0x0a,
+	0x0b, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x18, 0x73, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4b, 0x69, 0x6c,
+	0x6c, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0a, 0x6b, 0x69, 0x6c, 0x6c, 0x43, 0x72, 0x65, 0x64, 0x69, 0x74, 0x12, 0x43, 0x0a, 0x0d,
+	0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x74, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x48, 0x00, 0x52, 0x0c, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x36, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x75, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x6c, 0x6f, 0x67,
+	0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x76, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x48, 0x00, 0x52, 0x0d, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x37, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x77,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4e,
+	0x65, 0x77, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x08, 0x6e, 0x65, 0x77, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x49, 0x0a, 0x0f, 0x73, 0x61,
+	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x78, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x61,
+	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
+	0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x79, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0d,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x53, 0x0a,
+	0x13, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x7a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x11, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x18, 0x7b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x49, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x36, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x18, 0x7c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x7d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x6f,
+	0x67, 0x69, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x2a,
+	0xfd, 0x01, 0x0a, 0x10, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x1d, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45,
+	0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x49, 0x53, 0x43, 0x4f,
+	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4b, 0x49, 0x43,
+	0x4b, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e,
+	0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x42, 0x41, 0x4e, 0x4e, 0x45,
+	0x44, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
+	0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54,
+	0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e,
+	0x10, 0x04, 0x12, 0x24, 0x0a, 0x20, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x43, 0x4f, 0x4c,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x12, 0x25, 0x0a, 0x21, 0x44, 0x49, 0x53, 0x43,
+	0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x44, 0x55,
+	0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x10, 0x06, 0x42,
+	0x0d, 0x5a, 0x0b, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1402,54 +11695,315 @@ func file_packets_proto_rawDescGZIP() []byte {
 	return file_packets_proto_rawDescData
 }
 
-var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_packets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 145)
 var file_packets_proto_goTypes = []any{
-	(*ChatMessage)(nil),                     // 0: packets.ChatMessage
-	(*IdMessage)(nil),                       // 1: packets.IdMessage
-	(*LoginRequestMessage)(nil),             // 2: packets.LoginRequestMessage
-	(*RegisterRequestMessage)(nil),          // 3: packets.RegisterRequestMessage
-	(*OkResponseMessage)(nil),               // 4: packets.OkResponseMessage
-	(*DenyResponseMessage)(nil),             // 5: packets.DenyResponseMessage
-	(*PlayerMessage)(nil),                   // 6: packets.PlayerMessage
-	(*PlayerDirectionMessage)(nil),          // 7: packets.PlayerDirectionMessage
-	(*SporeMessage)(nil),                    // 8: packets.SporeMessage
-	(*SporeConsumedMessage)(nil),            // 9: packets.SporeConsumedMessage
-	(*SporesBatchMessage)(nil),              // 10: packets.SporesBatchMessage
-	(*PlayerConsumedMessage)(nil),           // 11: packets.PlayerConsumedMessage
-	(*HiscoreBoardRequestMessage)(nil),      // 12: packets.HiscoreBoardRequestMessage
-	(*HiscoreMessage)(nil),                  // 13: packets.HiscoreMessage
-	(*HiscoreBoardMessage)(nil),             // 14: packets.HiscoreBoardMessage
-	(*FinishedBrowsingHiscoresMessage)(nil), // 15: packets.FinishedBrowsingHiscoresMessage
-	(*SearchHiscoreMessage)(nil),            // 16: packets.SearchHiscoreMessage
-	(*DisconnectMessage)(nil),               // 17: packets.DisconnectMessage
-	(*Packet)(nil),                          // 18: packets.Packet
+	(DisconnectReason)(0),                     // 0: packets.DisconnectReason
+	(*ChatMessage)(nil),                       // 1: packets.ChatMessage
+	(*IdMessage)(nil),                         // 2: packets.IdMessage
+	(*LoginRequestMessage)(nil),               // 3: packets.LoginRequestMessage
+	(*LoginTokenMessage)(nil),                 // 4: packets.LoginTokenMessage
+	(*RegisterRequestMessage)(nil),            // 5: packets.RegisterRequestMessage
+	(*OkResponseMessage)(nil),                 // 6: packets.OkResponseMessage
+	(*DenyResponseMessage)(nil),               // 7: packets.DenyResponseMessage
+	(*PlayerMessage)(nil),                     // 8: packets.PlayerMessage
+	(*PlayerDirectionMessage)(nil),            // 9: packets.PlayerDirectionMessage
+	(*MoveToMessage)(nil),                     // 10: packets.MoveToMessage
+	(*SporeMessage)(nil),                      // 11: packets.SporeMessage
+	(*SporeConsumedMessage)(nil),              // 12: packets.SporeConsumedMessage
+	(*SporesBatchMessage)(nil),                // 13: packets.SporesBatchMessage
+	(*PlayerConsumedMessage)(nil),             // 14: packets.PlayerConsumedMessage
+	(*HiscoreBoardRequestMessage)(nil),        // 15: packets.HiscoreBoardRequestMessage
+	(*HiscoreMessage)(nil),                    // 16: packets.HiscoreMessage
+	(*HiscoreBoardMessage)(nil),               // 17: packets.HiscoreBoardMessage
+	(*FinishedBrowsingHiscoresMessage)(nil),   // 18: packets.FinishedBrowsingHiscoresMessage
+	(*SearchHiscoreMessage)(nil),              // 19: packets.SearchHiscoreMessage
+	(*DisconnectMessage)(nil),                 // 20: packets.DisconnectMessage
+	(*TimeSyncRequestMessage)(nil),            // 21: packets.TimeSyncRequestMessage
+	(*TimeSyncResponseMessage)(nil),           // 22: packets.TimeSyncResponseMessage
+	(*PresenceSubscribeMessage)(nil),          // 23: packets.PresenceSubscribeMessage
+	(*PresenceUnsubscribeMessage)(nil),        // 24: packets.PresenceUnsubscribeMessage
+	(*PresenceMessage)(nil),                   // 25: packets.PresenceMessage
+	(*ResourceNodeMessage)(nil),               // 26: packets.ResourceNodeMessage
+	(*ResourceNodesBatchMessage)(nil),         // 27: packets.ResourceNodesBatchMessage
+	(*GatherStartMessage)(nil),                // 28: packets.GatherStartMessage
+	(*GatherFinishMessage)(nil),               // 29: packets.GatherFinishMessage
+	(*ItemStackMessage)(nil),                  // 30: packets.ItemStackMessage
+	(*InventoryMessage)(nil),                  // 31: packets.InventoryMessage
+	(*CraftRequestMessage)(nil),               // 32: packets.CraftRequestMessage
+	(*BlockPlayerMessage)(nil),                // 33: packets.BlockPlayerMessage
+	(*UnblockPlayerMessage)(nil),              // 34: packets.UnblockPlayerMessage
+	(*BlockListMessage)(nil),                  // 35: packets.BlockListMessage
+	(*MinimapRequestMessage)(nil),             // 36: packets.MinimapRequestMessage
+	(*PointOfInterestMessage)(nil),            // 37: packets.PointOfInterestMessage
+	(*OnboardingStepMessage)(nil),             // 38: packets.OnboardingStepMessage
+	(*CompleteOnboardingStepMessage)(nil),     // 39: packets.CompleteOnboardingStepMessage
+	(*EmoteMessage)(nil),                      // 40: packets.EmoteMessage
+	(*EmotesMessage)(nil),                     // 41: packets.EmotesMessage
+	(*EmoteUnlockedMessage)(nil),              // 42: packets.EmoteUnlockedMessage
+	(*CustomMessage)(nil),                     // 43: packets.CustomMessage
+	(*AuctionListRequestMessage)(nil),         // 44: packets.AuctionListRequestMessage
+	(*AuctionSearchRequestMessage)(nil),       // 45: packets.AuctionSearchRequestMessage
+	(*AuctionListingMessage)(nil),             // 46: packets.AuctionListingMessage
+	(*AuctionSearchResultsMessage)(nil),       // 47: packets.AuctionSearchResultsMessage
+	(*AuctionBuyoutMessage)(nil),              // 48: packets.AuctionBuyoutMessage
+	(*MailboxRequestMessage)(nil),             // 49: packets.MailboxRequestMessage
+	(*MailMessage)(nil),                       // 50: packets.MailMessage
+	(*MailboxMessage)(nil),                    // 51: packets.MailboxMessage
+	(*ClaimMailMessage)(nil),                  // 52: packets.ClaimMailMessage
+	(*ImpersonationRequestMessage)(nil),       // 53: packets.ImpersonationRequestMessage
+	(*ImpersonationConsentMessage)(nil),       // 54: packets.ImpersonationConsentMessage
+	(*ImpersonationStatusMessage)(nil),        // 55: packets.ImpersonationStatusMessage
+	(*FeatureFlagsRequestMessage)(nil),        // 56: packets.FeatureFlagsRequestMessage
+	(*FeatureFlagsMessage)(nil),               // 57: packets.FeatureFlagsMessage
+	(*EntitlementListRequestMessage)(nil),     // 58: packets.EntitlementListRequestMessage
+	(*EntitlementListMessage)(nil),            // 59: packets.EntitlementListMessage
+	(*ChannelListRequestMessage)(nil),         // 60: packets.ChannelListRequestMessage
+	(*ChannelInfoMessage)(nil),                // 61: packets.ChannelInfoMessage
+	(*ChannelListMessage)(nil),                // 62: packets.ChannelListMessage
+	(*ChannelSwitchMessage)(nil),              // 63: packets.ChannelSwitchMessage
+	(*RollCommitMessage)(nil),                 // 64: packets.RollCommitMessage
+	(*RollRevealMessage)(nil),                 // 65: packets.RollRevealMessage
+	(*VoiceSignalMessage)(nil),                // 66: packets.VoiceSignalMessage
+	(*VoicePartyMessage)(nil),                 // 67: packets.VoicePartyMessage
+	(*VoiceStatusMessage)(nil),                // 68: packets.VoiceStatusMessage
+	(*IceServersRequestMessage)(nil),          // 69: packets.IceServersRequestMessage
+	(*IceServerMessage)(nil),                  // 70: packets.IceServerMessage
+	(*IceServersMessage)(nil),                 // 71: packets.IceServersMessage
+	(*DataExportRequestMessage)(nil),          // 72: packets.DataExportRequestMessage
+	(*AccountDeletionRequestMessage)(nil),     // 73: packets.AccountDeletionRequestMessage
+	(*AccountDeletionCancelMessage)(nil),      // 74: packets.AccountDeletionCancelMessage
+	(*AccountDataStatusRequestMessage)(nil),   // 75: packets.AccountDataStatusRequestMessage
+	(*AccountDataStatusMessage)(nil),          // 76: packets.AccountDataStatusMessage
+	(*FileOfferMessage)(nil),                  // 77: packets.FileOfferMessage
+	(*FileChunkMessage)(nil),                  // 78: packets.FileChunkMessage
+	(*FileReceivedMessage)(nil),               // 79: packets.FileReceivedMessage
+	(*StoreRequestMessage)(nil),               // 80: packets.StoreRequestMessage
+	(*StoreProductMessage)(nil),               // 81: packets.StoreProductMessage
+	(*StoreEntryMessage)(nil),                 // 82: packets.StoreEntryMessage
+	(*StoreMessage)(nil),                      // 83: packets.StoreMessage
+	(*StorePurchaseMessage)(nil),              // 84: packets.StorePurchaseMessage
+	(*StoreReceiptMessage)(nil),               // 85: packets.StoreReceiptMessage
+	(*StoreReceiptStatusMessage)(nil),         // 86: packets.StoreReceiptStatusMessage
+	(*ClientUpdateMessage)(nil),               // 87: packets.ClientUpdateMessage
+	(*DataFileMessage)(nil),                   // 88: packets.DataFileMessage
+	(*DataPackMessage)(nil),                   // 89: packets.DataPackMessage
+	(*InteractMessage)(nil),                   // 90: packets.InteractMessage
+	(*InteractionFieldMessage)(nil),           // 91: packets.InteractionFieldMessage
+	(*InteractionResultMessage)(nil),          // 92: packets.InteractionResultMessage
+	(*PromptOptionMessage)(nil),               // 93: packets.PromptOptionMessage
+	(*PromptMessage)(nil),                     // 94: packets.PromptMessage
+	(*PromptResponseMessage)(nil),             // 95: packets.PromptResponseMessage
+	(*PromptClosedMessage)(nil),               // 96: packets.PromptClosedMessage
+	(*EnvironmentCueMessage)(nil),             // 97: packets.EnvironmentCueMessage
+	(*SimulatorLoginRequestMessage)(nil),      // 98: packets.SimulatorLoginRequestMessage
+	(*ActorMessage)(nil),                      // 99: packets.ActorMessage
+	(*ActorSpawnRequestMessage)(nil),          // 100: packets.ActorSpawnRequestMessage
+	(*ActorSpawnResponseMessage)(nil),         // 101: packets.ActorSpawnResponseMessage
+	(*ActorUpdatesMessage)(nil),               // 102: packets.ActorUpdatesMessage
+	(*ActorDespawnMessage)(nil),               // 103: packets.ActorDespawnMessage
+	(*DialogChoiceMessage)(nil),               // 104: packets.DialogChoiceMessage
+	(*DialogMessage)(nil),                     // 105: packets.DialogMessage
+	(*DialogChooseMessage)(nil),               // 106: packets.DialogChooseMessage
+	(*DialogClosedMessage)(nil),               // 107: packets.DialogClosedMessage
+	(*QuestStageMessage)(nil),                 // 108: packets.QuestStageMessage
+	(*SubscribeMessage)(nil),                  // 109: packets.SubscribeMessage
+	(*UnsubscribeMessage)(nil),                // 110: packets.UnsubscribeMessage
+	(*SubscriptionMessage)(nil),               // 111: packets.SubscriptionMessage
+	(*AuctionListingClosedMessage)(nil),       // 112: packets.AuctionListingClosedMessage
+	(*AnnouncementHistoryRequestMessage)(nil), // 113: packets.AnnouncementHistoryRequestMessage
+	(*AnnouncementMessage)(nil),               // 114: packets.AnnouncementMessage
+	(*AnnouncementHistoryMessage)(nil),        // 115: packets.AnnouncementHistoryMessage
+	(*ChatSentMessage)(nil),                   // 116: packets.ChatSentMessage
+	(*ChatReactMessage)(nil),                  // 117: packets.ChatReactMessage
+	(*ChatReactionMessage)(nil),               // 118: packets.ChatReactionMessage
+	(*ReportPlayerMessage)(nil),               // 119: packets.ReportPlayerMessage
+	(*DebugOverlayRequestMessage)(nil),        // 120: packets.DebugOverlayRequestMessage
+	(*DebugColliderMessage)(nil),              // 121: packets.DebugColliderMessage
+	(*DebugWaypointMessage)(nil),              // 122: packets.DebugWaypointMessage
+	(*DebugRejectionMessage)(nil),             // 123: packets.DebugRejectionMessage
+	(*DebugOverlayMessage)(nil),               // 124: packets.DebugOverlayMessage
+	(*BossLockoutMessage)(nil),                // 125: packets.BossLockoutMessage
+	(*BossLockoutsMessage)(nil),               // 126: packets.BossLockoutsMessage
+	(*EmitterMessage)(nil),                    // 127: packets.EmitterMessage
+	(*EmitterSpawnMessage)(nil),               // 128: packets.EmitterSpawnMessage
+	(*EmitterDespawnMessage)(nil),             // 129: packets.EmitterDespawnMessage
+	(*InterestMessage)(nil),                   // 130: packets.InterestMessage
+	(*KickMessage)(nil),                       // 131: packets.KickMessage
+	(*CaptchaChallengeMessage)(nil),           // 132: packets.CaptchaChallengeMessage
+	(*CaptchaResponseMessage)(nil),            // 133: packets.CaptchaResponseMessage
+	(*ActorDamageMessage)(nil),                // 134: packets.ActorDamageMessage
+	(*ActorKilledMessage)(nil),                // 135: packets.ActorKilledMessage
+	(*KillCreditMessage)(nil),                 // 136: packets.KillCreditMessage
+	(*SessionMessage)(nil),                    // 137: packets.SessionMessage
+	(*ListSessionsMessage)(nil),               // 138: packets.ListSessionsMessage
+	(*SessionsMessage)(nil),                   // 139: packets.SessionsMessage
+	(*LogoutSessionMessage)(nil),              // 140: packets.LogoutSessionMessage
+	(*NewLoginMessage)(nil),                   // 141: packets.NewLoginMessage
+	(*SandboxCommandMessage)(nil),             // 142: packets.SandboxCommandMessage
+	(*SandboxStatusMessage)(nil),              // 143: packets.SandboxStatusMessage
+	(*MinimapMessage)(nil),                    // 144: packets.MinimapMessage
+	(*Packet)(nil),                            // 145: packets.Packet
 }
 var file_packets_proto_depIdxs = []int32{
-	8,  // 0: packets.SporesBatchMessage.spores:type_name -> packets.SporeMessage
-	13, // 1: packets.HiscoreBoardMessage.hiscores:type_name -> packets.HiscoreMessage
-	0,  // 2: packets.Packet.chat:type_name -> packets.ChatMessage
-	1,  // 3: packets.Packet.id:type_name -> packets.IdMessage
-	2,  // 4: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
-	3,  // 5: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
-	4,  // 6: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
-	5,  // 7: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
-	6,  // 8: packets.Packet.player:type_name -> packets.PlayerMessage
-	7,  // 9: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
-	8,  // 10: packets.Packet.spore:type_name -> packets.SporeMessage
-	9,  // 11: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
-	10, // 12: packets.Packet.spores_batch:type_name -> packets.SporesBatchMessage
-	11, // 13: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
-	12, // 14: packets.Packet.hiscore_board_request:type_name -> packets.HiscoreBoardRequestMessage
-	13, // 15: packets.Packet.hiscore:type_name -> packets.HiscoreMessage
-	14, // 16: packets.Packet.hiscore_board:type_name -> packets.HiscoreBoardMessage
-	15, // 17: packets.Packet.finished_browsing_hiscores:type_name -> packets.FinishedBrowsingHiscoresMessage
-	16, // 18: packets.Packet.search_hiscore:type_name -> packets.SearchHiscoreMessage
-	17, // 19: packets.Packet.disconnect:type_name -> packets.DisconnectMessage
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	11,  // 0: packets.SporesBatchMessage.spores:type_name -> packets.SporeMessage
+	16,  // 1: packets.HiscoreBoardMessage.hiscores:type_name -> packets.HiscoreMessage
+	26,  // 2: packets.ResourceNodesBatchMessage.resource_nodes:type_name -> packets.ResourceNodeMessage
+	30,  // 3: packets.InventoryMessage.items:type_name -> packets.ItemStackMessage
+	40,  // 4: packets.EmotesMessage.emotes:type_name -> packets.EmoteMessage
+	46,  // 5: packets.AuctionSearchResultsMessage.listings:type_name -> packets.AuctionListingMessage
+	50,  // 6: packets.MailboxMessage.mail:type_name -> packets.MailMessage
+	61,  // 7: packets.ChannelListMessage.channels:type_name -> packets.ChannelInfoMessage
+	70,  // 8: packets.IceServersMessage.servers:type_name -> packets.IceServerMessage
+	81,  // 9: packets.StoreEntryMessage.products:type_name -> packets.StoreProductMessage
+	82,  // 10: packets.StoreMessage.entries:type_name -> packets.StoreEntryMessage
+	88,  // 11: packets.DataPackMessage.files:type_name -> packets.DataFileMessage
+	91,  // 12: packets.InteractionResultMessage.fields:type_name -> packets.InteractionFieldMessage
+	93,  // 13: packets.PromptMessage.options:type_name -> packets.PromptOptionMessage
+	99,  // 14: packets.ActorSpawnRequestMessage.actors:type_name -> packets.ActorMessage
+	99,  // 15: packets.ActorUpdatesMessage.actors:type_name -> packets.ActorMessage
+	104, // 16: packets.DialogMessage.choices:type_name -> packets.DialogChoiceMessage
+	114, // 17: packets.AnnouncementHistoryMessage.announcements:type_name -> packets.AnnouncementMessage
+	121, // 18: packets.DebugOverlayMessage.colliders:type_name -> packets.DebugColliderMessage
+	122, // 19: packets.DebugOverlayMessage.waypoints:type_name -> packets.DebugWaypointMessage
+	123, // 20: packets.DebugOverlayMessage.rejections:type_name -> packets.DebugRejectionMessage
+	125, // 21: packets.BossLockoutsMessage.lockouts:type_name -> packets.BossLockoutMessage
+	127, // 22: packets.EmitterSpawnMessage.emitters:type_name -> packets.EmitterMessage
+	0,   // 23: packets.KickMessage.disconnect_reason:type_name -> packets.DisconnectReason
+	30,  // 24: packets.KillCreditMessage.items:type_name -> packets.ItemStackMessage
+	108, // 25: packets.KillCreditMessage.quest_stages:type_name -> packets.QuestStageMessage
+	137, // 26: packets.SessionsMessage.sessions:type_name -> packets.SessionMessage
+	137, // 27: packets.NewLoginMessage.session:type_name -> packets.SessionMessage
+	37,  // 28: packets.MinimapMessage.points_of_interest:type_name -> packets.PointOfInterestMessage
+	1,   // 29: packets.Packet.chat:type_name -> packets.ChatMessage
+	2,   // 30: packets.Packet.id:type_name -> packets.IdMessage
+	3,   // 31: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
+	5,   // 32: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
+	6,   // 33: packets.Packet.ok_response:type_name -> packets.OkResponseMessage
+	7,   // 34: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
+	8,   // 35: packets.Packet.player:type_name -> packets.PlayerMessage
+	9,   // 36: packets.Packet.player_direction:type_name -> packets.PlayerDirectionMessage
+	11,  // 37: packets.Packet.spore:type_name -> packets.SporeMessage
+	12,  // 38: packets.Packet.spore_consumed:type_name -> packets.SporeConsumedMessage
+	13,  // 39: packets.Packet.spores_batch:type_name -> packets.SporesBatchMessage
+	14,  // 40: packets.Packet.player_consumed:type_name -> packets.PlayerConsumedMessage
+	15,  // 41: packets.Packet.hiscore_board_request:type_name -> packets.HiscoreBoardRequestMessage
+	16,  // 42: packets.Packet.hiscore:type_name -> packets.HiscoreMessage
+	17,  // 43: packets.Packet.hiscore_board:type_name -> packets.HiscoreBoardMessage
+	18,  // 44: packets.Packet.finished_browsing_hiscores:type_name -> packets.FinishedBrowsingHiscoresMessage
+	19,  // 45: packets.Packet.search_hiscore:type_name -> packets.SearchHiscoreMessage
+	20,  // 46: packets.Packet.disconnect:type_name -> packets.DisconnectMessage
+	21,  // 47: packets.Packet.time_sync_request:type_name -> packets.TimeSyncRequestMessage
+	22,  // 48: packets.Packet.time_sync_response:type_name -> packets.TimeSyncResponseMessage
+	23,  // 49: packets.Packet.presence_subscribe:type_name -> packets.PresenceSubscribeMessage
+	24,  // 50: packets.Packet.presence_unsubscribe:type_name -> packets.PresenceUnsubscribeMessage
+	25,  // 51: packets.Packet.presence:type_name -> packets.PresenceMessage
+	26,  // 52: packets.Packet.resource_node:type_name -> packets.ResourceNodeMessage
+	27,  // 53: packets.Packet.resource_nodes_batch:type_name -> packets.ResourceNodesBatchMessage
+	28,  // 54: packets.Packet.gather_start:type_name -> packets.GatherStartMessage
+	29,  // 55: packets.Packet.gather_finish:type_name -> packets.GatherFinishMessage
+	31,  // 56: packets.Packet.inventory:type_name -> packets.InventoryMessage
+	32,  // 57: packets.Packet.craft_request:type_name -> packets.CraftRequestMessage
+	33,  // 58: packets.Packet.block_player:type_name -> packets.BlockPlayerMessage
+	34,  // 59: packets.Packet.unblock_player:type_name -> packets.UnblockPlayerMessage
+	35,  // 60: packets.Packet.block_list:type_name -> packets.BlockListMessage
+	36,  // 61: packets.Packet.minimap_request:type_name -> packets.MinimapRequestMessage
+	144, // 62: packets.Packet.minimap:type_name -> packets.MinimapMessage
+	38,  // 63: packets.Packet.onboarding_step:type_name -> packets.OnboardingStepMessage
+	39,  // 64: packets.Packet.complete_onboarding_step:type_name -> packets.CompleteOnboardingStepMessage
+	41,  // 65: packets.Packet.emotes:type_name -> packets.EmotesMessage
+	42,  // 66: packets.Packet.emote_unlocked:type_name -> packets.EmoteUnlockedMessage
+	43,  // 67: packets.Packet.custom:type_name -> packets.CustomMessage
+	44,  // 68: packets.Packet.auction_list_request:type_name -> packets.AuctionListRequestMessage
+	45,  // 69: packets.Packet.auction_search_request:type_name -> packets.AuctionSearchRequestMessage
+	47,  // 70: packets.Packet.auction_search_results:type_name -> packets.AuctionSearchResultsMessage
+	48,  // 71: packets.Packet.auction_buyout:type_name -> packets.AuctionBuyoutMessage
+	49,  // 72: packets.Packet.mailbox_request:type_name -> packets.MailboxRequestMessage
+	51,  // 73: packets.Packet.mailbox:type_name -> packets.MailboxMessage
+	52,  // 74: packets.Packet.claim_mail:type_name -> packets.ClaimMailMessage
+	53,  // 75: packets.Packet.impersonation_request:type_name -> packets.ImpersonationRequestMessage
+	54,  // 76: packets.Packet.impersonation_consent:type_name -> packets.ImpersonationConsentMessage
+	55,  // 77: packets.Packet.impersonation_status:type_name -> packets.ImpersonationStatusMessage
+	56,  // 78: packets.Packet.feature_flags_request:type_name -> packets.FeatureFlagsRequestMessage
+	57,  // 79: packets.Packet.feature_flags:type_name -> packets.FeatureFlagsMessage
+	60,  // 80: packets.Packet.channel_list_request:type_name -> packets.ChannelListRequestMessage
+	62,  // 81: packets.Packet.channel_list:type_name -> packets.ChannelListMessage
+	63,  // 82: packets.Packet.channel_switch:type_name -> packets.ChannelSwitchMessage
+	64,  // 83: packets.Packet.roll_commit:type_name -> packets.RollCommitMessage
+	65,  // 84: packets.Packet.roll_reveal:type_name -> packets.RollRevealMessage
+	66,  // 85: packets.Packet.voice_signal:type_name -> packets.VoiceSignalMessage
+	67,  // 86: packets.Packet.voice_party:type_name -> packets.VoicePartyMessage
+	68,  // 87: packets.Packet.voice_status:type_name -> packets.VoiceStatusMessage
+	72,  // 88: packets.Packet.data_export_request:type_name -> packets.DataExportRequestMessage
+	73,  // 89: packets.Packet.account_deletion_request:type_name -> packets.AccountDeletionRequestMessage
+	74,  // 90: packets.Packet.account_deletion_cancel:type_name -> packets.AccountDeletionCancelMessage
+	75,  // 91: packets.Packet.account_data_status_request:type_name -> packets.AccountDataStatusRequestMessage
+	76,  // 92: packets.Packet.account_data_status:type_name -> packets.AccountDataStatusMessage
+	77,  // 93: packets.Packet.file_offer:type_name -> packets.FileOfferMessage
+	78,  // 94: packets.Packet.file_chunk:type_name -> packets.FileChunkMessage
+	79,  // 95: packets.Packet.file_received:type_name -> packets.FileReceivedMessage
+	80,  // 96: packets.Packet.store_request:type_name -> packets.StoreRequestMessage
+	83,  // 97: packets.Packet.store:type_name -> packets.StoreMessage
+	84,  // 98: packets.Packet.store_purchase:type_name -> packets.StorePurchaseMessage
+	85,  // 99: packets.Packet.store_receipt:type_name -> packets.StoreReceiptMessage
+	86,  // 100: packets.Packet.store_receipt_status:type_name -> packets.StoreReceiptStatusMessage
+	89,  // 101: packets.Packet.data_pack:type_name -> packets.DataPackMessage
+	90,  // 102: packets.Packet.interact:type_name -> packets.InteractMessage
+	92,  // 103: packets.Packet.interaction_result:type_name -> packets.InteractionResultMessage
+	94,  // 104: packets.Packet.prompt:type_name -> packets.PromptMessage
+	95,  // 105: packets.Packet.prompt_response:type_name -> packets.PromptResponseMessage
+	96,  // 106: packets.Packet.prompt_closed:type_name -> packets.PromptClosedMessage
+	10,  // 107: packets.Packet.move_to:type_name -> packets.MoveToMessage
+	58,  // 108: packets.Packet.entitlement_list_request:type_name -> packets.EntitlementListRequestMessage
+	59,  // 109: packets.Packet.entitlement_list:type_name -> packets.EntitlementListMessage
+	97,  // 110: packets.Packet.environment_cue:type_name -> packets.EnvironmentCueMessage
+	87,  // 111: packets.Packet.client_update:type_name -> packets.ClientUpdateMessage
+	98,  // 112: packets.Packet.simulator_login_request:type_name -> packets.SimulatorLoginRequestMessage
+	100, // 113: packets.Packet.actor_spawn_request:type_name -> packets.ActorSpawnRequestMessage
+	101, // 114: packets.Packet.actor_spawn_response:type_name -> packets.ActorSpawnResponseMessage
+	102, // 115: packets.Packet.actor_updates:type_name -> packets.ActorUpdatesMessage
+	103, // 116: packets.Packet.actor_despawn:type_name -> packets.ActorDespawnMessage
+	105, // 117: packets.Packet.dialog:type_name -> packets.DialogMessage
+	106, // 118: packets.Packet.dialog_choose:type_name -> packets.DialogChooseMessage
+	107, // 119: packets.Packet.dialog_closed:type_name -> packets.DialogClosedMessage
+	108, // 120: packets.Packet.quest_stage:type_name -> packets.QuestStageMessage
+	109, // 121: packets.Packet.subscribe:type_name -> packets.SubscribeMessage
+	110, // 122: packets.Packet.unsubscribe:type_name -> packets.UnsubscribeMessage
+	111, // 123: packets.Packet.subscription:type_name -> packets.SubscriptionMessage
+	46,  // 124: packets.Packet.auction_listing:type_name -> packets.AuctionListingMessage
+	112, // 125: packets.Packet.auction_listing_closed:type_name -> packets.AuctionListingClosedMessage
+	113, // 126: packets.Packet.announcement_history_request:type_name -> packets.AnnouncementHistoryRequestMessage
+	115, // 127: packets.Packet.announcement_history:type_name -> packets.AnnouncementHistoryMessage
+	116, // 128: packets.Packet.chat_sent:type_name -> packets.ChatSentMessage
+	117, // 129: packets.Packet.chat_react:type_name -> packets.ChatReactMessage
+	118, // 130: packets.Packet.chat_reaction:type_name -> packets.ChatReactionMessage
+	119, // 131: packets.Packet.report_player:type_name -> packets.ReportPlayerMessage
+	120, // 132: packets.Packet.debug_overlay_request:type_name -> packets.DebugOverlayRequestMessage
+	124, // 133: packets.Packet.debug_overlay:type_name -> packets.DebugOverlayMessage
+	126, // 134: packets.Packet.boss_lockouts:type_name -> packets.BossLockoutsMessage
+	128, // 135: packets.Packet.emitter_spawn:type_name -> packets.EmitterSpawnMessage
+	129, // 136: packets.Packet.emitter_despawn:type_name -> packets.EmitterDespawnMessage
+	131, // 137: packets.Packet.kick:type_name -> packets.KickMessage
+	132, // 138: packets.Packet.captcha_challenge:type_name -> packets.CaptchaChallengeMessage
+	133, // 139: packets.Packet.captcha_response:type_name -> packets.CaptchaResponseMessage
+	134, // 140: packets.Packet.actor_damage:type_name -> packets.ActorDamageMessage
+	135, // 141: packets.Packet.actor_killed:type_name -> packets.ActorKilledMessage
+	136, // 142: packets.Packet.kill_credit:type_name -> packets.KillCreditMessage
+	138, // 143: packets.Packet.list_sessions:type_name -> packets.ListSessionsMessage
+	139, // 144: packets.Packet.sessions:type_name -> packets.SessionsMessage
+	140, // 145: packets.Packet.logout_session:type_name -> packets.LogoutSessionMessage
+	141, // 146: packets.Packet.new_login:type_name -> packets.NewLoginMessage
+	142, // 147: packets.Packet.sandbox_command:type_name -> packets.SandboxCommandMessage
+	143, // 148: packets.Packet.sandbox_status:type_name -> packets.SandboxStatusMessage
+	69,  // 149: packets.Packet.ice_servers_request:type_name -> packets.IceServersRequestMessage
+	71,  // 150: packets.Packet.ice_servers:type_name -> packets.IceServersMessage
+	130, // 151: packets.Packet.interest:type_name -> packets.InterestMessage
+	4,   // 152: packets.Packet.login_token:type_name -> packets.LoginTokenMessage
+	153, // [153:153] is the sub-list for method output_type
+	153, // [153:153] is the sub-list for method input_type
+	153, // [153:153] is the sub-list for extension type_name
+	153, // [153:153] is the sub-list for extension extendee
+	0,   // [0:153] is the sub-list for field type_name
 }
 
 func init() { file_packets_proto_init() }
@@ -1457,7 +12011,7 @@ func file_packets_proto_init() {
 	if File_packets_proto != nil {
 		return
 	}
-	file_packets_proto_msgTypes[18].OneofWrappers = []any{
+	file_packets_proto_msgTypes[144].OneofWrappers = []any{
 		(*Packet_Chat)(nil),
 		(*Packet_Id)(nil),
 		(*Packet_LoginRequest)(nil),
@@ -1476,19 +12030,126 @@ func file_packets_proto_init() {
 		(*Packet_FinishedBrowsingHiscores)(nil),
 		(*Packet_SearchHiscore)(nil),
 		(*Packet_Disconnect)(nil),
+		(*Packet_TimeSyncRequest)(nil),
+		(*Packet_TimeSyncResponse)(nil),
+		(*Packet_PresenceSubscribe)(nil),
+		(*Packet_PresenceUnsubscribe)(nil),
+		(*Packet_Presence)(nil),
+		(*Packet_ResourceNode)(nil),
+		(*Packet_ResourceNodesBatch)(nil),
+		(*Packet_GatherStart)(nil),
+		(*Packet_GatherFinish)(nil),
+		(*Packet_Inventory)(nil),
+		(*Packet_CraftRequest)(nil),
+		(*Packet_BlockPlayer)(nil),
+		(*Packet_UnblockPlayer)(nil),
+		(*Packet_BlockList)(nil),
+		(*Packet_MinimapRequest)(nil),
+		(*Packet_Minimap)(nil),
+		(*Packet_OnboardingStep)(nil),
+		(*Packet_CompleteOnboardingStep)(nil),
+		(*Packet_Emotes)(nil),
+		(*Packet_EmoteUnlocked)(nil),
+		(*Packet_Custom)(nil),
+		(*Packet_AuctionListRequest)(nil),
+		(*Packet_AuctionSearchRequest)(nil),
+		(*Packet_AuctionSearchResults)(nil),
+		(*Packet_AuctionBuyout)(nil),
+		(*Packet_MailboxRequest)(nil),
+		(*Packet_Mailbox)(nil),
+		(*Packet_ClaimMail)(nil),
+		(*Packet_ImpersonationRequest)(nil),
+		(*Packet_ImpersonationConsent)(nil),
+		(*Packet_ImpersonationStatus)(nil),
+		(*Packet_FeatureFlagsRequest)(nil),
+		(*Packet_FeatureFlags)(nil),
+		(*Packet_ChannelListRequest)(nil),
+		(*Packet_ChannelList)(nil),
+		(*Packet_ChannelSwitch)(nil),
+		(*Packet_RollCommit)(nil),
+		(*Packet_RollReveal)(nil),
+		(*Packet_VoiceSignal)(nil),
+		(*Packet_VoiceParty)(nil),
+		(*Packet_VoiceStatus)(nil),
+		(*Packet_DataExportRequest)(nil),
+		(*Packet_AccountDeletionRequest)(nil),
+		(*Packet_AccountDeletionCancel)(nil),
+		(*Packet_AccountDataStatusRequest)(nil),
+		(*Packet_AccountDataStatus)(nil),
+		(*Packet_FileOffer)(nil),
+		(*Packet_FileChunk)(nil),
+		(*Packet_FileReceived)(nil),
+		(*Packet_StoreRequest)(nil),
+		(*Packet_Store)(nil),
+		(*Packet_StorePurchase)(nil),
+		(*Packet_StoreReceipt)(nil),
+		(*Packet_StoreReceiptStatus)(nil),
+		(*Packet_DataPack)(nil),
+		(*Packet_Interact)(nil),
+		(*Packet_InteractionResult)(nil),
+		(*Packet_Prompt)(nil),
+		(*Packet_PromptResponse)(nil),
+		(*Packet_PromptClosed)(nil),
+		(*Packet_MoveTo)(nil),
+		(*Packet_EntitlementListRequest)(nil),
+		(*Packet_EntitlementList)(nil),
+		(*Packet_EnvironmentCue)(nil),
+		(*Packet_ClientUpdate)(nil),
+		(*Packet_SimulatorLoginRequest)(nil),
+		(*Packet_ActorSpawnRequest)(nil),
+		(*Packet_ActorSpawnResponse)(nil),
+		(*Packet_ActorUpdates)(nil),
+		(*Packet_ActorDespawn)(nil),
+		(*Packet_Dialog)(nil),
+		(*Packet_DialogChoose)(nil),
+		(*Packet_DialogClosed)(nil),
+		(*Packet_QuestStage)(nil),
+		(*Packet_Subscribe)(nil),
+		(*Packet_Unsubscribe)(nil),
+		(*Packet_Subscription)(nil),
+		(*Packet_AuctionListing)(nil),
+		(*Packet_AuctionListingClosed)(nil),
+		(*Packet_AnnouncementHistoryRequest)(nil),
+		(*Packet_AnnouncementHistory)(nil),
+		(*Packet_ChatSent)(nil),
+		(*Packet_ChatReact)(nil),
+		(*Packet_ChatReaction)(nil),
+		(*Packet_ReportPlayer)(nil),
+		(*Packet_DebugOverlayRequest)(nil),
+		(*Packet_DebugOverlay)(nil),
+		(*Packet_BossLockouts)(nil),
+		(*Packet_EmitterSpawn)(nil),
+		(*Packet_EmitterDespawn)(nil),
+		(*Packet_Kick)(nil),
+		(*Packet_CaptchaChallenge)(nil),
+		(*Packet_CaptchaResponse)(nil),
+		(*Packet_ActorDamage)(nil),
+		(*Packet_ActorKilled)(nil),
+		(*Packet_KillCredit)(nil),
+		(*Packet_ListSessions)(nil),
+		(*Packet_Sessions)(nil),
+		(*Packet_LogoutSession)(nil),
+		(*Packet_NewLogin)(nil),
+		(*Packet_SandboxCommand)(nil),
+		(*Packet_SandboxStatus)(nil),
+		(*Packet_IceServersRequest)(nil),
+		(*Packet_IceServers)(nil),
+		(*Packet_Interest)(nil),
+		(*Packet_LoginToken)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_packets_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   19,
+			NumEnums:      1,
+			NumMessages:   145,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_packets_proto_goTypes,
 		DependencyIndexes: file_packets_proto_depIdxs,
+		EnumInfos:         file_packets_proto_enumTypes,
 		MessageInfos:      file_packets_proto_msgTypes,
 	}.Build()
 	File_packets_proto = out.File
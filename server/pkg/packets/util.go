@@ -1,6 +1,9 @@
 package packets
 
-import "server/internal/server/objects"
+import (
+	"server/internal/server/objects"
+	"time"
+)
 
 type Msg = isPacket_Msg
 
@@ -34,6 +37,65 @@ func NewDenyResponse(reason string) Msg {
 	}
 }
 
+// Refuse a name, suggesting others that could be had instead
+func NewNameDenied(reason string, suggestions []string) Msg {
+	return &Packet_DenyResponse{
+		DenyResponse: &DenyResponseMessage{
+			Reason:          reason,
+			NameSuggestions: suggestions,
+		},
+	}
+}
+
+func NewClientUpdate(required bool, minBuild uint32, deadline int64, downloadUrl string) Msg {
+	return &Packet_ClientUpdate{
+		ClientUpdate: &ClientUpdateMessage{
+			Required:    required,
+			MinBuild:    minBuild,
+			Deadline:    deadline,
+			DownloadUrl: downloadUrl,
+		},
+	}
+}
+
+func NewLoginRequest(username string, password string, capabilities ...string) Msg {
+	return &Packet_LoginRequest{
+		LoginRequest: &LoginRequestMessage{
+			Username:     username,
+			Password:     password,
+			Capabilities: capabilities,
+		},
+	}
+}
+
+func NewLoginToken(token string, expiresAt int64) Msg {
+	return &Packet_LoginToken{
+		LoginToken: &LoginTokenMessage{
+			Token:     token,
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+func NewSimulatorLoginRequest(secret string, name string) Msg {
+	return &Packet_SimulatorLoginRequest{
+		SimulatorLoginRequest: &SimulatorLoginRequestMessage{
+			Secret: secret,
+			Name:   name,
+		},
+	}
+}
+
+func NewRegisterRequest(username string, password string, color int32) Msg {
+	return &Packet_RegisterRequest{
+		RegisterRequest: &RegisterRequestMessage{
+			Username: username,
+			Password: password,
+			Color:    color,
+		},
+	}
+}
+
 func NewPlayer(id uint64, player *objects.Player) Msg {
 	return &Packet_Player{
 		Player: &PlayerMessage{
@@ -92,3 +154,597 @@ func NewDisconnect(reason string) Msg {
 		},
 	}
 }
+
+func NewKick(kick *KickMessage) Msg {
+	return &Packet_Kick{
+		Kick: kick,
+	}
+}
+
+func NewCaptchaChallenge(provider string, siteKey string) Msg {
+	return &Packet_CaptchaChallenge{
+		CaptchaChallenge: &CaptchaChallengeMessage{
+			Provider: provider,
+			SiteKey:  siteKey,
+		},
+	}
+}
+
+func NewCaptchaResponse(token string) Msg {
+	return &Packet_CaptchaResponse{
+		CaptchaResponse: &CaptchaResponseMessage{
+			Token: token,
+		},
+	}
+}
+
+func NewTimeSyncResponse(seq uint64, clientTime int64, serverTime time.Time) Msg {
+	return &Packet_TimeSyncResponse{
+		TimeSyncResponse: &TimeSyncResponseMessage{
+			Seq:        seq,
+			ClientTime: clientTime,
+			ServerTime: serverTime.UnixMilli(),
+		},
+	}
+}
+
+func NewPresence(name string, online bool) Msg {
+	return &Packet_Presence{
+		Presence: &PresenceMessage{
+			Name:   name,
+			Online: online,
+		},
+	}
+}
+
+func newResourceNodeMessage(id uint64, node *objects.ResourceNode) *ResourceNodeMessage {
+	return &ResourceNodeMessage{
+		Id:        id,
+		Kind:      node.Kind,
+		X:         node.X,
+		Y:         node.Y,
+		Radius:    node.Radius,
+		Available: node.Available(),
+	}
+}
+
+func NewResourceNode(id uint64, node *objects.ResourceNode) Msg {
+	return &Packet_ResourceNode{
+		ResourceNode: newResourceNodeMessage(id, node),
+	}
+}
+
+func NewResourceNodesBatch(nodes map[uint64]*objects.ResourceNode) Msg {
+	nodeMessages := make([]*ResourceNodeMessage, 0, len(nodes))
+	for id, node := range nodes {
+		nodeMessages = append(nodeMessages, newResourceNodeMessage(id, node))
+	}
+
+	return &Packet_ResourceNodesBatch{
+		ResourceNodesBatch: &ResourceNodesBatchMessage{
+			ResourceNodes: nodeMessages,
+		},
+	}
+}
+
+func NewInventory(items map[string]int64) Msg {
+	itemMessages := make([]*ItemStackMessage, 0, len(items))
+	for itemId, quantity := range items {
+		itemMessages = append(itemMessages, &ItemStackMessage{
+			ItemId:   itemId,
+			Quantity: quantity,
+		})
+	}
+
+	return &Packet_Inventory{
+		Inventory: &InventoryMessage{
+			Items: itemMessages,
+		},
+	}
+}
+
+func NewBossLockouts(lockouts []*BossLockoutMessage) Msg {
+	return &Packet_BossLockouts{
+		BossLockouts: &BossLockoutsMessage{
+			Lockouts: lockouts,
+		},
+	}
+}
+
+func NewEmitterSpawn(emitters []*EmitterMessage) Msg {
+	return &Packet_EmitterSpawn{
+		EmitterSpawn: &EmitterSpawnMessage{
+			Emitters: emitters,
+		},
+	}
+}
+
+func NewEmitterDespawn(ids []uint64) Msg {
+	return &Packet_EmitterDespawn{
+		EmitterDespawn: &EmitterDespawnMessage{
+			Ids: ids,
+		},
+	}
+}
+
+func NewKillCredit(actorId uint64, share float64, mass float64, items map[string]int64, questStages map[string]int64) Msg {
+	itemMessages := make([]*ItemStackMessage, 0, len(items))
+	for itemId, quantity := range items {
+		itemMessages = append(itemMessages, &ItemStackMessage{ItemId: itemId, Quantity: quantity})
+	}
+	stageMessages := make([]*QuestStageMessage, 0, len(questStages))
+	for questId, stage := range questStages {
+		stageMessages = append(stageMessages, &QuestStageMessage{QuestId: questId, Stage: stage})
+	}
+
+	return &Packet_KillCredit{
+		KillCredit: &KillCreditMessage{
+			ActorId:     actorId,
+			Share:       share,
+			Mass:        mass,
+			Items:       itemMessages,
+			QuestStages: stageMessages,
+		},
+	}
+}
+
+func NewSessions(sessions []*SessionMessage) Msg {
+	return &Packet_Sessions{
+		Sessions: &SessionsMessage{
+			Sessions: sessions,
+		},
+	}
+}
+
+func NewNewLogin(session *SessionMessage, newIp bool, newDevice bool) Msg {
+	return &Packet_NewLogin{
+		NewLogin: &NewLoginMessage{
+			Session:   session,
+			NewIp:     newIp,
+			NewDevice: newDevice,
+		},
+	}
+}
+
+func NewSandboxStatus(inside bool, zoneId string, actors int, scenarios []string, message string) Msg {
+	return &Packet_SandboxStatus{
+		SandboxStatus: &SandboxStatusMessage{
+			Inside:    inside,
+			ZoneId:    zoneId,
+			Actors:    uint32(actors),
+			Scenarios: scenarios,
+			Message:   message,
+		},
+	}
+}
+
+func NewIceServers(servers []*IceServerMessage, expiresAt int64) Msg {
+	return &Packet_IceServers{
+		IceServers: &IceServersMessage{
+			Servers:   servers,
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+func NewInterest(entered []uint64, left []uint64) Msg {
+	return &Packet_Interest{
+		Interest: &InterestMessage{
+			Entered: entered,
+			Left:    left,
+		},
+	}
+}
+
+func NewBlockList(names []string) Msg {
+	return &Packet_BlockList{
+		BlockList: &BlockListMessage{
+			Names: names,
+		},
+	}
+}
+
+func NewMinimap(minimap *MinimapMessage) Msg {
+	return &Packet_Minimap{
+		Minimap: minimap,
+	}
+}
+
+func NewOnboardingStep(stepId string, prompt string, step int, steps int) Msg {
+	return &Packet_OnboardingStep{
+		OnboardingStep: &OnboardingStepMessage{
+			StepId: stepId,
+			Prompt: prompt,
+			Step:   uint32(step),
+			Steps:  uint32(steps),
+		},
+	}
+}
+
+func NewEmotes(emotes []*EmoteMessage) Msg {
+	return &Packet_Emotes{
+		Emotes: &EmotesMessage{
+			Emotes: emotes,
+		},
+	}
+}
+
+func NewEmoteUnlocked(emoteId string) Msg {
+	return &Packet_EmoteUnlocked{
+		EmoteUnlocked: &EmoteUnlockedMessage{
+			EmoteId: emoteId,
+		},
+	}
+}
+
+func NewCustom(customType string, payload []byte) Msg {
+	return &Packet_Custom{
+		Custom: &CustomMessage{
+			Type:    customType,
+			Payload: payload,
+		},
+	}
+}
+
+func NewAuctionSearchResults(listings []*AuctionListingMessage, page int, pages int) Msg {
+	return &Packet_AuctionSearchResults{
+		AuctionSearchResults: &AuctionSearchResultsMessage{
+			Listings: listings,
+			Page:     uint32(page),
+			Pages:    uint32(pages),
+		},
+	}
+}
+
+func NewMailboxRequest() Msg {
+	return &Packet_MailboxRequest{
+		MailboxRequest: &MailboxRequestMessage{},
+	}
+}
+
+func NewMailbox(mail []*MailMessage) Msg {
+	return &Packet_Mailbox{
+		Mailbox: &MailboxMessage{
+			Mail: mail,
+		},
+	}
+}
+
+func NewImpersonationRequest(requestId string, admin string, reason string, control bool) Msg {
+	return &Packet_ImpersonationRequest{
+		ImpersonationRequest: &ImpersonationRequestMessage{
+			RequestId: requestId,
+			Admin:     admin,
+			Reason:    reason,
+			Control:   control,
+		},
+	}
+}
+
+func NewImpersonationStatus(requestId string, admin string, active bool, control bool) Msg {
+	return &Packet_ImpersonationStatus{
+		ImpersonationStatus: &ImpersonationStatusMessage{
+			RequestId: requestId,
+			Admin:     admin,
+			Active:    active,
+			Control:   control,
+		},
+	}
+}
+
+func NewFeatureFlagsRequest() Msg {
+	return &Packet_FeatureFlagsRequest{
+		FeatureFlagsRequest: &FeatureFlagsRequestMessage{},
+	}
+}
+
+// Only the flags that are on are sent, so any flag the client doesn't hear about is off
+func NewFeatureFlags(enabled []string) Msg {
+	return &Packet_FeatureFlags{
+		FeatureFlags: &FeatureFlagsMessage{
+			Enabled: enabled,
+		},
+	}
+}
+
+func NewEntitlementListRequest() Msg {
+	return &Packet_EntitlementListRequest{
+		EntitlementListRequest: &EntitlementListRequestMessage{},
+	}
+}
+
+func NewEntitlementList(entitlements []string, characterSlots int) Msg {
+	return &Packet_EntitlementList{
+		EntitlementList: &EntitlementListMessage{
+			Entitlements:   entitlements,
+			CharacterSlots: uint32(characterSlots),
+		},
+	}
+}
+
+func NewChannelListRequest() Msg {
+	return &Packet_ChannelListRequest{
+		ChannelListRequest: &ChannelListRequestMessage{},
+	}
+}
+
+func NewChannelList(zoneId string, current int, channels []*ChannelInfoMessage) Msg {
+	return &Packet_ChannelList{
+		ChannelList: &ChannelListMessage{
+			ZoneId:   zoneId,
+			Current:  uint32(current),
+			Channels: channels,
+		},
+	}
+}
+
+func NewRollCommit(encounterId uint64, commitment []byte) Msg {
+	return &Packet_RollCommit{
+		RollCommit: &RollCommitMessage{
+			EncounterId: encounterId,
+			Commitment:  commitment,
+		},
+	}
+}
+
+func NewRollReveal(encounterId uint64, seed []byte, clientSeed string, rolls []float64) Msg {
+	return &Packet_RollReveal{
+		RollReveal: &RollRevealMessage{
+			EncounterId: encounterId,
+			Seed:        seed,
+			ClientSeed:  clientSeed,
+			Rolls:       rolls,
+		},
+	}
+}
+
+func NewVoiceSignal(peerId uint64, kind string, payload string) Msg {
+	return &Packet_VoiceSignal{
+		VoiceSignal: &VoiceSignalMessage{
+			PeerId:  peerId,
+			Kind:    kind,
+			Payload: payload,
+		},
+	}
+}
+
+func NewVoiceStatus(muted bool, mutedUntil int64, reason string, party string) Msg {
+	return &Packet_VoiceStatus{
+		VoiceStatus: &VoiceStatusMessage{
+			Muted:      muted,
+			MutedUntil: mutedUntil,
+			Reason:     reason,
+			Party:      party,
+		},
+	}
+}
+
+func NewAccountDataStatusRequest() Msg {
+	return &Packet_AccountDataStatusRequest{
+		AccountDataStatusRequest: &AccountDataStatusRequestMessage{},
+	}
+}
+
+func NewAccountDataStatus(exportStatus string, exportUrl string, exportExpiresAt int64, deletionAt int64) Msg {
+	return &Packet_AccountDataStatus{
+		AccountDataStatus: &AccountDataStatusMessage{
+			ExportStatus:    exportStatus,
+			ExportUrl:       exportUrl,
+			ExportExpiresAt: exportExpiresAt,
+			DeletionAt:      deletionAt,
+		},
+	}
+}
+
+func NewFileOffer(transferId uint64, name string, contentType string, size uint64, chunks uint32, sha256 []byte) Msg {
+	return &Packet_FileOffer{
+		FileOffer: &FileOfferMessage{
+			TransferId:  transferId,
+			Name:        name,
+			ContentType: contentType,
+			Size:        size,
+			Chunks:      chunks,
+			Sha256:      sha256,
+		},
+	}
+}
+
+func NewFileChunk(transferId uint64, index uint32, data []byte) Msg {
+	return &Packet_FileChunk{
+		FileChunk: &FileChunkMessage{
+			TransferId: transferId,
+			Index:      index,
+			Data:       data,
+		},
+	}
+}
+
+func NewStore(entries []*StoreEntryMessage) Msg {
+	return &Packet_Store{
+		Store: &StoreMessage{
+			Entries: entries,
+		},
+	}
+}
+
+func NewStoreReceiptStatus(receiptId uint64, entryId string, status string, reason string) Msg {
+	return &Packet_StoreReceiptStatus{
+		StoreReceiptStatus: &StoreReceiptStatusMessage{
+			ReceiptId: receiptId,
+			EntryId:   entryId,
+			Status:    status,
+			Reason:    reason,
+		},
+	}
+}
+
+func NewDataPack(version int64, checksum []byte, files []*DataFileMessage, url string) Msg {
+	return &Packet_DataPack{
+		DataPack: &DataPackMessage{
+			Version:  version,
+			Checksum: checksum,
+			Files:    files,
+			Url:      url,
+		},
+	}
+}
+
+func NewInteractionResult(targetId uint64, verb string, kind string, text string, fields []*InteractionFieldMessage) Msg {
+	return &Packet_InteractionResult{
+		InteractionResult: &InteractionResultMessage{
+			TargetId: targetId,
+			Verb:     verb,
+			Ok:       true,
+			Kind:     kind,
+			Text:     text,
+			Fields:   fields,
+		},
+	}
+}
+
+func NewInteractionDenied(targetId uint64, verb string, reason string) Msg {
+	return &Packet_InteractionResult{
+		InteractionResult: &InteractionResultMessage{
+			TargetId: targetId,
+			Verb:     verb,
+			Reason:   reason,
+		},
+	}
+}
+
+func NewPrompt(promptId uint64, title string, text string, options []*PromptOptionMessage, timeout time.Duration) Msg {
+	return &Packet_Prompt{
+		Prompt: &PromptMessage{
+			PromptId:  promptId,
+			Title:     title,
+			Text:      text,
+			Options:   options,
+			TimeoutMs: uint32(timeout.Milliseconds()),
+		},
+	}
+}
+
+func NewPromptClosed(promptId uint64, reason string) Msg {
+	return &Packet_PromptClosed{
+		PromptClosed: &PromptClosedMessage{
+			PromptId: promptId,
+			Reason:   reason,
+		},
+	}
+}
+
+func NewEnvironmentCue(ambientTrack string, particleEvent string, x float64, y float64, shakeIntensity float64, shakeDuration time.Duration) Msg {
+	return &Packet_EnvironmentCue{
+		EnvironmentCue: &EnvironmentCueMessage{
+			AmbientTrack:   ambientTrack,
+			ParticleEvent:  particleEvent,
+			X:              x,
+			Y:              y,
+			ShakeIntensity: shakeIntensity,
+			ShakeMs:        uint32(shakeDuration.Milliseconds()),
+		},
+	}
+}
+
+func NewActorSpawnResponse(ids []uint64) Msg {
+	return &Packet_ActorSpawnResponse{
+		ActorSpawnResponse: &ActorSpawnResponseMessage{
+			Ids: ids,
+		},
+	}
+}
+
+func NewDialog(targetId uint64, dialogId string, nodeId string, speaker string, text string, choices []*DialogChoiceMessage) Msg {
+	return &Packet_Dialog{
+		Dialog: &DialogMessage{
+			TargetId: targetId,
+			DialogId: dialogId,
+			NodeId:   nodeId,
+			Speaker:  speaker,
+			Text:     text,
+			Choices:  choices,
+		},
+	}
+}
+
+func NewDialogClosed(dialogId string, reason string) Msg {
+	return &Packet_DialogClosed{
+		DialogClosed: &DialogClosedMessage{
+			DialogId: dialogId,
+			Reason:   reason,
+		},
+	}
+}
+
+func NewQuestStage(questId string, stage int64) Msg {
+	return &Packet_QuestStage{
+		QuestStage: &QuestStageMessage{
+			QuestId: questId,
+			Stage:   stage,
+		},
+	}
+}
+
+func NewSubscription(feed string, subscribed bool, reason string) Msg {
+	return &Packet_Subscription{
+		Subscription: &SubscriptionMessage{
+			Feed:       feed,
+			Subscribed: subscribed,
+			Reason:     reason,
+		},
+	}
+}
+
+func NewHiscore(rank uint64, name string, score uint64) Msg {
+	return &Packet_Hiscore{
+		Hiscore: &HiscoreMessage{
+			Rank:  rank,
+			Name:  name,
+			Score: score,
+		},
+	}
+}
+
+func NewAuctionListing(listing *AuctionListingMessage) Msg {
+	return &Packet_AuctionListing{
+		AuctionListing: listing,
+	}
+}
+
+func NewAuctionListingClosed(id uint64, reason string) Msg {
+	return &Packet_AuctionListingClosed{
+		AuctionListingClosed: &AuctionListingClosedMessage{
+			Id:     id,
+			Reason: reason,
+		},
+	}
+}
+
+func NewAnnouncementHistory(announcements []*AnnouncementMessage, page int, pages int) Msg {
+	return &Packet_AnnouncementHistory{
+		AnnouncementHistory: &AnnouncementHistoryMessage{
+			Announcements: announcements,
+			Page:          uint32(page),
+			Pages:         uint32(pages),
+		},
+	}
+}
+
+func NewChatSent(id uint64) Msg {
+	return &Packet_ChatSent{
+		ChatSent: &ChatSentMessage{
+			Id: id,
+		},
+	}
+}
+
+func NewChatReaction(messageId uint64, emoteId string, delta int32, count int) Msg {
+	return &Packet_ChatReaction{
+		ChatReaction: &ChatReactionMessage{
+			MessageId: messageId,
+			EmoteId:   emoteId,
+			Delta:     delta,
+			Count:     uint32(count),
+		},
+	}
+}